@@ -13,11 +13,12 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/five82/spindle/internal/daemonctl"
+	"github.com/five82/spindle/internal/daemonrun"
 	"github.com/five82/spindle/internal/discidcache"
 	"github.com/five82/spindle/internal/discmonitor"
+	"github.com/five82/spindle/internal/fileutil"
 	"github.com/five82/spindle/internal/fingerprint"
 	"github.com/five82/spindle/internal/identify"
-	"github.com/five82/spindle/internal/keydb"
 	"github.com/five82/spindle/internal/logs"
 	"github.com/five82/spindle/internal/notify"
 	"github.com/five82/spindle/internal/queue"
@@ -26,7 +27,6 @@ import (
 	"github.com/five82/spindle/internal/ripper"
 	"github.com/five82/spindle/internal/ripspec"
 	"github.com/five82/spindle/internal/stage"
-	"github.com/five82/spindle/internal/tmdb"
 )
 
 func newCacheCmd() *cobra.Command {
@@ -93,6 +93,8 @@ func newCacheRipCmd() *cobra.Command {
 
 			// Check if already cached.
 			ripCacheStore := ripcache.New(cfg.RipCacheDir(), cfg.RipCache.MaxGiB)
+			ripCacheStore.SetTransfer(fileutil.TransferMode(cfg.RipCache.StagingTransfer))
+			ripCacheStore.SetMinFree(int64(cfg.FreeSpace.RipCacheGiB) << 30)
 			if ripCacheStore.HasCache(fp) {
 				fmt.Printf("Disc already cached (fingerprint: %s)\n", truncate(fp, 12))
 				return nil
@@ -159,22 +161,22 @@ func newCacheRipCmd() *cobra.Command {
 			}
 
 			// Set up dependencies for identification.
-			tmdbClient := tmdb.New(cfg.TMDB.APIKey, cfg.TMDB.BaseURL, cfg.TMDB.Language, nil)
+			clients, err := daemonrun.NewClients(cfg, logger)
+			if err != nil {
+				return err
+			}
 
 			discIDStore, cacheErr := discidcache.Open(cfg.DiscIDCachePath(), nil)
 			if cacheErr != nil {
 				logger.Debug("disc ID cache unavailable", "error", cacheErr)
 			}
 
-			var keydbCat *keydb.Catalog
-			if cat, _, loadErr := keydb.LoadOrDownload(ctx, cfg.MakeMKV.KeyDBPath, cfg.MakeMKV.KeyDBDownloadURL,
-				cfg.MakeMKV.KeyDBTimeout(), logger); loadErr == nil {
-				keydbCat = cat
-			}
+			keydbCat := daemonrun.LoadKeyDB(ctx, cfg, logger)
 
 			// Run identification stage.
 			fmt.Printf("Identifying disc on %s...\n", device)
-			identifyHandler := identify.New(cfg, tmdbClient, nil, discIDStore, keydbCat)
+			identifyHandler := identify.New(cfg, clients.TMDB, nil, discIDStore, keydbCat)
+			attachScanCache(identifyHandler, logger)
 			if err := executeOneShotStage(identifyHandler); err != nil {
 				return fmt.Errorf("identification: %w", err)
 			}
@@ -276,8 +278,7 @@ func newCacheRipCmd() *cobra.Command {
 }
 
 func newCacheListCmd() *cobra.Command {
-	var asJSON bool
-	cmd := &cobra.Command{
+	return &cobra.Command{
 		Use:   "list",
 		Short: "List cached rips",
 		RunE: func(_ *cobra.Command, _ []string) error {
@@ -287,8 +288,8 @@ func newCacheListCmd() *cobra.Command {
 				return err
 			}
 
-			if asJSON {
-				return printJSON(entries)
+			if flagJSON {
+				return printJSONOutput(jsonKindCacheEntries, entries)
 			}
 
 			if flagVerbose {
@@ -324,8 +325,6 @@ func newCacheListCmd() *cobra.Command {
 			return nil
 		},
 	}
-	cmd.Flags().BoolVar(&asJSON, "json", false, "Output entries as JSON")
-	return cmd
 }
 
 func cacheEntryByNumber(num int) (ripcache.EntryMetadata, error) {
@@ -349,7 +348,8 @@ func parseCacheEntryNumber(arg string) (int, error) {
 }
 
 func newCacheProcessCmd() *cobra.Command {
-	var allowDuplicate bool
+	var allowDuplicate, skipSubtitles, skipCommentary bool
+	var crf float64
 	cmd := &cobra.Command{
 		Use:   "process <number>",
 		Short: "Queue a cached rip for processing",
@@ -371,6 +371,21 @@ func newCacheProcessCmd() *cobra.Command {
 			if entry.RipSpecData == "" {
 				return fmt.Errorf("cache entry missing identification data; re-cache with 'spindle cache rip'")
 			}
+			ripSpecData := entry.RipSpecData
+			opts := ripspec.ItemOptions{SkipSubtitles: skipSubtitles, SkipCommentary: skipCommentary, EncodeCRF: crf}
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+			if opts != (ripspec.ItemOptions{}) {
+				env, err := ripspec.Parse(ripSpecData)
+				if err != nil {
+					return fmt.Errorf("parse cached rip spec: %w", err)
+				}
+				env.Options = opts
+				if ripSpecData, err = env.Encode(); err != nil {
+					return err
+				}
+			}
 
 			acc, err := openQueueAccess()
 			if err != nil {
@@ -379,7 +394,7 @@ func newCacheProcessCmd() *cobra.Command {
 			item, err := acc.EnqueueCached(queueaccess.EnqueueCachedRequest{
 				DiscTitle:      entry.DiscTitle,
 				Fingerprint:    entry.Fingerprint,
-				RipSpecData:    entry.RipSpecData,
+				RipSpecData:    ripSpecData,
 				MetadataJSON:   entry.MetadataJSON,
 				AllowDuplicate: allowDuplicate,
 			})
@@ -388,8 +403,12 @@ func newCacheProcessCmd() *cobra.Command {
 			}
 
 			displayItem := queue.Item{ID: item.ID, DiscTitle: item.DiscTitle, MetadataJSON: string(item.Metadata)}
-			notifier := notify.New(cfg.Notifications.NtfyTopic, cfg.Notifications.RequestTimeout, logger)
-			_ = notify.SendLogged(context.Background(), notifier, logger, notify.EventItemQueued,
+			clients, err := daemonrun.NewClients(cfg, logger)
+			if err != nil {
+				return err
+			}
+			_ = notify.SendItemLogged(context.Background(), clients.Notifier, logger, notify.EventItemQueued,
+				notify.Fields{Item: item.ID},
 				"Queued: "+displayItem.DisplayTitle(),
 				"Accepted for processing from rip cache.",
 				"item_id", item.ID,
@@ -401,6 +420,9 @@ func newCacheProcessCmd() *cobra.Command {
 		},
 	}
 	cmd.Flags().BoolVar(&allowDuplicate, "allow-duplicate", false, "Allow multiple queue items with same fingerprint")
+	cmd.Flags().BoolVar(&skipSubtitles, "skip-subtitles", false, "Skip subtitle generation for this item")
+	cmd.Flags().BoolVar(&skipCommentary, "skip-commentary", false, "Skip commentary detection for this item")
+	cmd.Flags().Float64Var(&crf, "crf", 0, "Encode at this fixed CRF, 1-70, lower is higher quality (default: target quality)")
 	return cmd
 }
 
@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/five82/spindle/internal/config"
+	"github.com/five82/spindle/internal/notify"
 )
 
 func newConfigCmd() *cobra.Command {
@@ -71,6 +72,9 @@ func newConfigValidateCmd() *cobra.Command {
 			if err := cfg.Validate(); err != nil {
 				return fmt.Errorf("config invalid: %w", err)
 			}
+			if _, err := notify.ParseTemplates(cfg.Notifications.Templates); err != nil {
+				return fmt.Errorf("config invalid: notifications.templates: %w", err)
+			}
 			if err := cfg.EnsureDirectories(); err != nil {
 				return fmt.Errorf("ensure directories: %w", err)
 			}
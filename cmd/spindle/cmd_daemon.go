@@ -8,12 +8,14 @@ import (
 	"os/signal"
 	"path/filepath"
 	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/five82/spindle/internal/config"
 	"github.com/five82/spindle/internal/daemonctl"
 	"github.com/five82/spindle/internal/daemonrun"
+	"github.com/five82/spindle/internal/httpapi"
 	"github.com/five82/spindle/internal/queue"
 )
 
@@ -96,18 +98,49 @@ func newRestartCmd() *cobra.Command {
 	}
 }
 
+// statusStages is the order queue stages are listed in by status output.
+var statusStages = []queue.Stage{
+	queue.StageIdentification, queue.StageRipping,
+	queue.StageEpisodeIdentification, queue.StageEncoding,
+	queue.StageAnalysis, queue.StageSubtitling, queue.StageApply,
+	queue.StageOrganizing, queue.StageCompleted, queue.StageFailed,
+}
+
 func newStatusCmd() *cobra.Command {
-	var asJSON bool
+	var (
+		watch    bool
+		interval time.Duration
+	)
 	cmd := &cobra.Command{
 		Use:     "status",
 		Short:   "Show system and queue status",
 		GroupID: groupDaemon,
+		Long: `Show system and queue status.
+
+With --watch, the status is re-fetched every --interval and redrawn as a
+compact dashboard of queue depth, active stages, and task progress until
+interrupted with Ctrl-C.`,
 		RunE: func(_ *cobra.Command, _ []string) error {
+			if watch {
+				if flagJSON {
+					return fmt.Errorf("--watch cannot be combined with --json")
+				}
+				if interval <= 0 {
+					return fmt.Errorf("--interval must be positive")
+				}
+				acc, err := openQueueAccess()
+				if err != nil {
+					return err
+				}
+				ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+				defer cancel()
+				return watchStatus(ctx, os.Stdout, interval, acc.Snapshot)
+			}
+
 			lp, sp := lockPath(), socketPath()
 			if !daemonctl.IsRunning(lp, sp) {
-				if asJSON {
-					fmt.Println(`{"running": false}`)
-					return nil
+				if flagJSON {
+					return printJSONOutput(jsonKindStatus, httpapi.StatusAPIResponse{})
 				}
 				fmt.Println("Daemon stopped")
 				return nil
@@ -117,13 +150,14 @@ func newStatusCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			status, err := acc.Status()
+			snap, err := acc.Snapshot()
 			if err != nil {
 				return err
 			}
+			status := snap.Status
 
-			if asJSON {
-				return printJSON(status)
+			if flagJSON {
+				return printJSONOutput(jsonKindStatus, status.Response)
 			}
 
 			fmt.Println()
@@ -171,12 +205,7 @@ func newStatusCmd() *cobra.Command {
 			fmt.Println()
 			stats := status.Workflow.QueueStats
 			hasItems := false
-			for _, stage := range []queue.Stage{
-				queue.StageIdentification, queue.StageRipping,
-				queue.StageEpisodeIdentification, queue.StageEncoding,
-				queue.StageAnalysis, queue.StageSubtitling, queue.StageApply,
-				queue.StageOrganizing, queue.StageCompleted, queue.StageFailed,
-			} {
+			for _, stage := range statusStages {
 				count := stats[stage]
 				if count > 0 || flagVerbose {
 					fmt.Printf("  %-24s %d\n", labelStyle(stage), count)
@@ -186,10 +215,30 @@ func newStatusCmd() *cobra.Command {
 			if !hasItems {
 				fmt.Printf("  %s\n", dimStyle("Empty"))
 			}
+			if tp := status.Workflow.Throughput; tp != nil && (tp.ItemsCompleted > 0 || flagVerbose) {
+				fmt.Printf("  %-24s %d (%.1f/hour over %.0fh)\n", labelStyle("Throughput"), tp.ItemsCompleted, tp.ItemsPerHour, tp.WindowHours)
+				if flagVerbose {
+					for _, st := range tp.Stages {
+						avg := time.Duration(st.AvgSeconds * float64(time.Second)).Truncate(time.Second)
+						fmt.Printf("    %-22s %d done, avg %s\n", st.Stage, st.Completed, avg)
+					}
+				}
+			}
+			if snap.ItemsErr != nil {
+				fmt.Printf("  %s %v\n", failStyle("Items unavailable:"), snap.ItemsErr)
+			}
+			for _, item := range snap.Items {
+				if !item.InProgress {
+					continue
+				}
+				fmt.Printf("  %s #%d %s %s\n", labelStyle("Active"), item.ID, truncate(item.DiscTitle, 40), dimStyle(item.Stage))
+				printTaskLines("    ", item.Tasks, flagVerbose)
+			}
 			return nil
 		},
 	}
-	cmd.Flags().BoolVar(&asJSON, "json", false, "Output status as JSON")
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "Refresh the status as a live dashboard until Ctrl-C")
+	cmd.Flags().DurationVar(&interval, "interval", 2*time.Second, "Refresh interval for --watch")
 	return cmd
 }
 
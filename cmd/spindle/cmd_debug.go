@@ -12,10 +12,10 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/five82/spindle/internal/audioanalysis"
+	"github.com/five82/spindle/internal/daemonrun"
 	"github.com/five82/spindle/internal/encodingstate"
-	"github.com/five82/spindle/internal/llm"
 	"github.com/five82/spindle/internal/media/ffprobe"
-	"github.com/five82/spindle/internal/textutil"
 	"github.com/five82/spindle/internal/transcription"
 )
 
@@ -29,7 +29,7 @@ func newDebugCmd() *cobra.Command {
 		newDebugCropCmd(),
 		newDebugCommentaryCmd(),
 		newGensubtitleCmd(),
-		newTestNotifyCmd(),
+		newNotifyTestCmd("notify"),
 	)
 	return cmd
 }
@@ -203,8 +203,12 @@ func debugStreamIsHDR(stream ffprobe.Stream) bool {
 func newDebugCommentaryCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "commentary <entry|path>",
-		Short: "Run commentary detection on a video file",
-		Args:  cobra.ExactArgs(1),
+		Short: "Preview commentary detection on a video file",
+		Long: `Run the analysis stage's commentary detection on a video file and report,
+per candidate audio track, whether it would be kept as commentary or dropped
+and why. Nothing is recorded on any queue item, so thresholds can be tuned
+and the preview re-run freely.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(_ *cobra.Command, args []string) error {
 			path, err := resolveTarget(args[0])
 			if err != nil {
@@ -213,52 +217,34 @@ func newDebugCommentaryCmd() *cobra.Command {
 			ctx := context.Background()
 			logger := buildLogger()
 
-			fmt.Printf("Probing %s...\n", filepath.Base(path))
-			probeResult, err := ffprobe.Inspect(ctx, "", path)
+			clients, err := daemonrun.NewClients(cfg, logger)
 			if err != nil {
-				return fmt.Errorf("ffprobe: %w", err)
-			}
-
-			// Collect audio streams.
-			audioStreams := probeResult.AudioStreams()
-
-			if len(audioStreams) == 0 {
-				fmt.Println("No audio streams found")
-				return nil
-			}
-
-			fmt.Printf("\n%s\n", headerStyle(fmt.Sprintf("=== Audio Streams (%d) ===", len(audioStreams))))
-			for _, s := range audioStreams {
-				title := s.Tags["title"]
-				lang := s.Tags["language"]
-				fmt.Printf("  Stream %d: %s, %d ch, %s", s.Index, s.CodecName, s.Channels, s.ChannelLayout)
-				if lang != "" {
-					fmt.Printf(", lang=%s", lang)
-				}
-				if title != "" {
-					fmt.Printf(", title=%q", title)
-				}
-				fmt.Println()
-			}
-
-			if len(audioStreams) <= 1 {
-				fmt.Println("\nOnly one audio stream; no commentary analysis needed")
-				return nil
+				return err
 			}
-
-			// Set up transcription and LLM for commentary detection.
-			llmClient := llm.New(cfg.LLM, nil)
+			llmClient := clients.LLM
 			if llmClient == nil {
-				fmt.Println("\nLLM not configured; commentary classification requires LLM")
-				return nil
+				if cfg.Offline {
+					return fmt.Errorf("commentary classification requires the LLM, which offline mode disables")
+				}
+				return fmt.Errorf("commentary classification requires a configured LLM")
 			}
-
 			transcriber := transcription.New(transcription.Params{
 				Model:       cfg.Subtitles.WhisperXModel,
 				CUDAEnabled: cfg.Subtitles.WhisperXCUDAEnabled,
+				ComputeType: cfg.Subtitles.WhisperXComputeType,
+				DeviceIndex: cfg.Subtitles.WhisperXDeviceIndex,
 				VADMethod:   cfg.Subtitles.WhisperXVADMethod,
 				HFToken:     cfg.Subtitles.WhisperXHFToken,
+				Workers:     cfg.Subtitles.WhisperXWorkers,
 			}, nil)
+			handler := audioanalysis.New(cfg, llmClient, transcriber)
+			if cfg.Commentary.StreamCache {
+				if streamCache, cacheErr := audioanalysis.OpenStreamCache(cfg.CommentaryCachePath()); cacheErr == nil {
+					handler.SetStreamCache(streamCache)
+				} else {
+					logger.Debug("commentary stream cache unavailable", "error", cacheErr)
+				}
+			}
 
 			workDir, err := os.MkdirTemp("", "spindle-debug-commentary-*")
 			if err != nil {
@@ -266,109 +252,66 @@ func newDebugCommentaryCmd() *cobra.Command {
 			}
 			defer func() { _ = os.RemoveAll(workDir) }()
 
-			fmt.Printf("\n%s\n", headerStyle("=== Commentary Analysis ==="))
-			fmt.Printf("%s %.3f\n", labelStyle("Similarity threshold:"), cfg.Commentary.SimilarityThreshold)
-			fmt.Printf("%s %.3f\n", labelStyle("Confidence threshold:"), cfg.Commentary.ConfidenceThreshold)
-
-			// Use audio-relative indices for ffmpeg -map 0:a:N.
-			for candidateAudioIdx, candidate := range audioStreams[1:] {
-				candidateAudioIdx++ // 0-based: primary=0, first candidate=1
-				fmt.Printf("\n%s\n", dimStyle(fmt.Sprintf("--- Stream %d ---", candidate.Index)))
-				title := candidate.Tags["title"]
-				if title != "" {
-					fmt.Printf("%s %s\n", labelStyle("Title:   "), title)
-				}
-				fmt.Printf("%s %d (%s)\n", labelStyle("Channels:"), candidate.Channels, candidate.ChannelLayout)
-
-				// Stereo similarity check.
-				primaryResult, pErr := transcriber.Transcribe(ctx, transcription.TranscribeRequest{
-					InputPath:  path,
-					AudioIndex: 0,
-					Language:   "en",
-					OutputDir:  filepath.Join(workDir, "audio-0"),
-				})
-				if pErr != nil {
-					logger.Warn("primary transcription failed", "error", pErr)
-					fmt.Printf("Similarity: error (primary transcription failed)\n")
-					continue
-				}
-
-				candidateResult, cErr := transcriber.Transcribe(ctx, transcription.TranscribeRequest{
-					InputPath:  path,
-					AudioIndex: candidateAudioIdx,
-					Language:   "en",
-					OutputDir:  filepath.Join(workDir, fmt.Sprintf("audio-%d", candidateAudioIdx)),
-				})
-				if cErr != nil {
-					logger.Warn("candidate transcription failed", "error", cErr)
-					fmt.Printf("Similarity: error (candidate transcription failed)\n")
-					continue
-				}
-
-				primaryText, _ := os.ReadFile(primaryResult.SRTPath)
-				candidateText, _ := os.ReadFile(candidateResult.SRTPath)
-
-				fpA := textutil.NewFingerprint(string(primaryText))
-				fpB := textutil.NewFingerprint(string(candidateText))
-				sim := textutil.CosineSimilarity(fpA, fpB)
-
-				fmt.Printf("Similarity: %.3f", sim)
-				if sim >= cfg.Commentary.SimilarityThreshold {
-					fmt.Printf(" (>= %.3f, likely stereo downmix)\n", cfg.Commentary.SimilarityThreshold)
-					continue
-				}
-				fmt.Println()
-
-				// LLM classification.
-				transcript := string(candidateText)
-				if len(transcript) > 4000 {
-					transcript = transcript[:4000] + "\n[truncated]"
-				}
-
-				var userPrompt strings.Builder
-				if title != "" {
-					fmt.Fprintf(&userPrompt, "Title: %s\n\n", title)
-				}
-				fmt.Fprintf(&userPrompt, "Transcript sample:\n%s", transcript)
-
-				var resp struct {
-					Decision   string  `json:"decision"`
-					Confidence float64 `json:"confidence"`
-					Reason     string  `json:"reason"`
-				}
-				if llmErr := llmClient.CompleteJSON(ctx, commentarySystemPrompt, userPrompt.String(), &resp); llmErr != nil {
-					fmt.Printf("LLM: error (%v)\n", llmErr)
-					continue
-				}
-
-				fmt.Printf("%s %s\n", labelStyle("LLM decision:  "), resp.Decision)
-				fmt.Printf("%s %.2f\n", labelStyle("LLM confidence:"), resp.Confidence)
-				fmt.Printf("%s %s\n", labelStyle("LLM reason:    "), resp.Reason)
+			if !flagJSON {
+				fmt.Printf("Analyzing %s...\n", filepath.Base(path))
 			}
-
+			report, err := handler.Preview(ctx, logger, path, "", workDir)
+			if err != nil {
+				return err
+			}
+			if flagJSON {
+				return printJSONOutput(jsonKindCommentaryReport, report)
+			}
+			printCommentaryReport(report)
 			return nil
 		},
 	}
 }
 
-// commentarySystemPrompt is the LLM system prompt for commentary classification.
-const commentarySystemPrompt = `You are an assistant that determines if an audio track is commentary or not.
-
-IMPORTANT: Commentary tracks come in two forms:
-1. Commentary-only: People talking about the film without movie audio
-2. Mixed commentary: Movie/TV dialogue plays while commentators talk over it
-
-Both forms are commentary. The presence of movie dialogue does NOT mean it's not commentary.
-
-Commentary tracks include:
-- Director/cast commentary over the film
-- Behind-the-scenes discussion mixed with film audio
-- Any track where people discuss or react to the film while it plays
-
-NOT commentary:
-- Alternate language dubs
-- Audio descriptions for visually impaired
-- Stereo downmix of main audio
-- Isolated music/effects tracks
-
-Respond ONLY with JSON: {"decision": "commentary" or "not_commentary", "confidence": 0.0-1.0, "reason": "brief explanation"}`
+func printCommentaryReport(r *audioanalysis.Report) {
+	fmt.Printf("\n%s\n", headerStyle("=== Commentary Preview ==="))
+	fmt.Printf("%s %.3f\n", labelStyle("Similarity threshold:     "), cfg.Commentary.SimilarityThreshold)
+	fmt.Printf("%s %.3f\n", labelStyle("Confidence threshold:     "), cfg.Commentary.ConfidenceThreshold)
+	fmt.Printf("%s %.3f\n", labelStyle("AD min gap speech ratio:  "), cfg.Commentary.AudioDescriptionMinGapRatio)
+	fmt.Printf("%s %.3f\n", labelStyle("AD max primary overlap:   "), cfg.Commentary.AudioDescriptionMaxOverlap)
+	if r.PrimaryIndex < 0 {
+		fmt.Println("\nNo primary audio selected; no commentary analysis performed")
+		return
+	}
+	fmt.Printf("%s a:%d\n", labelStyle("Primary audio:            "), r.PrimaryIndex)
+	if len(r.Candidates) == 0 {
+		fmt.Println("\nNo candidate audio tracks")
+		return
+	}
+	for _, c := range r.Candidates {
+		fmt.Printf("\n%s\n", dimStyle(fmt.Sprintf("--- Audio a:%d (stream %d) ---", c.AudioIndex, c.StreamIndex)))
+		if c.Title != "" {
+			fmt.Printf("%s %s\n", labelStyle("Title:     "), c.Title)
+		}
+		if c.Language != "" {
+			fmt.Printf("%s %s\n", labelStyle("Language:  "), c.Language)
+		}
+		decision := c.Decision
+		switch c.Decision {
+		case audioanalysis.DecisionCommentary:
+			decision = successStyle("kept as commentary")
+		case audioanalysis.DecisionNotCommentary:
+			decision = warnStyle("dropped (not commentary)")
+		case audioanalysis.DecisionExcluded:
+			decision = warnStyle("dropped (excluded)")
+		}
+		fmt.Printf("%s %s\n", labelStyle("Decision:  "), decision)
+		if c.Reason != "" {
+			fmt.Printf("%s %s\n", labelStyle("Reason:    "), c.Reason)
+		}
+		if c.Confidence > 0 {
+			fmt.Printf("%s %.2f\n", labelStyle("Confidence:"), c.Confidence)
+		}
+		if c.Similarity > 0 {
+			fmt.Printf("%s %.3f\n", labelStyle("Similarity:"), c.Similarity)
+		}
+		if c.GapRatio > 0 || c.Overlap > 0 {
+			fmt.Printf("%s gap speech %.3f, primary overlap %.3f\n", labelStyle("Speech:    "), c.GapRatio, c.Overlap)
+		}
+	}
+}
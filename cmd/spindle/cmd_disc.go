@@ -1,16 +1,19 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/five82/spindle/internal/daemonctl"
+	"github.com/five82/spindle/internal/makemkv"
 	"github.com/five82/spindle/internal/sockhttp"
 )
 
@@ -25,6 +28,7 @@ func newDiscCmd() *cobra.Command {
 		newDiscResumeCmd(),
 		newDiscDetectCmd(),
 		newIdentifyCmd(),
+		newDiscInfoCmd(),
 	)
 	return cmd
 }
@@ -105,6 +109,89 @@ safe to call from udev hooks on disc insertion.`,
 	}
 }
 
+func newDiscInfoCmd() *cobra.Command {
+	minLength := -1
+	cmd := &cobra.Command{
+		Use:   "info [device]",
+		Short: "Show the titles and streams on a disc without ripping",
+		Long: `Scan the disc with MakeMKV and list each title's duration, chapters,
+size, and video, audio, and subtitle streams, to help decide what to keep
+before ripping. Titles shorter than makemkv.min_title_length are left out
+unless --min-length is given.`,
+		Example: "  spindle disc info\n  spindle disc info /dev/sr1 --min-length 0 --json",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			device := cfg.MakeMKV.OpticalDrive
+			if len(args) > 0 {
+				device = args[0]
+			}
+			if device == "" {
+				return fmt.Errorf("no device specified and no optical drive configured")
+			}
+			if minLength < 0 {
+				minLength = cfg.MakeMKV.MinTitleLength
+			}
+			if !flagJSON {
+				fmt.Printf("Scanning disc on %s...\n", device)
+			}
+			info, err := makemkv.Scan(context.Background(), device,
+				time.Duration(cfg.MakeMKV.InfoTimeout)*time.Second, minLength, buildLogger())
+			if err != nil {
+				return err
+			}
+			report := makemkv.NewDiscReport(info)
+			if flagJSON {
+				return printJSONOutput(jsonKindDiscReport, report)
+			}
+			printDiscReport(os.Stdout, report)
+			return nil
+		},
+	}
+	cmd.Flags().IntVar(&minLength, "min-length", -1, "Minimum title length in seconds (default: makemkv.min_title_length)")
+	return cmd
+}
+
+// printDiscReport writes the human-readable disc contents report.
+func printDiscReport(w io.Writer, report makemkv.DiscReport) {
+	fmt.Fprintf(w, "\n%s %s\n", labelStyle("Label: "), report.Name)
+	fmt.Fprintf(w, "%s %d\n", labelStyle("Titles:"), len(report.Titles))
+	for _, t := range report.Titles {
+		fmt.Fprintf(w, "\n%s\n", headerStyle(fmt.Sprintf("=== Title %d: %s ===", t.ID, t.Name)))
+		fmt.Fprintf(w, "  %d:%02d:%02d, %d chapters, %s",
+			t.DurationSeconds/3600, (t.DurationSeconds%3600)/60, t.DurationSeconds%60, t.Chapters, formatBytes(t.SizeBytes))
+		if t.Playlist != "" {
+			fmt.Fprintf(w, ", playlist %s", t.Playlist)
+		}
+		fmt.Fprintln(w)
+		if t.Segments != "" {
+			fmt.Fprintf(w, "  %s\n", dimStyle("segments "+t.Segments))
+		}
+		printStreams(w, "Video", t.Video)
+		printStreams(w, "Audio", t.Audio)
+		printStreams(w, "Subtitles", t.Subtitles)
+	}
+}
+
+// printStreams lists one stream type of a title, one line per stream.
+func printStreams(w io.Writer, label string, streams []makemkv.StreamReport) {
+	if len(streams) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "  %s\n", labelStyle(label+":"))
+	for _, s := range streams {
+		parts := []string{s.Codec}
+		for _, v := range []string{s.Resolution, s.FrameRate, s.LanguageName, s.ChannelLayout, s.Name} {
+			if v != "" {
+				parts = append(parts, v)
+			}
+		}
+		if s.ChannelLayout == "" && s.Channels > 0 {
+			parts = append(parts, fmt.Sprintf("%dch", s.Channels))
+		}
+		fmt.Fprintf(w, "    - %s\n", strings.Join(parts, ", "))
+	}
+}
+
 // daemonDiscPost sends a POST to the daemon Unix socket and decodes the JSON
 // response into out (which may be nil to discard the body).
 func daemonDiscPost(path string, out any) error {
@@ -20,8 +20,7 @@ func newDiscIDCmd() *cobra.Command {
 }
 
 func newDiscIDListCmd() *cobra.Command {
-	var asJSON bool
-	cmd := &cobra.Command{
+	return &cobra.Command{
 		Use:   "list",
 		Short: "List cached disc ID mappings",
 		RunE: func(_ *cobra.Command, _ []string) error {
@@ -31,8 +30,8 @@ func newDiscIDListCmd() *cobra.Command {
 			}
 			entries := store.List()
 
-			if asJSON {
-				return printJSON(entries)
+			if flagJSON {
+				return printJSONOutput(jsonKindDiscIDEntries, entries)
 			}
 
 			if len(entries) == 0 {
@@ -53,8 +52,6 @@ func newDiscIDListCmd() *cobra.Command {
 			return nil
 		},
 	}
-	cmd.Flags().BoolVar(&asJSON, "json", false, "Output entries as JSON")
-	return cmd
 }
 
 func newDiscIDRemoveCmd() *cobra.Command {
@@ -0,0 +1,385 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sys/unix"
+
+	"github.com/five82/spindle/internal/config"
+	"github.com/five82/spindle/internal/daemonctl"
+	"github.com/five82/spindle/internal/deps"
+	"github.com/five82/spindle/internal/jellyfin"
+	"github.com/five82/spindle/internal/opensubtitles"
+	"github.com/five82/spindle/internal/tmdb"
+)
+
+// doctorMinStagingGiB is the free staging space below which doctor warns:
+// roughly one UHD Blu-ray rip.
+const doctorMinStagingGiB = 100
+
+// doctorProbeTimeout bounds each external service probe.
+const doctorProbeTimeout = 15 * time.Second
+
+// doctorSeverity ranks a check result. Only doctorFail makes doctor exit
+// non-zero.
+type doctorSeverity int
+
+const (
+	doctorOK doctorSeverity = iota
+	doctorWarn
+	doctorFail
+)
+
+// doctorCheck is one line of the doctor report.
+type doctorCheck struct {
+	Category string
+	Name     string
+	Severity doctorSeverity
+	Detail   string
+	Hint     string
+}
+
+// doctorReport aggregates check results in the order they were added.
+type doctorReport struct {
+	Checks []doctorCheck
+}
+
+func (r *doctorReport) add(checks ...doctorCheck) {
+	r.Checks = append(r.Checks, checks...)
+}
+
+// count returns the number of checks with the given severity.
+func (r *doctorReport) count(sev doctorSeverity) int {
+	n := 0
+	for _, c := range r.Checks {
+		if c.Severity == sev {
+			n++
+		}
+	}
+	return n
+}
+
+func newDoctorCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "doctor",
+		Short:   "Diagnose configuration, dependencies, and external services",
+		GroupID: groupDiagnostics,
+		Annotations: map[string]string{
+			// Doctor reports config errors instead of aborting on them.
+			"skipConfigLoad": "true",
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			report := runDoctor(context.Background())
+			printDoctorReport(report)
+			if n := report.count(doctorFail); n > 0 {
+				return fmt.Errorf("doctor found %d critical problem(s)", n)
+			}
+			return nil
+		},
+	}
+}
+
+// runDoctor runs every check. Checks that need a valid config are skipped
+// when it fails to load.
+func runDoctor(ctx context.Context) *doctorReport {
+	report := &doctorReport{}
+
+	loaded, loadErr := config.Load(flagConfig, nil)
+	report.add(configCheck(loaded, loadErr))
+
+	report.add(dependencyChecks(deps.CheckRequirements(deps.DefaultRequirements()))...)
+	report.add(toolVersionChecks()...)
+
+	if loadErr != nil {
+		return report
+	}
+
+	// Checks read the loaded config directly; the package-level cfg is
+	// left alone so doctor cannot change settings other code sees.
+	socket := loaded.SocketPath()
+	if flagSocket != "" {
+		socket = flagSocket
+	}
+	report.add(pathChecks(loaded)...)
+	report.add(stagingSpaceCheck(loaded.Paths.StagingDir, freeBytes))
+	report.add(opticalDriveCheck(loaded.MakeMKV.OpticalDrive))
+	report.add(daemonCheck(daemonctl.IsRunning(loaded.LockPath(), socket)))
+	report.add(serviceChecks(ctx, loaded)...)
+	return report
+}
+
+func configCheck(loaded *config.Config, err error) doctorCheck {
+	check := doctorCheck{Category: "Config", Name: "config"}
+	if err != nil {
+		check.Severity = doctorFail
+		check.Detail = err.Error()
+		check.Hint = "fix the listed keys; 'spindle config init' writes a commented sample"
+		return check
+	}
+	check.Detail = "valid"
+	if loaded.SourcePath != "" {
+		check.Detail = "valid (" + loaded.SourcePath + ")"
+	}
+	if loaded.Offline {
+		check.Severity = doctorWarn
+		check.Detail += ", offline mode"
+		check.Hint = "external services are disabled; unset offline for real runs"
+	}
+	return check
+}
+
+// dependencyChecks maps dependency statuses to checks: missing required
+// dependencies are critical, missing optional ones are warnings.
+func dependencyChecks(statuses []deps.Status) []doctorCheck {
+	checks := make([]doctorCheck, 0, len(statuses))
+	for _, s := range statuses {
+		check := doctorCheck{Category: "Dependencies", Name: s.Name, Detail: s.Detail}
+		if !s.Available {
+			check.Severity = doctorFail
+			if s.Optional {
+				check.Severity = doctorWarn
+			}
+			check.Hint = "install " + s.Description
+			if s.Library {
+				check.Hint += " and make sure the dynamic linker can find it (ldconfig or LD_LIBRARY_PATH)"
+			}
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// toolVersionChecks reports the first line of each command-line tool's
+// version output. A tool that is missing is already reported by
+// dependencyChecks, so it is skipped here.
+func toolVersionChecks() []doctorCheck {
+	tools := []struct {
+		name string
+		args []string
+	}{
+		{"ffmpeg", []string{"-version"}},
+		{"ffprobe", []string{"-version"}},
+		{"mkvmerge", []string{"--version"}},
+		{"uvx", []string{"--version"}},
+	}
+	var checks []doctorCheck
+	for _, tool := range tools {
+		path, err := exec.LookPath(tool.name)
+		if err != nil {
+			if tool.name == "uvx" {
+				checks = append(checks, doctorCheck{
+					Category: "Versions", Name: tool.name, Severity: doctorWarn,
+					Detail: "not found",
+					Hint:   "install uv; WhisperX transcription runs through uvx",
+				})
+			}
+			continue
+		}
+		out, err := exec.Command(path, tool.args...).Output()
+		check := doctorCheck{Category: "Versions", Name: tool.name, Detail: firstLine(string(out))}
+		if err != nil {
+			check.Severity = doctorWarn
+			check.Detail = err.Error()
+			check.Hint = "the tool is installed but failed to report its version"
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(line)
+}
+
+// pathChecks reports configured directories that are missing or not
+// directories. Missing directories are warnings: the daemon creates them.
+func pathChecks(c *config.Config) []doctorCheck {
+	paths := []struct{ name, path string }{
+		{"staging_dir", c.Paths.StagingDir},
+		{"state_dir", c.Paths.StateDir},
+		{"review_dir", c.Paths.ReviewDir},
+		{"library_dir", c.Paths.LibraryDir},
+	}
+	checks := make([]doctorCheck, 0, len(paths))
+	for _, p := range paths {
+		check := doctorCheck{Category: "Paths", Name: p.name, Detail: p.path}
+		info, err := os.Stat(p.path)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			check.Severity = doctorWarn
+			check.Detail = p.path + " (missing)"
+			check.Hint = "run 'spindle config validate' to create it"
+		case err != nil:
+			check.Severity = doctorFail
+			check.Detail = err.Error()
+			check.Hint = "check permissions on " + filepath.Dir(p.path)
+		case !info.IsDir():
+			check.Severity = doctorFail
+			check.Detail = p.path + " is not a directory"
+			check.Hint = "point paths." + p.name + " at a directory"
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// freeBytes returns the bytes available to unprivileged users on the
+// filesystem holding path.
+func freeBytes(path string) (int64, error) {
+	var fs unix.Statfs_t
+	if err := unix.Statfs(path, &fs); err != nil {
+		return 0, err
+	}
+	return int64(fs.Bavail) * int64(fs.Bsize), nil
+}
+
+func stagingSpaceCheck(dir string, free func(string) (int64, error)) doctorCheck {
+	check := doctorCheck{Category: "Disk", Name: "staging free space"}
+	bytes, err := free(dir)
+	if err != nil {
+		check.Severity = doctorWarn
+		check.Detail = err.Error()
+		check.Hint = "create the staging directory so its free space can be measured"
+		return check
+	}
+	gibFree := float64(bytes) / (1 << 30)
+	check.Detail = fmt.Sprintf("%.1f GiB free in %s", gibFree, dir)
+	if gibFree < doctorMinStagingGiB {
+		check.Severity = doctorWarn
+		check.Hint = fmt.Sprintf("rips need up to ~%d GiB; free up space or move paths.staging_dir", doctorMinStagingGiB)
+	}
+	return check
+}
+
+func opticalDriveCheck(device string) doctorCheck {
+	check := doctorCheck{Category: "Disk", Name: "optical drive", Detail: device}
+	if device == "" {
+		check.Severity = doctorWarn
+		check.Detail = "not configured"
+		check.Hint = "set makemkv.optical_drive to enable disc detection"
+		return check
+	}
+	if _, err := os.Stat(device); err != nil {
+		check.Severity = doctorWarn
+		check.Detail = err.Error()
+		check.Hint = "connect the drive or fix makemkv.optical_drive"
+	}
+	return check
+}
+
+func daemonCheck(running bool) doctorCheck {
+	check := doctorCheck{Category: "Daemon", Name: "daemon", Detail: "running"}
+	if !running {
+		check.Detail = "stopped"
+		check.Hint = "run 'spindle start'"
+	}
+	return check
+}
+
+// serviceChecks probes each configured external service. TMDB is required
+// for identification; the others only disable optional features.
+func serviceChecks(ctx context.Context, c *config.Config) []doctorCheck {
+	if c.Offline {
+		return []doctorCheck{{Category: "Services", Name: "external services", Severity: doctorWarn,
+			Detail: "skipped (offline mode)"}}
+	}
+	probe := func(fn func(context.Context) error) error {
+		probeCtx, cancel := context.WithTimeout(ctx, doctorProbeTimeout)
+		defer cancel()
+		return fn(probeCtx)
+	}
+
+	checks := []doctorCheck{serviceCheck("TMDB", true, true,
+		probe(tmdb.New(c.TMDB.APIKey, c.TMDB.BaseURL, c.TMDB.Language, nil).CheckHealth),
+		"check tmdb.api_key and network access to "+c.TMDB.BaseURL)}
+
+	osConfigured := c.Subtitles.OpenSubtitlesAPIKey != ""
+	var osErr error
+	if osConfigured {
+		osErr = probe(opensubtitles.New(opensubtitles.Params{
+			APIKey:    c.Subtitles.OpenSubtitlesAPIKey,
+			UserAgent: c.Subtitles.OpenSubtitlesUserAgent,
+			UserToken: c.Subtitles.OpenSubtitlesUserToken,
+		}, nil).CheckHealth)
+	}
+	checks = append(checks, serviceCheck("OpenSubtitles", osConfigured, false, osErr,
+		"set subtitles.opensubtitles_api_key to enable TV episode identification"))
+
+	var jfErr error
+	if c.Jellyfin.Enabled {
+		jfErr = probe(jellyfin.New(c.Jellyfin.URL, c.Jellyfin.APIKey, nil).CheckHealth)
+	}
+	checks = append(checks, serviceCheck("Jellyfin", c.Jellyfin.Enabled, false, jfErr,
+		"check jellyfin.url and jellyfin.api_key"))
+
+	// OpenRouter and ntfy are not probed: a probe would spend credits or
+	// publish a message. 'spindle notify test' exercises ntfy.
+	checks = append(checks,
+		serviceCheck("OpenRouter", c.LLM.APIKey != "", false, nil,
+			"set llm.api_key to enable commentary detection and episode verification"),
+		serviceCheck("ntfy", c.Notifications.NtfyTopic != "", false, nil,
+			"set notifications.ntfy_topic to receive notifications"),
+	)
+	return checks
+}
+
+// serviceCheck classifies one external service. An unconfigured optional
+// service is a warning; a failed probe is critical only for required
+// services.
+func serviceCheck(name string, configured, required bool, probeErr error, hint string) doctorCheck {
+	check := doctorCheck{Category: "Services", Name: name, Detail: "reachable"}
+	switch {
+	case !configured:
+		check.Severity = doctorWarn
+		if required {
+			check.Severity = doctorFail
+		}
+		check.Detail = "not configured"
+		check.Hint = hint
+	case probeErr != nil:
+		check.Severity = doctorWarn
+		if required {
+			check.Severity = doctorFail
+		}
+		check.Detail = probeErr.Error()
+		check.Hint = hint
+	}
+	return check
+}
+
+func printDoctorReport(r *doctorReport) {
+	fmt.Println()
+	fmt.Println(headerStyle("Spindle Doctor"))
+	category := ""
+	for _, c := range r.Checks {
+		if c.Category != category {
+			category = c.Category
+			fmt.Println()
+			fmt.Println(headerStyle(category))
+			fmt.Println()
+		}
+		mark := successStyle("\u2713")
+		switch c.Severity {
+		case doctorWarn:
+			mark = warnStyle("!")
+		case doctorFail:
+			mark = failStyle("\u2717")
+		}
+		fmt.Printf("  %s %-20s %s\n", mark, c.Name, dimStyle(c.Detail))
+		if c.Hint != "" && c.Severity != doctorOK {
+			fmt.Printf("    %s %s\n", dimStyle("->"), c.Hint)
+		}
+	}
+	fmt.Println()
+	fmt.Printf("  %d ok, %d warning(s), %d critical\n",
+		r.count(doctorOK), r.count(doctorWarn), r.count(doctorFail))
+}
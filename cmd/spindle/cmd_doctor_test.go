@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/five82/spindle/internal/deps"
+)
+
+func TestDoctorReportAggregatesCheckResults(t *testing.T) {
+	report := &doctorReport{}
+	report.add(dependencyChecks([]deps.Status{
+		{Requirement: deps.Requirement{Name: "ffmpeg"}, Available: true, Detail: "/usr/bin/ffmpeg"},
+		{Requirement: deps.Requirement{Name: "mkvmerge", Description: "MKVToolNix merge tool"}, Detail: "not found"},
+		{Requirement: deps.Requirement{Name: "extra", Optional: true}, Detail: "not found"},
+	})...)
+	report.add(
+		serviceCheck("TMDB", true, true, errors.New("status 401"), "check tmdb.api_key"),
+		serviceCheck("Jellyfin", false, false, nil, "configure jellyfin"),
+		serviceCheck("OpenSubtitles", true, false, nil, ""),
+	)
+
+	if len(report.Checks) != 6 {
+		t.Fatalf("checks = %d, want 6", len(report.Checks))
+	}
+	if got := report.count(doctorOK); got != 2 {
+		t.Errorf("ok = %d, want 2 (ffmpeg, OpenSubtitles)", got)
+	}
+	if got := report.count(doctorWarn); got != 2 {
+		t.Errorf("warnings = %d, want 2 (optional dep, unconfigured Jellyfin)", got)
+	}
+	if got := report.count(doctorFail); got != 2 {
+		t.Errorf("critical = %d, want 2 (mkvmerge, TMDB probe)", got)
+	}
+	if hint := report.Checks[1].Hint; hint == "" {
+		t.Error("missing dependency should carry a remediation hint")
+	}
+}
+
+func TestServiceCheckUnconfiguredRequiredIsCritical(t *testing.T) {
+	check := serviceCheck("TMDB", false, true, nil, "set tmdb.api_key")
+	if check.Severity != doctorFail {
+		t.Fatalf("severity = %v, want doctorFail", check.Severity)
+	}
+	if check.Hint != "set tmdb.api_key" {
+		t.Errorf("hint = %q", check.Hint)
+	}
+}
+
+func TestStagingSpaceCheck(t *testing.T) {
+	low := stagingSpaceCheck("/staging", func(string) (int64, error) { return 10 << 30, nil })
+	if low.Severity != doctorWarn {
+		t.Errorf("10 GiB free severity = %v, want doctorWarn", low.Severity)
+	}
+	plenty := stagingSpaceCheck("/staging", func(string) (int64, error) { return 500 << 30, nil })
+	if plenty.Severity != doctorOK {
+		t.Errorf("500 GiB free severity = %v, want doctorOK", plenty.Severity)
+	}
+	failed := stagingSpaceCheck("/missing", func(string) (int64, error) { return 0, errors.New("no such file") })
+	if failed.Severity != doctorWarn || failed.Hint == "" {
+		t.Errorf("statfs failure = %+v, want warning with hint", failed)
+	}
+}
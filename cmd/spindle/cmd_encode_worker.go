@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/spf13/cobra"
 
@@ -15,6 +17,7 @@ import (
 func newEncodeWorkerCmd() *cobra.Command {
 	var input string
 	var outputDir string
+	var crf float64
 	cmd := &cobra.Command{
 		Use:    "encode-worker",
 		Short:  "Internal: encode one file and stream reporter events (used by the daemon)",
@@ -24,9 +27,13 @@ func newEncodeWorkerCmd() *cobra.Command {
 			if input == "" || outputDir == "" {
 				return fmt.Errorf("encode-worker requires --input and --output-dir")
 			}
+			// The daemon sends SIGTERM to cancel an encode; cancelling the
+			// context lets reel shut its encoder down instead of dying mid-write.
+			ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
 			// Errors are already reported on the stdout wire as a failure
 			// event; the non-zero exit is the daemon's secondary signal.
-			if err := encoder.RunWorker(cmd.Context(), input, outputDir, os.Stdout); err != nil {
+			if err := encoder.RunWorker(ctx, input, outputDir, crf, os.Stdout); err != nil {
 				return fmt.Errorf("encode failed: %w", err)
 			}
 			return nil
@@ -34,5 +41,6 @@ func newEncodeWorkerCmd() *cobra.Command {
 	}
 	cmd.Flags().StringVar(&input, "input", "", "Input video file")
 	cmd.Flags().StringVar(&outputDir, "output-dir", "", "Directory for the encoded output")
+	cmd.Flags().Float64Var(&crf, "crf", 0, "Fixed CRF replacing target-quality mode (0 keeps target quality)")
 	return cmd
 }
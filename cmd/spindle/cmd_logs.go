@@ -26,16 +26,19 @@ func newLogsCmd() *cobra.Command {
 		Long: `Display daemon logs.
 
 Without filters, tails the log file directly (works with the daemon stopped).
-Filters and --follow use the daemon API and require a running daemon.`,
+Filters use the daemon API and require a running daemon. Following uses the
+daemon API too when the daemon is running; otherwise it follows the log file,
+resuming where it left off across daemon restarts and log rotation.`,
 		Example: `  spindle logs -n 50
   spindle logs -f --item 3
+  spindle logs --stage encoding --item 3 --level info
   spindle logs --level warn --component encoder`,
 		RunE: func(_ *cobra.Command, _ []string) error {
 			hasFilter := query.Component != "" || query.Lane != "" || query.Request != "" ||
-				query.ItemID != 0 || query.Level != ""
+				query.Stage != "" || query.ItemID != 0 || query.Level != ""
 
-			// When filters are set or follow is requested, use the daemon API.
-			if hasFilter || follow {
+			// When filters are set, use the daemon API.
+			if hasFilter {
 				acc, err := openQueueAccess()
 				if err != nil {
 					return fmt.Errorf("daemon is not running (filters require the daemon API): %w", err)
@@ -44,8 +47,16 @@ Filters and --follow use the daemon API and require a running daemon.`,
 				return logsFromAPI(acc, query, follow)
 			}
 
-			// No filters: tail the log file directly.
+			// No filters: follow through the daemon API for formatted
+			// output when it is up, otherwise tail the log file directly.
 			logPath := cfg.DaemonLogPath()
+			if follow {
+				if acc, err := openQueueAccess(); err == nil {
+					query.Limit = lines
+					return logsFromAPI(acc, query, follow)
+				}
+				return followLogFile(logPath, lines)
+			}
 			logLines, err := logs.Tail(logPath, lines)
 			if err != nil {
 				return fmt.Errorf("read logs: %w", err)
@@ -61,44 +72,118 @@ Filters and --follow use the daemon API and require a running daemon.`,
 	cmd.Flags().StringVar(&query.Component, "component", "", "Filter by component label")
 	cmd.Flags().StringVar(&query.Lane, "lane", "", "Filter by processing lane")
 	cmd.Flags().StringVar(&query.Request, "request", "", "Filter by request/correlation ID")
+	cmd.Flags().StringVar(&query.Stage, "stage", "", "Filter by pipeline stage")
 	cmd.Flags().Int64VarP(&query.ItemID, "item", "i", 0, "Filter by queue item ID")
 	cmd.Flags().StringVar(&query.Level, "level", "", "Minimum log level (debug, info, warn, error)")
 	return cmd
 }
 
-// logsFromAPI fetches logs from the daemon HTTP API.
+// logsFromAPI fetches logs from the daemon HTTP API. Following streams
+// events over the socket, resuming after the last event seen if the stream
+// drops while the daemon restarts.
 func logsFromAPI(acc *queueaccess.HTTPAccess, query queueaccess.LogsQuery, follow bool) error {
 	query.Tail = true // seed the initial window from the tail
-	events, next, err := acc.Logs(query)
-	if err != nil {
-		return fmt.Errorf("fetch logs: %w", err)
-	}
-	for _, e := range events {
-		printLogEntry(e)
-	}
-
 	if !follow {
+		events, _, err := acc.Logs(query)
+		if err != nil {
+			return fmt.Errorf("fetch logs: %w", err)
+		}
+		for _, e := range events {
+			printLogEntry(e)
+		}
 		return nil
 	}
 
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
+	var resume logResume
 	for {
+		if status, err := acc.Status(); err == nil {
+			query = resume.rebase(query, status.PID)
+		}
+		err := acc.StreamLogs(ctx, query, func(e queueaccess.LogEntry) {
+			if resume.replayed(e) {
+				return
+			}
+			printLogEntry(e)
+			query.Since = e.Seq + 1
+		})
+		if err == nil || ctx.Err() != nil {
+			return nil
+		}
 		select {
 		case <-ctx.Done():
 			return nil
 		case <-time.After(1 * time.Second):
 		}
+	}
+}
 
-		query.Since = next
-		events, cursor, err := acc.Logs(query)
-		if err != nil {
-			continue // keep the cursor; retry the same window next tick
+// logResume keys a followed stream's cursor on the daemon process serving
+// it. Sequence numbers only order events within one process: a restarted
+// daemon numbers its buffer from 1 again, re-reading earlier events from the
+// log files, so an old cursor would skip or repeat events.
+type logResume struct {
+	pid int
+	// skipThrough drops replayed events at or before the last one printed
+	// by a previous daemon process; zero once the stream has moved past it.
+	skipThrough time.Time
+	last        time.Time
+}
+
+// rebase returns q for a stream served by daemon pid. The same process keeps
+// the seq cursor; a new one reseeds from the tail and skips what was
+// already printed.
+func (r *logResume) rebase(q queueaccess.LogsQuery, pid int) queueaccess.LogsQuery {
+	if r.pid != 0 && pid != r.pid {
+		q.Since = 0
+		r.skipThrough = r.last
+	}
+	r.pid = pid
+	return q
+}
+
+// replayed reports whether e was already printed before a daemon restart,
+// and otherwise records it as the latest event seen.
+func (r *logResume) replayed(e queueaccess.LogEntry) bool {
+	t, err := time.Parse(time.RFC3339Nano, e.Time)
+	if err != nil {
+		return false
+	}
+	if !r.skipThrough.IsZero() {
+		if !t.After(r.skipThrough) {
+			return true
 		}
-		next = cursor
-		for _, e := range events {
-			printLogEntry(e)
+		r.skipThrough = time.Time{}
+	}
+	r.last = t
+	return false
+}
+
+// followLogFile prints the last lines of the log file, then polls for new
+// ones. The cursor keys on file identity, so a daemon restart or rotation
+// switches to the new file without re-showing lines; read errors while the
+// file is being replaced are retried.
+func followLogFile(path string, lines int) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	logLines, cur, err := logs.TailFrom(path, logs.Cursor{}, lines)
+	if err != nil {
+		return fmt.Errorf("read logs: %w", err)
+	}
+	for {
+		for _, line := range logLines {
+			fmt.Println(line)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(500 * time.Millisecond):
+		}
+		if logLines, cur, err = logs.TailFrom(path, cur, lines); err != nil {
+			logLines = nil
 		}
 	}
 }
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/five82/spindle/internal/queueaccess"
+)
+
+func TestLogResumeResetsCursorForRestartedDaemon(t *testing.T) {
+	var r logResume
+	q := r.rebase(queueaccess.LogsQuery{Tail: true}, 100)
+	for _, ts := range []string{"2026-01-02T10:00:00Z", "2026-01-02T10:00:05Z"} {
+		if r.replayed(queueaccess.LogEntry{Time: ts}) {
+			t.Fatalf("first stream event %s reported as replayed", ts)
+		}
+	}
+	q.Since = 500
+
+	// Same daemon: keep the cursor.
+	if got := r.rebase(q, 100); got.Since != 500 {
+		t.Fatalf("Since for same daemon = %d, want 500", got.Since)
+	}
+
+	// Restarted daemon: its seqs start over, so reseed from the tail and
+	// drop the hydrated events already printed.
+	q = r.rebase(q, 200)
+	if q.Since != 0 || !q.Tail {
+		t.Fatalf("query after restart = %+v, want Since 0 with tail", q)
+	}
+	if !r.replayed(queueaccess.LogEntry{Seq: 7, Time: "2026-01-02T10:00:05Z"}) {
+		t.Error("event printed before the restart was not skipped")
+	}
+	if r.replayed(queueaccess.LogEntry{Seq: 8, Time: "2026-01-02T10:00:09Z"}) {
+		t.Error("event logged after the restart was skipped")
+	}
+	if r.replayed(queueaccess.LogEntry{Seq: 9, Time: "2026-01-02T10:00:08Z"}) {
+		t.Error("out-of-order event after catching up was skipped")
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/five82/spindle/internal/daemonrun"
+	"github.com/five82/spindle/internal/notify"
+)
+
+func newNotifyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "notify",
+		Short:   "Notification tools",
+		GroupID: groupDiagnostics,
+	}
+	cmd.AddCommand(newNotifyTestCmd("test"))
+	return cmd
+}
+
+// newNotifyTestCmd builds the self-test command under the given name; it is
+// "notify test", and "debug notify" is kept as an alias.
+func newNotifyTestCmd(use string) *cobra.Command {
+	return &cobra.Command{
+		Use:   use,
+		Short: "Send a test notification to the configured ntfy topic",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			clients, err := daemonrun.NewClients(cfg, buildLogger())
+			if err != nil {
+				return err
+			}
+			n := clients.Notifier
+			if cfg.Offline {
+				return fmt.Errorf("notifications are disabled in offline mode")
+			}
+			if n == nil {
+				return fmt.Errorf("notifications not configured (no ntfy topic)")
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			// Send goes through the same templates as workflow events.
+			start := time.Now()
+			err = n.Send(ctx, notify.EventTest, "Spindle Test", "Test notification from Spindle")
+			printNotifyTestResult(os.Stdout, cfg.Notifications.NtfyTopic, time.Since(start), err)
+			if err != nil {
+				return fmt.Errorf("send notification: %w", err)
+			}
+			return nil
+		},
+	}
+}
+
+// printNotifyTestResult writes the outcome of the test notification. The
+// topic name is redacted: anyone who knows it can read the notifications.
+func printNotifyTestResult(w io.Writer, topic string, elapsed time.Duration, err error) {
+	target := redactTopic(topic)
+	if err != nil {
+		_, _ = fmt.Fprintf(w, "%s ntfy (%s): %v\n", failStyle("FAIL"), target, err)
+		return
+	}
+	_, _ = fmt.Fprintf(w, "%s ntfy (%s) in %s\n", successStyle("OK"), target, elapsed.Round(time.Millisecond))
+}
+
+// redactTopic keeps the ntfy server of a topic URL and hides the topic.
+func redactTopic(topic string) string {
+	u, err := url.Parse(topic)
+	if err != nil || u.Host == "" {
+		return "***"
+	}
+	return u.Scheme + "://" + u.Host + "/***"
+}
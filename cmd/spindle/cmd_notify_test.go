@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrintNotifyTestResultRedactsTopic(t *testing.T) {
+	var buf bytes.Buffer
+	printNotifyTestResult(&buf, "https://ntfy.example/secret-topic", 120*time.Millisecond, nil)
+	if out := buf.String(); !strings.Contains(out, "OK") || !strings.Contains(out, "https://ntfy.example/***") {
+		t.Errorf("success line = %q", out)
+	}
+
+	buf.Reset()
+	printNotifyTestResult(&buf, "https://ntfy.example/secret-topic", 0, errors.New("notify: status 403"))
+	if out := buf.String(); !strings.Contains(out, "FAIL") || !strings.Contains(out, "status 403") {
+		t.Errorf("failure line = %q", out)
+	}
+	if strings.Contains(buf.String(), "secret-topic") {
+		t.Errorf("output leaks the topic: %q", buf.String())
+	}
+
+	if got := redactTopic("not a url"); got != "***" {
+		t.Errorf("redactTopic(invalid) = %q, want ***", got)
+	}
+}
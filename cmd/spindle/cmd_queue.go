@@ -1,17 +1,24 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/five82/spindle/internal/daemonctl"
+	"github.com/five82/spindle/internal/daemonrun"
 	"github.com/five82/spindle/internal/httpapi"
+	"github.com/five82/spindle/internal/mkvimport"
 	"github.com/five82/spindle/internal/queue"
+	"github.com/five82/spindle/internal/queueaccess"
 	"github.com/five82/spindle/internal/queueops"
+	"github.com/five82/spindle/internal/ripspec"
 )
 
 // printTaskLines renders per-task status lines: running tasks show percent
@@ -23,7 +30,11 @@ func printTaskLines(indent string, tasks []httpapi.TaskResponse, verbose bool) {
 	for _, t := range tasks {
 		switch queue.TaskState(t.State) {
 		case queue.TaskRunning:
-			fmt.Printf("%s%s %s (%.0f%%)\n", indent, labelStyle(fmt.Sprintf("Progress (%s):", t.Type)), t.Progress.Message, t.Progress.Percent)
+			eta := ""
+			if t.Progress.ETASeconds > 0 {
+				eta = ", ETA " + time.Duration(t.Progress.ETASeconds*float64(time.Second)).Round(time.Second).String()
+			}
+			fmt.Printf("%s%s %s (%.0f%%%s)\n", indent, labelStyle(fmt.Sprintf("Progress (%s):", t.Type)), t.Progress.Message, t.Progress.Percent, eta)
 			if verbose && t.Progress.TotalBytes > 0 {
 				fmt.Printf("%s  %s %s / %s\n", indent, labelStyle("Bytes:"), formatBytes(t.Progress.BytesCopied), formatBytes(t.Progress.TotalBytes))
 			}
@@ -48,6 +59,9 @@ func newQueueCmd() *cobra.Command {
 		newQueueClearCmd(),
 		newQueueRetryCmd(),
 		newQueueCancelCmd(),
+		newQueueOptionsCmd(),
+		newQueueNoteCmd(),
+		newQueueImportCmd(),
 		newQueueAuditCmd(),
 	)
 	return cmd
@@ -75,7 +89,6 @@ func parseQueueIDs(args []string) ([]int64, error) {
 
 func newQueueListCmd() *cobra.Command {
 	var stages []string
-	var asJSON bool
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List queue items",
@@ -94,8 +107,8 @@ func newQueueListCmd() *cobra.Command {
 				return err
 			}
 
-			if asJSON {
-				return printJSON(items)
+			if flagJSON {
+				return printJSONOutput(jsonKindQueueItems, items)
 			}
 
 			if len(items) == 0 {
@@ -110,7 +123,7 @@ func newQueueListCmd() *cobra.Command {
 					fmt.Printf("%-6d %-40s %-24s %-30s %-30s %s\n",
 						item.ID,
 						item.DiscTitle,
-						item.Stage,
+						itemStageLabel(&item),
 						item.CreatedAt,
 						item.UpdatedAt,
 						item.DiscFingerprint,
@@ -127,7 +140,7 @@ func newQueueListCmd() *cobra.Command {
 					fmt.Printf("%-6d %-30s %-24s %-16s %-14s\n",
 						item.ID,
 						truncate(item.DiscTitle, 28),
-						item.Stage,
+						itemStageLabel(&item),
 						relativeAge(item.CreatedAt),
 						shortFP(item.DiscFingerprint),
 					)
@@ -137,12 +150,10 @@ func newQueueListCmd() *cobra.Command {
 		},
 	}
 	cmd.Flags().StringSliceVarP(&stages, "stage", "s", nil, "Filter by queue stage (repeatable)")
-	cmd.Flags().BoolVar(&asJSON, "json", false, "Output items as JSON")
 	return cmd
 }
 
 func newQueueShowCmd() *cobra.Command {
-	var asJSON bool
 	cmd := &cobra.Command{
 		Use:   "show <id>",
 		Short: "Show detailed information for a queue item",
@@ -165,13 +176,13 @@ func newQueueShowCmd() *cobra.Command {
 				return fmt.Errorf("queue item %d not found", id)
 			}
 
-			if asJSON {
-				return printJSON(item)
+			if flagJSON {
+				return printJSONOutput(jsonKindQueueItem, item)
 			}
 
 			fmt.Printf("%s %d\n", labelStyle("ID:         "), item.ID)
 			fmt.Printf("%s %s\n", labelStyle("Title:      "), item.DiscTitle)
-			fmt.Printf("%s %s\n", labelStyle("Stage:      "), item.Stage)
+			fmt.Printf("%s %s\n", labelStyle("Stage:      "), itemStageLabel(item))
 			if flagVerbose && item.FailedAtStage != "" {
 				fmt.Printf("%s %s\n", labelStyle("FailedAt:   "), item.FailedAtStage)
 			}
@@ -179,6 +190,18 @@ func newQueueShowCmd() *cobra.Command {
 			fmt.Printf("%s %s\n", labelStyle("Updated:    "), item.UpdatedAt)
 			fmt.Printf("%s %s\n", labelStyle("Fingerprint:"), item.DiscFingerprint)
 			printTaskLines("", item.Tasks, flagVerbose)
+			if item.Notes != "" {
+				fmt.Printf("%s %s\n", labelStyle("Notes:      "), item.Notes)
+			}
+			if skips := itemSkips(item); len(skips) > 0 {
+				fmt.Printf("%s %s\n", labelStyle("Skipping:   "), strings.Join(skips, ", "))
+			}
+			if item.EncodeCRF > 0 {
+				fmt.Printf("%s CRF %g\n", labelStyle("Quality:    "), item.EncodeCRF)
+			}
+			if item.PinnedTMDBID > 0 {
+				fmt.Printf("%s TMDB %s %d\n", labelStyle("Pinned:     "), item.PinnedMediaType, item.PinnedTMDBID)
+			}
 			if item.NeedsReview {
 				fmt.Printf("%s %s\n", labelStyle("Review:     "), strings.Join(item.ReviewReasons, "; "))
 			}
@@ -197,7 +220,314 @@ func newQueueShowCmd() *cobra.Command {
 			return nil
 		},
 	}
-	cmd.Flags().BoolVar(&asJSON, "json", false, "Output the item as JSON")
+	return cmd
+}
+
+// itemSkips lists the processing an item's options skip.
+func itemSkips(item *queueaccess.Item) []string {
+	var skips []string
+	if item.SkipSubtitles {
+		skips = append(skips, "subtitles")
+	}
+	if item.SkipCommentary {
+		skips = append(skips, "commentary")
+	}
+	return skips
+}
+
+// itemStageLabel shows user-stopped items as cancelled rather than failed.
+func itemStageLabel(item *queueaccess.Item) string {
+	if item.UserStopped && item.Stage == string(queue.StageFailed) {
+		return "cancelled"
+	}
+	return item.Stage
+}
+
+func newQueueOptionsCmd() *cobra.Command {
+	var skipSubtitles, skipCommentary bool
+	var crf float64
+	var tmdbID int
+	var mediaType string
+	cmd := &cobra.Command{
+		Use:   "options <id>",
+		Short: "Set per-item processing options",
+		Long: `Set per-item processing options. Skipped stages complete as no-ops, and the
+options are stored in the item's rip spec so retries honor them. A TMDB pin
+makes identification use that title instead of searching; retry the item
+from identification to apply it. Options cannot change while a stage of the
+item is running.`,
+		Example: `  spindle queue options 5 --skip-subtitles            # silent film: no subtitles
+  spindle queue options 5 --skip-commentary           # no commentary analysis
+  spindle queue options 5 --skip-subtitles=false      # generate subtitles again
+  spindle queue options 5 --crf 20                    # archival favorite: higher quality
+  spindle queue options 5 --crf 0                     # back to target quality
+  spindle queue options 5 --tmdb-id 949 --type movie  # pin the TMDB match
+  spindle queue options 5 --tmdb-id 0                 # remove the pin`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseQueueID(args[0])
+			if err != nil {
+				return err
+			}
+			if !cmd.Flags().Changed("skip-subtitles") && !cmd.Flags().Changed("skip-commentary") && !cmd.Flags().Changed("crf") && !cmd.Flags().Changed("tmdb-id") {
+				return fmt.Errorf("specify --skip-subtitles, --skip-commentary, --crf, and/or --tmdb-id")
+			}
+			if cmd.Flags().Changed("type") && !cmd.Flags().Changed("tmdb-id") {
+				return fmt.Errorf("--type requires --tmdb-id")
+			}
+
+			acc, err := openQueueAccess()
+			if err != nil {
+				return err
+			}
+			item, err := acc.GetByID(id)
+			if err != nil {
+				return err
+			}
+			if item == nil {
+				return fmt.Errorf("queue item %d not found", id)
+			}
+
+			// Flags left unset keep the item's current choice.
+			opts := ripspec.ItemOptions{
+				SkipSubtitles:   item.SkipSubtitles,
+				SkipCommentary:  item.SkipCommentary,
+				EncodeCRF:       item.EncodeCRF,
+				PinnedTMDBID:    item.PinnedTMDBID,
+				PinnedMediaType: item.PinnedMediaType,
+			}
+			if cmd.Flags().Changed("skip-subtitles") {
+				opts.SkipSubtitles = skipSubtitles
+			}
+			if cmd.Flags().Changed("skip-commentary") {
+				opts.SkipCommentary = skipCommentary
+			}
+			if cmd.Flags().Changed("crf") {
+				opts.EncodeCRF = crf
+			}
+			if cmd.Flags().Changed("tmdb-id") {
+				opts.PinnedTMDBID, opts.PinnedMediaType = tmdbID, mediaType
+				if tmdbID == 0 {
+					opts.PinnedMediaType = ""
+				}
+			}
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+
+			result, err := acc.SetOptions(id, opts)
+			if err != nil {
+				return err
+			}
+			switch result {
+			case queueops.OptionsResultUpdated:
+				fmt.Println(successStyle(fmt.Sprintf("Updated options for item %d", id)))
+			case queueops.OptionsResultNotFound:
+				return fmt.Errorf("item %d not found", id)
+			case queueops.OptionsResultInProgress:
+				return fmt.Errorf("item %d has a stage running; try again when it finishes", id)
+			case queueops.OptionsResultStopped:
+				return fmt.Errorf("item %d is stopped; retry it before changing options", id)
+			default:
+				return fmt.Errorf("unexpected options result: %s", result)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&skipSubtitles, "skip-subtitles", false, "Skip subtitle generation for this item")
+	cmd.Flags().BoolVar(&skipCommentary, "skip-commentary", false, "Skip commentary detection for this item")
+	cmd.Flags().Float64Var(&crf, "crf", 0, "Encode at this fixed CRF, 1-70, lower is higher quality (0 restores target quality)")
+	cmd.Flags().IntVar(&tmdbID, "tmdb-id", 0, "Pin identification to this TMDB ID (0 removes the pin)")
+	cmd.Flags().StringVar(&mediaType, "type", "", "TMDB type of the pinned ID: movie or tv")
+	return cmd
+}
+
+func newQueueNoteCmd() *cobra.Command {
+	var clearNotes bool
+	cmd := &cobra.Command{
+		Use:   "note <id> [text]",
+		Short: "Set or clear an item's notes",
+		Long: `Attach free-text notes to a queue item, replacing any it has. Notes are
+shown by 'spindle queue show' and kept across stage changes, stops, and
+retries. They can change at any time, even while a stage is running.`,
+		Example: `  spindle queue note 5 "warped, read slowly"
+  spindle queue note 5 --clear`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			id, err := parseQueueID(args[0])
+			if err != nil {
+				return err
+			}
+			if clearNotes == (len(args) == 2) {
+				return fmt.Errorf("specify note text or --clear")
+			}
+			var notes string
+			if !clearNotes {
+				notes = args[1]
+			}
+
+			acc, err := openQueueAccess()
+			if err != nil {
+				return err
+			}
+			result, err := acc.SetNotes(id, notes)
+			if err != nil {
+				return err
+			}
+			switch result {
+			case queueops.NotesResultUpdated:
+				if clearNotes {
+					fmt.Println(successStyle(fmt.Sprintf("Cleared notes for item %d", id)))
+				} else {
+					fmt.Println(successStyle(fmt.Sprintf("Updated notes for item %d", id)))
+				}
+			case queueops.NotesResultNotFound:
+				return fmt.Errorf("item %d not found", id)
+			case queueops.NotesResultTooLong:
+				return fmt.Errorf("notes exceed %d bytes", queueops.MaxNotesBytes)
+			default:
+				return fmt.Errorf("unexpected notes result: %s", result)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&clearNotes, "clear", false, "Remove the item's notes")
+	return cmd
+}
+
+func newQueueImportCmd() *cobra.Command {
+	var (
+		hints                         mkvimport.Hints
+		allowDuplicate                bool
+		skipSubtitles, skipCommentary bool
+		crf                           float64
+	)
+	cmd := &cobra.Command{
+		Use:   "import <dir>",
+		Short: "Queue a folder of already-ripped MKV files",
+		Long: `Queue a folder of already-ripped MKV files for processing without a disc.
+Each .mkv directly in the folder is probed for its duration and recorded as a
+ripped title; the item skips identification and ripping and continues with
+episode identification, encoding, audio analysis, subtitles, and organizing.
+Files are read in place and never moved or deleted.
+
+The title and year are inferred from the folder name ("Name (Year)") unless
+given, and matched against TMDB. With --tmdb-id, the ID is checked against
+TMDB instead of searching and --type is required. Movie imports use the
+longest file as the feature; TV imports treat every file as an episode of
+--season. With --episode, files are numbered from that episode in name
+order and episode identification is skipped.
+
+Files are identified by content, so importing the same files again, even
+renamed or from another folder, reports the existing queue item instead of
+queueing a duplicate; --allow-duplicate queues them anyway.`,
+		Example: `  spindle queue import "/media/rips/Heat (1995)"
+  spindle queue import /media/rips/show-s2 --type tv --title "The Show" --season 2
+  spindle queue import /media/rips/misc --type movie --tmdb-id 949
+  spindle queue import /media/rips/odd-names --type tv --tmdb-id 1396 --season 1 --episode 4`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			switch hints.MediaType {
+			case "", "movie", "tv":
+			default:
+				return fmt.Errorf("invalid --type %q (want movie or tv)", hints.MediaType)
+			}
+			if hints.TMDBID > 0 && hints.MediaType == "" {
+				return fmt.Errorf("--tmdb-id requires --type movie or tv")
+			}
+			if hints.Episode > 0 && hints.MediaType != "tv" {
+				return fmt.Errorf("--episode requires --type tv")
+			}
+			opts := ripspec.ItemOptions{SkipSubtitles: skipSubtitles, SkipCommentary: skipCommentary, EncodeCRF: crf}
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			logger := buildLogger()
+			dir := args[0]
+
+			files, err := mkvimport.Scan(ctx, dir)
+			if err != nil {
+				return err
+			}
+
+			// Folder-name inference only feeds the search; a TMDB ID
+			// supplies its own title and year.
+			if hints.TMDBID <= 0 {
+				inferredTitle, inferredYear := mkvimport.InferTitle(dir)
+				if hints.Title == "" {
+					hints.Title = inferredTitle
+				}
+				if hints.Year == "" {
+					hints.Year = inferredYear
+				}
+			}
+
+			clients, err := daemonrun.NewClients(cfg, logger)
+			if err != nil {
+				return err
+			}
+			if clients.TMDB == nil {
+				return fmt.Errorf("queue import resolves metadata through TMDB, which offline mode disables")
+			}
+			meta, err := mkvimport.Resolve(ctx, clients.TMDB, hints, logger)
+			if err != nil {
+				if hints.TMDBID <= 0 {
+					return fmt.Errorf("%w; pass --tmdb-id to skip the search", err)
+				}
+				return err
+			}
+
+			env, err := mkvimport.NewEnvelope(files, meta)
+			if err != nil {
+				return err
+			}
+			if hints.Episode > 0 {
+				season, err := clients.TMDB.GetSeason(ctx, env.Metadata.ID, env.Metadata.SeasonNumber)
+				if err != nil {
+					return fmt.Errorf("fetch TMDB season %d: %w", env.Metadata.SeasonNumber, err)
+				}
+				if err := mkvimport.SeedEpisodes(&env, hints.Episode, season); err != nil {
+					return err
+				}
+			}
+			env.Options = opts
+			ripSpecData, metaJSON, err := mkvimport.Encode(&env)
+			if err != nil {
+				return err
+			}
+
+			acc, err := openQueueAccess()
+			if err != nil {
+				return err
+			}
+			discTitle := env.Metadata.QueueTitle()
+			item, err := acc.EnqueueImport(queueaccess.EnqueueCachedRequest{
+				DiscTitle:      discTitle,
+				Fingerprint:    env.Fingerprint,
+				RipSpecData:    ripSpecData,
+				MetadataJSON:   metaJSON,
+				AllowDuplicate: allowDuplicate,
+			})
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Queued: %s (item %d, %d files, TMDB %d)\n", discTitle, item.ID, len(files), env.Metadata.ID)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&hints.MediaType, "type", "", "Content type: movie or tv (default: from TMDB match)")
+	cmd.Flags().StringVar(&hints.Title, "title", "", "Title to search for, or display title with --tmdb-id (default: folder name)")
+	cmd.Flags().StringVar(&hints.Year, "year", "", "Release year (default: from folder name)")
+	cmd.Flags().IntVar(&hints.Season, "season", 1, "Season number for TV imports")
+	cmd.Flags().IntVar(&hints.Episode, "episode", 0, "First episode number; files are numbered in name order")
+	cmd.Flags().IntVar(&hints.TMDBID, "tmdb-id", 0, "TMDB ID to use instead of searching")
+	cmd.Flags().BoolVar(&allowDuplicate, "allow-duplicate", false, "Allow multiple queue items with same fingerprint")
+	cmd.Flags().BoolVar(&skipSubtitles, "skip-subtitles", false, "Skip subtitle generation for this item")
+	cmd.Flags().BoolVar(&skipCommentary, "skip-commentary", false, "Skip commentary detection for this item")
+	cmd.Flags().Float64Var(&crf, "crf", 0, "Encode at this fixed CRF, 1-70, lower is higher quality (default: target quality)")
 	return cmd
 }
 
@@ -281,22 +611,52 @@ func newQueueClearCmd() *cobra.Command {
 
 func newQueueRetryCmd() *cobra.Command {
 	var episode string
+	var resume bool
 	cmd := &cobra.Command{
 		Use:   "retry [id...]",
 		Short: "Retry failed queue items",
+		Long: `Retry failed queue items from the stage that failed, reusing the files
+already recorded for them. When recorded files have gone missing, the item
+rewinds to the stage that rebuilds them.
+
+With --resume, ripped files that have gone missing are restored from the rip
+cache (after checking the cache entry is complete) instead of rewinding to
+ripping, so the item resumes where it failed and keeps its transcripts and
+encodes. When the cache cannot be used, a warning is printed and the item
+re-rips from disc.`,
 		Example: `  spindle queue retry                    # retry all failed items
   spindle queue retry 5                  # retry item 5
+  spindle queue retry 5 --resume         # retry item 5 from the rip cache
   spindle queue retry 5 --episode s01e05 # retry one episode of item 5`,
 		RunE: func(_ *cobra.Command, args []string) error {
 			if episode != "" && len(args) != 1 {
 				return fmt.Errorf("--episode requires exactly one item ID")
 			}
+			if episode != "" && resume {
+				return fmt.Errorf("--episode and --resume cannot be combined")
+			}
 
 			acc, err := openQueueAccess()
 			if err != nil {
 				return err
 			}
 
+			if resume {
+				ids, err := parseQueueIDs(args)
+				if err != nil {
+					return err
+				}
+				resumed, err := acc.RetryResume(ids...)
+				if err != nil {
+					return err
+				}
+				if len(resumed) == 0 && len(ids) > 0 {
+					return fmt.Errorf("no failed items were retried")
+				}
+				printResumed(os.Stdout, resumed)
+				return nil
+			}
+
 			if episode != "" {
 				id, err := parseQueueID(args[0])
 				if err != nil {
@@ -348,9 +708,28 @@ func newQueueRetryCmd() *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVarP(&episode, "episode", "e", "", "Retry only a specific episode (e.g., s01e05)")
+	cmd.Flags().BoolVar(&resume, "resume", false, "Restore missing rips from the rip cache instead of re-ripping")
 	return cmd
 }
 
+// printResumed reports where each resumed item re-entered the pipeline.
+func printResumed(w io.Writer, resumed []queueops.Resumed) {
+	for _, r := range resumed {
+		line := fmt.Sprintf("Item %d: resuming at %s", r.ID, r.Stage)
+		if r.Rewound() {
+			line += fmt.Sprintf(" instead of %s (%d recorded file(s) missing)", r.Failed, len(r.Missing))
+		}
+		if r.CacheRestored {
+			line += "; ripped files restored from the rip cache"
+		}
+		_, _ = fmt.Fprintln(w, successStyle(line))
+		if r.CacheError != "" {
+			_, _ = fmt.Fprintln(w, warnStyle(fmt.Sprintf("  warning: %s; the disc will be re-ripped", r.CacheError)))
+		}
+	}
+	_, _ = fmt.Fprintln(w, successStyle(fmt.Sprintf("Retried %d failed item(s)", len(resumed))))
+}
+
 func newQueueCancelCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "cancel <id...>",
@@ -367,10 +746,18 @@ func newQueueCancelCmd() *cobra.Command {
 				return err
 			}
 
-			if _, err := acc.Stop(ids...); err != nil {
+			updated, running, err := acc.Stop(ids...)
+			if err != nil {
 				return err
 			}
-			fmt.Println(successStyle(fmt.Sprintf("Canceled %d item(s); use 'spindle queue retry' to resume", len(ids))))
+			if updated == 0 {
+				return fmt.Errorf("no queue items were canceled")
+			}
+			msg := fmt.Sprintf("Canceled %d item(s); use 'spindle queue retry' to resume", updated)
+			if running > 0 {
+				msg += fmt.Sprintf(" (%d running stage(s) interrupted)", running)
+			}
+			fmt.Println(successStyle(msg))
 			return nil
 		},
 	}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/five82/spindle/internal/queueaccess"
+	"github.com/five82/spindle/internal/queueops"
+)
+
+func newReviewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "review",
+		Short: "List and decide items awaiting review",
+		Long: `List items the organizer routed to the review directory and decide them.
+Approving sends the item back to library import with the chosen TMDB match
+and episode mappings; rejecting fails it and leaves its files in review.`,
+		GroupID: groupQueue,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runReviewList()
+		},
+	}
+	cmd.AddCommand(
+		newReviewListCmd(),
+		newReviewApproveCmd(),
+		newReviewRejectCmd(),
+	)
+	return cmd
+}
+
+func newReviewListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List items awaiting review with their reasons and candidates",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runReviewList()
+		},
+	}
+}
+
+func runReviewList() error {
+	acc, err := openQueueAccess()
+	if err != nil {
+		return err
+	}
+	reviews, err := acc.Reviews()
+	if err != nil {
+		return err
+	}
+	if flagJSON {
+		return printJSONOutput(jsonKindReviews, reviews)
+	}
+	if len(reviews) == 0 {
+		fmt.Println("No items awaiting review")
+		return nil
+	}
+	for i, r := range reviews {
+		if i > 0 {
+			fmt.Println()
+		}
+		printReview(r)
+	}
+	return nil
+}
+
+func printReview(r queueaccess.Review) {
+	fmt.Printf("%s %s\n", labelStyle(fmt.Sprintf("[%d]", r.ID)), r.DiscTitle)
+	if len(r.ReviewReasons) > 0 {
+		fmt.Printf("  %s %s\n", labelStyle("Reasons:   "), strings.Join(r.ReviewReasons, "; "))
+	}
+	for _, c := range r.Candidates {
+		marker := " "
+		if c.Current {
+			marker = "*"
+		}
+		line := fmt.Sprintf("%s %-8d %s", marker, c.TMDBID, c.Title)
+		if c.Year != "" {
+			line += " (" + c.Year + ")"
+		}
+		line += " [" + c.MediaType + "]"
+		line += fmt.Sprintf(" score %.2f", c.Score)
+		if c.ExactMatch {
+			line += " exact"
+		}
+		if c.VoteCount > 0 {
+			line += fmt.Sprintf(" %.1f/%d votes", c.VoteAverage, c.VoteCount)
+		}
+		fmt.Printf("  %s %s\n", labelStyle("Candidate: "), line)
+	}
+	for _, ep := range r.Episodes {
+		mapping := "unresolved"
+		if ep.Episode > 0 {
+			mapping = fmt.Sprintf("S%02dE%02d", ep.Season, ep.Episode)
+			if ep.EpisodeEnd > ep.Episode {
+				mapping += fmt.Sprintf("-E%02d", ep.EpisodeEnd)
+			}
+		}
+		line := fmt.Sprintf("%-10s %-12s", ep.Key, mapping)
+		if ep.MatchConfidence > 0 {
+			line += fmt.Sprintf(" confidence %.2f", ep.MatchConfidence)
+		}
+		if ep.NeedsReview {
+			line += " " + warnStyle("review: "+ep.ReviewReason)
+		}
+		fmt.Printf("  %s %s\n", labelStyle("Episode:   "), line)
+		if flagVerbose && ep.FinalPath != "" {
+			fmt.Printf("  %s %s\n", labelStyle("           "), dimStyle(ep.FinalPath))
+		}
+	}
+}
+
+// episodeMappingPattern matches "s01e05" or "s01e05-e06" (also "s01e05e06").
+var episodeMappingPattern = regexp.MustCompile(`(?i)^s(\d{1,2})e(\d{1,3})(?:-?e(\d{1,3}))?$`)
+
+// parseEpisodeMappings parses --episode values of the form KEY=s01e05.
+func parseEpisodeMappings(values []string) (map[string]queueops.EpisodeMapping, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	out := make(map[string]queueops.EpisodeMapping, len(values))
+	for _, v := range values {
+		key, spec, ok := strings.Cut(v, "=")
+		key = strings.TrimSpace(key)
+		m := episodeMappingPattern.FindStringSubmatch(strings.TrimSpace(spec))
+		if !ok || key == "" || m == nil {
+			return nil, fmt.Errorf("invalid --episode %q (want KEY=s01e05 or KEY=s01e05-e06)", v)
+		}
+		season, _ := strconv.Atoi(m[1])
+		episode, _ := strconv.Atoi(m[2])
+		mapping := queueops.EpisodeMapping{Season: season, Episode: episode}
+		if m[3] != "" {
+			mapping.EpisodeEnd, _ = strconv.Atoi(m[3])
+		}
+		out[key] = mapping
+	}
+	return out, nil
+}
+
+func newReviewApproveCmd() *cobra.Command {
+	var tmdbID int
+	var episodes []string
+	var replace bool
+	cmd := &cobra.Command{
+		Use:   "approve <id>",
+		Short: "Approve a review item and send it to the library",
+		Long: `Approve a review item. With no flags the item's current match and episode
+mappings are accepted. --tmdb-id switches to one of the candidates listed by
+'spindle review'; --episode corrects an episode mapping (repeatable).
+--replace overwrites library files the item would duplicate; an item sent to
+review as already in the library otherwise returns to review.`,
+		Example: `  spindle review approve 5                          # accept as identified
+  spindle review approve 5 --tmdb-id 949            # use another candidate
+  spindle review approve 7 --episode s01_003=s01e03 # map an unresolved episode
+  spindle review approve 9 --replace                # replace the library copy`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			id, err := parseQueueID(args[0])
+			if err != nil {
+				return err
+			}
+			mappings, err := parseEpisodeMappings(episodes)
+			if err != nil {
+				return err
+			}
+			decision := queueops.ReviewDecision{
+				Action:      queueops.ReviewActionApprove,
+				CandidateID: tmdbID,
+				Episodes:    mappings,
+				Replace:     replace,
+			}
+			if len(mappings) > 0 {
+				decision.Action = queueops.ReviewActionOverride
+			}
+			return submitReviewDecision(id, decision)
+		},
+	}
+	cmd.Flags().IntVar(&tmdbID, "tmdb-id", 0, "Use this TMDB candidate instead of the current match")
+	cmd.Flags().StringArrayVarP(&episodes, "episode", "e", nil, "Map an episode key, e.g. s01_003=s01e03 (repeatable)")
+	cmd.Flags().BoolVar(&replace, "replace", false, "Overwrite files already in the library")
+	return cmd
+}
+
+func newReviewRejectCmd() *cobra.Command {
+	var note string
+	cmd := &cobra.Command{
+		Use:   "reject <id>",
+		Short: "Reject a review item, leaving its files in the review directory",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			id, err := parseQueueID(args[0])
+			if err != nil {
+				return err
+			}
+			return submitReviewDecision(id, queueops.ReviewDecision{Action: queueops.ReviewActionReject, Note: note})
+		},
+	}
+	cmd.Flags().StringVar(&note, "note", "", "Reason recorded on the failed item")
+	return cmd
+}
+
+func submitReviewDecision(id int64, d queueops.ReviewDecision) error {
+	acc, err := openQueueAccess()
+	if err != nil {
+		return err
+	}
+	result, err := acc.DecideReview(id, d)
+	if err != nil {
+		return err
+	}
+	switch result {
+	case queueops.ReviewResultApproved:
+		fmt.Println(successStyle(fmt.Sprintf("Approved item %d; queued for library import", id)))
+	case queueops.ReviewResultRejected:
+		fmt.Println(successStyle(fmt.Sprintf("Rejected item %d; files remain in the review directory", id)))
+	case queueops.ReviewResultNotFound:
+		return fmt.Errorf("item %d not found", id)
+	case queueops.ReviewResultNotPending:
+		return fmt.Errorf("item %d is not awaiting review", id)
+	case queueops.ReviewResultUnknownCandidate:
+		return fmt.Errorf("TMDB ID %d is not a candidate for item %d; see 'spindle review'", d.CandidateID, id)
+	case queueops.ReviewResultEpisodeNotFound:
+		return fmt.Errorf("an --episode key does not exist in item %d", id)
+	case queueops.ReviewResultUnidentified:
+		return fmt.Errorf("item %d has no TMDB match; pass --tmdb-id with a candidate", id)
+	case queueops.ReviewResultUnresolvedEpisodes:
+		return fmt.Errorf("item %d has unresolved episodes; map them with --episode", id)
+	case queueops.ReviewResultMissingFiles:
+		return fmt.Errorf("item %d has no review files to import", id)
+	case queueops.ReviewResultInvalid:
+		return fmt.Errorf("invalid review decision for item %d", id)
+	default:
+		return fmt.Errorf("unexpected review result: %s", result)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/five82/spindle/internal/queueops"
+)
+
+func TestParseEpisodeMappings(t *testing.T) {
+	got, err := parseEpisodeMappings([]string{"s01_003=s01e03", "s01_004=S01E04-E05"})
+	if err != nil {
+		t.Fatalf("parseEpisodeMappings: %v", err)
+	}
+	want := map[string]queueops.EpisodeMapping{
+		"s01_003": {Season: 1, Episode: 3},
+		"s01_004": {Season: 1, Episode: 4, EpisodeEnd: 5},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s = %+v, want %+v", k, got[k], v)
+		}
+	}
+
+	for _, bad := range []string{"s01e03", "=s01e03", "s01_003=e03", "s01_003=3"} {
+		if _, err := parseEpisodeMappings([]string{bad}); err == nil {
+			t.Errorf("parseEpisodeMappings(%q) succeeded, want error", bad)
+		}
+	}
+}
@@ -8,32 +8,45 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/five82/spindle/internal/apply"
+	"github.com/five82/spindle/internal/daemonrun"
 	"github.com/five82/spindle/internal/discidcache"
 	"github.com/five82/spindle/internal/discmonitor"
 	"github.com/five82/spindle/internal/fingerprint"
 	"github.com/five82/spindle/internal/identify"
-	"github.com/five82/spindle/internal/keydb"
-	"github.com/five82/spindle/internal/llm"
-	"github.com/five82/spindle/internal/notify"
 	"github.com/five82/spindle/internal/queue"
+	"github.com/five82/spindle/internal/ripspec"
 	"github.com/five82/spindle/internal/subtitle"
 	"github.com/five82/spindle/internal/tmdb"
 	"github.com/five82/spindle/internal/transcription"
 )
 
 func newIdentifyCmd() *cobra.Command {
+	var noTMDBCache bool
+	var batchDir string
+	var pin ripspec.ItemOptions
 	cmd := &cobra.Command{
 		Use:     "identify [device]",
 		Short:   "Identify a disc and show TMDB matching details",
-		Example: "  spindle disc identify          # use the configured optical drive\n  spindle disc identify /dev/sr1",
+		Example: "  spindle disc identify          # use the configured optical drive\n  spindle disc identify /dev/sr1\n  spindle disc identify --tmdb-id 949 --type movie  # identify as a pinned TMDB title\n  spindle disc identify --batch ~/rips  # identify every disc image in a folder",
 		Args:    cobra.MaximumNArgs(1),
 		RunE: func(_ *cobra.Command, args []string) error {
+			if err := pin.Validate(); err != nil {
+				return err
+			}
+			if batchDir != "" {
+				if len(args) > 0 || pin.PinnedTMDBID > 0 {
+					return fmt.Errorf("--batch takes no device or --tmdb-id")
+				}
+				return runBatchIdentify(batchDir, noTMDBCache)
+			}
 			var device string
 			if len(args) > 0 {
 				device = args[0]
@@ -72,30 +85,28 @@ func newIdentifyCmd() *cobra.Command {
 				}
 			}
 
-			// Open disc ID cache (optional).
-			discIDStore, cacheErr := discidcache.Open(cfg.DiscIDCachePath(), nil)
-			if cacheErr != nil {
-				logger.Debug("disc ID cache unavailable", "error", cacheErr)
+			if noTMDBCache {
+				ctx = tmdb.WithoutCache(ctx)
 			}
-
-			// Load KeyDB catalog (optional).
-			var keydbCat *keydb.Catalog
-			if cat, _, loadErr := keydb.LoadOrDownload(ctx, cfg.MakeMKV.KeyDBPath, cfg.MakeMKV.KeyDBDownloadURL,
-				cfg.MakeMKV.KeyDBTimeout(), logger); loadErr == nil {
-				keydbCat = cat
+			handler, err := newCLIIdentifyHandler(ctx, logger)
+			if err != nil {
+				return err
 			}
-
-			// Build TMDB client.
-			tmdbClient := tmdb.New(cfg.TMDB.APIKey, cfg.TMDB.BaseURL, cfg.TMDB.Language, nil)
-
-			// Construct the identification handler (nil for notifier).
-			handler := identify.New(cfg, tmdbClient, nil, discIDStore, keydbCat)
+			attachScanCache(handler, logger)
 
 			// Build a temporary queue item for identification.
 			item := &queue.Item{
 				DiscTitle:       discLabel,
 				DiscFingerprint: fp,
 			}
+			if pin.PinnedTMDBID > 0 {
+				env := ripspec.Envelope{Version: ripspec.CurrentVersion, Options: pin}
+				data, err := env.Encode()
+				if err != nil {
+					return err
+				}
+				item.RipSpecData = data
+			}
 
 			fmt.Printf("Scanning disc on %s...\n", device)
 			result, err := handler.Identify(ctx, item, logger)
@@ -187,9 +198,88 @@ func newIdentifyCmd() *cobra.Command {
 			return nil
 		},
 	}
+	cmd.Flags().BoolVar(&noTMDBCache, "no-tmdb-cache", false, "Query TMDB directly instead of using cached responses (fresh results are still cached)")
+	cmd.Flags().StringVar(&batchDir, "batch", "", "Identify every disc image (BDMV/VIDEO_TS folder or .iso) in this directory and print a summary; nothing is queued")
+	cmd.Flags().IntVar(&pin.PinnedTMDBID, "tmdb-id", 0, "Use this TMDB ID instead of searching, as a pinned queue item would")
+	cmd.Flags().StringVar(&pin.PinnedMediaType, "type", "", "TMDB type of --tmdb-id: movie or tv")
 	return cmd
 }
 
+// newCLIIdentifyHandler builds an identification handler the way the daemon
+// does, with the optional disc ID cache and KeyDB catalog and no notifier.
+func newCLIIdentifyHandler(ctx context.Context, logger *slog.Logger) (*identify.Handler, error) {
+	clients, err := daemonrun.NewClients(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	discIDStore, cacheErr := discidcache.Open(cfg.DiscIDCachePath(), nil)
+	if cacheErr != nil {
+		logger.Debug("disc ID cache unavailable", "error", cacheErr)
+	}
+	return identify.New(cfg, clients.TMDB, nil, discIDStore, daemonrun.LoadKeyDB(ctx, cfg, logger)), nil
+}
+
+// runBatchIdentify identifies each disc image in dir and prints one summary
+// row per image.
+func runBatchIdentify(dir string, noTMDBCache bool) error {
+	ctx := context.Background()
+	if noTMDBCache {
+		ctx = tmdb.WithoutCache(ctx)
+	}
+	logger := buildLogger()
+	images, err := identify.FindDiscImages(dir)
+	if err != nil {
+		return err
+	}
+	if len(images) == 0 {
+		return fmt.Errorf("no disc images (BDMV or VIDEO_TS folders, .iso files) in %s", dir)
+	}
+	fmt.Printf("Identifying %d disc image(s) in %s...\n", len(images), dir)
+	handler, err := newCLIIdentifyHandler(ctx, logger)
+	if err != nil {
+		return err
+	}
+	results, err := handler.IdentifyBatch(ctx, dir, logger)
+	if err != nil {
+		return err
+	}
+	printBatchIdentify(os.Stdout, results)
+	return nil
+}
+
+// printBatchIdentify writes the batch summary table: one row per image with
+// its match, TMDB ID, match score, and outcome, then the outcome totals.
+func printBatchIdentify(w io.Writer, results []identify.BatchResult) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "\nIMAGE\tSOURCE\tMATCH\tTYPE\tTMDB\tSCORE\tSTATUS")
+	counts := make(map[string]int)
+	for _, br := range results {
+		match, mediaType, tmdbID, score, status := "-", "-", "-", "-", "identified"
+		res := br.Result
+		switch {
+		case br.Err != nil:
+			status = "failed: " + truncate(br.Err.Error(), 60)
+		case res.Fatal:
+			status = "failed: " + truncate(res.FatalMsg, 60)
+		case res.Degraded:
+			status = "review"
+		}
+		if res != nil && res.Best != nil {
+			match = br.DiscTitle
+			mediaType = res.MediaType
+			tmdbID = strconv.Itoa(res.Best.ID)
+			if s, ok := res.BestScore(); ok {
+				score = fmt.Sprintf("%.2f", s)
+			}
+		}
+		counts[strings.SplitN(status, ":", 2)[0]]++
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", br.Image.Name, br.Image.Source, match, mediaType, tmdbID, score, status)
+	}
+	_ = tw.Flush()
+	_, _ = fmt.Fprintf(w, "\n%d image(s): %d identified, %d need review, %d failed\n",
+		len(results), counts["identified"], counts["review"], counts["failed"])
+}
+
 func newGensubtitleCmd() *cobra.Command {
 	var (
 		output   string
@@ -241,6 +331,8 @@ func newGensubtitleCmd() *cobra.Command {
 			svc := transcription.New(transcription.Params{
 				Model:       cfg.Subtitles.WhisperXModel,
 				CUDAEnabled: cfg.Subtitles.WhisperXCUDAEnabled,
+				ComputeType: cfg.Subtitles.WhisperXComputeType,
+				DeviceIndex: cfg.Subtitles.WhisperXDeviceIndex,
 				VADMethod:   cfg.Subtitles.WhisperXVADMethod,
 				HFToken:     cfg.Subtitles.WhisperXHFToken,
 			}, cmdLogger)
@@ -254,7 +346,11 @@ func newGensubtitleCmd() *cobra.Command {
 				fmt.Printf("  %s en\n", labelStyle("Language:"))
 			}
 
-			llmClient := llm.New(cfg.LLM, cmdLogger)
+			clients, err := daemonrun.NewClients(cfg, cmdLogger)
+			if err != nil {
+				return err
+			}
+			llmClient := clients.LLM
 			mediaContext := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
 
 			selectedLanguage := "en"
@@ -387,23 +483,15 @@ func formatContentDuration(secs float64) string {
 	return time.Duration(secs * float64(time.Second)).Truncate(time.Second).String()
 }
 
-func newTestNotifyCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "notify",
-		Short: "Send a test notification",
-		RunE: func(_ *cobra.Command, _ []string) error {
-			n := notify.New(cfg.Notifications.NtfyTopic, cfg.Notifications.RequestTimeout, nil)
-			if n == nil {
-				return fmt.Errorf("notifications not configured (no ntfy topic)")
-			}
-			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-			defer cancel()
-
-			if err := n.Send(ctx, notify.EventTest, "Spindle Test", "Test notification from Spindle"); err != nil {
-				return fmt.Errorf("send notification: %w", err)
-			}
-			fmt.Println(successStyle("Test notification sent"))
-			return nil
-		},
+// attachScanCache gives the CLI's identification handler the disc scan
+// cache when scan_cache is enabled.
+func attachScanCache(h *identify.Handler, logger *slog.Logger) {
+	if !cfg.ScanCache.Enabled {
+		return
+	}
+	if store, err := identify.OpenScanCache(cfg.ScanCachePath(), cfg.ScanCache.TTL()); err == nil {
+		h.SetScanCache(store)
+	} else {
+		logger.Debug("disc scan cache unavailable", "error", err)
 	}
 }
@@ -23,8 +23,7 @@ func newStagingCmd() *cobra.Command {
 }
 
 func newStagingListCmd() *cobra.Command {
-	var asJSON bool
-	cmd := &cobra.Command{
+	return &cobra.Command{
 		Use:   "list",
 		Short: "List staging directories",
 		RunE: func(_ *cobra.Command, _ []string) error {
@@ -33,8 +32,8 @@ func newStagingListCmd() *cobra.Command {
 				return err
 			}
 
-			if asJSON {
-				return printJSON(dirs)
+			if flagJSON {
+				return printJSONOutput(jsonKindStagingDirs, dirs)
 			}
 
 			if len(dirs) == 0 {
@@ -52,8 +51,6 @@ func newStagingListCmd() *cobra.Command {
 			return nil
 		},
 	}
-	cmd.Flags().BoolVar(&asJSON, "json", false, "Output directories as JSON")
-	return cmd
 }
 
 func newStagingCleanCmd() *cobra.Command {
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+)
+
+// jsonSchemaVersion versions the --json output envelope and the payloads it
+// carries. Adding a field keeps the version; removing or redefining one
+// bumps it.
+const jsonSchemaVersion = 1
+
+// JSON output kinds, one per command that honors --json. Each names the
+// payload type carried in data.
+const (
+	jsonKindStatus           = "status"            // httpapi.StatusAPIResponse
+	jsonKindQueueItems       = "queue_items"       // []httpapi.ItemResponse
+	jsonKindQueueItem        = "queue_item"        // httpapi.ItemResponse
+	jsonKindReviews          = "reviews"           // []httpapi.ReviewResponse
+	jsonKindDiscIDEntries    = "discid_entries"    // []discidcache.ListEntry
+	jsonKindCacheEntries     = "cache_entries"     // []ripcache.EntryMetadata
+	jsonKindStagingDirs      = "staging_dirs"      // []stagingdir.DirInfo
+	jsonKindCommentaryReport = "commentary_report" // audioanalysis.Report
+	jsonKindDiscReport       = "disc_report"       // makemkv.DiscReport
+)
+
+// jsonOutput is the envelope every --json command prints. Scripts check
+// schema_version and kind before reading data; list payloads are always
+// arrays, never null.
+type jsonOutput struct {
+	SchemaVersion int    `json:"schema_version"`
+	Kind          string `json:"kind"`
+	Data          any    `json:"data"`
+}
+
+// writeJSONOutput writes v wrapped in the versioned envelope, two-space
+// indented with a trailing newline.
+func writeJSONOutput(w io.Writer, kind string, v any) error {
+	data, err := json.MarshalIndent(jsonOutput{
+		SchemaVersion: jsonSchemaVersion,
+		Kind:          kind,
+		Data:          nonNilSlice(v),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+func printJSONOutput(kind string, v any) error {
+	return writeJSONOutput(os.Stdout, kind, v)
+}
+
+// nonNilSlice replaces a nil slice with an empty JSON array so empty
+// listings render as [] rather than null.
+func nonNilSlice(v any) any {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice && rv.IsNil() {
+		return []any{}
+	}
+	return v
+}
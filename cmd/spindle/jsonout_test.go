@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/five82/spindle/internal/audioanalysis"
+	"github.com/five82/spindle/internal/discidcache"
+	"github.com/five82/spindle/internal/httpapi"
+	"github.com/five82/spindle/internal/makemkv"
+	"github.com/five82/spindle/internal/ripcache"
+	"github.com/five82/spindle/internal/stagingdir"
+)
+
+// TestJSONOutputMatchesDTOs checks that each command's --json payload
+// decodes strictly back into the DTO its kind documents.
+func TestJSONOutputMatchesDTOs(t *testing.T) {
+	tests := []struct {
+		kind string
+		data any
+		into func() any
+	}{
+		{jsonKindStatus, httpapi.StatusAPIResponse{Running: true, PID: 42, Workflow: httpapi.WorkflowStatus{Running: true}},
+			func() any { return &httpapi.StatusAPIResponse{} }},
+		{jsonKindQueueItems, []httpapi.ItemResponse{{ID: 1, DiscTitle: "Movie", Stage: "encoding"}},
+			func() any { return &[]httpapi.ItemResponse{} }},
+		{jsonKindQueueItem, httpapi.ItemResponse{ID: 1, DiscTitle: "Movie", Stage: "encoding", Notes: "bad disc"},
+			func() any { return &httpapi.ItemResponse{} }},
+		{jsonKindReviews, []httpapi.ReviewResponse{{ItemResponse: httpapi.ItemResponse{ID: 3}}},
+			func() any { return &[]httpapi.ReviewResponse{} }},
+		{jsonKindDiscIDEntries, []discidcache.ListEntry{{DiscID: "abc", Entry: discidcache.Entry{Title: "Movie", TMDBID: 9}}},
+			func() any { return &[]discidcache.ListEntry{} }},
+		{jsonKindCacheEntries, []ripcache.EntryMetadata{{DiscTitle: "Movie", Fingerprint: "fp"}},
+			func() any { return &[]ripcache.EntryMetadata{} }},
+		{jsonKindStagingDirs, []stagingdir.DirInfo{{Name: "fp"}},
+			func() any { return &[]stagingdir.DirInfo{} }},
+		{jsonKindCommentaryReport, audioanalysis.Report{Path: "/rip.mkv", PrimaryIndex: 0},
+			func() any { return &audioanalysis.Report{} }},
+		{jsonKindDiscReport, makemkv.DiscReport{Name: "HEAT", Titles: []makemkv.TitleReport{{ID: 0, Audio: []makemkv.StreamReport{{Codec: "DTS-HD MA"}}}}},
+			func() any { return &makemkv.DiscReport{} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeJSONOutput(&buf, tt.kind, tt.data); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			var envelope struct {
+				SchemaVersion int             `json:"schema_version"`
+				Kind          string          `json:"kind"`
+				Data          json.RawMessage `json:"data"`
+			}
+			dec := json.NewDecoder(bytes.NewReader(buf.Bytes()))
+			dec.DisallowUnknownFields()
+			if err := dec.Decode(&envelope); err != nil {
+				t.Fatalf("decode envelope: %v\n%s", err, buf.String())
+			}
+			if envelope.SchemaVersion != jsonSchemaVersion || envelope.Kind != tt.kind {
+				t.Fatalf("envelope = v%d %q, want v%d %q", envelope.SchemaVersion, envelope.Kind, jsonSchemaVersion, tt.kind)
+			}
+			got := tt.into()
+			dec = json.NewDecoder(bytes.NewReader(envelope.Data))
+			dec.DisallowUnknownFields()
+			if err := dec.Decode(got); err != nil {
+				t.Fatalf("data does not match %T: %v", got, err)
+			}
+			if !reflect.DeepEqual(reflect.ValueOf(got).Elem().Interface(), tt.data) {
+				t.Fatalf("round trip = %+v, want %+v", got, tt.data)
+			}
+		})
+	}
+}
+
+func TestJSONOutputEmptyListIsArray(t *testing.T) {
+	var buf bytes.Buffer
+	var items []httpapi.ItemResponse
+	if err := writeJSONOutput(&buf, jsonKindQueueItems, items); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &envelope); err != nil {
+		t.Fatal(err)
+	}
+	if string(envelope.Data) != "[]" {
+		t.Fatalf("data = %s, want []", envelope.Data)
+	}
+}
@@ -25,6 +25,8 @@ var (
 	flagConfig   string
 	flagLogLevel string
 	flagVerbose  bool
+	flagOffline  bool
+	flagJSON     bool
 )
 
 // Command group IDs for --help organization.
@@ -56,6 +58,10 @@ First run: 'spindle config init' to generate a config, then 'spindle start'.`,
 			if flagVerbose {
 				flagLogLevel = "debug"
 			}
+			// Exported so a daemon spawned by start/restart inherits it.
+			if flagOffline {
+				_ = os.Setenv("SPINDLE_OFFLINE", "1")
+			}
 			// Commands annotated with skipConfigLoad don't need config.
 			if cmd.Annotations["skipConfigLoad"] == "true" {
 				return nil
@@ -77,6 +83,8 @@ First run: 'spindle config init' to generate a config, then 'spindle start'.`,
 	pf.StringVarP(&flagConfig, "config", "c", "", "Configuration file path")
 	pf.StringVar(&flagLogLevel, "log-level", "info", "Log level: debug, info, warn, error")
 	pf.BoolVarP(&flagVerbose, "verbose", "v", false, "Shorthand for --log-level=debug")
+	pf.BoolVar(&flagOffline, "offline", false, "Disable all external service calls (TMDB, LLM, OpenSubtitles, Jellyfin, ntfy)")
+	pf.BoolVar(&flagJSON, "json", false, "Print versioned JSON instead of formatted text (listing and status commands)")
 
 	// Command groups organize --help output.
 	root.AddGroup(
@@ -94,6 +102,7 @@ First run: 'spindle config init' to generate a config, then 'spindle start'.`,
 		newRestartCmd(),
 		newStatusCmd(),
 		newQueueCmd(),
+		newReviewCmd(),
 		newLogsCmd(),
 		newDiscCmd(),
 		newCacheCmd(),
@@ -101,6 +110,8 @@ First run: 'spindle config init' to generate a config, then 'spindle start'.`,
 		newStagingCmd(),
 		newDiscIDCmd(),
 		newDebugCmd(),
+		newDoctorCmd(),
+		newNotifyCmd(),
 		newDaemonCmd(),
 		newEncodeWorkerCmd(),
 	)
@@ -132,7 +143,7 @@ func lockPath() string {
 
 // openQueueAccess opens daemon HTTP queue access.
 func openQueueAccess() (*queueaccess.HTTPAccess, error) {
-	return queueaccess.OpenHTTP(socketPath(), cfg.API.Token)
+	return queueaccess.OpenHTTP(socketPath(), lockPath(), cfg.API.Token, cfg.API.ReconnectDeadline())
 }
 
 // buildLogger creates a structured logger from the global log level flag.
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/five82/spindle/internal/queue"
+	"github.com/five82/spindle/internal/queueaccess"
+)
+
+// clearScreen moves the cursor home and clears the terminal before each
+// dashboard redraw.
+const clearScreen = "\033[H\033[2J"
+
+const progressBarWidth = 20
+
+// watchStatus fetches a snapshot every interval and redraws the dashboard
+// on w until ctx is cancelled. A failed fetch is drawn in place of the
+// dashboard and retried on the next tick, so the view survives a daemon
+// restart.
+func watchStatus(ctx context.Context, w io.Writer, interval time.Duration, fetch func() (*queueaccess.Snapshot, error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		snap, err := fetch()
+		var buf bytes.Buffer
+		buf.WriteString(clearScreen)
+		renderDashboard(&buf, snap, err, time.Now(), interval)
+		if _, werr := w.Write(buf.Bytes()); werr != nil {
+			return werr
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// renderDashboard writes the compact watch view: dependency health, queue
+// depth by stage, and a progress bar per running task.
+func renderDashboard(w io.Writer, snap *queueaccess.Snapshot, err error, now time.Time, interval time.Duration) {
+	_, _ = fmt.Fprintf(w, "%s  %s\n\n", headerStyle("Spindle Status"),
+		dimStyle(fmt.Sprintf("%s · every %s · Ctrl-C to exit", now.Format("15:04:05"), interval)))
+	if err != nil {
+		_, _ = fmt.Fprintf(w, "  %s %v\n", failStyle("Daemon unreachable:"), err)
+		return
+	}
+	status := snap.Status
+
+	var deps []string
+	for _, d := range status.Dependencies {
+		mark := successStyle("✓")
+		if !d.Available {
+			mark = failStyle("✗")
+		}
+		deps = append(deps, d.Name+" "+mark)
+	}
+	if len(deps) > 0 {
+		_, _ = fmt.Fprintf(w, "  %-10s %s\n", labelStyle("Deps"), strings.Join(deps, "  "))
+	}
+
+	depth := 0
+	var counts []string
+	for _, stage := range statusStages {
+		n := status.Workflow.QueueStats[stage]
+		if n == 0 {
+			continue
+		}
+		if stage != queue.StageCompleted && stage != queue.StageFailed {
+			depth += n
+		}
+		counts = append(counts, fmt.Sprintf("%s %d", stage, n))
+	}
+	_, _ = fmt.Fprintf(w, "  %-10s %d", labelStyle("Queue"), depth)
+	if len(counts) > 0 {
+		_, _ = fmt.Fprintf(w, "  %s", dimStyle(strings.Join(counts, " · ")))
+	}
+	_, _ = fmt.Fprintln(w)
+	if status.Workflow.LastError != "" {
+		_, _ = fmt.Fprintf(w, "  %-10s %s\n", failStyle("Error"), truncate(status.Workflow.LastError, 70))
+	}
+
+	_, _ = fmt.Fprintln(w)
+	if snap.ItemsErr != nil {
+		_, _ = fmt.Fprintf(w, "  %s %v\n", failStyle("Items unavailable:"), snap.ItemsErr)
+		return
+	}
+	active := 0
+	for _, item := range snap.Items {
+		if !item.InProgress {
+			continue
+		}
+		active++
+		_, _ = fmt.Fprintf(w, "  %s #%d %s %s\n", labelStyle("Active"), item.ID, truncate(item.DiscTitle, 40), dimStyle(item.Stage))
+		for _, t := range item.Tasks {
+			if queue.TaskState(t.State) != queue.TaskRunning {
+				continue
+			}
+			line := fmt.Sprintf("    %-22s %s %3.0f%%", t.Type, progressBar(t.Progress.Percent), t.Progress.Percent)
+			if t.Progress.ETASeconds > 0 {
+				line += " ETA " + time.Duration(t.Progress.ETASeconds*float64(time.Second)).Round(time.Second).String()
+			}
+			if t.Progress.Message != "" {
+				line += "  " + dimStyle(truncate(t.Progress.Message, 40))
+			}
+			_, _ = fmt.Fprintln(w, line)
+		}
+	}
+	if active == 0 {
+		_, _ = fmt.Fprintf(w, "  %s\n", dimStyle("Idle"))
+	}
+}
+
+// progressBar renders percent as a fixed-width bar.
+func progressBar(percent float64) string {
+	filled := int(min(max(percent, 0), 100) / 100 * progressBarWidth)
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", progressBarWidth-filled) + "]"
+}
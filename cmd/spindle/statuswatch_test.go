@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/five82/spindle/internal/httpapi"
+	"github.com/five82/spindle/internal/queue"
+	"github.com/five82/spindle/internal/queueaccess"
+)
+
+func watchSnapshot() *queueaccess.Snapshot {
+	return &queueaccess.Snapshot{
+		Status: &queueaccess.Status{
+			Running: true,
+			Workflow: queueaccess.WorkflowStatus{QueueStats: map[queue.Stage]int{
+				queue.StageRipping:   1,
+				queue.StageEncoding:  2,
+				queue.StageCompleted: 5,
+			}},
+			Dependencies: []queueaccess.DependencyStatus{{Name: "makemkvcon", Available: true}},
+		},
+		Items: []queueaccess.Item{{
+			ID: 7, DiscTitle: "Heat", Stage: "ripping", InProgress: true,
+			Tasks: []httpapi.TaskResponse{{
+				Type: "ripping", State: string(queue.TaskRunning),
+				Progress: httpapi.ProgressResponse{Percent: 50, Message: "Ripping title 1", ETASeconds: 90},
+			}},
+		}},
+	}
+}
+
+func TestRenderDashboard(t *testing.T) {
+	var buf bytes.Buffer
+	renderDashboard(&buf, watchSnapshot(), nil, time.Date(2026, 1, 1, 9, 30, 0, 0, time.UTC), 2*time.Second)
+	out := buf.String()
+	for _, want := range []string{
+		"09:30:00",
+		"makemkvcon ✓",
+		"Queue", " 3 ", // ripping + encoding; completed is not queue depth
+		"#7 Heat",
+		"[██████████░░░░░░░░░░]  50% ETA 1m30s",
+		"Ripping title 1",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dashboard missing %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	renderDashboard(&buf, nil, errors.New("connection refused"), time.Now(), time.Second)
+	if !strings.Contains(buf.String(), "Daemon unreachable: connection refused") {
+		t.Errorf("fetch error not rendered:\n%s", buf.String())
+	}
+}
+
+func TestWatchStatusRefreshesUntilCancelled(t *testing.T) {
+	before := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fetches := 0
+	fetch := func() (*queueaccess.Snapshot, error) {
+		fetches++
+		if fetches == 2 {
+			return nil, errors.New("daemon restarting")
+		}
+		if fetches == 4 {
+			cancel()
+		}
+		return watchSnapshot(), nil
+	}
+	var buf bytes.Buffer
+	if err := watchStatus(ctx, &buf, time.Millisecond, fetch); err != nil {
+		t.Fatalf("watchStatus: %v", err)
+	}
+
+	if fetches != 4 {
+		t.Fatalf("fetched %d times, want 4", fetches)
+	}
+	frames := strings.Split(buf.String(), clearScreen)[1:]
+	if len(frames) != 4 {
+		t.Fatalf("rendered %d frames, want one per fetch", len(frames))
+	}
+	if !strings.Contains(frames[1], "daemon restarting") || !strings.Contains(frames[2], "#7 Heat") {
+		t.Fatalf("a failed fetch should be drawn and the next one recover:\n%s", buf.String())
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Fatalf("goroutines after watch = %d, before = %d", n, before)
+	}
+}
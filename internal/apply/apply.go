@@ -5,6 +5,7 @@ package apply
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"strings"
 
@@ -28,6 +29,11 @@ func New(cfg *config.Config) *Handler {
 	return &Handler{cfg: cfg}
 }
 
+// audioKeepRule returns the configured audio keep rule.
+func (h *Handler) audioKeepRule() audioKeepRule {
+	return audioKeepRule{mode: h.cfg.Encoding.AudioKeep, languages: h.cfg.Encoding.AudioKeepLanguages}
+}
+
 // Run executes the apply stage.
 func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 	logger := sess.Logger
@@ -60,25 +66,30 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 	_ = sess.Progress(10, "Phase 1/3 - Audio refinement")
 	logger.Info("Phase 1/3 - Audio refinement")
 	var aggregateComms []ripspec.CommentaryTrackRef
+	var aggregateDescribed []ripspec.AudioDescriptionTrackRef
 	for i, in := range inputs {
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
 		var comms []ripspec.CommentaryTrackRef
+		var described []ripspec.AudioDescriptionTrackRef
 		epAnalysis := analysisData.EpisodeAnalysis(in.key)
 		if epAnalysis != nil {
-			comms = epAnalysis.CommentaryTracks
+			comms, described = epAnalysis.CommentaryTracks, epAnalysis.AudioDescriptionTracks
 		} else if len(analysisData.PerEpisode) == 0 {
 			// No per-episode data (single-file movies recorded pre-split, or
 			// commentary disabled): fall back to the aggregate list.
-			comms = analysisData.CommentaryTracks
+			comms, described = analysisData.CommentaryTracks, analysisData.AudioDescriptionTracks
 		}
 		var keep []int
 		for _, c := range comms {
 			keep = append(keep, c.Index)
 		}
+		for _, d := range described {
+			keep = append(keep, d.Index)
+		}
 
-		refinement, refErr := refineAudioTargets(ctx, logger, []string{in.path}, keep)
+		refinement, refErr := refineAudioTargets(ctx, logger, []string{in.path}, keep, h.audioKeepRule())
 		if refErr != nil {
 			logger.Warn("audio refinement failed",
 				"event_type", "audio_refinement_error",
@@ -89,13 +100,17 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 			refinement = nil
 		}
 
-		primary, primaryLabel, remapped, err := applyPostRefinementAudio(ctx, logger, in.path, refinement, comms)
+		primary, primaryLabel, remapped, described, err := applyPostRefinementAudio(ctx, logger, in.path, refinement, comms, described)
 		if err != nil {
 			return err
 		}
 		if epAnalysis != nil {
 			epAnalysis.CommentaryTracks = remapped
+			epAnalysis.AudioDescriptionTracks = described
 		}
+		aggregateDescribed = append(aggregateDescribed, described...)
+		h.normalizeAudio(ctx, logger, analysisData, in.key, in.path, primary, remapped)
+		applyChapterNames(ctx, logger, env, in.key, in.path)
 		aggregateComms = append(aggregateComms, remapped...)
 		if i == 0 {
 			analysisData.PrimaryTrack = primary
@@ -108,6 +123,7 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 		}
 	}
 	analysisData.CommentaryTracks = aggregateComms
+	analysisData.AudioDescriptionTracks = aggregateDescribed
 
 	// Phase 2: duration validation across all encoded outputs.
 	_ = sess.Progress(45, "Phase 2/3 - Audio validation")
@@ -169,6 +185,85 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 	return nil
 }
 
+// normalizeAudio applies loudness normalization to the primary track and
+// commentary format normalization to the commentary tracks. Failures leave
+// the audio as encoded: both are preferences, not reasons to fail the item.
+func (h *Handler) normalizeAudio(
+	ctx context.Context,
+	logger *slog.Logger,
+	analysisData *ripspec.AudioAnalysisData,
+	key string,
+	path string,
+	primary ripspec.AudioTrackRef,
+	comms []ripspec.CommentaryTrackRef,
+) {
+	h.applyLoudnorm(ctx, logger, analysisData, key, path, primary)
+
+	format, formatOn := commentaryFormatFromConfig(h.cfg.Encoding)
+	if !formatOn || hasCommentaryFormatRecord(analysisData, key) {
+		return
+	}
+	pending, err := planCommentaryFormat(ctx, path, key, format, primary, comms)
+	if err != nil {
+		logger.Warn("commentary format planning failed",
+			"event_type", "commentary_format_error",
+			"error_hint", err.Error(),
+			"impact", "commentary tracks keep their encoded format",
+			"episode_key", key,
+		)
+		return
+	}
+	if len(pending) == 0 {
+		return
+	}
+	if err := convertCommentaryFormat(ctx, path, format, pending); err != nil {
+		logger.Warn("commentary format conversion failed",
+			"event_type", "commentary_format_error",
+			"error_hint", err.Error(),
+			"impact", "commentary tracks keep their encoded format",
+			"episode_key", key,
+		)
+		return
+	}
+	logCommentaryFormat(logger, format, pending)
+	analysisData.CommentaryFormat = append(analysisData.CommentaryFormat, pending...)
+}
+
+// applyLoudnorm normalizes the episode's primary audio track to the EBU R128
+// target and records the result. Commentary tracks are left alone.
+func (h *Handler) applyLoudnorm(
+	ctx context.Context,
+	logger *slog.Logger,
+	analysisData *ripspec.AudioAnalysisData,
+	key string,
+	path string,
+	primary ripspec.AudioTrackRef,
+) {
+	if !h.cfg.Encoding.Loudnorm {
+		return
+	}
+	if hasLoudnessRecord(analysisData, key) {
+		logger.Info("loudness normalization skipped",
+			"decision_type", logs.DecisionLoudnessNormalization,
+			"decision_result", "skipped",
+			"decision_reason", "episode already normalized",
+			"episode_key", key,
+		)
+		return
+	}
+	records, err := normalizeLoudness(ctx, logger, path, key, ebuR128Target, primary.Index)
+	if err != nil {
+		logger.Warn("loudness normalization failed",
+			"event_type", "loudnorm_error",
+			"error_hint", err.Error(),
+			"impact", "audio keeps its original loudness",
+			"episode_key", key,
+		)
+		return
+	}
+	analysisData.Loudness = append(analysisData.Loudness, records...)
+}
+
 // applySubtitles places the episode's generated SRT next to the encoded
 // file and muxes it when configured, recording the subtitled asset. A
 // missing or severe-issue generation record means the episode has no
@@ -198,7 +293,7 @@ func (h *Handler) applySubtitles(ctx context.Context, sess *stage.Session, key,
 
 	// Place the sidecar next to the encoded file so the organizer's sidecar
 	// glob finds it (and Jellyfin when muxing is disabled).
-	sidecarPath := DisplaySubtitlePath(encodedPath, record.Language)
+	sidecarPath := SidecarSubtitlePath(encodedPath, record.Language, record.HearingImpaired)
 	if err := fileutil.CopyFile(record.SubtitlePath, sidecarPath); err != nil {
 		return fmt.Errorf("place subtitle sidecar %s: %w", key, err)
 	}
@@ -206,7 +301,9 @@ func (h *Handler) applySubtitles(ctx context.Context, sess *stage.Session, key,
 	subtitledPath := encodedPath
 	subtitlesMuxed := false
 	if h.cfg.Subtitles.MuxIntoMKV {
-		muxedPath, err := muxDisplaySubtitle(ctx, logger, encodedPath, sidecarPath, key, record.Language)
+		muxedPath, err := muxDisplaySubtitle(ctx, logger, encodedPath, key, MuxTrack{
+			Path: sidecarPath, Language: record.Language, HearingImpaired: record.HearingImpaired,
+		})
 		if err != nil {
 			logger.Warn("subtitle mux failed",
 				"event_type", "mux_error",
@@ -234,6 +331,36 @@ func (h *Handler) applySubtitles(ctx context.Context, sess *stage.Session, key,
 	})
 }
 
+// applyChapterNames imports the ripped source's chapter names into the
+// encoded file and records on the encoded asset whether it carries them.
+// Failures leave the encode's numbered chapters: names are a nicety, not a
+// reason to fail the item.
+func applyChapterNames(ctx context.Context, logger *slog.Logger, env *ripspec.Envelope, key, encodedPath string) {
+	encoded, ok := env.Assets.FindAsset(ripspec.AssetKindEncoded, key)
+	if !ok {
+		return
+	}
+	named := false
+	ripped, ok := env.Assets.FindAsset(ripspec.AssetKindRipped, key)
+	if _, err := os.Stat(ripped.Path); !ok || !ripped.IsCompleted() || err != nil {
+		logger.Info("chapter names not imported",
+			"decision_type", logs.DecisionChapterNames,
+			"decision_result", "numeric",
+			"decision_reason", "ripped source unavailable",
+			"episode_key", key,
+		)
+	} else if named, err = importChapterNames(ctx, logger, key, ripped.Path, encodedPath); err != nil {
+		logger.Warn("chapter name import failed",
+			"event_type", "chapter_import_error",
+			"error_hint", err.Error(),
+			"impact", "encoded file keeps numbered chapters",
+			"episode_key", key,
+		)
+	}
+	encoded.NamedChapters = named
+	env.Assets.AddAsset(ripspec.AssetKindEncoded, encoded)
+}
+
 // findSubtitleGenRecord returns the generation record for key, or nil.
 func findSubtitleGenRecord(env *ripspec.Envelope, key string) *ripspec.SubtitleGenRecord {
 	records := env.Attributes.SubtitleGenerationResults
@@ -11,20 +11,23 @@ import (
 	"github.com/five82/spindle/internal/ripspec"
 )
 
+var probePostRefinement = ffprobe.Inspect
+
 // applyPostRefinementAudio selects the primary audio track after refinement,
-// remaps commentary indices, and applies commentary metadata. Disposition and
-// validation failures are degraded because preserving an unlabeled track is
-// safer than dropping it.
+// remaps commentary and retained audio-description indices, and labels both
+// in one disposition remux. Disposition and validation failures are degraded
+// because preserving an unlabeled track is safer than dropping it.
 func applyPostRefinementAudio(
 	ctx context.Context,
 	logger *slog.Logger,
 	path string,
 	refinement *audioRefinementResult,
 	comms []ripspec.CommentaryTrackRef,
-) (ripspec.AudioTrackRef, string, []ripspec.CommentaryTrackRef, error) {
-	result, err := ffprobe.Inspect(ctx, "", path)
+	described []ripspec.AudioDescriptionTrackRef,
+) (ripspec.AudioTrackRef, string, []ripspec.CommentaryTrackRef, []ripspec.AudioDescriptionTrackRef, error) {
+	result, err := probePostRefinement(ctx, "", path)
 	if err != nil {
-		return ripspec.AudioTrackRef{}, "", nil, fmt.Errorf("ffprobe post-refinement %s: %w", path, err)
+		return ripspec.AudioTrackRef{}, "", nil, nil, fmt.Errorf("ffprobe post-refinement %s: %w", path, err)
 	}
 
 	selection := audio.Select(result.Streams, logger)
@@ -36,39 +39,43 @@ func applyPostRefinementAudio(
 		"decision_reason", fmt.Sprintf("score-based selection from %d tracks", result.AudioStreamCount()),
 	)
 
-	remapped := comms
-	if len(comms) > 0 && refinement != nil {
+	if refinement == nil {
+		return primary, selection.PrimaryLabel(), comms, described, nil
+	}
+	var remapped []ripspec.CommentaryTrackRef
+	if len(comms) > 0 {
 		remapped = remapCommentaryIndices(logger, comms, refinement.KeptIndices)
-		if len(remapped) > 0 {
-			audioStreams := result.AudioStreams()
-			var targets []commentaryTarget
-			for _, r := range remapped {
-				var title string
-				if r.Index < len(audioStreams) {
-					title = audioStreams[r.Index].Tags["title"]
-				}
-				targets = append(targets, commentaryTarget{Index: r.Index, Title: title})
-			}
-			if err := applyCommentaryDisposition(ctx, logger, path, targets); err != nil {
-				logger.Warn("commentary disposition failed",
-					"event_type", "commentary_disposition_error",
-					"error_hint", err.Error(),
-					"impact", "commentary tracks not labeled",
-				)
-			} else {
-				var remappedIndices []int
-				for _, t := range targets {
-					remappedIndices = append(remappedIndices, t.Index)
-				}
-				if err := validateCommentaryLabeling(ctx, logger, path, remappedIndices); err != nil {
-					logger.Warn("commentary labeling validation failed",
-						"event_type", "commentary_validation_error",
-						"error_hint", err.Error(),
-						"impact", "commentary labels may be incorrect",
-					)
-				}
-			}
+	}
+	remappedDescribed := remapAudioDescriptionIndices(described, refinement.KeptIndices)
+
+	audioStreams := result.AudioStreams()
+	streamTitle := func(idx int) string {
+		if idx < len(audioStreams) {
+			return audioStreams[idx].Tags["title"]
 		}
+		return ""
+	}
+	var targets []dispositionTarget
+	var commentaryIndices []int
+	for _, r := range remapped {
+		targets = append(targets, dispositionTarget{Index: r.Index, Disposition: dispositionCommentary, Title: commentaryLabel(streamTitle(r.Index))})
+		commentaryIndices = append(commentaryIndices, r.Index)
+	}
+	for _, r := range remappedDescribed {
+		targets = append(targets, dispositionTarget{Index: r.Index, Disposition: dispositionAudioDescription, Title: audioDescriptionLabel(streamTitle(r.Index))})
+	}
+	if err := applyAudioDisposition(ctx, logger, path, targets); err != nil {
+		logger.Warn("audio disposition failed",
+			"event_type", "audio_disposition_error",
+			"error_hint", err.Error(),
+			"impact", "commentary and audio description tracks not labeled",
+		)
+	} else if err := validateCommentaryLabeling(ctx, logger, path, commentaryIndices); err != nil {
+		logger.Warn("commentary labeling validation failed",
+			"event_type", "commentary_validation_error",
+			"error_hint", err.Error(),
+			"impact", "commentary labels may be incorrect",
+		)
 	}
-	return primary, selection.PrimaryLabel(), remapped, nil
+	return primary, selection.PrimaryLabel(), remapped, remappedDescribed, nil
 }
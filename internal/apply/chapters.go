@@ -0,0 +1,141 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/five82/spindle/internal/logs"
+	"github.com/five82/spindle/internal/media/ffprobe"
+)
+
+var probeChapterInput = ffprobe.Inspect
+
+// genericChapterTitle matches the placeholder names authoring tools and
+// MakeMKV give unnamed chapters: bare numbers, "Chapter 01" and its common
+// translations, and timestamps.
+var genericChapterTitle = regexp.MustCompile(
+	`(?i)^((chapter|chap\.?|ch\.?|kapitel|chapitre|cap[ií]tulo|capitolo|hoofdstuk)\s*#?\s*)?\d+$` +
+		`|^\d{1,2}:\d{2}(:\d{2})?([.,]\d+)?$`)
+
+// namedChapters reports whether any chapter carries a real name rather than
+// a numbered or timestamp placeholder.
+func namedChapters(chapters []ffprobe.Chapter) bool {
+	for _, ch := range chapters {
+		if title := ch.Title(); title != "" && !genericChapterTitle.MatchString(title) {
+			return true
+		}
+	}
+	return false
+}
+
+// chapterTitlesMatch reports whether got carries want's chapter names in
+// order.
+func chapterTitlesMatch(got, want []ffprobe.Chapter) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range want {
+		if got[i].Title() != want[i].Title() {
+			return false
+		}
+	}
+	return true
+}
+
+// ffmetadataEscaper escapes the characters the ffmetadata format treats as
+// syntax.
+var ffmetadataEscaper = strings.NewReplacer(`\`, `\\`, "=", `\=`, ";", `\;`, "#", `\#`, "\n", "\\\n")
+
+// buildChapterMetadata renders chapters as an ffmetadata file in
+// millisecond units. An untitled chapter keeps its number as its name.
+func buildChapterMetadata(chapters []ffprobe.Chapter) string {
+	var b strings.Builder
+	b.WriteString(";FFMETADATA1\n")
+	for i, ch := range chapters {
+		title := ch.Title()
+		if title == "" {
+			title = fmt.Sprintf("Chapter %02d", i+1)
+		}
+		fmt.Fprintf(&b, "[CHAPTER]\nTIMEBASE=1/1000\nSTART=%d\nEND=%d\ntitle=%s\n",
+			int64(ch.StartSeconds()*1000+0.5), int64(ch.EndSeconds()*1000+0.5), ffmetadataEscaper.Replace(title))
+	}
+	return b.String()
+}
+
+// buildChapterImportArgs stream-copies path into tmpPath with the chapters
+// from metaPath replacing its own. Everything else, global tags included,
+// comes from path.
+func buildChapterImportArgs(path, metaPath, tmpPath string) []string {
+	args := []string{"-y", "-hide_banner", "-loglevel", "error",
+		"-i", path, "-f", "ffmetadata", "-i", metaPath,
+		"-map", "0", "-map_metadata", "0", "-map_chapters", "1", "-c", "copy"}
+	if strings.EqualFold(filepath.Ext(tmpPath), ".mp4") {
+		args = append(args, "-movflags", "+faststart")
+	}
+	return append(args, tmpPath)
+}
+
+// importChapterNames carries the named chapters of the ripped source into
+// the encoded file, rewriting it in place. It reports whether the encoded
+// file ends up with the source's names. A source whose chapters are only
+// numbered leaves the encode's chapters untouched.
+func importChapterNames(ctx context.Context, logger *slog.Logger, key, sourcePath, encodedPath string) (bool, error) {
+	source, err := probeChapterInput(ctx, "", sourcePath)
+	if err != nil {
+		return false, fmt.Errorf("probe source chapters: %w", err)
+	}
+	if !namedChapters(source.Chapters) {
+		logger.Info("chapter names not imported",
+			"decision_type", logs.DecisionChapterNames,
+			"decision_result", "numeric",
+			"decision_reason", "source has no named chapters",
+			"episode_key", key,
+			"source_chapters", len(source.Chapters),
+		)
+		return false, nil
+	}
+	encoded, err := probeChapterInput(ctx, "", encodedPath)
+	if err != nil {
+		return false, fmt.Errorf("probe encoded chapters: %w", err)
+	}
+	if chapterTitlesMatch(encoded.Chapters, source.Chapters) {
+		logger.Info("chapter names already present",
+			"decision_type", logs.DecisionChapterNames,
+			"decision_result", "named",
+			"decision_reason", "encoded file carries the source chapter names",
+			"episode_key", key,
+			"chapters", len(source.Chapters),
+		)
+		return true, nil
+	}
+
+	dir, base := filepath.Dir(encodedPath), filepath.Base(encodedPath)
+	metaPath := filepath.Join(dir, ".chapters-"+strings.TrimSuffix(base, filepath.Ext(base))+".txt")
+	tmpPath := filepath.Join(dir, ".chapters-"+base)
+	if err := os.WriteFile(metaPath, []byte(buildChapterMetadata(source.Chapters)), 0o644); err != nil {
+		return false, fmt.Errorf("write chapter metadata: %w", err)
+	}
+	defer func() { _ = os.Remove(metaPath) }()
+	if output, err := runFFmpeg(ctx, buildChapterImportArgs(encodedPath, metaPath, tmpPath)); err != nil {
+		_ = os.Remove(tmpPath)
+		return false, fmt.Errorf("import chapters: %w: %s", err, output)
+	}
+	if err := os.Rename(tmpPath, encodedPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return false, fmt.Errorf("replace encoded file: %w", err)
+	}
+	logger.Info("chapter names imported",
+		"decision_type", logs.DecisionChapterNames,
+		"decision_result", "named",
+		"decision_reason", "source chapters carry names the encode lacked",
+		"episode_key", key,
+		"chapters", len(source.Chapters),
+		"encoded_chapters", len(encoded.Chapters),
+	)
+	return true, nil
+}
@@ -0,0 +1,119 @@
+package apply
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/five82/spindle/internal/media/ffprobe"
+	"github.com/five82/spindle/internal/ripspec"
+)
+
+// chapters builds up to three consecutive chapters with the given titles;
+// an empty title leaves the chapter untagged.
+func chapters(titles ...string) []ffprobe.Chapter {
+	bounds := []string{"0.000000", "300.500000", "900.000000", "1500.000000"}
+	out := make([]ffprobe.Chapter, len(titles))
+	for i, title := range titles {
+		out[i] = ffprobe.Chapter{ID: int64(i), StartTime: bounds[i], EndTime: bounds[i+1]}
+		if title != "" {
+			out[i].Tags = map[string]string{"title": title}
+		}
+	}
+	return out
+}
+
+func TestNamedChaptersIgnoresPlaceholders(t *testing.T) {
+	for _, placeholder := range [][]string{
+		{"Chapter 01", "Chapter 02"},
+		{"1", "2"},
+		{"Kapitel 1", "Chapitre 2"},
+		{"00:00:00.000", "00:05:00.500"},
+		{"", ""},
+	} {
+		if namedChapters(chapters(placeholder...)) {
+			t.Errorf("namedChapters(%q) = true, want false", placeholder)
+		}
+	}
+	if !namedChapters(chapters("Chapter 01", "The Bank Job")) {
+		t.Error("namedChapters with a real title = false, want true")
+	}
+}
+
+func TestApplyChapterNamesCarriesSourceNamesIntoEncode(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "rip.mkv")
+	encoded := filepath.Join(dir, "main.mkv")
+	for _, path := range []string{source, encoded} {
+		if err := os.WriteFile(path, []byte("media"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	origProbe, origRun := probeChapterInput, runFFmpeg
+	t.Cleanup(func() { probeChapterInput, runFFmpeg = origProbe, origRun })
+	probeChapterInput = func(_ context.Context, _, path string) (*ffprobe.Result, error) {
+		if path == source {
+			return &ffprobe.Result{Chapters: chapters("Opening; Titles", "The Bank Job", "")}, nil
+		}
+		return &ffprobe.Result{Chapters: chapters("Chapter 01", "Chapter 02", "Chapter 03")}, nil
+	}
+	var calls [][]string
+	runFFmpeg = func(_ context.Context, args []string) ([]byte, error) {
+		calls = append(calls, args)
+		// Stand in for the remux: the output carries the metadata it was given.
+		meta, err := os.ReadFile(args[slices.Index(args, "ffmetadata")+2])
+		if err != nil {
+			return nil, err
+		}
+		return nil, os.WriteFile(args[len(args)-1], meta, 0o644)
+	}
+
+	env := &ripspec.Envelope{}
+	env.Assets.AddAsset(ripspec.AssetKindRipped, ripspec.Asset{EpisodeKey: "main", Path: source, Status: ripspec.AssetStatusCompleted})
+	env.Assets.AddAsset(ripspec.AssetKindEncoded, ripspec.Asset{EpisodeKey: "main", Path: encoded, Status: ripspec.AssetStatusCompleted})
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	applyChapterNames(context.Background(), logger, env, "main", encoded)
+
+	if len(calls) != 1 || !slices.Contains(calls[0], "-map_chapters") {
+		t.Fatalf("ffmpeg calls = %v, want one chapter import", calls)
+	}
+	out, err := os.ReadFile(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"START=0\nEND=300500\ntitle=Opening\\; Titles\n",
+		"START=300500\nEND=900000\ntitle=The Bank Job\n",
+		"title=Chapter 03\n",
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("encoded chapters missing %q:\n%s", want, out)
+		}
+	}
+	if asset, _ := env.Assets.FindAsset(ripspec.AssetKindEncoded, "main"); !asset.NamedChapters {
+		t.Error("encoded asset NamedChapters = false, want true")
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".chapters-main.txt")); !os.IsNotExist(err) {
+		t.Errorf("chapter metadata file left behind: %v", err)
+	}
+
+	// A source with only numbered chapters leaves the encode alone.
+	probeChapterInput = func(context.Context, string, string) (*ffprobe.Result, error) {
+		return &ffprobe.Result{Chapters: chapters("Chapter 01", "Chapter 02")}, nil
+	}
+	calls = nil
+	applyChapterNames(context.Background(), logger, env, "main", encoded)
+	if len(calls) != 0 {
+		t.Fatalf("ffmpeg calls = %v, want none for numbered chapters", calls)
+	}
+	if asset, _ := env.Assets.FindAsset(ripspec.AssetKindEncoded, "main"); asset.NamedChapters {
+		t.Error("encoded asset NamedChapters = true, want false for numbered chapters")
+	}
+}
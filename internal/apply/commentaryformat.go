@@ -0,0 +1,165 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/five82/spindle/internal/config"
+	"github.com/five82/spindle/internal/logs"
+	"github.com/five82/spindle/internal/media/ffprobe"
+	"github.com/five82/spindle/internal/ripspec"
+)
+
+var probeCommentaryFormatInput = ffprobe.Inspect
+
+// commentaryFormat is the codec and channel layout retained commentary
+// tracks are normalized to. Codec is the ffprobe codec name.
+type commentaryFormat struct {
+	Codec    string
+	Channels int
+	Bitrate  int64 // bits per second
+}
+
+// Normalized commentary is stereo at a bitrate that keeps speech clear in
+// either codec.
+const (
+	commentaryChannels = 2
+	commentaryBitrate  = 96000
+)
+
+// commentaryFormatFromConfig returns the configured commentary format, or
+// false when commentary tracks keep their encoded format.
+func commentaryFormatFromConfig(cfg config.EncodingConfig) (commentaryFormat, bool) {
+	if cfg.CommentaryCodec == "" {
+		return commentaryFormat{}, false
+	}
+	return commentaryFormat{
+		Codec:    cfg.CommentaryCodec,
+		Channels: commentaryChannels,
+		Bitrate:  commentaryBitrate,
+	}, true
+}
+
+func (f commentaryFormat) encoder() string {
+	if f.Codec == config.CommentaryCodecOpus {
+		return "libopus"
+	}
+	return f.Codec
+}
+
+// encodeArgs are the ffmpeg output options encoding audio track n in f.
+func (f commentaryFormat) encodeArgs(n string) []string {
+	return []string{
+		"-c:a:" + n, f.encoder(),
+		"-ac:a:" + n, strconv.Itoa(f.Channels),
+		"-b:a:" + n, strconv.FormatInt(f.Bitrate, 10),
+	}
+}
+
+// matches reports whether st is already in f, so converting it would only
+// cost a generation of quality.
+func (f commentaryFormat) matches(st ffprobe.Stream) bool {
+	return st.CodecName == f.Codec && st.Channels == f.Channels
+}
+
+func (f commentaryFormat) String() string {
+	return fmt.Sprintf("%s %dch %d kb/s", f.Codec, f.Channels, f.Bitrate/1000)
+}
+
+// planCommentaryFormat probes path and returns a record for each commentary
+// track not yet in format. The records carry the source codec and layout, so
+// they are taken before any rewrite changes them.
+func planCommentaryFormat(
+	ctx context.Context,
+	path string,
+	key string,
+	format commentaryFormat,
+	primary ripspec.AudioTrackRef,
+	comms []ripspec.CommentaryTrackRef,
+) ([]ripspec.CommentaryFormatRecord, error) {
+	if len(comms) == 0 {
+		return nil, nil
+	}
+	probe, err := probeCommentaryFormatInput(ctx, "", path)
+	if err != nil {
+		return nil, fmt.Errorf("ffprobe %s: %w", path, err)
+	}
+	audioStreams := probe.AudioStreams()
+
+	var pending []ripspec.CommentaryFormatRecord
+	for _, c := range comms {
+		if c.Index == primary.Index {
+			continue
+		}
+		if c.Index < 0 || c.Index >= len(audioStreams) {
+			return nil, fmt.Errorf("audio track %d out of range (%d tracks)", c.Index, len(audioStreams))
+		}
+		st := audioStreams[c.Index]
+		if format.matches(st) {
+			continue
+		}
+		pending = append(pending, ripspec.CommentaryFormatRecord{
+			EpisodeKey:     key,
+			TrackIndex:     c.Index,
+			SourceCodec:    st.CodecName,
+			SourceChannels: st.Channels,
+			Codec:          format.Codec,
+			Channels:       format.Channels,
+			Bitrate:        format.Bitrate,
+		})
+	}
+	return pending, nil
+}
+
+// buildCommentaryFormatArgs copies every stream and re-encodes only the
+// pending commentary tracks in format.
+func buildCommentaryFormatArgs(path, tmpPath string, format commentaryFormat, pending []ripspec.CommentaryFormatRecord) []string {
+	args := []string{"-y", "-hide_banner", "-loglevel", "error", "-i", path, "-map", "0", "-c", "copy"}
+	for _, rec := range pending {
+		args = append(args, format.encodeArgs(strconv.Itoa(rec.TrackIndex))...)
+	}
+	return append(args, tmpPath)
+}
+
+// convertCommentaryFormat rewrites path in place with the pending tracks
+// re-encoded. The original file is untouched if the rewrite fails.
+func convertCommentaryFormat(ctx context.Context, path string, format commentaryFormat, pending []ripspec.CommentaryFormatRecord) error {
+	tmpPath := filepath.Join(filepath.Dir(path), ".commentary-"+filepath.Base(path))
+	if out, err := runFFmpeg(ctx, buildCommentaryFormatArgs(path, tmpPath, format, pending)); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("commentary format conversion: %w: %s", err, out)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("rename converted file: %w", err)
+	}
+	return nil
+}
+
+// hasCommentaryFormatRecord reports whether key's commentary was already
+// converted, so a retried apply stage does not plan from converted audio.
+func hasCommentaryFormatRecord(data *ripspec.AudioAnalysisData, key string) bool {
+	for _, rec := range data.CommentaryFormat {
+		if rec.EpisodeKey == key {
+			return true
+		}
+	}
+	return false
+}
+
+// logCommentaryFormat records the decision for each converted track.
+func logCommentaryFormat(logger *slog.Logger, format commentaryFormat, records []ripspec.CommentaryFormatRecord) {
+	for _, rec := range records {
+		logger.Info("commentary track format normalized",
+			"decision_type", logs.DecisionCommentaryFormat,
+			"decision_result", "converted",
+			"decision_reason", fmt.Sprintf("%s %dch converted to %s", rec.SourceCodec, rec.SourceChannels, format),
+			"episode_key", rec.EpisodeKey,
+			"track_index", rec.TrackIndex,
+		)
+	}
+}
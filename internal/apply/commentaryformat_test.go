@@ -0,0 +1,120 @@
+package apply
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/five82/spindle/internal/config"
+	"github.com/five82/spindle/internal/media/ffprobe"
+	"github.com/five82/spindle/internal/ripspec"
+)
+
+// stubCommentaryFormatIO serves a primary 5.1 track, a 5.1 AC-3 commentary,
+// and a commentary already in stereo AAC, and records every ffmpeg call.
+func stubCommentaryFormatIO(t *testing.T) *[][]string {
+	t.Helper()
+	origFormat, origLoud, origRun := probeCommentaryFormatInput, probeLoudnormInput, runFFmpeg
+	t.Cleanup(func() { probeCommentaryFormatInput, probeLoudnormInput, runFFmpeg = origFormat, origLoud, origRun })
+	probe := func(context.Context, string, string) (*ffprobe.Result, error) {
+		return &ffprobe.Result{Streams: []ffprobe.Stream{
+			{Index: 0, CodecType: "video"},
+			{Index: 1, CodecType: "audio", CodecName: "opus", Channels: 6},
+			{Index: 2, CodecType: "audio", CodecName: "ac3", Channels: 6},
+			{Index: 3, CodecType: "audio", CodecName: "aac", Channels: 2},
+		}}, nil
+	}
+	probeCommentaryFormatInput, probeLoudnormInput = probe, probe
+	var calls [][]string
+	runFFmpeg = func(_ context.Context, args []string) ([]byte, error) {
+		calls = append(calls, args)
+		if slices.Contains(args, "null") {
+			return []byte(loudnormStderr), nil
+		}
+		return nil, os.WriteFile(args[len(args)-1], []byte("rewritten"), 0o644)
+	}
+	return &calls
+}
+
+func commentaryFormatConfig() *config.Config {
+	return &config.Config{Encoding: config.EncodingConfig{CommentaryCodec: config.CommentaryCodecAAC}}
+}
+
+func TestNormalizeAudioTranscodesCommentaryToConfiguredFormat(t *testing.T) {
+	calls := stubCommentaryFormatIO(t)
+	path := filepath.Join(t.TempDir(), "main.mkv")
+	if err := os.WriteFile(path, []byte("encoded"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	h := New(commentaryFormatConfig())
+	data := &ripspec.AudioAnalysisData{}
+	comms := []ripspec.CommentaryTrackRef{{Index: 1}, {Index: 2}}
+
+	h.normalizeAudio(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), data, "main", path,
+		ripspec.AudioTrackRef{Index: 0}, comms)
+
+	if len(*calls) != 1 {
+		t.Fatalf("ffmpeg ran %d times, want one rewrite", len(*calls))
+	}
+	args := strings.Join((*calls)[0], " ")
+	if !strings.Contains(args, "-c:a:1 aac -ac:a:1 2 -b:a:1 96000") {
+		t.Fatalf("commentary track not encoded to stereo AAC: %s", args)
+	}
+	if strings.Contains(args, "-c:a:0 ") || strings.Contains(args, "-c:a:2 ") {
+		t.Fatalf("primary or already-conforming commentary re-encoded: %s", args)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "rewritten" {
+		t.Fatalf("file content = %q, want the converted output renamed into place", got)
+	}
+	want := ripspec.CommentaryFormatRecord{
+		EpisodeKey: "main", TrackIndex: 1, SourceCodec: "ac3", SourceChannels: 6,
+		Codec: "aac", Channels: 2, Bitrate: 96000,
+	}
+	if len(data.CommentaryFormat) != 1 || data.CommentaryFormat[0] != want {
+		t.Fatalf("records = %+v, want %+v", data.CommentaryFormat, want)
+	}
+
+	// A retried apply stage does not convert the episode again.
+	*calls = nil
+	h.normalizeAudio(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), data, "main", path,
+		ripspec.AudioTrackRef{Index: 0}, comms)
+	if len(*calls) != 0 {
+		t.Fatalf("retry ran ffmpeg %d times, want none", len(*calls))
+	}
+}
+
+func TestNormalizeAudioLeavesCommentaryOutOfLoudnorm(t *testing.T) {
+	calls := stubCommentaryFormatIO(t)
+	path := filepath.Join(t.TempDir(), "main.mkv")
+	if err := os.WriteFile(path, []byte("encoded"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := commentaryFormatConfig()
+	cfg.Encoding.CommentaryCodec = config.CommentaryCodecOpus
+	cfg.Encoding.Loudnorm = true
+	data := &ripspec.AudioAnalysisData{}
+
+	New(cfg).normalizeAudio(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), data, "main", path,
+		ripspec.AudioTrackRef{Index: 0}, []ripspec.CommentaryTrackRef{{Index: 1}})
+
+	// The primary is measured and normalized, then the commentary format
+	// conversion rewrites the commentary track on its own.
+	if len(*calls) != 3 {
+		t.Fatalf("ffmpeg ran %d times, want a measurement and two rewrites", len(*calls))
+	}
+	loudnorm := strings.Join((*calls)[1], " ")
+	if !strings.Contains(loudnorm, "-c:a:0 libopus -b:a:0") || strings.Contains(loudnorm, "-c:a:1 ") {
+		t.Fatalf("loudnorm rewrite must touch only the primary track: %s", loudnorm)
+	}
+	if !strings.Contains(strings.Join((*calls)[2], " "), "-c:a:1 libopus -ac:a:1 2 -b:a:1 96000") {
+		t.Fatalf("commentary track not converted to the configured layout: %v", (*calls)[2])
+	}
+	if len(data.Loudness) != 1 || len(data.CommentaryFormat) != 1 || data.CommentaryFormat[0].SourceCodec != "ac3" {
+		t.Fatalf("records: loudness %+v, commentary format %+v", data.Loudness, data.CommentaryFormat)
+	}
+}
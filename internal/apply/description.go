@@ -0,0 +1,41 @@
+package apply
+
+import (
+	"strings"
+
+	"github.com/five82/spindle/internal/ripspec"
+)
+
+// audioDescriptionLabel formats a stream title for a retained
+// audio-description track, following the same rules as commentaryLabel.
+func audioDescriptionLabel(original string) string {
+	title := strings.TrimSpace(original)
+	if title == "" {
+		return "Audio Description"
+	}
+	if strings.Contains(strings.ToLower(title), "description") {
+		return title
+	}
+	return title + " (Audio Description)"
+}
+
+// remapAudioDescriptionIndices maps retained audio-description indices from
+// the ripped layout to the refined file. Tracks refinement dropped are
+// omitted.
+func remapAudioDescriptionIndices(original []ripspec.AudioDescriptionTrackRef, keptIndices []int) []ripspec.AudioDescriptionTrackRef {
+	if len(original) == 0 || len(keptIndices) == 0 {
+		return nil
+	}
+	indexMap := make(map[int]int, len(keptIndices))
+	for newIdx, oldIdx := range keptIndices {
+		indexMap[oldIdx] = newIdx
+	}
+	var remapped []ripspec.AudioDescriptionTrackRef
+	for _, ref := range original {
+		if newIdx, ok := indexMap[ref.Index]; ok {
+			ref.Index = newIdx
+			remapped = append(remapped, ref)
+		}
+	}
+	return remapped
+}
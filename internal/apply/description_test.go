@@ -0,0 +1,91 @@
+package apply
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/five82/spindle/internal/media/ffprobe"
+	"github.com/five82/spindle/internal/ripspec"
+)
+
+func TestAudioDescriptionLabel(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", "Audio Description"},
+		{"Stereo", "Stereo (Audio Description)"},
+		{"Descriptive Audio", "Descriptive Audio (Audio Description)"},
+		{"English Audio Description", "English Audio Description"},
+	}
+	for _, tt := range tests {
+		if got := audioDescriptionLabel(tt.input); got != tt.expected {
+			t.Errorf("audioDescriptionLabel(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestRemapAudioDescriptionIndices(t *testing.T) {
+	original := []ripspec.AudioDescriptionTrackRef{
+		{Index: 3, GapRatio: 0.9, Reason: "audio description"},
+		{Index: 5},
+	}
+	// Refinement kept primary 1, commentary 2, and AD 3; track 5 was dropped.
+	got := remapAudioDescriptionIndices(original, []int{1, 2, 3})
+	if len(got) != 1 {
+		t.Fatalf("remapped = %+v, want one track", got)
+	}
+	if got[0].Index != 2 || got[0].GapRatio != 0.9 || got[0].Reason != "audio description" {
+		t.Fatalf("remapped[0] = %+v", got[0])
+	}
+}
+
+func TestApplyPostRefinementAudioLabelsInOnePass(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.mkv")
+	if err := os.WriteFile(path, []byte("refined"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	origProbe, origRun := probePostRefinement, runFFmpeg
+	t.Cleanup(func() { probePostRefinement, runFFmpeg = origProbe, origRun })
+	probePostRefinement = func(context.Context, string, string) (*ffprobe.Result, error) {
+		return &ffprobe.Result{Streams: []ffprobe.Stream{
+			{Index: 0, CodecType: "video"},
+			{Index: 1, CodecType: "audio", CodecName: "truehd", Channels: 8, Tags: map[string]string{"language": "eng"}},
+			{Index: 2, CodecType: "audio", CodecName: "ac3", Channels: 2, Tags: map[string]string{"title": "Director"}},
+			{Index: 3, CodecType: "audio", CodecName: "ac3", Channels: 2, Tags: map[string]string{"title": "Descriptive"}},
+		}}, nil
+	}
+	var calls [][]string
+	runFFmpeg = func(_ context.Context, args []string) ([]byte, error) {
+		calls = append(calls, args)
+		return nil, os.WriteFile(args[len(args)-1], []byte("labeled"), 0o644)
+	}
+
+	refinement := &audioRefinementResult{KeptIndices: []int{0, 2, 4}}
+	_, _, comms, described, err := applyPostRefinementAudio(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), path, refinement,
+		[]ripspec.CommentaryTrackRef{{Index: 2}},
+		[]ripspec.AudioDescriptionTrackRef{{Index: 4}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comms) != 1 || comms[0].Index != 1 || len(described) != 1 || described[0].Index != 2 {
+		t.Fatalf("remapped commentary = %+v, audio description = %+v", comms, described)
+	}
+	if len(calls) != 1 {
+		t.Fatalf("ffmpeg ran %d times, want one disposition remux", len(calls))
+	}
+	args := strings.Join(calls[0], " ")
+	for _, want := range []string{
+		"-disposition:a:1 comment -metadata:s:a:1 title=Director (Commentary)",
+		"-disposition:a:2 visual_impaired -metadata:s:a:2 title=Descriptive (Audio Description)",
+	} {
+		if !strings.Contains(args, want) {
+			t.Errorf("disposition args missing %q: %s", want, args)
+		}
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -29,18 +28,27 @@ func commentaryLabel(original string) string {
 	return title + " (Commentary)"
 }
 
-type commentaryTarget struct {
-	Index int
-	Title string
+// Audio dispositions written by the disposition remux.
+const (
+	dispositionCommentary       = "comment"
+	dispositionAudioDescription = "visual_impaired"
+)
+
+// dispositionTarget is one audio track to label: its FFmpeg disposition
+// and the stream title to write.
+type dispositionTarget struct {
+	Index       int
+	Disposition string
+	Title       string
 }
 
-// applyCommentaryDisposition labels commentary tracks with an FFmpeg
-// copy-mode remux.
-func applyCommentaryDisposition(
+// applyAudioDisposition labels commentary and audio-description tracks in
+// one FFmpeg copy-mode remux.
+func applyAudioDisposition(
 	ctx context.Context,
 	logger *slog.Logger,
 	path string,
-	targets []commentaryTarget,
+	targets []dispositionTarget,
 ) error {
 	if len(targets) == 0 {
 		return nil
@@ -51,8 +59,8 @@ func applyCommentaryDisposition(
 		indices[i] = t.Index
 	}
 
-	logger.Info("applying commentary disposition",
-		"event_type", "commentary_disposition_start",
+	logger.Info("applying audio disposition",
+		"event_type", "audio_disposition_start",
 		"path", path,
 		"tracks", indices,
 	)
@@ -63,14 +71,14 @@ func applyCommentaryDisposition(
 	args := []string{"-y", "-i", path, "-map", "0", "-c", "copy"}
 	for _, t := range targets {
 		idxStr := strconv.Itoa(t.Index)
-		args = append(args, "-disposition:a:"+idxStr, "comment")
-		label := commentaryLabel(t.Title)
-		args = append(args, "-metadata:s:a:"+idxStr, "title="+label)
+		args = append(args,
+			"-disposition:a:"+idxStr, t.Disposition,
+			"-metadata:s:a:"+idxStr, "title="+t.Title,
+		)
 	}
 	args = append(args, tmpPath)
 
-	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
-	output, err := cmd.CombinedOutput()
+	output, err := runFFmpeg(ctx, args)
 	if err != nil {
 		_ = os.Remove(tmpPath)
 		return fmt.Errorf("ffmpeg disposition: %w: %s", err, output)
@@ -81,13 +89,29 @@ func applyCommentaryDisposition(
 		return fmt.Errorf("rename disposition file: %w", err)
 	}
 
-	logger.Info("commentary disposition applied",
-		"decision_type", logs.DecisionCommentaryDisposition,
-		"decision_result", "applied",
-		"decision_reason", fmt.Sprintf("marked %d tracks as commentary", len(targets)),
-		"path", path,
-		"tracks", indices,
-	)
+	for _, kind := range []struct {
+		disposition, decisionType, noun string
+	}{
+		{dispositionCommentary, logs.DecisionCommentaryDisposition, "commentary"},
+		{dispositionAudioDescription, logs.DecisionAudioDescription, "audio description"},
+	} {
+		var marked []int
+		for _, t := range targets {
+			if t.Disposition == kind.disposition {
+				marked = append(marked, t.Index)
+			}
+		}
+		if len(marked) == 0 {
+			continue
+		}
+		logger.Info(kind.noun+" disposition applied",
+			"decision_type", kind.decisionType,
+			"decision_result", "applied",
+			"decision_reason", fmt.Sprintf("marked %d tracks as %s", len(marked), kind.noun),
+			"path", path,
+			"tracks", marked,
+		)
+	}
 
 	return nil
 }
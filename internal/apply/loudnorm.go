@@ -0,0 +1,201 @@
+package apply
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/five82/spindle/internal/logs"
+	"github.com/five82/spindle/internal/media/ffprobe"
+	"github.com/five82/spindle/internal/ripspec"
+)
+
+var (
+	probeLoudnormInput = ffprobe.Inspect
+	runFFmpeg          = func(ctx context.Context, args []string) ([]byte, error) {
+		return exec.CommandContext(ctx, "ffmpeg", args...).CombinedOutput()
+	}
+)
+
+// loudnormRolePrimary is the role recorded for the normalized track.
+const loudnormRolePrimary = "primary"
+
+// loudnormTarget is the EBU R128 target handed to ffmpeg's loudnorm filter.
+type loudnormTarget struct {
+	I, TP, LRA float64
+}
+
+// ebuR128Target is the broadcast target every normalized track is brought
+// to: -23 LUFS integrated, -1 dBTP true peak, 7 LU loudness range.
+var ebuR128Target = loudnormTarget{I: -23, TP: -1, LRA: 7}
+
+func (t loudnormTarget) filter() string {
+	return fmt.Sprintf("loudnorm=I=%g:TP=%g:LRA=%g", t.I, t.TP, t.LRA)
+}
+
+// loudnormMeasurement is the first-pass JSON loudnorm prints. ffmpeg
+// reports every value as a string.
+type loudnormMeasurement struct {
+	InputI       string `json:"input_i"`
+	InputTP      string `json:"input_tp"`
+	InputLRA     string `json:"input_lra"`
+	InputThresh  string `json:"input_thresh"`
+	TargetOffset string `json:"target_offset"`
+}
+
+// parseLoudnormOutput extracts the measurement block loudnorm prints at the
+// end of ffmpeg's stderr.
+func parseLoudnormOutput(out []byte) (loudnormMeasurement, error) {
+	start := bytes.LastIndexByte(out, '{')
+	end := bytes.LastIndexByte(out, '}')
+	if start < 0 || end < start {
+		return loudnormMeasurement{}, fmt.Errorf("no loudnorm measurement in ffmpeg output")
+	}
+	var m loudnormMeasurement
+	if err := json.Unmarshal(out[start:end+1], &m); err != nil {
+		return loudnormMeasurement{}, fmt.Errorf("parse loudnorm measurement: %w", err)
+	}
+	for _, v := range []string{m.InputI, m.InputTP, m.InputLRA, m.InputThresh, m.TargetOffset} {
+		if f, err := strconv.ParseFloat(v, 64); err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+			// Silent tracks measure as -inf, which the second pass rejects.
+			return loudnormMeasurement{}, fmt.Errorf("unusable loudnorm measurement %q", v)
+		}
+	}
+	return m, nil
+}
+
+func buildLoudnormMeasureArgs(path string, track int, target loudnormTarget) []string {
+	return []string{"-hide_banner", "-nostats", "-i", path,
+		"-map", "0:a:" + strconv.Itoa(track),
+		"-af", target.filter() + ":print_format=json",
+		"-f", "null", "-"}
+}
+
+// loudnormPlan is the measured track ready for the second pass.
+type loudnormPlan struct {
+	track   int // audio-relative index
+	measure loudnormMeasurement
+	bitrate int64
+}
+
+// filter is the second-pass loudnorm filter: linear normalization from the
+// first-pass measurement, resampled back from loudnorm's 192 kHz output.
+func (p loudnormPlan) filter(target loudnormTarget) string {
+	m := p.measure
+	return fmt.Sprintf("%s:measured_I=%s:measured_TP=%s:measured_LRA=%s:measured_thresh=%s:offset=%s:linear=true,aresample=48000",
+		target.filter(), m.InputI, m.InputTP, m.InputLRA, m.InputThresh, m.TargetOffset)
+}
+
+// buildLoudnormApplyArgs copies every stream and re-encodes only the
+// normalized track, to Opus at its original bitrate.
+func buildLoudnormApplyArgs(path, tmpPath string, target loudnormTarget, plan loudnormPlan) []string {
+	n := strconv.Itoa(plan.track)
+	return []string{"-y", "-hide_banner", "-loglevel", "error", "-i", path, "-map", "0", "-c", "copy",
+		"-filter:a:" + n, plan.filter(target),
+		"-c:a:" + n, "libopus",
+		"-b:a:" + n, strconv.FormatInt(plan.bitrate, 10),
+		tmpPath}
+}
+
+// audioBitrate is the stream's bitrate, falling back to the Matroska BPS tag
+// and then 64 kb/s per channel.
+func audioBitrate(st ffprobe.Stream) int64 {
+	for _, v := range []string{st.BitRate, st.Tags["BPS"], st.Tags["BPS-eng"]} {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil && n > 0 {
+			return n
+		}
+	}
+	return int64(max(st.Channels, 2)) * 64000
+}
+
+func parseLoudnormFloat(s string) float64 {
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
+
+// normalizeLoudness measures the track (audio-relative index), then
+// rewrites the file in place with it normalized, and returns what was
+// applied. The original file is untouched if any step fails.
+func normalizeLoudness(
+	ctx context.Context,
+	logger *slog.Logger,
+	path string,
+	key string,
+	target loudnormTarget,
+	track int,
+) ([]ripspec.LoudnessRecord, error) {
+	probe, err := probeLoudnormInput(ctx, "", path)
+	if err != nil {
+		return nil, fmt.Errorf("ffprobe %s: %w", path, err)
+	}
+	audioStreams := probe.AudioStreams()
+	if track < 0 || track >= len(audioStreams) {
+		return nil, fmt.Errorf("audio track %d out of range (%d tracks)", track, len(audioStreams))
+	}
+
+	start := time.Now()
+	out, err := runFFmpeg(ctx, buildLoudnormMeasureArgs(path, track, target))
+	if err != nil {
+		return nil, fmt.Errorf("loudnorm measure track %d: %w: %s", track, err, out)
+	}
+	m, err := parseLoudnormOutput(out)
+	if err != nil {
+		return nil, fmt.Errorf("loudnorm measure track %d: %w", track, err)
+	}
+	plan := loudnormPlan{track: track, measure: m, bitrate: audioBitrate(audioStreams[track])}
+
+	tmpPath := filepath.Join(filepath.Dir(path), ".loudnorm-"+filepath.Base(path))
+	if out, err := runFFmpeg(ctx, buildLoudnormApplyArgs(path, tmpPath, target, plan)); err != nil {
+		_ = os.Remove(tmpPath)
+		return nil, fmt.Errorf("loudnorm apply: %w: %s", err, out)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return nil, fmt.Errorf("rename normalized file: %w", err)
+	}
+
+	rec := ripspec.LoudnessRecord{
+		EpisodeKey:     key,
+		TrackIndex:     track,
+		Role:           loudnormRolePrimary,
+		MeasuredI:      parseLoudnormFloat(m.InputI),
+		MeasuredTP:     parseLoudnormFloat(m.InputTP),
+		MeasuredLRA:    parseLoudnormFloat(m.InputLRA),
+		MeasuredThresh: parseLoudnormFloat(m.InputThresh),
+		Offset:         parseLoudnormFloat(m.TargetOffset),
+		TargetI:        target.I,
+		TargetTP:       target.TP,
+		TargetLRA:      target.LRA,
+	}
+	logger.Info("audio loudness normalized",
+		"decision_type", logs.DecisionLoudnessNormalization,
+		"decision_result", "normalized",
+		"decision_reason", fmt.Sprintf("measured %.1f LUFS, target %g LUFS", rec.MeasuredI, target.I),
+		"episode_key", key,
+		"track_index", rec.TrackIndex,
+		"role", rec.Role,
+		"measured_tp", rec.MeasuredTP,
+		"measured_lra", rec.MeasuredLRA,
+		"duration_ms", time.Since(start).Milliseconds(),
+	)
+	return []ripspec.LoudnessRecord{rec}, nil
+}
+
+// hasLoudnessRecord reports whether key was already normalized, so a retried
+// apply stage does not normalize (and re-encode) the same audio twice.
+func hasLoudnessRecord(data *ripspec.AudioAnalysisData, key string) bool {
+	for _, rec := range data.Loudness {
+		if rec.EpisodeKey == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package apply
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/five82/spindle/internal/media/ffprobe"
+	"github.com/five82/spindle/internal/ripspec"
+)
+
+const loudnormStderr = `[Parsed_loudnorm_0 @ 0x55d]
+{
+	"input_i" : "-31.20",
+	"input_tp" : "-9.41",
+	"input_lra" : "14.80",
+	"input_thresh" : "-42.03",
+	"output_i" : "-23.02",
+	"output_tp" : "-1.00",
+	"output_lra" : "7.10",
+	"output_thresh" : "-33.70",
+	"normalization_type" : "dynamic",
+	"target_offset" : "0.02"
+}
+`
+
+func TestNormalizeLoudnessPassesMeasurementToFFmpegAndRecordsIt(t *testing.T) {
+	origProbe, origRun := probeLoudnormInput, runFFmpeg
+	t.Cleanup(func() { probeLoudnormInput, runFFmpeg = origProbe, origRun })
+	probeLoudnormInput = func(context.Context, string, string) (*ffprobe.Result, error) {
+		return &ffprobe.Result{Streams: []ffprobe.Stream{
+			{Index: 0, CodecType: "video"},
+			{Index: 1, CodecType: "audio", CodecName: "opus", Channels: 6, Tags: map[string]string{"BPS": "320000"}},
+			{Index: 2, CodecType: "audio", CodecName: "opus", Channels: 2},
+		}}, nil
+	}
+	var calls [][]string
+	runFFmpeg = func(_ context.Context, args []string) ([]byte, error) {
+		calls = append(calls, args)
+		if slices.Contains(args, "null") {
+			return []byte(loudnormStderr), nil
+		}
+		return nil, os.WriteFile(args[len(args)-1], []byte("normalized"), 0o644)
+	}
+
+	path := filepath.Join(t.TempDir(), "main.mkv")
+	if err := os.WriteFile(path, []byte("encoded"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	records, err := normalizeLoudness(context.Background(), logger, path, "main", ebuR128Target, 0)
+	if err != nil {
+		t.Fatalf("normalizeLoudness: %v", err)
+	}
+	if len(calls) != 2 {
+		t.Fatalf("ffmpeg ran %d times, want measure + apply for the primary only", len(calls))
+	}
+	measure := strings.Join(calls[0], " ")
+	if !strings.Contains(measure, "-map 0:a:0") || !strings.Contains(measure, "loudnorm=I=-23:TP=-1:LRA=7:print_format=json") {
+		t.Fatalf("measure args = %s", measure)
+	}
+	apply := strings.Join(calls[1], " ")
+	for _, want := range []string{
+		"-filter:a:0 loudnorm=I=-23:TP=-1:LRA=7:measured_I=-31.20:measured_TP=-9.41:measured_LRA=14.80:measured_thresh=-42.03:offset=0.02:linear=true",
+		"-c:a:0 libopus",
+		"-b:a:0 320000",
+	} {
+		if !strings.Contains(apply, want) {
+			t.Errorf("apply args missing %q: %s", want, apply)
+		}
+	}
+	if strings.Contains(apply, "-filter:a:1") {
+		t.Error("only the primary track may be normalized")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "normalized" {
+		t.Fatalf("file content = %q, want the normalized output renamed into place", data)
+	}
+
+	want := ripspec.LoudnessRecord{
+		EpisodeKey: "main", TrackIndex: 0, Role: loudnormRolePrimary,
+		MeasuredI: -31.2, MeasuredTP: -9.41, MeasuredLRA: 14.8, MeasuredThresh: -42.03, Offset: 0.02,
+		TargetI: -23, TargetTP: -1, TargetLRA: 7,
+	}
+	if len(records) != 1 || records[0] != want {
+		t.Fatalf("records = %+v, want %+v", records, want)
+	}
+}
+
+func TestNormalizeLoudnessLeavesFileOnFailure(t *testing.T) {
+	origProbe, origRun := probeLoudnormInput, runFFmpeg
+	t.Cleanup(func() { probeLoudnormInput, runFFmpeg = origProbe, origRun })
+	probeLoudnormInput = func(context.Context, string, string) (*ffprobe.Result, error) {
+		return &ffprobe.Result{Streams: []ffprobe.Stream{{Index: 0, CodecType: "audio", Channels: 2}}}, nil
+	}
+	runFFmpeg = func(context.Context, []string) ([]byte, error) {
+		return []byte(`{"input_i" : "-inf", "input_tp" : "-inf", "input_lra" : "0.00", "input_thresh" : "-70.00", "target_offset" : "inf"}`), nil
+	}
+	path := filepath.Join(t.TempDir(), "main.mkv")
+	if err := os.WriteFile(path, []byte("encoded"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := normalizeLoudness(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), path, "main",
+		ebuR128Target, 0)
+	if err == nil {
+		t.Fatal("expected error for a silent track measurement")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "encoded" {
+		t.Fatal("failed normalization must leave the encoded file untouched")
+	}
+}
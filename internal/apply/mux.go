@@ -15,7 +15,8 @@ import (
 
 // MuxTrack describes one SRT track to mux into an MKV.
 type MuxTrack struct {
-	Path, Language string
+	Path, Language  string
+	HearingImpaired bool
 }
 
 // MuxRequest describes an MKV subtitle mux operation.
@@ -35,6 +36,16 @@ func DisplaySubtitlePath(videoPath, subtitleLanguage string) string {
 	return base + "." + lang + ".srt"
 }
 
+// SidecarSubtitlePath is DisplaySubtitlePath with the .hi flag media servers
+// read as hearing-impaired (SDH) when hearingImpaired is set.
+func SidecarSubtitlePath(videoPath, subtitleLanguage string, hearingImpaired bool) string {
+	path := DisplaySubtitlePath(videoPath, subtitleLanguage)
+	if hearingImpaired {
+		path = strings.TrimSuffix(path, ".srt") + ".hi.srt"
+	}
+	return path
+}
+
 func buildSubtitleMuxArgs(outputPath, videoPath string, track MuxTrack, replaceExisting bool) []string {
 	args := []string{"-o", outputPath}
 	if replaceExisting {
@@ -50,9 +61,15 @@ func buildSubtitleMuxArgs(outputPath, videoPath string, track MuxTrack, replaceE
 	if strings.TrimSpace(name) == "" {
 		name = "English"
 	}
+	if track.HearingImpaired {
+		name += " (SDH)"
+	}
 	args = append(args, "--language", "0:"+lang, "--track-name", "0:"+name,
-		"--default-track-flag", "0:no", "--forced-track", "0:no", track.Path)
-	return args
+		"--default-track-flag", "0:no", "--forced-track", "0:no")
+	if track.HearingImpaired {
+		args = append(args, "--hearing-impaired-flag", "0:yes")
+	}
+	return append(args, track.Path)
 }
 
 // MuxSubtitleTrack runs mkvmerge and atomically replaces OutputPath.
@@ -102,9 +119,8 @@ func muxDisplaySubtitle(
 	ctx context.Context,
 	logger *slog.Logger,
 	videoPath string,
-	srtPath string,
 	key string,
-	subtitleLanguage string,
+	track MuxTrack,
 ) (string, error) {
 	dir := filepath.Dir(videoPath)
 	ext := filepath.Ext(videoPath)
@@ -115,14 +131,14 @@ func muxDisplaySubtitle(
 		"event_type", "mux_start",
 		"episode_key", key,
 		"video_path", videoPath,
-		"subtitle_path", srtPath,
+		"subtitle_path", track.Path,
 		"output_path", outPath,
 	)
 	muxStart := time.Now()
 	muxedPath, err := MuxSubtitleTrack(ctx, MuxRequest{
 		VideoPath:  videoPath,
 		OutputPath: outPath,
-		Track:      MuxTrack{Path: srtPath, Language: subtitleLanguage},
+		Track:      track,
 	})
 	if err != nil {
 		return "", fmt.Errorf("mux subtitles %s: %w", key, err)
@@ -15,3 +15,22 @@ func TestBuildSubtitleMuxArgs(t *testing.T) {
 		t.Fatalf("buildSubtitleMuxArgs() = %#v, want %#v", got, want)
 	}
 }
+
+func TestHearingImpairedSubtitleTrack(t *testing.T) {
+	if got := SidecarSubtitlePath("/lib/Movie (2020).mkv", "en", true); got != "/lib/Movie (2020).en.hi.srt" {
+		t.Fatalf("HI sidecar = %q", got)
+	}
+	if got := SidecarSubtitlePath("/lib/Movie (2020).mkv", "en", false); got != "/lib/Movie (2020).en.srt" {
+		t.Fatalf("sidecar = %q", got)
+	}
+
+	got := buildSubtitleMuxArgs("/tmp/out.mkv", "/media/movie.mkv", MuxTrack{Path: "/tmp/movie.en.hi.srt", Language: "en", HearingImpaired: true}, false)
+	want := []string{
+		"-o", "/tmp/out.mkv", "/media/movie.mkv",
+		"--language", "0:eng", "--track-name", "0:English (SDH)", "--default-track-flag", "0:no", "--forced-track", "0:no",
+		"--hearing-impaired-flag", "0:yes", "/tmp/movie.en.hi.srt",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("buildSubtitleMuxArgs() = %#v, want %#v", got, want)
+	}
+}
@@ -7,9 +7,13 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/five82/spindle/internal/config"
+	"github.com/five82/spindle/internal/language"
 	"github.com/five82/spindle/internal/logs"
 	"github.com/five82/spindle/internal/media/audio"
 	"github.com/five82/spindle/internal/media/ffprobe"
@@ -20,14 +24,65 @@ type audioRefinementResult struct {
 	KeptIndices             []int
 }
 
-// refineAudioTargets keeps only the selected audio tracks and makes the
-// primary track first and default. Commentary indices are preserved when
-// valid for the file.
+// audioKeepRule is the configured encoding.audio_keep rule with its
+// languages.
+type audioKeepRule struct {
+	mode      string
+	languages []string
+}
+
+// extraIndices returns the audio indices the rule keeps beside the primary
+// track. detected holds the commentary and audio-description indices from
+// analysis; an unset mode keeps them, like config.AudioKeepPrimaryCommentary.
+func (r audioKeepRule) extraIndices(streams []ffprobe.Stream, detected []int) []int {
+	switch r.mode {
+	case config.AudioKeepPrimaryOnly:
+		return nil
+	case config.AudioKeepAll:
+		all := make([]int, len(streams))
+		for i := range streams {
+			all[i] = i
+		}
+		return all
+	case config.AudioKeepLanguage:
+		wanted := language.NormalizeList(r.languages)
+		keep := slices.Clone(detected)
+		for i, st := range streams {
+			if lang := language.ToISO2(language.ExtractFromTags(st.Tags)); lang != "" && slices.Contains(wanted, lang) {
+				keep = append(keep, i)
+			}
+		}
+		return keep
+	default:
+		return detected
+	}
+}
+
+// keptTrackList describes the kept audio tracks in order for logging, e.g.
+// "0:en/truehd/8ch, 2:en/ac3/2ch".
+func keptTrackList(streams []ffprobe.Stream, kept []int) string {
+	parts := make([]string, 0, len(kept))
+	for _, idx := range kept {
+		st := streams[idx]
+		lang := language.ToISO2(language.ExtractFromTags(st.Tags))
+		if lang == "" {
+			lang = "und"
+		}
+		parts = append(parts, fmt.Sprintf("%d:%s/%s/%dch", idx, lang, st.CodecName, st.Channels))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// refineAudioTargets keeps the audio tracks the keep rule selects and makes
+// the primary track first and default. additionalKeep holds the detected
+// commentary and audio-description indices; they are kept when valid for
+// the file and the rule retains them.
 func refineAudioTargets(
 	ctx context.Context,
 	logger *slog.Logger,
 	paths []string,
 	additionalKeep []int,
+	rule audioKeepRule,
 ) (*audioRefinementResult, error) {
 	if len(paths) == 0 {
 		return &audioRefinementResult{}, nil
@@ -49,6 +104,9 @@ func refineAudioTargets(
 		if err != nil {
 			return nil, fmt.Errorf("ffprobe %s: %w", path, err)
 		}
+		if result.Partial {
+			return nil, fmt.Errorf("ffprobe %s: partial result cannot drive a stream map", path)
+		}
 
 		audioCount := result.AudioStreamCount()
 		sel := audio.Select(result.Streams, logger)
@@ -65,7 +123,18 @@ func refineAudioTargets(
 			continue
 		}
 
-		keptIndices := buildKeptIndices(audioCount, sel.PrimaryIndex, additionalKeep)
+		audioStreams := result.AudioStreams()
+		keptIndices := buildKeptIndices(audioCount, sel.PrimaryIndex, rule.extraIndices(audioStreams, additionalKeep))
+		mode := rule.mode
+		if mode == "" {
+			mode = config.AudioKeepPrimaryCommentary
+		}
+		logger.Info("audio keep rule applied",
+			"decision_type", logs.DecisionAudioRefinement,
+			"decision_result", mode,
+			"decision_reason", fmt.Sprintf("keeping %d of %d audio tracks: %s", len(keptIndices), audioCount, keptTrackList(audioStreams, keptIndices)),
+			"path", path,
+		)
 		needsRemux := len(keptIndices) != audioCount || needsDispositionFix(result, sel.PrimaryIndex)
 		if !needsRemux {
 			logger.Info("audio refinement: no remux needed",
@@ -149,6 +218,9 @@ func validateRemuxedAudio(ctx context.Context, path string, expectedAudio int) e
 	if err != nil {
 		return fmt.Errorf("post-remux ffprobe %s: %w", path, err)
 	}
+	if postResult.Partial {
+		return fmt.Errorf("post-remux ffprobe %s: partial result", path)
+	}
 	postAudio := postResult.AudioStreamCount()
 	if postAudio != expectedAudio {
 		return fmt.Errorf("post-remux audio count %d != expected %d for %s", postAudio, expectedAudio, path)
@@ -1,8 +1,10 @@
 package apply
 
 import (
+	"slices"
 	"testing"
 
+	"github.com/five82/spindle/internal/config"
 	"github.com/five82/spindle/internal/media/ffprobe"
 )
 
@@ -61,3 +63,41 @@ func TestNeedsDispositionFix_WhenAlreadyCorrect(t *testing.T) {
 		t.Fatal("did not expect disposition fix when primary is first and sole default")
 	}
 }
+
+func TestAudioKeepRulesSelectKeptTracks(t *testing.T) {
+	stream := func(lang, codec string, channels int) ffprobe.Stream {
+		return ffprobe.Stream{CodecType: "audio", CodecName: codec, Channels: channels, Tags: map[string]string{"language": lang}}
+	}
+	// 0: English main, 1: French dub, 2: English commentary (detected),
+	// 3: English stereo mix, 4: Japanese dub.
+	streams := []ffprobe.Stream{
+		stream("eng", "truehd", 8),
+		stream("fre", "ac3", 6),
+		stream("eng", "ac3", 2),
+		stream("eng", "ac3", 2),
+		stream("jpn", "ac3", 6),
+	}
+	detected := []int{2}
+
+	tests := []struct {
+		rule audioKeepRule
+		want []int
+	}{
+		{audioKeepRule{}, []int{0, 2}},
+		{audioKeepRule{mode: config.AudioKeepPrimaryCommentary}, []int{0, 2}},
+		{audioKeepRule{mode: config.AudioKeepPrimaryOnly}, []int{0}},
+		{audioKeepRule{mode: config.AudioKeepLanguage, languages: []string{"en"}}, []int{0, 2, 3}},
+		{audioKeepRule{mode: config.AudioKeepLanguage, languages: []string{"jpn"}}, []int{0, 2, 4}},
+		{audioKeepRule{mode: config.AudioKeepAll}, []int{0, 1, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		got := buildKeptIndices(len(streams), 0, tt.rule.extraIndices(streams, detected))
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("rule %q %v: kept %v, want %v", tt.rule.mode, tt.rule.languages, got, tt.want)
+		}
+	}
+
+	if got := keptTrackList(streams, []int{0, 4}); got != "0:en/truehd/8ch, 4:ja/ac3/6ch" {
+		t.Errorf("keptTrackList = %q", got)
+	}
+}
@@ -25,6 +25,9 @@ func validateAudioTargetDurations(ctx context.Context, paths []string) error {
 		if err != nil {
 			return fmt.Errorf("ffprobe %s: %w", path, err)
 		}
+		if result.Partial {
+			return fmt.Errorf("ffprobe %s: partial result", path)
+		}
 		if err := validateAudioDurations(filepath.Base(path), result); err != nil {
 			return err
 		}
@@ -7,17 +7,20 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
-	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/five82/spindle/internal/config"
+	"github.com/five82/spindle/internal/fingerprint"
 	"github.com/five82/spindle/internal/language"
 	"github.com/five82/spindle/internal/llm"
 	"github.com/five82/spindle/internal/logs"
 	"github.com/five82/spindle/internal/media/audio"
 	"github.com/five82/spindle/internal/media/ffprobe"
+	"github.com/five82/spindle/internal/queue"
 	"github.com/five82/spindle/internal/ripspec"
+	"github.com/five82/spindle/internal/srtutil"
 	"github.com/five82/spindle/internal/stage"
 	"github.com/five82/spindle/internal/textutil"
 	"github.com/five82/spindle/internal/transcription"
@@ -66,8 +69,20 @@ type Handler struct {
 	cfg         *config.Config
 	llmClient   *llm.Client
 	transcriber *transcription.Service
+	streamCache *StreamCache
 }
 
+// Test seams for probing and transcription.
+var (
+	inspectMedia    = ffprobe.Inspect
+	transcribeBatch = func(ctx context.Context, t *transcription.Service, reqs []transcription.TranscribeRequest) ([]*transcription.TranscribeResult, error) {
+		return t.TranscribeBatch(ctx, reqs)
+	}
+	transcribeOne = func(ctx context.Context, t *transcription.Service, req transcription.TranscribeRequest) (*transcription.TranscribeResult, error) {
+		return t.Transcribe(ctx, req)
+	}
+)
+
 // New creates an audio analysis handler.
 func New(
 	cfg *config.Config,
@@ -81,12 +96,17 @@ func New(
 	}
 }
 
+// SetStreamCache enables reuse of candidate transcripts across analysis runs
+// of unchanged rips.
+func (h *Handler) SetStreamCache(c *StreamCache) {
+	h.streamCache = c
+}
+
 // Run executes the analysis stage: per-episode commentary detection from
 // the RIPPED sources. This stage runs concurrently with encoding, so it is
 // progress-silent (encoding owns the item progress columns) and persists
 // envelope changes only through merge operations.
 func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
-	item := sess.Item
 	logger := sess.Logger
 	logger.Info("analysis stage started", "event_type", "stage_start", "stage", "analysis")
 	env := sess.Env
@@ -114,38 +134,56 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 	)
 
 	analysisData := &ripspec.AudioAnalysisData{}
-	if h.cfg.Commentary.Enabled && h.llmClient != nil {
+	var skip *ripspec.StageSkip
+	if h.cfg.Commentary.Enabled && h.llmClient != nil && !env.Options.SkipCommentary {
 		for _, in := range inputs {
 			if ctx.Err() != nil {
 				return ctx.Err()
 			}
-			result, err := ffprobe.Inspect(ctx, "", in.path)
+			result, err := inspectMedia(ctx, "", in.path)
 			if err != nil {
 				return fmt.Errorf("ffprobe %s: %w", in.path, err)
 			}
-			comms, excluded := h.detectCommentary(ctx, sess, result, in.path, item.DiscFingerprint, in.key)
+			report := h.detectCommentary(ctx, logger, result, h.sessionTarget(sess, in.key, in.path))
+			comms, excluded, described := report.Commentary, report.Excluded, report.AudioDescription
 			analysisData.PerEpisode = append(analysisData.PerEpisode, ripspec.EpisodeAudioAnalysis{
-				EpisodeKey:       in.key,
-				CommentaryTracks: comms,
-				ExcludedTracks:   excluded,
+				EpisodeKey:             in.key,
+				CommentaryTracks:       comms,
+				ExcludedTracks:         excluded,
+				AudioDescriptionTracks: described,
 			})
 			analysisData.CommentaryTracks = append(analysisData.CommentaryTracks, comms...)
 			analysisData.ExcludedTracks = append(analysisData.ExcludedTracks, excluded...)
+			analysisData.AudioDescriptionTracks = append(analysisData.AudioDescriptionTracks, described...)
 		}
 	} else {
-		reason := "commentary disabled"
-		if h.cfg.Commentary.Enabled {
-			reason = "LLM client not configured"
+		skip = &ripspec.StageSkip{
+			Stage:    string(queue.StageAnalysis),
+			Step:     "commentary_detection",
+			Category: ripspec.SkipMissingDependency,
+			Reason:   "LLM client not configured",
+		}
+		switch {
+		case !h.cfg.Commentary.Enabled:
+			skip.Category, skip.Reason = ripspec.SkipDisabled, "commentary disabled"
+		case env.Options.SkipCommentary:
+			skip.Category, skip.Reason = ripspec.SkipDisabled, "item option skip_commentary set"
+		case h.cfg.Offline:
+			skip.Category, skip.Reason = ripspec.SkipDisabled, "offline mode: LLM client disabled"
 		}
 		logger.Info("commentary detection skipped",
 			"decision_type", logs.DecisionCommentaryClassification,
 			"decision_result", "skipped",
-			"decision_reason", reason,
+			"decision_reason", skip.Reason,
+			"skip_category", skip.Category,
 		)
 	}
 
 	if err := sess.MergeSave(func(env *ripspec.Envelope) error {
 		env.Attributes.AudioAnalysis = analysisData
+		if skip != nil {
+			env.RecordSkip(*skip)
+		}
 		return nil
 	}); err != nil {
 		return err
@@ -156,6 +194,7 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 		"stage", "analysis",
 		"commentary_tracks", len(analysisData.CommentaryTracks),
 		"excluded_tracks", len(analysisData.ExcludedTracks),
+		"audio_description_tracks", len(analysisData.AudioDescriptionTracks),
 		"ripped_assets", len(inputs),
 	)
 	return nil
@@ -170,18 +209,12 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 // conservatively preserved as commentary.
 func (h *Handler) detectCommentary(
 	ctx context.Context,
-	sess *stage.Session,
+	logger *slog.Logger,
 	result *ffprobe.Result,
-	path string,
-	fingerprint string,
-	epKey string,
-) ([]ripspec.CommentaryTrackRef, []ripspec.ExcludedTrackRef) {
-	logger := sess.Logger
-	itemID := sess.Item.ID
-	var (
-		comms    []ripspec.CommentaryTrackRef
-		excluded []ripspec.ExcludedTrackRef
-	)
+	t detectTarget,
+) Report {
+	report := Report{Path: t.path, PrimaryIndex: -1}
+	epKey := t.epKey
 
 	// Identify audio streams with both absolute and audio-relative indices.
 	type audioStream struct {
@@ -203,7 +236,7 @@ func (h *Handler) detectCommentary(
 			"decision_result", "skipped",
 			"decision_reason", fmt.Sprintf("audio_streams=%d, need >1", len(audioStreams)),
 		)
-		return nil, nil
+		return report
 	}
 
 	selection := audio.Select(result.Streams, logger)
@@ -214,8 +247,9 @@ func (h *Handler) detectCommentary(
 			"decision_result", "skipped",
 			"decision_reason", "no primary audio selected",
 		)
-		return nil, nil
+		return report
 	}
+	report.PrimaryIndex = primaryAudioIdx
 
 	candidateCount := len(audioStreams) - 1
 	logger.Info("commentary detection plan",
@@ -227,12 +261,8 @@ func (h *Handler) detectCommentary(
 		"candidate_tracks", candidateCount,
 	)
 
-	// Language filter first: it needs only ffprobe tags and decides which
-	// candidates are worth transcribing at all.
-	type candidateTrack struct {
-		audioIndex int
-		stream     ffprobe.Stream
-	}
+	// Language and metadata filters first: they need only ffprobe tags and
+	// decide which candidates are worth transcribing at all.
 	var candidates []candidateTrack
 	for _, as := range audioStreams {
 		if as.audioIndex == primaryAudioIdx {
@@ -248,75 +278,29 @@ func (h *Handler) detectCommentary(
 				"track_index", as.absIndex,
 				"audio_index", as.audioIndex,
 			)
-			excluded = append(excluded, ripspec.ExcludedTrackRef{
+			report.exclude(candidateTrack{audioIndex: as.audioIndex, stream: stream}, ripspec.ExcludedTrackRef{
 				Index:  as.audioIndex,
 				Reason: "non-English audio",
-			})
+			}, nil)
 			continue
 		}
 		candidates = append(candidates, candidateTrack{audioIndex: as.audioIndex, stream: stream})
 	}
 	if len(candidates) == 0 {
-		return comms, excluded
+		return report
 	}
 
 	// Primary fingerprint: reuse the shared transcript artifact when episode
 	// identification already produced one; otherwise transcribe the primary
 	// once and record it as the artifact so subtitle generation can reuse it.
-	primaryFP := h.primaryFingerprint(ctx, sess, path, primaryAudioIdx, epKey)
+	primaryFP, primarySpeech := h.primaryFingerprint(ctx, logger, t, primaryAudioIdx)
 
-	// Transcribe ALL candidates in one WhisperX invocation. Each candidate is
-	// transcribed exactly once; the same transcript feeds both the stereo
-	// similarity filter and LLM classification.
-	logger.Info("commentary candidate transcription started",
-		"event_type", "commentary_candidates_transcribe",
-		"episode_key", epKey,
-		"candidate_count", len(candidates),
-	)
-	candidateText := make(map[int]string, len(candidates))
-	if h.transcriber != nil {
-		reqs := make([]transcription.TranscribeRequest, len(candidates))
-		for i, c := range candidates {
-			reqs[i] = transcription.TranscribeRequest{
-				InputPath:  path,
-				AudioIndex: c.audioIndex,
-				Language:   "en",
-				OutputDir:  tempOutputDir(fingerprint, epKey, c.audioIndex),
-				ItemID:     itemID,
-				EpisodeKey: epKey,
-				Purpose:    "commentary_candidate",
-			}
-		}
-		results, err := h.transcriber.TranscribeBatch(ctx, reqs)
-		if err != nil {
-			logger.Warn("candidate transcription batch failed",
-				"event_type", "commentary_detection_failed",
-				"error_hint", "whisperx batch transcription error",
-				"impact", "candidates will be conservatively preserved as commentary",
-				"error", err,
-				"candidate_count", len(candidates),
-			)
-		} else {
-			for i, c := range candidates {
-				text, readErr := os.ReadFile(results[i].SRTPath)
-				if readErr != nil {
-					logger.Warn("failed to read candidate transcript",
-						"event_type", "commentary_detection_failed",
-						"error_hint", "could not read srt file",
-						"impact", "track will be conservatively preserved as commentary",
-						"error", readErr,
-						"audio_index", c.audioIndex,
-					)
-					continue
-				}
-				candidateText[c.audioIndex] = string(text)
-			}
-		}
-	}
+	candidateText := h.candidateTranscripts(ctx, logger, t, candidates)
 
 	for i, c := range candidates {
 		candidateNumber := i + 1
-		text, transcribed := candidateText[c.audioIndex]
+		transcript, transcribed := candidateText[c.audioIndex]
+		text := transcript.text
 
 		// Stereo similarity filter: compare transcript fingerprints so a
 		// stereo downmix of the primary is excluded before LLM classification.
@@ -339,69 +323,281 @@ func (h *Handler) detectCommentary(
 						"decision_reason", fmt.Sprintf("similarity %.3f >= threshold %.3f", sim, h.cfg.Commentary.SimilarityThreshold),
 						"audio_index", c.audioIndex,
 					)
-					excluded = append(excluded, ripspec.ExcludedTrackRef{
+					report.exclude(c, ripspec.ExcludedTrackRef{
 						Index:      c.audioIndex,
 						Reason:     "stereo downmix of primary",
 						Similarity: sim,
-					})
+					}, nil)
 					continue
 				}
 			}
 		}
 
+		// Speech-activity filter: audio description narrates in the
+		// primary's pauses and is set aside before LLM classification.
+		if transcribed {
+			if overlap, isAD := h.audioDescriptionCheck(logger, epKey, c.audioIndex, primarySpeech, transcript.speech); isAD {
+				h.recordAudioDescription(&report, c, overlap)
+				continue
+			}
+		}
+
 		logger.Info("commentary candidate classification",
 			"event_type", "commentary_candidate_classify",
 			"episode_key", epKey,
 			"candidate_number", candidateNumber,
 			"candidate_count", candidateCount,
 		)
-		ref := h.classifyTrack(ctx, logger, c.audioIndex, c.stream, epKey, text, transcribed)
-		if ref != nil {
-			comms = append(comms, *ref)
-		}
+		ref, verdict := h.classifyTrack(ctx, logger, c.audioIndex, c.stream, epKey, text, transcribed)
+		report.classify(c, ref, verdict)
 	}
 
+	sort.Slice(report.Candidates, func(i, j int) bool {
+		return report.Candidates[i].AudioIndex < report.Candidates[j].AudioIndex
+	})
 	logger.Info("commentary detection complete",
 		"event_type", "commentary_detection_complete",
 		"episode_key", epKey,
-		"commentary_tracks", len(comms),
-		"excluded_tracks", len(excluded),
+		"commentary_tracks", len(report.Commentary),
+		"excluded_tracks", len(report.Excluded),
+		"audio_description_tracks", len(report.AudioDescription),
+	)
+	return report
+}
+
+// titleKeyword returns the first configured commentary keyword found in the
+// stream's title tag, compared case-insensitively, or "" when none matches.
+// A match is a hint for the classifier, not a verdict: titles such as
+// "Director's Cut" name the film, not a commentary.
+func (h *Handler) titleKeyword(stream ffprobe.Stream) string {
+	title := strings.ToLower(stream.Tags["title"])
+	if title == "" {
+		return ""
+	}
+	for _, keyword := range h.cfg.Commentary.Keywords {
+		keyword = strings.TrimSpace(keyword)
+		if keyword != "" && strings.Contains(title, strings.ToLower(keyword)) {
+			return keyword
+		}
+	}
+	return ""
+}
+
+// audioDescriptionCheck measures where a candidate speaks relative to the
+// primary track and reports whether the pattern crosses the configured
+// audio-description thresholds. Tracks without timed speech on both sides
+// are never excluded here.
+func (h *Handler) audioDescriptionCheck(
+	logger *slog.Logger,
+	epKey string,
+	audioIndex int,
+	primary, candidate []speechSpan,
+) (speechOverlap, bool) {
+	overlap, ok := measureSpeechOverlap(primary, candidate)
+	if !ok {
+		return overlap, false
+	}
+	minGap, maxOverlap := h.cfg.Commentary.AudioDescriptionMinGapRatio, h.cfg.Commentary.AudioDescriptionMaxOverlap
+	isAD := overlap.audioDescription(minGap, maxOverlap)
+	result := "kept"
+	switch {
+	case isAD && h.cfg.Commentary.RetainAudioDescription:
+		result = "audio_description_retained"
+	case isAD:
+		result = "excluded"
+	}
+	logger.Info("speech overlap check completed",
+		"decision_type", logs.DecisionCommentarySpeechOverlap,
+		"decision_result", result,
+		"decision_reason", fmt.Sprintf("gap speech %.3f (min %.3f), primary overlap %.3f (max %.3f)",
+			overlap.GapRatio, minGap, overlap.Overlap, maxOverlap),
+		"episode_key", epKey,
+		"audio_index", audioIndex,
+		"gap_ratio", overlap.GapRatio,
+		"overlap", overlap.Overlap,
+		"min_gap_ratio", minGap,
+		"max_overlap", maxOverlap,
 	)
-	return comms, excluded
+	return overlap, isAD
+}
+
+// recordAudioDescription records a detected audio-description track: kept
+// as a labeled secondary track when commentary.retain_audio_description is
+// set, excluded otherwise.
+func (h *Handler) recordAudioDescription(report *Report, c candidateTrack, overlap speechOverlap) {
+	reason := fmt.Sprintf("audio description (gap speech %.2f, overlap %.2f)", overlap.GapRatio, overlap.Overlap)
+	if h.cfg.Commentary.RetainAudioDescription {
+		report.describe(c, ripspec.AudioDescriptionTrackRef{
+			Index:    c.audioIndex,
+			GapRatio: overlap.GapRatio,
+			Overlap:  overlap.Overlap,
+			Reason:   reason,
+		})
+		return
+	}
+	report.exclude(c, ripspec.ExcludedTrackRef{Index: c.audioIndex, Reason: reason}, &overlap)
+}
+
+// candidateTranscript is a candidate's transcript text and the speech
+// activity derived from its cue timings.
+type candidateTranscript struct {
+	text   string
+	speech []speechSpan
+}
+
+// candidateTrack is a non-primary audio stream considered for commentary.
+type candidateTrack struct {
+	audioIndex int
+	stream     ffprobe.Stream
+}
+
+// candidateTranscripts returns the transcript text of each candidate keyed
+// by audio index. Candidates are transcribed together in one WhisperX
+// invocation, exactly once; the same transcript feeds both the stereo
+// similarity filter and LLM classification. With a stream cache, transcripts
+// of an unchanged rip are reused and only uncached candidates are
+// transcribed. Candidates missing from the result failed transcription.
+func (h *Handler) candidateTranscripts(
+	ctx context.Context,
+	logger *slog.Logger,
+	t detectTarget,
+	candidates []candidateTrack,
+) map[int]candidateTranscript {
+	path, epKey := t.path, t.epKey
+	candidateText := make(map[int]candidateTranscript, len(candidates))
+	if h.transcriber == nil {
+		return candidateText
+	}
+	minConfidence := h.cfg.Subtitles.WhisperXMinConfidence
+
+	var hash string
+	pending := candidates
+	if h.streamCache != nil {
+		var err error
+		if hash, err = fingerprint.ContentHash([]string{path}); err != nil {
+			logger.Warn("commentary stream cache unavailable for rip",
+				"event_type", "commentary_stream_cache_error",
+				"error_hint", "could not hash ripped file",
+				"impact", "candidate tracks are transcribed without the cache",
+				"error", err,
+			)
+		} else {
+			pending = nil
+			cached, _, _ := h.streamCache.Get(hash)
+			for _, c := range candidates {
+				if entry, ok := cached.Streams[streamKey(c.audioIndex, minConfidence)]; ok {
+					candidateText[c.audioIndex] = candidateTranscript{text: entry.Transcript, speech: entry.Speech}
+					continue
+				}
+				pending = append(pending, c)
+			}
+			reason := "rip content unchanged since the cached analysis"
+			if len(pending) == len(candidates) {
+				reason = "no cached analysis for this rip content"
+			}
+			logger.Info("commentary stream cache checked",
+				"decision_type", logs.DecisionCommentaryStreamCache,
+				"decision_result", fmt.Sprintf("%d of %d cached", len(candidates)-len(pending), len(candidates)),
+				"decision_reason", reason,
+				"episode_key", epKey,
+			)
+		}
+	}
+	if len(pending) == 0 {
+		return candidateText
+	}
+
+	logger.Info("commentary candidate transcription started",
+		"event_type", "commentary_candidates_transcribe",
+		"episode_key", epKey,
+		"candidate_count", len(pending),
+	)
+	reqs := make([]transcription.TranscribeRequest, len(pending))
+	for i, c := range pending {
+		reqs[i] = transcription.TranscribeRequest{
+			InputPath:  path,
+			AudioIndex: c.audioIndex,
+			Language:   "en",
+			OutputDir:  t.candidateOutputDir(c.audioIndex),
+			ItemID:     t.itemID,
+			EpisodeKey: epKey,
+			Purpose:    "commentary_candidate",
+		}
+	}
+	results, err := transcribeBatch(ctx, h.transcriber, reqs)
+	if err != nil {
+		logger.Warn("candidate transcription batch failed",
+			"event_type", "commentary_detection_failed",
+			"error_hint", "whisperx batch transcription error",
+			"impact", "candidates will be conservatively preserved as commentary",
+			"error", err,
+			"candidate_count", len(pending),
+		)
+		return candidateText
+	}
+	fresh := make(map[string]candidateTranscript, len(pending))
+	for i, c := range pending {
+		text, readErr := readCandidateTranscript(results[i], minConfidence)
+		if readErr != nil {
+			logger.Warn("failed to read candidate transcript",
+				"event_type", "commentary_detection_failed",
+				"error_hint", "could not read srt file",
+				"impact", "track will be conservatively preserved as commentary",
+				"error", readErr,
+				"audio_index", c.audioIndex,
+			)
+			continue
+		}
+		transcript := candidateTranscript{text: text}
+		if cues, err := srtutil.ParseFile(results[i].SRTPath); err == nil {
+			transcript.speech = speechSpans(cues)
+		}
+		candidateText[c.audioIndex] = transcript
+		fresh[streamKey(c.audioIndex, minConfidence)] = transcript
+	}
+	if hash != "" && len(fresh) > 0 {
+		if err := storeTranscripts(h.streamCache, hash, path, fresh); err != nil {
+			logger.Warn("commentary stream cache write failed",
+				"event_type", "commentary_stream_cache_error",
+				"error_hint", err.Error(),
+				"impact", "the next analysis of this rip transcribes its candidates again",
+			)
+		}
+	}
+	return candidateText
 }
 
 // primaryFingerprint returns the transcript fingerprint of the primary audio
 // track. It reuses the shared per-episode transcript artifact when one exists
 // (recorded by episode identification); otherwise it transcribes the primary
 // once into the staging transcripts directory and records the artifact so
-// subtitle generation can reuse it. Returns nil (logged) on failure --
-// callers then skip the similarity filter, matching the previous behavior.
+// subtitle generation can reuse it. The primary's speech activity comes from
+// the same transcript's cue timings. Returns nil (logged) on failure --
+// callers then skip the similarity and speech-overlap filters.
 func (h *Handler) primaryFingerprint(
 	ctx context.Context,
-	sess *stage.Session,
-	path string,
+	logger *slog.Logger,
+	t detectTarget,
 	primaryIdx int,
-	epKey string,
-) *textutil.Fingerprint {
-	logger := sess.Logger
-
-	if asset, ok := sess.Env.Assets.FindAsset(ripspec.AssetKindTranscript, epKey); ok && asset.IsCompleted() {
-		if text, err := os.ReadFile(asset.Path); err == nil {
+) (*textutil.Fingerprint, []speechSpan) {
+	epKey := t.epKey
+	if t.transcriptPath != "" {
+		if text, err := os.ReadFile(t.transcriptPath); err == nil {
 			logger.Info("primary transcript artifact reused",
 				"decision_type", logs.DecisionCommentaryStereoFilter,
 				"decision_result", "artifact_reused",
 				"decision_reason", "canonical transcript already produced earlier in the pipeline",
 				"episode_key", epKey,
-				"srt_path", asset.Path,
+				"srt_path", t.transcriptPath,
 			)
-			return textutil.NewFingerprint(string(text))
+			return textutil.NewFingerprint(string(text)), speechSpans(srtutil.Parse(string(text)))
 		}
 	}
 
 	if h.transcriber == nil {
-		return nil
+		return nil, nil
 	}
-	stagingRoot, err := sess.Item.StagingRoot(h.cfg.Paths.StagingDir)
+	outputDir, err := t.transcriptDir()
 	if err != nil {
 		logger.Warn("primary transcription skipped",
 			"event_type", "commentary_detection_failed",
@@ -409,14 +605,14 @@ func (h *Handler) primaryFingerprint(
 			"impact", "similarity filter disabled for this item",
 			"error", err,
 		)
-		return nil
+		return nil, nil
 	}
-	result, err := h.transcriber.Transcribe(ctx, transcription.TranscribeRequest{
-		InputPath:  path,
+	result, err := transcribeOne(ctx, h.transcriber, transcription.TranscribeRequest{
+		InputPath:  t.path,
 		AudioIndex: primaryIdx,
 		Language:   "en",
-		OutputDir:  filepath.Join(stagingRoot, "transcripts", epKey),
-		ItemID:     sess.Item.ID,
+		OutputDir:  outputDir,
+		ItemID:     t.itemID,
 		EpisodeKey: epKey,
 		Purpose:    "commentary_similarity_primary",
 	})
@@ -427,19 +623,17 @@ func (h *Handler) primaryFingerprint(
 			"impact", "similarity filter disabled for this item",
 			"error", err,
 		)
-		return nil
+		return nil, nil
 	}
-	if err := sess.SaveAssetSuccess(ripspec.AssetKindTranscript, ripspec.Asset{
-		EpisodeKey: epKey,
-		Path:       result.SRTPath,
-		Status:     ripspec.AssetStatusCompleted,
-	}); err != nil {
-		logger.Warn("transcript artifact record failed",
-			"event_type", "commentary_detection_failed",
-			"error_hint", "could not persist transcript asset",
-			"impact", "later stages will re-transcribe the primary track",
-			"error", err,
-		)
+	if t.recordTranscript != nil {
+		if err := t.recordTranscript(result.SRTPath); err != nil {
+			logger.Warn("transcript artifact record failed",
+				"event_type", "commentary_detection_failed",
+				"error_hint", "could not persist transcript asset",
+				"impact", "later stages will re-transcribe the primary track",
+				"error", err,
+			)
+		}
 	}
 	text, err := os.ReadFile(result.SRTPath)
 	if err != nil {
@@ -449,9 +643,9 @@ func (h *Handler) primaryFingerprint(
 			"impact", "similarity filter disabled for this item",
 			"error", err,
 		)
-		return nil
+		return nil, nil
 	}
-	return textutil.NewFingerprint(string(text))
+	return textutil.NewFingerprint(string(text)), speechSpans(srtutil.Parse(string(text)))
 }
 
 // classifyTrack sends a candidate track's transcript to the LLM for
@@ -459,7 +653,7 @@ func (h *Handler) primaryFingerprint(
 // batch transcription; transcribed=false means that transcription failed and
 // the track is conservatively preserved as commentary. Returns a
 // CommentaryTrackRef if the track is classified as commentary (or on error,
-// conservatively).
+// conservatively), along with the LLM's verdict when one was returned.
 func (h *Handler) classifyTrack(
 	ctx context.Context,
 	logger *slog.Logger,
@@ -468,9 +662,9 @@ func (h *Handler) classifyTrack(
 	epKey string,
 	transcript string,
 	transcribed bool,
-) *ripspec.CommentaryTrackRef {
+) (*ripspec.CommentaryTrackRef, commentaryLLMResponse) {
 	if h.llmClient == nil {
-		return nil
+		return nil, commentaryLLMResponse{}
 	}
 	if !transcribed {
 		logger.Warn("commentary transcription unavailable, conservatively marking as commentary",
@@ -483,17 +677,19 @@ func (h *Handler) classifyTrack(
 			Index:      idx,
 			Confidence: 0,
 			Reason:     "transcription failed",
-		}
+		}, commentaryLLMResponse{}
 	}
 
 	// Build user prompt.
-	userPrompt := buildCommentaryUserPrompt(stream, transcript)
+	keyword := h.titleKeyword(stream)
+	userPrompt := buildCommentaryUserPrompt(stream, keyword, transcript)
 
 	logger.Info("LLM commentary classification started",
 		"event_type", "commentary_llm_start",
 		"episode_key", epKey,
 		"audio_index", idx,
 		"stream_index", stream.Index,
+		"title_keyword", keyword,
 	)
 	llmStart := time.Now()
 	var resp commentaryLLMResponse
@@ -509,7 +705,7 @@ func (h *Handler) classifyTrack(
 			Index:      idx,
 			Confidence: 0,
 			Reason:     fmt.Sprintf("llm classification failed: %v", err),
-		}
+		}, commentaryLLMResponse{}
 	}
 
 	logger.Info("LLM commentary classification completed",
@@ -532,7 +728,7 @@ func (h *Handler) classifyTrack(
 			Index:      idx,
 			Confidence: resp.Confidence,
 			Reason:     resp.Reason,
-		}
+		}, resp
 	}
 
 	logger.Info("track classified as not commentary",
@@ -542,7 +738,18 @@ func (h *Handler) classifyTrack(
 		"track_index", idx,
 		"confidence", resp.Confidence,
 	)
-	return nil
+	return nil, resp
+}
+
+// readCandidateTranscript returns a candidate's transcript for the
+// similarity filter and LLM prompt: the raw SRT by default, or the
+// confidence-filtered plain text when minConfidence > 0.
+func readCandidateTranscript(result *transcription.TranscribeResult, minConfidence float64) (string, error) {
+	if minConfidence <= 0 {
+		data, err := os.ReadFile(result.SRTPath)
+		return string(data), err
+	}
+	return transcription.TranscriptText(result.SRTPath, result.JSONPath, minConfidence)
 }
 
 // buildCommentaryUserPrompt constructs the user prompt for commentary LLM
@@ -556,7 +763,7 @@ func allowedAudioLanguage(tags map[string]string) (string, bool) {
 	return raw, language.ToISO2(raw) == "en"
 }
 
-func buildCommentaryUserPrompt(stream ffprobe.Stream, transcript string) string {
+func buildCommentaryUserPrompt(stream ffprobe.Stream, keyword, transcript string) string {
 	title := strings.TrimSpace(stream.Tags["title"])
 
 	// Truncate transcript if needed.
@@ -568,6 +775,9 @@ func buildCommentaryUserPrompt(stream ffprobe.Stream, transcript string) string
 	if title != "" {
 		_, _ = fmt.Fprintf(&b, "Title: %s\n\n", title)
 	}
+	if keyword != "" {
+		_, _ = fmt.Fprintf(&b, "Label hint: the title contains the commentary keyword %q.\n\n", keyword)
+	}
 	_, _ = fmt.Fprintf(&b, "Transcript sample:\n%s", transcript)
 	return b.String()
 }
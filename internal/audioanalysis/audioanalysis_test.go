@@ -1,10 +1,22 @@
 package audioanalysis
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/five82/spindle/internal/config"
+	"github.com/five82/spindle/internal/llm"
 	"github.com/five82/spindle/internal/media/ffprobe"
+	"github.com/five82/spindle/internal/queue"
 	"github.com/five82/spindle/internal/ripspec"
+	"github.com/five82/spindle/internal/srtutil"
+	"github.com/five82/spindle/internal/stage"
+	"github.com/five82/spindle/internal/transcription"
 )
 
 func TestAssetKeys_Movie(t *testing.T) {
@@ -99,7 +111,7 @@ func TestBuildCommentaryUserPrompt_WithTitle(t *testing.T) {
 	stream := ffprobe.Stream{
 		Tags: map[string]string{"title": "Director Commentary"},
 	}
-	prompt := buildCommentaryUserPrompt(stream, "Some transcript text here.")
+	prompt := buildCommentaryUserPrompt(stream, "", "Some transcript text here.")
 
 	if !contains(prompt, "Title: Director Commentary") {
 		t.Errorf("expected title in prompt, got:\n%s", prompt)
@@ -113,7 +125,7 @@ func TestBuildCommentaryUserPrompt_NoTitle(t *testing.T) {
 	stream := ffprobe.Stream{
 		Tags: map[string]string{},
 	}
-	prompt := buildCommentaryUserPrompt(stream, "Transcript.")
+	prompt := buildCommentaryUserPrompt(stream, "", "Transcript.")
 
 	if contains(prompt, "Title:") {
 		t.Errorf("expected no title line, got:\n%s", prompt)
@@ -130,7 +142,7 @@ func TestBuildCommentaryUserPrompt_Truncation(t *testing.T) {
 	}
 
 	stream := ffprobe.Stream{Tags: map[string]string{}}
-	prompt := buildCommentaryUserPrompt(stream, string(long))
+	prompt := buildCommentaryUserPrompt(stream, "", string(long))
 
 	if !contains(prompt, "[truncated]") {
 		t.Error("expected truncation marker in prompt")
@@ -149,3 +161,240 @@ func containsStr(s, substr string) bool {
 	}
 	return false
 }
+
+func TestCandidateTranscriptsReusesStreamCache(t *testing.T) {
+	dir := t.TempDir()
+	rip := filepath.Join(dir, "title_00.mkv")
+	if err := os.WriteFile(rip, []byte("ripped media v1"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cache, err := OpenStreamCache(filepath.Join(dir, "commentary_cache.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var transcribed []int
+	orig := transcribeBatch
+	t.Cleanup(func() { transcribeBatch = orig })
+	transcribeBatch = func(_ context.Context, _ *transcription.Service, reqs []transcription.TranscribeRequest) ([]*transcription.TranscribeResult, error) {
+		results := make([]*transcription.TranscribeResult, len(reqs))
+		for i, req := range reqs {
+			transcribed = append(transcribed, req.AudioIndex)
+			srt := filepath.Join(dir, fmt.Sprintf("a%d.srt", req.AudioIndex))
+			if err := os.WriteFile(srt, []byte(fmt.Sprintf("track %d speech", req.AudioIndex)), 0o644); err != nil {
+				return nil, err
+			}
+			results[i] = &transcription.TranscribeResult{SRTPath: srt}
+		}
+		return results, nil
+	}
+
+	h := New(&config.Config{}, nil, transcription.New(transcription.Params{}, nil))
+	h.SetStreamCache(cache)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	candidates := []candidateTrack{{audioIndex: 1}, {audioIndex: 2}}
+	run := func() map[int]candidateTranscript {
+		return h.candidateTranscripts(context.Background(), logger, detectTarget{path: rip, epKey: "s01_001", workDir: dir}, candidates)
+	}
+
+	first := run()
+	if len(transcribed) != 2 {
+		t.Fatalf("first run transcribed %v, want both candidates", transcribed)
+	}
+	transcribed = nil
+	second := run()
+	if len(transcribed) != 0 {
+		t.Fatalf("second run on unchanged rip transcribed %v, want none", transcribed)
+	}
+	for idx, transcript := range first {
+		if second[idx].text != transcript.text {
+			t.Errorf("audio %d: cached transcript %q, want %q", idx, second[idx].text, transcript.text)
+		}
+	}
+
+	// A changed rip misses the cache and replaces its stale entries.
+	if err := os.WriteFile(rip, []byte("ripped media v2, remuxed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run()
+	if len(transcribed) != 2 {
+		t.Fatalf("changed rip transcribed %v, want both candidates", transcribed)
+	}
+	if cache.Size() != 1 {
+		t.Errorf("cache size = %d, want the stale entry dropped", cache.Size())
+	}
+
+	reopened, err := OpenStreamCache(filepath.Join(dir, "commentary_cache.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reopened.Size() != 1 {
+		t.Errorf("reopened cache size = %d, want 1", reopened.Size())
+	}
+}
+
+func TestMeasureSpeechOverlap(t *testing.T) {
+	primary := speechSpans([]srtutil.Cue{{Start: 0, End: 10}, {Start: 20, End: 30}, {Start: 9, End: 12}})
+	if len(primary) != 2 || primary[0].End != 12 {
+		t.Fatalf("speechSpans merged = %+v", primary)
+	}
+	// 4s of candidate speech: 2s over primary dialogue, 2s in its pause.
+	overlap, ok := measureSpeechOverlap(primary, []speechSpan{{Start: 11, End: 15}})
+	if !ok {
+		t.Fatal("expected overlap to be measurable")
+	}
+	if overlap.GapRatio != 0.75 || overlap.Overlap != 1.0/22 {
+		t.Errorf("overlap = %+v, want gap 0.75 and overlap 1/22", overlap)
+	}
+	if _, ok := measureSpeechOverlap(primary, nil); ok {
+		t.Error("candidate without speech should not be measurable")
+	}
+}
+
+func TestAudioDescriptionCheckFollowsThresholds(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	primary := []speechSpan{{Start: 0, End: 10}, {Start: 20, End: 30}, {Start: 40, End: 50}}
+	// Narration mostly in the pauses, brushing 2s of dialogue: gap ratio
+	// 0.80, primary overlap 0.067.
+	candidate := []speechSpan{{Start: 9.5, End: 14}, {Start: 29, End: 34}, {Start: 45, End: 45.5}}
+
+	cfg := &config.Config{}
+	cfg.Commentary.AudioDescriptionMinGapRatio = 0.90
+	cfg.Commentary.AudioDescriptionMaxOverlap = 0.10
+	h := New(cfg, nil, nil)
+	overlap, isAD := h.audioDescriptionCheck(logger, "s01_001", 1, primary, candidate)
+	if isAD {
+		t.Fatalf("gap ratio %.3f excluded with min 0.90", overlap.GapRatio)
+	}
+
+	cfg.Commentary.AudioDescriptionMinGapRatio = 0.75
+	if _, isAD := h.audioDescriptionCheck(logger, "s01_001", 1, primary, candidate); !isAD {
+		t.Fatal("borderline track kept after lowering min gap ratio to 0.75")
+	}
+
+	cfg.Commentary.AudioDescriptionMaxOverlap = 0.04
+	if _, isAD := h.audioDescriptionCheck(logger, "s01_001", 1, primary, candidate); isAD {
+		t.Fatal("track excluded although it talks over more than the max overlap")
+	}
+}
+
+func TestTitleKeywordHintsClassifier(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Commentary.Keywords = []string{"commentary", "Audiokommentar"}
+	h := New(cfg, nil, nil)
+
+	tests := []struct {
+		name string
+		tags map[string]string
+		want string
+	}{
+		{"default keyword", map[string]string{"title": "Director's Commentary"}, "commentary"},
+		{"custom keyword any case", map[string]string{"title": "AUDIOKOMMENTAR mit Regisseur"}, "Audiokommentar"},
+		{"other metadata ignored", map[string]string{"handler_name": "audiokommentar"}, ""},
+		{"film title", map[string]string{"title": "Director's Cut"}, ""},
+		{"no tags", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := h.titleKeyword(ffprobe.Stream{Tags: tt.tags}); got != tt.want {
+				t.Fatalf("titleKeyword() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	prompt := buildCommentaryUserPrompt(ffprobe.Stream{Tags: map[string]string{"title": "Audiokommentar"}}, "Audiokommentar", "Transcript.")
+	if !contains(prompt, `commentary keyword "Audiokommentar"`) {
+		t.Errorf("expected keyword hint in prompt, got:\n%s", prompt)
+	}
+}
+
+func TestRecordAudioDescriptionRetainsWhenConfigured(t *testing.T) {
+	c := candidateTrack{audioIndex: 2, stream: ffprobe.Stream{Index: 3, Tags: map[string]string{"title": "Descriptive Audio"}}}
+	overlap := speechOverlap{GapRatio: 0.92, Overlap: 0.03}
+
+	cfg := &config.Config{}
+	h := New(cfg, nil, nil)
+	var report Report
+	h.recordAudioDescription(&report, c, overlap)
+	if len(report.Excluded) != 1 || len(report.AudioDescription) != 0 {
+		t.Fatalf("default: excluded=%+v described=%+v, want excluded", report.Excluded, report.AudioDescription)
+	}
+
+	cfg.Commentary.RetainAudioDescription = true
+	report = Report{}
+	h.recordAudioDescription(&report, c, overlap)
+	if len(report.Excluded) != 0 || len(report.AudioDescription) != 1 {
+		t.Fatalf("retain: excluded=%+v described=%+v, want retained", report.Excluded, report.AudioDescription)
+	}
+	if ref := report.AudioDescription[0]; ref.Index != 2 || ref.GapRatio != 0.92 || ref.Overlap != 0.03 {
+		t.Fatalf("retained ref = %+v", ref)
+	}
+	if cr := report.Candidates[0]; cr.Decision != DecisionAudioDescription {
+		t.Fatalf("candidate decision = %q, want %q", cr.Decision, DecisionAudioDescription)
+	}
+}
+
+func TestRunRecordsCommentarySkipReason(t *testing.T) {
+	tests := []struct {
+		name           string
+		enabled        bool
+		skipCommentary bool
+		withLLM        bool
+		category       string
+		reason         string
+	}{
+		{"disabled in config", false, false, true, ripspec.SkipDisabled, "commentary disabled"},
+		{"item option", true, true, true, ripspec.SkipDisabled, "item option skip_commentary set"},
+		{"no LLM client", true, false, false, ripspec.SkipMissingDependency, "LLM client not configured"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := queue.Open(filepath.Join(t.TempDir(), "queue.db"))
+			if err != nil {
+				t.Fatalf("open queue: %v", err)
+			}
+			defer func() { _ = store.Close() }()
+			item, err := store.NewDisc("Test", "fp1")
+			if err != nil {
+				t.Fatalf("new disc: %v", err)
+			}
+			env := ripspec.Envelope{Version: ripspec.CurrentVersion, Metadata: ripspec.Metadata{MediaType: "movie"}}
+			env.Options.SkipCommentary = tt.skipCommentary
+			env.Assets.AddAsset(ripspec.AssetKindRipped, ripspec.Asset{EpisodeKey: "main", Path: "/staging/main.mkv", Status: ripspec.AssetStatusCompleted})
+			if item.RipSpecData, err = env.Encode(); err != nil {
+				t.Fatal(err)
+			}
+			if err := store.UpdateWorkState(item); err != nil {
+				t.Fatalf("update work state: %v", err)
+			}
+
+			cfg := &config.Config{}
+			cfg.Commentary.Enabled = tt.enabled
+			var client *llm.Client
+			if tt.withLLM {
+				client = llm.New(config.LLMConfig{APIKey: "test-key"}, nil)
+			}
+			sess, err := stage.NewSession(context.Background(), store, item, nil)
+			if err != nil {
+				t.Fatalf("NewSession: %v", err)
+			}
+			sess.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+			if err := New(cfg, client, nil).Run(context.Background(), sess); err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+
+			stored, err := store.GetByID(item.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			persisted, err := ripspec.Parse(stored.RipSpecData)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := ripspec.StageSkip{Stage: "analysis", Step: "commentary_detection", Category: tt.category, Reason: tt.reason}
+			if skips := persisted.Attributes.StageSkips; len(skips) != 1 || skips[0] != want {
+				t.Fatalf("stage skips = %+v, want [%+v]", skips, want)
+			}
+		})
+	}
+}
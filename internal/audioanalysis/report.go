@@ -0,0 +1,174 @@
+package audioanalysis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"github.com/five82/spindle/internal/language"
+	"github.com/five82/spindle/internal/ripspec"
+	"github.com/five82/spindle/internal/stage"
+)
+
+// Candidate decisions reported per non-primary audio track.
+const (
+	DecisionCommentary    = "commentary"
+	DecisionNotCommentary = "not_commentary"
+	DecisionExcluded      = "excluded"
+	// DecisionAudioDescription marks a retained audio-description track.
+	DecisionAudioDescription = "audio_description"
+)
+
+// CandidateReport explains the decision for one non-primary audio track.
+type CandidateReport struct {
+	AudioIndex  int     `json:"audio_index"`
+	StreamIndex int     `json:"stream_index"`
+	Title       string  `json:"title,omitempty"`
+	Language    string  `json:"language,omitempty"`
+	Decision    string  `json:"decision"`
+	Reason      string  `json:"reason,omitempty"`
+	Confidence  float64 `json:"confidence,omitempty"`
+	Similarity  float64 `json:"similarity,omitempty"`
+	GapRatio    float64 `json:"gap_ratio,omitempty"`
+	Overlap     float64 `json:"overlap,omitempty"`
+}
+
+// Report is the commentary detection outcome for one ripped file.
+// Commentary, Excluded, and AudioDescription are what the analysis stage
+// records in the rip spec; Candidates explains every non-primary track in
+// audio order.
+type Report struct {
+	Path             string                             `json:"path"`
+	PrimaryIndex     int                                `json:"primary_audio_index"`
+	Commentary       []ripspec.CommentaryTrackRef       `json:"commentary_tracks,omitempty"`
+	Excluded         []ripspec.ExcludedTrackRef         `json:"excluded_tracks,omitempty"`
+	AudioDescription []ripspec.AudioDescriptionTrackRef `json:"audio_description_tracks,omitempty"`
+	Candidates       []CandidateReport                  `json:"candidates,omitempty"`
+}
+
+func newCandidateReport(c candidateTrack) CandidateReport {
+	return CandidateReport{
+		AudioIndex:  c.audioIndex,
+		StreamIndex: c.stream.Index,
+		Title:       strings.TrimSpace(c.stream.Tags["title"]),
+		Language:    language.ExtractFromTags(c.stream.Tags),
+	}
+}
+
+func (r *Report) exclude(c candidateTrack, ref ripspec.ExcludedTrackRef, overlap *speechOverlap) {
+	r.Excluded = append(r.Excluded, ref)
+	cr := newCandidateReport(c)
+	cr.Decision = DecisionExcluded
+	cr.Reason = ref.Reason
+	cr.Similarity = ref.Similarity
+	if overlap != nil {
+		cr.GapRatio, cr.Overlap = overlap.GapRatio, overlap.Overlap
+	}
+	r.Candidates = append(r.Candidates, cr)
+}
+
+func (r *Report) describe(c candidateTrack, ref ripspec.AudioDescriptionTrackRef) {
+	r.AudioDescription = append(r.AudioDescription, ref)
+	cr := newCandidateReport(c)
+	cr.Decision, cr.Reason = DecisionAudioDescription, ref.Reason
+	cr.GapRatio, cr.Overlap = ref.GapRatio, ref.Overlap
+	r.Candidates = append(r.Candidates, cr)
+}
+
+func (r *Report) classify(c candidateTrack, ref *ripspec.CommentaryTrackRef, verdict commentaryLLMResponse) {
+	cr := newCandidateReport(c)
+	if ref != nil {
+		r.Commentary = append(r.Commentary, *ref)
+		cr.Decision, cr.Reason, cr.Confidence = DecisionCommentary, ref.Reason, ref.Confidence
+	} else {
+		cr.Decision, cr.Reason, cr.Confidence = DecisionNotCommentary, verdict.Reason, verdict.Confidence
+	}
+	r.Candidates = append(r.Candidates, cr)
+}
+
+// detectTarget is one ripped file to analyze and how detection may reuse and
+// record the primary transcript.
+type detectTarget struct {
+	path   string
+	epKey  string
+	itemID int64
+	// fingerprint scopes candidate transcription output under /tmp.
+	fingerprint string
+	// workDir, when set, holds candidate transcription output instead.
+	workDir string
+	// transcriptPath is an existing primary transcript; empty when none was
+	// produced earlier in the pipeline.
+	transcriptPath string
+	// transcriptDir resolves where a fresh primary transcript is written.
+	transcriptDir func() (string, error)
+	// recordTranscript persists a fresh primary transcript for later stages;
+	// nil in preview, which never mutates item state.
+	recordTranscript func(srtPath string) error
+}
+
+func (t detectTarget) candidateOutputDir(audioIndex int) string {
+	if t.workDir != "" {
+		return filepath.Join(t.workDir, fmt.Sprintf("audio-%d", audioIndex))
+	}
+	return tempOutputDir(t.fingerprint, t.epKey, audioIndex)
+}
+
+// sessionTarget describes a ripped asset of the session's item: the shared
+// transcript artifact is reused when present, and a fresh primary transcript
+// goes to the staging transcripts directory and is recorded as the artifact.
+func (h *Handler) sessionTarget(sess *stage.Session, epKey, path string) detectTarget {
+	t := detectTarget{
+		path:        path,
+		epKey:       epKey,
+		itemID:      sess.Item.ID,
+		fingerprint: sess.Item.DiscFingerprint,
+		transcriptDir: func() (string, error) {
+			root, err := sess.Item.StagingRoot(h.cfg.Paths.StagingDir)
+			if err != nil {
+				return "", err
+			}
+			return filepath.Join(root, "transcripts", epKey), nil
+		},
+		recordTranscript: func(srtPath string) error {
+			return sess.SaveAssetSuccess(ripspec.AssetKindTranscript, ripspec.Asset{
+				EpisodeKey: epKey,
+				Path:       srtPath,
+				Status:     ripspec.AssetStatusCompleted,
+			})
+		},
+	}
+	if asset, ok := sess.Env.Assets.FindAsset(ripspec.AssetKindTranscript, epKey); ok && asset.IsCompleted() {
+		t.transcriptPath = asset.Path
+	}
+	return t
+}
+
+// Preview runs the analysis stage's commentary detection on one file and
+// returns the per-candidate report without touching any queue item: every
+// transcript is written under workDir and nothing is recorded. transcriptPath
+// optionally names an existing primary transcript to reuse.
+func (h *Handler) Preview(ctx context.Context, logger *slog.Logger, path, transcriptPath, workDir string) (*Report, error) {
+	if h.llmClient == nil {
+		return nil, errors.New("commentary detection requires a configured LLM")
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	result, err := inspectMedia(ctx, "", path)
+	if err != nil {
+		return nil, fmt.Errorf("ffprobe %s: %w", path, err)
+	}
+	report := h.detectCommentary(ctx, logger, result, detectTarget{
+		path:           path,
+		epKey:          "preview",
+		workDir:        filepath.Join(workDir, "candidates"),
+		transcriptPath: transcriptPath,
+		transcriptDir: func() (string, error) {
+			return filepath.Join(workDir, "primary"), nil
+		},
+	})
+	return &report, nil
+}
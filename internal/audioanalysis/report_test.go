@@ -0,0 +1,193 @@
+package audioanalysis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/five82/spindle/internal/config"
+	"github.com/five82/spindle/internal/llm"
+	"github.com/five82/spindle/internal/media/ffprobe"
+	"github.com/five82/spindle/internal/queue"
+	"github.com/five82/spindle/internal/ripspec"
+	"github.com/five82/spindle/internal/stage"
+	"github.com/five82/spindle/internal/transcription"
+)
+
+// stubCommentaryPipeline replaces probing and transcription with a fixed
+// five-track file: primary, stereo downmix, director commentary, a French
+// dub, and audio description narrated in the primary's pauses.
+func stubCommentaryPipeline(t *testing.T) {
+	t.Helper()
+	const dialogue = "the ship sails north tonight captain holds the wheel steady against the storm"
+	srts := map[int]string{
+		0: "1\n00:00:00,000 --> 00:00:10,000\n" + dialogue + "\n\n2\n00:00:20,000 --> 00:00:30,000\nland ahead\n",
+		1: "1\n00:00:00,000 --> 00:00:10,000\n" + dialogue + "\n\n2\n00:00:20,000 --> 00:00:30,000\nland ahead\n",
+		2: "1\n00:00:00,000 --> 00:00:30,000\nwhen we shot this the director wanted real rain on the set\n",
+		4: "1\n00:00:11,000 --> 00:00:19,000\na door creaks open and a woman steps into the lamplight\n",
+	}
+	writeSRT := func(dir string, idx int) (*transcription.TranscribeResult, error) {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+		path := filepath.Join(dir, fmt.Sprintf("a%d.srt", idx))
+		if err := os.WriteFile(path, []byte(srts[idx]), 0o644); err != nil {
+			return nil, err
+		}
+		return &transcription.TranscribeResult{SRTPath: path}, nil
+	}
+
+	origInspect, origBatch, origOne := inspectMedia, transcribeBatch, transcribeOne
+	t.Cleanup(func() { inspectMedia, transcribeBatch, transcribeOne = origInspect, origBatch, origOne })
+	inspectMedia = func(context.Context, string, string) (*ffprobe.Result, error) {
+		return &ffprobe.Result{Streams: []ffprobe.Stream{
+			{Index: 0, CodecType: "video", CodecName: "h264"},
+			{Index: 1, CodecType: "audio", CodecName: "truehd", Channels: 6, Tags: map[string]string{"language": "eng"}},
+			{Index: 2, CodecType: "audio", CodecName: "ac3", Channels: 2, Tags: map[string]string{"language": "eng", "title": "Stereo"}},
+			{Index: 3, CodecType: "audio", CodecName: "ac3", Channels: 2, Tags: map[string]string{"language": "eng", "title": "Commentary"}},
+			{Index: 4, CodecType: "audio", CodecName: "ac3", Channels: 2, Tags: map[string]string{"language": "fre"}},
+			{Index: 5, CodecType: "audio", CodecName: "ac3", Channels: 2, Tags: map[string]string{"language": "eng"}},
+		}}, nil
+	}
+	transcribeOne = func(_ context.Context, _ *transcription.Service, req transcription.TranscribeRequest) (*transcription.TranscribeResult, error) {
+		return writeSRT(req.OutputDir, req.AudioIndex)
+	}
+	transcribeBatch = func(_ context.Context, _ *transcription.Service, reqs []transcription.TranscribeRequest) ([]*transcription.TranscribeResult, error) {
+		results := make([]*transcription.TranscribeResult, len(reqs))
+		for i, req := range reqs {
+			result, err := writeSRT(req.OutputDir, req.AudioIndex)
+			if err != nil {
+				return nil, err
+			}
+			results[i] = result
+		}
+		return results, nil
+	}
+}
+
+// commentaryLLM answers "commentary" for transcripts mentioning the director.
+func commentaryLLM(t *testing.T) *llm.Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		answer := `{"decision": "not_commentary", "confidence": 0.9, "reason": "film audio only"}`
+		if strings.Contains(string(body), "director") {
+			answer = `{"decision": "commentary", "confidence": 0.95, "reason": "filmmakers discuss the shoot"}`
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]any{"content": answer}}},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return llm.New(config.LLMConfig{APIKey: "test-key", BaseURL: srv.URL, Model: "test-model", TimeoutSeconds: 10}, nil)
+}
+
+func TestPreviewMatchesRunWithoutPersisting(t *testing.T) {
+	stubCommentaryPipeline(t)
+	dir := t.TempDir()
+	rip := filepath.Join(dir, "main.mkv")
+	if err := os.WriteFile(rip, []byte("ripped"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{}
+	cfg.Paths.StagingDir = filepath.Join(dir, "staging")
+	cfg.Commentary = config.CommentaryConfig{
+		Enabled:                     true,
+		SimilarityThreshold:         0.92,
+		ConfidenceThreshold:         0.80,
+		AudioDescriptionMinGapRatio: 0.80,
+		AudioDescriptionMaxOverlap:  0.10,
+	}
+	h := New(cfg, commentaryLLM(t), transcription.New(transcription.Params{}, nil))
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	store, err := queue.Open(filepath.Join(dir, "queue.db"))
+	if err != nil {
+		t.Fatalf("open queue: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	item, err := store.NewDisc("Test", "fp1")
+	if err != nil {
+		t.Fatalf("new disc: %v", err)
+	}
+	env := ripspec.Envelope{Version: ripspec.CurrentVersion, Metadata: ripspec.Metadata{MediaType: "movie"}}
+	env.Assets.AddAsset(ripspec.AssetKindRipped, ripspec.Asset{EpisodeKey: "main", Path: rip, Status: ripspec.AssetStatusCompleted})
+	if item.RipSpecData, err = env.Encode(); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.UpdateWorkState(item); err != nil {
+		t.Fatalf("update work state: %v", err)
+	}
+
+	report, err := h.Preview(context.Background(), logger, rip, "", filepath.Join(dir, "preview"))
+	if err != nil {
+		t.Fatalf("Preview: %v", err)
+	}
+	wantDecisions := map[int]string{
+		1: DecisionExcluded,   // stereo downmix
+		2: DecisionCommentary, // director commentary
+		3: DecisionExcluded,   // French dub
+		4: DecisionExcluded,   // audio description
+	}
+	if len(report.Candidates) != len(wantDecisions) {
+		t.Fatalf("candidates = %+v, want %d", report.Candidates, len(wantDecisions))
+	}
+	for _, c := range report.Candidates {
+		if c.Decision != wantDecisions[c.AudioIndex] {
+			t.Errorf("audio %d: decision %q (%s), want %q", c.AudioIndex, c.Decision, c.Reason, wantDecisions[c.AudioIndex])
+		}
+	}
+
+	stored, err := store.GetByID(item.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.RipSpecData != item.RipSpecData {
+		t.Fatalf("preview mutated the rip spec:\n%s", stored.RipSpecData)
+	}
+	if _, err := os.Stat(cfg.Paths.StagingDir); !os.IsNotExist(err) {
+		t.Fatalf("preview wrote into staging: %v", err)
+	}
+
+	sess, err := stage.NewSession(context.Background(), store, stored, nil)
+	if err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	sess.Logger = logger
+	if err := h.Run(context.Background(), sess); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	analysis := sess.Env.Attributes.AudioAnalysis
+	if analysis == nil || len(analysis.PerEpisode) != 1 {
+		t.Fatalf("run recorded no per-episode analysis: %+v", analysis)
+	}
+	got := analysis.PerEpisode[0]
+	if !reflect.DeepEqual(got.CommentaryTracks, report.Commentary) {
+		t.Errorf("commentary: run %+v, preview %+v", got.CommentaryTracks, report.Commentary)
+	}
+	// Similarity may differ in the last float bits between runs.
+	if len(got.ExcludedTracks) != len(report.Excluded) {
+		t.Fatalf("excluded: run %+v, preview %+v", got.ExcludedTracks, report.Excluded)
+	}
+	for i, ex := range got.ExcludedTracks {
+		pv := report.Excluded[i]
+		if ex.Index != pv.Index || ex.Reason != pv.Reason || math.Abs(ex.Similarity-pv.Similarity) > 1e-9 {
+			t.Errorf("excluded[%d]: run %+v, preview %+v", i, ex, pv)
+		}
+	}
+	if _, ok := sess.Env.Assets.FindAsset(ripspec.AssetKindTranscript, "main"); !ok {
+		t.Error("run did not record the primary transcript artifact")
+	}
+}
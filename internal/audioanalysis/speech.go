@@ -0,0 +1,97 @@
+package audioanalysis
+
+import (
+	"sort"
+
+	"github.com/five82/spindle/internal/srtutil"
+)
+
+// speechSpan is one interval of transcribed speech, in seconds.
+type speechSpan struct {
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
+
+// speechSpans derives speech activity from transcript cue timings, merging
+// cues that touch or overlap into sorted, disjoint spans.
+func speechSpans(cues []srtutil.Cue) []speechSpan {
+	spans := make([]speechSpan, 0, len(cues))
+	for _, cue := range cues {
+		if cue.End > cue.Start {
+			spans = append(spans, speechSpan{Start: cue.Start, End: cue.End})
+		}
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].Start < spans[j].Start })
+	merged := spans[:0]
+	for _, s := range spans {
+		if n := len(merged); n > 0 && s.Start <= merged[n-1].End {
+			merged[n-1].End = max(merged[n-1].End, s.End)
+			continue
+		}
+		merged = append(merged, s)
+	}
+	return merged
+}
+
+func totalSpeech(spans []speechSpan) float64 {
+	var total float64
+	for _, s := range spans {
+		total += s.End - s.Start
+	}
+	return total
+}
+
+// sharedSpeech returns the time two sorted, disjoint span lists speak at
+// once.
+func sharedSpeech(a, b []speechSpan) float64 {
+	var shared float64
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		start, end := max(a[i].Start, b[j].Start), min(a[i].End, b[j].End)
+		if end > start {
+			shared += end - start
+		}
+		if a[i].End < b[j].End {
+			i++
+		} else {
+			j++
+		}
+	}
+	return shared
+}
+
+// speechOverlap describes where a candidate track speaks relative to the
+// primary track.
+type speechOverlap struct {
+	// GapRatio is the fraction of the candidate's speech that falls in
+	// primary-track silence.
+	GapRatio float64
+	// Overlap is the fraction of the primary's speech the candidate also
+	// speaks over.
+	Overlap float64
+}
+
+// measureSpeechOverlap compares candidate speech activity with the primary's.
+// It reports false when either track has no timed speech to compare.
+func measureSpeechOverlap(primary, candidate []speechSpan) (speechOverlap, bool) {
+	primaryTotal, candidateTotal := totalSpeech(primary), totalSpeech(candidate)
+	if primaryTotal <= 0 || candidateTotal <= 0 {
+		return speechOverlap{}, false
+	}
+	shared := sharedSpeech(primary, candidate)
+	return speechOverlap{
+		GapRatio: (candidateTotal - shared) / candidateTotal,
+		Overlap:  shared / primaryTotal,
+	}, true
+}
+
+// audioDescription reports whether the measured pattern looks like audio
+// description: narration placed in the primary's pauses that rarely talks
+// over dialogue. Commentary, by contrast, runs over the film's dialogue.
+//
+// Only narration-only stems are caught. A mixed AD track carries the full
+// soundtrack under the narration, so its transcript repeats the primary's
+// dialogue, overlaps it heavily, and is kept like any other track.
+func (o speechOverlap) audioDescription(minGapRatio, maxOverlap float64) bool {
+	return o.GapRatio >= minGapRatio && o.Overlap <= maxOverlap
+}
@@ -0,0 +1,54 @@
+package audioanalysis
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/five82/spindle/internal/jsoncache"
+)
+
+// streamTranscript is the cached analysis of one audio stream.
+type streamTranscript struct {
+	Transcript string       `json:"transcript"`
+	Speech     []speechSpan `json:"speech,omitempty"`
+}
+
+// ripTranscripts is the cached analysis of one rip's audio streams, keyed by
+// streamKey.
+type ripTranscripts struct {
+	Path    string                      `json:"path"`
+	Streams map[string]streamTranscript `json:"streams"`
+}
+
+// StreamCache is a JSON file-backed cache of per-stream commentary analysis
+// keyed on rip content (fingerprint.ContentHash), so re-running analysis on
+// an unchanged rip reuses the candidate transcripts instead of invoking
+// WhisperX again. A changed file hashes to a new key; storing it drops the
+// stale entry recorded for the same path.
+type StreamCache = jsoncache.Store[ripTranscripts]
+
+// OpenStreamCache loads or creates a stream cache at path.
+func OpenStreamCache(path string) (*StreamCache, error) {
+	return jsoncache.Open[ripTranscripts](path, 0)
+}
+
+// storeTranscripts adds fresh transcripts for the rip at path to its cached
+// entry.
+func storeTranscripts(c *StreamCache, hash, path string, fresh map[string]candidateTranscript) error {
+	rip, _, _ := c.Get(hash)
+	streams := make(map[string]streamTranscript, len(rip.Streams)+len(fresh))
+	maps.Copy(streams, rip.Streams)
+	for key, t := range fresh {
+		streams[key] = streamTranscript{Transcript: t.text, Speech: t.speech}
+	}
+	return c.Put(hash, ripTranscripts{Path: path, Streams: streams}, func(_ string, stale ripTranscripts) bool {
+		return stale.Path == path
+	})
+}
+
+// streamKey identifies one audio stream's transcript within a rip. The
+// confidence filter is part of the key because it changes the transcript
+// text.
+func streamKey(audioIndex int, minConfidence float64) string {
+	return fmt.Sprintf("a%d:c%.3f", audioIndex, minConfidence)
+}
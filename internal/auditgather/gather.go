@@ -760,8 +760,10 @@ func probeBestAsset(ctx context.Context, env *ripspec.Envelope, episodeKey strin
 	return MediaFileProbe{}
 }
 
+// probeFile probes tolerantly: an audit would rather report the streams of
+// a damaged file, marked partial, than nothing.
 func probeFile(ctx context.Context, path, role, episodeKey string) MediaFileProbe {
-	result, err := ffprobe.Inspect(ctx, "", path)
+	result, err := ffprobe.InspectWithOptions(ctx, "", path, ffprobe.Options{Tolerant: true})
 	if err != nil {
 		return MediaFileProbe{
 			Path:       path,
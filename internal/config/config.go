@@ -12,6 +12,11 @@ type Config struct {
 	// Empty when using defaults only (no config file found).
 	SourcePath string `toml:"-"`
 
+	// Offline disables every external service client (TMDB, OpenRouter,
+	// OpenSubtitles, Jellyfin, ntfy, KeyDB download) so the pipeline runs
+	// against deterministic stubs. Intended for development, CI, and demos.
+	Offline bool `toml:"offline"`
+
 	Paths         PathsConfig         `toml:"paths"`
 	API           APIConfig           `toml:"api"`
 	TMDB          TMDBConfig          `toml:"tmdb"`
@@ -20,11 +25,18 @@ type Config struct {
 	Notifications NotificationsConfig `toml:"notifications"`
 	Subtitles     SubtitlesConfig     `toml:"subtitles"`
 	RipCache      RipCacheConfig      `toml:"rip_cache"`
+	WatchFolder   WatchFolderConfig   `toml:"watch_folder"`
+	Staging       StagingConfig       `toml:"staging"`
+	FreeSpace     FreeSpaceConfig     `toml:"free_space"`
 	DiscIDCache   DiscIDCacheConfig   `toml:"disc_id_cache"`
+	TMDBCache     TMDBCacheConfig     `toml:"tmdb_cache"`
+	ScanCache     ScanCacheConfig     `toml:"scan_cache"`
 	MakeMKV       MakeMKVConfig       `toml:"makemkv"`
+	Encoding      EncodingConfig      `toml:"encoding"`
 	LLM           LLMConfig           `toml:"llm"`
 	Commentary    CommentaryConfig    `toml:"commentary"`
 	ContentID     ContentIDConfig     `toml:"content_id"`
+	Workflow      WorkflowConfig      `toml:"workflow"`
 	Logging       LoggingConfig       `toml:"logging"`
 }
 
@@ -34,12 +46,22 @@ type PathsConfig struct {
 	LibraryDir string `toml:"library_dir"`
 	StateDir   string `toml:"state_dir"`
 	ReviewDir  string `toml:"review_dir"`
+	// ReviewCollisionSuffix names a file moved into review_dir when its
+	// name is taken there; a CollisionSuffix* value.
+	ReviewCollisionSuffix string `toml:"review_collision_suffix"`
 }
 
 // APIConfig defines the HTTP API server settings.
 type APIConfig struct {
-	Bind  string `toml:"bind"`
-	Token string `toml:"token"`
+	Bind             string `toml:"bind"`
+	Token            string `toml:"token"`
+	ReconnectTimeout int    `toml:"reconnect_timeout"`
+}
+
+// ReconnectDeadline returns how long CLI commands keep retrying an
+// unreachable daemon as a time.Duration.
+func (a APIConfig) ReconnectDeadline() time.Duration {
+	return time.Duration(a.ReconnectTimeout) * time.Second
 }
 
 // TMDBConfig defines The Movie Database API settings.
@@ -47,8 +69,31 @@ type TMDBConfig struct {
 	APIKey   string `toml:"api_key"`
 	BaseURL  string `toml:"base_url"`
 	Language string `toml:"language"`
+	// Region is an ISO 3166-1 code such as "DE" sent with every lookup so
+	// release dates follow the user's locale. Empty sends none.
+	Region string `toml:"region"`
+	// FallbackLanguages are tried in order when a search in Language finds
+	// no confident match: each is a TMDB language, or TMDBOriginalLanguage
+	// to match against the titles' original-language names.
+	FallbackLanguages     []string `toml:"fallback_languages"`
+	MaxConcurrentSearches int      `toml:"max_concurrent_searches"`
+	// ReviewCandidates is how many TMDB search results identification keeps
+	// in the rip spec as alternatives a reviewer can choose. 0 keeps none.
+	ReviewCandidates int `toml:"review_candidates"`
+	// YearTolerance is how many years a disc year may differ from a TMDB
+	// release year when no match is found with the exact year. 0 disables
+	// the fallback.
+	YearTolerance int `toml:"year_tolerance"`
+	// MatchThreshold is the score a TMDB match whose title is not an exact
+	// match needs to be accepted; weaker matches go to review. Score is 1
+	// for a title containing the query plus vote_average/10.
+	MatchThreshold float64 `toml:"match_threshold"`
 }
 
+// TMDBOriginalLanguage in TMDBConfig.FallbackLanguages matches search
+// results by their original-language titles instead of a translation.
+const TMDBOriginalLanguage = "original"
+
 // JellyfinConfig defines Jellyfin server integration settings.
 type JellyfinConfig struct {
 	Enabled bool   `toml:"enabled"`
@@ -61,12 +106,54 @@ type LibraryConfig struct {
 	MoviesDir         string `toml:"movies_dir"`
 	TVDir             string `toml:"tv_dir"`
 	OverwriteExisting bool   `toml:"overwrite_existing"`
+	WriteNFO          bool   `toml:"write_nfo"`
+	DownloadArtwork   bool   `toml:"download_artwork"`
+	ArtworkSize       string `toml:"artwork_size"`
+	// MovieStructure arranges movie folders under movies_dir; custom uses
+	// MovieTemplate.
+	MovieStructure string `toml:"movie_structure"`
+	MovieTemplate  string `toml:"movie_template"`
+	// EpisodeFormat numbers TV episode filenames (see
+	// mediameta.FormatEpisodeNumber), e.g. "S{season:02}E{episode:02}".
+	EpisodeFormat string `toml:"episode_format"`
+}
+
+// Movie directory structures for library.movie_structure.
+const (
+	MovieStructureFlat    = "flat"
+	MovieStructureByYear  = "by_year"
+	MovieStructureByGenre = "by_genre"
+	MovieStructureCustom  = "custom"
+)
+
+// MovieDirTemplate returns the movie directory template (see
+// mediameta.MovieDir) for the configured movie structure. The template is
+// checked where it is expanded, not here.
+func (l *LibraryConfig) MovieDirTemplate() string {
+	switch l.MovieStructure {
+	case MovieStructureByYear:
+		return "{year}/{name}"
+	case MovieStructureByGenre:
+		return "{genre}/{name}"
+	case MovieStructureCustom:
+		return l.MovieTemplate
+	}
+	return "{name}"
 }
 
+// Collision suffix strategies for paths.review_collision_suffix.
+const (
+	CollisionSuffixNumeric   = "numeric"
+	CollisionSuffixHash      = "hash"
+	CollisionSuffixTimestamp = "timestamp"
+)
+
 // NotificationsConfig defines ntfy notification settings.
 type NotificationsConfig struct {
-	NtfyTopic      string `toml:"ntfy_topic"`
-	RequestTimeout int    `toml:"request_timeout"`
+	NtfyTopic               string            `toml:"ntfy_topic"`
+	RequestTimeout          int               `toml:"request_timeout"`
+	ProgressIntervalMinutes int               `toml:"progress_interval_minutes"`
+	Templates               map[string]string `toml:"templates"`
 }
 
 // SubtitlesConfig defines subtitle generation pipeline settings.
@@ -75,18 +162,89 @@ type SubtitlesConfig struct {
 	MuxIntoMKV             bool     `toml:"mux_into_mkv"`
 	WhisperXModel          string   `toml:"whisperx_model"`
 	WhisperXCUDAEnabled    bool     `toml:"whisperx_cuda_enabled"`
+	WhisperXComputeType    string   `toml:"whisperx_compute_type"`
+	WhisperXDeviceIndex    int      `toml:"whisperx_device_index"`
 	WhisperXVADMethod      string   `toml:"whisperx_vad_method"`
 	WhisperXHFToken        string   `toml:"whisperx_hf_token"`
+	WhisperXMinConfidence  float64  `toml:"whisperx_min_confidence"`
+	WhisperXWorkers        int      `toml:"whisperx_workers"`
+	EpisodeWorkers         int      `toml:"episode_workers"`
 	OpenSubtitlesAPIKey    string   `toml:"opensubtitles_api_key"`
 	OpenSubtitlesUserAgent string   `toml:"opensubtitles_user_agent"`
 	OpenSubtitlesUserToken string   `toml:"opensubtitles_user_token"`
 	OpenSubtitlesLanguages []string `toml:"opensubtitles_languages"`
+	HearingImpaired        string   `toml:"hearing_impaired"`
+	MinCoverage            float64  `toml:"min_coverage"`
+	SyncReferenceOffset    bool     `toml:"sync_reference_offset"`
+	SourcePriority         []string `toml:"source_priority"`
 }
 
+// Hearing-impaired (SDH) preferences accepted in subtitles.hearing_impaired.
+const (
+	HearingImpairedPrefer = "prefer"
+	HearingImpairedAvoid  = "avoid"
+	HearingImpairedEither = "either"
+)
+
+// Display subtitle sources accepted in subtitles.source_priority.
+const (
+	SubtitleSourceEmbedded      = "embedded"
+	SubtitleSourceOpenSubtitles = "opensubtitles"
+	SubtitleSourceWhisperX      = "whisperx"
+)
+
 // RipCacheConfig defines rip cache settings.
 type RipCacheConfig struct {
 	Enabled bool `toml:"enabled"`
 	MaxGiB  int  `toml:"max_gib"`
+	// StagingTransfer selects how rips move between staging and the cache:
+	// "hardlink", "reflink", or "copy".
+	StagingTransfer string `toml:"staging_transfer"`
+}
+
+// Rip cache staging transfer modes. Link modes fall back to a verified
+// copy when staging and the cache are on different filesystems.
+const (
+	StagingTransferHardlink = "hardlink"
+	StagingTransferReflink  = "reflink"
+	StagingTransferCopy     = "copy"
+)
+
+// WatchFolderConfig defines the daemon's watched-folder ingestion. MKV
+// files dropped into Dir are queued as imports once they stop changing for
+// SettleSeconds. An empty Dir disables the watcher.
+type WatchFolderConfig struct {
+	Dir           string `toml:"dir"`
+	SettleSeconds int    `toml:"settle_seconds"`
+}
+
+// Staging cleanup policies applied once the organizer has confirmed an
+// item's final placement.
+const (
+	StagingCleanupImmediate         = "immediate"
+	StagingCleanupKeepRecent        = "keep_recent"
+	StagingCleanupKeepUntilLowSpace = "keep_until_low_space"
+)
+
+// StagingConfig defines what happens to an item's staging directory after
+// organization. Directories are only removed once every final asset exists.
+type StagingConfig struct {
+	CleanupPolicy string `toml:"cleanup_policy"`
+	// KeepRecent is how many organized staging directories keep_recent
+	// retains, newest first.
+	KeepRecent int `toml:"keep_recent"`
+	// MinFreeGiB is the free space keep_until_low_space preserves on the
+	// staging filesystem by removing the oldest organized directories.
+	MinFreeGiB int `toml:"min_free_gib"`
+}
+
+// FreeSpaceConfig defines the free space, in GiB, each directory's
+// filesystem keeps after a large write. Zero disables a directory's floor.
+type FreeSpaceConfig struct {
+	StagingGiB  int `toml:"staging_gib"`
+	LibraryGiB  int `toml:"library_gib"`
+	ReviewGiB   int `toml:"review_gib"`
+	RipCacheGiB int `toml:"rip_cache_gib"`
 }
 
 // DiscIDCacheConfig defines disc ID cache settings.
@@ -94,6 +252,28 @@ type DiscIDCacheConfig struct {
 	Enabled bool `toml:"enabled"`
 }
 
+// TMDBCacheConfig defines TMDB response cache settings.
+type TMDBCacheConfig struct {
+	Enabled  bool `toml:"enabled"`
+	TTLHours int  `toml:"ttl_hours"`
+}
+
+// TTL returns the cache entry lifetime as a time.Duration.
+func (t TMDBCacheConfig) TTL() time.Duration {
+	return time.Duration(t.TTLHours) * time.Hour
+}
+
+// ScanCacheConfig defines disc scan cache settings.
+type ScanCacheConfig struct {
+	Enabled    bool `toml:"enabled"`
+	TTLMinutes int  `toml:"ttl_minutes"`
+}
+
+// TTL returns the cache entry lifetime as a time.Duration.
+func (s ScanCacheConfig) TTL() time.Duration {
+	return time.Duration(s.TTLMinutes) * time.Minute
+}
+
 // MakeMKVConfig defines MakeMKV ripping settings.
 type MakeMKVConfig struct {
 	OpticalDrive         string `toml:"optical_drive"`
@@ -101,16 +281,74 @@ type MakeMKVConfig struct {
 	InfoTimeout          int    `toml:"info_timeout"`
 	DiscSettleDelay      int    `toml:"disc_settle_delay"`
 	MinTitleLength       int    `toml:"min_title_length"`
+	MinEpisodeLength     int    `toml:"min_episode_length"`
+	MaxEpisodeLength     int    `toml:"max_episode_length"`
 	KeyDBPath            string `toml:"keydb_path"`
 	KeyDBDownloadURL     string `toml:"keydb_download_url"`
 	KeyDBDownloadTimeout int    `toml:"keydb_download_timeout"`
+	EjectAfterRip        string `toml:"eject_after_rip"`
+	MovieVersions        string `toml:"movie_versions"`
 }
 
+// Post-rip ejection modes for MakeMKVConfig.EjectAfterRip.
+const (
+	EjectAfterRipKeep      = "keep"       // leave the disc in the closed drive
+	EjectAfterRipAlways    = "eject"      // eject once ripping ends, even on failure
+	EjectAfterRipOnSuccess = "on_success" // eject only after a successful rip
+)
+
+// Policies for MakeMKVConfig.MovieVersions, applied when a movie disc holds
+// several full-length cuts, e.g. theatrical and extended.
+const (
+	MovieVersionsLongest    = "longest"    // rip only the longest cut
+	MovieVersionsTheatrical = "theatrical" // rip only the shortest cut
+	MovieVersionsKeepAll    = "keep_all"   // rip every cut, tagged by edition
+)
+
 // KeyDBTimeout returns the KeyDB download timeout as a time.Duration.
 func (m MakeMKVConfig) KeyDBTimeout() time.Duration {
 	return time.Duration(m.KeyDBDownloadTimeout) * time.Second
 }
 
+// Encoding queue order strategies.
+const (
+	EncodeOrderFIFO          = "fifo"
+	EncodeOrderShortestFirst = "shortest_first"
+	EncodeOrderLongestFirst  = "longest_first"
+)
+
+// EncodingConfig defines encoding lane scheduling and the audio rewrites the apply stage performs on encoded output. Reel
+// quality settings are not configurable.
+type EncodingConfig struct {
+	QueueOrder string `toml:"queue_order"`
+	// Loudnorm has the apply stage normalize the primary audio track to
+	// EBU R128 (-23 LUFS); commentary tracks are left alone.
+	Loudnorm bool `toml:"loudnorm"`
+	// CommentaryCodec re-encodes retained commentary tracks to stereo in
+	// one codec for player compatibility: "" keeps them as encoded,
+	// otherwise a CommentaryCodec* value.
+	CommentaryCodec string `toml:"commentary_codec"`
+	// AudioKeep is the AudioKeep* rule deciding which audio tracks the
+	// audio refinement remux keeps beside the primary track.
+	AudioKeep string `toml:"audio_keep"`
+	// AudioKeepLanguages lists the languages AudioKeepLanguage keeps.
+	AudioKeepLanguages []string `toml:"audio_keep_languages"`
+}
+
+// Audio keep rules for EncodingConfig.AudioKeep.
+const (
+	AudioKeepPrimaryCommentary = "primary_commentary" // primary plus detected commentary and audio description
+	AudioKeepPrimaryOnly       = "primary_only"       // primary track alone
+	AudioKeepLanguage          = "language"           // also every track in audio_keep_languages
+	AudioKeepAll               = "all"                // every audio track
+)
+
+// Commentary normalization codecs.
+const (
+	CommentaryCodecOpus = "opus"
+	CommentaryCodecAAC  = "aac"
+)
+
 // LLMConfig defines LLM API settings for OpenRouter.
 type LLMConfig struct {
 	APIKey         string `toml:"api_key"`
@@ -126,9 +364,18 @@ type LLMConfig struct {
 // transcribed once and the same transcript feeds both the similarity filter
 // and LLM classification.
 type CommentaryConfig struct {
-	Enabled             bool    `toml:"enabled"`
-	SimilarityThreshold float64 `toml:"similarity_threshold"`
-	ConfidenceThreshold float64 `toml:"confidence_threshold"`
+	Enabled                     bool    `toml:"enabled"`
+	SimilarityThreshold         float64 `toml:"similarity_threshold"`
+	ConfidenceThreshold         float64 `toml:"confidence_threshold"`
+	AudioDescriptionMinGapRatio float64 `toml:"audio_description_min_gap_ratio"`
+	AudioDescriptionMaxOverlap  float64 `toml:"audio_description_max_overlap"`
+	StreamCache                 bool    `toml:"stream_cache"`
+	// RetainAudioDescription keeps detected audio-description tracks as
+	// labeled secondary tracks instead of excluding them.
+	RetainAudioDescription bool `toml:"retain_audio_description"`
+	// Keywords are matched case-insensitively against a candidate's title
+	// tag; a match is passed to the LLM classifier as a hint.
+	Keywords []string `toml:"keywords"`
 }
 
 // ContentIDConfig defines episode identification policy thresholds.
@@ -138,13 +385,98 @@ type ContentIDConfig struct {
 	LowConfidenceReviewThreshold float64 `toml:"low_confidence_review_threshold"`
 	DecisiveAutoAcceptThreshold  float64 `toml:"decisive_auto_accept_threshold"`
 	ClearConfidenceThreshold     float64 `toml:"clear_confidence_threshold"`
+	MinTranscriptTokens          int     `toml:"min_transcript_tokens"`
+	LLMTiebreaker                bool    `toml:"llm_tiebreaker"`
+	// SeasonSpan is how many neighboring seasons on each side of the disc's
+	// season are searched for titles the disc's own season cannot claim.
+	SeasonSpan int `toml:"season_span"`
+	// MatchSpecials checks titles no regular season claims against the
+	// show's specials (TMDB season 0), filing matches as s00eNN.
+	MatchSpecials bool `toml:"match_specials"`
+}
+
+// WorkflowConfig defines scheduler settings.
+type WorkflowConfig struct {
+	// StageTimeouts maps a stage name to its maximum run time in seconds.
+	// Stages without an entry run unbounded.
+	StageTimeouts map[string]int `toml:"stage_timeouts"`
+	// StageStallTimeouts maps a stage name to how many seconds its progress
+	// may stay frozen before the stage is declared stalled and cancelled.
+	// Stages without an entry are never watched.
+	StageStallTimeouts map[string]int `toml:"stage_stall_timeouts"`
+	// StageRetries maps a stage name to how many times a failed run is
+	// retried before the item fails. Stages without an entry never retry.
+	StageRetries map[string]int `toml:"stage_retries"`
+	// FFprobeTimeout bounds the ffprobe run that validates each rip, in
+	// seconds; 0 disables it.
+	FFprobeTimeout int `toml:"ffprobe_timeout"`
+	// DependencyPauseFailures is the number of consecutive failed health
+	// checks of an external service that pause the stages needing it; 0
+	// disables auto-pause.
+	DependencyPauseFailures int `toml:"dependency_pause_failures"`
+	// DependencyCheckInterval is the seconds between health checks.
+	DependencyCheckInterval int `toml:"dependency_check_interval"`
+	// GPUCapacity is how many runs of the GPU stages (episode
+	// identification, audio analysis, subtitling) may hold the "gpu"
+	// resource at once across all items.
+	GPUCapacity int `toml:"gpu_capacity"`
+	// EncodeUsesGPU counts each encode against GPUCapacity, for setups where
+	// the encoder's quality metric shares the card with WhisperX.
+	EncodeUsesGPU bool `toml:"encode_uses_gpu"`
+}
+
+// FFprobeTimeoutDuration returns FFprobeTimeout as a time.Duration.
+func (w WorkflowConfig) FFprobeTimeoutDuration() time.Duration {
+	return time.Duration(w.FFprobeTimeout) * time.Second
+}
+
+// StageRetryBudget returns the configured retry count for stage, or 0 when
+// the stage has none.
+func (w WorkflowConfig) StageRetryBudget(stage string) int {
+	return w.StageRetries[stage]
 }
 
-// LoggingConfig defines log retention settings.
+// DependencyCheckIntervalDuration returns DependencyCheckInterval as a
+// time.Duration.
+func (w WorkflowConfig) DependencyCheckIntervalDuration() time.Duration {
+	return time.Duration(w.DependencyCheckInterval) * time.Second
+}
+
+// StageTimeout returns the configured run-time limit for stage, or 0 when
+// the stage has none.
+func (w WorkflowConfig) StageTimeout(stage string) time.Duration {
+	return time.Duration(w.StageTimeouts[stage]) * time.Second
+}
+
+// StageStallTimeout returns the configured progress stall limit for stage,
+// or 0 when the stage has none.
+func (w WorkflowConfig) StageStallTimeout(stage string) time.Duration {
+	return time.Duration(w.StageStallTimeouts[stage]) * time.Second
+}
+
+// LoggingConfig defines log retention and field filtering settings.
 type LoggingConfig struct {
 	RetentionDays int `toml:"retention_days"`
+	// Fields filters structured log fields by record level. Keys are
+	// "all" (every level), "debug", "info", "warn", and "error".
+	Fields map[string]LogFieldFilter `toml:"fields"`
+}
+
+// LogFieldFilter lists field names to drop from log records. A field is
+// dropped when a Deny entry matches it and no Allow entry does; fields
+// neither list names are kept. An entry ending in "*" matches by prefix.
+type LogFieldFilter struct {
+	Allow []string `toml:"allow"`
+	Deny  []string `toml:"deny"`
 }
 
+// LogFieldLevels lists the valid logging.fields keys.
+var LogFieldLevels = []string{"all", "debug", "info", "warn", "error"}
+
+// KeptLogFields are the fields log filtering, status views, and item log
+// lookups rely on; logging.fields can never drop them.
+var KeptLogFields = []string{"item_id", "stage", "lane", "component", "decision_type", "event_type"}
+
 // cacheBaseDir returns the XDG cache base directory for Spindle.
 func cacheBaseDir() string {
 	dir, err := os.UserCacheDir()
@@ -177,6 +509,22 @@ func (c *Config) DiscIDCachePath() string {
 	return filepath.Join(cacheBaseDir(), "discid_cache.json")
 }
 
+// TMDBCachePath returns the auto-derived TMDB response cache file path.
+func (c *Config) TMDBCachePath() string {
+	return filepath.Join(cacheBaseDir(), "tmdb_cache.json")
+}
+
+// ScanCachePath returns the auto-derived disc scan cache file path.
+func (c *Config) ScanCachePath() string {
+	return filepath.Join(cacheBaseDir(), "scan_cache.json")
+}
+
+// CommentaryCachePath returns the auto-derived commentary stream cache file
+// path.
+func (c *Config) CommentaryCachePath() string {
+	return filepath.Join(cacheBaseDir(), "commentary_cache.json")
+}
+
 // QueueDBPath returns the queue database path within the state directory.
 func (c *Config) QueueDBPath() string {
 	return filepath.Join(c.Paths.StateDir, "queue.db")
@@ -3,10 +3,15 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	toml "github.com/pelletier/go-toml/v2"
+
+	"github.com/five82/spindle/internal/mediameta"
+	"github.com/five82/spindle/internal/queue"
 )
 
 func TestLoadNoConfigReturnsDefaults(t *testing.T) {
@@ -181,6 +186,30 @@ func TestValidatePassesWithRequiredFields(t *testing.T) {
 	}
 }
 
+func TestValidateOfflineSkipsTMDBKey(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Offline = true
+	cfg.Paths.StagingDir = "/tmp/staging"
+	cfg.Paths.StateDir = "/tmp/state"
+	cfg.Paths.ReviewDir = "/tmp/review"
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate should not require tmdb.api_key offline, got: %v", err)
+	}
+}
+
+func TestOfflineEnvOverride(t *testing.T) {
+	t.Setenv("SPINDLE_OFFLINE", "true")
+	cfg := defaultConfig()
+	applied := collectEnvOverrides(cfg)
+	if !cfg.Offline {
+		t.Fatal("SPINDLE_OFFLINE=true should enable offline mode")
+	}
+	if !slices.Contains(applied, "SPINDLE_OFFLINE") {
+		t.Errorf("applied = %v, want SPINDLE_OFFLINE", applied)
+	}
+}
+
 func TestValidateJellyfinConditional(t *testing.T) {
 	cfg := defaultConfig()
 	cfg.TMDB.APIKey = "test-key"
@@ -265,7 +294,7 @@ func TestSampleConfigIsValidTOML(t *testing.T) {
 	// Should contain all major sections.
 	expectedSections := []string{
 		"tmdb", "paths", "api", "jellyfin", "library",
-		"notifications", "subtitles", "rip_cache", "disc_id_cache",
+		"notifications", "subtitles", "rip_cache", "staging", "disc_id_cache", "tmdb_cache",
 		"makemkv", "llm", "commentary", "content_id", "logging",
 	}
 	for _, section := range expectedSections {
@@ -305,6 +334,9 @@ func TestAutoDerivedPaths(t *testing.T) {
 	if !strings.Contains(discIDPath, "discid_cache.json") {
 		t.Errorf("DiscIDCachePath should contain 'discid_cache.json', got %q", discIDPath)
 	}
+	if tmdbPath := cfg.TMDBCachePath(); !strings.Contains(tmdbPath, "tmdb_cache.json") {
+		t.Errorf("TMDBCachePath should contain 'tmdb_cache.json', got %q", tmdbPath)
+	}
 
 	// Socket and lock use XDG_RUNTIME_DIR.
 	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
@@ -463,6 +495,541 @@ func TestMakeMKVRipTimeoutValidation(t *testing.T) {
 	}
 }
 
+func TestTMDBCacheTTLValidation(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.TMDB.APIKey = "test-key"
+	cfg.Paths.StagingDir = "/tmp/staging"
+	cfg.Paths.StateDir = "/tmp/state"
+	cfg.Paths.ReviewDir = "/tmp/review"
+	if cfg.TMDBCache.Enabled || cfg.TMDBCache.TTL() != 168*time.Hour {
+		t.Errorf("default tmdb_cache = %+v, want disabled with 168h ttl", cfg.TMDBCache)
+	}
+
+	cfg.TMDBCache.TTLHours = 0
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "ttl_hours") {
+		t.Fatalf("expected error about ttl_hours, got: %v", err)
+	}
+}
+
+func TestTMDBMaxConcurrentSearchesValidation(t *testing.T) {
+	for _, tc := range []struct {
+		value   int
+		wantErr bool
+	}{
+		{1, false},
+		{8, false},
+		{0, true},
+		{9, true},
+	} {
+		cfg := defaultConfig()
+		cfg.TMDB.APIKey = "test-key"
+		cfg.Paths.StagingDir = "/tmp/staging"
+		cfg.Paths.StateDir = "/tmp/state"
+		cfg.Paths.ReviewDir = "/tmp/review"
+		cfg.TMDB.MaxConcurrentSearches = tc.value
+		err := cfg.Validate()
+		if (err != nil) != tc.wantErr {
+			t.Errorf("max_concurrent_searches=%d: err = %v, wantErr %v", tc.value, err, tc.wantErr)
+		}
+		if err != nil && !strings.Contains(err.Error(), "max_concurrent_searches") {
+			t.Errorf("expected error about max_concurrent_searches, got: %s", err)
+		}
+	}
+}
+
+func TestTMDBReviewCandidatesValidation(t *testing.T) {
+	for _, tc := range []struct {
+		value   int
+		wantErr bool
+	}{
+		{0, false},
+		{20, false},
+		{-1, true},
+		{21, true},
+	} {
+		cfg := defaultConfig()
+		cfg.TMDB.APIKey = "test-key"
+		cfg.Paths.StagingDir = "/tmp/staging"
+		cfg.Paths.StateDir = "/tmp/state"
+		cfg.Paths.ReviewDir = "/tmp/review"
+		cfg.TMDB.ReviewCandidates = tc.value
+		err := cfg.Validate()
+		if (err != nil) != tc.wantErr {
+			t.Errorf("review_candidates=%d: err = %v, wantErr %v", tc.value, err, tc.wantErr)
+		}
+		if err != nil && !strings.Contains(err.Error(), "review_candidates") {
+			t.Errorf("expected error about review_candidates, got: %s", err)
+		}
+	}
+}
+
+func TestTMDBYearToleranceValidation(t *testing.T) {
+	for _, tc := range []struct {
+		value   int
+		wantErr bool
+	}{
+		{0, false},
+		{5, false},
+		{-1, true},
+		{6, true},
+	} {
+		cfg := defaultConfig()
+		cfg.TMDB.APIKey = "test-key"
+		cfg.Paths.StagingDir = "/tmp/staging"
+		cfg.Paths.StateDir = "/tmp/state"
+		cfg.Paths.ReviewDir = "/tmp/review"
+		cfg.TMDB.YearTolerance = tc.value
+		err := cfg.Validate()
+		if (err != nil) != tc.wantErr {
+			t.Errorf("year_tolerance=%d: err = %v, wantErr %v", tc.value, err, tc.wantErr)
+		}
+		if err != nil && !strings.Contains(err.Error(), "year_tolerance") {
+			t.Errorf("expected error about year_tolerance, got: %s", err)
+		}
+	}
+}
+
+func TestAPIReconnectTimeoutValidation(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.TMDB.APIKey = "test-key"
+	cfg.Paths.StagingDir = "/tmp/staging"
+	cfg.Paths.StateDir = "/tmp/state"
+	cfg.Paths.ReviewDir = "/tmp/review"
+	if got := cfg.API.ReconnectDeadline(); got != 10*time.Second {
+		t.Errorf("default reconnect deadline = %v, want 10s", got)
+	}
+
+	cfg.API.ReconnectTimeout = 0
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("reconnect_timeout 0 should disable retries, got: %v", err)
+	}
+	cfg.API.ReconnectTimeout = -1
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "reconnect_timeout") {
+		t.Fatalf("expected error about reconnect_timeout, got: %v", err)
+	}
+}
+
+func TestLibraryArtworkSizeValidation(t *testing.T) {
+	for _, tc := range []struct {
+		size    string
+		wantErr bool
+	}{
+		{"original", false},
+		{"w780", false},
+		{"", true},
+		{"w", true},
+		{"large", true},
+	} {
+		cfg := defaultConfig()
+		cfg.TMDB.APIKey = "test-key"
+		cfg.Paths.StagingDir = "/tmp/staging"
+		cfg.Paths.StateDir = "/tmp/state"
+		cfg.Paths.ReviewDir = "/tmp/review"
+		cfg.Library.ArtworkSize = tc.size
+		err := cfg.Validate()
+		if (err != nil) != tc.wantErr {
+			t.Errorf("artwork_size=%q: err = %v, wantErr %v", tc.size, err, tc.wantErr)
+		}
+		if err != nil && !strings.Contains(err.Error(), "artwork_size") {
+			t.Errorf("expected error about artwork_size, got: %s", err)
+		}
+	}
+}
+
+func TestWhisperXMinConfidenceValidation(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.TMDB.APIKey = "test-key"
+	cfg.Paths.StagingDir = "/tmp/staging"
+	cfg.Paths.StateDir = "/tmp/state"
+	cfg.Paths.ReviewDir = "/tmp/review"
+	cfg.Subtitles.WhisperXMinConfidence = 1.5
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Validate should fail with whisperx_min_confidence >= 1")
+	}
+	if !strings.Contains(err.Error(), "whisperx_min_confidence") {
+		t.Errorf("expected error about whisperx_min_confidence, got: %s", err.Error())
+	}
+}
+
+func TestWhisperXWorkersValidation(t *testing.T) {
+	for _, workers := range []int{0, 9} {
+		cfg := defaultConfig()
+		cfg.TMDB.APIKey = "test-key"
+		cfg.Paths.StagingDir = "/tmp/staging"
+		cfg.Paths.StateDir = "/tmp/state"
+		cfg.Paths.ReviewDir = "/tmp/review"
+		cfg.Subtitles.WhisperXWorkers = workers
+
+		err := cfg.Validate()
+		if err == nil || !strings.Contains(err.Error(), "whisperx_workers") {
+			t.Errorf("workers=%d: expected error about whisperx_workers, got: %v", workers, err)
+		}
+	}
+}
+
+func TestEpisodeWorkersValidation(t *testing.T) {
+	for _, workers := range []int{0, 9} {
+		cfg := defaultConfig()
+		cfg.TMDB.APIKey = "test-key"
+		cfg.Paths.StagingDir = "/tmp/staging"
+		cfg.Paths.StateDir = "/tmp/state"
+		cfg.Paths.ReviewDir = "/tmp/review"
+		cfg.Subtitles.EpisodeWorkers = workers
+
+		err := cfg.Validate()
+		if err == nil || !strings.Contains(err.Error(), "episode_workers") {
+			t.Errorf("workers=%d: expected error about episode_workers, got: %v", workers, err)
+		}
+	}
+}
+
+func TestMinCoverageValidation(t *testing.T) {
+	for _, coverage := range []float64{-0.1, 1.5} {
+		cfg := defaultConfig()
+		cfg.TMDB.APIKey = "test-key"
+		cfg.Paths.StagingDir = "/tmp/staging"
+		cfg.Paths.StateDir = "/tmp/state"
+		cfg.Paths.ReviewDir = "/tmp/review"
+		cfg.Subtitles.MinCoverage = coverage
+
+		err := cfg.Validate()
+		if err == nil || !strings.Contains(err.Error(), "min_coverage") {
+			t.Errorf("coverage=%v: expected error about min_coverage, got: %v", coverage, err)
+		}
+	}
+}
+
+func TestHearingImpairedValidation(t *testing.T) {
+	for _, pref := range []string{HearingImpairedPrefer, HearingImpairedAvoid, HearingImpairedEither, "sdh"} {
+		cfg := defaultConfig()
+		cfg.TMDB.APIKey = "test-key"
+		cfg.Paths.StagingDir = "/tmp/staging"
+		cfg.Paths.StateDir = "/tmp/state"
+		cfg.Paths.ReviewDir = "/tmp/review"
+		cfg.Subtitles.HearingImpaired = pref
+
+		err := cfg.Validate()
+		if pref == "sdh" {
+			if err == nil || !strings.Contains(err.Error(), "hearing_impaired") {
+				t.Errorf("pref=%q: expected error about hearing_impaired, got: %v", pref, err)
+			}
+		} else if err != nil {
+			t.Errorf("pref=%q: unexpected error: %v", pref, err)
+		}
+	}
+}
+
+func TestEpisodeFormatValidation(t *testing.T) {
+	for _, format := range []string{mediameta.DefaultEpisodeFormat, "{season}x{episode:02}", "{season}{episode:02}", "E{episode}"} {
+		cfg := defaultConfig()
+		cfg.TMDB.APIKey = "test-key"
+		cfg.Paths.StagingDir = "/tmp/staging"
+		cfg.Paths.StateDir = "/tmp/state"
+		cfg.Paths.ReviewDir = "/tmp/review"
+		cfg.Library.EpisodeFormat = format
+
+		err := cfg.Validate()
+		if format == "E{episode}" {
+			if err == nil || !strings.Contains(err.Error(), "library.episode_format") {
+				t.Errorf("format=%q: expected error about library.episode_format, got: %v", format, err)
+			}
+		} else if err != nil {
+			t.Errorf("format=%q: unexpected error: %v", format, err)
+		}
+	}
+}
+
+func TestEjectAfterRipValidation(t *testing.T) {
+	for _, mode := range []string{EjectAfterRipKeep, EjectAfterRipAlways, EjectAfterRipOnSuccess, "always"} {
+		cfg := defaultConfig()
+		cfg.TMDB.APIKey = "test-key"
+		cfg.Paths.StagingDir = "/tmp/staging"
+		cfg.Paths.StateDir = "/tmp/state"
+		cfg.Paths.ReviewDir = "/tmp/review"
+		cfg.MakeMKV.EjectAfterRip = mode
+
+		err := cfg.Validate()
+		if mode == "always" {
+			if err == nil || !strings.Contains(err.Error(), "makemkv.eject_after_rip") {
+				t.Errorf("mode=%q: expected error about makemkv.eject_after_rip, got: %v", mode, err)
+			}
+		} else if err != nil {
+			t.Errorf("mode=%q: unexpected error: %v", mode, err)
+		}
+	}
+}
+
+func TestMovieVersionsValidation(t *testing.T) {
+	for _, policy := range []string{MovieVersionsLongest, MovieVersionsTheatrical, MovieVersionsKeepAll, "both"} {
+		cfg := defaultConfig()
+		cfg.TMDB.APIKey = "test-key"
+		cfg.Paths.StagingDir = "/tmp/staging"
+		cfg.Paths.StateDir = "/tmp/state"
+		cfg.Paths.ReviewDir = "/tmp/review"
+		cfg.MakeMKV.MovieVersions = policy
+
+		err := cfg.Validate()
+		if policy == "both" {
+			if err == nil || !strings.Contains(err.Error(), "makemkv.movie_versions") {
+				t.Errorf("policy=%q: expected error about makemkv.movie_versions, got: %v", policy, err)
+			}
+		} else if err != nil {
+			t.Errorf("policy=%q: unexpected error: %v", policy, err)
+		}
+	}
+}
+
+func TestGPUCapacityValidation(t *testing.T) {
+	cfg := defaultConfig()
+	if cfg.Workflow.GPUCapacity != 1 {
+		t.Fatalf("default gpu_capacity = %d, want 1", cfg.Workflow.GPUCapacity)
+	}
+	cfg.TMDB.APIKey = "test-key"
+	cfg.Paths.StagingDir = "/tmp/staging"
+	cfg.Paths.StateDir = "/tmp/state"
+	cfg.Paths.ReviewDir = "/tmp/review"
+	cfg.Workflow.GPUCapacity = 0
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "workflow.gpu_capacity") {
+		t.Fatalf("expected error about workflow.gpu_capacity, got: %v", err)
+	}
+}
+
+func TestWhisperXComputeOptionsValidation(t *testing.T) {
+	for _, tc := range []struct {
+		cuda        bool
+		computeType string
+		deviceIndex int
+		want        string
+	}{
+		{cuda: true, computeType: "int8_float16", deviceIndex: 1},
+		{computeType: "int8"},
+		{computeType: "bfloat8", want: "whisperx_compute_type"},
+		{computeType: "float16", want: "whisperx_compute_type"},
+		{cuda: true, deviceIndex: -1, want: "whisperx_device_index"},
+		{deviceIndex: 1, want: "whisperx_device_index"},
+	} {
+		cfg := defaultConfig()
+		cfg.TMDB.APIKey = "test-key"
+		cfg.Paths.StagingDir = "/tmp/staging"
+		cfg.Paths.StateDir = "/tmp/state"
+		cfg.Paths.ReviewDir = "/tmp/review"
+		cfg.Subtitles.WhisperXCUDAEnabled = tc.cuda
+		cfg.Subtitles.WhisperXComputeType = tc.computeType
+		cfg.Subtitles.WhisperXDeviceIndex = tc.deviceIndex
+
+		err := cfg.Validate()
+		switch {
+		case tc.want == "" && err != nil:
+			t.Errorf("%+v: unexpected error: %v", tc, err)
+		case tc.want != "" && (err == nil || !strings.Contains(err.Error(), tc.want)):
+			t.Errorf("%+v: expected error about %s, got: %v", tc, tc.want, err)
+		}
+	}
+}
+
+func TestAudioDescriptionThresholdValidation(t *testing.T) {
+	for _, tc := range []struct {
+		gap, overlap float64
+		want         string
+	}{
+		{gap: 1, overlap: 1},
+		{gap: 0, overlap: 0.1, want: "audio_description_min_gap_ratio"},
+		{gap: -0.1, overlap: 0.1, want: "audio_description_min_gap_ratio"},
+		{gap: 0.8, overlap: 0, want: "audio_description_max_overlap"},
+		{gap: 0.8, overlap: 1.5, want: "audio_description_max_overlap"},
+	} {
+		cfg := defaultConfig()
+		cfg.TMDB.APIKey = "test-key"
+		cfg.Paths.StagingDir = "/tmp/staging"
+		cfg.Paths.StateDir = "/tmp/state"
+		cfg.Paths.ReviewDir = "/tmp/review"
+		cfg.Commentary.AudioDescriptionMinGapRatio = tc.gap
+		cfg.Commentary.AudioDescriptionMaxOverlap = tc.overlap
+
+		err := cfg.Validate()
+		if tc.want == "" {
+			if err != nil {
+				t.Errorf("gap=%.2f overlap=%.2f: unexpected error: %v", tc.gap, tc.overlap, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("gap=%.2f overlap=%.2f: expected error about %s, got: %v", tc.gap, tc.overlap, tc.want, err)
+		}
+	}
+}
+
+func TestEncodingQueueOrderValidation(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.TMDB.APIKey = "test-key"
+	cfg.Paths.StagingDir = "/tmp/staging"
+	cfg.Paths.StateDir = "/tmp/state"
+	cfg.Paths.ReviewDir = "/tmp/review"
+	cfg.Encoding.QueueOrder = "random"
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Validate should fail with unknown queue_order")
+	}
+	if !strings.Contains(err.Error(), "encoding.queue_order") {
+		t.Errorf("expected error about encoding.queue_order, got: %s", err.Error())
+	}
+}
+
+func TestEpisodeLengthValidation(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		min, max int
+		wantErr  string
+	}{
+		{"defaults", 0, 10800, ""},
+		{"minimum set", 300, 10800, ""},
+		{"no maximum", 300, 0, ""},
+		{"negative minimum", -1, 0, "min_episode_length"},
+		{"maximum below minimum", 600, 300, "max_episode_length"},
+		{"negative maximum", 0, -5, "max_episode_length"},
+	} {
+		cfg := defaultConfig()
+		cfg.TMDB.APIKey = "test-key"
+		cfg.Paths.StagingDir = "/tmp/staging"
+		cfg.Paths.StateDir = "/tmp/state"
+		cfg.Paths.ReviewDir = "/tmp/review"
+		cfg.MakeMKV.MinEpisodeLength = tc.min
+		cfg.MakeMKV.MaxEpisodeLength = tc.max
+		err := cfg.Validate()
+		if tc.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", tc.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+			t.Errorf("%s: err = %v, want error about %s", tc.name, err, tc.wantErr)
+		}
+	}
+}
+
+func TestStagingCleanupPolicyValidation(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.TMDB.APIKey = "test-key"
+	cfg.Paths.StagingDir = "/tmp/staging"
+	cfg.Paths.StateDir = "/tmp/state"
+	cfg.Paths.ReviewDir = "/tmp/review"
+	if cfg.Staging.CleanupPolicy != StagingCleanupImmediate {
+		t.Fatalf("default cleanup_policy = %q, want %q", cfg.Staging.CleanupPolicy, StagingCleanupImmediate)
+	}
+
+	cfg.Staging.CleanupPolicy = "never"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "staging.cleanup_policy") {
+		t.Fatalf("Validate should reject unknown policy, got: %v", err)
+	}
+
+	cfg.Staging.CleanupPolicy = StagingCleanupKeepRecent
+	cfg.Staging.KeepRecent = 0
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "staging.keep_recent") {
+		t.Fatalf("Validate should reject keep_recent = 0, got: %v", err)
+	}
+	cfg.Staging.KeepRecent = 2
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate should accept keep_recent = 2, got: %v", err)
+	}
+
+	cfg.Staging.CleanupPolicy = StagingCleanupKeepUntilLowSpace
+	cfg.Staging.MinFreeGiB = 0
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "staging.min_free_gib") {
+		t.Fatalf("Validate should reject min_free_gib = 0, got: %v", err)
+	}
+}
+
+func TestTMDBLocaleValidation(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.TMDB.APIKey = "test-key"
+	cfg.Paths.StagingDir = "/tmp/staging"
+	cfg.Paths.StateDir = "/tmp/state"
+	cfg.Paths.ReviewDir = "/tmp/review"
+	cfg.TMDB.Language = "de-DE"
+	cfg.TMDB.Region = "DE"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate rejected de-DE/DE: %v", err)
+	}
+
+	cfg.TMDB.Language = "german"
+	cfg.TMDB.Region = "de"
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "tmdb.language") || !strings.Contains(err.Error(), "tmdb.region") {
+		t.Fatalf("Validate should reject malformed language and region, got: %v", err)
+	}
+}
+
+func TestTMDBFallbackLanguagesValidation(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.TMDB.APIKey = "test-key"
+	cfg.Paths.StagingDir = "/tmp/staging"
+	cfg.Paths.StateDir = "/tmp/state"
+	cfg.Paths.ReviewDir = "/tmp/review"
+	cfg.TMDB.FallbackLanguages = []string{"en-US", "original"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate rejected en-US/original: %v", err)
+	}
+
+	cfg.TMDB.FallbackLanguages = []string{"en-US", "english"}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "tmdb.fallback_languages") {
+		t.Fatalf("expected error about tmdb.fallback_languages, got: %v", err)
+	}
+}
+
+func TestEncodingCommentaryFormatValidation(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.TMDB.APIKey = "test-key"
+	cfg.Paths.StagingDir = "/tmp/staging"
+	cfg.Paths.StateDir = "/tmp/state"
+	cfg.Paths.ReviewDir = "/tmp/review"
+	if cfg.Encoding.CommentaryCodec != "" {
+		t.Fatalf("commentary_codec default = %q, want off", cfg.Encoding.CommentaryCodec)
+	}
+
+	cfg.Encoding.CommentaryCodec = "flac"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "encoding.commentary_codec") {
+		t.Fatalf("Validate should reject an unknown commentary codec, got: %v", err)
+	}
+	cfg.Encoding.CommentaryCodec = CommentaryCodecOpus
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate rejected stereo Opus commentary: %v", err)
+	}
+}
+
+func TestEncodingAudioKeepValidation(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.TMDB.APIKey = "test-key"
+	cfg.Paths.StagingDir = "/tmp/staging"
+	cfg.Paths.StateDir = "/tmp/state"
+	cfg.Paths.ReviewDir = "/tmp/review"
+	if cfg.Encoding.AudioKeep != AudioKeepPrimaryCommentary {
+		t.Fatalf("audio_keep default = %q, want %q", cfg.Encoding.AudioKeep, AudioKeepPrimaryCommentary)
+	}
+
+	cfg.Encoding.AudioKeep = "english"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "encoding.audio_keep") {
+		t.Fatalf("Validate should reject an unknown keep rule, got: %v", err)
+	}
+	cfg.Encoding.AudioKeep = AudioKeepLanguage
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "requires encoding.audio_keep_languages") {
+		t.Fatalf("Validate should require languages for the language rule, got: %v", err)
+	}
+	cfg.Encoding.AudioKeepLanguages = []string{"en", "klingon"}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), `unknown language "klingon"`) {
+		t.Fatalf("Validate should reject an unknown language, got: %v", err)
+	}
+	cfg.Encoding.AudioKeepLanguages = []string{"en", "jpn"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate rejected en/jpn: %v", err)
+	}
+}
+
 func TestMakeMKVMinTitleLengthValidation(t *testing.T) {
 	cfg := defaultConfig()
 	cfg.TMDB.APIKey = "test-key"
@@ -503,6 +1070,12 @@ api_key = "from-file"
 		if cfg.ContentID.DecisiveAutoAcceptThreshold != 0.80 {
 			t.Fatalf("expected decisive_auto_accept_threshold default 0.80, got %f", cfg.ContentID.DecisiveAutoAcceptThreshold)
 		}
+		if cfg.ContentID.MinTranscriptTokens != 50 {
+			t.Fatalf("expected min_transcript_tokens default 50, got %d", cfg.ContentID.MinTranscriptTokens)
+		}
+		if cfg.ContentID.SeasonSpan != 0 {
+			t.Fatalf("expected season_span default 0, got %d", cfg.ContentID.SeasonSpan)
+		}
 	})
 
 	t.Run("explicit override preserved", func(t *testing.T) {
@@ -514,6 +1087,7 @@ api_key = "from-file"
 [content_id]
 clear_match_margin = 0.08
 decisive_auto_accept_threshold = 0.82
+season_span = 2
 `
 		if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
 			t.Fatal(err)
@@ -529,6 +1103,27 @@ decisive_auto_accept_threshold = 0.82
 		if cfg.ContentID.DecisiveAutoAcceptThreshold != 0.82 {
 			t.Fatalf("expected explicit decisive_auto_accept_threshold to be preserved, got %f", cfg.ContentID.DecisiveAutoAcceptThreshold)
 		}
+		if cfg.ContentID.SeasonSpan != 2 {
+			t.Fatalf("expected explicit season_span 2 to be preserved, got %d", cfg.ContentID.SeasonSpan)
+		}
+	})
+
+	t.Run("non-positive transcript gate rejected", func(t *testing.T) {
+		cid := defaultConfig().ContentID
+		cid.MinTranscriptTokens = 0
+		errs := ValidateContentID(cid)
+		if len(errs) != 1 || !strings.Contains(errs[0], "min_transcript_tokens") {
+			t.Fatalf("ValidateContentID() = %v, want min_transcript_tokens error", errs)
+		}
+	})
+
+	t.Run("season span out of range rejected", func(t *testing.T) {
+		cid := defaultConfig().ContentID
+		cid.SeasonSpan = 4
+		errs := ValidateContentID(cid)
+		if len(errs) != 1 || !strings.Contains(errs[0], "season_span") {
+			t.Fatalf("ValidateContentID() = %v, want season_span error", errs)
+		}
 	})
 }
 
@@ -605,3 +1200,336 @@ func TestExpandHome(t *testing.T) {
 		}
 	}
 }
+
+func TestSubtitleSourcePriorityValidation(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.TMDB.APIKey = "test-key"
+	cfg.Paths.StagingDir = "/tmp/staging"
+	cfg.Paths.StateDir = "/tmp/state"
+	cfg.Paths.ReviewDir = "/tmp/review"
+
+	cfg.Subtitles.SourcePriority = []string{SubtitleSourceEmbedded, SubtitleSourceOpenSubtitles, SubtitleSourceWhisperX}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("full priority list should validate: %v", err)
+	}
+
+	for _, priority := range [][]string{nil, {"plex"}, {SubtitleSourceWhisperX, SubtitleSourceWhisperX}} {
+		cfg.Subtitles.SourcePriority = priority
+		err := cfg.Validate()
+		if err == nil || !strings.Contains(err.Error(), "subtitles.source_priority") {
+			t.Errorf("priority %v: expected subtitles.source_priority error, got %v", priority, err)
+		}
+	}
+}
+
+func TestStageNamesMatchPipeline(t *testing.T) {
+	want := make([]string, len(queue.StageOrder))
+	for i, stage := range queue.StageOrder {
+		want[i] = string(stage)
+	}
+	if !slices.Equal(stageNames, want) {
+		t.Fatalf("stageNames = %v, want queue.StageOrder %v", stageNames, want)
+	}
+}
+
+func TestWorkflowStageTimeoutsValidation(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.TMDB.APIKey = "test-key"
+	cfg.Paths.StagingDir = "/tmp/staging"
+	cfg.Paths.StateDir = "/tmp/state"
+	cfg.Paths.ReviewDir = "/tmp/review"
+	if got := cfg.Workflow.StageTimeout("ripping"); got != 0 {
+		t.Errorf("default ripping timeout = %v, want none", got)
+	}
+
+	cfg.Workflow.StageTimeouts = map[string]int{"subtitling": 7200}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("valid stage timeout rejected: %v", err)
+	}
+	if got := cfg.Workflow.StageTimeout("subtitling"); got != 2*time.Hour {
+		t.Errorf("subtitling timeout = %v, want 2h", got)
+	}
+
+	cfg.Workflow.StageTimeouts = map[string]int{"subtitles": 60, "encoding": 0}
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), `unknown stage "subtitles"`) || !strings.Contains(err.Error(), "stage_timeouts.encoding must be > 0") {
+		t.Fatalf("expected unknown-stage and non-positive errors, got: %v", err)
+	}
+}
+
+func TestWorkflowStageStallTimeoutsValidation(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.TMDB.APIKey = "test-key"
+	cfg.Paths.StagingDir = "/tmp/staging"
+	cfg.Paths.StateDir = "/tmp/state"
+	cfg.Paths.ReviewDir = "/tmp/review"
+	if got := cfg.Workflow.StageStallTimeout("encoding"); got != 0 {
+		t.Errorf("default encoding stall timeout = %v, want none", got)
+	}
+
+	cfg.Workflow.StageStallTimeouts = map[string]int{"encoding": 1800}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("valid stall timeout rejected: %v", err)
+	}
+	if got := cfg.Workflow.StageStallTimeout("encoding"); got != 30*time.Minute {
+		t.Errorf("encoding stall timeout = %v, want 30m", got)
+	}
+
+	cfg.Workflow.StageStallTimeouts = map[string]int{"drapto": 60, "ripping": -1}
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), `stage_stall_timeouts has unknown stage "drapto"`) || !strings.Contains(err.Error(), "stage_stall_timeouts.ripping must be > 0") {
+		t.Fatalf("expected unknown-stage and non-positive errors, got: %v", err)
+	}
+}
+
+func TestRipCacheStagingTransferValidation(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.TMDB.APIKey = "test-key"
+	cfg.Paths.StagingDir = "/tmp/staging"
+	cfg.Paths.StateDir = "/tmp/state"
+	cfg.Paths.ReviewDir = "/tmp/review"
+	if cfg.RipCache.StagingTransfer != StagingTransferHardlink {
+		t.Errorf("default staging_transfer = %q, want hardlink", cfg.RipCache.StagingTransfer)
+	}
+
+	cfg.RipCache.StagingTransfer = StagingTransferReflink
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("reflink rejected: %v", err)
+	}
+
+	cfg.RipCache.StagingTransfer = "symlink"
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "rip_cache.staging_transfer") {
+		t.Fatalf("expected staging_transfer error, got: %v", err)
+	}
+}
+
+func TestWorkflowStageRetriesValidation(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.TMDB.APIKey = "test-key"
+	cfg.Paths.StagingDir = "/tmp/staging"
+	cfg.Paths.StateDir = "/tmp/state"
+	cfg.Paths.ReviewDir = "/tmp/review"
+	if got := cfg.Workflow.StageRetryBudget("ripping"); got != 0 {
+		t.Errorf("default ripping retries = %d, want 0", got)
+	}
+
+	cfg.Workflow.StageRetries = map[string]int{"ripping": 0, "organizing": 3}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("valid stage retries rejected: %v", err)
+	}
+	if got := cfg.Workflow.StageRetryBudget("organizing"); got != 3 {
+		t.Errorf("organizing retries = %d, want 3", got)
+	}
+
+	cfg.Workflow.StageRetries = map[string]int{"notify": 1, "encoding": -1}
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), `unknown stage "notify"`) || !strings.Contains(err.Error(), "stage_retries.encoding must be between 0 and 10") {
+		t.Fatalf("expected unknown-stage and range errors, got: %v", err)
+	}
+}
+
+func TestLoggingFieldsValidation(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.TMDB.APIKey = "test-key"
+	cfg.Paths.StagingDir = "/tmp/staging"
+	cfg.Paths.StateDir = "/tmp/state"
+	cfg.Paths.ReviewDir = "/tmp/review"
+
+	cfg.Logging.Fields = map[string]LogFieldFilter{
+		"all":   {Deny: []string{"fingerprint"}},
+		"debug": {Deny: []string{"candidate_*"}, Allow: []string{"candidate_title"}},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("valid field filters rejected: %v", err)
+	}
+
+	for _, deny := range []string{"*", "decision_*", "item_id", "st*"} {
+		cfg.Logging.Fields = map[string]LogFieldFilter{"info": {Deny: []string{deny}}}
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "always kept") {
+			t.Errorf("deny %q: expected always-kept error, got: %v", deny, err)
+		}
+	}
+
+	cfg.Logging.Fields = map[string]LogFieldFilter{
+		"verbose": {Deny: []string{"path"}},
+		"info":    {Deny: []string{" "}},
+	}
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), `unknown level "verbose"`) || !strings.Contains(err.Error(), "logging.fields.info.deny must not contain blank entries") {
+		t.Fatalf("expected unknown-level and blank-entry errors, got: %v", err)
+	}
+}
+
+func TestWorkflowFFprobeTimeout(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.TMDB.APIKey = "test-key"
+	cfg.Paths.StagingDir = "/tmp/staging"
+	cfg.Paths.StateDir = "/tmp/state"
+	cfg.Paths.ReviewDir = "/tmp/review"
+	if got := cfg.Workflow.FFprobeTimeoutDuration(); got != 2*time.Minute {
+		t.Errorf("default ffprobe timeout = %v, want 2m", got)
+	}
+
+	cfg.Workflow.FFprobeTimeout = -1
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "workflow.ffprobe_timeout") {
+		t.Fatalf("expected ffprobe_timeout error, got: %v", err)
+	}
+}
+
+func TestWorkflowDependencyPauseValidation(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.TMDB.APIKey = "test-key"
+	cfg.Paths.StagingDir = "/tmp/staging"
+	cfg.Paths.StateDir = "/tmp/state"
+	cfg.Paths.ReviewDir = "/tmp/review"
+	if cfg.Workflow.DependencyPauseFailures != 3 || cfg.Workflow.DependencyCheckIntervalDuration() != time.Minute {
+		t.Errorf("defaults = %d failures every %v, want 3 every 1m",
+			cfg.Workflow.DependencyPauseFailures, cfg.Workflow.DependencyCheckIntervalDuration())
+	}
+
+	cfg.Workflow.DependencyPauseFailures = 0
+	cfg.Workflow.DependencyCheckInterval = 0
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("disabled auto-pause rejected: %v", err)
+	}
+
+	cfg.Workflow.DependencyPauseFailures = 2
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "workflow.dependency_check_interval") {
+		t.Fatalf("expected dependency_check_interval error, got: %v", err)
+	}
+}
+
+func TestTMDBMatchThresholdValidation(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.TMDB.APIKey = "test-key"
+	cfg.Paths.StagingDir = "/tmp/staging"
+	cfg.Paths.StateDir = "/tmp/state"
+	cfg.Paths.ReviewDir = "/tmp/review"
+	if cfg.TMDB.MatchThreshold != 1.3 {
+		t.Fatalf("default match_threshold = %g, want 1.3", cfg.TMDB.MatchThreshold)
+	}
+	for threshold, valid := range map[float64]bool{1: true, 1.5: true, 2: true, 0.9: false, 2.1: false} {
+		cfg.TMDB.MatchThreshold = threshold
+		err := cfg.Validate()
+		if (err == nil) != valid {
+			t.Errorf("match_threshold %g: err = %v, want valid=%t", threshold, err, valid)
+		}
+		if err != nil && !strings.Contains(err.Error(), "tmdb.match_threshold") {
+			t.Errorf("match_threshold %g: error %v does not name the field", threshold, err)
+		}
+	}
+}
+
+func TestReviewCollisionSuffixValidation(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.TMDB.APIKey = "test-key"
+	cfg.Paths.StagingDir = "/tmp/staging"
+	cfg.Paths.StateDir = "/tmp/state"
+	cfg.Paths.ReviewDir = "/tmp/review"
+	if cfg.Paths.ReviewCollisionSuffix != CollisionSuffixNumeric {
+		t.Fatalf("default review_collision_suffix = %q, want %q", cfg.Paths.ReviewCollisionSuffix, CollisionSuffixNumeric)
+	}
+	for _, strategy := range []string{CollisionSuffixNumeric, CollisionSuffixHash, CollisionSuffixTimestamp} {
+		cfg.Paths.ReviewCollisionSuffix = strategy
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Validate(%q): %v", strategy, err)
+		}
+	}
+	cfg.Paths.ReviewCollisionSuffix = "overwrite"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "paths.review_collision_suffix") {
+		t.Fatalf("Validate should reject unknown review_collision_suffix, got: %v", err)
+	}
+}
+
+func TestLibraryMovieStructureValidation(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.TMDB.APIKey = "test-key"
+	cfg.Paths.StagingDir = "/tmp/staging"
+	cfg.Paths.StateDir = "/tmp/state"
+	cfg.Paths.ReviewDir = "/tmp/review"
+	if cfg.Library.MovieStructure != MovieStructureFlat || cfg.Library.MovieDirTemplate() != "{name}" {
+		t.Fatalf("default movie structure = %q (%q), want flat", cfg.Library.MovieStructure, cfg.Library.MovieDirTemplate())
+	}
+	for _, structure := range []string{MovieStructureFlat, MovieStructureByYear, MovieStructureByGenre} {
+		cfg.Library.MovieStructure = structure
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Validate(%q): %v", structure, err)
+		}
+	}
+	cfg.Library.MovieStructure = MovieStructureCustom
+	cfg.Library.MovieTemplate = "{genre}/{decade}/{name}"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate(custom): %v", err)
+	}
+	cfg.Library.MovieTemplate = ""
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "library.movie_template") {
+		t.Fatalf("Validate should require a custom template, got: %v", err)
+	}
+	cfg.Library.MovieStructure = "nested"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "library.movie_structure") {
+		t.Fatalf("Validate should reject unknown movie_structure, got: %v", err)
+	}
+}
+
+func TestWatchFolderValidation(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.TMDB.APIKey = "test-key"
+	cfg.Paths.StagingDir = "/tmp/staging"
+	cfg.Paths.StateDir = "/tmp/state"
+	cfg.Paths.ReviewDir = "/tmp/review"
+	if cfg.WatchFolder.Dir != "" || cfg.WatchFolder.SettleSeconds != 30 {
+		t.Fatalf("watch folder defaults = %+v, want disabled with 30s settle", cfg.WatchFolder)
+	}
+	cfg.WatchFolder.SettleSeconds = 0
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("settle_seconds should be ignored while disabled: %v", err)
+	}
+	cfg.WatchFolder.Dir = "/tmp/inbox"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "watch_folder.settle_seconds") {
+		t.Fatalf("Validate should reject settle_seconds below 1, got: %v", err)
+	}
+}
+
+func TestNotificationProgressValidation(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.TMDB.APIKey = "test-key"
+	cfg.Paths.StagingDir = "/tmp/staging"
+	cfg.Paths.StateDir = "/tmp/state"
+	cfg.Paths.ReviewDir = "/tmp/review"
+	if cfg.Notifications.ProgressIntervalMinutes != 0 {
+		t.Fatalf("progress pings should default off, got interval=%d", cfg.Notifications.ProgressIntervalMinutes)
+	}
+
+	cfg.Notifications.ProgressIntervalMinutes = -1
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "notifications.progress_interval_minutes") {
+		t.Fatalf("Validate should reject negative interval, got: %v", err)
+	}
+	cfg.Notifications.ProgressIntervalMinutes = 30
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+}
+
+func TestFreeSpaceFloorValidation(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.TMDB.APIKey = "test-key"
+	cfg.Paths.StagingDir = "/tmp/staging"
+	cfg.Paths.StateDir = "/tmp/state"
+	cfg.Paths.ReviewDir = "/tmp/review"
+	if cfg.FreeSpace.StagingGiB != 10 || cfg.FreeSpace.RipCacheGiB != 10 {
+		t.Fatalf("free space defaults = %+v, want 10 GiB floors", cfg.FreeSpace)
+	}
+	cfg.FreeSpace.LibraryGiB = 0
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate rejected a disabled floor: %v", err)
+	}
+
+	cfg.FreeSpace.ReviewGiB = -1
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "free_space.review_gib") {
+		t.Fatalf("Validate should reject a negative floor, got: %v", err)
+	}
+}
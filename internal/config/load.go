@@ -5,11 +5,13 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	toml "github.com/pelletier/go-toml/v2"
 
 	"github.com/five82/spindle/internal/logs"
+	"github.com/five82/spindle/internal/mediameta"
 )
 
 // Load reads, normalizes, and validates config from the search path.
@@ -106,18 +108,35 @@ func defaultConfig() *Config {
 
 	return &Config{
 		Paths: PathsConfig{
-			StagingDir: filepath.Join(home, ".local", "share", "spindle", "staging"),
-			LibraryDir: filepath.Join(home, "library"),
-			StateDir:   filepath.Join(home, ".local", "state", "spindle"),
-			ReviewDir:  filepath.Join(home, "review"),
+			StagingDir:            filepath.Join(home, ".local", "share", "spindle", "staging"),
+			LibraryDir:            filepath.Join(home, "library"),
+			StateDir:              filepath.Join(home, ".local", "state", "spindle"),
+			ReviewDir:             filepath.Join(home, "review"),
+			ReviewCollisionSuffix: CollisionSuffixNumeric,
+		},
+		API: APIConfig{
+			ReconnectTimeout: 10,
 		},
 		TMDB: TMDBConfig{
-			BaseURL:  "https://api.themoviedb.org/3",
-			Language: "en-US",
+			BaseURL:               "https://api.themoviedb.org/3",
+			Language:              "en-US",
+			MaxConcurrentSearches: 1,
+			ReviewCandidates:      5,
+			YearTolerance:         1,
+			MatchThreshold:        1.3,
+		},
+		TMDBCache: TMDBCacheConfig{
+			TTLHours: 168,
+		},
+		ScanCache: ScanCacheConfig{
+			TTLMinutes: 60,
 		},
 		Library: LibraryConfig{
-			MoviesDir: "movies",
-			TVDir:     "tv",
+			MoviesDir:      "movies",
+			TVDir:          "tv",
+			ArtworkSize:    "original",
+			MovieStructure: MovieStructureFlat,
+			EpisodeFormat:  mediameta.DefaultEpisodeFormat,
 		},
 		Notifications: NotificationsConfig{
 			RequestTimeout: 10,
@@ -126,11 +145,31 @@ func defaultConfig() *Config {
 			MuxIntoMKV:             true,
 			WhisperXModel:          "large-v3",
 			WhisperXVADMethod:      "silero",
+			WhisperXWorkers:        1,
+			EpisodeWorkers:         1,
 			OpenSubtitlesUserAgent: "Spindle/dev v0.1.0",
 			OpenSubtitlesLanguages: []string{"en"},
+			HearingImpaired:        HearingImpairedAvoid,
+			MinCoverage:            0.8,
+			SourcePriority:         []string{SubtitleSourceWhisperX},
 		},
 		RipCache: RipCacheConfig{
-			MaxGiB: 150,
+			MaxGiB:          150,
+			StagingTransfer: StagingTransferHardlink,
+		},
+		WatchFolder: WatchFolderConfig{
+			SettleSeconds: 30,
+		},
+		Staging: StagingConfig{
+			CleanupPolicy: StagingCleanupImmediate,
+			KeepRecent:    3,
+			MinFreeGiB:    100,
+		},
+		FreeSpace: FreeSpaceConfig{
+			StagingGiB:  10,
+			LibraryGiB:  10,
+			ReviewGiB:   10,
+			RipCacheGiB: 10,
 		},
 		MakeMKV: MakeMKVConfig{
 			OpticalDrive:         "/dev/sr0",
@@ -138,9 +177,16 @@ func defaultConfig() *Config {
 			InfoTimeout:          600,
 			DiscSettleDelay:      10,
 			MinTitleLength:       120,
+			MaxEpisodeLength:     10800,
 			KeyDBPath:            filepath.Join(home, ".config", "spindle", "keydb", "KEYDB.cfg"),
 			KeyDBDownloadURL:     "http://fvonline-db.bplaced.net/export/keydb_eng.zip",
 			KeyDBDownloadTimeout: 300,
+			EjectAfterRip:        EjectAfterRipKeep,
+			MovieVersions:        MovieVersionsLongest,
+		},
+		Encoding: EncodingConfig{
+			QueueOrder: EncodeOrderFIFO,
+			AudioKeep:  AudioKeepPrimaryCommentary,
 		},
 		LLM: LLMConfig{
 			BaseURL:        "https://openrouter.ai/api/v1/chat/completions",
@@ -150,8 +196,11 @@ func defaultConfig() *Config {
 			TimeoutSeconds: 60,
 		},
 		Commentary: CommentaryConfig{
-			SimilarityThreshold: 0.92,
-			ConfidenceThreshold: 0.80,
+			SimilarityThreshold:         0.92,
+			ConfidenceThreshold:         0.80,
+			AudioDescriptionMinGapRatio: 0.80,
+			AudioDescriptionMaxOverlap:  0.10,
+			Keywords:                    []string{"commentary", "commentaire", "kommentar", "comentario", "commento"},
 		},
 		ContentID: ContentIDConfig{
 			MinSimilarityScore:           0.58,
@@ -159,6 +208,14 @@ func defaultConfig() *Config {
 			LowConfidenceReviewThreshold: 0.70,
 			DecisiveAutoAcceptThreshold:  0.80,
 			ClearConfidenceThreshold:     0.85,
+			MinTranscriptTokens:          50,
+			MatchSpecials:                true,
+		},
+		Workflow: WorkflowConfig{
+			FFprobeTimeout:          120,
+			DependencyPauseFailures: 3,
+			DependencyCheckInterval: 60,
+			GPUCapacity:             1,
 		},
 		Logging: LoggingConfig{
 			RetentionDays: 60,
@@ -182,6 +239,12 @@ func collectEnvOverrides(cfg *Config) []string {
 		cfg.LLM.APIKey = v
 		applied = append(applied, "OPENROUTER_API_KEY")
 	}
+	if v := os.Getenv("SPINDLE_OFFLINE"); v != "" {
+		if offline, err := strconv.ParseBool(v); err == nil {
+			cfg.Offline = offline
+			applied = append(applied, "SPINDLE_OFFLINE")
+		}
+	}
 	if v := os.Getenv("SPINDLE_API_TOKEN"); v != "" {
 		cfg.API.Token = v
 		applied = append(applied, "SPINDLE_API_TOKEN")
@@ -256,5 +319,19 @@ func normalizePaths(cfg *Config) error {
 		*p = abs
 	}
 
+	// The watch folder is optional; an empty value must stay empty rather
+	// than resolve to the working directory.
+	if cfg.WatchFolder.Dir != "" {
+		expanded, err := expandHome(cfg.WatchFolder.Dir)
+		if err != nil {
+			return err
+		}
+		abs, err := filepath.Abs(expanded)
+		if err != nil {
+			return fmt.Errorf("resolve absolute path %q: %w", expanded, err)
+		}
+		cfg.WatchFolder.Dir = abs
+	}
+
 	return nil
 }
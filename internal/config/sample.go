@@ -6,6 +6,11 @@ func SampleConfig() string {
 	return `# Spindle configuration file
 # Omitted values use the defaults shown in comments.
 
+# Disable all external services (TMDB, OpenRouter, OpenSubtitles, Jellyfin,
+# ntfy) and use deterministic stubs; for development and CI
+# (or pass --offline / set SPINDLE_OFFLINE=1)
+# offline = false
+
 [paths]
 # Working directory for in-progress items
 # staging_dir = "~/.local/share/spindle/staging"
@@ -19,6 +24,14 @@ func SampleConfig() string {
 # Unidentified files routed for manual review
 # review_dir = "~/review"
 
+# Naming of a file moved into review_dir when that name is already taken
+# there: "numeric" appends " (1)", " (2)", ...; "hash" appends a short content
+# hash of the file such as " [3f9a1c2e]"; "timestamp" appends the placement
+# time such as " (20260314-201500)". A suffixed name that is itself taken gets
+# a further numeric suffix, so a review file is never replaced. Library files
+# are never renamed: an existing one is kept, and the new copy goes to review.
+# review_collision_suffix = "numeric"
+
 [api]
 # Optional TCP listen address for HTTP API (e.g., "127.0.0.1:7487")
 # bind = ""
@@ -26,6 +39,9 @@ func SampleConfig() string {
 # Bearer token for HTTP API auth (or set SPINDLE_API_TOKEN env var)
 # token = ""
 
+# Seconds CLI commands keep retrying while a started daemon binds its socket (0 = fail immediately)
+# reconnect_timeout = 10
+
 [tmdb]
 # TMDB API bearer token (required; or set TMDB_API_KEY env var)
 api_key = ""
@@ -33,9 +49,41 @@ api_key = ""
 # TMDB API base URL
 # base_url = "https://api.themoviedb.org/3"
 
-# TMDB query language
+# TMDB metadata language (ISO 639-1, optionally with region, e.g. "de-DE").
+# Titles and overviews used for library naming and NFO files come back in
+# this language; anything TMDB has not translated falls back to English.
 # language = "en-US"
 
+# Languages to search in, in order, when a search in the language above
+# finds no confident match. "original" matches against each title's
+# original-language name, e.g. ["en-US", "original"] for a French setup.
+# The rip spec records which language the match came from.
+# fallback_languages = []
+
+# TMDB region (ISO 3166-1, e.g. "DE") for regional release dates. Empty
+# sends no region.
+# region = ""
+
+# TMDB searches identification may run at once (1-8). Above 1, a TV-hinted
+# disc searches TV and multi together instead of falling back sequentially.
+# Requests share one rate limiter either way.
+# max_concurrent_searches = 1
+
+# TMDB search results kept as alternatives for "spindle review" (0-20).
+# 0 keeps none, so a reviewer can only approve the chosen match.
+# review_candidates = 5
+
+# Years a disc year may differ from TMDB's release year (0-5) when nothing
+# matches the exact year, e.g. a regional release. Such matches score lower.
+# 0 disables the fallback.
+# year_tolerance = 1
+
+# Score a TMDB match needs to be accepted without review when its title is not
+# an exact match: 1 for a title containing the search query plus its vote
+# average / 10. Higher means fewer wrong auto-accepts but more items to
+# review; lower means less review. 1.0-2.0.
+# match_threshold = 1.3
+
 [jellyfin]
 # Enable Jellyfin library refresh
 # enabled = false
@@ -56,6 +104,35 @@ api_key = ""
 # Overwrite files already in library
 # overwrite_existing = false
 
+# Write Kodi-style .nfo metadata sidecars (movie, tvshow, episode) next to
+# library files so metadata survives independent of the media server
+# write_nfo = false
+
+# Download TMDB poster and backdrop artwork (poster.jpg, fanart.jpg) into the
+# library folder for Jellyfin/Plex local artwork
+# download_artwork = false
+
+# TMDB image size: "original" or a width such as "w780"; sizes TMDB does not
+# offer for an image type fall back to "original"
+# artwork_size = "original"
+
+# Movie folder layout under movies_dir: "flat" places each movie in its own
+# "Title (Year)" folder; "by_year" nests it under the year ("2010/Inception
+# (2010)"); "by_genre" under its primary TMDB genre ("Science Fiction/...");
+# "custom" uses movie_template. Templates are "/"-separated folders using
+# {name} ("Title (Year)"), {title}, {year}, {decade} ("2010s"), and {genre};
+# the last folder must contain {name}, or {title} with {year}. A missing
+# year or genre files under "Unknown Year" or "Unknown Genre".
+# movie_structure = "flat"
+# movie_template = "{genre}/{decade}/{name}"
+
+# TV episode numbering in filenames. {season} and {episode} may be
+# zero-padded, e.g. {episode:02}: "S{season:02}E{episode:02}" gives the Plex
+# standard "Show - S01E01", "{season}x{episode:02}" gives "1x01", and
+# "{season}{episode:02}" gives "101". A multi-episode file repeats the part
+# after {season} for its last episode ("S01E01-E02").
+# episode_format = "S{season:02}E{episode:02}"
+
 [notifications]
 # ntfy topic URL (empty disables all notifications)
 # ntfy_topic = ""
@@ -63,6 +140,26 @@ api_key = ""
 # HTTP timeout in seconds
 # request_timeout = 10
 
+# Periodic encode progress pings every progress_interval_minutes while an
+# encode runs (0 disables). Pings are never sent less than a minute apart.
+# progress_interval_minutes = 0
+
+# Custom message wording per event, as Go text/template strings. Events:
+# item_queued, identification_complete, rip_cache_hit, rip_complete,
+# encode_progress, encode_complete, review_required, pipeline_complete,
+# queue_started, queue_completed, lane_paused, lane_resumed, error, test.
+# Fields: {{.Event}}, {{.Title}} (notification title), {{.Message}}
+# (built-in message), {{.Item}} (queue item ID, 0 when none), {{.Stage}},
+# {{.Error}} (failure reason), and for encode_complete {{.Encode}}:
+# .Files, .Resolution, .OriginalBytes, .EncodedBytes, .Duration, .Speed,
+# .Reduction (percent), and .Summary.
+# Events without a template keep the built-in message; templates are checked
+# at startup.
+# [notifications.templates]
+# rip_complete = "{{.Title}}: disc is ready to eject."
+# encode_complete = "{{.Title}}: {{.Encode.Summary}}"
+# error = "Item {{.Item}} failed in {{.Stage}}: {{.Error}}"
+
 [subtitles]
 # Enable subtitle generation pipeline
 # enabled = false
@@ -73,9 +170,18 @@ api_key = ""
 # WhisperX model name
 # whisperx_model = "large-v3"
 
-# Enable CUDA acceleration
+# Enable CUDA acceleration. A run that fails with a CUDA error (out of
+# memory, driver fault) is retried on the CPU.
 # whisperx_cuda_enabled = false
 
+# CTranslate2 compute type: "float16", "float32", "int8", or "int8_float16".
+# Empty picks float16 with CUDA and int8 without. int8 variants use less VRAM
+# at some cost in accuracy; float16 and int8_float16 require CUDA.
+# whisperx_compute_type = ""
+
+# GPU index WhisperX runs on when CUDA is enabled
+# whisperx_device_index = 0
+
 # Voice activity detection method: "silero" (default) or "pyannote"
 #   silero  - fast, lightweight, no token required
 #   pyannote - better precision with background noise and overlapping speech;
@@ -86,6 +192,21 @@ api_key = ""
 # (or set HUGGING_FACE_HUB_TOKEN / HF_TOKEN env var)
 # whisperx_hf_token = ""
 
+# Drop transcript segments whose mean WhisperX word score is below this when
+# extracting text for episode matching and commentary detection
+# (0 keeps every segment)
+# whisperx_min_confidence = 0.0
+
+# WhisperX processes run at once when transcribing a multi-title disc. Each
+# loads its own copy of the model, so raise this only when GPU memory (or
+# CPU RAM without CUDA) can hold that many models.
+# whisperx_workers = 1
+
+# Episodes whose subtitles are generated at once on a multi-episode disc.
+# Audio extraction, OpenSubtitles lookups, and LLM audits overlap freely;
+# WhisperX runs still share the whisperx_workers limit above.
+# episode_workers = 1
+
 # OpenSubtitles API key (or set OPENSUBTITLES_API_KEY env var)
 # opensubtitles_api_key = ""
 
@@ -99,6 +220,36 @@ api_key = ""
 # Preferred subtitle languages
 # opensubtitles_languages = ["en"]
 
+# Hearing-impaired (SDH) subtitles, which add sound descriptions:
+#   prefer - rank HI OpenSubtitles uploads and embedded tracks first
+#   avoid  - rank them last
+#   either - ignore the HI flag and rank on rating and downloads alone
+# Delivered HI subtitles are written as <name>.<lang>.hi.srt and flagged in
+# the muxed track. WhisperX transcripts are dialogue only and never HI.
+# hearing_impaired = "avoid"
+
+# Minimum fraction of the runtime a subtitle must span, measured to its last
+# cue. Shorter subtitles (usually truncated downloads) are rejected so the
+# next source is tried, and flagged for review if none qualifies. Leave room
+# for end credits; 0 disables the check.
+# min_coverage = 0.8
+
+# Align downloaded OpenSubtitles subtitles to the WhisperX transcript: both
+# the references episode identification compares and the display subtitles
+# delivered from the opensubtitles source. References timed for another frame
+# rate (e.g. 25 fps PAL against a 23.976 fps rip) are rescaled, then any
+# constant offset from a different edition is corrected. Skipped when no
+# WhisperX transcript is available.
+# sync_reference_offset = false
+
+# Display subtitle sources, tried in order until one passes validation:
+#   embedded      - text subtitle stream already in the rip (bitmap PGS/VobSub
+#                   tracks are skipped)
+#   opensubtitles - downloaded reference, aligned to the WhisperX transcript
+#                   when one exists
+#   whisperx      - generated from the audio
+# source_priority = ["whisperx"]
+
 [rip_cache]
 # Enable rip cache
 # enabled = false
@@ -106,15 +257,76 @@ api_key = ""
 # Maximum cache size in GiB
 # max_gib = 150
 
+# How rips move between staging and the cache. "hardlink" shares one copy
+# of each file when both live on the same filesystem; "reflink" clones
+# copy-on-write (Btrfs, XFS), so cache and staging never share an inode;
+# "copy" always writes a full second copy. Hardlink and reflink fall back
+# to a verified copy across filesystems. Ripped files are never modified in
+# place, so every mode keeps cached rips unchanged.
+# staging_transfer = "hardlink"
+
+[watch_folder]
+# Directory the daemon watches for .mkv files to import. Each file is matched
+# against TMDB by its name and queued like 'spindle queue import' once it has
+# stopped growing. Queued files are moved to the imported/ subfolder and
+# files that could not be queued to failed/; move one back to retry it.
+# Empty disables the watcher.
+# dir = ""
+
+# Seconds a file's size and modification time must stay unchanged before it
+# is considered fully written
+# settle_seconds = 30
+
+[staging]
+# What happens to an item's staging directory once organization has confirmed
+# every final file is in place:
+#   immediate            - delete it right away
+#   keep_recent          - keep the keep_recent most recently organized items
+#   keep_until_low_space - keep organized items until free space on the
+#                          staging filesystem drops below min_free_gib
+# cleanup_policy = "immediate"
+
+# Organized staging directories kept by keep_recent
+# keep_recent = 3
+
+# Free space (GiB) keep_until_low_space maintains on the staging filesystem
+# min_free_gib = 100
+
+[free_space]
+# Free space (GiB) each directory's filesystem must keep after a large write.
+# Rips, encodes, library and review placement, and rip cache stores halt the
+# stage with a clear error instead of filling the disk. 0 disables a floor.
+# staging_gib = 10
+# library_gib = 10
+# review_gib = 10
+# rip_cache_gib = 10
+
 [disc_id_cache]
 # Enable disc ID -> TMDB ID cache
 # enabled = false
 
+[tmdb_cache]
+# Cache TMDB search and season responses on disk so reruns and box sets
+# reuse them (bypass once with: spindle disc identify --no-tmdb-cache)
+# enabled = false
+
+# Hours a cached response stays valid
+# ttl_hours = 168
+
+[scan_cache]
+# Cache MakeMKV disc scans by disc fingerprint so identification reruns skip
+# the scan. Inserting a different disc in the drive drops the old scan.
+# enabled = false
+
+# Minutes a cached scan stays valid
+# ttl_minutes = 60
+
 [makemkv]
 # Optical drive device path
 # optical_drive = "/dev/sr0"
 
-# Rip timeout in seconds (4 hours)
+# Rip timeout in seconds (4 hours) for each title. Titles larger than a
+# single-layer Blu-ray (25 GB) get a proportionally longer timeout.
 # rip_timeout = 14400
 
 # Disc info scan timeout in seconds (10 minutes)
@@ -126,6 +338,26 @@ api_key = ""
 # Skip titles shorter than this (seconds)
 # min_title_length = 120
 
+# TV discs: only titles within this range (seconds) are considered episodes,
+# keeping featurettes and menus out of episode matching. 0 disables either
+# bound; min_title_length still applies. Raise the minimum only for shows
+# whose episodes all run longer, since short episodes and cold opens can
+# run under five minutes.
+# min_episode_length = 0
+# max_episode_length = 10800
+
+# What to do with the disc once ripping ends: "keep" (leave the drive
+# closed), "eject" (eject after every rip, even a failed one), or
+# "on_success" (eject only after a successful rip, so a failed disc stays in
+# the drive for a retry). Applies only when optical_drive is a /dev path.
+# eject_after_rip = "keep"
+
+# Which cut to rip when a movie disc holds several full-length versions
+# (theatrical and extended cuts, told apart by runtime): "longest",
+# "theatrical" (the shortest cut), or "keep_all" (rip every cut and file each
+# with an edition tag, e.g. "Movie (1999) {edition-Extended}.mkv").
+# movie_versions = "longest"
+
 # Local KeyDB file path
 # keydb_path = "~/.config/spindle/keydb/KEYDB.cfg"
 
@@ -137,6 +369,35 @@ api_key = ""
 
 # Encoding uses Reel target-quality mode with Reel defaults.
 
+[encoding]
+# Order of items waiting to encode: "fifo" (arrival order), "shortest_first"
+# (quick wins), or "longest_first" (clear big jobs). Cost is estimated at
+# identification from runtime, resolution, and HDR.
+# queue_order = "fifo"
+
+# EBU R128 loudness normalization (ffmpeg loudnorm, two-pass) of the primary
+# audio track, applied by the apply stage: -23 LUFS integrated, -1 dBTP true
+# peak, 7 LU range. The normalized track is re-encoded to Opus; commentary
+# tracks are left untouched. Measured and applied values are recorded in the
+# rip spec.
+# loudnorm = false
+
+# Re-encode retained commentary tracks to stereo (96 kb/s) in one codec so
+# players handle them consistently: "opus" or "aac". Empty (default) keeps
+# commentary as encoded. The primary track is never affected; tracks already
+# stereo in the target codec are left alone. Conversions are recorded in the
+# rip spec.
+# commentary_codec = ""
+
+# Audio tracks kept after encoding, besides the primary track:
+# "primary_commentary" (default) keeps detected commentary and retained audio
+# description, "primary_only" drops everything else, "language" also keeps
+# every track in audio_keep_languages, and "all" keeps every track. The kept
+# track list is logged for each file.
+# audio_keep = "primary_commentary"
+# Languages kept by audio_keep = "language" (ISO 639 codes, e.g. ["en", "ja"])
+# audio_keep_languages = []
+
 [llm]
 # OpenRouter is used for ambiguous episode verification, commentary detection,
 # and best-effort subtitle audit. An empty key disables those LLM operations.
@@ -168,6 +429,29 @@ api_key = ""
 # LLM confidence required for classification
 # confidence_threshold = 0.80
 
+# Audio description narrates in the primary track's pauses. A candidate is
+# treated as audio description when at least this fraction of its speech
+# falls in primary-track silence (0-1, above 0)...
+# audio_description_min_gap_ratio = 0.80
+
+# ...and it talks over at most this fraction of the primary's speech (0-1,
+# above 0). Tracks mixing narration over the full soundtrack repeat the
+# primary's dialogue and are not recognized.
+# audio_description_max_overlap = 0.10
+
+# Keep detected audio-description tracks, labeled "Audio Description" and
+# flagged for the visually impaired, instead of excluding them
+# retain_audio_description = false
+
+# Cache candidate track transcripts so re-running analysis on an unchanged
+# rip skips WhisperX
+# stream_cache = false
+
+# Label hints matched case-insensitively against each candidate track's title.
+# A match is passed to the LLM classifier as a hint; the track is still
+# transcribed and classified. Add the wording your discs use.
+# keywords = ["commentary", "commentaire", "kommentar", "comentario", "commento"]
+
 [content_id]
 # Minimum cosine similarity required to keep a candidate claim
 # min_similarity_score = 0.58
@@ -184,8 +468,95 @@ api_key = ""
 # Strong-margin matches at or above this are labeled clear instead of decisive_low_similarity
 # clear_confidence_threshold = 0.85
 
+# Titles whose transcript has fewer distinct words than this skip similarity
+# matching; their episode is inferred from disc order and flagged for review
+# min_transcript_tokens = 50
+
+# Ask the LLM to choose between a title's two best candidate episodes, using
+# their TMDB synopses, when their scores are nearly tied. The choice is still
+# verified against the reference subtitle (requires [llm])
+# llm_tiebreaker = false
+
+# Neighboring seasons (on each side) searched for titles the disc's season
+# cannot match, for discs that span a season boundary. Each extra season is
+# another TMDB and OpenSubtitles fetch for every unmatched title, so it is
+# off by default; set 1 for sets known to cross a season boundary.
+# season_span = 0
+
+# Check titles no season claims against the show's specials (TMDB season 0)
+# and file matches as s00eNN in the "Season 00" folder, where Plex expects
+# bonus episodes. Shows with many specials are narrowed by runtime first.
+# match_specials = true
+
+[workflow]
+# Per-stage run-time limits in seconds. A stage that exceeds its limit is
+# cancelled and the item fails with a "stage timeout" error, ready for
+# retry. Stages not listed run without a limit. makemkv.rip_timeout still
+# bounds the MakeMKV process inside the ripping stage.
+# [workflow.stage_timeouts]
+# episode_identification = 7200
+# subtitling = 7200
+
+# Per-stage progress watchdogs in seconds. Once a stage has reported
+# progress, a percentage, message, or byte count frozen for this long (a
+# hung MakeMKV or Drapto) cancels the stage with a "stage stalled" error,
+# which stage_retries can retry. Quiet setup before the first progress
+# update, such as a disc scan, is left to stage_timeouts. Stages not listed
+# are not watched.
+# [workflow.stage_stall_timeouts]
+# ripping = 1800
+# encoding = 1800
+
+# Per-stage retry budgets. A failed stage re-runs up to this many times,
+# 30 seconds apart, before the item fails; once a budget runs out the item
+# is also flagged for review with every attempt's error. Stages not listed
+# fail on the first error, and failures that cannot succeed on a rerun
+# (such as a TV disc with no TMDB match) never retry.
+# [workflow.stage_retries]
+# identification = 2
+# organizing = 3
+
+# Seconds before a hung ffprobe validating a fresh rip is killed, so one
+# malformed file cannot stall the ripping lane (0 disables)
+# ffprobe_timeout = 120
+
+# Consecutive failed health checks of an external service (TMDB) before the
+# stages that need it are paused, so queued items wait instead of failing.
+# Paused stages resume on their own once a check passes. 0 disables.
+# dependency_pause_failures = 3
+
+# Seconds between dependency health checks
+# dependency_check_interval = 60
+
+# How many GPU stage runs (episode identification, audio analysis,
+# subtitling) may proceed at once across all items. WhisperX processes stay
+# capped by subtitles.whisperx_workers whatever this is; a higher capacity
+# lets one item's audio extraction and LLM work overlap another's
+# transcription.
+# gpu_capacity = 1
+
+# Count each encode against gpu_capacity too, when the encoder's quality
+# metric runs on the same card as WhisperX. With gpu_capacity = 1 this
+# serializes encoding with the WhisperX stages.
+# encode_uses_gpu = false
+
 [logging]
 # Days to retain daemon log files
 # retention_days = 60
+
+# Drop structured fields from daemon log records to cut log volume. Filters
+# apply to the log file, the terminal, and the API log stream. Sections are
+# "all" (every level), "debug", "info", "warn", and "error"; a record uses
+# "all" plus its own level. A field is dropped when a deny entry matches it
+# and no allow entry does; fields neither list names are kept. An entry
+# ending in "*" matches by prefix. item_id, stage, lane, component,
+# decision_type, and event_type are always kept; a deny entry matching one
+# is rejected.
+# [logging.fields.all]
+# deny = ["fingerprint"]
+#
+# [logging.fields.debug]
+# deny = ["candidate_*", "similarity"]
+# allow = ["candidate_title"]
 `
 }
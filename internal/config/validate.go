@@ -2,7 +2,18 @@ package config
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
+
+	"github.com/five82/spindle/internal/language"
+	"github.com/five82/spindle/internal/mediameta"
+)
+
+var (
+	tmdbLanguagePattern = regexp.MustCompile(`^[a-z]{2}(-[A-Z]{2})?$`)
+	tmdbRegionPattern   = regexp.MustCompile(`^[A-Z]{2}$`)
 )
 
 // Validate checks all configuration constraints and returns all errors joined.
@@ -10,7 +21,7 @@ func (c *Config) Validate() error {
 	var errs []string
 
 	// Required fields.
-	if c.TMDB.APIKey == "" {
+	if c.TMDB.APIKey == "" && !c.Offline {
 		errs = append(errs, "tmdb.api_key is required")
 	}
 	if c.Paths.StagingDir == "" {
@@ -25,12 +36,237 @@ func (c *Config) Validate() error {
 
 	// Value ranges.
 	errs = append(errs, ValidateContentID(c.ContentID)...)
+	if c.Subtitles.WhisperXMinConfidence < 0 || c.Subtitles.WhisperXMinConfidence >= 1 {
+		errs = append(errs, fmt.Sprintf("subtitles.whisperx_min_confidence must be >= 0 and < 1 (got %.2f)", c.Subtitles.WhisperXMinConfidence))
+	}
+	if c.Subtitles.WhisperXWorkers < 1 || c.Subtitles.WhisperXWorkers > 8 {
+		errs = append(errs, fmt.Sprintf("subtitles.whisperx_workers must be between 1 and 8 (got %d)", c.Subtitles.WhisperXWorkers))
+	}
+	if c.Subtitles.EpisodeWorkers < 1 || c.Subtitles.EpisodeWorkers > 8 {
+		errs = append(errs, fmt.Sprintf("subtitles.episode_workers must be between 1 and 8 (got %d)", c.Subtitles.EpisodeWorkers))
+	}
+	switch c.Subtitles.WhisperXComputeType {
+	case "", "float32", "int8":
+	case "float16", "int8_float16":
+		if !c.Subtitles.WhisperXCUDAEnabled {
+			errs = append(errs, fmt.Sprintf("subtitles.whisperx_compute_type %q requires whisperx_cuda_enabled", c.Subtitles.WhisperXComputeType))
+		}
+	default:
+		errs = append(errs, fmt.Sprintf("subtitles.whisperx_compute_type must be float16, float32, int8, or int8_float16 (got %q)", c.Subtitles.WhisperXComputeType))
+	}
+	if c.Subtitles.WhisperXDeviceIndex < 0 {
+		errs = append(errs, fmt.Sprintf("subtitles.whisperx_device_index must be >= 0 (got %d)", c.Subtitles.WhisperXDeviceIndex))
+	} else if c.Subtitles.WhisperXDeviceIndex > 0 && !c.Subtitles.WhisperXCUDAEnabled {
+		errs = append(errs, "subtitles.whisperx_device_index requires whisperx_cuda_enabled")
+	}
+	for _, pair := range []struct {
+		name string
+		val  float64
+	}{
+		{"commentary.audio_description_min_gap_ratio", c.Commentary.AudioDescriptionMinGapRatio},
+		{"commentary.audio_description_max_overlap", c.Commentary.AudioDescriptionMaxOverlap},
+	} {
+		if pair.val <= 0 || pair.val > 1 {
+			errs = append(errs, fmt.Sprintf("%s must be greater than 0 and at most 1 (got %.2f)", pair.name, pair.val))
+		}
+	}
+	if !tmdbLanguagePattern.MatchString(c.TMDB.Language) {
+		errs = append(errs, fmt.Sprintf("tmdb.language must be an ISO 639-1 code with optional region, such as \"de\" or \"de-DE\" (got %q)", c.TMDB.Language))
+	}
+	for _, lang := range c.TMDB.FallbackLanguages {
+		if lang != TMDBOriginalLanguage && !tmdbLanguagePattern.MatchString(lang) {
+			errs = append(errs, fmt.Sprintf("tmdb.fallback_languages entries must be ISO 639-1 codes with optional region, or %q (got %q)", TMDBOriginalLanguage, lang))
+		}
+	}
+	if c.TMDB.Region != "" && !tmdbRegionPattern.MatchString(c.TMDB.Region) {
+		errs = append(errs, fmt.Sprintf("tmdb.region must be an ISO 3166-1 code such as \"DE\" (got %q)", c.TMDB.Region))
+	}
+	if c.TMDB.MaxConcurrentSearches < 1 || c.TMDB.MaxConcurrentSearches > 8 {
+		errs = append(errs, fmt.Sprintf("tmdb.max_concurrent_searches must be between 1 and 8 (got %d)", c.TMDB.MaxConcurrentSearches))
+	}
+	if c.TMDB.ReviewCandidates < 0 || c.TMDB.ReviewCandidates > 20 {
+		errs = append(errs, fmt.Sprintf("tmdb.review_candidates must be between 0 and 20 (got %d)", c.TMDB.ReviewCandidates))
+	}
+	if c.TMDB.YearTolerance < 0 || c.TMDB.YearTolerance > 5 {
+		errs = append(errs, fmt.Sprintf("tmdb.year_tolerance must be between 0 and 5 (got %d)", c.TMDB.YearTolerance))
+	}
+	if c.TMDB.MatchThreshold < 1 || c.TMDB.MatchThreshold > 2 {
+		errs = append(errs, fmt.Sprintf("tmdb.match_threshold must be between 1.0 and 2.0 (got %g)", c.TMDB.MatchThreshold))
+	}
+	if c.TMDBCache.TTLHours <= 0 {
+		errs = append(errs, fmt.Sprintf("tmdb_cache.ttl_hours must be > 0 (got %d)", c.TMDBCache.TTLHours))
+	}
+	if c.ScanCache.TTLMinutes <= 0 {
+		errs = append(errs, fmt.Sprintf("scan_cache.ttl_minutes must be > 0 (got %d)", c.ScanCache.TTLMinutes))
+	}
+	if !validArtworkSize(c.Library.ArtworkSize) {
+		errs = append(errs, fmt.Sprintf("library.artwork_size must be \"original\" or a TMDB width such as \"w780\" (got %q)", c.Library.ArtworkSize))
+	}
+	if c.Notifications.ProgressIntervalMinutes < 0 {
+		errs = append(errs, fmt.Sprintf("notifications.progress_interval_minutes must be >= 0 (got %d)", c.Notifications.ProgressIntervalMinutes))
+	}
+	switch c.RipCache.StagingTransfer {
+	case StagingTransferHardlink, StagingTransferReflink, StagingTransferCopy:
+	default:
+		errs = append(errs, fmt.Sprintf("rip_cache.staging_transfer must be one of %s, %s, %s (got %q)",
+			StagingTransferHardlink, StagingTransferReflink, StagingTransferCopy, c.RipCache.StagingTransfer))
+	}
+	switch c.Paths.ReviewCollisionSuffix {
+	case CollisionSuffixNumeric, CollisionSuffixHash, CollisionSuffixTimestamp:
+	default:
+		errs = append(errs, fmt.Sprintf("paths.review_collision_suffix must be one of %s, %s, %s (got %q)",
+			CollisionSuffixNumeric, CollisionSuffixHash, CollisionSuffixTimestamp, c.Paths.ReviewCollisionSuffix))
+	}
+	switch c.Library.MovieStructure {
+	case MovieStructureFlat, MovieStructureByYear, MovieStructureByGenre:
+	case MovieStructureCustom:
+		if strings.TrimSpace(c.Library.MovieTemplate) == "" {
+			errs = append(errs, "library.movie_template is required when library.movie_structure is custom")
+		}
+	default:
+		errs = append(errs, fmt.Sprintf("library.movie_structure must be one of %s, %s, %s, %s (got %q)",
+			MovieStructureFlat, MovieStructureByYear, MovieStructureByGenre, MovieStructureCustom, c.Library.MovieStructure))
+	}
+	if err := mediameta.ValidateEpisodeFormat(c.Library.EpisodeFormat); err != nil {
+		errs = append(errs, fmt.Sprintf("library.episode_format: %v", err))
+	}
+	if c.WatchFolder.Dir != "" && c.WatchFolder.SettleSeconds < 1 {
+		errs = append(errs, fmt.Sprintf("watch_folder.settle_seconds must be >= 1 (got %d)", c.WatchFolder.SettleSeconds))
+	}
+	errs = append(errs, validateStageTimeouts("stage_timeouts", c.Workflow.StageTimeouts)...)
+	errs = append(errs, validateStageTimeouts("stage_stall_timeouts", c.Workflow.StageStallTimeouts)...)
+	errs = append(errs, validateStageRetries(c.Workflow.StageRetries)...)
+	errs = append(errs, validateLogFields(c.Logging.Fields)...)
+	if c.Workflow.FFprobeTimeout < 0 {
+		errs = append(errs, fmt.Sprintf("workflow.ffprobe_timeout must be >= 0 (got %d)", c.Workflow.FFprobeTimeout))
+	}
+	if c.Workflow.DependencyPauseFailures < 0 {
+		errs = append(errs, fmt.Sprintf("workflow.dependency_pause_failures must be >= 0 (got %d)", c.Workflow.DependencyPauseFailures))
+	}
+	if c.Workflow.DependencyPauseFailures > 0 && c.Workflow.DependencyCheckInterval < 1 {
+		errs = append(errs, fmt.Sprintf("workflow.dependency_check_interval must be >= 1 (got %d)", c.Workflow.DependencyCheckInterval))
+	}
+	if c.Workflow.GPUCapacity < 1 {
+		errs = append(errs, fmt.Sprintf("workflow.gpu_capacity must be >= 1 (got %d)", c.Workflow.GPUCapacity))
+	}
+	if c.API.ReconnectTimeout < 0 {
+		errs = append(errs, fmt.Sprintf("api.reconnect_timeout must be >= 0 (got %d)", c.API.ReconnectTimeout))
+	}
 	if c.MakeMKV.RipTimeout <= 0 {
 		errs = append(errs, fmt.Sprintf("makemkv.rip_timeout must be > 0 (got %d)", c.MakeMKV.RipTimeout))
 	}
 	if c.MakeMKV.MinTitleLength < 0 {
 		errs = append(errs, fmt.Sprintf("makemkv.min_title_length must be >= 0 (got %d)", c.MakeMKV.MinTitleLength))
 	}
+	if c.MakeMKV.MinEpisodeLength < 0 {
+		errs = append(errs, fmt.Sprintf("makemkv.min_episode_length must be >= 0 (got %d)", c.MakeMKV.MinEpisodeLength))
+	}
+	if c.MakeMKV.MaxEpisodeLength < 0 || (c.MakeMKV.MaxEpisodeLength > 0 && c.MakeMKV.MaxEpisodeLength <= c.MakeMKV.MinEpisodeLength) {
+		errs = append(errs, fmt.Sprintf("makemkv.max_episode_length must be 0 or greater than min_episode_length (got %d)", c.MakeMKV.MaxEpisodeLength))
+	}
+	switch c.MakeMKV.EjectAfterRip {
+	case EjectAfterRipKeep, EjectAfterRipAlways, EjectAfterRipOnSuccess:
+	default:
+		errs = append(errs, fmt.Sprintf("makemkv.eject_after_rip must be one of %s, %s, %s (got %q)",
+			EjectAfterRipKeep, EjectAfterRipAlways, EjectAfterRipOnSuccess, c.MakeMKV.EjectAfterRip))
+	}
+	switch c.MakeMKV.MovieVersions {
+	case MovieVersionsLongest, MovieVersionsTheatrical, MovieVersionsKeepAll:
+	default:
+		errs = append(errs, fmt.Sprintf("makemkv.movie_versions must be one of %s, %s, %s (got %q)",
+			MovieVersionsLongest, MovieVersionsTheatrical, MovieVersionsKeepAll, c.MakeMKV.MovieVersions))
+	}
+	if len(c.Subtitles.SourcePriority) == 0 {
+		errs = append(errs, "subtitles.source_priority must list at least one source")
+	}
+	seenSources := make(map[string]bool, len(c.Subtitles.SourcePriority))
+	for _, source := range c.Subtitles.SourcePriority {
+		switch source {
+		case SubtitleSourceEmbedded, SubtitleSourceOpenSubtitles, SubtitleSourceWhisperX:
+		default:
+			errs = append(errs, fmt.Sprintf("subtitles.source_priority entries must be one of %s, %s, %s (got %q)",
+				SubtitleSourceEmbedded, SubtitleSourceOpenSubtitles, SubtitleSourceWhisperX, source))
+			continue
+		}
+		if seenSources[source] {
+			errs = append(errs, fmt.Sprintf("subtitles.source_priority lists %q more than once", source))
+		}
+		seenSources[source] = true
+	}
+	if c.Subtitles.MinCoverage < 0 || c.Subtitles.MinCoverage > 1 {
+		errs = append(errs, fmt.Sprintf("subtitles.min_coverage must be between 0 and 1 (got %g)", c.Subtitles.MinCoverage))
+	}
+	switch c.Subtitles.HearingImpaired {
+	case HearingImpairedPrefer, HearingImpairedAvoid, HearingImpairedEither:
+	default:
+		errs = append(errs, fmt.Sprintf("subtitles.hearing_impaired must be one of %s, %s, %s (got %q)",
+			HearingImpairedPrefer, HearingImpairedAvoid, HearingImpairedEither, c.Subtitles.HearingImpaired))
+	}
+
+	for _, keyword := range c.Commentary.Keywords {
+		if strings.TrimSpace(keyword) == "" {
+			errs = append(errs, "commentary.keywords entries must not be empty")
+			break
+		}
+	}
+
+	switch c.Staging.CleanupPolicy {
+	case StagingCleanupImmediate:
+	case StagingCleanupKeepRecent:
+		if c.Staging.KeepRecent < 1 {
+			errs = append(errs, fmt.Sprintf("staging.keep_recent must be at least 1 (got %d)", c.Staging.KeepRecent))
+		}
+	case StagingCleanupKeepUntilLowSpace:
+		if c.Staging.MinFreeGiB < 1 {
+			errs = append(errs, fmt.Sprintf("staging.min_free_gib must be at least 1 (got %d)", c.Staging.MinFreeGiB))
+		}
+	default:
+		errs = append(errs, fmt.Sprintf("staging.cleanup_policy must be one of %s, %s, %s (got %q)",
+			StagingCleanupImmediate, StagingCleanupKeepRecent, StagingCleanupKeepUntilLowSpace, c.Staging.CleanupPolicy))
+	}
+
+	for _, floor := range []struct {
+		key string
+		gib int
+	}{
+		{"staging_gib", c.FreeSpace.StagingGiB},
+		{"library_gib", c.FreeSpace.LibraryGiB},
+		{"review_gib", c.FreeSpace.ReviewGiB},
+		{"rip_cache_gib", c.FreeSpace.RipCacheGiB},
+	} {
+		if floor.gib < 0 {
+			errs = append(errs, fmt.Sprintf("free_space.%s must be >= 0 (got %d)", floor.key, floor.gib))
+		}
+	}
+
+	switch c.Encoding.QueueOrder {
+	case EncodeOrderFIFO, EncodeOrderShortestFirst, EncodeOrderLongestFirst:
+	default:
+		errs = append(errs, fmt.Sprintf("encoding.queue_order must be one of %s, %s, %s (got %q)",
+			EncodeOrderFIFO, EncodeOrderShortestFirst, EncodeOrderLongestFirst, c.Encoding.QueueOrder))
+	}
+	switch c.Encoding.CommentaryCodec {
+	case "":
+	case CommentaryCodecOpus, CommentaryCodecAAC:
+	default:
+		errs = append(errs, fmt.Sprintf("encoding.commentary_codec must be empty, %s, or %s (got %q)",
+			CommentaryCodecOpus, CommentaryCodecAAC, c.Encoding.CommentaryCodec))
+	}
+	switch c.Encoding.AudioKeep {
+	case AudioKeepPrimaryCommentary, AudioKeepPrimaryOnly, AudioKeepAll:
+	case AudioKeepLanguage:
+		if len(c.Encoding.AudioKeepLanguages) == 0 {
+			errs = append(errs, "encoding.audio_keep = \"language\" requires encoding.audio_keep_languages")
+		}
+		for _, lang := range c.Encoding.AudioKeepLanguages {
+			if language.ToISO2(lang) == "" {
+				errs = append(errs, fmt.Sprintf("encoding.audio_keep_languages has unknown language %q", lang))
+			}
+		}
+	default:
+		errs = append(errs, fmt.Sprintf("encoding.audio_keep must be one of %s, %s, %s, %s (got %q)",
+			AudioKeepPrimaryCommentary, AudioKeepPrimaryOnly, AudioKeepLanguage, AudioKeepAll, c.Encoding.AudioKeep))
+	}
 
 	// Conditional requirements.
 	if c.Jellyfin.Enabled {
@@ -54,7 +290,8 @@ func (c *Config) Validate() error {
 	return nil
 }
 
-// ValidateContentID checks episode identification threshold ranges.
+// ValidateContentID checks episode identification thresholds, the transcript
+// gate, and season span.
 func ValidateContentID(cid ContentIDConfig) []string {
 	var errs []string
 	for _, pair := range []struct {
@@ -74,5 +311,122 @@ func ValidateContentID(cid ContentIDConfig) []string {
 	if cid.DecisiveAutoAcceptThreshold <= cid.LowConfidenceReviewThreshold || cid.DecisiveAutoAcceptThreshold > cid.ClearConfidenceThreshold {
 		errs = append(errs, "content_id.decisive_auto_accept_threshold must be > low_confidence_review_threshold and <= clear_confidence_threshold")
 	}
+	if cid.MinTranscriptTokens <= 0 {
+		errs = append(errs, fmt.Sprintf("content_id.min_transcript_tokens must be > 0 (got %d)", cid.MinTranscriptTokens))
+	}
+	if cid.SeasonSpan < 0 || cid.SeasonSpan > 3 {
+		errs = append(errs, fmt.Sprintf("content_id.season_span must be between 0 and 3 (got %d)", cid.SeasonSpan))
+	}
+	return errs
+}
+
+// validArtworkSize accepts "original" or a TMDB width size like "w780".
+func validArtworkSize(size string) bool {
+	if size == "original" {
+		return true
+	}
+	digits, ok := strings.CutPrefix(size, "w")
+	if !ok || digits == "" {
+		return false
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// stageNames lists the pipeline stages that per-stage settings may name.
+// It mirrors queue.StageOrder; config cannot import queue.
+var stageNames = []string{
+	"identification",
+	"ripping",
+	"episode_identification",
+	"encoding",
+	"analysis",
+	"subtitling",
+	"apply",
+	"organizing",
+}
+
+// validateStageTimeouts checks that every key of the workflow table named by
+// key names a pipeline stage and every limit is positive.
+func validateStageTimeouts(key string, timeouts map[string]int) []string {
+	stages := make([]string, 0, len(timeouts))
+	for name := range timeouts {
+		stages = append(stages, name)
+	}
+	sort.Strings(stages)
+
+	var errs []string
+	for _, name := range stages {
+		if !slices.Contains(stageNames, name) {
+			errs = append(errs, fmt.Sprintf("workflow.%s has unknown stage %q", key, name))
+			continue
+		}
+		if timeouts[name] <= 0 {
+			errs = append(errs, fmt.Sprintf("workflow.%s.%s must be > 0 (got %d)", key, name, timeouts[name]))
+		}
+	}
+	return errs
+}
+
+// validateStageRetries checks that every workflow.stage_retries key names a
+// pipeline stage and every budget is between 0 and 10.
+func validateStageRetries(retries map[string]int) []string {
+	stages := make([]string, 0, len(retries))
+	for name := range retries {
+		stages = append(stages, name)
+	}
+	sort.Strings(stages)
+
+	var errs []string
+	for _, name := range stages {
+		if !slices.Contains(stageNames, name) {
+			errs = append(errs, fmt.Sprintf("workflow.stage_retries has unknown stage %q", name))
+			continue
+		}
+		if retries[name] < 0 || retries[name] > 10 {
+			errs = append(errs, fmt.Sprintf("workflow.stage_retries.%s must be between 0 and 10 (got %d)", name, retries[name]))
+		}
+	}
+	return errs
+}
+
+// validateLogFields checks that every logging.fields key names a level, no
+// allow or deny entry is blank, and no deny entry matches a KeptLogFields
+// field.
+func validateLogFields(fields map[string]LogFieldFilter) []string {
+	levels := make([]string, 0, len(fields))
+	for name := range fields {
+		levels = append(levels, name)
+	}
+	sort.Strings(levels)
+
+	var errs []string
+	for _, name := range levels {
+		if !slices.Contains(LogFieldLevels, name) {
+			errs = append(errs, fmt.Sprintf("logging.fields has unknown level %q (want one of %s)", name, strings.Join(LogFieldLevels, ", ")))
+			continue
+		}
+		blank := func(e string) bool { return strings.TrimSpace(e) == "" }
+		if slices.ContainsFunc(fields[name].Allow, blank) {
+			errs = append(errs, fmt.Sprintf("logging.fields.%s.allow must not contain blank entries", name))
+		}
+		if slices.ContainsFunc(fields[name].Deny, blank) {
+			errs = append(errs, fmt.Sprintf("logging.fields.%s.deny must not contain blank entries", name))
+		}
+		for _, entry := range fields[name].Deny {
+			prefix, wildcard := strings.CutSuffix(entry, "*")
+			for _, kept := range KeptLogFields {
+				if kept == entry || (wildcard && strings.HasPrefix(kept, prefix)) {
+					errs = append(errs, fmt.Sprintf("logging.fields.%s.deny entry %q matches %s, which is always kept (%s)",
+						name, entry, kept, strings.Join(KeptLogFields, ", ")))
+					break
+				}
+			}
+		}
+	}
 	return errs
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/five82/spindle/internal/llm"
 	"github.com/five82/spindle/internal/logs"
 	"github.com/five82/spindle/internal/opensubtitles"
+	"github.com/five82/spindle/internal/queue"
 	"github.com/five82/spindle/internal/ripspec"
 	"github.com/five82/spindle/internal/stage"
 	"github.com/five82/spindle/internal/textutil"
@@ -51,6 +52,22 @@ func New(
 // Compile-time check that Handler implements stage.Handler.
 var _ stage.Handler = (*Handler)(nil)
 
+// missingDependencies names the content matcher clients that are not
+// configured; matching needs all of them.
+func (h *Handler) missingDependencies() []string {
+	var missing []string
+	if h.transcriber == nil {
+		missing = append(missing, "transcriber")
+	}
+	if h.osClient == nil {
+		missing = append(missing, "OpenSubtitles client")
+	}
+	if h.tmdbClient == nil {
+		missing = append(missing, "TMDB client")
+	}
+	return missing
+}
+
 // Run executes the episode identification stage.
 func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 	item := sess.Item
@@ -65,17 +82,26 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 			"decision_type", logs.DecisionEpisodeIDSkip,
 			"decision_result", "skipped",
 			"decision_reason", "media type is movie",
+			"skip_category", ripspec.SkipContentType,
 		)
+		sess.RecordSkip(ripspec.StageSkip{
+			Stage: string(queue.StageEpisodeIdentification), Category: ripspec.SkipContentType, Reason: "media type is movie",
+		})
 		return nil
 	default:
 		if mediaType == "" {
 			mediaType = "unknown"
 		}
+		reason := fmt.Sprintf("media type is %s", mediaType)
 		logger.Info("skipping episode identification for non-TV content",
 			"decision_type", logs.DecisionEpisodeIDSkip,
 			"decision_result", "skipped",
-			"decision_reason", fmt.Sprintf("media type is %s", mediaType),
+			"decision_reason", reason,
+			"skip_category", ripspec.SkipContentType,
 		)
+		sess.RecordSkip(ripspec.StageSkip{
+			Stage: string(queue.StageEpisodeIdentification), Category: ripspec.SkipContentType, Reason: reason,
+		})
 		return nil
 	}
 
@@ -87,7 +113,22 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 		"disc_number", env.Metadata.DiscNumber,
 	)
 
-	if h.transcriber == nil || h.osClient == nil || h.tmdbClient == nil {
+	if missing := h.missingDependencies(); len(missing) > 0 {
+		reason := "content matcher unavailable: no " + strings.Join(missing, ", ")
+		category := ripspec.SkipMissingDependency
+		if h.cfg != nil && h.cfg.Offline {
+			reason = "offline mode: " + reason
+			category = ripspec.SkipDisabled
+		}
+		logger.Info("skipping episode identification without content matcher",
+			"decision_type", logs.DecisionEpisodeIDSkip,
+			"decision_result", "skipped",
+			"decision_reason", reason,
+			"skip_category", category,
+		)
+		env.RecordSkip(ripspec.StageSkip{
+			Stage: string(queue.StageEpisodeIdentification), Category: category, Reason: reason,
+		})
 		env.Attributes.ContentID = newDegradedContentIDSummary(h.policy, 0, 0)
 		sess.AddReviewReason("Episode ID: content matcher unavailable")
 		if err := sess.Save(); err != nil {
@@ -149,6 +190,17 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 	if err != nil {
 		return err
 	}
+	ripPrints, sparsePrints := gateSparseTranscripts(ripPrints, h.policy.MinTranscriptTokens)
+	for _, rip := range sparsePrints {
+		logger.Info("content ID transcript too sparse for matching",
+			"decision_type", logs.DecisionContentIDTranscriptGate,
+			"decision_result", "skipped",
+			"decision_reason", "transcript below minimum distinct token count",
+			"episode_key", rip.EpisodeKey,
+			"token_count", len(rip.Vector.Terms),
+			"min_transcript_tokens", h.policy.MinTranscriptTokens,
+		)
+	}
 	if len(ripPrints) == 0 {
 		logger.Warn("no valid transcriptions for episode ID",
 			"event_type", "episode_id_no_transcripts",
@@ -179,7 +231,7 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 		return &stage.ErrDegraded{Msg: "no reference subtitles found"}
 	}
 
-	matches, refs, err := h.matchEpisodes(ctx, sess, env, season, seasonNum, plan, ripPrints, refs, refCache)
+	matches, refs, err := h.matchEpisodes(ctx, sess, env, season, seasonNum, plan, ripPrints, sparsePrints, refs, refCache)
 	if err != nil {
 		return err
 	}
@@ -201,7 +253,8 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 // fingerprints, expanding the reference scope and re-fetching when the
 // initial candidates are insufficient, verifying ambiguous pairs via LLM,
 // and applying the accepted matches to the envelope (task: episode_match).
-// It returns the accepted matches and the (possibly expanded) reference set.
+// Sparse-transcript rips take no part in matching; they are placed by disc
+// order where their matched neighbors allow it. It returns the accepted matches and the (possibly expanded) reference set.
 func (h *Handler) matchEpisodes(
 	ctx context.Context,
 	sess *stage.Session,
@@ -210,6 +263,7 @@ func (h *Handler) matchEpisodes(
 	seasonNum int,
 	plan candidateEpisodePlan,
 	ripPrints []ripFingerprint,
+	sparsePrints []ripFingerprint,
 	refs []referenceFingerprint,
 	refCache map[int]referenceFingerprint,
 ) ([]matchResult, []referenceFingerprint, error) {
@@ -261,7 +315,12 @@ func (h *Handler) matchEpisodes(
 	}
 
 	matches := append([]matchResult(nil), resolution.Accepted...)
-	verifiedMatches, remainingPending, verifyResult := verifyMatches(ctx, h.llmClient, matches, resolution.PendingByRip, ripPrints, refs, logger)
+	pending := resolution.PendingByRip
+	if h.cfg != nil && h.cfg.ContentID.LLMTiebreaker {
+		pending = breakNearTies(ctx, h.llmClient, matches, pending, ripPrints, season, logger)
+	}
+	syncReferences := h.cfg != nil && h.cfg.Subtitles.SyncReferenceOffset
+	verifiedMatches, remainingPending, verifyResult := verifyMatches(ctx, h.llmClient, matches, pending, ripPrints, refs, syncReferences, logger)
 	matches = verifiedMatches
 	if verifyResult != nil && verifyResult.NeedsReview && verifyResult.ReviewReason != "" {
 		sess.AddReviewReason("Episode ID: " + verifyResult.ReviewReason)
@@ -280,19 +339,57 @@ func (h *Handler) matchEpisodes(
 		sess.AddReviewReason("Episode ID: one or more matches rely on suspect references")
 	}
 
+	seasons := map[int]*tmdb.Season{seasonNum: season}
+	crossSeason := make(map[string]struct{})
+	if unmatched := unmatchedRips(ripPrints, matches); len(unmatched) > 0 && h.cfg != nil && h.cfg.ContentID.SeasonSpan > 0 {
+		crossMatches, adjacent, err := h.matchAdjacentSeasons(ctx, logger, item, env, seasonNum, unmatched)
+		if err != nil {
+			return nil, nil, err
+		}
+		for num, s := range adjacent {
+			seasons[num] = s
+		}
+		for _, m := range crossMatches {
+			crossSeason[strings.ToLower(m.EpisodeKey)] = struct{}{}
+			delete(remainingPending, m.EpisodeKey)
+		}
+		matches = append(matches, crossMatches...)
+	}
+	if unmatched := unmatchedRips(ripPrints, matches); len(unmatched) > 0 && h.cfg != nil && h.cfg.ContentID.MatchSpecials {
+		specialMatches, specials, err := h.matchSpecials(ctx, logger, item, env, unmatched)
+		if err != nil {
+			return nil, nil, err
+		}
+		if specials != nil {
+			seasons[ripspec.SpecialsSeason] = specials
+		}
+		for _, m := range specialMatches {
+			crossSeason[strings.ToLower(m.EpisodeKey)] = struct{}{}
+			delete(remainingPending, m.EpisodeKey)
+		}
+		matches = append(matches, specialMatches...)
+	}
+	matches = append(matches, inferSparseEpisodes(env.Episodes, matches, sparsePrints)...)
+	sparseRips := make(map[string]struct{}, len(sparsePrints))
+	for _, rip := range sparsePrints {
+		sparseRips[strings.ToLower(rip.EpisodeKey)] = struct{}{}
+	}
+
 	_ = sess.Progress(80, "Phase 3/3 - Matching episodes")
 
 	noClaimRips := make(map[string]struct{}, len(resolution.RipsWithoutClaims))
 	for _, key := range resolution.RipsWithoutClaims {
-		noClaimRips[strings.ToLower(key)] = struct{}{}
+		if _, ok := crossSeason[strings.ToLower(key)]; !ok {
+			noClaimRips[strings.ToLower(key)] = struct{}{}
+		}
 	}
-	h.applyMatches(logger, env, seasonNum, season, matches, sess, noClaimRips, remainingPending)
+	h.applyMatches(logger, env, seasonNum, seasons, matches, sess, noClaimRips, sparseRips, remainingPending)
 
 	// Structural gaps are checked on the envelope after opening-double
 	// correction so a legitimately renumbered E1-E2 opener is not flagged. A
 	// known-incomplete episode set routes every resolved episode to review
 	// instead of delivering a partial season to the library.
-	if reasons := structuralReviewReasons(env.Episodes, env.Metadata.DiscNumber); len(reasons) > 0 {
+	if reasons := structuralReviewReasons(primarySeasonEpisodes(env.Episodes, seasonNum), env.Metadata.DiscNumber); len(reasons) > 0 {
 		joined := strings.Join(reasons, "; ")
 		for _, reason := range reasons {
 			sess.AddReviewReason("Episode ID: " + reason)
@@ -336,8 +433,9 @@ func (h *Handler) generateEpisodeFingerprints(ctx context.Context, sess *stage.S
 	}
 
 	// Select the primary audio track per episode (cheap ffprobe), then
-	// transcribe every episode in ONE WhisperX invocation so uvx startup and
-	// model load are paid once per disc instead of once per episode.
+	// transcribe every episode in ONE batch so uvx startup and model load are
+	// paid once per disc (or once per worker with subtitles.whisperx_workers)
+	// instead of once per episode.
 	var batched []ripspec.Episode
 	var reqs []transcription.TranscribeRequest
 	for _, ep := range env.Episodes {
@@ -388,7 +486,7 @@ func (h *Handler) generateEpisodeFingerprints(ctx context.Context, sess *stage.S
 		}); err != nil {
 			return nil, fmt.Errorf("record transcript asset %s: %w", ep.Key, err)
 		}
-		text := readSRTText(result.SRTPath)
+		text := readTranscriptText(result.SRTPath, result.JSONPath, h.cfg.Subtitles.WhisperXMinConfidence)
 		fp := textutil.NewFingerprint(text)
 		if fp == nil {
 			continue
@@ -397,6 +495,7 @@ func (h *Handler) generateEpisodeFingerprints(ctx context.Context, sess *stage.S
 			EpisodeKey: ep.Key,
 			TitleID:    ep.TitleID,
 			Path:       result.SRTPath,
+			VideoPath:  reqs[i].InputPath,
 			Vector:     fp,
 			RawVector:  fp,
 		})
@@ -451,14 +550,20 @@ func buildContentIDSummary(env *ripspec.Envelope, matches []matchResult, transcr
 	return summary
 }
 
+// applyMatches writes accepted matches onto the envelope's episodes. seasons
+// maps season numbers to their TMDB details: seasonNum for the disc's own
+// season plus any neighboring season a cross-season match points at, and
+// season 0 when a rip matched a special.
+// sparseRips holds the rips gated out of matching for too little dialogue.
 func (h *Handler) applyMatches(
 	logger *slog.Logger,
 	env *ripspec.Envelope,
 	seasonNum int,
-	season *tmdb.Season,
+	seasons map[int]*tmdb.Season,
 	matches []matchResult,
 	sess *stage.Session,
 	noClaimRips map[string]struct{},
+	sparseRips map[string]struct{},
 	pending map[string][]matchResult,
 ) {
 	matchMap := make(map[string]matchResult, len(matches))
@@ -470,19 +575,35 @@ func (h *Handler) applyMatches(
 		pendingByKey[strings.ToLower(key)] = claims
 	}
 
-	episodeDetails := make(map[int]tmdb.Episode, len(season.Episodes))
-	for _, ep := range season.Episodes {
-		episodeDetails[ep.EpisodeNumber] = ep
+	episodeDetails := make(map[int]map[int]tmdb.Episode, len(seasons))
+	for num, season := range seasons {
+		byNumber := make(map[int]tmdb.Episode, len(season.Episodes))
+		for _, ep := range season.Episodes {
+			byNumber[ep.EpisodeNumber] = ep
+		}
+		episodeDetails[num] = byNumber
 	}
 
 	unresolvedCount := 0
 	probableExtraCount := 0
 	lowConfCount := 0
+	sparseCount := 0
+	inferredCount := 0
 	for i := range env.Episodes {
 		ep := &env.Episodes[i]
 		m, ok := matchMap[strings.ToLower(ep.Key)]
 		if !ok {
-			if _, noClaim := noClaimRips[strings.ToLower(ep.Key)]; noClaim {
+			if _, sparse := sparseRips[strings.ToLower(ep.Key)]; sparse {
+				sparseCount++
+				ep.AppendReviewReason("Episode ID: transcript too sparse to match")
+				logger.Info("sparse-transcript rip left unresolved",
+					"decision_type", logs.DecisionEpisodeMatch,
+					"decision_result", fmt.Sprintf("%s -> unresolved", ep.Key),
+					"decision_reason", "transcript gated out and disc order gave no unambiguous episode",
+					"episode_key", ep.Key,
+					"title_id", ep.TitleID,
+				)
+			} else if _, noClaim := noClaimRips[strings.ToLower(ep.Key)]; noClaim {
 				probableExtraCount++
 				ep.AppendReviewReason("Episode ID: probable extra; no candidate episode matched")
 				logger.Info("rip classified as probable extra",
@@ -521,17 +642,36 @@ func (h *Handler) applyMatches(
 			}
 			continue
 		}
-		details := episodeDetails[m.TargetEpisode]
-		ep.Season = seasonNum
+		targetSeason := seasonNum
+		switch {
+		case m.Special:
+			targetSeason = ripspec.SpecialsSeason
+		case m.TargetSeason > 0:
+			targetSeason = m.TargetSeason
+		}
+		details := episodeDetails[targetSeason][m.TargetEpisode]
+		ep.Season = targetSeason
 		ep.Episode = m.TargetEpisode
 		ep.EpisodeTitle = strings.TrimSpace(details.Name)
 		ep.EpisodeAirDate = strings.TrimSpace(details.AirDate)
 		ep.MatchScore = m.Score
 		ep.MatchConfidence = m.Confidence
+		if m.AcceptedBy == acceptedByDiscOrder {
+			inferredCount++
+			ep.AppendReviewReason("Episode ID: transcript too sparse to match; episode inferred from disc order")
+			logger.Info("episode inferred from disc order",
+				"decision_type", logs.DecisionEpisodeMatch,
+				"decision_result", fmt.Sprintf("%s -> E%02d", ep.Key, m.TargetEpisode),
+				"decision_reason", m.AcceptedBy,
+				"season", targetSeason,
+			)
+			continue
+		}
 		logger.Info("episode matched",
 			"decision_type", logs.DecisionEpisodeMatch,
 			"decision_result", fmt.Sprintf("%s -> E%02d", ep.Key, m.TargetEpisode),
 			"decision_reason", m.AcceptedBy,
+			"season", targetSeason,
 			"match_score", m.Score,
 			"weighted_match_score", m.WeightedScore,
 			"raw_match_score", m.RawScore,
@@ -575,7 +715,7 @@ func (h *Handler) applyMatches(
 			)
 		}
 	}
-	applyOpeningDoubleEpisode(logger, env, seasonNum, env.Metadata.DiscNumber, episodeDetails)
+	applyOpeningDoubleEpisode(logger, env, seasonNum, env.Metadata.DiscNumber, episodeDetails[seasonNum])
 
 	if unresolvedCount > 0 {
 		sess.AddReviewReason(fmt.Sprintf("Episode ID: %d of %d episodes unresolved", unresolvedCount, len(env.Episodes)))
@@ -586,6 +726,12 @@ func (h *Handler) applyMatches(
 	if lowConfCount > 0 {
 		sess.AddReviewReason(fmt.Sprintf("Episode ID: %d matches below confidence threshold %.2f", lowConfCount, h.policy.LowConfidenceReviewThreshold))
 	}
+	if inferredCount > 0 {
+		sess.AddReviewReason(fmt.Sprintf("Episode ID: %d episode(s) inferred from disc order after sparse transcripts", inferredCount))
+	}
+	if sparseCount > 0 {
+		sess.AddReviewReason(fmt.Sprintf("Episode ID: %d rip(s) with transcripts too sparse to match", sparseCount))
+	}
 }
 
 // structuralReviewReasons inspects the final episode numbering (after
@@ -644,7 +790,7 @@ func applyOpeningDoubleEpisode(logger *slog.Logger, env *ripspec.Envelope, seaso
 		return
 	}
 	for _, ep := range env.Episodes {
-		if ep.Episode <= 0 {
+		if ep.Episode <= 0 || ep.Season != seasonNum {
 			return
 		}
 	}
@@ -3,6 +3,7 @@ package contentid
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"log/slog"
 	"net/http"
@@ -37,10 +38,10 @@ This is a test.
 	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
 		t.Fatal(err)
 	}
-	got := readSRTText(path)
+	got := readTranscriptText(path, "", 0)
 	want := "Hello world. This is a test."
 	if got != want {
-		t.Fatalf("readSRTText got %q want %q", got, want)
+		t.Fatalf("readTranscriptText got %q want %q", got, want)
 	}
 }
 
@@ -156,34 +157,63 @@ func TestHasExactEpisodeMarkerRequiresEpisodeBoundary(t *testing.T) {
 	}
 }
 
-func TestRunSkipsNonTVContent(t *testing.T) {
-	env := ripspec.Envelope{Version: ripspec.CurrentVersion, Metadata: ripspec.Metadata{MediaType: "unknown"}}
-	data, err := json.Marshal(env)
-	if err != nil {
-		t.Fatal(err)
-	}
-	store, err := queue.Open(filepath.Join(t.TempDir(), "queue.db"))
-	if err != nil {
-		t.Fatalf("open queue: %v", err)
-	}
-	defer func() { _ = store.Close() }()
-	item, err := store.NewDisc("Test", "fp1")
-	if err != nil {
-		t.Fatalf("new disc: %v", err)
-	}
-	item.RipSpecData = string(data)
-	if err := store.UpdateWorkState(item); err != nil {
-		t.Fatalf("update work state: %v", err)
+func TestRunRecordsSkipReason(t *testing.T) {
+	tests := []struct {
+		mediaType string
+		category  string
+		reason    string
+	}{
+		{"unknown", ripspec.SkipContentType, "media type is unknown"},
+		{"movie", ripspec.SkipContentType, "media type is movie"},
+		{"tv", ripspec.SkipMissingDependency, "content matcher unavailable: no transcriber, OpenSubtitles client, TMDB client"},
 	}
+	for _, tt := range tests {
+		t.Run(tt.mediaType, func(t *testing.T) {
+			env := ripspec.Envelope{Version: ripspec.CurrentVersion, Metadata: ripspec.Metadata{MediaType: tt.mediaType}}
+			data, err := json.Marshal(env)
+			if err != nil {
+				t.Fatal(err)
+			}
+			store, err := queue.Open(filepath.Join(t.TempDir(), "queue.db"))
+			if err != nil {
+				t.Fatalf("open queue: %v", err)
+			}
+			defer func() { _ = store.Close() }()
+			item, err := store.NewDisc("Test", "fp1")
+			if err != nil {
+				t.Fatalf("new disc: %v", err)
+			}
+			item.RipSpecData = string(data)
+			if err := store.UpdateWorkState(item); err != nil {
+				t.Fatalf("update work state: %v", err)
+			}
 
-	h := &Handler{}
-	ctx := context.Background()
-	sess, err := stage.NewSession(ctx, store, item, nil)
-	if err != nil {
-		t.Fatalf("NewSession: %v", err)
-	}
-	if err := h.Run(ctx, sess); err != nil {
-		t.Fatalf("Run returned error for non-TV content: %v", err)
+			h := &Handler{}
+			ctx := context.Background()
+			sess, err := stage.NewSession(ctx, store, item, nil)
+			if err != nil {
+				t.Fatalf("NewSession: %v", err)
+			}
+			if err := h.Run(ctx, sess); err != nil {
+				var degraded *stage.ErrDegraded
+				if tt.category != ripspec.SkipMissingDependency || !errors.As(err, &degraded) {
+					t.Fatalf("Run: %v", err)
+				}
+			}
+
+			stored, err := store.GetByID(item.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			persisted, err := ripspec.Parse(stored.RipSpecData)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := []ripspec.StageSkip{{Stage: "episode_identification", Category: tt.category, Reason: tt.reason}}
+			if !reflect.DeepEqual(persisted.Attributes.StageSkips, want) {
+				t.Fatalf("stage skips = %+v, want %+v", persisted.Attributes.StageSkips, want)
+			}
+		})
 	}
 }
 
@@ -409,7 +439,7 @@ func TestVerifyMatchesConfirmsPairWithoutInflatingConfidence(t *testing.T) {
 	}
 	accepted, remaining, result := verifyMatches(context.Background(), client, nil, map[string][]matchResult{
 		"s01_001": {candidate},
-	}, []ripFingerprint{{EpisodeKey: "s01_001", Path: ripPath}}, []referenceFingerprint{{EpisodeNumber: 7, CachePath: refPath}}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	}, []ripFingerprint{{EpisodeKey: "s01_001", Path: ripPath}}, []referenceFingerprint{{EpisodeNumber: 7, CachePath: refPath}}, false, slog.New(slog.NewTextHandler(io.Discard, nil)))
 	if result == nil || result.Verified != 1 {
 		t.Fatalf("expected one verified match, got %+v", result)
 	}
@@ -427,6 +457,58 @@ func TestVerifyMatchesConfirmsPairWithoutInflatingConfidence(t *testing.T) {
 	}
 }
 
+func TestBreakNearTiesOnlyConsultsLLMOnNearTie(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{
+				"message": map[string]any{
+					"content": `{"choice":"B","explanation":"synopsis B mentions the trial"}`,
+				},
+			}},
+		})
+	}))
+	defer server.Close()
+
+	client := llm.New(config.LLMConfig{APIKey: "test-key", BaseURL: server.URL, Model: "test-model", TimeoutSeconds: 5}, nil)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ripPath := writeTestSRT(t, "1\n00:10:00,000 --> 00:10:02,000\nThe court will now hear the case\n")
+	rips := []ripFingerprint{{EpisodeKey: "s01_001", Path: ripPath}, {EpisodeKey: "s01_002", Path: ripPath}}
+	season := &tmdb.Season{Episodes: []tmdb.Episode{
+		{EpisodeNumber: 3, Overview: "The crew visits a pleasure planet."},
+		{EpisodeNumber: 4, Overview: "The captain stands trial."},
+		{EpisodeNumber: 5, Overview: "A storm strands the away team."},
+		{EpisodeNumber: 6, Overview: "An old friend returns."},
+	}}
+
+	clear := map[string][]matchResult{"s01_002": {
+		{EpisodeKey: "s01_002", TargetEpisode: 5, Score: 0.80},
+		{EpisodeKey: "s01_002", TargetEpisode: 6, Score: 0.70},
+	}}
+	remaining := breakNearTies(context.Background(), client, nil, clear, rips, season, logger)
+	if calls != 0 || len(remaining["s01_002"]) != 2 || remaining["s01_002"][0].TargetEpisode != 5 {
+		t.Fatalf("clear match consulted LLM: calls=%d remaining=%+v", calls, remaining)
+	}
+
+	nearTie := map[string][]matchResult{"s01_001": {
+		{EpisodeKey: "s01_001", TargetEpisode: 3, Score: 0.71, Confidence: 0.6},
+		{EpisodeKey: "s01_001", TargetEpisode: 4, Score: 0.70, Confidence: 0.6},
+	}}
+	remaining = breakNearTies(context.Background(), client, nil, nearTie, rips, season, logger)
+	if calls != 1 {
+		t.Fatalf("LLM calls = %d, want 1 for a near-tie", calls)
+	}
+	// The winner stays pending, first in line for reference verification.
+	got := remaining["s01_001"]
+	if len(got) != 2 || got[0].TargetEpisode != 4 || got[0].VerificationReason != "llm_tiebreak" || !got[0].NeedsVerification {
+		t.Fatalf("pending = %+v, want E04 first and marked for verification", got)
+	}
+	if got[0].Confidence != 0.6 {
+		t.Fatalf("confidence = %.2f, want original 0.60", got[0].Confidence)
+	}
+}
+
 func TestReconcileSingleHoleFillsObviousMissingEpisode(t *testing.T) {
 	policy := DefaultPolicy()
 	matches := []matchResult{
@@ -513,7 +595,7 @@ func TestApplyMatchesSetsEpisodeFieldsWithoutRenamingKeys(t *testing.T) {
 		Assets:   ripspec.Assets{Ripped: []ripspec.Asset{{EpisodeKey: "s03_001", Path: "/rip/1.mkv", Status: ripspec.AssetStatusCompleted}, {EpisodeKey: "s03_002", Path: "/rip/2.mkv", Status: ripspec.AssetStatusCompleted}}},
 	}
 	season := &tmdb.Season{Episodes: []tmdb.Episode{{EpisodeNumber: 3, Name: "Three"}, {EpisodeNumber: 4, Name: "Four"}}}
-	h.applyMatches(logger, env, 3, season, []matchResult{{EpisodeKey: "s03_001", TargetEpisode: 3, Score: 0.91}, {EpisodeKey: "s03_002", TargetEpisode: 4, Score: 0.88}}, nil, nil, nil)
+	h.applyMatches(logger, env, 3, map[int]*tmdb.Season{3: season}, []matchResult{{EpisodeKey: "s03_001", TargetEpisode: 3, Score: 0.91}, {EpisodeKey: "s03_002", TargetEpisode: 4, Score: 0.88}}, nil, nil, nil, nil)
 	if env.Episodes[0].Key != "s03_001" || env.Episodes[1].Key != "s03_002" {
 		t.Fatalf("episode keys must stay permanent placeholders: %+v", env.Episodes)
 	}
@@ -542,7 +624,7 @@ func TestApplyMatchesInfersOpeningDoubleEpisode(t *testing.T) {
 		}},
 	}
 	season := &tmdb.Season{Episodes: []tmdb.Episode{{EpisodeNumber: 1, Name: "Pilot Part 1"}, {EpisodeNumber: 2, Name: "Pilot Part 2"}, {EpisodeNumber: 3, Name: "Third"}, {EpisodeNumber: 4, Name: "Fourth"}}}
-	h.applyMatches(logger, env, 1, season, []matchResult{{EpisodeKey: "s01_001", TargetEpisode: 1, Score: 0.91}, {EpisodeKey: "s01_002", TargetEpisode: 2, Score: 0.88}, {EpisodeKey: "s01_003", TargetEpisode: 3, Score: 0.89}}, nil, nil, nil)
+	h.applyMatches(logger, env, 1, map[int]*tmdb.Season{1: season}, []matchResult{{EpisodeKey: "s01_001", TargetEpisode: 1, Score: 0.91}, {EpisodeKey: "s01_002", TargetEpisode: 2, Score: 0.88}, {EpisodeKey: "s01_003", TargetEpisode: 3, Score: 0.89}}, nil, nil, nil, nil)
 	if env.Episodes[0].Key != "s01_001" || env.Episodes[0].Episode != 1 || env.Episodes[0].EpisodeEnd != 2 {
 		t.Fatalf("opening episode not converted to range: %+v", env.Episodes[0])
 	}
@@ -566,7 +648,7 @@ func TestApplyMatchesProbableExtra(t *testing.T) {
 	}
 	season := &tmdb.Season{Episodes: []tmdb.Episode{{EpisodeNumber: 1, Name: "One"}}}
 	noClaimRips := map[string]struct{}{"s01_002": {}}
-	h.applyMatches(logger, env, 1, season, []matchResult{{EpisodeKey: "s01_001", TargetEpisode: 1, Score: 0.91, Confidence: 0.91}}, nil, noClaimRips, nil)
+	h.applyMatches(logger, env, 1, map[int]*tmdb.Season{1: season}, []matchResult{{EpisodeKey: "s01_001", TargetEpisode: 1, Score: 0.91, Confidence: 0.91}}, nil, noClaimRips, nil, nil)
 
 	if env.Episodes[0].NeedsReview {
 		t.Fatalf("matched episode s01_001 should not need review: %+v", env.Episodes[0])
@@ -680,6 +762,190 @@ func TestResolveEpisodeClaimsRipsWithoutClaims(t *testing.T) {
 	}
 }
 
+func TestSelectCrossSeasonMatchesSplitsDiscAcrossSeasons(t *testing.T) {
+	fp := textutil.NewFingerprint
+	// Disc for season 2 whose first two titles close out season 1.
+	rips := []ripFingerprint{
+		{EpisodeKey: "s02_001", TitleID: 1, Vector: fp("finale reactor breach captain farewell unique eleven"), RawVector: fp("finale reactor breach captain farewell unique eleven")},
+		{EpisodeKey: "s02_002", TitleID: 2, Vector: fp("cliffhanger borg cube wolf unique twelve"), RawVector: fp("cliffhanger borg cube wolf unique twelve")},
+	}
+	refsBySeason := map[int][]referenceFingerprint{
+		1: {
+			{EpisodeNumber: 11, Vector: fp("finale reactor breach captain farewell unique eleven"), RawVector: fp("finale reactor breach captain farewell unique eleven")},
+			{EpisodeNumber: 12, Vector: fp("cliffhanger borg cube wolf unique twelve"), RawVector: fp("cliffhanger borg cube wolf unique twelve")},
+		},
+		3: {
+			{EpisodeNumber: 1, Vector: fp("new crew shakedown cruise nebula unique thirty"), RawVector: fp("new crew shakedown cruise nebula unique thirty")},
+			{EpisodeNumber: 2, Vector: fp("diplomatic summit treaty ambassador unique thirtyone"), RawVector: fp("diplomatic summit treaty ambassador unique thirtyone")},
+		},
+	}
+	matches := selectCrossSeasonMatches(rips, refsBySeason, DefaultPolicy())
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 cross-season matches, got %d: %+v", len(matches), matches)
+	}
+	want := map[string]int{"s02_001": 11, "s02_002": 12}
+	for _, m := range matches {
+		if m.TargetSeason != 1 || m.TargetEpisode != want[m.EpisodeKey] {
+			t.Fatalf("%s matched S%02dE%02d, want S01E%02d", m.EpisodeKey, m.TargetSeason, m.TargetEpisode, want[m.EpisodeKey])
+		}
+	}
+}
+
+func TestApplyMatchesPersistsPerTitleSeason(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	h := &Handler{policy: DefaultPolicy()}
+	env := &ripspec.Envelope{
+		Metadata: ripspec.Metadata{DiscNumber: 1, SeasonNumber: 2},
+		Episodes: []ripspec.Episode{
+			{Key: "s02_001", Season: 2},
+			{Key: "s02_002", Season: 2},
+			{Key: "s02_003", Season: 2},
+		},
+	}
+	seasons := map[int]*tmdb.Season{
+		1: {Episodes: []tmdb.Episode{{EpisodeNumber: 12, Name: "Season One Finale"}}},
+		2: {Episodes: []tmdb.Episode{{EpisodeNumber: 1, Name: "Premiere"}, {EpisodeNumber: 2, Name: "Second"}}},
+	}
+	h.applyMatches(logger, env, 2, seasons, []matchResult{
+		{EpisodeKey: "s02_001", TargetSeason: 1, TargetEpisode: 12, Score: 0.9, Confidence: 0.9},
+		{EpisodeKey: "s02_002", TargetEpisode: 1, Score: 0.9, Confidence: 0.9},
+		{EpisodeKey: "s02_003", TargetEpisode: 2, Score: 0.9, Confidence: 0.9},
+	}, nil, nil, nil, nil)
+
+	got := env.Episodes
+	if got[0].Season != 1 || got[0].Episode != 12 || got[0].EpisodeTitle != "Season One Finale" {
+		t.Fatalf("cross-season title = %+v, want S01E12 Season One Finale", got[0])
+	}
+	if got[1].Season != 2 || got[1].Episode != 1 || got[2].Season != 2 || got[2].Episode != 2 {
+		t.Fatalf("disc season titles = %+v, want S02E01 and S02E02", got[1:])
+	}
+	if reasons := structuralReviewReasons(primarySeasonEpisodes(got, 2), 1); len(reasons) != 0 {
+		t.Fatalf("structuralReviewReasons() = %v, want none for a season boundary disc", reasons)
+	}
+}
+
+func TestApplyMatchesFilesSpecialUnderSeasonZero(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	h := &Handler{policy: DefaultPolicy()}
+	env := &ripspec.Envelope{
+		Metadata: ripspec.Metadata{DiscNumber: 1, SeasonNumber: 1},
+		Episodes: []ripspec.Episode{
+			{Key: "s01_001", Season: 1},
+			{Key: "s01_002", Season: 1},
+		},
+	}
+	seasons := map[int]*tmdb.Season{
+		0: {Episodes: []tmdb.Episode{{EpisodeNumber: 5, Name: "Christmas Special"}}},
+		1: {Episodes: []tmdb.Episode{{EpisodeNumber: 1, Name: "Pilot"}}},
+	}
+	h.applyMatches(logger, env, 1, seasons, []matchResult{
+		{EpisodeKey: "s01_001", TargetEpisode: 1, Score: 0.9, Confidence: 0.9},
+		{EpisodeKey: "s01_002", TargetEpisode: 5, Special: true, Score: 0.9, Confidence: 0.9},
+	}, nil, nil, nil, nil)
+
+	special := env.Episodes[1]
+	if special.Season != ripspec.SpecialsSeason || special.Episode != 5 || special.EpisodeTitle != "Christmas Special" {
+		t.Fatalf("special = %+v, want S00E05 Christmas Special", special)
+	}
+	if !special.IsSpecial() || env.Episodes[0].IsSpecial() {
+		t.Fatalf("IsSpecial() = %v/%v, want only the special flagged", env.Episodes[0].IsSpecial(), special.IsSpecial())
+	}
+	if reasons := structuralReviewReasons(primarySeasonEpisodes(env.Episodes, 1), 1); len(reasons) != 0 {
+		t.Fatalf("structuralReviewReasons() = %v, want none when a special sits beside the premiere", reasons)
+	}
+}
+
+func TestSpecialCandidatesNarrowsByRuntime(t *testing.T) {
+	small := &tmdb.Season{Episodes: []tmdb.Episode{{EpisodeNumber: 1, Runtime: 45}, {EpisodeNumber: 2, Runtime: 90}}}
+	if got := specialCandidates(small, []int{600}); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Fatalf("small pool = %v, want every special", got)
+	}
+	large := &tmdb.Season{}
+	for i := 1; i <= 20; i++ {
+		large.Episodes = append(large.Episodes, tmdb.Episode{EpisodeNumber: i, Runtime: i * 5})
+	}
+	// 44 minutes sits between specials 8 (40m) and 9 (45m); 10 (50m) is next.
+	if got := specialCandidates(large, []int{44 * 60}); !reflect.DeepEqual(got, []int{8, 9, 10}) {
+		t.Fatalf("large pool = %v, want [8 9 10]", got)
+	}
+}
+
+func TestSeasonEdgeEpisodes(t *testing.T) {
+	season := &tmdb.Season{Episodes: []tmdb.Episode{{EpisodeNumber: 1}, {EpisodeNumber: 2}, {EpisodeNumber: 3}, {EpisodeNumber: 4}}}
+	if got := seasonEdgeEpisodes(season, 1, 2, 2); !reflect.DeepEqual(got, []int{3, 4}) {
+		t.Fatalf("earlier season edge = %v, want [3 4]", got)
+	}
+	if got := seasonEdgeEpisodes(season, 3, 2, 2); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Fatalf("later season edge = %v, want [1 2]", got)
+	}
+	if got := adjacentSeasonNumbers(1, 2); !reflect.DeepEqual(got, []int{2, 3}) {
+		t.Fatalf("adjacentSeasonNumbers(1, 2) = %v, want [2 3]", got)
+	}
+}
+
+func TestGateSparseTranscriptsExcludesSparseTitle(t *testing.T) {
+	fp := textutil.NewFingerprint
+	rips := []ripFingerprint{
+		{EpisodeKey: "s01_001", Vector: fp("captain orders shields raise warp engage bridge helm course heading")},
+		{EpisodeKey: "s01_002", Vector: fp("explosion music")},
+		{EpisodeKey: "s01_003", Vector: fp("ambassador treaty signing ceremony delegates council vote planet orbit")},
+	}
+	kept, sparse := gateSparseTranscripts(rips, 5)
+	if len(kept) != 2 || kept[0].EpisodeKey != "s01_001" || kept[1].EpisodeKey != "s01_003" {
+		t.Fatalf("kept = %+v, want s01_001 and s01_003", kept)
+	}
+	if len(sparse) != 1 || sparse[0].EpisodeKey != "s01_002" {
+		t.Fatalf("sparse = %+v, want s01_002", sparse)
+	}
+}
+
+func TestInferSparseEpisodesFromDiscOrder(t *testing.T) {
+	episodes := []ripspec.Episode{{Key: "s01_001"}, {Key: "s01_002"}, {Key: "s01_003"}, {Key: "s01_004"}}
+	matches := []matchResult{
+		{EpisodeKey: "s01_001", TargetEpisode: 5},
+		{EpisodeKey: "s01_003", TargetEpisode: 7},
+	}
+	sparse := []ripFingerprint{{EpisodeKey: "s01_002", TitleID: 2}, {EpisodeKey: "s01_004", TitleID: 4}}
+	got := inferSparseEpisodes(episodes, matches, sparse)
+	if len(got) != 2 {
+		t.Fatalf("inferred = %+v, want 2 entries", got)
+	}
+	if got[0].EpisodeKey != "s01_002" || got[0].TargetEpisode != 6 || got[0].AcceptedBy != acceptedByDiscOrder {
+		t.Fatalf("inferred[0] = %+v, want s01_002 -> E06 by disc order", got[0])
+	}
+	if got[1].EpisodeKey != "s01_004" || got[1].TargetEpisode != 8 {
+		t.Fatalf("inferred[1] = %+v, want s01_004 -> E08", got[1])
+	}
+
+	// Neighbors that disagree leave the sparse rip unresolved.
+	matches[1].TargetEpisode = 9
+	got = inferSparseEpisodes(episodes, matches, sparse[:1])
+	if len(got) != 0 {
+		t.Fatalf("inferred = %+v, want none when neighbors disagree", got)
+	}
+}
+
+func TestApplyMatchesFlagsSparseTranscripts(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	h := &Handler{policy: DefaultPolicy()}
+	env := &ripspec.Envelope{
+		Episodes: []ripspec.Episode{{Key: "s01_001", Season: 1}, {Key: "s01_002", Season: 1}, {Key: "s01_003", Season: 1}},
+	}
+	season := &tmdb.Season{Episodes: []tmdb.Episode{{EpisodeNumber: 1, Name: "One"}, {EpisodeNumber: 2, Name: "Two"}}}
+	sparseRips := map[string]struct{}{"s01_002": {}, "s01_003": {}}
+	h.applyMatches(logger, env, 1, map[int]*tmdb.Season{1: season}, []matchResult{
+		{EpisodeKey: "s01_001", TargetEpisode: 1, Score: 0.91, Confidence: 0.91},
+		{EpisodeKey: "s01_002", TargetEpisode: 2, AcceptedBy: acceptedByDiscOrder},
+	}, nil, nil, sparseRips, nil)
+
+	if ep := env.Episodes[1]; ep.Episode != 2 || ep.EpisodeTitle != "Two" || !ep.NeedsReview || !strings.Contains(ep.ReviewReason, "disc order") {
+		t.Fatalf("inferred episode = %+v, want E02 flagged as inferred from disc order", ep)
+	}
+	if ep := env.Episodes[2]; ep.Episode != 0 || !strings.Contains(ep.ReviewReason, "too sparse") {
+		t.Fatalf("gated episode = %+v, want unresolved with sparse transcript reason", ep)
+	}
+}
+
 func writeTestSRT(t *testing.T, content string) string {
 	t.Helper()
 	path := filepath.Join(t.TempDir(), "sample.srt")
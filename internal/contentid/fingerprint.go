@@ -9,12 +9,14 @@ import (
 	"github.com/five82/spindle/internal/opensubtitles"
 	"github.com/five82/spindle/internal/srtutil"
 	"github.com/five82/spindle/internal/textutil"
+	"github.com/five82/spindle/internal/transcription"
 )
 
 type ripFingerprint struct {
 	EpisodeKey string
 	TitleID    int
 	Path       string
+	VideoPath  string
 	Vector     *textutil.Fingerprint
 	RawVector  *textutil.Fingerprint
 }
@@ -36,6 +38,8 @@ type matchResult struct {
 	EpisodeKey              string
 	TitleID                 int
 	TargetEpisode           int
+	TargetSeason            int  // 0 means the disc's own season
+	Special                 bool // matched from the specials pool (season 0)
 	Score                   float64
 	WeightedScore           float64
 	RawScore                float64
@@ -61,14 +65,15 @@ type matchResult struct {
 	ReferenceSuspectReason  string
 }
 
-// readSRTText reads an SRT file and returns the concatenated cue text,
-// suitable for TF-IDF fingerprinting. Returns "" on I/O error.
-func readSRTText(path string) string {
-	cues, err := srtutil.ParseFile(path)
+// readTranscriptText returns the concatenated transcript text, suitable for
+// TF-IDF fingerprinting, dropping low-confidence WhisperX segments when
+// minConfidence > 0. Returns "" on I/O or parse error.
+func readTranscriptText(srtPath, jsonPath string, minConfidence float64) string {
+	text, err := transcription.TranscriptText(srtPath, jsonPath, minConfidence)
 	if err != nil {
 		return ""
 	}
-	return srtutil.PlainText(cues)
+	return text
 }
 
 func loadPlainText(path string) (string, error) {
@@ -511,13 +511,18 @@ func classifyDerivedConfidenceWithNormalizedPolicy(confidence float64, hasClearM
 	}
 }
 
+// checkContiguity reports whether the matches within the disc's own season
+// form an unbroken episode run. Cross-season and specials matches are not
+// considered.
 func checkContiguity(matches []matchResult) bool {
-	if len(matches) < 2 {
-		return true
+	episodes := make([]int, 0, len(matches))
+	for _, match := range matches {
+		if match.TargetSeason == 0 && !match.Special {
+			episodes = append(episodes, match.TargetEpisode)
+		}
 	}
-	episodes := make([]int, len(matches))
-	for i, match := range matches {
-		episodes[i] = match.TargetEpisode
+	if len(episodes) < 2 {
+		return true
 	}
 	sort.Ints(episodes)
 	for i := 1; i < len(episodes); i++ {
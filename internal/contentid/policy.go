@@ -16,6 +16,9 @@ type Policy struct {
 	LowConfidenceReviewThreshold float64
 	DecisiveAutoAcceptThreshold  float64
 	ClearConfidenceThreshold     float64
+	// MinTranscriptTokens is the fewest distinct transcript tokens a rip
+	// needs to take part in similarity matching.
+	MinTranscriptTokens int
 }
 
 // DefaultPolicy returns conservative defaults for the content-first TV matcher.
@@ -26,6 +29,7 @@ func DefaultPolicy() Policy {
 		LowConfidenceReviewThreshold: 0.70,
 		DecisiveAutoAcceptThreshold:  0.80,
 		ClearConfidenceThreshold:     0.85,
+		MinTranscriptTokens:          50,
 	}
 }
 
@@ -49,6 +53,9 @@ func policyFromConfig(cfg *config.Config) Policy {
 	if cfg.ContentID.ClearConfidenceThreshold > 0 {
 		p.ClearConfidenceThreshold = cfg.ContentID.ClearConfidenceThreshold
 	}
+	if cfg.ContentID.MinTranscriptTokens > 0 {
+		p.MinTranscriptTokens = cfg.ContentID.MinTranscriptTokens
+	}
 	return p.normalized()
 }
 
@@ -69,6 +76,9 @@ func (p Policy) normalized() Policy {
 	if p.ClearConfidenceThreshold <= 0 || p.ClearConfidenceThreshold >= 1 {
 		p.ClearConfidenceThreshold = d.ClearConfidenceThreshold
 	}
+	if p.MinTranscriptTokens <= 0 {
+		p.MinTranscriptTokens = d.MinTranscriptTokens
+	}
 	if p.DecisiveAutoAcceptThreshold <= p.LowConfidenceReviewThreshold || p.DecisiveAutoAcceptThreshold > p.ClearConfidenceThreshold {
 		p.LowConfidenceReviewThreshold = d.LowConfidenceReviewThreshold
 		p.DecisiveAutoAcceptThreshold = d.DecisiveAutoAcceptThreshold
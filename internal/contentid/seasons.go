@@ -0,0 +1,170 @@
+package contentid
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/five82/spindle/internal/logs"
+	"github.com/five82/spindle/internal/queue"
+	"github.com/five82/spindle/internal/ripspec"
+	"github.com/five82/spindle/internal/tmdb"
+)
+
+// adjacentSeasonNumbers returns the seasons within span of primary, nearest
+// first, excluding primary itself and specials (season 0).
+func adjacentSeasonNumbers(primary, span int) []int {
+	var out []int
+	for d := 1; d <= span; d++ {
+		if primary-d >= 1 {
+			out = append(out, primary-d)
+		}
+		out = append(out, primary+d)
+	}
+	return out
+}
+
+// seasonEdgeEpisodes returns the episodes of seasonNum a disc of primary
+// could plausibly hold across a season boundary: the closing width episodes
+// of an earlier season or the opening width episodes of a later one.
+func seasonEdgeEpisodes(season *tmdb.Season, seasonNum, primary, width int) []int {
+	all := seasonEpisodeNumbers(season)
+	if len(all) == 0 || width <= 0 {
+		return nil
+	}
+	width = min(width, len(all))
+	if seasonNum < primary {
+		return append([]int(nil), all[len(all)-width:]...)
+	}
+	return append([]int(nil), all[:width]...)
+}
+
+// selectCrossSeasonMatches resolves rips against each neighboring season's
+// references independently and keeps, per rip, the auto-accepted claim with
+// the highest score across seasons. Claims that would need LLM verification
+// are not considered: a cross-season assignment must stand on content alone.
+func selectCrossSeasonMatches(rips []ripFingerprint, refsBySeason map[int][]referenceFingerprint, policy Policy) []matchResult {
+	seasons := make([]int, 0, len(refsBySeason))
+	for num := range refsBySeason {
+		seasons = append(seasons, num)
+	}
+	sort.Ints(seasons)
+
+	best := make(map[string]matchResult, len(rips))
+	for _, num := range seasons {
+		resolution := resolveEpisodeClaims(rips, refsBySeason[num], policy)
+		for _, match := range resolution.Accepted {
+			match.TargetSeason = num
+			match.AcceptedBy = "cross_season_" + match.AcceptedBy
+			key := strings.ToLower(match.EpisodeKey)
+			if current, ok := best[key]; ok && current.Score >= match.Score {
+				continue
+			}
+			best[key] = match
+		}
+	}
+
+	out := make([]matchResult, 0, len(best))
+	for _, match := range best {
+		out = append(out, match)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].EpisodeKey < out[j].EpisodeKey })
+	return out
+}
+
+// matchAdjacentSeasons correlates rips the disc's own season left unmatched
+// against the boundary episodes of neighboring seasons. Lookup failures for a
+// neighboring season are logged and skip that season; the rips then stay
+// unresolved as they would without the cross-season pass. It returns the
+// accepted matches and the TMDB seasons they refer to.
+func (h *Handler) matchAdjacentSeasons(
+	ctx context.Context,
+	logger *slog.Logger,
+	item *queue.Item,
+	env *ripspec.Envelope,
+	seasonNum int,
+	rips []ripFingerprint,
+) ([]matchResult, map[int]*tmdb.Season, error) {
+	width := len(rips) * 2
+	seasons := make(map[int]*tmdb.Season)
+	refsBySeason := make(map[int][]referenceFingerprint)
+	for _, num := range adjacentSeasonNumbers(seasonNum, h.cfg.ContentID.SeasonSpan) {
+		season, err := h.tmdbClient.GetSeason(ctx, env.Metadata.ID, num)
+		if err == nil && (season == nil || len(season.Episodes) == 0) {
+			err = fmt.Errorf("season %d contains no episodes", num)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, nil, ctx.Err()
+			}
+			logger.Debug("adjacent season unavailable",
+				"season", num,
+				"error", err,
+			)
+			continue
+		}
+		episodes := seasonEdgeEpisodes(season, num, seasonNum, width)
+		refs, err := h.fetchReferenceFingerprints(ctx, logger, item, num, env.Metadata.ID, season, episodes, make(map[int]referenceFingerprint))
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, nil, ctx.Err()
+			}
+			logger.Warn("adjacent season references unavailable",
+				"event_type", "contentid_adjacent_season_error",
+				"error_hint", err.Error(),
+				"impact", "titles from this season cannot be matched across the season boundary",
+				"season", num,
+			)
+			continue
+		}
+		if len(refs) == 0 {
+			continue
+		}
+		seasons[num] = season
+		refsBySeason[num] = refs
+	}
+	if len(refsBySeason) == 0 {
+		return nil, nil, nil
+	}
+
+	matches := selectCrossSeasonMatches(rips, refsBySeason, h.policy)
+	logger.Info("content ID cross-season matching computed",
+		"decision_type", logs.DecisionContentIDMatches,
+		"decision_result", fmt.Sprintf("%d of %d matched", len(matches), len(rips)),
+		"decision_reason", "unmatched_titles_checked_against_adjacent_seasons",
+		"season", seasonNum,
+		"adjacent_seasons", len(refsBySeason),
+	)
+	return matches, seasons, nil
+}
+
+// unmatchedRips returns the rips with no accepted match.
+func unmatchedRips(rips []ripFingerprint, matches []matchResult) []ripFingerprint {
+	matched := make(map[string]struct{}, len(matches))
+	for _, m := range matches {
+		matched[strings.ToLower(m.EpisodeKey)] = struct{}{}
+	}
+	var out []ripFingerprint
+	for _, rip := range rips {
+		if _, ok := matched[strings.ToLower(rip.EpisodeKey)]; !ok {
+			out = append(out, rip)
+		}
+	}
+	return out
+}
+
+// primarySeasonEpisodes drops episodes assigned to a season other than
+// seasonNum, specials included, so structural checks only judge the disc's
+// own season.
+func primarySeasonEpisodes(episodes []ripspec.Episode, seasonNum int) []ripspec.Episode {
+	out := make([]ripspec.Episode, 0, len(episodes))
+	for _, ep := range episodes {
+		if ep.IsSpecial() || (ep.Season > 0 && ep.Season != seasonNum) {
+			continue
+		}
+		out = append(out, ep)
+	}
+	return out
+}
@@ -0,0 +1,96 @@
+package contentid
+
+import (
+	"strings"
+
+	"github.com/five82/spindle/internal/ripspec"
+)
+
+// acceptedByDiscOrder marks a sparse-transcript rip whose episode was
+// inferred from its position among content-matched rips.
+const acceptedByDiscOrder = "disc_order_fallback"
+
+// gateSparseTranscripts splits rips into those with enough distinct
+// transcript tokens for similarity matching and those too sparse (mostly
+// action or music) to score reliably.
+func gateSparseTranscripts(rips []ripFingerprint, minTokens int) (kept, sparse []ripFingerprint) {
+	for _, rip := range rips {
+		if rip.Vector == nil || len(rip.Vector.Terms) < minTokens {
+			sparse = append(sparse, rip)
+			continue
+		}
+		kept = append(kept, rip)
+	}
+	return kept, sparse
+}
+
+// inferSparseEpisodes places sparse-transcript rips by disc order. A rip
+// between two matched rips takes the episode their numbering implies when
+// both sides agree; a rip with a matched rip on one side only must be its
+// immediate neighbor. Episodes already assigned are never reused, and only
+// matches within the disc's own season anchor the inference.
+func inferSparseEpisodes(episodes []ripspec.Episode, matches []matchResult, sparse []ripFingerprint) []matchResult {
+	if len(sparse) == 0 || len(matches) == 0 {
+		return nil
+	}
+	matchedAt := make(map[int]int, len(matches))
+	assigned := make(map[int]struct{}, len(matches))
+	indexByKey := make(map[string]int, len(episodes))
+	for i, ep := range episodes {
+		indexByKey[strings.ToLower(ep.Key)] = i
+	}
+	for _, m := range matches {
+		if m.TargetSeason != 0 || m.Special {
+			continue
+		}
+		if i, ok := indexByKey[strings.ToLower(m.EpisodeKey)]; ok {
+			matchedAt[i] = m.TargetEpisode
+		}
+		assigned[m.TargetEpisode] = struct{}{}
+	}
+
+	var inferred []matchResult
+	for _, rip := range sparse {
+		i, ok := indexByKey[strings.ToLower(rip.EpisodeKey)]
+		if !ok {
+			continue
+		}
+		episode := 0
+		prev, hasPrev := nearestMatched(matchedAt, i, -1, len(episodes))
+		next, hasNext := nearestMatched(matchedAt, i, 1, len(episodes))
+		switch {
+		case hasPrev && hasNext:
+			fromPrev := matchedAt[prev] + (i - prev)
+			if fromPrev == matchedAt[next]-(next-i) {
+				episode = fromPrev
+			}
+		case hasPrev && prev == i-1:
+			episode = matchedAt[prev] + 1
+		case hasNext && next == i+1:
+			episode = matchedAt[next] - 1
+		}
+		if episode <= 0 {
+			continue
+		}
+		if _, taken := assigned[episode]; taken {
+			continue
+		}
+		assigned[episode] = struct{}{}
+		inferred = append(inferred, matchResult{
+			EpisodeKey:    rip.EpisodeKey,
+			TitleID:       rip.TitleID,
+			TargetEpisode: episode,
+			AcceptedBy:    acceptedByDiscOrder,
+		})
+	}
+	return inferred
+}
+
+func nearestMatched(matchedAt map[int]int, from, step, n int) (int, bool) {
+	for i := from + step; i >= 0 && i < n; i += step {
+		if _, ok := matchedAt[i]; ok {
+			return i, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,132 @@
+package contentid
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/five82/spindle/internal/logs"
+	"github.com/five82/spindle/internal/queue"
+	"github.com/five82/spindle/internal/ripspec"
+	"github.com/five82/spindle/internal/tmdb"
+)
+
+// Specials pool sizing. A show with few specials has every one fetched as a
+// reference; a larger pool is narrowed to the specials whose TMDB runtime is
+// closest to each unmatched rip.
+const (
+	maxSpecialsReferences = 12
+	specialsPerRip        = 3
+)
+
+// specialCandidates picks the season-0 episodes worth fetching references
+// for. runtimes holds the unmatched rips' durations in seconds. Specials
+// with no TMDB runtime rank after every timed one.
+func specialCandidates(specials *tmdb.Season, runtimes []int) []int {
+	all := seasonEpisodeNumbers(specials)
+	if len(all) <= maxSpecialsReferences {
+		return all
+	}
+	picked := make(map[int]struct{})
+	for _, runtime := range runtimes {
+		eps := append([]tmdb.Episode(nil), specials.Episodes...)
+		sort.SliceStable(eps, func(i, j int) bool {
+			return runtimeDistance(eps[i], runtime) < runtimeDistance(eps[j], runtime)
+		})
+		for _, ep := range eps[:min(specialsPerRip, len(eps))] {
+			if ep.EpisodeNumber > 0 {
+				picked[ep.EpisodeNumber] = struct{}{}
+			}
+		}
+	}
+	out := make([]int, 0, len(picked))
+	for num := range picked {
+		out = append(out, num)
+	}
+	sort.Ints(out)
+	return out
+}
+
+// runtimeDistance is how far a special's TMDB runtime is from a rip's
+// duration in seconds; an unknown runtime on either side is maximally far.
+func runtimeDistance(ep tmdb.Episode, runtimeSeconds int) int {
+	if ep.Runtime <= 0 || runtimeSeconds <= 0 {
+		return math.MaxInt
+	}
+	d := ep.Runtime*60 - runtimeSeconds
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// matchSpecials correlates rips that no regular season claimed against the
+// show's TMDB season 0, so bonus episodes file as s00eNN instead of being
+// mis-numbered or left as extras. Like the cross-season pass, only claims
+// that stand on content alone are accepted. A show without specials, or a
+// failed lookup, leaves the rips unresolved. It returns the accepted
+// matches, marked Special, and the specials season they refer to.
+func (h *Handler) matchSpecials(
+	ctx context.Context,
+	logger *slog.Logger,
+	item *queue.Item,
+	env *ripspec.Envelope,
+	rips []ripFingerprint,
+) ([]matchResult, *tmdb.Season, error) {
+	specials, err := h.tmdbClient.GetSeason(ctx, env.Metadata.ID, ripspec.SpecialsSeason)
+	if err == nil && (specials == nil || len(specials.Episodes) == 0) {
+		err = fmt.Errorf("show has no specials")
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+		logger.Debug("specials season unavailable", "error", err)
+		return nil, nil, nil
+	}
+
+	runtimes := make([]int, 0, len(rips))
+	for _, rip := range rips {
+		if ep := env.EpisodeByKey(rip.EpisodeKey); ep != nil {
+			runtimes = append(runtimes, ep.RuntimeSeconds)
+		}
+	}
+	candidates := specialCandidates(specials, runtimes)
+	refs, err := h.fetchReferenceFingerprints(ctx, logger, item, ripspec.SpecialsSeason, env.Metadata.ID, specials, candidates, make(map[int]referenceFingerprint))
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+		logger.Warn("specials references unavailable",
+			"event_type", "contentid_specials_error",
+			"error_hint", err.Error(),
+			"impact", "unmatched titles cannot be identified as specials",
+		)
+		return nil, nil, nil
+	}
+	if len(refs) == 0 {
+		return nil, nil, nil
+	}
+
+	resolution := resolveEpisodeClaims(rips, refs, h.policy)
+	matches := make([]matchResult, 0, len(resolution.Accepted))
+	for _, match := range resolution.Accepted {
+		match.Special = true
+		match.AcceptedBy = "specials_" + match.AcceptedBy
+		matches = append(matches, match)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return strings.ToLower(matches[i].EpisodeKey) < strings.ToLower(matches[j].EpisodeKey)
+	})
+	logger.Info("content ID specials matching computed",
+		"decision_type", logs.DecisionContentIDMatches,
+		"decision_result", fmt.Sprintf("%d of %d matched", len(matches), len(rips)),
+		"decision_reason", "unmatched_titles_checked_against_season_0",
+		"special_candidates", len(candidates),
+		"special_references", len(refs),
+	)
+	return matches, specials, nil
+}
@@ -0,0 +1,167 @@
+package contentid
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/five82/spindle/internal/llm"
+	"github.com/five82/spindle/internal/logs"
+	"github.com/five82/spindle/internal/tmdb"
+)
+
+const tiebreakPrompt = `You decide which of two TV episodes a transcript comes from.
+
+TRANSCRIPT is a WhisperX speech-to-text transcription of the middle of one episode from a Blu-ray disc. It may contain speech recognition errors.
+EPISODE A and EPISODE B are synopses of the two candidate episodes.
+
+Pick the episode whose synopsis best fits the characters, places, and events in the transcript.
+Answer "neither" when the transcript does not fit one synopsis clearly better than the other.
+
+Respond ONLY with JSON: {"choice": "A" or "B" or "neither", "explanation": "brief reason"}`
+
+type tiebreakDecision struct {
+	Choice      string `json:"choice"`
+	Explanation string `json:"explanation"`
+}
+
+// tiebreakEpsilon is the score gap below which two candidates count as a
+// near-tie that text similarity alone cannot separate.
+const tiebreakEpsilon = 0.02
+
+// isNearTie reports whether a rip's two best pending candidates score within
+// tiebreakEpsilon of each other.
+func isNearTie(candidates []matchResult) bool {
+	return len(candidates) >= 2 && math.Abs(candidates[0].Score-candidates[1].Score) < tiebreakEpsilon
+}
+
+// breakNearTies asks the LLM to choose between the two best candidates of
+// each rip whose candidates are a near-tie, comparing the rip's transcript
+// against both episodes' TMDB synopses. A decisive answer moves the chosen
+// candidate to the front of the rip's pending list; the rip stays pending so
+// verifyMatches still checks the choice against its reference subtitle.
+// Clear matches never reach the LLM here.
+func breakNearTies(
+	ctx context.Context,
+	client *llm.Client,
+	accepted []matchResult,
+	pendingByRip map[string][]matchResult,
+	rips []ripFingerprint,
+	season *tmdb.Season,
+	logger *slog.Logger,
+) map[string][]matchResult {
+	if client == nil || len(pendingByRip) == 0 {
+		return pendingByRip
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	remaining := clonePendingByRip(pendingByRip)
+	acceptedEpisodes := make(map[int]struct{}, len(accepted))
+	for _, match := range accepted {
+		acceptedEpisodes[match.TargetEpisode] = struct{}{}
+	}
+
+	keys := make([]string, 0, len(pendingByRip))
+	for key := range pendingByRip {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		candidates := remaining[key]
+		if !isNearTie(candidates) {
+			continue
+		}
+		a, b := candidates[0], candidates[1]
+		if _, ok := acceptedEpisodes[a.TargetEpisode]; ok {
+			continue
+		}
+		if _, ok := acceptedEpisodes[b.TargetEpisode]; ok {
+			continue
+		}
+		synopsisA, synopsisB := episodeSynopsis(season, a.TargetEpisode), episodeSynopsis(season, b.TargetEpisode)
+		if synopsisA == "" || synopsisB == "" {
+			logger.Debug("content ID tiebreak skipped; candidate synopsis missing",
+				"episode_key", key,
+				"candidate_a", a.TargetEpisode,
+				"candidate_b", b.TargetEpisode,
+			)
+			continue
+		}
+		ripText, err := extractMiddleTranscript(findRipPath(rips, key))
+		if err != nil || strings.TrimSpace(ripText) == "" {
+			continue
+		}
+
+		var decision tiebreakDecision
+		userPrompt := buildTiebreakPrompt(ripText, a.TargetEpisode, synopsisA, b.TargetEpisode, synopsisB)
+		if err := client.CompleteJSON(ctx, tiebreakPrompt, userPrompt, &decision); err != nil {
+			logger.Info("episode LLM tiebreak",
+				"decision_type", logs.DecisionContentIDTiebreak,
+				"decision_result", "failed",
+				"decision_reason", err.Error(),
+				"episode_key", key,
+				"candidate_a", a.TargetEpisode,
+				"candidate_b", b.TargetEpisode,
+			)
+			continue
+		}
+		var winner, loser matchResult
+		switch strings.ToUpper(strings.TrimSpace(decision.Choice)) {
+		case "A":
+			winner, loser = a, b
+		case "B":
+			winner, loser = b, a
+		default:
+			logger.Info("episode LLM tiebreak",
+				"decision_type", logs.DecisionContentIDTiebreak,
+				"decision_result", "undecided",
+				"decision_reason", decision.Explanation,
+				"episode_key", key,
+				"candidate_a", a.TargetEpisode,
+				"candidate_b", b.TargetEpisode,
+			)
+			continue
+		}
+		winner.NeedsVerification = true
+		winner.VerificationReason = "llm_tiebreak"
+		remaining[key] = append([]matchResult{winner, loser}, candidates[2:]...)
+		logger.Info("episode LLM tiebreak",
+			"decision_type", logs.DecisionContentIDTiebreak,
+			"decision_result", fmt.Sprintf("%s -> E%02d", key, winner.TargetEpisode),
+			"decision_reason", decision.Explanation,
+			"episode_key", key,
+			"candidate_a", a.TargetEpisode,
+			"candidate_a_score", a.Score,
+			"candidate_b", b.TargetEpisode,
+			"candidate_b_score", b.Score,
+		)
+	}
+	return remaining
+}
+
+func episodeSynopsis(season *tmdb.Season, episode int) string {
+	if season == nil {
+		return ""
+	}
+	for _, ep := range season.Episodes {
+		if ep.EpisodeNumber == episode {
+			return strings.TrimSpace(ep.Overview)
+		}
+	}
+	return ""
+}
+
+func buildTiebreakPrompt(transcript string, episodeA int, synopsisA string, episodeB int, synopsisB string) string {
+	return fmt.Sprintf(`=== TRANSCRIPT ===
+%s
+
+=== EPISODE A (episode %d) ===
+%s
+
+=== EPISODE B (episode %d) ===
+%s`, transcript, episodeA, synopsisA, episodeB, synopsisB)
+}
@@ -8,6 +8,8 @@ import (
 	"strings"
 
 	"github.com/five82/spindle/internal/llm"
+	"github.com/five82/spindle/internal/logs"
+	"github.com/five82/spindle/internal/srtsync"
 	"github.com/five82/spindle/internal/srtutil"
 )
 
@@ -43,7 +45,7 @@ type verifyResult struct {
 	ReviewReason string
 }
 
-func verifyMatches(ctx context.Context, client *llm.Client, accepted []matchResult, pendingByRip map[string][]matchResult, rips []ripFingerprint, refs []referenceFingerprint, logger *slog.Logger) ([]matchResult, map[string][]matchResult, *verifyResult) {
+func verifyMatches(ctx context.Context, client *llm.Client, accepted []matchResult, pendingByRip map[string][]matchResult, rips []ripFingerprint, refs []referenceFingerprint, syncReferences bool, logger *slog.Logger) ([]matchResult, map[string][]matchResult, *verifyResult) {
 	remaining := clonePendingByRip(pendingByRip)
 	if client == nil || len(pendingByRip) == 0 {
 		return accepted, remaining, nil
@@ -90,7 +92,7 @@ func verifyMatches(ctx context.Context, client *llm.Client, accepted []matchResu
 				remaining[entry.EpisodeKey] = removeCandidateEpisode(remaining[entry.EpisodeKey], candidate.TargetEpisode)
 				continue
 			}
-			refText, err := extractMiddleTranscript(refPath)
+			refText, err := extractReferenceTranscript(ctx, refPath, ripPath, findRipVideoPath(rips, candidate.EpisodeKey), syncReferences, candidate, logger)
 			if err != nil {
 				result.Failed++
 				result.NeedsReview = true
@@ -240,6 +242,15 @@ func findRipPath(rips []ripFingerprint, key string) string {
 	return ""
 }
 
+func findRipVideoPath(rips []ripFingerprint, key string) string {
+	for _, r := range rips {
+		if strings.EqualFold(r.EpisodeKey, key) {
+			return r.VideoPath
+		}
+	}
+	return ""
+}
+
 func findRefPath(refs []referenceFingerprint, episode int) string {
 	for _, r := range refs {
 		if r.EpisodeNumber == episode {
@@ -268,14 +279,81 @@ func extractMiddleTranscript(srtPath string) (string, error) {
 	if len(cues) == 0 {
 		return "", fmt.Errorf("no subtitle cues found")
 	}
+	start, end := middleWindow(cues)
+	return windowText(cues, start, end)
+}
+
+// extractReferenceTranscript extracts the reference text compared against
+// the rip. With syncReference set, the reference is first rescaled and
+// shifted onto the rip's WhisperX timing and read over the rip's own middle
+// window, so both transcripts cover the same scenes even when the release
+// was timed for another frame rate or carries a constant offset.
+func extractReferenceTranscript(ctx context.Context, refPath, ripPath, videoPath string, syncReference bool, candidate matchResult, logger *slog.Logger) (string, error) {
+	if !syncReference {
+		return extractMiddleTranscript(refPath)
+	}
+	refCues, err := srtutil.ParseFile(refPath)
+	if err != nil {
+		return "", err
+	}
+	synced, sync := srtsync.SyncReferenceCues(ctx, refCues, ripPath, videoPath)
+	if len(synced) == 0 {
+		return "", fmt.Errorf("no subtitle cues found")
+	}
+	if logger != nil {
+		logReferenceSync(logger, candidate, sync)
+	}
+	start, end := middleWindow(synced)
+	if sync.Rate.Applied || sync.Offset.Applied {
+		if ripCues, err := srtutil.ParseFile(ripPath); err == nil && len(ripCues) > 0 {
+			start, end = middleWindow(ripCues)
+		}
+	}
+	return windowText(synced, start, end)
+}
+
+func logReferenceSync(logger *slog.Logger, candidate matchResult, sync srtsync.ReferenceSync) {
+	rateResult := "unchanged"
+	if sync.Rate.Applied {
+		rateResult = "rescaled"
+	}
+	logger.Info("reference subtitle frame rate",
+		"decision_type", logs.DecisionReferenceSync,
+		"decision_result", rateResult,
+		"decision_reason", sync.Rate.Reason,
+		"episode_key", candidate.EpisodeKey,
+		"target_episode", candidate.TargetEpisode,
+		"reference_fps", sync.Rate.SourceFPS,
+		"video_fps", sync.Rate.TargetFPS,
+		"rate_factor", sync.Rate.Factor,
+	)
+	offsetResult := "skipped"
+	if sync.Offset.Applied {
+		offsetResult = "shifted"
+	}
+	logger.Info("reference subtitle sync",
+		"decision_type", logs.DecisionReferenceSync,
+		"decision_result", offsetResult,
+		"decision_reason", sync.Offset.Reason,
+		"episode_key", candidate.EpisodeKey,
+		"target_episode", candidate.TargetEpisode,
+		"offset_seconds", sync.Offset.Offset,
+		"matched_cues", sync.Offset.Matched,
+	)
+}
+
+// middleWindow returns the comparison window around the midpoint of cues,
+// or the whole span when it is shorter than the window.
+func middleWindow(cues []srtutil.Cue) (start, end float64) {
 	total := cues[len(cues)-1].End
-	mid := total / 2
-	start := max(0.0, mid-middleWindowHalfSec)
-	end := mid + middleWindowHalfSec
 	if total < 2*middleWindowHalfSec {
-		start = 0
-		end = total
+		return 0, total
 	}
+	mid := total / 2
+	return max(0.0, mid-middleWindowHalfSec), mid + middleWindowHalfSec
+}
+
+func windowText(cues []srtutil.Cue, start, end float64) (string, error) {
 	var sb strings.Builder
 	for _, cue := range cues {
 		if cue.End < start || cue.Start > end {
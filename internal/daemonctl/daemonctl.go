@@ -34,7 +34,28 @@ type StartOptions struct {
 // IsRunning checks if the daemon is running by testing the lock file
 // and checking the socket.
 func IsRunning(lockPath, socketPath string) bool {
-	// Check lock file.
+	if !LockHeld(lockPath) {
+		return false
+	}
+
+	// Also verify socket is reachable.
+	conn, err := net.DialTimeout("unix", socketPath, 2*time.Second)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
+
+// LockHeld reports whether another process holds the daemon lock. A daemon
+// holds it from startup, before its socket is serving.
+func LockHeld(lockPath string) bool {
+	if lockPath == "" {
+		return false
+	}
+	if _, err := os.Stat(lockPath); err != nil {
+		return false
+	}
 	fl := flock.New(lockPath)
 	locked, err := fl.TryLock()
 	if err != nil {
@@ -45,14 +66,6 @@ func IsRunning(lockPath, socketPath string) bool {
 		_ = fl.Unlock()
 		return false
 	}
-	// Lock is held by another process = daemon is running.
-
-	// Also verify socket is reachable.
-	conn, err := net.DialTimeout("unix", socketPath, 2*time.Second)
-	if err != nil {
-		return false
-	}
-	_ = conn.Close()
 	return true
 }
 
@@ -4,8 +4,10 @@ package daemonrun
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
+	"math"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -21,18 +23,23 @@ import (
 	"github.com/five82/spindle/internal/deps"
 	"github.com/five82/spindle/internal/discidcache"
 	"github.com/five82/spindle/internal/discmonitor"
+	"github.com/five82/spindle/internal/fileutil"
 	"github.com/five82/spindle/internal/httpapi"
 	"github.com/five82/spindle/internal/jellyfin"
+	"github.com/five82/spindle/internal/jsoncache"
 	"github.com/five82/spindle/internal/keydb"
 	"github.com/five82/spindle/internal/llm"
 	"github.com/five82/spindle/internal/logs"
+	"github.com/five82/spindle/internal/mediameta"
 	"github.com/five82/spindle/internal/notify"
 	"github.com/five82/spindle/internal/opensubtitles"
 	"github.com/five82/spindle/internal/queue"
 	"github.com/five82/spindle/internal/ripcache"
 	"github.com/five82/spindle/internal/ripspec"
+	"github.com/five82/spindle/internal/stage"
 	"github.com/five82/spindle/internal/tmdb"
 	"github.com/five82/spindle/internal/transcription"
+	"github.com/five82/spindle/internal/watchfolder"
 	"github.com/five82/spindle/internal/workflow"
 
 	// Stage handlers
@@ -46,6 +53,86 @@ import (
 	"github.com/five82/spindle/internal/subtitle"
 )
 
+// Clients are the external service clients. Every one is nil in offline
+// mode, so each consumer takes its existing unconfigured path.
+type Clients struct {
+	TMDB          *tmdb.Client
+	LLM           *llm.Client
+	Notifier      *notify.Notifier
+	Jellyfin      *jellyfin.Client
+	OpenSubtitles *opensubtitles.Client
+}
+
+// NewClients builds the external service clients, or none in offline mode,
+// logging that decision. The daemon and the CLI stage commands share it so
+// both honor offline mode. The TMDB client gets the response cache when
+// tmdb_cache is enabled.
+func NewClients(cfg *config.Config, logger *slog.Logger) (Clients, error) {
+	if cfg.Offline {
+		logger.Info("offline mode enabled",
+			"decision_type", logs.DecisionIntegrationConfig,
+			"decision_result", "offline",
+			"decision_reason", "external service clients disabled",
+		)
+		return Clients{}, nil
+	}
+	notifier, err := notify.New(cfg.Notifications.NtfyTopic, cfg.Notifications.RequestTimeout, logger).
+		WithTemplates(cfg.Notifications.Templates)
+	if err != nil {
+		return Clients{}, err
+	}
+	if notifier == nil {
+		logger.Info("ntfy notifications disabled",
+			"decision_type", logs.DecisionIntegrationConfig,
+			"decision_result", "disabled",
+			"decision_reason", "no ntfy topic configured",
+		)
+	}
+	tmdbClient := tmdb.New(cfg.TMDB.APIKey, cfg.TMDB.BaseURL, cfg.TMDB.Language, logger)
+	tmdbClient.SetRegion(cfg.TMDB.Region)
+	if cfg.TMDBCache.Enabled {
+		if tmdbCache, err := jsoncache.Open[json.RawMessage](cfg.TMDBCachePath(), cfg.TMDBCache.TTL()); err != nil {
+			logger.Warn("TMDB response cache unavailable",
+				"event_type", "tmdb_cache_unavailable",
+				"error_hint", "cache file could not be opened",
+				"impact", "identification queries TMDB for every lookup",
+				"error", err,
+			)
+		} else {
+			tmdbClient.SetCache(tmdbCache)
+		}
+	}
+	return Clients{
+		TMDB:     tmdbClient,
+		LLM:      llm.New(cfg.LLM, logger),
+		Notifier: notifier,
+		Jellyfin: jellyfin.New(cfg.Jellyfin.URL, cfg.Jellyfin.APIKey, logger),
+		OpenSubtitles: opensubtitles.New(opensubtitles.Params{
+			APIKey:    cfg.Subtitles.OpenSubtitlesAPIKey,
+			UserAgent: cfg.Subtitles.OpenSubtitlesUserAgent,
+			UserToken: cfg.Subtitles.OpenSubtitlesUserToken,
+		}, logger),
+	}, nil
+}
+
+// LoadKeyDB returns the KeyDB catalog, or nil when none is available. Offline
+// mode only reads the local file; otherwise a missing or stale catalog is
+// downloaded.
+func LoadKeyDB(ctx context.Context, cfg *config.Config, logger *slog.Logger) *keydb.Catalog {
+	var cat *keydb.Catalog
+	var err error
+	if cfg.Offline {
+		cat, _, err = keydb.LoadFromFile(cfg.MakeMKV.KeyDBPath, logger)
+	} else {
+		cat, _, err = keydb.LoadOrDownload(ctx, cfg.MakeMKV.KeyDBPath, cfg.MakeMKV.KeyDBDownloadURL,
+			cfg.MakeMKV.KeyDBTimeout(), logger)
+	}
+	if err != nil {
+		return nil
+	}
+	return cat
+}
+
 // contentIDClaims claims the GPU only for TV items: episode identification
 // is a pure skip for movies and unknown media types, so those items must
 // not queue behind other items' GPU work just to no-op through the stage.
@@ -57,8 +144,82 @@ func contentIDClaims(item *queue.Item) map[string]int {
 	return map[string]int{}
 }
 
+// encodeClaims is the encoding stage's resource claim: its own lane, plus a
+// share of the GPU budget when workflow.encode_uses_gpu says the encoder
+// contends with WhisperX for VRAM.
+func encodeClaims(usesGPU bool) map[string]int {
+	if usesGPU {
+		return map[string]int{"encode": 1, "gpu": 1}
+	}
+	return map[string]int{"encode": 1}
+}
+
+// encodeOrder maps the configured encoding queue order to the scheduler's
+// per-item sort key; nil keeps arrival order. Items without a cost estimate
+// sort after every estimated one under either strategy.
+func encodeOrder(strategy string) func(*queue.Item) float64 {
+	var sign float64
+	switch strategy {
+	case config.EncodeOrderShortestFirst:
+		sign = 1
+	case config.EncodeOrderLongestFirst:
+		sign = -1
+	default:
+		return nil
+	}
+	return func(item *queue.Item) float64 {
+		if item.EstimatedEncodeCost <= 0 {
+			return math.Inf(1)
+		}
+		return sign * item.EstimatedEncodeCost
+	}
+}
+
+// stageHandlers are the handlers pipelineStages wires into the workflow.
+type stageHandlers struct {
+	Identify, Rip, ContentID, Encode, Analysis, Subtitle, Apply, Organize stage.Handler
+}
+
+// pipelineStages is the daemon's stage graph: each stage's handler, resource
+// claims, dependencies, and configured timeouts and retry budget.
+func pipelineStages(cfg *config.Config, h stageHandlers) []workflow.PipelineStage {
+	// Encoding streams completed rips while the analysis branch reads the
+	// same immutable ripped assets. Apply joins both branches and is the only
+	// stage allowed to rewrite encoded files. Permanent rip-time asset keys
+	// let episode matching proceed without renaming files under the encoder.
+	stages := []workflow.PipelineStage{
+		{Stage: queue.StageIdentification, Handler: h.Identify, Claims: map[string]int{"drive": 1}},
+		{Stage: queue.StageRipping, Handler: h.Rip, Claims: map[string]int{"drive": 1}, DependsOn: []queue.Stage{queue.StageIdentification}},
+		{Stage: queue.StageEpisodeIdentification, Handler: h.ContentID, Claims: map[string]int{"gpu": 1}, ClaimsFunc: contentIDClaims, DependsOn: []queue.Stage{queue.StageRipping}},
+		{Stage: queue.StageEncoding, Handler: h.Encode,
+			// One encode at a time. Cross-tier pairing (one 1080p + one 4K
+			// slot) was removed 2026-07-07: each reel process sizes its CVVDP
+			// metric pool as if it owns the GPU, and two concurrent pools
+			// exhausted the 16GB card's VRAM, killing both encodes.
+			Claims:    encodeClaims(cfg.Workflow.EncodeUsesGPU),
+			OrderFunc: encodeOrder(cfg.Encoding.QueueOrder),
+			DependsOn: []queue.Stage{queue.StageIdentification}},
+		{Stage: queue.StageAnalysis, Handler: h.Analysis, Claims: map[string]int{"gpu": 1}, DependsOn: []queue.Stage{queue.StageEpisodeIdentification}},
+		{Stage: queue.StageSubtitling, Handler: h.Subtitle, Claims: map[string]int{"gpu": 1}, DependsOn: []queue.Stage{queue.StageAnalysis}},
+		{Stage: queue.StageApply, Handler: h.Apply, DependsOn: []queue.Stage{queue.StageSubtitling, queue.StageEncoding}},
+		{Stage: queue.StageOrganizing, Handler: h.Organize, DependsOn: []queue.Stage{queue.StageApply}},
+	}
+	for i := range stages {
+		stages[i].Timeout = cfg.Workflow.StageTimeout(string(stages[i].Stage))
+		stages[i].StallTimeout = cfg.Workflow.StageStallTimeout(string(stages[i].Stage))
+		stages[i].Retries = cfg.Workflow.StageRetryBudget(string(stages[i].Stage))
+	}
+	return stages
+}
+
 // Run starts the daemon and blocks until shutdown signal.
 func Run(ctx context.Context, cfg *config.Config) error {
+	// The organizer expands the movie template per item; checking it here
+	// stops a bad custom template before any disc is ripped.
+	if err := mediameta.ValidateMovieDirTemplate(cfg.Library.MovieDirTemplate()); err != nil {
+		return fmt.Errorf("library.movie_template: %w", err)
+	}
+
 	// Ensure state/log directory exists.
 	logDir := cfg.DaemonLogDir()
 	if err := os.MkdirAll(logDir, 0o755); err != nil {
@@ -106,7 +267,10 @@ func Run(ctx context.Context, cfg *config.Config) error {
 	if err := logBuffer.HydrateFromDir(logDir); err != nil {
 		fmt.Fprintf(os.Stderr, "warning: log buffer hydration failed: %v\n", err)
 	}
-	slog.SetDefault(slog.New(httpapi.NewLogHandler(multi, logBuffer)))
+	// Field filters apply before the API buffer so every output sees the
+	// same trimmed record.
+	fieldRules := fieldRulesFromConfig(cfg.Logging.Fields)
+	slog.SetDefault(slog.New(newFieldFilterHandler(httpapi.NewLogHandler(multi, logBuffer), fieldRules)))
 	logger := slog.Default()
 
 	logger.Info("daemon log file opened", "path", logFilePath, "console_logging", consoleLogging)
@@ -118,23 +282,16 @@ func Run(ctx context.Context, cfg *config.Config) error {
 	}
 	defer func() { _ = store.Close() }()
 
-	// Create clients.
-	tmdbClient := tmdb.New(cfg.TMDB.APIKey, cfg.TMDB.BaseURL, cfg.TMDB.Language, logger)
-	llmClient := llm.New(cfg.LLM, logger)
-	notifier := notify.New(cfg.Notifications.NtfyTopic, cfg.Notifications.RequestTimeout, logger)
-	if notifier == nil {
-		logger.Info("ntfy notifications disabled",
-			"decision_type", logs.DecisionIntegrationConfig,
-			"decision_result", "disabled",
-			"decision_reason", "no ntfy topic configured",
-		)
+	if cfg.Offline {
+		logger = logger.With("offline_mode", true)
+		slog.SetDefault(logger)
 	}
-	jfClient := jellyfin.New(cfg.Jellyfin.URL, cfg.Jellyfin.APIKey, logger)
-	osClient := opensubtitles.New(opensubtitles.Params{
-		APIKey:    cfg.Subtitles.OpenSubtitlesAPIKey,
-		UserAgent: cfg.Subtitles.OpenSubtitlesUserAgent,
-		UserToken: cfg.Subtitles.OpenSubtitlesUserToken,
-	}, logger)
+	clients, err := NewClients(cfg, logger)
+	if err != nil {
+		return err
+	}
+	tmdbClient, llmClient, notifier := clients.TMDB, clients.LLM, clients.Notifier
+	jfClient, osClient := clients.Jellyfin, clients.OpenSubtitles
 
 	// Optional services.
 	var discIDStore *discidcache.Store
@@ -150,23 +307,26 @@ func Run(ctx context.Context, cfg *config.Config) error {
 		}
 	}
 
-	var keydbCat *keydb.Catalog
-	if cat, _, loadErr := keydb.LoadOrDownload(ctx, cfg.MakeMKV.KeyDBPath, cfg.MakeMKV.KeyDBDownloadURL,
-		cfg.MakeMKV.KeyDBTimeout(), logger); loadErr == nil {
-		keydbCat = cat
+	keydbCat := LoadKeyDB(ctx, cfg, logger)
+	if keydbCat != nil {
 		logger.Debug("KeyDB catalog loaded", "entries", keydbCat.Size())
 	}
 
 	var ripCacheStore *ripcache.Store
 	if cfg.RipCache.Enabled {
 		ripCacheStore = ripcache.New(cfg.RipCacheDir(), cfg.RipCache.MaxGiB)
+		ripCacheStore.SetTransfer(fileutil.TransferMode(cfg.RipCache.StagingTransfer))
+		ripCacheStore.SetMinFree(int64(cfg.FreeSpace.RipCacheGiB) << 30)
 	}
 
 	transcriber := transcription.New(transcription.Params{
 		Model:       cfg.Subtitles.WhisperXModel,
 		CUDAEnabled: cfg.Subtitles.WhisperXCUDAEnabled,
+		ComputeType: cfg.Subtitles.WhisperXComputeType,
+		DeviceIndex: cfg.Subtitles.WhisperXDeviceIndex,
 		VADMethod:   cfg.Subtitles.WhisperXVADMethod,
 		HFToken:     cfg.Subtitles.WhisperXHFToken,
+		Workers:     cfg.Subtitles.WhisperXWorkers,
 	}, logger)
 
 	// Create disc monitor (if optical drive configured).
@@ -178,30 +338,40 @@ func Run(ctx context.Context, cfg *config.Config) error {
 
 	// Create stage handlers.
 	identifyHandler := identify.New(cfg, tmdbClient, notifier, discIDStore, keydbCat)
+	if cfg.ScanCache.Enabled {
+		if scanCache, err := identify.OpenScanCache(cfg.ScanCachePath(), cfg.ScanCache.TTL()); err != nil {
+			logger.Warn("disc scan cache unavailable",
+				"event_type", "scan_cache_unavailable",
+				"error_hint", "cache file could not be opened",
+				"impact", "identification reruns rescan the disc",
+				"error", err,
+			)
+		} else {
+			identifyHandler.SetScanCache(scanCache)
+		}
+	}
 	ripperHandler := ripper.New(cfg, notifier, ripCacheStore, discMon, ripper.NoTitleOverride)
 	contentidHandler := contentid.New(cfg, llmClient, osClient, tmdbClient, transcriber)
 	encoderHandler := encoder.New(cfg, notifier)
 	analysisHandler := audioanalysis.New(cfg, llmClient, transcriber)
-	subtitleHandler := subtitle.New(cfg, transcriber, llmClient)
+	if cfg.Commentary.StreamCache {
+		if streamCache, err := audioanalysis.OpenStreamCache(cfg.CommentaryCachePath()); err != nil {
+			logger.Warn("commentary stream cache unavailable",
+				"event_type", "commentary_stream_cache_unavailable",
+				"error_hint", "cache file could not be opened",
+				"impact", "audio analysis transcribes every candidate track",
+				"error", err,
+			)
+		} else {
+			analysisHandler.SetStreamCache(streamCache)
+		}
+	}
+	subtitleHandler := subtitle.New(cfg, transcriber, llmClient, osClient)
 	applyHandler := apply.New(cfg)
-	organizerHandler := organizer.New(cfg, jfClient, notifier)
+	organizerHandler := organizer.New(cfg, jfClient, tmdbClient, notifier)
 
 	// Check dependencies and create status tracker.
-	depReqs := []deps.Requirement{
-		{Name: "makemkvcon", Command: "makemkvcon", Description: "MakeMKV CLI", Optional: false},
-		{Name: "ffmpeg", Command: "ffmpeg", Description: "FFmpeg media processor", Optional: false},
-		{Name: "ffprobe", Command: "ffprobe", Description: "FFprobe media analyzer", Optional: false},
-		{Name: "mkvmerge", Command: "mkvmerge", Description: "MKVToolNix merge tool", Optional: false},
-		{Name: "libSvtAv1Enc", Command: "libSvtAv1Enc.so", Description: "Reel SVT-AV1 encoder library", Optional: false, Library: true},
-		{Name: "libavformat", Command: "libavformat.so", Description: "Reel FFmpeg format library", Optional: false, Library: true},
-		{Name: "libavcodec", Command: "libavcodec.so", Description: "Reel FFmpeg codec library", Optional: false, Library: true},
-		{Name: "libavutil", Command: "libavutil.so", Description: "Reel FFmpeg utility library", Optional: false, Library: true},
-		{Name: "libswscale", Command: "libswscale.so", Description: "Reel FFmpeg scaling library", Optional: false, Library: true},
-		{Name: "libswresample", Command: "libswresample.so", Description: "Reel FFmpeg resampling library", Optional: false, Library: true},
-		{Name: "libopusenc", Command: "libopusenc.so", Description: "Reel Opus encoder library", Optional: false, Library: true},
-		{Name: "libvship", Command: "libvship.so", Description: "Reel target-quality VSHIP/CVVDP library", Optional: false, Library: true},
-	}
-	depStatuses := deps.CheckRequirements(depReqs)
+	depStatuses := deps.CheckRequirements(deps.DefaultRequirements())
 	depResponses := make([]httpapi.DependencyResponse, len(depStatuses))
 	for i, s := range depStatuses {
 		depResponses[i] = httpapi.DependencyResponse{
@@ -217,26 +387,26 @@ func Run(ctx context.Context, cfg *config.Config) error {
 
 	// Create workflow manager and configure stages.
 	manager := workflow.New(store, notifier, statusTracker, logger)
-	// Encoding streams completed rips while the analysis branch reads the
-	// same immutable ripped assets. Apply joins both branches and is the only
-	// stage allowed to rewrite encoded files. Permanent rip-time asset keys
-	// let episode matching proceed without renaming files under the encoder.
-	manager.ConfigureStages([]workflow.PipelineStage{
-		{Stage: queue.StageIdentification, Handler: identifyHandler, Claims: map[string]int{"drive": 1}},
-		{Stage: queue.StageRipping, Handler: ripperHandler, Claims: map[string]int{"drive": 1}, DependsOn: []queue.Stage{queue.StageIdentification}},
-		{Stage: queue.StageEpisodeIdentification, Handler: contentidHandler, Claims: map[string]int{"gpu": 1}, ClaimsFunc: contentIDClaims, DependsOn: []queue.Stage{queue.StageRipping}},
-		{Stage: queue.StageEncoding, Handler: encoderHandler,
-			// One encode at a time. Cross-tier pairing (one 1080p + one 4K
-			// slot) was removed 2026-07-07: each reel process sizes its CVVDP
-			// metric pool as if it owns the GPU, and two concurrent pools
-			// exhausted the 16GB card's VRAM, killing both encodes.
-			Claims:    map[string]int{"encode": 1},
-			DependsOn: []queue.Stage{queue.StageIdentification}},
-		{Stage: queue.StageAnalysis, Handler: analysisHandler, Claims: map[string]int{"gpu": 1}, DependsOn: []queue.Stage{queue.StageEpisodeIdentification}},
-		{Stage: queue.StageSubtitling, Handler: subtitleHandler, Claims: map[string]int{"gpu": 1}, DependsOn: []queue.Stage{queue.StageAnalysis}},
-		{Stage: queue.StageApply, Handler: applyHandler, DependsOn: []queue.Stage{queue.StageSubtitling, queue.StageEncoding}},
-		{Stage: queue.StageOrganizing, Handler: organizerHandler, DependsOn: []queue.Stage{queue.StageApply}},
-	})
+	manager.ConfigureStages(pipelineStages(cfg, stageHandlers{
+		Identify:  identifyHandler,
+		Rip:       ripperHandler,
+		ContentID: contentidHandler,
+		Encode:    encoderHandler,
+		Analysis:  analysisHandler,
+		Subtitle:  subtitleHandler,
+		Apply:     applyHandler,
+		Organize:  organizerHandler,
+	}))
+	manager.SetCapacity("gpu", cfg.Workflow.GPUCapacity)
+	// Identification cannot match without TMDB, so a TMDB outage pauses that
+	// lane instead of failing every queued disc. Jellyfin and OpenSubtitles
+	// are not gated: organizing treats a failed library refresh as a
+	// warning, and subtitling falls back to local transcription.
+	if tmdbClient != nil {
+		manager.ConfigureDependencies([]workflow.Dependency{
+			{Name: "tmdb", Stages: []queue.Stage{queue.StageIdentification}, Check: tmdbClient.CheckHealth},
+		}, cfg.Workflow.DependencyPauseFailures, cfg.Workflow.DependencyCheckIntervalDuration())
+	}
 
 	// Create HTTP API with shutdown channel. The manager supplies the
 	// pipeline template and live resource occupancy for /api/status.
@@ -252,6 +422,8 @@ func Run(ctx context.Context, cfg *config.Config) error {
 		StatusTracker: statusTracker,
 		Pipeline:      manager.PipelineInfo(),
 		Scheduler:     manager,
+		ReviewTMDB:    reviewEnricher(tmdbClient, logger),
+		RipCache:      ripCacheStore,
 	})
 
 	// Create netlink monitor if optical drive is configured.
@@ -367,6 +539,32 @@ func Run(ctx context.Context, cfg *config.Config) error {
 		manager.Run(workflowCtx)
 	}()
 
+	// Start watched-folder ingestion (non-fatal). Imports need TMDB to
+	// match each file, so the watcher stays off without a client.
+	if cfg.WatchFolder.Dir != "" {
+		if tmdbClient == nil {
+			logger.Warn("watch folder not started",
+				"event_type", "watch_folder_start_failed",
+				"error_hint", "tmdb.api_key is not configured",
+				"impact", "files dropped into the watch folder are not queued",
+			)
+		} else {
+			watcher := watchfolder.New(cfg.WatchFolder.Dir, time.Duration(cfg.WatchFolder.SettleSeconds)*time.Second,
+				watchfolder.Importer(store, tmdbClient, logger), logger)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := watcher.Run(workflowCtx); err != nil {
+					logger.Warn("watch folder not started",
+						"event_type", "watch_folder_start_failed",
+						"error_hint", err.Error(),
+						"impact", "files dropped into the watch folder are not queued",
+					)
+				}
+			}()
+		}
+	}
+
 	logger.Info("daemon started")
 
 	// SIGQUIT: dump goroutine stacks to stderr (non-fatal, continues running).
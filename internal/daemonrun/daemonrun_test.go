@@ -1,11 +1,34 @@
 package daemonrun
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
 	"reflect"
+	"slices"
+	"sort"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/five82/spindle/internal/audioanalysis"
+	"github.com/five82/spindle/internal/config"
+	"github.com/five82/spindle/internal/contentid"
+	"github.com/five82/spindle/internal/identify"
+	"github.com/five82/spindle/internal/makemkv"
+	"github.com/five82/spindle/internal/organizer"
 	"github.com/five82/spindle/internal/queue"
 	"github.com/five82/spindle/internal/ripspec"
+	"github.com/five82/spindle/internal/stage"
+	"github.com/five82/spindle/internal/subtitle"
+	"github.com/five82/spindle/internal/transcription"
+	"github.com/five82/spindle/internal/workflow"
 )
 
 // encodeEnvelope encodes env and fails the test on error.
@@ -18,6 +41,15 @@ func encodeEnvelope(t *testing.T, env ripspec.Envelope) string {
 	return data
 }
 
+func TestEncodeClaimsSharesGPUWhenConfigured(t *testing.T) {
+	if got := encodeClaims(false); !reflect.DeepEqual(got, map[string]int{"encode": 1}) {
+		t.Fatalf("encodeClaims(false) = %v, want the encode lane only", got)
+	}
+	if got := encodeClaims(true); !reflect.DeepEqual(got, map[string]int{"encode": 1, "gpu": 1}) {
+		t.Fatalf("encodeClaims(true) = %v, want the encode lane and a GPU share", got)
+	}
+}
+
 func TestContentIDClaims(t *testing.T) {
 	cases := []struct {
 		name        string
@@ -67,3 +99,261 @@ func TestContentIDClaims(t *testing.T) {
 	}
 }
 
+func TestOfflineClientsSkipContentID(t *testing.T) {
+	cfg := &config.Config{Offline: true}
+	cfg.TMDB.APIKey = "key"
+	cfg.Subtitles.OpenSubtitlesAPIKey = "key"
+	clients, err := NewClients(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("NewClients: %v", err)
+	}
+	if clients != (Clients{}) {
+		t.Fatalf("offline clients = %+v, want none", clients)
+	}
+
+	store, err := queue.Open(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("open queue: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	item, err := store.NewDisc("Show Season 1", "fp1")
+	if err != nil {
+		t.Fatalf("new disc: %v", err)
+	}
+	item.RipSpecData = encodeEnvelope(t, ripspec.Envelope{Version: ripspec.CurrentVersion, Metadata: ripspec.Metadata{MediaType: "tv"}})
+	if err := store.UpdateWorkState(item); err != nil {
+		t.Fatalf("update work state: %v", err)
+	}
+
+	ctx := context.Background()
+	sess, err := stage.NewSession(ctx, store, item, nil)
+	if err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	h := contentid.New(cfg, clients.LLM, clients.OpenSubtitles, clients.TMDB, nil)
+	var degraded *stage.ErrDegraded
+	if err := h.Run(ctx, sess); err != nil && !errors.As(err, &degraded) {
+		t.Fatalf("Run: %v", err)
+	}
+
+	stored, err := store.GetByID(item.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, err := ripspec.Parse(stored.RipSpecData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ripspec.StageSkip{{
+		Stage:    string(queue.StageEpisodeIdentification),
+		Category: ripspec.SkipDisabled,
+		Reason:   "offline mode: content matcher unavailable: no transcriber, OpenSubtitles client, TMDB client",
+	}}
+	if !reflect.DeepEqual(env.Attributes.StageSkips, want) {
+		t.Fatalf("stage skips = %+v, want %+v", env.Attributes.StageSkips, want)
+	}
+}
+
+// refusingTransport fails every HTTP request and records its host, so a
+// test can show that no external service was contacted.
+type refusingTransport struct {
+	mu    sync.Mutex
+	hosts []string
+}
+
+func (rt *refusingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	rt.hosts = append(rt.hosts, req.URL.Host)
+	return nil, errors.New("network disabled in offline test")
+}
+
+func (rt *refusingTransport) requests() []string {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	return slices.Clone(rt.hosts)
+}
+
+type stageFunc func(context.Context, *stage.Session) error
+
+func (f stageFunc) Run(ctx context.Context, sess *stage.Session) error { return f(ctx, sess) }
+
+// placeholderAssets stands in for a stage that needs a drive or media tools:
+// it records a completed asset of kind, backed by a small file, for every
+// asset key.
+func placeholderAssets(dir, kind string) stage.Handler {
+	return stageFunc(func(_ context.Context, sess *stage.Session) error {
+		return sess.MergeSave(func(env *ripspec.Envelope) error {
+			for _, key := range env.AssetKeys() {
+				path := filepath.Join(dir, kind+"-"+key+".mkv")
+				if err := os.WriteFile(path, []byte(kind), 0o644); err != nil {
+					return err
+				}
+				env.Assets.AddAsset(kind, ripspec.Asset{EpisodeKey: key, Path: path, Status: ripspec.AssetStatusCompleted})
+			}
+			return nil
+		})
+	})
+}
+
+func TestOfflineItemCompletesWithoutExternalCalls(t *testing.T) {
+	guard := &refusingTransport{}
+	defaultTransport := http.DefaultTransport
+	http.DefaultTransport = guard
+	t.Cleanup(func() { http.DefaultTransport = defaultTransport })
+
+	// Every external service is configured, so only offline mode keeps the
+	// pipeline away from them.
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.toml")
+	cfgTOML := fmt.Sprintf(`offline = true
+
+[paths]
+staging_dir = %[1]q
+library_dir = %[2]q
+state_dir = %[3]q
+review_dir = %[4]q
+
+[tmdb]
+api_key = "key"
+
+[jellyfin]
+enabled = true
+url = "https://jellyfin.invalid"
+api_key = "key"
+
+[library]
+download_artwork = true
+
+[notifications]
+ntfy_topic = "https://ntfy.invalid/spindle"
+
+[subtitles]
+opensubtitles_api_key = "key"
+
+[makemkv]
+optical_drive = %[5]q
+
+[llm]
+api_key = "key"
+
+[commentary]
+enabled = true
+`, filepath.Join(dir, "staging"), filepath.Join(dir, "library"), filepath.Join(dir, "state"),
+		filepath.Join(dir, "review"), filepath.Join(dir, "sr0"))
+	if err := os.WriteFile(cfgPath, []byte(cfgTOML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg, err := config.Load(cfgPath, logger)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	clients, err := NewClients(cfg, logger)
+	if err != nil {
+		t.Fatalf("NewClients: %v", err)
+	}
+
+	// A cached scan stands in for MakeMKV.
+	const fingerprint = "offline-fp"
+	scanPath := filepath.Join(dir, "scan_cache.json")
+	scan, err := json.Marshal(map[string]any{fingerprint: map[string]any{
+		"value": map[string]any{
+			"device":     cfg.MakeMKV.OpticalDrive,
+			"min_length": cfg.MakeMKV.MinTitleLength,
+			"info":       makemkv.DiscInfo{Name: "OFFLINE_MOVIE", Titles: []makemkv.TitleInfo{{ID: 0, Name: "Main", Duration: 5700}}},
+		},
+		"stored_at": time.Now(),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(scanPath, scan, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	scanCache, err := identify.OpenScanCache(scanPath, time.Hour)
+	if err != nil {
+		t.Fatalf("open scan cache: %v", err)
+	}
+
+	store, err := queue.Open(filepath.Join(dir, "queue.db"))
+	if err != nil {
+		t.Fatalf("open queue: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	item, err := store.NewDisc("Offline Movie (2004)", fingerprint)
+	if err != nil {
+		t.Fatalf("new disc: %v", err)
+	}
+
+	// Ripping, encoding, and apply need a drive and media tools; every
+	// stage that talks to TMDB, OpenSubtitles, or the LLM runs for real.
+	transcriber := transcription.New(transcription.Params{}, logger)
+	identifyHandler := identify.New(cfg, clients.TMDB, clients.Notifier, nil, nil)
+	identifyHandler.SetScanCache(scanCache)
+	assets := t.TempDir()
+	manager := workflow.New(store, clients.Notifier, nil, logger)
+	manager.ConfigureStages(pipelineStages(cfg, stageHandlers{
+		Identify:  identifyHandler,
+		Rip:       placeholderAssets(assets, ripspec.AssetKindRipped),
+		ContentID: contentid.New(cfg, clients.LLM, clients.OpenSubtitles, clients.TMDB, transcriber),
+		Encode:    placeholderAssets(assets, ripspec.AssetKindEncoded),
+		Analysis:  audioanalysis.New(cfg, clients.LLM, transcriber),
+		Subtitle:  subtitle.New(cfg, transcriber, clients.LLM, clients.OpenSubtitles),
+		Apply:     stageFunc(func(context.Context, *stage.Session) error { return nil }),
+		Organize:  organizer.New(cfg, clients.Jellyfin, clients.TMDB, clients.Notifier),
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		manager.Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	var got *queue.Item
+	for deadline := time.Now().Add(30 * time.Second); time.Now().Before(deadline); time.Sleep(20 * time.Millisecond) {
+		if got, err = store.GetByID(item.ID); err != nil {
+			t.Fatalf("get item: %v", err)
+		}
+		if got.Stage == queue.StageCompleted || got.Stage == queue.StageFailed {
+			break
+		}
+	}
+	if got.Stage != queue.StageCompleted {
+		t.Fatalf("item stage = %q (failed at %q: %s), want completed", got.Stage, got.FailedAtStage, got.ErrorMessage)
+	}
+	env, err := ripspec.Parse(got.RipSpecData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if final, ok := env.Assets.FindAsset(ripspec.AssetKindFinal, ripspec.MainEditionKey); !ok || !final.IsCompleted() {
+		t.Fatalf("no completed final asset: %+v", env.Assets.Final)
+	}
+	if hosts := guard.requests(); len(hosts) != 0 {
+		t.Fatalf("offline pipeline contacted %v", hosts)
+	}
+}
+
+func TestEncodeOrderPutsUnknownCostsLast(t *testing.T) {
+	items := []*queue.Item{{ID: 1}, {ID: 2, EstimatedEncodeCost: 9000}, {ID: 3, EstimatedEncodeCost: 1200}}
+	for strategy, want := range map[string][]int64{
+		config.EncodeOrderShortestFirst: {3, 2, 1},
+		config.EncodeOrderLongestFirst:  {2, 3, 1},
+	} {
+		key := encodeOrder(strategy)
+		sorted := append([]*queue.Item(nil), items...)
+		sort.SliceStable(sorted, func(i, j int) bool { return key(sorted[i]) < key(sorted[j]) })
+		var got []int64
+		for _, item := range sorted {
+			got = append(got, item.ID)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s order = %v, want %v", strategy, got, want)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package daemonrun
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/five82/spindle/internal/config"
+)
+
+// fieldRules decides which structured fields a record keeps. A field is
+// dropped when a deny pattern matches it and no allow pattern does, so
+// fields neither list names pass through. A pattern ending in "*" matches
+// by prefix. config.KeptLogFields are never dropped.
+type fieldRules struct {
+	allow []string
+	deny  []string
+}
+
+func (r fieldRules) keep(key string) bool {
+	return !matchField(r.deny, key) || matchField(r.allow, key) || slices.Contains(config.KeptLogFields, key)
+}
+
+func matchField(patterns []string, key string) bool {
+	for _, p := range patterns {
+		if prefix, ok := strings.CutSuffix(p, "*"); ok {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if p == key {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldRulesFromConfig merges the "all" filter into each level's filter,
+// omitting levels left with no rules.
+func fieldRulesFromConfig(fields map[string]config.LogFieldFilter) map[slog.Level]fieldRules {
+	levels := map[string]slog.Level{
+		"debug": slog.LevelDebug,
+		"info":  slog.LevelInfo,
+		"warn":  slog.LevelWarn,
+		"error": slog.LevelError,
+	}
+	all := fields["all"]
+	rules := make(map[slog.Level]fieldRules)
+	for name, level := range levels {
+		f := fields[name]
+		r := fieldRules{
+			allow: append(append([]string(nil), all.Allow...), f.Allow...),
+			deny:  append(append([]string(nil), all.Deny...), f.Deny...),
+		}
+		if len(r.deny) > 0 {
+			rules[level] = r
+		}
+	}
+	return rules
+}
+
+// fieldFilterHandler strips denied fields from records before passing them
+// on, using the rules for the record's level. Attributes added with
+// WithAttrs are held back and filtered per record, since their level is
+// not known until then.
+type fieldFilterHandler struct {
+	inner slog.Handler
+	rules map[slog.Level]fieldRules
+	attrs []slog.Attr
+}
+
+// newFieldFilterHandler wraps inner with rules, returning inner unchanged
+// when there are none.
+func newFieldFilterHandler(inner slog.Handler, rules map[slog.Level]fieldRules) slog.Handler {
+	if len(rules) == 0 {
+		return inner
+	}
+	return &fieldFilterHandler{inner: inner, rules: rules}
+}
+
+func (h *fieldFilterHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.inner.Enabled(ctx, level)
+}
+
+func (h *fieldFilterHandler) Handle(ctx context.Context, r slog.Record) error {
+	rules, ok := h.rules[r.Level]
+	if !ok && len(h.attrs) == 0 {
+		return h.inner.Handle(ctx, r)
+	}
+	out := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	for _, a := range h.attrs {
+		if !ok || rules.keep(a.Key) {
+			out.AddAttrs(a)
+		}
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		if !ok || rules.keep(a.Key) {
+			out.AddAttrs(a)
+		}
+		return true
+	})
+	return h.inner.Handle(ctx, out)
+}
+
+func (h *fieldFilterHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	merged := make([]slog.Attr, 0, len(h.attrs)+len(attrs))
+	merged = append(merged, h.attrs...)
+	merged = append(merged, attrs...)
+	return &fieldFilterHandler{inner: h.inner, rules: h.rules, attrs: merged}
+}
+
+// WithGroup passes held attributes and the group to inner. Fields under a
+// group are not filtered; spindle does not log with groups.
+func (h *fieldFilterHandler) WithGroup(name string) slog.Handler {
+	inner := h.inner
+	if len(h.attrs) > 0 {
+		inner = inner.WithAttrs(h.attrs)
+	}
+	return &fieldFilterHandler{inner: inner.WithGroup(name), rules: h.rules}
+}
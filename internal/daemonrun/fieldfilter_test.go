@@ -0,0 +1,96 @@
+package daemonrun
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/five82/spindle/internal/config"
+)
+
+func decodeLogLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var lines []map[string]any
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var m map[string]any
+		if err := dec.Decode(&m); err != nil {
+			t.Fatalf("decode log line: %v", err)
+		}
+		lines = append(lines, m)
+	}
+	return lines
+}
+
+func TestFieldFilterStripsDeniedFields(t *testing.T) {
+	var buf bytes.Buffer
+	rules := fieldRulesFromConfig(map[string]config.LogFieldFilter{
+		"all":  {Deny: []string{"fingerprint"}},
+		"info": {Deny: []string{"candidate_*"}},
+	})
+	logger := slog.New(newFieldFilterHandler(
+		slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}), rules,
+	)).With("item_id", 7, "fingerprint", "abc")
+
+	logger.Info("matched", "candidate_title", "Heat", "candidate_score", 2.1, "decision_type", "tmdb_search")
+	logger.Debug("scanned", "candidate_title", "Heat")
+
+	lines := decodeLogLines(t, &buf)
+	if len(lines) != 2 {
+		t.Fatalf("got %d log lines, want 2", len(lines))
+	}
+	info, debug := lines[0], lines[1]
+	for _, key := range []string{"fingerprint", "candidate_title", "candidate_score"} {
+		if _, ok := info[key]; ok {
+			t.Errorf("info record kept denied field %q: %v", key, info)
+		}
+	}
+	if info["item_id"] != float64(7) || info["decision_type"] != "tmdb_search" || info["msg"] != "matched" {
+		t.Errorf("info record lost unlisted fields: %v", info)
+	}
+	if _, ok := debug["fingerprint"]; ok {
+		t.Errorf("debug record kept field denied for all levels: %v", debug)
+	}
+	if debug["candidate_title"] != "Heat" {
+		t.Errorf("debug record dropped field denied only at info: %v", debug)
+	}
+}
+
+func TestFieldFilterAllowOverridesDeny(t *testing.T) {
+	var buf bytes.Buffer
+	rules := fieldRulesFromConfig(map[string]config.LogFieldFilter{
+		"warn": {Deny: []string{"*"}, Allow: []string{"decision_*", "item_id"}},
+	})
+	logger := slog.New(newFieldFilterHandler(slog.NewJSONHandler(&buf, nil), rules))
+
+	logger.Warn("slow", "item_id", 3, "decision_reason", "timeout", "path", "/tmp/x", "error_hint", "check drive", "event_type", "rip_slow")
+
+	lines := decodeLogLines(t, &buf)
+	if len(lines) != 1 {
+		t.Fatalf("got %d log lines, want 1", len(lines))
+	}
+	got := lines[0]
+	if got["item_id"] != float64(3) || got["decision_reason"] != "timeout" {
+		t.Errorf("allowed fields missing: %v", got)
+	}
+	if got["event_type"] != "rip_slow" {
+		t.Errorf("always-kept field dropped: %v", got)
+	}
+	if _, ok := got["path"]; ok {
+		t.Errorf("denied field kept: %v", got)
+	}
+	if _, ok := got["error_hint"]; ok {
+		t.Errorf("denied field kept: %v", got)
+	}
+	if got["msg"] != "slow" || got["level"] != "WARN" {
+		t.Errorf("core record fields altered: %v", got)
+	}
+}
+
+func TestFieldFilterWithoutRulesIsPassthrough(t *testing.T) {
+	inner := slog.NewJSONHandler(&bytes.Buffer{}, nil)
+	if h := newFieldFilterHandler(inner, fieldRulesFromConfig(nil)); h != inner {
+		t.Fatal("expected inner handler when no rules are configured")
+	}
+}
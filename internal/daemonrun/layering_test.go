@@ -34,9 +34,10 @@ func TestPackageBoundaries(t *testing.T) {
 	}
 
 	assertNoImports(t, "queue", map[string]bool{"ripspec": true})
+	assertNoImports(t, "queueops", map[string]bool{"mkvimport": true, "tmdb": true})
 	assertNoImports(t, "config", map[string]bool{
 		"jellyfin": true, "keydb": true, "llm": true, "notify": true,
-		"opensubtitles": true, "tmdb": true,
+		"opensubtitles": true, "queue": true, "tmdb": true,
 	})
 }
 
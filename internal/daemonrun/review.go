@@ -0,0 +1,85 @@
+package daemonrun
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/five82/spindle/internal/queueops"
+	"github.com/five82/spindle/internal/ripspec"
+	"github.com/five82/spindle/internal/tmdb"
+)
+
+type (
+	detailsLookup func(ctx context.Context, mediaType string, id int) (*tmdb.SearchResult, error)
+	seasonLookup  func(ctx context.Context, tvID, season int) (*tmdb.Season, error)
+)
+
+// reviewEnricher re-enriches review selections from TMDB. A nil client
+// leaves metadata as recorded at identification time.
+func reviewEnricher(client *tmdb.Client, logger *slog.Logger) queueops.ReviewEnricher {
+	if client == nil {
+		return nil
+	}
+	return func(ctx context.Context, env *ripspec.Envelope, details bool) {
+		enrichReview(ctx, client.GetDetails, client.GetSeason, logger, env, details)
+	}
+}
+
+// enrichReview implements queueops.ReviewEnricher over TMDB lookups. The
+// lookups are passed as functions so tests can stand in for the client.
+func enrichReview(ctx context.Context, getDetails detailsLookup, getSeason seasonLookup, logger *slog.Logger, env *ripspec.Envelope, details bool) {
+	meta := &env.Metadata
+	if details {
+		r, err := getDetails(ctx, meta.MediaType, meta.ID)
+		if err != nil {
+			logger.Warn("review candidate enrichment failed",
+				"event_type", "review_enrich_error",
+				"error_hint", err.Error(),
+				"impact", "recorded candidate metadata used as-is",
+				"tmdb_id", meta.ID,
+			)
+		} else {
+			*meta = meta.Rematched(r.Candidate(meta.MediaType))
+		}
+	}
+	if meta.MediaType != "tv" || meta.ID <= 0 {
+		return
+	}
+
+	seasons := make(map[int]map[int]tmdb.Episode)
+	for i := range env.Episodes {
+		ep := &env.Episodes[i]
+		if ep.Season < 0 || ep.Episode <= 0 {
+			continue
+		}
+		byNumber, ok := seasons[ep.Season]
+		if !ok {
+			season, err := getSeason(ctx, meta.ID, ep.Season)
+			if err != nil {
+				logger.Warn("review season lookup failed",
+					"event_type", "review_enrich_error",
+					"error_hint", err.Error(),
+					"impact", "episode titles left unset",
+					"tmdb_id", meta.ID,
+					"season", ep.Season,
+				)
+			} else {
+				byNumber = make(map[int]tmdb.Episode, len(season.Episodes))
+				for _, e := range season.Episodes {
+					byNumber[e.EpisodeNumber] = e
+				}
+			}
+			seasons[ep.Season] = byNumber
+		}
+		first, ok := byNumber[ep.Episode]
+		if !ok {
+			continue
+		}
+		ep.EpisodeTitle = strings.TrimSpace(first.Name)
+		ep.EpisodeAirDate = strings.TrimSpace(first.AirDate)
+		if last, ok := byNumber[ep.EpisodeEnd]; ok && ep.EpisodeEnd > ep.Episode {
+			ep.EpisodeTitle = strings.TrimSpace(first.Name + " / " + last.Name)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package daemonrun
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/five82/spindle/internal/ripspec"
+	"github.com/five82/spindle/internal/tmdb"
+)
+
+func TestEnrichReviewAppliesTMDBDetailsAndEpisodes(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	getDetails := func(_ context.Context, mediaType string, id int) (*tmdb.SearchResult, error) {
+		if id != 949 {
+			return nil, errors.New("not found")
+		}
+		return &tmdb.SearchResult{ID: 949, Title: "Heat", MediaType: mediaType, ReleaseDate: "1995-12-15", Overview: "Obsessive master thief.", VoteCount: 7000}, nil
+	}
+	getSeason := func(_ context.Context, _ int, season int) (*tmdb.Season, error) {
+		if season != 1 {
+			return nil, errors.New("not found")
+		}
+		return &tmdb.Season{Episodes: []tmdb.Episode{{EpisodeNumber: 2, Name: "Two", AirDate: "2001-01-02"}, {EpisodeNumber: 3, Name: "Three"}}}, nil
+	}
+
+	movie := &ripspec.Envelope{Metadata: ripspec.Metadata{ID: 949, Title: "Heat", MediaType: "movie", Movie: true, DiscSource: "bluray"}}
+	enrichReview(context.Background(), getDetails, getSeason, logger, movie, true)
+	if movie.Metadata.Overview != "Obsessive master thief." || movie.Metadata.VoteCount != 7000 {
+		t.Fatalf("metadata = %+v, want TMDB details applied", movie.Metadata)
+	}
+	if movie.Metadata.DiscSource != "bluray" || !movie.Metadata.Movie {
+		t.Fatalf("metadata = %+v, want disc source and movie flag kept", movie.Metadata)
+	}
+
+	// Episode titles come from the season of the chosen show.
+	show := &ripspec.Envelope{
+		Metadata: ripspec.Metadata{ID: 7, MediaType: "tv"},
+		Episodes: []ripspec.Episode{{Key: "a", Season: 1, Episode: 2, EpisodeEnd: 3}, {Key: "b", Season: 2, Episode: 1}},
+	}
+	enrichReview(context.Background(), getDetails, getSeason, logger, show, false)
+	if ep := show.Episodes[0]; ep.EpisodeTitle != "Two / Three" || ep.EpisodeAirDate != "2001-01-02" {
+		t.Fatalf("episode a = %+v, want double-episode title from season 1", ep)
+	}
+	if ep := show.Episodes[1]; ep.EpisodeTitle != "" {
+		t.Fatalf("episode b = %+v, want no title when the season lookup fails", ep)
+	}
+}
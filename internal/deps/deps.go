@@ -25,6 +25,25 @@ type Status struct {
 	Detail    string
 }
 
+// DefaultRequirements lists the external tools and Reel libraries the daemon
+// needs. The daemon status API and `spindle doctor` both check this list.
+func DefaultRequirements() []Requirement {
+	return []Requirement{
+		{Name: "makemkvcon", Command: "makemkvcon", Description: "MakeMKV CLI", Optional: false},
+		{Name: "ffmpeg", Command: "ffmpeg", Description: "FFmpeg media processor", Optional: false},
+		{Name: "ffprobe", Command: "ffprobe", Description: "FFprobe media analyzer", Optional: false},
+		{Name: "mkvmerge", Command: "mkvmerge", Description: "MKVToolNix merge tool", Optional: false},
+		{Name: "libSvtAv1Enc", Command: "libSvtAv1Enc.so", Description: "Reel SVT-AV1 encoder library", Optional: false, Library: true},
+		{Name: "libavformat", Command: "libavformat.so", Description: "Reel FFmpeg format library", Optional: false, Library: true},
+		{Name: "libavcodec", Command: "libavcodec.so", Description: "Reel FFmpeg codec library", Optional: false, Library: true},
+		{Name: "libavutil", Command: "libavutil.so", Description: "Reel FFmpeg utility library", Optional: false, Library: true},
+		{Name: "libswscale", Command: "libswscale.so", Description: "Reel FFmpeg scaling library", Optional: false, Library: true},
+		{Name: "libswresample", Command: "libswresample.so", Description: "Reel FFmpeg resampling library", Optional: false, Library: true},
+		{Name: "libopusenc", Command: "libopusenc.so", Description: "Reel Opus encoder library", Optional: false, Library: true},
+		{Name: "libvship", Command: "libvship.so", Description: "Reel target-quality VSHIP/CVVDP library", Optional: false, Library: true},
+	}
+}
+
 // CheckRequirements probes the system PATH for command requirements and the dynamic
 // linker cache for library requirements. Results preserve input order.
 func CheckRequirements(requirements []Requirement) []Status {
@@ -96,8 +96,8 @@ func (s *Store) Set(discID string, entry Entry) error {
 
 // ListEntry is a disc ID cache entry with its disc ID, for display.
 type ListEntry struct {
-	DiscID string
-	Entry  Entry
+	DiscID string `json:"disc_id"`
+	Entry  Entry  `json:"entry"`
 }
 
 // List returns all entries as a slice, sorted by disc ID.
@@ -298,7 +298,8 @@ func (m *Monitor) enqueuePipeline(ctx context.Context, event *DiscEvent) (*Enque
 	)
 
 	msg := fmt.Sprintf("Accepted for processing from %s media.", event.DiscType)
-	_ = notify.SendLogged(ctx, m.notifier, m.logger, notify.EventItemQueued,
+	_ = notify.SendItemLogged(ctx, m.notifier, m.logger, notify.EventItemQueued,
+		notify.Fields{Item: item.ID},
 		"Queued: "+item.DisplayTitle(),
 		msg,
 		"item_id", item.ID,
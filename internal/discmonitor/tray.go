@@ -18,6 +18,7 @@ import (
 // CD-ROM drive status ioctl and return codes.
 const (
 	cdromDriveStatus = 0x5326
+	cdromEject       = 0x5309
 
 	// Drive status codes returned by CDROM_DRIVE_STATUS ioctl.
 	StatusNoInfo    = 0
@@ -78,3 +79,41 @@ func WaitForReady(ctx context.Context, device string, logger *slog.Logger) error
 
 	return fmt.Errorf("drive %s not ready after %d polls", device, maxPolls)
 }
+
+// Eject opens the drive tray. A drive that has just finished reading often
+// reports busy for a few seconds, so a failed eject is retried up to five
+// times at 2-second intervals before the last error is returned.
+func Eject(ctx context.Context, device string) error {
+	const maxAttempts = 5
+	const retryInterval = 2 * time.Second
+
+	var lastErr error
+	for attempt := range maxAttempts {
+		if lastErr = ejectOnce(device); lastErr == nil {
+			return nil
+		}
+		if attempt == maxAttempts-1 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(retryInterval):
+		}
+	}
+	return fmt.Errorf("eject %s after %d attempts: %w", device, maxAttempts, lastErr)
+}
+
+func ejectOnce(device string) error {
+	// O_NONBLOCK lets the open succeed while the drive is still settling.
+	fd, err := os.OpenFile(device, os.O_RDONLY|unix.O_NONBLOCK, 0)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", device, err)
+	}
+	defer func() { _ = fd.Close() }()
+
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, fd.Fd(), cdromEject, 0); errno != 0 {
+		return fmt.Errorf("ioctl CDROMEJECT on %s: %w", device, errno)
+	}
+	return nil
+}
@@ -50,11 +50,19 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 		return fmt.Errorf("create encoded dir: %w", err)
 	}
 
-	logger.Info("Reel target-quality mode selected",
-		"decision_type", logs.DecisionEncodingConfig,
-		"decision_result", "target",
-		"decision_reason", "spindle always uses Reel target-quality mode; encodes run in per-file worker subprocesses",
-	)
+	if crf := env.Options.EncodeCRF; crf > 0 {
+		logger.Info("Reel fixed-CRF mode selected",
+			"decision_type", logs.DecisionEncodingConfig,
+			"decision_result", "crf",
+			"decision_reason", fmt.Sprintf("item quality override crf=%g replaces target-quality mode; encodes run in per-file worker subprocesses", crf),
+		)
+	} else {
+		logger.Info("Reel target-quality mode selected",
+			"decision_type", logs.DecisionEncodingConfig,
+			"decision_result", "target",
+			"decision_reason", "spindle uses Reel target-quality mode unless the item sets a CRF override; encodes run in per-file worker subprocesses",
+		)
+	}
 
 	logger.Info("encoding plan",
 		"decision_type", logs.DecisionEncodingPlan,
@@ -70,6 +78,9 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 	var summary encodeSummary
 	attempted := 0
 	attemptedKeys := make(map[string]bool)
+	// One pinger per item, so progress pings pace across all its encodes.
+	pinger := notify.NewProgressPinger(h.notifier,
+		time.Duration(h.cfg.Notifications.ProgressIntervalMinutes)*time.Minute)
 	for {
 		if err := ctx.Err(); err != nil {
 			return err
@@ -106,10 +117,8 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 			attemptedKeys[job.Key] = true
 		}
 		attempted += len(jobs)
-		batch, err := h.encodeJobs(ctx, sess, encodedDir, jobs)
-		summary.errors += batch.errors
-		summary.originalSize += batch.originalSize
-		summary.encodedSize += batch.encodedSize
+		batch, err := h.encodeJobs(ctx, sess, encodedDir, jobs, pinger)
+		summary.merge(batch)
 		if err != nil {
 			return err
 		}
@@ -129,17 +138,11 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 
 	// Notification.
 	snap, _ := encodingstate.Unmarshal(item.EncodingDetailsJSON)
-	msg := fmt.Sprintf("Encoded %s (%d files", item.DisplayTitle(), attempted)
-	if snap.Resolution != "" {
-		msg += ", " + snap.Resolution
-	}
-	if summary.originalSize > 0 {
-		reduction := (1 - float64(summary.encodedSize)/float64(summary.originalSize)) * 100
-		msg += fmt.Sprintf(", %.1f%% smaller", reduction)
-	}
-	msg += ")"
+	stats := summary.stats(attempted, snap.Resolution)
+	msg := fmt.Sprintf("Encoded %s (%s)", item.DisplayTitle(), stats.Summary())
 	msg += queue.FormatAlsoProcessing(sess.Store, item.ID)
-	_ = notify.SendLogged(ctx, h.notifier, logger, notify.EventEncodeComplete,
+	_ = notify.SendItemLogged(ctx, h.notifier, logger, notify.EventEncodeComplete,
+		notify.Fields{Item: item.ID, Stage: string(queue.StageEncoding), Encode: stats},
 		"Encode Complete: "+item.DisplayTitle(),
 		msg,
 	)
@@ -176,15 +179,51 @@ type encodeSummary struct {
 	errors       int
 	originalSize int64
 	encodedSize  int64
+	duration     time.Duration
+	// speedTime is the sum of each job's speed times its seconds, so
+	// speedTime / duration is the time-weighted average speed.
+	speedTime float64
 }
 
 type encodeJobResult struct {
 	failed       bool
 	originalSize int64
 	encodedSize  int64
+	duration     time.Duration
+	speed        float64
+}
+
+func (s *encodeSummary) add(r encodeJobResult) {
+	s.originalSize += r.originalSize
+	s.encodedSize += r.encodedSize
+	s.duration += r.duration
+	s.speedTime += r.speed * r.duration.Seconds()
+}
+
+func (s *encodeSummary) merge(o encodeSummary) {
+	s.errors += o.errors
+	s.originalSize += o.originalSize
+	s.encodedSize += o.encodedSize
+	s.duration += o.duration
+	s.speedTime += o.speedTime
 }
 
-func (h *Handler) encodeJobs(ctx context.Context, sess *stage.Session, encodedDir string, jobs []stage.AssetJob) (encodeSummary, error) {
+// stats converts the summary into the encode_complete notification fields.
+func (s encodeSummary) stats(files int, resolution string) notify.EncodeStats {
+	stats := notify.EncodeStats{
+		Files:         files,
+		Resolution:    resolution,
+		OriginalBytes: s.originalSize,
+		EncodedBytes:  s.encodedSize,
+		Duration:      s.duration,
+	}
+	if s.duration > 0 {
+		stats.Speed = s.speedTime / s.duration.Seconds()
+	}
+	return stats
+}
+
+func (h *Handler) encodeJobs(ctx context.Context, sess *stage.Session, encodedDir string, jobs []stage.AssetJob, pinger *notify.ProgressPinger) (encodeSummary, error) {
 	logger := sess.Logger
 	env := sess.Env
 	var summary encodeSummary
@@ -204,7 +243,7 @@ func (h *Handler) encodeJobs(ctx context.Context, sess *stage.Session, encodedDi
 			continue
 		}
 
-		result, err := h.encodeJob(ctx, sess, encodedDir, job)
+		result, err := h.encodeJob(ctx, sess, encodedDir, job, pinger)
 		if err != nil {
 			return summary, err
 		}
@@ -212,8 +251,7 @@ func (h *Handler) encodeJobs(ctx context.Context, sess *stage.Session, encodedDi
 			summary.errors++
 			continue
 		}
-		summary.originalSize += result.originalSize
-		summary.encodedSize += result.encodedSize
+		summary.add(result)
 	}
 	return summary, nil
 }
@@ -231,7 +269,7 @@ func persistProgress(logger *slog.Logger, sess *stage.Session, percent float64,
 	}
 }
 
-func (h *Handler) encodeJob(ctx context.Context, sess *stage.Session, encodedDir string, job stage.AssetJob) (encodeJobResult, error) {
+func (h *Handler) encodeJob(ctx context.Context, sess *stage.Session, encodedDir string, job stage.AssetJob, pinger *notify.ProgressPinger) (encodeJobResult, error) {
 	item := sess.Item
 	logger := sess.Logger
 
@@ -248,6 +286,12 @@ func (h *Handler) encodeJob(ctx context.Context, sess *stage.Session, encodedDir
 		)
 	}
 
+	// An encode is usually much smaller than its source, so only the floor
+	// is enforced; reserving the source size would block encodes that fit.
+	if err := stage.EnsureFreeSpace(logger, "staging", encodedDir, 0, int64(h.cfg.FreeSpace.StagingGiB)<<30); err != nil {
+		return encodeJobResult{}, err
+	}
+
 	message := job.PhaseMessage("Encoding " + filepath.Base(job.Input.Path))
 	logger.Info(message,
 		"event_type", "encode_start",
@@ -256,22 +300,32 @@ func (h *Handler) encodeJob(ctx context.Context, sess *stage.Session, encodedDir
 	_ = sess.Progress(job.Percent(0), message, stage.WithActiveEpisode(job.Key))
 
 	// Reset encoding snapshot and force-persist.
-	snap := h.initialEncodingSnapshot(ctx, logger, job)
+	snap, source := h.initialEncodingSnapshot(ctx, logger, job)
 	item.EncodingDetailsJSON = snap.Marshal()
 	persistProgress(logger, sess, sess.Task.ProgressPercent, sess.Task.ProgressMessage,
 		"failed to persist initial snapshot", "progress display may be stale",
 		stage.WithEncodingDetails(item.EncodingDetailsJSON))
 
 	reporter := newSpindleReporter(sess, logger, job.Key, job.ProgressIndex, job.ProgressTotal)
-	result, encErr := runWorkerProcess(ctx, logger, job.Input.Path, encodedDir, reporter)
+	reporter.pinger = pinger
+	start := time.Now()
+	result, encErr := runWorkerProcess(ctx, logger, job.Input.Path, encodedDir, sess.Env.Options.EncodeCRF, reporter)
+	if encErr == nil {
+		encErr = recordOutputCheck(ctx, logger, sess, job, result.OutputFile, source)
+	}
 	if encErr != nil {
 		return encodeJobResult{failed: true}, h.handleEncodeFailure(logger, sess, job, encErr)
 	}
 
-	return h.handleEncodeSuccess(logger, sess, job, result)
+	jobResult, err := h.handleEncodeSuccess(ctx, logger, sess, job, result)
+	jobResult.duration = time.Since(start)
+	return jobResult, err
 }
 
-func (h *Handler) initialEncodingSnapshot(ctx context.Context, logger *slog.Logger, job stage.AssetJob) encodingstate.Snapshot {
+// initialEncodingSnapshot probes the encode input and seeds the snapshot from
+// it. The probe is also returned for the post-encode output check; it is nil
+// when probing failed.
+func (h *Handler) initialEncodingSnapshot(ctx context.Context, logger *slog.Logger, job stage.AssetJob) (encodingstate.Snapshot, *ffprobe.Result) {
 	snap := encodingstate.Snapshot{
 		InputFile: filepath.Base(job.Input.Path),
 		Substage:  "initializing",
@@ -285,7 +339,7 @@ func (h *Handler) initialEncodingSnapshot(ctx context.Context, logger *slog.Logg
 			"impact", "encode proceeds without probe metadata",
 			"episode_key", job.Key,
 		)
-		return snap
+		return snap, nil
 	}
 
 	var resolution string
@@ -294,6 +348,7 @@ func (h *Handler) initialEncodingSnapshot(ctx context.Context, logger *slog.Logg
 		if s.CodecType == "video" && resolution == "" {
 			resolution = fmt.Sprintf("%dx%d", s.Width, s.Height)
 			snap.Resolution = resolution
+			snap.Video = &encodingstate.Video{Source: resolution}
 		}
 		if s.CodecName != "" {
 			codecs = append(codecs, s.CodecName)
@@ -309,7 +364,136 @@ func (h *Handler) initialEncodingSnapshot(ctx context.Context, logger *slog.Logg
 		"original_size_bytes", snap.OriginalSize,
 		"episode_key", job.Key,
 	)
-	return snap
+	return snap, probeResult
+}
+
+// encodedRuntimeTolerance is the fraction of the source runtime an encode may
+// fall short by before it is treated as truncated.
+const encodedRuntimeTolerance = 0.05
+
+// probeOutput inspects an encoded file; tests replace it.
+var probeOutput = ffprobe.Inspect
+
+// checkEncodedFile verifies that an encode left a usable file behind. A
+// worker crash can leave a zero-byte or partial output that Reel never
+// reports, and the organizer would move it into the library. The output must
+// be non-empty, probe cleanly, and carry a video stream, plus an audio stream
+// when the source had one. When source is known, the output must also run
+// within encodedRuntimeTolerance of it.
+func checkEncodedFile(ctx context.Context, path string, source *ffprobe.Result) encodingstate.ValidationStep {
+	step := encodingstate.ValidationStep{Name: "output_file"}
+	info, err := os.Stat(path)
+	if err != nil {
+		step.Details = fmt.Sprintf("output missing: %v", err)
+		return step
+	}
+	if info.Size() == 0 {
+		step.Details = "output is zero bytes"
+		return step
+	}
+	probe, err := probeOutput(ctx, "", path)
+	if err != nil {
+		step.Details = fmt.Sprintf("output is not playable: %v", err)
+		return step
+	}
+	if probe.VideoStreamCount() == 0 {
+		step.Details = "output has no video stream"
+		return step
+	}
+	if source != nil && source.AudioStreamCount() > 0 && probe.AudioStreamCount() == 0 {
+		step.Details = fmt.Sprintf("output has no audio streams (source has %d)", source.AudioStreamCount())
+		return step
+	}
+	if source != nil {
+		expected, actual := source.DurationSeconds(), probe.DurationSeconds()
+		if expected > 0 && actual < expected*(1-encodedRuntimeTolerance) {
+			step.Details = fmt.Sprintf("output is truncated: runtime %.0fs, source %.0fs", actual, expected)
+			return step
+		}
+	}
+	step.Passed = true
+	step.Details = fmt.Sprintf("%d bytes, %d video and %d audio streams", info.Size(), probe.VideoStreamCount(), probe.AudioStreamCount())
+	return step
+}
+
+// recordOutputCheck runs checkEncodedFile on the encode output and appends
+// the result to the snapshot's validation. A failed check is returned as an
+// encode error, so the broken file is never recorded as an encoded asset.
+func recordOutputCheck(ctx context.Context, logger *slog.Logger, sess *stage.Session, job stage.AssetJob, outputPath string, source *ffprobe.Result) error {
+	step := checkEncodedFile(ctx, outputPath, source)
+	item := sess.Item
+	snap, _ := encodingstate.Unmarshal(item.EncodingDetailsJSON)
+	if snap.Validation == nil {
+		snap.Validation = &encodingstate.Validation{Passed: true}
+	}
+	snap.Validation.Steps = append(snap.Validation.Steps, step)
+	snap.Validation.Passed = snap.Validation.Passed && step.Passed
+	item.EncodingDetailsJSON = snap.Marshal()
+	persistProgress(logger, sess, sess.Task.ProgressPercent, sess.Task.ProgressMessage,
+		"failed to persist output check", "output check not reflected in progress",
+		stage.WithEncodingDetails(item.EncodingDetailsJSON))
+
+	result := "passed"
+	if !step.Passed {
+		result = "failed"
+	}
+	logger.Info("encoded output checked",
+		"decision_type", logs.DecisionEncodingValidation,
+		"decision_result", result,
+		"decision_reason", step.Details,
+		"episode_key", job.Key,
+	)
+	if !step.Passed {
+		return fmt.Errorf("encoded output check: %s", step.Details)
+	}
+	return nil
+}
+
+// checkOutputVideo probes the encoded file and records in snap.Video whether
+// its frame matches the source after the detected crop. It is skipped when
+// the source resolution is unknown; a failed output probe is logged and
+// leaves the check unrecorded rather than failing the encode.
+func checkOutputVideo(ctx context.Context, logger *slog.Logger, snap *encodingstate.Snapshot, key, outputPath string) {
+	if snap.Video == nil || snap.Video.Source == "" {
+		snap.Video = nil
+		return
+	}
+	probe, err := probeOutput(ctx, "", outputPath)
+	if err != nil {
+		logger.Warn("output resolution check skipped",
+			"event_type", "probe_error",
+			"error_hint", err.Error(),
+			"impact", "a crop or scale mistake in this encode would go unnoticed",
+			"episode_key", key,
+		)
+		snap.Video = nil
+		return
+	}
+	var output string
+	for _, s := range probe.Streams {
+		if s.CodecType == "video" {
+			output = fmt.Sprintf("%dx%d", s.Width, s.Height)
+			break
+		}
+	}
+	crop := ""
+	if snap.CropRequired {
+		crop = snap.CropFilter
+	}
+	video := encodingstate.CheckVideo(snap.Video.Source, crop, output)
+	snap.Video = &video
+
+	result, reason := "match", fmt.Sprintf("output %s matches expected %s", video.Output, video.Expected)
+	if !video.Passed {
+		result, reason = "flagged_for_review", video.Details
+	}
+	logger.Info("output resolution checked",
+		"decision_type", logs.DecisionResolutionCheck,
+		"decision_result", result,
+		"decision_reason", reason,
+		"episode_key", key,
+		"source_resolution", video.Source,
+	)
 }
 
 func (h *Handler) handleEncodeFailure(logger *slog.Logger, sess *stage.Session, job stage.AssetJob, encErr error) error {
@@ -333,7 +517,7 @@ func (h *Handler) handleEncodeFailure(logger *slog.Logger, sess *stage.Session,
 	return sess.SaveAssetFailure(ripspec.AssetKindEncoded, job.Key, encErr.Error())
 }
 
-func (h *Handler) handleEncodeSuccess(logger *slog.Logger, sess *stage.Session, job stage.AssetJob, result *reel.Result) (encodeJobResult, error) {
+func (h *Handler) handleEncodeSuccess(ctx context.Context, logger *slog.Logger, sess *stage.Session, job stage.AssetJob, result *reel.Result) (encodeJobResult, error) {
 	item := sess.Item
 	snap, _ := encodingstate.Unmarshal(item.EncodingDetailsJSON)
 	snap.Substage = "complete"
@@ -342,6 +526,7 @@ func (h *Handler) handleEncodeSuccess(logger *slog.Logger, sess *stage.Session,
 	snap.OriginalSize = int64(result.OriginalSize)
 	snap.SizeReductionPercent = result.SizeReductionPercent
 	snap.AverageSpeed = float64(result.EncodingSpeed)
+	checkOutputVideo(ctx, logger, &snap, job.Key, result.OutputFile)
 
 	item.EncodingDetailsJSON = snap.Marshal()
 	persistProgress(logger, sess, job.CompletionPercent(), sess.Task.ProgressMessage,
@@ -384,9 +569,24 @@ func (h *Handler) handleEncodeSuccess(logger *slog.Logger, sess *stage.Session,
 		)
 	}
 
+	if snap.Video != nil && !snap.Video.Passed {
+		if mergeErr := sess.MergeSave(func(env *ripspec.Envelope) error {
+			if ep := env.EpisodeByKey(job.Key); ep != nil {
+				ep.AppendReviewReason("Output resolution mismatch")
+			}
+			return nil
+		}); mergeErr != nil {
+			return encodeJobResult{}, mergeErr
+		}
+		if mergeErr := sess.MergeAddReviewReason(fmt.Sprintf("output resolution mismatch for %s: %s", job.Key, snap.Video.Details)); mergeErr != nil {
+			return encodeJobResult{}, mergeErr
+		}
+	}
+
 	return encodeJobResult{
 		originalSize: int64(result.OriginalSize),
 		encodedSize:  int64(result.EncodedSize),
+		speed:        float64(result.EncodingSpeed),
 	}, nil
 }
 
@@ -413,7 +613,8 @@ type spindleReporter struct {
 	totalJobs     int
 	lastPush      time.Time
 	lastLog       time.Time
-	now           func() time.Time // injectable clock for testing
+	pinger        *notify.ProgressPinger // nil unless progress pings are enabled
+	now           func() time.Time       // injectable clock for testing
 }
 
 func newSpindleReporter(sess *stage.Session, logger *slog.Logger, episodeKey string, completedJobs int, totalJobs int) *spindleReporter {
@@ -479,6 +680,14 @@ func (r *spindleReporter) EncodingProgress(p reel.ProgressSnapshot) {
 		r.sess.Task.ProgressPercent = stage.OverallPercent(r.completedJobs, r.totalJobs, float64(p.Percent))
 	}, "failed to persist encoding progress", "", "progress display may be stale")
 
+	overall := r.sess.Task.ProgressPercent
+	r.pinger.Update(r.sess.Ctx, r.logger, "Encoding: "+r.item.DisplayTitle(),
+		overall,
+		fmt.Sprintf("%.0f%% overall; %s at %.0f%%, %.2fx, ETA %s", overall, r.episodeKey, p.Percent, p.Speed, p.ETA.Round(time.Minute)),
+		"episode_key", r.episodeKey,
+		"percent", round1(overall),
+	)
+
 	if r.lastLog.IsZero() || now.Sub(r.lastLog) >= encodingProgressLogInterval || p.Percent >= 100 {
 		r.lastLog = now
 		r.logger.Info("encoding progress",
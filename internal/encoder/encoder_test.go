@@ -2,11 +2,17 @@ package encoder
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/five82/reel"
 
+	"github.com/five82/spindle/internal/media/ffprobe"
+	"github.com/five82/spindle/internal/notify"
 	"github.com/five82/spindle/internal/queue"
 	"github.com/five82/spindle/internal/ripspec"
 	"github.com/five82/spindle/internal/stage"
@@ -236,3 +242,79 @@ func TestRippingActiveStates(t *testing.T) {
 		t.Fatal("absent ripping task should be inactive")
 	}
 }
+
+func TestCheckEncodedFileRejectsBrokenOutput(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	empty := write("empty.mkv", nil)
+	garbage := write("garbage.mkv", []byte("not a matroska file"))
+	partial := write("partial.mkv", []byte("partial"))
+	good := write("good.mkv", []byte("good"))
+
+	orig := probeOutput
+	probeOutput = func(_ context.Context, _ string, path string) (*ffprobe.Result, error) {
+		streams := []ffprobe.Stream{{CodecType: "video"}, {CodecType: "audio"}}
+		switch filepath.Base(path) {
+		case "garbage.mkv":
+			return nil, errors.New("Invalid data found when processing input")
+		case "partial.mkv":
+			return &ffprobe.Result{Streams: streams, Format: ffprobe.Format{Duration: "1200"}}, nil
+		}
+		return &ffprobe.Result{Streams: streams, Format: ffprobe.Format{Duration: "6590"}}, nil
+	}
+	t.Cleanup(func() { probeOutput = orig })
+
+	source := &ffprobe.Result{
+		Streams: []ffprobe.Stream{{CodecType: "video"}, {CodecType: "audio"}, {CodecType: "audio"}},
+		Format:  ffprobe.Format{Duration: "6600"},
+	}
+	for path, want := range map[string]string{
+		empty:                          "zero bytes",
+		garbage:                        "not playable",
+		partial:                        "truncated",
+		filepath.Join(dir, "gone.mkv"): "missing",
+	} {
+		step := checkEncodedFile(context.Background(), path, source)
+		if step.Passed || !strings.Contains(step.Details, want) {
+			t.Errorf("%s: step = %+v, want a failure mentioning %q", filepath.Base(path), step, want)
+		}
+	}
+	if step := checkEncodedFile(context.Background(), good, source); !step.Passed || step.Name != "output_file" {
+		t.Fatalf("good output step = %+v, want passed", step)
+	}
+	// Without a source probe only the file itself is checked.
+	if step := checkEncodedFile(context.Background(), partial, nil); !step.Passed {
+		t.Fatalf("partial output without source = %+v, want passed", step)
+	}
+}
+
+func TestEncodeSummaryStats(t *testing.T) {
+	var batch encodeSummary
+	batch.add(encodeJobResult{originalSize: 300, encodedSize: 100, duration: 10 * time.Minute, speed: 2})
+	batch.add(encodeJobResult{originalSize: 100, encodedSize: 50, duration: 30 * time.Minute, speed: 4})
+	var summary encodeSummary
+	summary.merge(batch)
+	summary.merge(encodeSummary{errors: 1})
+
+	stats := summary.stats(2, "1920x1080")
+	want := notify.EncodeStats{
+		Files:         2,
+		Resolution:    "1920x1080",
+		OriginalBytes: 400,
+		EncodedBytes:  150,
+		Duration:      40 * time.Minute,
+		Speed:         3.5, // time-weighted: (2*10 + 4*30) / 40
+	}
+	if stats != want {
+		t.Fatalf("stats = %+v, want %+v", stats, want)
+	}
+	if summary.errors != 1 {
+		t.Fatalf("errors = %d, want 1", summary.errors)
+	}
+}
@@ -10,8 +10,10 @@ import (
 	"log/slog"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/five82/reel"
@@ -93,11 +95,12 @@ func (r *wireReporter) Error(e reel.ReporterError)              { r.w.emit(wireE
 
 // RunWorker is the `spindle encode-worker` entry point: encode one file in
 // this process and stream reporter events to out as JSON lines, ending with
-// a result or failure event.
-func RunWorker(ctx context.Context, input, outputDir string, out io.Writer) error {
+// a result or failure event. A positive crf replaces target-quality mode with
+// that fixed CRF; every other Reel setting stays at its default.
+func RunWorker(ctx context.Context, input, outputDir string, crf float64, out io.Writer) error {
 	w := &wireWriter{enc: json.NewEncoder(out)}
 
-	enc, err := reel.New(reel.WithQualityMode("target"))
+	enc, err := reel.New(qualityOption(crf))
 	if err != nil {
 		w.emit(wireFailure, wireMessage{Message: fmt.Sprintf("create reel encoder: %v", err)})
 		return err
@@ -112,6 +115,24 @@ func RunWorker(ctx context.Context, input, outputDir string, out io.Writer) erro
 	return nil
 }
 
+// qualityOption selects Reel's quality mode: target quality by default, or
+// the item's fixed CRF override.
+func qualityOption(crf float64) reel.Option {
+	if crf > 0 {
+		return reel.WithCRF(crf)
+	}
+	return reel.WithQualityMode("target")
+}
+
+// workerArgs builds the encode-worker command line for one file.
+func workerArgs(input, outputDir string, crf float64) []string {
+	args := []string{"encode-worker", "--input", input, "--output-dir", outputDir}
+	if crf > 0 {
+		args = append(args, "--crf", strconv.FormatFloat(crf, 'f', -1, 64))
+	}
+	return args
+}
+
 // dispatchWireEvent replays one worker event into the daemon-side reporter.
 // It returns the final result or failure message when the event carries one.
 func dispatchWireEvent(ev wireEvent, rep *spindleReporter) (*reel.Result, string, error) {
@@ -203,13 +224,16 @@ func dispatchWireEvent(ev wireEvent, rep *spindleReporter) (*reel.Result, string
 // runWorkerProcess spawns the encode worker for one file and replays its
 // event stream into the daemon-side reporter. The worker is this same
 // binary, so versions cannot skew.
-func runWorkerProcess(ctx context.Context, logger *slog.Logger, input, outputDir string, rep *spindleReporter) (*reel.Result, error) {
+func runWorkerProcess(ctx context.Context, logger *slog.Logger, input, outputDir string, crf float64, rep *spindleReporter) (*reel.Result, error) {
 	exe, err := os.Executable()
 	if err != nil {
 		return nil, fmt.Errorf("resolve spindle binary: %w", err)
 	}
 
-	cmd := exec.CommandContext(ctx, exe, "encode-worker", "--input", input, "--output-dir", outputDir)
+	cmd := exec.CommandContext(ctx, exe, workerArgs(input, outputDir, crf)...)
+	// On cancellation the worker gets SIGTERM first so reel can stop its
+	// encoder and keep resumable state; it is killed after WaitDelay.
+	cmd.Cancel = func() error { return cmd.Process.Signal(syscall.SIGTERM) }
 	cmd.WaitDelay = 10 * time.Second
 	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
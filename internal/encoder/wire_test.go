@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"io"
 	"log/slog"
+	"slices"
 	"testing"
 	"time"
 
@@ -128,3 +129,14 @@ func TestWireFailureEvent(t *testing.T) {
 		t.Fatalf("failure = %q, want boom", failure)
 	}
 }
+
+func TestWorkerArgsCRFOverride(t *testing.T) {
+	base := []string{"encode-worker", "--input", "/rip/a.mkv", "--output-dir", "/enc"}
+	if got := workerArgs("/rip/a.mkv", "/enc", 0); !slices.Equal(got, base) {
+		t.Fatalf("default args = %v, want %v", got, base)
+	}
+	got := workerArgs("/rip/a.mkv", "/enc", 20.25)
+	if want := append(base, "--crf", "20.25"); !slices.Equal(got, want) {
+		t.Fatalf("override args = %v, want %v", got, want)
+	}
+}
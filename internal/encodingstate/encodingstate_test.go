@@ -1,6 +1,7 @@
 package encodingstate
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -96,6 +97,7 @@ func TestMarshalUnmarshalRoundTrip(t *testing.T) {
 		Warning:               "slow encode",
 		Error:                 &Issue{Title: "warning", Message: "minor issue"},
 		Validation:            &Validation{Passed: true, Steps: []ValidationStep{{Name: "size", Passed: true, Details: "ok"}}},
+		Video:                 &Video{Source: "1920x1080", Expected: "1920x800", Output: "1920x800", Passed: true},
 	}
 
 	raw := original.Marshal()
@@ -224,3 +226,34 @@ func TestMatchStandardRatio(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckVideo(t *testing.T) {
+	tests := []struct {
+		name               string
+		source, crop, out  string
+		wantPass           bool
+		wantExpected       string
+		wantDetailsContain string
+	}{
+		{name: "uncropped match", source: "1920x1080", out: "1920x1080", wantPass: true, wantExpected: "1920x1080"},
+		{name: "cropped match with padding", source: "1920x1080", crop: "crop=1920:800:0:140", out: "1920x804", wantPass: true, wantExpected: "1920x800"},
+		{name: "crop not applied", source: "1920x1080", crop: "crop=1920:800:0:140", out: "1920x1080", wantExpected: "1920x800", wantDetailsContain: "expected 1920x800"},
+		{name: "downscaled", source: "1920x1080", out: "1280x720", wantExpected: "1920x1080", wantDetailsContain: "output 1280x720"},
+		{name: "stretched", source: "1920x1080", out: "1920x1200", wantExpected: "1920x1080", wantDetailsContain: "aspect"},
+		{name: "unknown output", source: "1920x1080", out: "", wantExpected: "1920x1080", wantDetailsContain: "output"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := CheckVideo(tt.source, tt.crop, tt.out)
+			if v.Passed != tt.wantPass {
+				t.Errorf("Passed = %v, want %v (%s)", v.Passed, tt.wantPass, v.Details)
+			}
+			if v.Expected != tt.wantExpected {
+				t.Errorf("Expected = %q, want %q", v.Expected, tt.wantExpected)
+			}
+			if !strings.Contains(v.Details, tt.wantDetailsContain) {
+				t.Errorf("Details = %q, want it to contain %q", v.Details, tt.wantDetailsContain)
+			}
+		})
+	}
+}
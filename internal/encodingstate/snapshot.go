@@ -55,6 +55,7 @@ type Snapshot struct {
 	Warning               string      `json:"warning,omitempty"`
 	Error                 *Issue      `json:"error,omitempty"`
 	Validation            *Validation `json:"validation,omitempty"`
+	Video                 *Video      `json:"video,omitempty"`
 }
 
 // IsZero returns true when all fields are zero, empty, or nil.
@@ -0,0 +1,74 @@
+package encodingstate
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Video records the post-encode frame check: the source frame, the frame
+// expected after any crop, and the frame the encode produced. Resolutions
+// are "WxH".
+type Video struct {
+	Source   string `json:"source,omitempty"`
+	Expected string `json:"expected,omitempty"`
+	Output   string `json:"output,omitempty"`
+	Passed   bool   `json:"passed"`
+	Details  string `json:"details,omitempty"`
+}
+
+// CheckVideo compares an encode's output frame against its source. The
+// expected frame is the crop filter's size when crop is non-empty, else the
+// source. Each dimension may differ by the 2% ratio tolerance (encoders pad
+// to block multiples), and the aspect ratio must agree within the same
+// tolerance. An unparseable source or output fails with the reason in
+// Details.
+func CheckVideo(source, crop, output string) Video {
+	v := Video{Source: source, Expected: source, Output: output}
+	sw, sh, err := ParseResolution(source)
+	if err != nil {
+		v.Details = "source: " + err.Error()
+		return v
+	}
+	ew, eh := sw, sh
+	if strings.TrimSpace(crop) != "" {
+		if ew, eh, err = ParseCropFilter(crop); err != nil {
+			v.Details = err.Error()
+			return v
+		}
+		v.Expected = fmt.Sprintf("%dx%d", ew, eh)
+	}
+	ow, oh, err := ParseResolution(output)
+	if err != nil {
+		v.Details = "output: " + err.Error()
+		return v
+	}
+
+	var problems []string
+	if !within(ow, ew) || !within(oh, eh) {
+		problems = append(problems, fmt.Sprintf("output %dx%d, expected %dx%d", ow, oh, ew, eh))
+	}
+	want, got := float64(ew)/float64(eh), float64(ow)/float64(oh)
+	if math.Abs(got-want)/want > tolerance {
+		problems = append(problems, fmt.Sprintf("aspect %.3f, expected %.3f", got, want))
+	}
+	v.Passed = len(problems) == 0
+	v.Details = strings.Join(problems, "; ")
+	return v
+}
+
+func within(got, want int) bool {
+	return math.Abs(float64(got-want)) <= float64(want)*tolerance
+}
+
+// ParseResolution parses a "WxH" resolution.
+func ParseResolution(s string) (width, height int, err error) {
+	var w, h int
+	if n, scanErr := fmt.Sscanf(strings.TrimSpace(s), "%dx%d", &w, &h); scanErr != nil || n != 2 {
+		return 0, 0, fmt.Errorf("invalid resolution %q", s)
+	}
+	if w <= 0 || h <= 0 {
+		return 0, 0, fmt.Errorf("resolution must be positive: %dx%d", w, h)
+	}
+	return w, h, nil
+}
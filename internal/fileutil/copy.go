@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+
+	"golang.org/x/sys/unix"
 )
 
 // CopyProgress reports bytes copied during a verified copy.
@@ -142,6 +144,84 @@ func LinkOrCopyFileVerified(src, dst string, progress ProgressFunc) error {
 	return CopyFileVerifiedWithProgress(src, dst, progress)
 }
 
+// ReflinkOrCopyFileVerified clones src to dst with a copy-on-write reflink
+// when the filesystem supports it (Btrfs, XFS), falling back to a verified
+// copy otherwise. Any existing dst is replaced. Unlike a hardlink, the clone
+// is its own inode, so writing either path never changes the other.
+func ReflinkOrCopyFileVerified(src, dst string, progress ProgressFunc) error {
+	if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove existing destination: %w", err)
+	}
+	if size, err := reflinkFile(src, dst); err == nil {
+		if progress != nil {
+			progress(CopyProgress{BytesCopied: size, TotalBytes: size})
+		}
+		return nil
+	}
+	// Cross-device or filesystem without reflink support.
+	return CopyFileVerifiedWithProgress(src, dst, progress)
+}
+
+// reflinkFile clones src into a new dst, returning the cloned size. A
+// failed clone leaves no dst behind.
+func reflinkFile(src, dst string) (int64, error) {
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = srcFile.Close() }()
+	info, err := srcFile.Stat()
+	if err != nil {
+		return 0, err
+	}
+	dstFile, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o644)
+	if err != nil {
+		return 0, err
+	}
+	if err := unix.IoctlFileClone(int(dstFile.Fd()), int(srcFile.Fd())); err != nil {
+		_ = dstFile.Close()
+		removeBestEffort(dst)
+		return 0, err
+	}
+	if err := dstFile.Close(); err != nil {
+		removeBestEffort(dst)
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// TransferMode selects how TransferFileVerified places a file.
+type TransferMode string
+
+const (
+	// TransferHardlink shares the source inode (LinkOrCopyFileVerified).
+	TransferHardlink TransferMode = "hardlink"
+	// TransferReflink clones the source copy-on-write
+	// (ReflinkOrCopyFileVerified).
+	TransferReflink TransferMode = "reflink"
+	// TransferCopy always writes a verified byte copy.
+	TransferCopy TransferMode = "copy"
+)
+
+// TransferFileVerified places src at dst using mode, replacing any existing
+// dst. Link and clone modes fall back to a verified copy across filesystems.
+// An empty or unknown mode means TransferHardlink. The old dst is always
+// unlinked first, never truncated, so a dst that was a hardlink of src
+// cannot rewrite src.
+func TransferFileVerified(src, dst string, mode TransferMode, progress ProgressFunc) error {
+	switch mode {
+	case TransferReflink:
+		return ReflinkOrCopyFileVerified(src, dst, progress)
+	case TransferCopy:
+		if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove existing destination: %w", err)
+		}
+		return CopyFileVerifiedWithProgress(src, dst, progress)
+	default:
+		return LinkOrCopyFileVerified(src, dst, progress)
+	}
+}
+
 // progressWriter wraps a writer and reports cumulative copy progress.
 type progressWriter struct {
 	w       io.Writer
@@ -259,3 +259,33 @@ func TestLinkOrCopyFileVerified(t *testing.T) {
 		}
 	})
 }
+
+func TestTransferFileVerifiedCopyNeverWritesThroughLink(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.bin")
+	dst := filepath.Join(dir, "dst.bin")
+	if err := os.WriteFile(src, []byte("cached rip"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := TransferFileVerified(src, dst, TransferHardlink, nil); err != nil {
+		t.Fatalf("hardlink: %v", err)
+	}
+
+	// Switching modes over a hardlinked dst must unlink it, not truncate the
+	// shared inode.
+	other := filepath.Join(dir, "other.bin")
+	if err := os.WriteFile(other, []byte("replacement"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, mode := range []TransferMode{TransferCopy, TransferReflink} {
+		if err := TransferFileVerified(other, dst, mode, nil); err != nil {
+			t.Fatalf("%s: %v", mode, err)
+		}
+		if got, _ := os.ReadFile(src); string(got) != "cached rip" {
+			t.Fatalf("%s rewrote the linked source: %q", mode, got)
+		}
+		if got, _ := os.ReadFile(dst); string(got) != "replacement" {
+			t.Fatalf("%s dst = %q, want replacement", mode, got)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package fileutil
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// ErrInsufficientSpace is the error code every refused write carries;
+// match it with errors.Is.
+var ErrInsufficientSpace = errors.New("insufficient free space")
+
+// SpaceError describes a write refused because it would leave Dir's
+// filesystem below its free-space floor.
+type SpaceError struct {
+	Label string // what Dir holds, e.g. "staging"
+	Dir   string
+	Need  int64 // bytes the write is expected to add
+	Free  int64
+	Floor int64
+}
+
+func (e *SpaceError) Error() string {
+	return fmt.Sprintf(
+		"insufficient %s space: %s has %.1f GiB free but the write needs about %.1f GiB and %.1f GiB must stay free; free up space and retry",
+		e.Label, e.Dir, gib(e.Free), gib(e.Need), gib(e.Floor))
+}
+
+func (e *SpaceError) Unwrap() error { return ErrInsufficientSpace }
+
+// FreeSpace reports the bytes available to unprivileged users on dir's
+// filesystem. It is a variable so tests can simulate low space.
+var FreeSpace = func(dir string) (int64, error) {
+	var fs unix.Statfs_t
+	if err := unix.Statfs(dir, &fs); err != nil {
+		return 0, err
+	}
+	return int64(fs.Bavail) * int64(fs.Bsize), nil
+}
+
+// CheckFreeSpace guards a write of about need bytes into dir. It returns a
+// *SpaceError when the write would leave less than floor bytes free, and the
+// filesystem's free bytes otherwise. A statfs failure is returned as a plain
+// error so callers can decide whether an unmeasurable volume blocks them.
+func CheckFreeSpace(label, dir string, need, floor int64) (int64, error) {
+	free, err := FreeSpace(dir)
+	if err != nil {
+		return 0, fmt.Errorf("statfs %s: %w", dir, err)
+	}
+	if free-need < floor {
+		return free, &SpaceError{Label: label, Dir: dir, Need: need, Free: free, Floor: floor}
+	}
+	return free, nil
+}
+
+func gib(n int64) float64 { return float64(n) / (1 << 30) }
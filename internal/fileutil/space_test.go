@@ -0,0 +1,54 @@
+package fileutil
+
+import (
+	"errors"
+	"testing"
+)
+
+func stubFreeSpace(t *testing.T, free int64, err error) {
+	t.Helper()
+	orig := FreeSpace
+	t.Cleanup(func() { FreeSpace = orig })
+	FreeSpace = func(string) (int64, error) { return free, err }
+}
+
+func TestCheckFreeSpaceBlocksWriteBelowFloor(t *testing.T) {
+	stubFreeSpace(t, 12<<30, nil)
+
+	_, err := CheckFreeSpace("staging", "/staging", 4<<30, 10<<30)
+	if !errors.Is(err, ErrInsufficientSpace) {
+		t.Fatalf("err = %v, want ErrInsufficientSpace", err)
+	}
+	var spaceErr *SpaceError
+	if !errors.As(err, &spaceErr) {
+		t.Fatalf("err = %T, want *SpaceError", err)
+	}
+	want := SpaceError{Label: "staging", Dir: "/staging", Need: 4 << 30, Free: 12 << 30, Floor: 10 << 30}
+	if *spaceErr != want {
+		t.Fatalf("SpaceError = %+v, want %+v", *spaceErr, want)
+	}
+	if got := err.Error(); got != "insufficient staging space: /staging has 12.0 GiB free but the write needs about 4.0 GiB and 10.0 GiB must stay free; free up space and retry" {
+		t.Fatalf("message = %q", got)
+	}
+}
+
+func TestCheckFreeSpaceAllowsWriteAboveFloor(t *testing.T) {
+	stubFreeSpace(t, 12<<30, nil)
+
+	free, err := CheckFreeSpace("library", "/library", 2<<30, 10<<30)
+	if err != nil {
+		t.Fatalf("write leaving exactly the floor free was blocked: %v", err)
+	}
+	if free != 12<<30 {
+		t.Fatalf("free = %d, want %d", free, int64(12<<30))
+	}
+}
+
+func TestCheckFreeSpaceStatfsFailureIsNotLowSpace(t *testing.T) {
+	stubFreeSpace(t, 0, errors.New("no such file or directory"))
+
+	_, err := CheckFreeSpace("rip cache", "/missing", 1, 1)
+	if err == nil || errors.Is(err, ErrInsufficientSpace) {
+		t.Fatalf("err = %v, want a plain statfs error", err)
+	}
+}
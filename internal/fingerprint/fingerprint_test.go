@@ -295,6 +295,69 @@ func TestGenerate_FallsBackToFullContent(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// ContentHash
+// ---------------------------------------------------------------------------
+
+func TestContentHash_IgnoresNamesAndLocation(t *testing.T) {
+	a, b := t.TempDir(), t.TempDir()
+	writeFile(t, filepath.Join(a, "Heat (1995).mkv"), "feature")
+	writeFile(t, filepath.Join(a, "extras.mkv"), "extras")
+	writeFile(t, filepath.Join(b, "x.mkv"), "extras")
+	writeFile(t, filepath.Join(b, "heat.mkv"), "feature")
+
+	h1, err := ContentHash([]string{filepath.Join(a, "Heat (1995).mkv"), filepath.Join(a, "extras.mkv")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h2, err := ContentHash([]string{filepath.Join(b, "x.mkv"), filepath.Join(b, "heat.mkv")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h1 != h2 {
+		t.Errorf("renamed and moved copies hash differently: %s vs %s", h1, h2)
+	}
+
+	writeFile(t, filepath.Join(b, "heat.mkv"), "featurf")
+	h3, err := ContentHash([]string{filepath.Join(b, "x.mkv"), filepath.Join(b, "heat.mkv")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h3 == h1 {
+		t.Error("different content produced the same hash")
+	}
+}
+
+func TestContentHash_SamplesTailOfLargeFiles(t *testing.T) {
+	dir := t.TempDir()
+	body := make([]byte, 3*contentSampleBytes)
+	path := filepath.Join(dir, "big.mkv")
+	if err := os.WriteFile(path, body, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	h1, err := ContentHash([]string{path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body[len(body)-1] = 1
+	if err := os.WriteFile(path, body, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	h2, err := ContentHash([]string{path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h1 == h2 {
+		t.Error("change in the final bytes not reflected in the hash")
+	}
+}
+
+func TestContentHash_MissingFile(t *testing.T) {
+	if _, err := ContentHash([]string{filepath.Join(t.TempDir(), "gone.mkv")}); err == nil {
+		t.Fatal("expected error for a missing file")
+	}
+}
+
 // ---------------------------------------------------------------------------
 // collectGlob
 // ---------------------------------------------------------------------------
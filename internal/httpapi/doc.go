@@ -1,5 +1,8 @@
 // Package httpapi provides an HTTP API server for Spindle queue operations.
 // It supports both Unix socket and TCP listeners, with optional bearer token
 // authentication. The /api/health endpoint is unauthenticated; all other
-// endpoints require a valid token when one is configured.
+// endpoints require a valid token when one is configured. POST /api/batch
+// runs several requests in one round trip, returning a per-request status
+// and body. GET /api/logs/stream serves log events as newline-delimited
+// JSON, buffered history first and then live events.
 package httpapi
@@ -1,9 +1,12 @@
 package httpapi
 
 import (
+	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
 	"net"
 	"net/http"
@@ -13,8 +16,12 @@ import (
 	"time"
 
 	"github.com/five82/spindle/internal/discmonitor"
+	"github.com/five82/spindle/internal/logs"
+	"github.com/five82/spindle/internal/mkvimport"
 	"github.com/five82/spindle/internal/queue"
 	"github.com/five82/spindle/internal/queueops"
+	"github.com/five82/spindle/internal/ripcache"
+	"github.com/five82/spindle/internal/ripspec"
 )
 
 // Server is the HTTP API server.
@@ -31,6 +38,8 @@ type Server struct {
 	statusTracker *StatusTracker
 	pipeline      []PipelineStageInfo
 	scheduler     SchedulerSource
+	reviewTMDB    queueops.ReviewEnricher
+	ripCache      *ripcache.Store
 }
 
 // Params holds the dependencies and options for New. DiscMonitor, ShutdownCh,
@@ -46,6 +55,12 @@ type Params struct {
 	StatusTracker *StatusTracker
 	Pipeline      []PipelineStageInfo
 	Scheduler     SchedulerSource
+	// ReviewTMDB re-enriches review selections; nil leaves metadata as
+	// recorded at identification time.
+	ReviewTMDB queueops.ReviewEnricher
+	// RipCache lets resume retries restore missing rips; nil when the rip
+	// cache is disabled.
+	RipCache *ripcache.Store
 }
 
 // New creates an HTTP API server.
@@ -62,6 +77,8 @@ func New(p Params) *Server {
 		statusTracker: p.StatusTracker,
 		pipeline:      p.Pipeline,
 		scheduler:     p.Scheduler,
+		reviewTMDB:    p.ReviewTMDB,
+		ripCache:      p.RipCache,
 	}
 	s.registerRoutes()
 	s.httpServer = &http.Server{
@@ -111,16 +128,23 @@ func (s *Server) registerRoutes() {
 	s.mux.HandleFunc("POST /api/queue/retry", s.authMiddleware(s.handleQueueRetry))
 	s.mux.HandleFunc("POST /api/queue/retry-episode", s.authMiddleware(s.handleQueueRetryEpisode))
 	s.mux.HandleFunc("POST /api/queue/stop", s.authMiddleware(s.handleQueueStop))
+	s.mux.HandleFunc("POST /api/queue/options", s.authMiddleware(s.handleQueueOptions))
+	s.mux.HandleFunc("POST /api/queue/notes", s.authMiddleware(s.handleQueueNotes))
 	s.mux.HandleFunc("POST /api/queue/enqueue-cached", s.authMiddleware(s.handleQueueEnqueueCached))
+	s.mux.HandleFunc("POST /api/queue/import", s.authMiddleware(s.handleQueueImport))
 	s.mux.HandleFunc("DELETE /api/queue/{id}", s.authMiddleware(s.handleQueueRemove))
 	s.mux.HandleFunc("POST /api/queue/clear", s.authMiddleware(s.handleQueueClear))
+	s.mux.HandleFunc("GET /api/review", s.authMiddleware(s.handleReviewList))
+	s.mux.HandleFunc("POST /api/review/{id}", s.authMiddleware(s.handleReviewDecide))
 	s.mux.HandleFunc("GET /api/logs", s.authMiddleware(s.handleLogs))
+	s.mux.HandleFunc("GET /api/logs/stream", s.authMiddleware(s.handleLogStream))
 	s.mux.HandleFunc("GET /api/status", s.authMiddleware(s.handleStatus))
 	s.mux.HandleFunc("GET /api/health", s.handleHealth) // no auth
 	s.mux.HandleFunc("POST /api/daemon/stop", s.authMiddleware(s.handleDaemonStop))
 	s.mux.HandleFunc("POST /api/disc/pause", s.authMiddleware(s.handleDiscPause))
 	s.mux.HandleFunc("POST /api/disc/resume", s.authMiddleware(s.handleDiscResume))
 	s.mux.HandleFunc("POST /api/disc/detect", s.authMiddleware(s.handleDiscDetect))
+	s.mux.HandleFunc("POST /api/batch", s.authMiddleware(s.handleBatch))
 }
 
 func (s *Server) authMiddleware(next http.HandlerFunc) http.HandlerFunc {
@@ -205,23 +229,57 @@ func (s *Server) logOperatorAction(msg, action string, extra ...any) {
 
 func (s *Server) handleQueueRetry(w http.ResponseWriter, r *http.Request) {
 	var body struct {
-		IDs []int64 `json:"ids"`
+		IDs    []int64 `json:"ids"`
+		Resume bool    `json:"resume"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
-	count, err := s.store.RetryFailed(body.IDs...)
+	retry := queueops.RetryFailed
+	if body.Resume {
+		retry = func(store *queue.Store, ids ...int64) ([]queueops.Resumed, error) {
+			return queueops.ResumeFailed(store, s.ripCache, ids...)
+		}
+	}
+	resumed, err := retry(s.store, body.IDs...)
 	if err != nil {
 		s.logger.Error("retry failed items", "error", err)
 		writeError(w, http.StatusInternalServerError, "failed to retry items")
 		return
 	}
+	for _, r := range resumed {
+		if r.CacheRestored {
+			s.logger.Info("retry restored ripped files from rip cache",
+				"decision_type", logs.DecisionRipCache,
+				"decision_result", "restored",
+				"decision_reason", "resume retry found recorded rips missing",
+				"item_id", r.ID,
+			)
+		}
+		if r.CacheError != "" {
+			s.logger.Warn("resume retry could not use rip cache",
+				"event_type", "retry_cache_unusable",
+				"item_id", r.ID,
+				"error_hint", r.CacheError,
+				"impact", "item re-rips from disc",
+			)
+		}
+		if r.Rewound() {
+			s.logger.Warn("retry rewound past failed stage; recorded assets missing",
+				"event_type", "retry_rewound",
+				"item_id", r.ID,
+				"error_hint", fmt.Sprintf("%d recorded asset(s) no longer exist, e.g. %s", len(r.Missing), r.Missing[0]),
+				"impact", fmt.Sprintf("item resumes at %s instead of %s", r.Stage, r.Failed),
+			)
+		}
+	}
 	s.logOperatorAction("queue retry requested", "retry",
 		"item_ids", fmt.Sprint(body.IDs),
-		"updated", count,
+		"resume", body.Resume,
+		"updated", len(resumed),
 	)
-	writeJSON(w, http.StatusOK, map[string]int{"updated": count})
+	writeJSON(w, http.StatusOK, map[string]any{"updated": len(resumed), "items": resumed})
 }
 
 func (s *Server) handleQueueRetryEpisode(w http.ResponseWriter, r *http.Request) {
@@ -259,6 +317,21 @@ func (s *Server) handleQueueStop(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
+	// Items with a stage running are counted before the stop lands; the
+	// workflow cancels those stages' contexts on its next pass, and each
+	// stage terminates its tools and removes partial output.
+	var running int
+	for _, id := range body.IDs {
+		item, err := s.store.GetByID(id)
+		if err != nil {
+			s.logger.Error("stop items", "error", err)
+			writeError(w, http.StatusInternalServerError, "failed to stop items")
+			return
+		}
+		if item != nil && item.InProgress != 0 {
+			running++
+		}
+	}
 	count, err := s.store.StopItems(body.IDs...)
 	if err != nil {
 		s.logger.Error("stop items", "error", err)
@@ -268,11 +341,154 @@ func (s *Server) handleQueueStop(w http.ResponseWriter, r *http.Request) {
 	s.logOperatorAction("queue stop requested", "stop",
 		"item_ids", fmt.Sprint(body.IDs),
 		"updated", count,
+		"running_cancelled", running,
+	)
+	writeJSON(w, http.StatusOK, map[string]int{"updated": count, "cancelled": running})
+}
+
+func (s *Server) handleQueueOptions(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		ID int64 `json:"id"`
+		ripspec.ItemOptions
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if body.ID == 0 {
+		writeError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+	if err := body.ItemOptions.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	result, err := queueops.SetOptions(s.store, body.ID, body.ItemOptions)
+	if err != nil {
+		s.logger.Error("set item options", "error", err, "id", body.ID)
+		writeError(w, http.StatusInternalServerError, "failed to set item options")
+		return
+	}
+	s.logOperatorAction("item options change requested", "set_options",
+		"item_id", body.ID,
+		"skip_subtitles", body.SkipSubtitles,
+		"skip_commentary", body.SkipCommentary,
+		"encode_crf", body.EncodeCRF,
+		"pinned_tmdb_id", body.PinnedTMDBID,
+		"pinned_media_type", body.PinnedMediaType,
+		"result", string(result),
+	)
+	writeJSON(w, http.StatusOK, map[string]string{"result": string(result)})
+}
+
+func (s *Server) handleQueueNotes(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		ID    int64  `json:"id"`
+		Notes string `json:"notes"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if body.ID == 0 {
+		writeError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+	result, err := queueops.SetNotes(s.store, body.ID, body.Notes)
+	if err != nil {
+		s.logger.Error("set item notes", "error", err, "id", body.ID)
+		writeError(w, http.StatusInternalServerError, "failed to set item notes")
+		return
+	}
+	s.logOperatorAction("item notes change requested", "set_notes",
+		"item_id", body.ID,
+		"notes_bytes", len(body.Notes),
+		"result", string(result),
 	)
-	writeJSON(w, http.StatusOK, map[string]int{"updated": count})
+	writeJSON(w, http.StatusOK, map[string]string{"result": string(result)})
+}
+
+func (s *Server) handleReviewList(w http.ResponseWriter, _ *http.Request) {
+	items, err := queueops.PendingReviews(s.store)
+	if err != nil {
+		s.logger.Error("list pending reviews", "error", err)
+		writeError(w, http.StatusInternalServerError, "failed to list reviews")
+		return
+	}
+	responses := make([]ReviewResponse, 0, len(items))
+	for _, item := range items {
+		responses = append(responses, toReviewResponse(item))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"reviews": responses})
+}
+
+// handleReviewDecide applies an approve, override, or reject decision to an
+// item awaiting review. Decisions the item cannot take (unknown candidate,
+// unresolved episodes, ...) come back as a result string, not an HTTP error.
+func (s *Server) handleReviewDecide(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+	var body struct {
+		Decision string                             `json:"decision"`
+		TMDBID   int                                `json:"tmdb_id"`
+		Episodes map[string]queueops.EpisodeMapping `json:"episodes"`
+		Note     string                             `json:"note"`
+		Replace  bool                               `json:"replace"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if body.Decision == "" {
+		writeError(w, http.StatusBadRequest, "decision is required")
+		return
+	}
+	result, err := queueops.DecideReview(r.Context(), s.store, id, queueops.ReviewDecision{
+		Action:      queueops.ReviewAction(body.Decision),
+		CandidateID: body.TMDBID,
+		Episodes:    body.Episodes,
+		Note:        body.Note,
+		Replace:     body.Replace,
+	}, s.reviewTMDB)
+	if err != nil {
+		s.logger.Error("review decision", "error", err, "id", id)
+		writeError(w, http.StatusInternalServerError, "failed to apply review decision")
+		return
+	}
+	s.logOperatorAction("review decision requested", "review_decision",
+		"item_id", id,
+		"decision", body.Decision,
+		"tmdb_id", body.TMDBID,
+		"episode_overrides", len(body.Episodes),
+		"replace", body.Replace,
+		"result", string(result),
+	)
+	writeJSON(w, http.StatusOK, map[string]string{"result": string(result)})
 }
 
 func (s *Server) handleQueueEnqueueCached(w http.ResponseWriter, r *http.Request) {
+	s.enqueuePrebuilt(w, r, "cached rip", s.store.NewCachedRip)
+}
+
+// handleQueueImport queues an imported MKV folder whose rip spec the CLI
+// synthesized. The item starts after ripping, or after episode
+// identification when the import named every episode.
+func (s *Server) handleQueueImport(w http.ResponseWriter, r *http.Request) {
+	s.enqueuePrebuilt(w, r, "imported rip", func(title, fingerprint, ripSpecData, metadataJSON string) (*queue.Item, error) {
+		env, err := ripspec.Parse(ripSpecData)
+		if err != nil {
+			return nil, err
+		}
+		return s.store.NewImportedRip(title, fingerprint, mkvimport.EntryStage(&env), ripSpecData, metadataJSON)
+	})
+}
+
+// enqueuePrebuilt decodes a request carrying a complete rip spec, rejects
+// duplicate fingerprints unless allowed, and inserts the item via insert.
+func (s *Server) enqueuePrebuilt(w http.ResponseWriter, r *http.Request, kind string, insert func(title, fingerprint, ripSpecData, metadataJSON string) (*queue.Item, error)) {
 	var body struct {
 		DiscTitle      string `json:"disc_title"`
 		Fingerprint    string `json:"fingerprint"`
@@ -293,7 +509,7 @@ func (s *Server) handleQueueEnqueueCached(w http.ResponseWriter, r *http.Request
 	if !body.AllowDuplicate {
 		existing, err := s.store.FindByFingerprint(body.Fingerprint)
 		if err != nil {
-			s.logger.Error("check duplicate cached enqueue", "error", err, "fingerprint", body.Fingerprint)
+			s.logger.Error("check duplicate "+kind+" enqueue", "error", err, "fingerprint", body.Fingerprint)
 			writeError(w, http.StatusInternalServerError, "failed to check duplicate fingerprint")
 			return
 		}
@@ -302,10 +518,10 @@ func (s *Server) handleQueueEnqueueCached(w http.ResponseWriter, r *http.Request
 			return
 		}
 	}
-	item, err := s.store.NewCachedRip(body.DiscTitle, body.Fingerprint, body.RipSpecData, body.MetadataJSON)
+	item, err := insert(body.DiscTitle, body.Fingerprint, body.RipSpecData, body.MetadataJSON)
 	if err != nil {
-		s.logger.Error("enqueue cached rip", "error", err, "fingerprint", body.Fingerprint)
-		writeError(w, http.StatusInternalServerError, "failed to enqueue cached rip")
+		s.logger.Error("enqueue "+kind, "error", err, "fingerprint", body.Fingerprint)
+		writeError(w, http.StatusInternalServerError, "failed to enqueue "+kind)
 		return
 	}
 	writeJSON(w, http.StatusOK, map[string]any{"item": toItemResponse(item, nil, false)})
@@ -364,11 +580,68 @@ func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	events, next := s.logBuffer.Query(s.logQueryOpts(r))
+	if events == nil {
+		events = []LogEntry{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"events": events, "next": next})
+}
+
+// handleLogStream writes matching buffered events, then live events as the
+// daemon captures them, as newline-delimited JSON until the client goes
+// away. It takes the same filters as /api/logs; since resumes a dropped
+// stream without repeating events.
+func (s *Server) handleLogStream(w http.ResponseWriter, r *http.Request) {
+	if s.logBuffer == nil {
+		writeError(w, http.StatusServiceUnavailable, "log buffer unavailable")
+		return
+	}
+	rc := http.NewResponseController(w)
+	// A stream outlives the server's write timeout by design.
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		writeError(w, http.StatusBadRequest, "streaming not supported for this request")
+		return
+	}
+
+	opts := s.logQueryOpts(r)
+	limit := cmp.Or(opts.Limit, defaultLogQueryLimit)
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	enc := json.NewEncoder(w)
+	for {
+		changed := s.logBuffer.Changed()
+		events, next := s.logBuffer.Query(opts)
+		for _, e := range events {
+			if err := enc.Encode(e); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+		// A forward query returns at most limit entries; drain any backlog
+		// before waiting for new ones. A tail window is complete as sent.
+		backlog := len(events) == limit && (!opts.Tail || opts.Since > 0)
+		opts.Since, opts.Tail = next, false
+		if backlog {
+			continue
+		}
+		select {
+		case <-r.Context().Done():
+			return
+		case <-changed:
+		}
+	}
+}
+
+// logQueryOpts parses the /api/logs filter parameters.
+func (s *Server) logQueryOpts(r *http.Request) LogQueryOpts {
 	q := r.URL.Query()
 	opts := LogQueryOpts{
 		Component: q.Get("component"),
 		Lane:      q.Get("lane"),
 		Request:   q.Get("request"),
+		Stage:     q.Get("stage"),
 		Level:     q.Get("level"),
 	}
 
@@ -400,12 +673,7 @@ func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request) {
 	if v := q.Get("daemon_only"); v == "1" {
 		opts.DaemonOnly = true
 	}
-
-	events, next := s.logBuffer.Query(opts)
-	if events == nil {
-		events = []LogEntry{}
-	}
-	writeJSON(w, http.StatusOK, map[string]any{"events": events, "next": next})
+	return opts
 }
 
 func (s *Server) handleStatus(w http.ResponseWriter, _ *http.Request) {
@@ -426,6 +694,21 @@ func (s *Server) handleStatus(w http.ResponseWriter, _ *http.Request) {
 	}
 	deps := []DependencyResponse{}
 
+	var resources map[string]ResourceStatus
+	if s.scheduler != nil {
+		resources = s.scheduler.SchedulerSnapshot()
+	}
+	finished, err := s.store.TasksFinishedSince(time.Now().Add(-throughputWindow))
+	if err == nil {
+		wf.Throughput = buildThroughput(throughputWindow, finished, resources)
+	} else {
+		s.logger.Warn("throughput metrics unavailable",
+			"event_type", "status_throughput_error",
+			"error_hint", err.Error(),
+			"impact", "status omits throughput",
+		)
+	}
+
 	if s.statusTracker != nil {
 		lastErr, trackerDeps := s.statusTracker.Snapshot()
 		wf.LastError = lastErr
@@ -443,8 +726,8 @@ func (s *Server) handleStatus(w http.ResponseWriter, _ *http.Request) {
 		Dependencies: deps,
 		Pipeline:     s.pipeline,
 	}
-	if s.scheduler != nil {
-		resp.Scheduler = &SchedulerStatus{Resources: s.scheduler.SchedulerSnapshot()}
+	if resources != nil {
+		resp.Scheduler = &SchedulerStatus{Resources: resources}
 	}
 	if s.discMonitor != nil {
 		resp.Disc = &DiscStatus{Paused: s.discMonitor.IsPaused()}
@@ -505,6 +788,89 @@ func (s *Server) handleDiscDetect(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, resp)
 }
 
+// maxBatchRequests caps one /api/batch call; the CLI needs a handful.
+const maxBatchRequests = 16
+
+// handleBatch runs several API requests in one round trip. Each entry is
+// dispatched through the mux independently, with the caller's credentials,
+// so one failing request yields its own error entry without affecting the
+// others. Nested batches are rejected.
+func (s *Server) handleBatch(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Requests []BatchRequest `json:"requests"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if len(body.Requests) == 0 || len(body.Requests) > maxBatchRequests {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("batch must contain 1-%d requests", maxBatchRequests))
+		return
+	}
+	responses := make([]BatchResponse, 0, len(body.Requests))
+	for _, sub := range body.Requests {
+		responses = append(responses, s.serveBatchEntry(r, sub))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"responses": responses})
+}
+
+func (s *Server) serveBatchEntry(parent *http.Request, sub BatchRequest) BatchResponse {
+	path, _, _ := strings.Cut(sub.Path, "?")
+	if !strings.HasPrefix(path, "/api/") || path == "/api/batch" {
+		return batchError(http.StatusBadRequest, "invalid batch path")
+	}
+	method := sub.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	var reqBody io.Reader = http.NoBody
+	if len(sub.Body) > 0 {
+		reqBody = bytes.NewReader(sub.Body)
+	}
+	req, err := http.NewRequestWithContext(parent.Context(), method, sub.Path, reqBody)
+	if err != nil {
+		return batchError(http.StatusBadRequest, "invalid batch request")
+	}
+	req.Header.Set("Authorization", parent.Header.Get("Authorization"))
+	req.Header.Set("Content-Type", "application/json")
+
+	rec := &batchRecorder{header: make(http.Header), status: http.StatusOK}
+	s.mux.ServeHTTP(rec, req)
+	out := bytes.TrimSpace(rec.body.Bytes())
+	if !json.Valid(out) {
+		out, _ = json.Marshal(string(out))
+	}
+	return BatchResponse{Status: rec.status, Body: out}
+}
+
+func batchError(status int, message string) BatchResponse {
+	body, _ := json.Marshal(map[string]string{"error": message})
+	return BatchResponse{Status: status, Body: body}
+}
+
+// batchRecorder captures one batch entry's response in memory.
+type batchRecorder struct {
+	header      http.Header
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (b *batchRecorder) Header() http.Header { return b.header }
+
+func (b *batchRecorder) WriteHeader(status int) {
+	if b.wroteHeader {
+		return
+	}
+	b.status = status
+	b.wroteHeader = true
+}
+
+func (b *batchRecorder) Write(p []byte) (int, error) {
+	b.wroteHeader = true
+	return b.body.Write(p)
+}
+
 func writeJSON(w http.ResponseWriter, status int, data any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
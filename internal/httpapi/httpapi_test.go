@@ -1,6 +1,7 @@
 package httpapi_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log/slog"
@@ -135,6 +136,57 @@ func TestQueueEnqueueCachedCreatesRippingItem(t *testing.T) {
 	}
 }
 
+func TestQueueNotesSetAndProjected(t *testing.T) {
+	store := testStore(t)
+	srv := httpapi.New(httpapi.Params{Store: store, Logger: slog.New(slog.NewTextHandler(os.Stderr, nil))})
+	item, err := store.NewDisc("Disc", "fp1")
+	if err != nil {
+		t.Fatalf("new disc: %v", err)
+	}
+
+	post := func(body string) string {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/api/queue/notes", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		srv.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp struct {
+			Result string `json:"result"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		return resp.Result
+	}
+
+	if got := post(fmt.Sprintf(`{"id":%d,"notes":"  warped, read slowly  "}`, item.ID)); got != "updated" {
+		t.Fatalf("result = %q, want updated", got)
+	}
+	if got := post(`{"id":9999,"notes":"x"}`); got != "not_found" {
+		t.Fatalf("missing item result = %q, want not_found", got)
+	}
+	if got := post(fmt.Sprintf(`{"id":%d,"notes":%q}`, item.ID, strings.Repeat("x", 5000))); got != "too_long" {
+		t.Fatalf("oversized notes result = %q, want too_long", got)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/queue/%d", item.ID), nil)
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	var resp struct {
+		Item struct {
+			Notes string `json:"notes"`
+		} `json:"item"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode item: %v", err)
+	}
+	if resp.Item.Notes != "warped, read slowly" {
+		t.Fatalf("item notes = %q, want the trimmed notes", resp.Item.Notes)
+	}
+}
+
 func TestQueueEnqueueCachedRejectsDuplicate(t *testing.T) {
 	store := testStore(t)
 	if _, err := store.NewDisc("Existing", "fp1"); err != nil {
@@ -152,6 +204,54 @@ func TestQueueEnqueueCachedRejectsDuplicate(t *testing.T) {
 	}
 }
 
+func TestQueueStopReportsCancelledRunningStages(t *testing.T) {
+	store := testStore(t)
+	running, err := store.NewDisc("Running", "fp-run")
+	if err != nil {
+		t.Fatalf("new disc: %v", err)
+	}
+	if err := store.StartStage(running); err != nil {
+		t.Fatalf("start stage: %v", err)
+	}
+	idle, err := store.NewDisc("Idle", "fp-idle")
+	if err != nil {
+		t.Fatalf("new disc: %v", err)
+	}
+	srv := httpapi.New(httpapi.Params{Store: store, Logger: slog.New(slog.NewTextHandler(os.Stderr, nil))})
+
+	body := fmt.Sprintf(`{"ids":[%d,%d]}`, running.ID, idle.ID)
+	req := httptest.NewRequest(http.MethodPost, "/api/queue/stop", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Updated   int `json:"updated"`
+		Cancelled int `json:"cancelled"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if resp.Updated != 2 || resp.Cancelled != 1 {
+		t.Fatalf("stop response = %+v, want 2 updated with 1 running stage cancelled", resp)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/queue/%d", running.ID), nil)
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	var got struct {
+		Item httpapi.ItemResponse `json:"item"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("decode item: %v", err)
+	}
+	if got.Item.Stage != string(queue.StageFailed) || !got.Item.UserStopped || got.Item.InProgress {
+		t.Fatalf("stopped item = %+v, want a user-stopped failed item no longer in progress", got.Item)
+	}
+}
+
 func TestStatusReturnsStructuredResponse(t *testing.T) {
 	store := testStore(t)
 	srv := httpapi.New(httpapi.Params{Store: store, Logger: slog.New(slog.NewTextHandler(os.Stderr, nil))})
@@ -223,6 +323,9 @@ func newProjectionEnvelope(t *testing.T) string {
 				MatchedEpisodes: 1,
 				Completed:       true,
 			},
+			StageSkips: []ripspec.StageSkip{
+				{Stage: "analysis", Step: "commentary_detection", Category: ripspec.SkipDisabled, Reason: "commentary disabled"},
+			},
 		},
 	}
 	data, err := env.Encode()
@@ -315,6 +418,9 @@ func TestQueueResponsesProjectTasksAndEnvelope(t *testing.T) {
 	if got.ContentID == nil || got.ContentID.Method != "transcript_match" || !got.ContentID.Completed {
 		t.Fatalf("contentId not projected: %+v", got.ContentID)
 	}
+	if len(got.StageSkips) != 1 || got.StageSkips[0].Step != "commentary_detection" || got.StageSkips[0].Category != ripspec.SkipDisabled {
+		t.Fatalf("stageSkips not projected: %+v", got.StageSkips)
+	}
 
 	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/queue/%d", item.ID), nil)
 	w = httptest.NewRecorder()
@@ -436,3 +542,120 @@ func TestLogsItemQueryScopedToItemLifetime(t *testing.T) {
 		t.Fatalf("unscoped query returned %d events, want 2", len(events))
 	}
 }
+
+func TestLogStreamDeliversBufferedThenLiveEvents(t *testing.T) {
+	store := testStore(t)
+	buffer := httpapi.NewLogBuffer(16)
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+	buffer.Append(httpapi.LogEntry{Time: now, Level: "INFO", Stage: "encoding", ItemID: 4, Msg: "buffered encode"})
+	buffer.Append(httpapi.LogEntry{Time: now, Level: "DEBUG", Stage: "encoding", ItemID: 4, Msg: "below level"})
+	buffer.Append(httpapi.LogEntry{Time: now, Level: "INFO", Stage: "ripping", ItemID: 4, Msg: "other stage"})
+
+	srv := httpapi.New(httpapi.Params{
+		Store:     store,
+		Logger:    slog.New(slog.NewTextHandler(os.Stderr, nil)),
+		LogBuffer: buffer,
+	})
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/api/logs/stream?level=info&stage=encoding", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("open stream: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	dec := json.NewDecoder(resp.Body)
+	next := func() httpapi.LogEntry {
+		t.Helper()
+		var e httpapi.LogEntry
+		if err := dec.Decode(&e); err != nil {
+			t.Fatalf("read stream: %v", err)
+		}
+		return e
+	}
+
+	if e := next(); e.Msg != "buffered encode" {
+		t.Fatalf("first event = %+v, want buffered encode", e)
+	}
+
+	// Live events arrive after the buffered window, still filtered.
+	buffer.Append(httpapi.LogEntry{Time: now, Level: "WARN", Stage: "ripping", Msg: "live other stage"})
+	buffer.Append(httpapi.LogEntry{Time: now, Level: "WARN", Stage: "encoding", ItemID: 4, Msg: "live encode"})
+	if e := next(); e.Msg != "live encode" || e.Seq != 5 {
+		t.Fatalf("live event = %+v, want live encode seq 5", e)
+	}
+}
+
+func TestBatchIsolatesFailingRequests(t *testing.T) {
+	store := testStore(t)
+	if _, err := store.NewDisc("Batch Disc", "fp-batch"); err != nil {
+		t.Fatalf("new disc: %v", err)
+	}
+	srv := httpapi.New(httpapi.Params{Store: store, Token: "secret-token", Logger: slog.New(slog.NewTextHandler(os.Stderr, nil))})
+
+	body := `{"requests":[{"path":"/api/queue"},{"path":"/api/queue/999"},{"path":"/api/batch","method":"POST"},{"path":"/api/status"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/batch", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer secret-token")
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Responses []httpapi.BatchResponse `json:"responses"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if len(resp.Responses) != 4 {
+		t.Fatalf("expected 4 responses, got %d", len(resp.Responses))
+	}
+
+	wantStatus := []int{http.StatusOK, http.StatusNotFound, http.StatusBadRequest, http.StatusOK}
+	for i, want := range wantStatus {
+		if resp.Responses[i].Status != want {
+			t.Errorf("response %d status = %d, want %d (%s)", i, resp.Responses[i].Status, want, resp.Responses[i].Body)
+		}
+	}
+
+	var list struct {
+		Items []httpapi.ItemResponse `json:"items"`
+	}
+	if err := json.Unmarshal(resp.Responses[0].Body, &list); err != nil {
+		t.Fatalf("decode queue entry: %v", err)
+	}
+	if len(list.Items) != 1 || list.Items[0].DiscTitle != "Batch Disc" {
+		t.Fatalf("queue entry items = %+v, want the one disc", list.Items)
+	}
+	var notFound map[string]string
+	if err := json.Unmarshal(resp.Responses[1].Body, &notFound); err != nil || notFound["error"] == "" {
+		t.Fatalf("missing item entry = %s, want error body", resp.Responses[1].Body)
+	}
+	var status httpapi.StatusAPIResponse
+	if err := json.Unmarshal(resp.Responses[3].Body, &status); err != nil {
+		t.Fatalf("decode status entry: %v", err)
+	}
+}
+
+func TestBatchRejectsMissingToken(t *testing.T) {
+	store := testStore(t)
+	srv := httpapi.New(httpapi.Params{Store: store, Token: "secret-token", Logger: slog.New(slog.NewTextHandler(os.Stderr, nil))})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/batch", strings.NewReader(`{"requests":[{"path":"/api/queue"}]}`))
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", w.Code)
+	}
+}
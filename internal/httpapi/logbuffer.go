@@ -14,7 +14,10 @@ import (
 	"time"
 )
 
-const defaultLogBufferCapacity = 10000
+const (
+	defaultLogBufferCapacity = 10000
+	defaultLogQueryLimit     = 200
+)
 
 // LogEntry is a single structured log event stored in the buffer.
 type LogEntry struct {
@@ -43,6 +46,7 @@ type LogBuffer struct {
 	count   int
 	cap     int
 	nextSeq atomic.Uint64
+	changed chan struct{} // closed and replaced on every Append
 }
 
 // NewLogBuffer creates a log buffer with the given capacity.
@@ -53,6 +57,7 @@ func NewLogBuffer(capacity int) *LogBuffer {
 	buf := &LogBuffer{
 		entries: make([]LogEntry, capacity),
 		cap:     capacity,
+		changed: make(chan struct{}),
 	}
 	buf.nextSeq.Store(1) // sequences start at 1
 	return buf
@@ -70,9 +75,19 @@ func (b *LogBuffer) Append(entry LogEntry) {
 	if b.count < b.cap {
 		b.count++
 	}
+	close(b.changed)
+	b.changed = make(chan struct{})
 	b.mu.Unlock()
 }
 
+// Changed returns a channel that is closed when the next entry is appended.
+// Take it before querying so an append racing the query is not missed.
+func (b *LogBuffer) Changed() <-chan struct{} {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.changed
+}
+
 // LogQueryOpts configures a log buffer query.
 type LogQueryOpts struct {
 	Since      uint64 // cursor from a prior query's next: return entries with seq >= Since (0 = from the start)
@@ -82,6 +97,7 @@ type LogQueryOpts struct {
 	Component  string // filter by component (case-insensitive)
 	Lane       string // filter by lane (case-insensitive)
 	Request    string // filter by request ID (case-insensitive)
+	Stage      string // filter by stage (case-insensitive)
 	Level      string // minimum log level (debug, info, warn, error)
 	DaemonOnly bool   // only entries with ItemID == 0
 
@@ -111,7 +127,7 @@ func levelRank(level string) int {
 // Query returns entries matching the filter, plus the next sequence cursor.
 func (b *LogBuffer) Query(opts LogQueryOpts) ([]LogEntry, uint64) {
 	if opts.Limit <= 0 {
-		opts.Limit = 200
+		opts.Limit = defaultLogQueryLimit
 	}
 
 	minLevel := -1
@@ -204,6 +220,9 @@ func (b *LogBuffer) matchesFilter(e LogEntry, opts LogQueryOpts, minLevel int) b
 	if opts.Request != "" && !strings.EqualFold(e.Request, opts.Request) {
 		return false
 	}
+	if opts.Stage != "" && !strings.EqualFold(e.Stage, opts.Stage) {
+		return false
+	}
 	if minLevel >= 0 && levelRank(e.Level) < minLevel {
 		return false
 	}
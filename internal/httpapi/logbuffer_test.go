@@ -215,6 +215,24 @@ func TestQueryMinTimeClampsEntries(t *testing.T) {
 	}
 }
 
+func TestQueryStageFilterCombinesWithItemAndLevel(t *testing.T) {
+	buf := NewLogBuffer(10)
+	buf.Append(LogEntry{Time: "2026-07-01T12:00:00Z", ItemID: 4, Stage: "encoding", Level: "INFO", Msg: "encode started"})
+	buf.Append(LogEntry{Time: "2026-07-01T12:00:01Z", ItemID: 4, Stage: "ripping", Level: "WARN", Msg: "rip retry"})
+	buf.Append(LogEntry{Time: "2026-07-01T12:00:02Z", ItemID: 4, Stage: "Encoding", Level: "DEBUG", Msg: "encode chunk"})
+	buf.Append(LogEntry{Time: "2026-07-01T12:00:03Z", ItemID: 5, Stage: "encoding", Level: "WARN", Msg: "other item"})
+
+	entries, _ := buf.Query(LogQueryOpts{Stage: "encoding", ItemID: 4, Limit: 10})
+	if len(entries) != 2 || entries[0].Msg != "encode started" || entries[1].Msg != "encode chunk" {
+		t.Fatalf("stage query returned %+v, want item 4's encoding lines only", entries)
+	}
+
+	entries, _ = buf.Query(LogQueryOpts{Stage: "encoding", ItemID: 4, Level: "info", Limit: 10})
+	if len(entries) != 1 || entries[0].Msg != "encode started" {
+		t.Fatalf("stage+level query returned %+v, want only the INFO encoding line", entries)
+	}
+}
+
 func TestQueryTailIgnoredOnCursorPolls(t *testing.T) {
 	buf := NewLogBuffer(20)
 	for i := 0; i < 10; i++ {
@@ -15,31 +15,61 @@ import (
 // a display label -- during overlap windows it lags the running tasks.
 // RipSpec (the raw envelope) is included only on single-item GETs.
 type ItemResponse struct {
-	ID                      int64              `json:"id"`
-	DiscTitle               string             `json:"discTitle"`
-	DisplayTitle            string             `json:"displayTitle"`
-	Stage                   string             `json:"stage"`
-	InProgress              bool               `json:"inProgress"`
-	FailedAtStage           string             `json:"failedAtStage,omitempty"`
-	ErrorMessage            string             `json:"errorMessage,omitempty"`
-	CreatedAt               string             `json:"createdAt"`
-	UpdatedAt               string             `json:"updatedAt"`
-	DiscFingerprint         string             `json:"discFingerprint,omitempty"`
-	NeedsReview             bool               `json:"needsReview"`
-	UserStopped             bool               `json:"userStopped,omitempty"`
-	ReviewReasons           []string           `json:"reviewReasons,omitempty"`
-	Metadata                json.RawMessage    `json:"metadata,omitempty"`
-	RipSpec                 json.RawMessage    `json:"ripSpec,omitempty"`
-	Tasks                   []TaskResponse     `json:"tasks,omitempty"`
-	Encoding                json.RawMessage    `json:"encoding,omitempty"`
-	Episodes                []EpisodeResponse  `json:"episodes,omitempty"`
-	EpisodeTotals           *TotalsResponse    `json:"episodeTotals,omitempty"`
-	EpisodeIdentifiedCount  int                `json:"episodeIdentifiedCount,omitempty"`
-	SubtitleGeneration      *SubGenResponse    `json:"subtitleGeneration,omitempty"`
-	PrimaryAudioDescription string             `json:"primaryAudioDescription,omitempty"`
-	CommentaryCount         int                `json:"commentaryCount,omitempty"`
-	ContentID               *ContentIDResponse `json:"contentId,omitempty"`
-	Source                  *SourceResponse    `json:"source,omitempty"`
+	ID                      int64               `json:"id"`
+	DiscTitle               string              `json:"discTitle"`
+	DisplayTitle            string              `json:"displayTitle"`
+	Stage                   string              `json:"stage"`
+	InProgress              bool                `json:"inProgress"`
+	FailedAtStage           string              `json:"failedAtStage,omitempty"`
+	ErrorMessage            string              `json:"errorMessage,omitempty"`
+	CreatedAt               string              `json:"createdAt"`
+	UpdatedAt               string              `json:"updatedAt"`
+	DiscFingerprint         string              `json:"discFingerprint,omitempty"`
+	NeedsReview             bool                `json:"needsReview"`
+	UserStopped             bool                `json:"userStopped,omitempty"`
+	ReviewReasons           []string            `json:"reviewReasons,omitempty"`
+	Metadata                json.RawMessage     `json:"metadata,omitempty"`
+	RipSpec                 json.RawMessage     `json:"ripSpec,omitempty"`
+	Tasks                   []TaskResponse      `json:"tasks,omitempty"`
+	Encoding                json.RawMessage     `json:"encoding,omitempty"`
+	Episodes                []EpisodeResponse   `json:"episodes,omitempty"`
+	EpisodeTotals           *TotalsResponse     `json:"episodeTotals,omitempty"`
+	EpisodeIdentifiedCount  int                 `json:"episodeIdentifiedCount,omitempty"`
+	SubtitleGeneration      *SubGenResponse     `json:"subtitleGeneration,omitempty"`
+	PrimaryAudioDescription string              `json:"primaryAudioDescription,omitempty"`
+	CommentaryCount         int                 `json:"commentaryCount,omitempty"`
+	ContentID               *ContentIDResponse  `json:"contentId,omitempty"`
+	StageSkips              []StageSkipResponse `json:"stageSkips,omitempty"`
+	Source                  *SourceResponse     `json:"source,omitempty"`
+	EstimatedEncodeCost     float64             `json:"estimatedEncodeCost,omitempty"`
+	SkipSubtitles           bool                `json:"skipSubtitles,omitempty"`
+	SkipCommentary          bool                `json:"skipCommentary,omitempty"`
+	EncodeCRF               float64             `json:"encodeCrf,omitempty"`
+	PinnedTMDBID            int                 `json:"pinnedTmdbId,omitempty"`
+	PinnedMediaType         string              `json:"pinnedMediaType,omitempty"`
+	Notes                   string              `json:"notes,omitempty"`
+}
+
+// ReviewResponse is an item awaiting a review decision: the item (whose
+// episodes carry the current mappings, review flags, and review-file paths)
+// plus the TMDB alternatives a reviewer may choose instead.
+type ReviewResponse struct {
+	ItemResponse
+	Candidates []CandidateResponse `json:"candidates,omitempty"`
+}
+
+// CandidateResponse is one recorded TMDB match alternative, in rank order.
+type CandidateResponse struct {
+	TMDBID      int     `json:"tmdbId"`
+	Score       float64 `json:"score"`
+	ExactMatch  bool    `json:"exactMatch,omitempty"`
+	Title       string  `json:"title"`
+	MediaType   string  `json:"mediaType,omitempty"`
+	Year        string  `json:"year,omitempty"`
+	Overview    string  `json:"overview,omitempty"`
+	VoteAverage float64 `json:"voteAverage,omitempty"`
+	VoteCount   int     `json:"voteCount,omitempty"`
+	Current     bool    `json:"current,omitempty"`
 }
 
 // SourceResponse summarizes the primary rip-spec title (the movie main
@@ -71,6 +101,7 @@ type ProgressResponse struct {
 	Message     string  `json:"message"`
 	BytesCopied int64   `json:"bytesCopied,omitempty"`
 	TotalBytes  int64   `json:"totalBytes,omitempty"`
+	ETASeconds  float64 `json:"etaSeconds,omitempty"`
 }
 
 // EpisodeResponse represents an episode in the API response.
@@ -136,6 +167,29 @@ type ContentIDResponse struct {
 	Completed            bool    `json:"completed,omitempty"`
 }
 
+// StageSkipResponse explains why a stage, or one step of it, did not run.
+type StageSkipResponse struct {
+	Stage    string `json:"stage"`
+	Step     string `json:"step,omitempty"`
+	Category string `json:"category"`
+	Reason   string `json:"reason"`
+}
+
+// BatchRequest is one entry of a POST /api/batch call. Path includes any
+// query string; Method defaults to GET.
+type BatchRequest struct {
+	Method string          `json:"method,omitempty"`
+	Path   string          `json:"path"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// BatchResponse is the result of one BatchRequest, in request order. Status
+// and Body are exactly what the endpoint would have returned on its own.
+type BatchResponse struct {
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body"`
+}
+
 // StatusAPIResponse is the top-level /api/status response.
 type StatusAPIResponse struct {
 	Running      bool                 `json:"running"`
@@ -202,9 +256,30 @@ type DependencyResponse struct {
 // terminal stage, active items by their earliest running task, and idle items
 // by coarse stage; each item's Tasks remain the detailed source of truth.
 type WorkflowStatus struct {
-	Running    bool           `json:"running"`
-	QueueStats map[string]int `json:"queueStats"`
-	LastError  string         `json:"lastError"`
+	Running    bool              `json:"running"`
+	QueueStats map[string]int    `json:"queueStats"`
+	LastError  string            `json:"lastError"`
+	Throughput *ThroughputStatus `json:"throughput,omitempty"`
+}
+
+// ThroughputStatus summarizes pipeline throughput over a trailing window,
+// derived from task start/finish timestamps. Items cleared from the queue
+// take their task history with them, so the figures cover what is still
+// queued.
+type ThroughputStatus struct {
+	WindowHours    float64           `json:"windowHours"`
+	ItemsCompleted int               `json:"itemsCompleted"`
+	ItemsPerHour   float64           `json:"itemsPerHour"`
+	Stages         []StageThroughput `json:"stages,omitempty"`
+	// LaneUtilization is each scheduler resource's current Used/Capacity.
+	LaneUtilization map[string]float64 `json:"laneUtilization,omitempty"`
+}
+
+// StageThroughput is one stage's finished work in the window.
+type StageThroughput struct {
+	Stage      string  `json:"stage"`
+	Completed  int     `json:"completed"`
+	AvgSeconds float64 `json:"avgSeconds"`
 }
 
 // StatusInfo provides config-derived values needed by the status endpoint.
@@ -260,20 +335,22 @@ func (t *StatusTracker) Snapshot() (lastError string, deps []DependencyResponse)
 // GETs only: the list endpoint would ship every envelope on every poll).
 func toItemResponse(item *queue.Item, tasks []*queue.Task, includeRipSpec bool) ItemResponse {
 	resp := ItemResponse{
-		ID:              item.ID,
-		DiscTitle:       item.DiscTitle,
-		DisplayTitle:    item.DisplayTitle(),
-		Stage:           string(item.Stage),
-		InProgress:      item.InProgress != 0,
-		FailedAtStage:   string(item.FailedAtStage),
-		ErrorMessage:    item.ErrorMessage,
-		CreatedAt:       item.CreatedAt,
-		UpdatedAt:       item.UpdatedAt,
-		DiscFingerprint: item.DiscFingerprint,
-		NeedsReview:     item.NeedsReview != 0,
-		UserStopped:     item.UserStopped(),
-		ReviewReasons:   item.ReviewReasons(),
-		Tasks:           toTaskResponses(tasks),
+		ID:                  item.ID,
+		DiscTitle:           item.DiscTitle,
+		DisplayTitle:        item.DisplayTitle(),
+		Stage:               string(item.Stage),
+		InProgress:          item.InProgress != 0,
+		FailedAtStage:       string(item.FailedAtStage),
+		ErrorMessage:        item.ErrorMessage,
+		CreatedAt:           item.CreatedAt,
+		UpdatedAt:           item.UpdatedAt,
+		DiscFingerprint:     item.DiscFingerprint,
+		NeedsReview:         item.NeedsReview != 0,
+		UserStopped:         item.UserStopped(),
+		ReviewReasons:       item.ReviewReasons(),
+		Tasks:               toTaskResponses(tasks),
+		EstimatedEncodeCost: item.EstimatedEncodeCost,
+		Notes:               item.Notes,
 	}
 
 	// MetadataJSON -> json.RawMessage
@@ -300,6 +377,31 @@ func toItemResponse(item *queue.Item, tasks []*queue.Task, includeRipSpec bool)
 	return resp
 }
 
+// toReviewResponse builds the review view of item. Candidates come from the
+// rip spec; Current marks the match the item carries now.
+func toReviewResponse(item *queue.Item) ReviewResponse {
+	resp := ReviewResponse{ItemResponse: toItemResponse(item, nil, false)}
+	env, err := ripspec.Parse(item.RipSpecData)
+	if err != nil {
+		return resp
+	}
+	for _, c := range env.Attributes.MatchCandidates {
+		resp.Candidates = append(resp.Candidates, CandidateResponse{
+			TMDBID:      c.ID,
+			Score:       c.Score,
+			ExactMatch:  c.ExactMatch,
+			Title:       c.Title,
+			MediaType:   c.MediaType,
+			Year:        c.Year,
+			Overview:    c.Overview,
+			VoteAverage: c.VoteAverage,
+			VoteCount:   c.VoteCount,
+			Current:     c.ID == env.Metadata.ID,
+		})
+	}
+	return resp
+}
+
 // toTaskResponses maps task rows to the API shape, resolving dependency row
 // IDs to task types.
 func toTaskResponses(tasks []*queue.Task) []TaskResponse {
@@ -324,6 +426,7 @@ func toTaskResponses(tasks []*queue.Task) []TaskResponse {
 				Message:     t.ProgressMessage,
 				BytesCopied: t.ProgressBytesCopied,
 				TotalBytes:  t.ProgressTotalBytes,
+				ETASeconds:  t.ProgressETASeconds,
 			},
 			ActiveAssetKey: t.ActiveAssetKey,
 		}
@@ -351,6 +454,11 @@ func activeAssetKeys(tasks []*queue.Task) map[string]bool {
 // populateRipSpecDerived computes episodes, totals, subtitle generation,
 // audio description, etc. from a pre-parsed envelope.
 func populateRipSpecDerived(resp *ItemResponse, env *ripspec.Envelope, activeKeys map[string]bool) {
+	resp.SkipSubtitles = env.Options.SkipSubtitles
+	resp.SkipCommentary = env.Options.SkipCommentary
+	resp.EncodeCRF = env.Options.EncodeCRF
+	resp.PinnedTMDBID = env.Options.PinnedTMDBID
+	resp.PinnedMediaType = env.Options.PinnedMediaType
 
 	// Episodes
 	resp.Episodes = buildEpisodes(env, activeKeys)
@@ -410,6 +518,10 @@ func populateRipSpecDerived(resp *ItemResponse, env *ripspec.Envelope, activeKey
 			Completed:            cid.Completed,
 		}
 	}
+
+	for _, skip := range env.Attributes.StageSkips {
+		resp.StageSkips = append(resp.StageSkips, StageSkipResponse(skip))
+	}
 }
 
 // buildEpisodes constructs EpisodeResponse slice from envelope data.
@@ -0,0 +1,67 @@
+package httpapi
+
+import (
+	"time"
+
+	"github.com/five82/spindle/internal/queue"
+)
+
+// throughputWindow is how far back status throughput looks.
+const throughputWindow = 24 * time.Hour
+
+// buildThroughput aggregates items completed and per-stage task durations
+// from the tasks finished within window. An item counts as completed when
+// its organizing task finished in the window; item timestamps move on any
+// edit, such as a note. Stages are listed in pipeline order.
+func buildThroughput(window time.Duration, finished []*queue.Task, resources map[string]ResourceStatus) *ThroughputStatus {
+	out := &ThroughputStatus{WindowHours: window.Hours()}
+	organized := make(map[int64]bool)
+	for _, task := range finished {
+		if task.Type == queue.StageOrganizing {
+			organized[task.ItemID] = true
+		}
+	}
+	out.ItemsCompleted = len(organized)
+	if window > 0 {
+		out.ItemsPerHour = float64(out.ItemsCompleted) / window.Hours()
+	}
+
+	type stageTotals struct {
+		count int
+		total time.Duration
+	}
+	totals := make(map[queue.Stage]*stageTotals)
+	for _, task := range finished {
+		d, ok := task.Duration()
+		if !ok {
+			continue
+		}
+		st := totals[task.Type]
+		if st == nil {
+			st = &stageTotals{}
+			totals[task.Type] = st
+		}
+		st.count++
+		st.total += d
+	}
+	for _, stage := range queue.StageOrder {
+		if st := totals[stage]; st != nil {
+			out.Stages = append(out.Stages, StageThroughput{
+				Stage:      string(stage),
+				Completed:  st.count,
+				AvgSeconds: st.total.Seconds() / float64(st.count),
+			})
+		}
+	}
+
+	for name, r := range resources {
+		if r.Capacity <= 0 {
+			continue
+		}
+		if out.LaneUtilization == nil {
+			out.LaneUtilization = make(map[string]float64, len(resources))
+		}
+		out.LaneUtilization[name] = float64(r.Used) / float64(r.Capacity)
+	}
+	return out
+}
@@ -0,0 +1,51 @@
+package httpapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/five82/spindle/internal/queue"
+)
+
+func TestBuildThroughputFromFinishedTasks(t *testing.T) {
+	now := time.Date(2026, 5, 24, 12, 0, 0, 0, time.UTC)
+	at := func(ago time.Duration) string { return now.Add(-ago).Format("2006-01-02 15:04:05") }
+
+	finished := []*queue.Task{
+		{ItemID: 1, Type: queue.StageEncoding, StartedAt: at(3 * time.Hour), FinishedAt: at(time.Hour)},
+		{ItemID: 2, Type: queue.StageEncoding, StartedAt: at(8 * time.Hour), FinishedAt: at(7 * time.Hour)},
+		{ItemID: 1, Type: queue.StageRipping, StartedAt: at(4 * time.Hour), FinishedAt: at(3*time.Hour + 30*time.Minute)},
+		{ItemID: 2, Type: queue.StageSubtitling, FinishedAt: at(6 * time.Hour)}, // never started
+		{ItemID: 1, Type: queue.StageOrganizing, StartedAt: at(time.Hour), FinishedAt: at(time.Hour)},
+		{ItemID: 2, Type: queue.StageOrganizing, StartedAt: at(5 * time.Hour), FinishedAt: at(5 * time.Hour)},
+		{ItemID: 2, Type: queue.StageOrganizing, StartedAt: at(2 * time.Hour), FinishedAt: at(2 * time.Hour)}, // re-organized
+	}
+	resources := map[string]ResourceStatus{
+		"drive":   {Capacity: 1, Used: 1},
+		"encoder": {Capacity: 2, Used: 1},
+	}
+
+	got := buildThroughput(24*time.Hour, finished, resources)
+	if got.ItemsCompleted != 2 || got.WindowHours != 24 {
+		t.Fatalf("completed = %d over %.0fh, want 2 over 24h", got.ItemsCompleted, got.WindowHours)
+	}
+	if want := 2.0 / 24; got.ItemsPerHour != want {
+		t.Errorf("items/hour = %v, want %v", got.ItemsPerHour, want)
+	}
+	want := []StageThroughput{
+		{Stage: string(queue.StageRipping), Completed: 1, AvgSeconds: 1800},
+		{Stage: string(queue.StageEncoding), Completed: 2, AvgSeconds: 5400},
+		{Stage: string(queue.StageOrganizing), Completed: 3, AvgSeconds: 0},
+	}
+	if len(got.Stages) != len(want) {
+		t.Fatalf("stages = %+v, want %+v", got.Stages, want)
+	}
+	for i := range want {
+		if got.Stages[i] != want[i] {
+			t.Errorf("stage %d = %+v, want %+v", i, got.Stages[i], want[i])
+		}
+	}
+	if got.LaneUtilization["drive"] != 1 || got.LaneUtilization["encoder"] != 0.5 {
+		t.Errorf("lane utilization = %v", got.LaneUtilization)
+	}
+}
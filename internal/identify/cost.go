@@ -0,0 +1,103 @@
+package identify
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/five82/spindle/internal/makemkv"
+	"github.com/five82/spindle/internal/ripspec"
+)
+
+const (
+	// referencePixels is the frame area of the cost unit: one second of
+	// 1080p SDR video.
+	referencePixels = 1920 * 1080
+
+	// hdrCostFactor is the extra cost of HDR sources. UHD discs carry HEVC
+	// video, which stands in for HDR since MakeMKV does not report the
+	// dynamic range directly.
+	hdrCostFactor = 1.25
+
+	// makemkvAttrVideoSize is the MakeMKV stream attribute holding the
+	// video frame size ("1920x1080").
+	makemkvAttrVideoSize = 19
+)
+
+// estimateEncodeCost returns the item's relative encode cost in 1080p SDR
+// runtime-second equivalents: runtime scaled by frame area, with a surcharge
+// for HDR. TV sums the episode titles; movies use the main title. The value
+// only orders the encoding lane, so a coarse estimate is enough.
+func estimateEncodeCost(env *ripspec.Envelope, discInfo *makemkv.DiscInfo) float64 {
+	if env == nil || len(env.Titles) == 0 {
+		return 0
+	}
+	titles := []ripspec.Title{env.Titles[0]}
+	if len(env.Episodes) > 0 {
+		titles = titles[:0]
+		for _, ep := range env.Episodes {
+			for _, t := range env.Titles {
+				if t.ID == ep.TitleID {
+					titles = append(titles, t)
+					break
+				}
+			}
+		}
+	}
+
+	var cost float64
+	for _, t := range titles {
+		cost += float64(t.Duration) * titleCostFactor(t.ID, discInfo, env.Metadata.DiscSource)
+	}
+	return cost
+}
+
+// titleCostFactor returns the per-second cost multiplier for a title from
+// its MakeMKV video stream, falling back to the disc source when the scan
+// did not report a frame size.
+func titleCostFactor(titleID int, discInfo *makemkv.DiscInfo, discSource string) float64 {
+	pixels := 0
+	hdr := false
+	if discInfo != nil {
+		for _, ti := range discInfo.Titles {
+			if ti.ID != titleID {
+				continue
+			}
+			for _, tr := range ti.Tracks {
+				if tr.Type != makemkv.TrackTypeVideo {
+					continue
+				}
+				pixels = parseFrameArea(tr.Attributes[makemkvAttrVideoSize])
+				hdr = strings.Contains(strings.ToUpper(tr.CodecID), "HEVC")
+				break
+			}
+			break
+		}
+	}
+	if pixels == 0 {
+		if discSource == "dvd" {
+			pixels = 720 * 480
+		} else {
+			pixels = referencePixels
+		}
+	}
+	factor := float64(pixels) / referencePixels
+	if hdr {
+		factor *= hdrCostFactor
+	}
+	return factor
+}
+
+// parseFrameArea parses a "WIDTHxHEIGHT" frame size, returning 0 when the
+// value is missing or malformed.
+func parseFrameArea(size string) int {
+	w, h, ok := strings.Cut(strings.TrimSpace(size), "x")
+	if !ok {
+		return 0
+	}
+	width, errW := strconv.Atoi(w)
+	height, errH := strconv.Atoi(h)
+	if errW != nil || errH != nil || width <= 0 || height <= 0 {
+		return 0
+	}
+	return width * height
+}
@@ -2,10 +2,11 @@ package identify
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
+	"math"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -17,7 +18,6 @@ import (
 	"github.com/five82/spindle/internal/logs"
 
 	"github.com/five82/spindle/internal/makemkv"
-	"github.com/five82/spindle/internal/mediameta"
 	"github.com/five82/spindle/internal/notify"
 	"github.com/five82/spindle/internal/queue"
 	"github.com/five82/spindle/internal/ripspec"
@@ -64,8 +64,12 @@ type Handler struct {
 	notifier    *notify.Notifier
 	discIDCache *discidcache.Store
 	keydbCat    *keydb.Catalog
+	scanCache   *ScanCache
 }
 
+// scanDiscInfo runs the MakeMKV disc scan; tests replace it.
+var scanDiscInfo = makemkv.Scan
+
 // New creates an identification handler.
 func New(
 	cfg *config.Config,
@@ -83,6 +87,12 @@ func New(
 	}
 }
 
+// SetScanCache makes identification reuse recent MakeMKV scans of the same
+// disc. A nil store disables the cache.
+func (h *Handler) SetScanCache(store *ScanCache) {
+	h.scanCache = store
+}
+
 // IdentifyResult holds the results of disc identification without persistence.
 // Used by both the daemon (via Run) and the CLI identify command.
 type IdentifyResult struct {
@@ -96,13 +106,16 @@ type IdentifyResult struct {
 	MediaHint   string
 	Best        *tmdb.SearchResult
 	AllResults  []tmdb.SearchResult
-	DiscInfo    *makemkv.DiscInfo
-	BDInfo      *BDInfoResult
-	Envelope    ripspec.Envelope
-	Degraded    bool
-	DegradedMsg string
-	Fatal       bool
-	FatalMsg    string
+	// MatchLanguage is the TMDB language, or config.TMDBOriginalLanguage,
+	// whose search produced Best; empty without a TMDB match.
+	MatchLanguage string
+	DiscInfo      *makemkv.DiscInfo
+	BDInfo        *BDInfoResult
+	Envelope      ripspec.Envelope
+	Degraded      bool
+	DegradedMsg   string
+	Fatal         bool
+	FatalMsg      string
 }
 
 // Identify runs the full identification pipeline and returns results
@@ -146,6 +159,12 @@ func (h *Handler) scanDisc(ctx context.Context, item *queue.Item, logger *slog.L
 		)
 	}
 
+	// A recent scan of this disc skips MakeMKV and the settle delay before it.
+	var cached *makemkv.DiscInfo
+	if h.scanCache != nil && item.DiscFingerprint != "" {
+		cached = cachedScan(h.scanCache, item.DiscFingerprint, h.cfg.MakeMKV.MinTitleLength, logger)
+	}
+
 	// Step 2: BDInfo (Blu-ray discs only, non-fatal).
 	if result.DiscSource == "bluray" {
 		var bdErr error
@@ -169,19 +188,32 @@ func (h *Handler) scanDisc(ctx context.Context, item *queue.Item, logger *slog.L
 		}
 
 		// Apply disc_settle_delay between bd_info and MakeMKV scan.
-		if h.cfg.MakeMKV.DiscSettleDelay > 0 {
+		if h.cfg.MakeMKV.DiscSettleDelay > 0 && cached == nil {
 			time.Sleep(time.Duration(h.cfg.MakeMKV.DiscSettleDelay) * time.Second)
 		}
 	}
 
-	// Step 3: MakeMKV scan (always runs -- titles are needed for ripping).
+	// Step 3: MakeMKV scan (titles are needed for ripping), unless cached.
+	if cached != nil {
+		result.DiscInfo = cached
+		return result, nil
+	}
 	var err error
-	result.DiscInfo, err = makemkv.Scan(ctx, h.cfg.MakeMKV.OpticalDrive,
+	result.DiscInfo, err = scanDiscInfo(ctx, h.cfg.MakeMKV.OpticalDrive,
 		time.Duration(h.cfg.MakeMKV.InfoTimeout)*time.Second,
 		h.cfg.MakeMKV.MinTitleLength, logger)
 	if err != nil {
 		return nil, fmt.Errorf("makemkv scan: %w", err)
 	}
+	if h.scanCache != nil && item.DiscFingerprint != "" {
+		if err := storeScan(h.scanCache, h.cfg.MakeMKV.OpticalDrive, item.DiscFingerprint, h.cfg.MakeMKV.MinTitleLength, result.DiscInfo); err != nil {
+			logger.Warn("disc scan cache write failed",
+				"event_type", "cache_write_error",
+				"error_hint", err.Error(),
+				"impact", "next identification of this disc rescans it",
+			)
+		}
+	}
 
 	return result, nil
 }
@@ -205,6 +237,12 @@ func (h *Handler) resolveMetadata(ctx context.Context, item *queue.Item, result
 	mediaHint := detectMediaTypeHint(result.RawTitle)
 	result.MediaHint = mediaHint
 
+	// An operator's TMDB pin replaces both the disc ID cache and the search,
+	// so a corrected match is not re-guessed on rerun.
+	if opts := itemOptions(item); opts.PinnedTMDBID > 0 && h.tmdbClient != nil {
+		return h.resolvePinned(ctx, item, result, opts, logger)
+	}
+
 	discID := ""
 	if result.BDInfo != nil {
 		discID = strings.TrimSpace(result.BDInfo.DiscID)
@@ -275,41 +313,32 @@ func (h *Handler) resolveMetadata(ctx context.Context, item *queue.Item, result
 		)
 	}
 
-	var err error
-	switch mediaHint {
-	case "tv":
+	switch {
+	case h.tmdbClient == nil:
+		result.Best = offlineSearchResult(result.QueryTitle, result.SearchYear, mediaHint)
+		logger.Info("TMDB search stubbed",
+			"decision_type", logs.DecisionTMDBSearch,
+			"decision_result", "offline",
+			"decision_reason", "offline mode: TMDB client disabled",
+		)
+	case mediaHint == "tv":
 		logger.Info("media type hint detected",
 			"decision_type", logs.DecisionTMDBSearch,
 			"decision_result", "tv",
 			"decision_reason", fmt.Sprintf("raw_title=%q", result.RawTitle),
 		)
-		yearStr := ""
-		if result.SearchYear > 0 {
-			yearStr = strconv.Itoa(result.SearchYear)
-		}
-		result.AllResults, err = h.tmdbClient.SearchTV(ctx, result.QueryTitle, yearStr)
-		if err != nil {
-			return fmt.Errorf("tmdb search (tv): %w", err)
-		}
-		result.Best = tmdb.SelectBestResult(result.AllResults, result.QueryTitle, result.SearchYear, 5, logger)
-		if result.Best == nil {
-			logger.Info("TV-hinted search found no match, falling back to multi",
-				"decision_type", logs.DecisionTMDBSearch,
-				"decision_result", "fallback_multi",
-				"decision_reason", "no tv match above threshold",
-			)
-			result.AllResults, err = h.tmdbClient.SearchMulti(ctx, result.QueryTitle)
-			if err != nil {
-				return fmt.Errorf("tmdb search (multi fallback): %w", err)
-			}
-			result.Best = tmdb.SelectBestResult(result.AllResults, result.QueryTitle, result.SearchYear, 5, logger)
+		if err := h.searchTVHinted(ctx, logger, result); err != nil {
+			return err
 		}
 	default:
-		result.AllResults, err = h.tmdbClient.SearchMulti(ctx, result.QueryTitle)
-		if err != nil {
-			return fmt.Errorf("tmdb search: %w", err)
+		if err := h.searchTMDB(ctx, logger, result); err != nil {
+			return err
+		}
+	}
+	if h.tmdbClient != nil {
+		if err := h.searchFallbackLanguages(ctx, logger, result); err != nil {
+			return err
 		}
-		result.Best = tmdb.SelectBestResult(result.AllResults, result.QueryTitle, result.SearchYear, 5, logger)
 	}
 	if result.Best == nil {
 		impact := "item flagged for review"
@@ -323,6 +352,7 @@ func (h *Handler) resolveMetadata(ctx context.Context, item *queue.Item, result
 		)
 		item.AppendReviewReason("TMDB: no confident match found")
 		result.Envelope = h.buildFallbackEnvelope(ctx, logger, item, result.DiscInfo)
+		result.Envelope.Attributes.MatchCandidates = matchCandidates(result.AllResults, result.QueryTitle, result.SearchYear, h.reviewCandidateLimit())
 		if noTMDBMatchIsFatal(mediaHint) {
 			result.Fatal = true
 			result.FatalMsg = "no TMDB match found for TV disc: " + result.QueryTitle
@@ -349,14 +379,50 @@ func (h *Handler) resolveMetadata(ctx context.Context, item *queue.Item, result
 			"decision_reason", "empty media type from search result",
 		)
 	}
+	h.tmdbClient.FillUntranslated(ctx, result.Best)
 	item.DiscTitle = canonicalTitle(*result.Best, result.MediaType, item.DiscTitle, result.DiscInfo)
 
 	// Step 6: Build RipSpec envelope.
 	result.Envelope = h.buildEnvelope(ctx, logger, item, result.DiscInfo, result.Best, result.MediaType, result.DiscSource)
+	result.Envelope.Attributes.MatchCandidates = matchCandidates(result.AllResults, result.QueryTitle, result.SearchYear, h.reviewCandidateLimit())
+	result.Envelope.Attributes.MatchLanguage = result.MatchLanguage
 
 	return nil
 }
 
+// resolvePinned builds the envelope from the item's pinned TMDB title. A
+// failed lookup fails identification rather than falling back to a search
+// that could undo the operator's correction.
+func (h *Handler) resolvePinned(ctx context.Context, item *queue.Item, result *IdentifyResult, opts ripspec.ItemOptions, logger *slog.Logger) error {
+	best, err := h.tmdbClient.GetDetails(ctx, opts.PinnedMediaType, opts.PinnedTMDBID)
+	if err != nil {
+		return fmt.Errorf("tmdb pinned %s %d: %w", opts.PinnedMediaType, opts.PinnedTMDBID, err)
+	}
+	logger.Info("TMDB match pinned",
+		"decision_type", logs.DecisionTMDBMatch,
+		"decision_result", best.DisplayTitle(),
+		"decision_reason", fmt.Sprintf("pinned tmdb_id=%d media_type=%s", best.ID, best.MediaType),
+	)
+	result.Best = best
+	result.MediaType = best.MediaType
+	item.DiscTitle = canonicalTitle(*best, result.MediaType, item.DiscTitle, result.DiscInfo)
+	result.Envelope = h.buildEnvelope(ctx, logger, item, result.DiscInfo, best, result.MediaType, result.DiscSource)
+	return nil
+}
+
+// itemOptions returns the operator options already recorded in the item's
+// rip spec. Items not yet given a rip spec have none.
+func itemOptions(item *queue.Item) ripspec.ItemOptions {
+	if item.RipSpecData == "" {
+		return ripspec.ItemOptions{}
+	}
+	env, err := ripspec.Parse(item.RipSpecData)
+	if err != nil {
+		return ripspec.ItemOptions{}
+	}
+	return env.Options
+}
+
 // Run executes the identification stage.
 func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 	item := sess.Item
@@ -384,18 +450,37 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 
 	result, err := h.Identify(ctx, item, logger)
 	if err != nil {
+		if reason, remedy, ok := makemkv.DiscFailureAdvice(err); ok {
+			logger.Warn("disc failure recognized in MakeMKV output",
+				"event_type", "disc_failure",
+				"error_hint", remedy,
+				"impact", "item failed and flagged for review",
+				"reason", reason,
+			)
+			if mergeErr := sess.MergeAddReviewReason(reason); mergeErr != nil {
+				logger.Warn("review reason persistence failed",
+					"event_type", "review_reason_persist_failed",
+					"error_hint", mergeErr.Error(),
+					"impact", "failure reason only recorded in the error message",
+				)
+			}
+		}
 		return err
 	}
 
 	// Persist envelope.
 	_ = sess.Progress(85, "Phase 3/3 - Finalizing identification")
+	item.EstimatedEncodeCost = estimateEncodeCost(&result.Envelope, result.DiscInfo)
+	// Operator options predate identification (set at ingestion or on a
+	// previous run) and must survive the rebuilt envelope.
+	result.Envelope.Options = sess.Env.Options
 	sess.SetEnvelope(&result.Envelope)
 	if err := h.persistEnvelope(sess); err != nil {
 		return err
 	}
 
 	if result.Fatal {
-		return fmt.Errorf("identification fatal: %s", result.FatalMsg)
+		return stage.Fatal(fmt.Errorf("identification fatal: %s", result.FatalMsg))
 	}
 	if result.Degraded {
 		return &stage.ErrDegraded{Msg: result.DegradedMsg}
@@ -406,7 +491,7 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 	if result.BDInfo != nil {
 		cacheDiscID = strings.TrimSpace(result.BDInfo.DiscID)
 	}
-	if result.Best != nil && h.discIDCache != nil && cacheDiscID != "" {
+	if result.Best != nil && result.Best.ID > 0 && h.discIDCache != nil && cacheDiscID != "" {
 		entry := discidcache.Entry{
 			TMDBID:    result.Best.ID,
 			MediaType: result.MediaType,
@@ -425,7 +510,8 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 
 	// Send notification.
 	msg := item.DisplayTitle() + queue.FormatAlsoProcessing(sess.Store, item.ID)
-	_ = notify.SendLogged(ctx, h.notifier, logger, notify.EventIdentificationComplete,
+	_ = notify.SendItemLogged(ctx, h.notifier, logger, notify.EventIdentificationComplete,
+		notify.Fields{Item: item.ID, Stage: string(queue.StageIdentification)},
 		"Identification Complete: "+item.DisplayTitle(),
 		msg,
 	)
@@ -436,10 +522,90 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 		"media_type", result.MediaType,
 		"tmdb_id", result.Envelope.Metadata.ID,
 		"title", result.Envelope.Metadata.Title,
+		"estimated_encode_cost", item.EstimatedEncodeCost,
 	)
 	return nil
 }
 
+// selectBestResult picks the TMDB match for result.AllResults.
+func (h *Handler) selectBestResult(logger *slog.Logger, result *IdentifyResult) *tmdb.SearchResult {
+	return tmdb.SelectBestResult(result.AllResults, result.QueryTitle, result.SearchYear, h.yearTolerance(), 5, h.matchThreshold(), logger)
+}
+
+// yearTolerance is how far a disc year may be from an exact title's TMDB
+// release year when nothing matches the exact year; 0 disables the fallback.
+func (h *Handler) yearTolerance() int {
+	if h.cfg == nil {
+		return tmdb.DefaultYearTolerance
+	}
+	return h.cfg.TMDB.YearTolerance
+}
+
+// matchThreshold is the score a non-exact TMDB match needs to be accepted
+// without review; unset configs use the TMDB package default.
+func (h *Handler) matchThreshold() float64 {
+	if h.cfg == nil || h.cfg.TMDB.MatchThreshold <= 0 {
+		return tmdb.DefaultMatchThreshold
+	}
+	return h.cfg.TMDB.MatchThreshold
+}
+
+// reviewCandidateLimit is how many TMDB alternatives to keep for review.
+func (h *Handler) reviewCandidateLimit() int {
+	if h.cfg == nil {
+		return 0
+	}
+	return h.cfg.TMDB.ReviewCandidates
+}
+
+// matchCandidates ranks the TMDB search results and keeps the leading ones,
+// with their scores, so a reviewer can pick a different title later. Person
+// results from multi search are not titles and are skipped.
+func matchCandidates(results []tmdb.SearchResult, query string, year, limit int) []ripspec.MatchCandidate {
+	var out []ripspec.MatchCandidate
+	for _, r := range tmdb.RankResults(results, query, year) {
+		if len(out) >= limit {
+			break
+		}
+		if r.ID <= 0 || r.MediaType == "person" {
+			continue
+		}
+		mediaType := r.MediaType
+		if mediaType == "" {
+			// Single-type searches omit media_type; movies carry title, TV name.
+			mediaType = "tv"
+			if r.Title != "" {
+				mediaType = "movie"
+			}
+		}
+		c := r.Candidate(mediaType)
+		c.Score = math.Round(r.Score*1000) / 1000
+		c.ExactMatch = r.ExactMatch
+		out = append(out, c)
+	}
+	return out
+}
+
+// offlineSearchResult returns the deterministic stand-in for a TMDB match
+// used in offline mode. It carries no TMDB ID, so it is never written to the
+// disc ID cache.
+func offlineSearchResult(queryTitle string, year int, mediaHint string) *tmdb.SearchResult {
+	r := &tmdb.SearchResult{MediaType: "movie"}
+	date := ""
+	if year > 0 {
+		date = fmt.Sprintf("%04d-01-01", year)
+	}
+	if mediaHint == "tv" {
+		r.MediaType = "tv"
+		r.Name = queryTitle
+		r.FirstAirDate = date
+	} else {
+		r.Title = queryTitle
+		r.ReleaseDate = date
+	}
+	return r
+}
+
 // resolveTitle implements the title priority chain and returns both the
 // resolved title and the source that was used for observability.
 func (h *Handler) resolveTitle(item *queue.Item, discInfo *makemkv.DiscInfo, bdInfo *BDInfoResult) (string, string) {
@@ -643,16 +809,19 @@ func (h *Handler) buildEnvelope(
 	discSource string,
 ) ripspec.Envelope {
 	metadata := ripspec.Metadata{
-		ID:          best.ID,
-		Title:       best.DisplayTitle(),
-		Overview:    best.Overview,
-		MediaType:   mediaType,
-		Year:        best.Year(),
-		ReleaseDate: best.ReleaseDate,
-		VoteAverage: best.VoteAverage,
-		VoteCount:   best.VoteCount,
-		Movie:       mediaType == "movie",
-		DiscSource:  discSource,
+		ID:           best.ID,
+		Title:        best.DisplayTitle(),
+		Overview:     best.Overview,
+		MediaType:    mediaType,
+		Year:         best.Year(),
+		Genre:        best.Genre(),
+		ReleaseDate:  best.ReleaseDate,
+		VoteAverage:  best.VoteAverage,
+		VoteCount:    best.VoteCount,
+		Movie:        mediaType == "movie",
+		DiscSource:   discSource,
+		PosterPath:   best.PosterPath,
+		BackdropPath: best.BackdropPath,
 	}
 
 	if best.FirstAirDate != "" {
@@ -673,17 +842,26 @@ func (h *Handler) buildEnvelope(
 }
 
 // createEpisodePlaceholders adds episode entries for selected TV titles.
-// Titles are excluded only on structural evidence (length gate, duplicates,
+// Titles are excluded only on structural evidence (length gates, duplicates,
 // gross outliers, proven play-all composites) or on a strong mismatch against
 // TMDB expected episode runtimes; content identification arbitrates the rest.
 func (h *Handler) createEpisodePlaceholders(ctx context.Context, logger *slog.Logger, env *ripspec.Envelope) {
 	season := env.Metadata.SeasonNumber
 	if season <= 0 {
-		season = 1
+		var inferred bool
+		season, inferred = h.inferSeason(ctx, logger, env.Metadata.ID)
+		if inferred {
+			// Persist it so episode identification fetches the same season.
+			env.Metadata.SeasonNumber = season
+		}
 	}
 
 	expected := h.fetchExpectedEpisodes(ctx, logger, env.Metadata.ID, season)
-	selection := selectTVEpisodeTitles(env.Titles, h.cfg.MakeMKV.MinTitleLength, expected)
+	selection := selectTVEpisodeTitles(env.Titles, tvTitleLimits{
+		MinTitleLength:   h.cfg.MakeMKV.MinTitleLength,
+		MinEpisodeLength: h.cfg.MakeMKV.MinEpisodeLength,
+		MaxEpisodeLength: h.cfg.MakeMKV.MaxEpisodeLength,
+	}, expected)
 	for _, decision := range selection.Decisions {
 		switch {
 		case decision.Selected:
@@ -703,6 +881,16 @@ func (h *Handler) createEpisodePlaceholders(ctx context.Context, logger *slog.Lo
 				"duration", decision.Title.Duration,
 				"min_title_length", h.cfg.MakeMKV.MinTitleLength,
 			)
+		case decision.Reason == "below_min_episode_length", decision.Reason == "above_max_episode_length":
+			logger.Debug("tv title excluded",
+				"decision_type", logs.DecisionTitleSelection,
+				"decision_result", "excluded",
+				"decision_reason", decision.Reason,
+				"title_id", decision.Title.ID,
+				"duration", decision.Title.Duration,
+				"min_episode_length", h.cfg.MakeMKV.MinEpisodeLength,
+				"max_episode_length", h.cfg.MakeMKV.MaxEpisodeLength,
+			)
 		case decision.Reason == "duplicate_title":
 			logger.Info("duplicate TV title skipped",
 				"decision_type", logs.DecisionDuplicateDetection,
@@ -772,6 +960,193 @@ func (h *Handler) createEpisodePlaceholders(ctx context.Context, logger *slog.Lo
 	)
 }
 
+// inferSeason picks the season for a disc whose title names none. A series
+// with a single regular season resolves to it (inferred is true); otherwise
+// season 1 is assumed, as before series lookups existed.
+func (h *Handler) inferSeason(ctx context.Context, logger *slog.Logger, tmdbID int) (season int, inferred bool) {
+	if h.tmdbClient == nil || tmdbID <= 0 {
+		return 1, false
+	}
+	series, err := h.tmdbClient.GetTV(ctx, tmdbID)
+	if err != nil {
+		logger.Warn("tmdb series lookup for season inference failed",
+			"event_type", "tmdb_series_error",
+			"error_hint", err.Error(),
+			"impact", "season 1 assumed for episode placeholders",
+		)
+		return 1, false
+	}
+	regular := series.RegularSeasons()
+	if len(regular) == 1 {
+		logger.Info("season inferred from series",
+			"decision_type", logs.DecisionSeasonInference,
+			"decision_result", strconv.Itoa(regular[0].SeasonNumber),
+			"decision_reason", "series has a single regular season",
+		)
+		return regular[0].SeasonNumber, true
+	}
+	logger.Info("season assumed",
+		"decision_type", logs.DecisionSeasonInference,
+		"decision_result", "1",
+		"decision_reason", fmt.Sprintf("disc title names no season and series has %d", len(regular)),
+	)
+	return 1, false
+}
+
+// searchTVHinted searches TV shows first and falls back to a multi search
+// when no TV result clears the threshold. With tmdb.max_concurrent_searches
+// above one, both searches are issued together; selection still prefers
+// the TV results, so the outcome matches the sequential path.
+func (h *Handler) searchTVHinted(ctx context.Context, logger *slog.Logger, result *IdentifyResult) error {
+	yearStr := ""
+	if result.SearchYear > 0 {
+		yearStr = strconv.Itoa(result.SearchYear)
+	}
+	limit := 1
+	if h.cfg != nil {
+		limit = h.cfg.TMDB.MaxConcurrentSearches
+	}
+	queries := []tmdb.SearchQuery{{Query: result.QueryTitle, Year: yearStr, MediaType: "tv"}}
+	if limit > 1 {
+		queries = append(queries, tmdb.SearchQuery{Query: result.QueryTitle})
+	}
+	outcomes := h.tmdbClient.SearchAll(ctx, queries, limit)
+	if err := outcomes[0].Err; err != nil {
+		return fmt.Errorf("tmdb search (tv): %w", err)
+	}
+	result.AllResults = outcomes[0].Results
+	result.Best = h.selectBestResult(logger, result)
+	if result.Best != nil {
+		return nil
+	}
+
+	logger.Info("TV-hinted search found no match, falling back to multi",
+		"decision_type", logs.DecisionTMDBSearch,
+		"decision_result", "fallback_multi",
+		"decision_reason", "no tv match above threshold",
+	)
+	var multi tmdb.SearchOutcome
+	if len(outcomes) > 1 {
+		multi = outcomes[1]
+	} else {
+		multi.Results, multi.Err = h.tmdbClient.SearchMulti(ctx, result.QueryTitle)
+	}
+	if multi.Err != nil {
+		return fmt.Errorf("tmdb search (multi fallback): %w", multi.Err)
+	}
+	result.AllResults = multi.Results
+	result.Best = h.selectBestResult(logger, result)
+	return nil
+}
+
+// searchTMDB runs a multi search for result's query and selects the best
+// match.
+func (h *Handler) searchTMDB(ctx context.Context, logger *slog.Logger, result *IdentifyResult) error {
+	var err error
+	result.AllResults, err = h.tmdbClient.SearchMulti(ctx, result.QueryTitle)
+	if err != nil {
+		return fmt.Errorf("tmdb search: %w", err)
+	}
+	result.Best = h.selectBestResult(logger, result)
+	return nil
+}
+
+// searchFallbackLanguages retries a search that found no confident match in
+// each of tmdb.fallback_languages in order, and the first language to match
+// wins. config.TMDBOriginalLanguage re-ranks the primary results by their
+// original-language titles without a new request. A match is re-fetched in
+// the configured language so library names stay localized. A failed
+// fallback search is logged and the chain moves on, since the primary search
+// succeeded. result.MatchLanguage records where the match came from.
+func (h *Handler) searchFallbackLanguages(ctx context.Context, logger *slog.Logger, result *IdentifyResult) error {
+	primary := "en-US"
+	if h.cfg != nil && h.cfg.TMDB.Language != "" {
+		primary = h.cfg.TMDB.Language
+	}
+	if result.Best != nil {
+		result.MatchLanguage = primary
+		return nil
+	}
+	if h.cfg == nil {
+		return nil
+	}
+	for _, lang := range h.cfg.TMDB.FallbackLanguages {
+		if lang == primary {
+			continue
+		}
+		try := *result
+		if lang == config.TMDBOriginalLanguage {
+			try.AllResults = make([]tmdb.SearchResult, len(result.AllResults))
+			for i, r := range result.AllResults {
+				try.AllResults[i] = r.WithOriginalTitle()
+			}
+			if best := h.selectBestResult(logger, &try); best != nil {
+				i := slices.IndexFunc(result.AllResults, func(r tmdb.SearchResult) bool {
+					return r.ID == best.ID && r.MediaType == best.MediaType
+				})
+				localized := result.AllResults[i]
+				try.Best = &localized
+			}
+		} else {
+			var err error
+			if try.MediaHint == "tv" {
+				err = h.searchTVHinted(tmdb.WithLanguage(ctx, lang), logger, &try)
+			} else {
+				err = h.searchTMDB(tmdb.WithLanguage(ctx, lang), logger, &try)
+			}
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				logger.Warn("TMDB fallback language search failed",
+					"event_type", "tmdb_language_fallback_failed",
+					"error_hint", err.Error(),
+					"impact", "language skipped in the fallback chain",
+					"language", lang,
+				)
+				continue
+			}
+			if try.Best != nil {
+				h.localizeMatch(ctx, logger, &try)
+			}
+		}
+		if try.Best == nil {
+			continue
+		}
+		logger.Info("TMDB match found in fallback language",
+			"decision_type", logs.DecisionTMDBSearch,
+			"decision_result", "language_fallback",
+			"decision_reason", fmt.Sprintf("no match in %s; matched in %s", primary, lang),
+			"language", lang,
+		)
+		*result = try
+		result.MatchLanguage = lang
+		return nil
+	}
+	return nil
+}
+
+// localizeMatch replaces a match found in a fallback language with its
+// details in the configured language. A failed lookup keeps the fallback
+// result, so the match is still used under that language's title.
+func (h *Handler) localizeMatch(ctx context.Context, logger *slog.Logger, result *IdentifyResult) {
+	mediaType := result.Best.MediaType
+	if mediaType != "tv" {
+		mediaType = "movie"
+	}
+	localized, err := h.tmdbClient.GetDetails(ctx, mediaType, result.Best.ID)
+	if err != nil {
+		logger.Warn("TMDB match not localized",
+			"event_type", "tmdb_localize_failed",
+			"error_hint", err.Error(),
+			"impact", "library names use the fallback language's title",
+			"tmdb_id", result.Best.ID,
+		)
+		return
+	}
+	result.Best = localized
+}
+
 // fetchExpectedEpisodes retrieves the TMDB season episode list so title
 // selection can bound and rank candidates against expected runtimes. Failures
 // degrade selection to structural evidence only; they never fail the stage.
@@ -818,6 +1193,10 @@ func (h *Handler) buildEnvelopeFromCache(ctx context.Context, logger *slog.Logge
 	if entry.MediaType == "tv" {
 		metadata.ShowTitle = entry.Title
 	}
+	needsGenre := entry.MediaType == "movie" && strings.Contains(h.cfg.Library.MovieDirTemplate(), "{genre}")
+	if h.cfg.Library.DownloadArtwork || needsGenre {
+		h.fillCachedDetails(ctx, logger, &metadata)
+	}
 
 	env := h.newEnvelope(logger, item, discInfo, metadata)
 
@@ -829,6 +1208,28 @@ func (h *Handler) buildEnvelopeFromCache(ctx context.Context, logger *slog.Logge
 	return env
 }
 
+// fillCachedDetails looks up the artwork and genre of a disc ID cache hit,
+// which skipped the search that normally carries them. A failed lookup
+// leaves the item without local artwork and filed under "Unknown Genre".
+func (h *Handler) fillCachedDetails(ctx context.Context, logger *slog.Logger, metadata *ripspec.Metadata) {
+	if h.tmdbClient == nil {
+		return
+	}
+	details, err := h.tmdbClient.GetDetails(ctx, metadata.MediaType, metadata.ID)
+	if err != nil {
+		logger.Warn("TMDB details lookup for cached disc failed",
+			"event_type", "tmdb_details_error",
+			"error_hint", err.Error(),
+			"impact", "library item has no local artwork or genre",
+			"tmdb_id", metadata.ID,
+		)
+		return
+	}
+	metadata.PosterPath = details.PosterPath
+	metadata.BackdropPath = details.BackdropPath
+	metadata.Genre = details.Genre()
+}
+
 // buildFallbackEnvelope constructs an envelope with unknown media type for review.
 func (h *Handler) buildFallbackEnvelope(ctx context.Context, logger *slog.Logger, item *queue.Item, discInfo *makemkv.DiscInfo) ripspec.Envelope {
 	title := item.DiscTitle
@@ -858,20 +1259,11 @@ func (h *Handler) buildFallbackEnvelope(ctx context.Context, logger *slog.Logger
 // persistEnvelope updates the item's metadata_json and persists the RipSpec.
 func (h *Handler) persistEnvelope(sess *stage.Session) error {
 	// Update metadata_json on the item.
-	meta := mediameta.Metadata{
-		ID:           sess.Env.Metadata.ID,
-		Title:        sess.Env.Metadata.Title,
-		MediaType:    sess.Env.Metadata.MediaType,
-		ShowTitle:    sess.Env.Metadata.ShowTitle,
-		Year:         sess.Env.Metadata.Year,
-		SeasonNumber: sess.Env.Metadata.SeasonNumber,
-		Movie:        sess.Env.Metadata.Movie,
-	}
-	metaJSON, err := json.Marshal(meta)
+	metaJSON, err := sess.Env.Metadata.ItemMetadataJSON()
 	if err != nil {
-		return fmt.Errorf("marshal metadata: %w", err)
+		return err
 	}
-	sess.Item.MetadataJSON = string(metaJSON)
+	sess.Item.MetadataJSON = metaJSON
 
 	// Persist RipSpec via the stage session.
 	return sess.Save()
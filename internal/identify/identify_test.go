@@ -4,10 +4,16 @@ import (
 	"context"
 	"io"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/five82/spindle/internal/config"
 	"github.com/five82/spindle/internal/discidcache"
@@ -408,6 +414,41 @@ func TestBuildEnvelopeFromCache(t *testing.T) {
 		}
 	})
 
+	t.Run("artwork fetched for cache hit", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/movie/42" {
+				t.Errorf("path = %s, want /movie/42", r.URL.Path)
+			}
+			_, _ = w.Write([]byte(`{"id":42,"title":"Cached Movie","poster_path":"/p.jpg","backdrop_path":"/b.jpg"}`))
+		}))
+		defer srv.Close()
+		h := &Handler{cfg: &config.Config{}, tmdbClient: tmdb.New("key", srv.URL, "", nil)}
+		h.cfg.Library.DownloadArtwork = true
+		entry := &discidcache.Entry{TMDBID: 42, MediaType: "movie", Title: "Cached Movie", Year: "2022"}
+
+		env := h.buildEnvelopeFromCache(context.Background(), discardLogger(), &queue.Item{DiscFingerprint: "cached-fp"}, entry, nil, "bluray")
+
+		if env.Metadata.PosterPath != "/p.jpg" || env.Metadata.BackdropPath != "/b.jpg" {
+			t.Errorf("artwork = %q, %q; want the TMDB details images", env.Metadata.PosterPath, env.Metadata.BackdropPath)
+		}
+	})
+
+	t.Run("genre fetched for cache hit filed by genre", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"id":42,"title":"Cached Movie","genres":[{"id":878,"name":"Science Fiction"}]}`))
+		}))
+		defer srv.Close()
+		h := &Handler{cfg: &config.Config{}, tmdbClient: tmdb.New("key", srv.URL, "", nil)}
+		h.cfg.Library.MovieStructure = config.MovieStructureByGenre
+		entry := &discidcache.Entry{TMDBID: 42, MediaType: "movie", Title: "Cached Movie", Year: "2022"}
+
+		env := h.buildEnvelopeFromCache(context.Background(), discardLogger(), &queue.Item{DiscFingerprint: "cached-fp"}, entry, nil, "bluray")
+
+		if env.Metadata.Genre != "Science Fiction" {
+			t.Errorf("genre = %q, want the TMDB details genre", env.Metadata.Genre)
+		}
+	})
+
 	t.Run("nil discInfo produces empty titles", func(t *testing.T) {
 		h := &Handler{cfg: &config.Config{}}
 		item := &queue.Item{DiscFingerprint: "cached-fp"}
@@ -1083,7 +1124,7 @@ func TestSelectTVEpisodeTitles(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := selectTVEpisodeTitles(tt.titles, tt.minTitleLength, tt.expected)
+			got := selectTVEpisodeTitles(tt.titles, tvTitleLimits{MinTitleLength: tt.minTitleLength}, tt.expected)
 			if got.Ambiguous != tt.wantAmbiguous {
 				t.Fatalf("Ambiguous = %v, want %v (reasons=%v)", got.Ambiguous, tt.wantAmbiguous, got.AmbiguityReasons)
 			}
@@ -1118,6 +1159,47 @@ func TestSelectTVEpisodeTitles(t *testing.T) {
 	}
 }
 
+func TestSelectTVEpisodeTitlesEpisodeLengthLimits(t *testing.T) {
+	titles := []ripspec.Title{
+		{ID: 0, Duration: 2700, SegmentMap: "0"},
+		{ID: 1, Duration: 240, SegmentMap: "1"},
+		{ID: 2, Duration: 2650, SegmentMap: "2"},
+		{ID: 3, Duration: 14400, SegmentMap: "3"},
+	}
+	limits := tvTitleLimits{MinTitleLength: 120, MinEpisodeLength: 300, MaxEpisodeLength: 10800}
+	got := selectTVEpisodeTitles(titles, limits, nil)
+
+	var gotIDs []int
+	for _, title := range got.SelectedTitles {
+		gotIDs = append(gotIDs, title.ID)
+	}
+	if !reflect.DeepEqual(gotIDs, []int{0, 2}) {
+		t.Fatalf("SelectedTitles IDs = %v, want [0 2]", gotIDs)
+	}
+	wantReasons := map[int]string{
+		1: "below_min_episode_length",
+		3: "above_max_episode_length",
+	}
+	for _, decision := range got.Decisions {
+		want, ok := wantReasons[decision.Title.ID]
+		if !ok {
+			continue
+		}
+		if decision.Selected || decision.Reason != want {
+			t.Fatalf("title %d: selected=%v reason=%q, want excluded with %q", decision.Title.ID, decision.Selected, decision.Reason, want)
+		}
+	}
+
+	// Without a maximum the long title becomes a candidate again.
+	limits.MaxEpisodeLength = 0
+	got = selectTVEpisodeTitles(titles, limits, nil)
+	for _, decision := range got.Decisions {
+		if decision.Title.ID == 3 && decision.Reason == "above_max_episode_length" {
+			t.Fatal("title 3 excluded by max_episode_length with no maximum configured")
+		}
+	}
+}
+
 func TestCreateEpisodePlaceholders_TNGLikeSelection(t *testing.T) {
 	h := &Handler{cfg: &config.Config{}}
 	h.cfg.MakeMKV.MinTitleLength = 120
@@ -1154,3 +1236,424 @@ func TestCreateEpisodePlaceholders_TNGLikeSelection(t *testing.T) {
 		}
 	}
 }
+
+func TestResolveMetadata_Offline(t *testing.T) {
+	h := &Handler{cfg: &config.Config{Offline: true}}
+	h.cfg.MakeMKV.MinTitleLength = 120
+
+	t.Run("movie", func(t *testing.T) {
+		item := &queue.Item{DiscTitle: "Munich (2005)", DiscFingerprint: "fp1"}
+		result := &IdentifyResult{DiscInfo: &makemkv.DiscInfo{
+			Titles: []makemkv.TitleInfo{{ID: 0, Name: "Main", Duration: 9000}},
+		}}
+		if err := h.resolveMetadata(context.Background(), item, result, discardLogger()); err != nil {
+			t.Fatalf("resolveMetadata: %v", err)
+		}
+		if result.Degraded || result.Fatal {
+			t.Fatalf("offline resolve should succeed, got degraded=%v fatal=%v", result.Degraded, result.Fatal)
+		}
+		if result.Best == nil || result.Best.ID != 0 {
+			t.Fatalf("Best = %+v, want stub with ID 0", result.Best)
+		}
+		if result.MediaType != "movie" {
+			t.Errorf("MediaType = %q, want movie", result.MediaType)
+		}
+		if item.DiscTitle != "Munich (2005)" {
+			t.Errorf("DiscTitle = %q, want %q", item.DiscTitle, "Munich (2005)")
+		}
+		if result.Envelope.Metadata.Title != "Munich" {
+			t.Errorf("Metadata.Title = %q, want Munich", result.Envelope.Metadata.Title)
+		}
+	})
+
+	t.Run("tv", func(t *testing.T) {
+		item := &queue.Item{DiscTitle: "Breaking Bad Season 2"}
+		result := &IdentifyResult{DiscInfo: &makemkv.DiscInfo{
+			Titles: []makemkv.TitleInfo{
+				{ID: 0, Name: "Title 1", Duration: 2700},
+				{ID: 1, Name: "Title 2", Duration: 3000},
+			},
+		}}
+		if err := h.resolveMetadata(context.Background(), item, result, discardLogger()); err != nil {
+			t.Fatalf("resolveMetadata: %v", err)
+		}
+		if result.MediaType != "tv" {
+			t.Errorf("MediaType = %q, want tv", result.MediaType)
+		}
+		if len(result.Envelope.Episodes) != 2 {
+			t.Errorf("len(Episodes) = %d, want 2", len(result.Envelope.Episodes))
+		}
+	})
+}
+
+func TestMatchCandidatesPersistRankedList(t *testing.T) {
+	results := []tmdb.SearchResult{
+		{ID: 1, Title: "Heat Wave", MediaType: "movie", ReleaseDate: "2001-06-01", VoteAverage: 9.0, VoteCount: 900},
+		{ID: 2, Name: "Heat", MediaType: "person"},
+		{ID: 3, Title: "Heat", MediaType: "movie", ReleaseDate: "1986-03-14", VoteAverage: 5.0, VoteCount: 80},
+		{ID: 4, Title: "Heat", MediaType: "movie", ReleaseDate: "1995-12-15", VoteAverage: 7.9, VoteCount: 7000},
+		{ID: 5, Title: "Heat Lightning", ReleaseDate: "1934-03-03", VoteAverage: 6.0, VoteCount: 10},
+	}
+
+	got := matchCandidates(results, "Heat", 1995, 3)
+	gotIDs := make([]int, 0, len(got))
+	for _, c := range got {
+		gotIDs = append(gotIDs, c.ID)
+	}
+	if want := []int{4, 1, 5}; !slices.Equal(gotIDs, want) {
+		t.Fatalf("candidate IDs = %v, want %v (exact year match first, then by score, persons skipped)", gotIDs, want)
+	}
+	if !got[0].ExactMatch || got[1].ExactMatch {
+		t.Errorf("ExactMatch = %v, %v; want true, false", got[0].ExactMatch, got[1].ExactMatch)
+	}
+	if got[0].Score != 8.79 {
+		t.Errorf("Score = %v, want 8.79", got[0].Score)
+	}
+
+	env := ripspec.Envelope{Version: ripspec.CurrentVersion}
+	env.Attributes.MatchCandidates = got
+	raw, err := env.Encode()
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	parsed, err := ripspec.Parse(raw)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if !reflect.DeepEqual(parsed.Attributes.MatchCandidates, got) {
+		t.Fatalf("persisted candidates = %+v, want %+v", parsed.Attributes.MatchCandidates, got)
+	}
+}
+
+func TestResolveMetadata_FuzzyYearFallback(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search/multi" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"results":[{"id":868,"title":"Tsotsi","media_type":"movie","release_date":"2006-02-24","vote_average":2.8,"vote_count":300}]}`))
+	}))
+	defer srv.Close()
+
+	for _, tc := range []struct {
+		discTitle string
+		tolerance int
+		wantMatch bool
+	}{
+		{"Tsotsi (2005)", 1, true},
+		{"Tsotsi (2005)", 0, false},
+		{"Tsotsi (2003)", 1, false},
+	} {
+		cfg := &config.Config{}
+		cfg.MakeMKV.MinTitleLength = 120
+		cfg.TMDB.YearTolerance = tc.tolerance
+		h := &Handler{cfg: cfg, tmdbClient: tmdb.New("key", srv.URL, "", discardLogger())}
+		item := &queue.Item{DiscTitle: tc.discTitle}
+		result := &IdentifyResult{DiscInfo: &makemkv.DiscInfo{
+			Titles: []makemkv.TitleInfo{{ID: 0, Name: "Main", Duration: 5700}},
+		}}
+		if err := h.resolveMetadata(context.Background(), item, result, discardLogger()); err != nil {
+			t.Fatalf("%s tolerance %d: resolveMetadata: %v", tc.discTitle, tc.tolerance, err)
+		}
+		if got := result.Best != nil && result.Best.ID == 868; got != tc.wantMatch {
+			t.Errorf("%s tolerance %d: Best = %+v, want match %v", tc.discTitle, tc.tolerance, result.Best, tc.wantMatch)
+		}
+		if result.Degraded == tc.wantMatch {
+			t.Errorf("%s tolerance %d: Degraded = %v", tc.discTitle, tc.tolerance, result.Degraded)
+		}
+	}
+}
+
+func TestResolveMetadata_LanguageFallbackChain(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lang := r.URL.Query().Get("language")
+		mu.Lock()
+		requests = append(requests, r.URL.Path+" "+lang)
+		mu.Unlock()
+		switch {
+		case r.URL.Path == "/movie/1":
+			_, _ = w.Write([]byte(`{"id":1,"title":"L'Homme qui voulait savoir","overview":"Un homme cherche sa compagne disparue.","release_date":"1988-10-27","vote_average":7.5,"vote_count":900}`))
+		case r.URL.Path == "/search/multi" && lang == "en-US":
+			_, _ = w.Write([]byte(`{"results":[{"id":1,"title":"The Vanishing","original_title":"Spoorloos","media_type":"movie","release_date":"1988-10-27","vote_average":7.5,"vote_count":900}]}`))
+		case r.URL.Path == "/search/multi":
+			_, _ = w.Write([]byte(`{"results":[{"id":1,"title":"L'Homme qui voulait savoir","original_title":"Spoorloos","overview":"Un homme cherche sa compagne disparue.","media_type":"movie","release_date":"1988-10-27","vote_average":7.5,"vote_count":900}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	for _, tc := range []struct {
+		discTitle    string
+		fallbacks    []string
+		wantLanguage string
+		wantRequests []string
+	}{
+		// The English search matches first, so de-DE is never tried.
+		{"THE VANISHING", []string{"en-US", "de-DE"}, "en-US", []string{"/search/multi fr-FR", "/search/multi en-US", "/movie/1 fr-FR"}},
+		// Only the original title matches; it re-ranks the French results.
+		{"SPOORLOOS", []string{"en-US", "de-DE", config.TMDBOriginalLanguage}, config.TMDBOriginalLanguage, []string{"/search/multi fr-FR", "/search/multi en-US", "/search/multi de-DE"}},
+	} {
+		requests = nil
+		cfg := &config.Config{}
+		cfg.MakeMKV.MinTitleLength = 120
+		cfg.TMDB.Language = "fr-FR"
+		cfg.TMDB.FallbackLanguages = tc.fallbacks
+		h := &Handler{cfg: cfg, tmdbClient: tmdb.New("key", srv.URL, "fr-FR", discardLogger())}
+		item := &queue.Item{DiscTitle: tc.discTitle}
+		result := &IdentifyResult{DiscInfo: &makemkv.DiscInfo{
+			Titles: []makemkv.TitleInfo{{ID: 0, Name: "Main", Duration: 6420}},
+		}}
+		if err := h.resolveMetadata(context.Background(), item, result, discardLogger()); err != nil {
+			t.Fatalf("%s: resolveMetadata: %v", tc.discTitle, err)
+		}
+		if result.Best == nil || result.Best.ID != 1 || result.Degraded {
+			t.Fatalf("%s: Best = %+v, Degraded = %v; want TMDB 1", tc.discTitle, result.Best, result.Degraded)
+		}
+		if result.MatchLanguage != tc.wantLanguage || result.Envelope.Attributes.MatchLanguage != tc.wantLanguage {
+			t.Errorf("%s: match language = %q / %q, want %q", tc.discTitle, result.MatchLanguage, result.Envelope.Attributes.MatchLanguage, tc.wantLanguage)
+		}
+		if item.DiscTitle != "L'Homme qui voulait savoir (1988)" {
+			t.Errorf("%s: DiscTitle = %q, want the French title", tc.discTitle, item.DiscTitle)
+		}
+		if !slices.Equal(requests, tc.wantRequests) {
+			t.Errorf("%s: requests = %q, want %q", tc.discTitle, requests, tc.wantRequests)
+		}
+	}
+}
+
+func TestScanDisc_ReusesCachedScan(t *testing.T) {
+	var scans int
+	orig := scanDiscInfo
+	scanDiscInfo = func(context.Context, string, time.Duration, int, *slog.Logger) (*makemkv.DiscInfo, error) {
+		scans++
+		return &makemkv.DiscInfo{Name: "HEAT", Titles: []makemkv.TitleInfo{{ID: 0, Name: "Main", Duration: 10200, Chapters: 32}}}, nil
+	}
+	t.Cleanup(func() { scanDiscInfo = orig })
+
+	cache, err := OpenScanCache(filepath.Join(t.TempDir(), "scan_cache.json"), time.Hour)
+	if err != nil {
+		t.Fatalf("OpenScanCache: %v", err)
+	}
+	cfg := &config.Config{}
+	cfg.MakeMKV.OpticalDrive = "/dev/spindle-test"
+	cfg.MakeMKV.MinTitleLength = 120
+	h := &Handler{cfg: cfg}
+	h.SetScanCache(cache)
+
+	for range 2 {
+		result, err := h.scanDisc(context.Background(), &queue.Item{DiscFingerprint: "fp1"}, discardLogger())
+		if err != nil {
+			t.Fatalf("scanDisc: %v", err)
+		}
+		if result.DiscInfo == nil || result.DiscInfo.Name != "HEAT" || result.DiscInfo.Titles[0].Chapters != 32 {
+			t.Fatalf("DiscInfo = %+v, want the scanned disc", result.DiscInfo)
+		}
+	}
+	if scans != 1 {
+		t.Fatalf("MakeMKV scans = %d, want 1 with the second identify reusing the cache", scans)
+	}
+
+	// A different disc in the drive is scanned afresh.
+	if _, err := h.scanDisc(context.Background(), &queue.Item{DiscFingerprint: "fp2"}, discardLogger()); err != nil {
+		t.Fatalf("scanDisc: %v", err)
+	}
+	if scans != 2 {
+		t.Fatalf("MakeMKV scans = %d, want a rescan for a new disc", scans)
+	}
+	if cache.Size() != 1 {
+		t.Fatalf("cache size = %d, want the first disc's scan dropped", cache.Size())
+	}
+
+	// A scan made with another title length filter is stale.
+	cfg.MakeMKV.MinTitleLength = 300
+	if _, err := h.scanDisc(context.Background(), &queue.Item{DiscFingerprint: "fp2"}, discardLogger()); err != nil {
+		t.Fatalf("scanDisc: %v", err)
+	}
+	if scans != 3 {
+		t.Fatalf("MakeMKV scans = %d, want a rescan after min_title_length changed", scans)
+	}
+}
+
+func TestResolveMetadata_MatchThresholdRoutesReview(t *testing.T) {
+	// "Heat Wave" is a non-exact match for "Heat" scoring 1 + 7.0/10 = 1.7.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":11,"title":"Heat Wave","media_type":"movie","release_date":"1990-01-01","vote_average":7.0,"vote_count":0}]}`))
+	}))
+	defer srv.Close()
+
+	for _, tc := range []struct {
+		threshold  float64
+		wantAccept bool
+	}{
+		{1.65, true},
+		{1.75, false},
+	} {
+		cfg := &config.Config{}
+		cfg.MakeMKV.MinTitleLength = 120
+		cfg.TMDB.MatchThreshold = tc.threshold
+		h := &Handler{cfg: cfg, tmdbClient: tmdb.New("key", srv.URL, "", discardLogger())}
+		item := &queue.Item{DiscTitle: "HEAT"}
+		result := &IdentifyResult{DiscInfo: &makemkv.DiscInfo{
+			Titles: []makemkv.TitleInfo{{ID: 0, Name: "Main", Duration: 5700}},
+		}}
+		if err := h.resolveMetadata(context.Background(), item, result, discardLogger()); err != nil {
+			t.Fatalf("threshold %g: resolveMetadata: %v", tc.threshold, err)
+		}
+		if got := result.Best != nil && result.Best.ID == 11; got != tc.wantAccept {
+			t.Errorf("threshold %g: Best = %+v, want accepted %v", tc.threshold, result.Best, tc.wantAccept)
+		}
+		if result.Degraded == tc.wantAccept || (item.NeedsReview != 0) == tc.wantAccept {
+			t.Errorf("threshold %g: Degraded = %v, NeedsReview = %d; want review %v", tc.threshold, result.Degraded, item.NeedsReview, !tc.wantAccept)
+		}
+	}
+}
+
+func TestResolveMetadata_PinnedTMDBIDSkipsSearch(t *testing.T) {
+	var searches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/movie/949":
+			_, _ = w.Write([]byte(`{"id":949,"title":"Heat","release_date":"1995-12-15","vote_average":7.9,"vote_count":7000}`))
+		default:
+			searches.Add(1)
+			_, _ = w.Write([]byte(`{"results":[{"id":11,"title":"Heat Wave","media_type":"movie","release_date":"1995-01-01","vote_count":900}]}`))
+		}
+	}))
+	defer srv.Close()
+
+	cfg := &config.Config{}
+	cfg.MakeMKV.MinTitleLength = 120
+	h := &Handler{cfg: cfg, tmdbClient: tmdb.New("key", srv.URL, "", discardLogger())}
+	env := ripspec.Envelope{Version: ripspec.CurrentVersion, Options: ripspec.ItemOptions{PinnedTMDBID: 949, PinnedMediaType: "movie"}}
+	raw, err := env.Encode()
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	item := &queue.Item{DiscTitle: "HEAT_WAVE_1995", RipSpecData: raw}
+	result := &IdentifyResult{DiscInfo: &makemkv.DiscInfo{
+		Titles: []makemkv.TitleInfo{{ID: 0, Name: "Main", Duration: 10200}},
+	}}
+	if err := h.resolveMetadata(context.Background(), item, result, discardLogger()); err != nil {
+		t.Fatalf("resolveMetadata: %v", err)
+	}
+	if n := searches.Load(); n != 0 {
+		t.Errorf("TMDB searched %d times, want pinned lookup only", n)
+	}
+	if result.Best == nil || result.Best.ID != 949 || result.MediaType != "movie" {
+		t.Fatalf("Best = %+v (%s), want pinned movie 949", result.Best, result.MediaType)
+	}
+	if got := result.Envelope.Metadata; got.ID != 949 || got.Title != "Heat" || got.Year != "1995" {
+		t.Errorf("Metadata = %+v, want pinned Heat (1995)", got)
+	}
+	if item.DiscTitle != "Heat (1995)" {
+		t.Errorf("DiscTitle = %q, want %q", item.DiscTitle, "Heat (1995)")
+	}
+}
+
+func TestSearchTVHinted_ConcurrentMatchesSequential(t *testing.T) {
+	var multiCalls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/search/tv":
+			_, _ = w.Write([]byte(`{"results":[]}`))
+		case "/search/multi":
+			multiCalls.Add(1)
+			_, _ = w.Write([]byte(`{"results":[{"id":1396,"name":"Breaking Bad","media_type":"tv","first_air_date":"2008-01-20","vote_average":8.9,"vote_count":14000}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	client := tmdb.New("key", srv.URL, "", discardLogger())
+
+	for _, limit := range []int{1, 2} {
+		multiCalls.Store(0)
+		cfg := &config.Config{}
+		cfg.TMDB.MaxConcurrentSearches = limit
+		h := &Handler{cfg: cfg, tmdbClient: client}
+		result := &IdentifyResult{QueryTitle: "Breaking Bad"}
+		if err := h.searchTVHinted(context.Background(), discardLogger(), result); err != nil {
+			t.Fatalf("limit %d: searchTVHinted: %v", limit, err)
+		}
+		if result.Best == nil || result.Best.ID != 1396 {
+			t.Fatalf("limit %d: Best = %+v, want multi fallback match 1396", limit, result.Best)
+		}
+		if got := multiCalls.Load(); got != 1 {
+			t.Errorf("limit %d: multi searches = %d, want 1", limit, got)
+		}
+	}
+}
+
+func TestInferSeason(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tv/10":
+			_, _ = w.Write([]byte(`{"id":10,"seasons":[{"season_number":0},{"season_number":2,"episode_count":8}]}`))
+		case "/tv/20":
+			_, _ = w.Write([]byte(`{"id":20,"seasons":[{"season_number":1},{"season_number":2}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	h := &Handler{cfg: &config.Config{}, tmdbClient: tmdb.New("key", srv.URL, "", discardLogger())}
+
+	tests := []struct {
+		name         string
+		tmdbID       int
+		wantSeason   int
+		wantInferred bool
+	}{
+		{"single regular season", 10, 2, true},
+		{"multiple seasons", 20, 1, false},
+		{"lookup fails", 30, 1, false},
+		{"no tmdb id", 0, 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			season, inferred := h.inferSeason(context.Background(), discardLogger(), tt.tmdbID)
+			if season != tt.wantSeason || inferred != tt.wantInferred {
+				t.Fatalf("inferSeason = %d, %v; want %d, %v", season, inferred, tt.wantSeason, tt.wantInferred)
+			}
+		})
+	}
+}
+
+func TestEstimateEncodeCost(t *testing.T) {
+	videoTrack := func(size, codec string) []makemkv.Track {
+		return []makemkv.Track{{Type: makemkv.TrackTypeVideo, CodecID: codec, Attributes: map[int]string{19: size}}}
+	}
+
+	t.Run("movie scales by frame area and HDR", func(t *testing.T) {
+		env := &ripspec.Envelope{Titles: []ripspec.Title{{ID: 0, Duration: 6000}, {ID: 1, Duration: 300}}}
+		hd := &makemkv.DiscInfo{Titles: []makemkv.TitleInfo{{ID: 0, Tracks: videoTrack("1920x1080", "V_MPEG4/ISO/AVC")}}}
+		uhd := &makemkv.DiscInfo{Titles: []makemkv.TitleInfo{{ID: 0, Tracks: videoTrack("3840x2160", "V_MPEGH/ISO/HEVC")}}}
+		if got := estimateEncodeCost(env, hd); got != 6000 {
+			t.Errorf("1080p cost = %v, want 6000", got)
+		}
+		if got := estimateEncodeCost(env, uhd); got != 6000*4*hdrCostFactor {
+			t.Errorf("UHD HDR cost = %v, want %v", got, 6000*4*hdrCostFactor)
+		}
+	})
+
+	t.Run("tv sums episode titles", func(t *testing.T) {
+		env := &ripspec.Envelope{
+			Metadata: ripspec.Metadata{DiscSource: "dvd"},
+			Titles:   []ripspec.Title{{ID: 0, Duration: 2700}, {ID: 1, Duration: 2700}, {ID: 2, Duration: 120}},
+			Episodes: []ripspec.Episode{{Key: "s01_001", TitleID: 0}, {Key: "s01_002", TitleID: 1}},
+		}
+		want := 5400 * float64(720*480) / referencePixels
+		if got := estimateEncodeCost(env, nil); got != want {
+			t.Errorf("dvd tv cost = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("no titles", func(t *testing.T) {
+		if got := estimateEncodeCost(&ripspec.Envelope{}, nil); got != 0 {
+			t.Errorf("cost = %v, want 0", got)
+		}
+	})
+}
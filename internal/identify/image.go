@@ -0,0 +1,174 @@
+package identify
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/five82/spindle/internal/fingerprint"
+	"github.com/five82/spindle/internal/logs"
+	"github.com/five82/spindle/internal/queue"
+	"github.com/five82/spindle/internal/tmdb"
+)
+
+// DiscImage is a disc extracted to disk: a directory holding a BDMV or
+// VIDEO_TS structure, or an .iso file.
+type DiscImage struct {
+	Name   string // base name, used as the disc label
+	Path   string
+	Source string // "bluray", "dvd", or "unknown" for an ISO
+}
+
+// IsISO reports whether the image is an .iso file rather than an extracted
+// disc structure.
+func (img DiscImage) IsISO() bool {
+	return strings.EqualFold(filepath.Ext(img.Path), ".iso")
+}
+
+// makemkvSource is the makemkvcon source naming the image.
+func (img DiscImage) makemkvSource() string {
+	if img.IsISO() {
+		return "iso:" + img.Path
+	}
+	return "file:" + img.Path
+}
+
+// FindDiscImages lists the disc images directly under dir, sorted by name:
+// subdirectories holding a BDMV or VIDEO_TS structure and .iso files. Other
+// entries are ignored. dir itself is the only image when it holds a disc
+// structure.
+func FindDiscImages(dir string) ([]DiscImage, error) {
+	if source := discStructureSource(dir); source != "" {
+		return []DiscImage{{Name: filepath.Base(filepath.Clean(dir)), Path: dir, Source: source}}, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read image dir: %w", err)
+	}
+	var images []DiscImage
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		switch {
+		case entry.IsDir():
+			if source := discStructureSource(path); source != "" {
+				images = append(images, DiscImage{Name: entry.Name(), Path: path, Source: source})
+			}
+		case strings.EqualFold(filepath.Ext(entry.Name()), ".iso"):
+			name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+			images = append(images, DiscImage{Name: name, Path: path, Source: "unknown"})
+		}
+	}
+	sort.Slice(images, func(i, j int) bool { return images[i].Name < images[j].Name })
+	return images, nil
+}
+
+// discStructureSource returns "bluray" or "dvd" when dir holds that disc
+// structure, and "" otherwise.
+func discStructureSource(dir string) string {
+	if info, err := os.Stat(filepath.Join(dir, "BDMV")); err == nil && info.IsDir() {
+		return "bluray"
+	}
+	if info, err := os.Stat(filepath.Join(dir, "VIDEO_TS")); err == nil && info.IsDir() {
+		return "dvd"
+	}
+	return ""
+}
+
+// IdentifyImage identifies a disc image the way Identify does a disc in the
+// drive: bd_info for an extracted Blu-ray, a MakeMKV scan of the image, then
+// metadata resolution. The scan cache is keyed to the drive and not used.
+func (h *Handler) IdentifyImage(ctx context.Context, item *queue.Item, img DiscImage, logger *slog.Logger) (*IdentifyResult, error) {
+	logger = logs.Default(logger)
+	result := &IdentifyResult{DiscSource: img.Source}
+	if img.Source == "bluray" {
+		bdInfo, err := RunBDInfo(ctx, img.Path, logger)
+		if err != nil {
+			logger.Warn("bd_info failed",
+				"event_type", "bdinfo_error",
+				"error_hint", err.Error(),
+				"impact", "bd_info metadata unavailable",
+			)
+		}
+		result.BDInfo = bdInfo
+	}
+
+	var err error
+	result.DiscInfo, err = scanDiscInfo(ctx, img.makemkvSource(),
+		time.Duration(h.cfg.MakeMKV.InfoTimeout)*time.Second,
+		h.cfg.MakeMKV.MinTitleLength, logger)
+	if err != nil {
+		return nil, fmt.Errorf("makemkv scan: %w", err)
+	}
+	if err := h.resolveMetadata(ctx, item, result, logger); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BatchResult is one disc image's outcome in IdentifyBatch. Err is set
+// when the image could not be identified; Result is nil then.
+type BatchResult struct {
+	Image       DiscImage
+	Fingerprint string
+	DiscTitle   string // canonical title identification settled on
+	Result      *IdentifyResult
+	Err         error
+}
+
+// IdentifyBatch fingerprints and identifies each disc image under dir in
+// turn, without queueing anything. A failed image is recorded in its
+// result and does not stop the batch; only an unreadable dir or
+// cancellation returns an error.
+func (h *Handler) IdentifyBatch(ctx context.Context, dir string, logger *slog.Logger) ([]BatchResult, error) {
+	logger = logs.Default(logger)
+	images, err := FindDiscImages(dir)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]BatchResult, 0, len(images))
+	for _, img := range images {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		imgLogger := logger.With("disc_image", img.Name)
+		br := BatchResult{Image: img}
+		if !img.IsISO() {
+			// An ISO would need mounting to fingerprint; its identification
+			// still works from the scan and bd_info.
+			if fp, err := fingerprint.Generate(img.Path, imgLogger); err == nil {
+				br.Fingerprint = fp
+			} else {
+				imgLogger.Warn("image fingerprint failed",
+					"event_type", "fingerprint_error",
+					"error_hint", err.Error(),
+					"impact", "identification proceeds without a fingerprint",
+				)
+			}
+		}
+		item := &queue.Item{DiscTitle: img.Name, DiscFingerprint: br.Fingerprint}
+		br.Result, br.Err = h.IdentifyImage(ctx, item, img, imgLogger)
+		br.DiscTitle = item.DiscTitle
+		results = append(results, br)
+	}
+	return results, nil
+}
+
+// BestScore returns the match score tmdb.RankResults gives the selected
+// TMDB result; ok is false when there is no ranked selection (no match, or
+// a disc ID cache or pinned hit).
+func (r *IdentifyResult) BestScore() (score float64, ok bool) {
+	if r == nil || r.Best == nil {
+		return 0, false
+	}
+	for _, ranked := range tmdb.RankResults(r.AllResults, r.QueryTitle, r.SearchYear) {
+		if ranked.ID == r.Best.ID && ranked.MediaType == r.Best.MediaType {
+			return ranked.Score, true
+		}
+	}
+	return 0, false
+}
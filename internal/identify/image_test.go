@@ -0,0 +1,123 @@
+package identify
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/five82/spindle/internal/config"
+	"github.com/five82/spindle/internal/makemkv"
+	"github.com/five82/spindle/internal/tmdb"
+)
+
+// writeFixture creates dir/rel with placeholder content.
+func writeFixture(t *testing.T, dir, rel string) {
+	t.Helper()
+	path := filepath.Join(dir, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(rel), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindDiscImages(t *testing.T) {
+	dir := t.TempDir()
+	writeFixture(t, dir, "HEAT/BDMV/index.bdmv")
+	writeFixture(t, dir, "ALIEN/VIDEO_TS/VIDEO_TS.IFO")
+	writeFixture(t, dir, "GATTACA.iso")
+	writeFixture(t, dir, "notes/readme.txt")
+	writeFixture(t, dir, "cover.jpg")
+
+	images, err := FindDiscImages(dir)
+	if err != nil {
+		t.Fatalf("FindDiscImages: %v", err)
+	}
+	var got []string
+	for _, img := range images {
+		got = append(got, img.Name+"="+img.Source+"="+img.makemkvSource()[:4])
+	}
+	want := "ALIEN=dvd=file GATTACA=unknown=iso: HEAT=bluray=file"
+	if strings.Join(got, " ") != want {
+		t.Fatalf("images = %v, want %s", got, want)
+	}
+
+	// A disc folder given directly is the only image.
+	single, err := FindDiscImages(filepath.Join(dir, "HEAT"))
+	if err != nil || len(single) != 1 || single[0].Name != "HEAT" || single[0].Source != "bluray" {
+		t.Fatalf("single image = %+v, %v", single, err)
+	}
+}
+
+func TestIdentifyBatchReportsEachImage(t *testing.T) {
+	dir := t.TempDir()
+	writeFixture(t, dir, "HEAT/BDMV/index.bdmv")
+	writeFixture(t, dir, "HEAT/BDMV/PLAYLIST/00800.mpls")
+	writeFixture(t, dir, "ALIEN/VIDEO_TS/VIDEO_TS.IFO")
+	writeFixture(t, dir, "BROKEN.iso")
+
+	orig := scanDiscInfo
+	scanDiscInfo = func(_ context.Context, source string, _ time.Duration, _ int, _ *slog.Logger) (*makemkv.DiscInfo, error) {
+		name := strings.TrimSuffix(filepath.Base(source), ".iso")
+		if name == "BROKEN" {
+			return nil, errors.New("no usable titles")
+		}
+		return &makemkv.DiscInfo{Name: name, Titles: []makemkv.TitleInfo{{ID: 0, Name: name, Duration: 6600}}}, nil
+	}
+	t.Cleanup(func() { scanDiscInfo = orig })
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.ToLower(r.URL.Query().Get("query")) {
+		case "heat":
+			_, _ = w.Write([]byte(`{"results":[{"id":949,"title":"Heat","media_type":"movie","release_date":"1995-12-15","vote_average":7.9,"vote_count":7000}]}`))
+		case "alien":
+			_, _ = w.Write([]byte(`{"results":[{"id":348,"title":"Alien","media_type":"movie","release_date":"1979-05-25","vote_average":8.1,"vote_count":15000}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"results":[]}`))
+		}
+	}))
+	defer srv.Close()
+
+	cfg := &config.Config{}
+	cfg.MakeMKV.MinTitleLength = 120
+	h := &Handler{cfg: cfg, tmdbClient: tmdb.New("key", srv.URL, "", discardLogger())}
+
+	results, err := h.IdentifyBatch(context.Background(), dir, discardLogger())
+	if err != nil {
+		t.Fatalf("IdentifyBatch: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("results = %d, want one per image", len(results))
+	}
+	byName := make(map[string]BatchResult)
+	for _, br := range results {
+		byName[br.Image.Name] = br
+	}
+
+	for name, wantID := range map[string]int{"HEAT": 949, "ALIEN": 348} {
+		br := byName[name]
+		if br.Err != nil || br.Result == nil || br.Result.Best == nil || br.Result.Best.ID != wantID {
+			t.Fatalf("%s = %+v, want TMDB %d", name, br, wantID)
+		}
+		if br.Fingerprint == "" {
+			t.Errorf("%s fingerprint is empty", name)
+		}
+		if score, ok := br.Result.BestScore(); !ok || score <= 0 {
+			t.Errorf("%s score = %v, %v; want a positive match score", name, score, ok)
+		}
+	}
+	if byName["HEAT"].Result.DiscSource != "bluray" || byName["ALIEN"].Result.DiscSource != "dvd" {
+		t.Errorf("disc sources = %q/%q, want bluray/dvd", byName["HEAT"].Result.DiscSource, byName["ALIEN"].Result.DiscSource)
+	}
+	if broken := byName["BROKEN"]; broken.Err == nil || broken.Result != nil {
+		t.Fatalf("BROKEN = %+v, want a recorded scan failure", broken)
+	}
+}
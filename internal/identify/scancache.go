@@ -0,0 +1,73 @@
+package identify
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/five82/spindle/internal/jsoncache"
+	"github.com/five82/spindle/internal/logs"
+	"github.com/five82/spindle/internal/makemkv"
+)
+
+// scanEntry is one cached disc scan. MinLength is the title length filter
+// the scan ran with; a scan made with a different filter is a miss.
+type scanEntry struct {
+	Device    string            `json:"device"`
+	MinLength int               `json:"min_length"`
+	Info      *makemkv.DiscInfo `json:"info"`
+}
+
+// ScanCache is a JSON file-backed cache of MakeMKV disc scans keyed on disc
+// fingerprint, so identification reruns skip the slow scan.
+type ScanCache = jsoncache.Store[scanEntry]
+
+// OpenScanCache loads or creates a disc scan cache at path. Scans older than
+// ttl are misses.
+func OpenScanCache(path string, ttl time.Duration) (*ScanCache, error) {
+	return jsoncache.Open[scanEntry](path, ttl)
+}
+
+// cachedScan returns the cached scan for fingerprint when present, made
+// with minLength, and within the TTL.
+func cachedScan(cache *ScanCache, fingerprint string, minLength int, logger *slog.Logger) *makemkv.DiscInfo {
+	entry, ok, expired := cache.Get(fingerprint)
+	switch {
+	case expired:
+		logger.Info("disc scan cache entry expired",
+			"decision_type", logs.DecisionScanCache,
+			"decision_result", "expired",
+			"decision_reason", fmt.Sprintf("older than ttl %s", cache.TTL()),
+			"fingerprint", fingerprint,
+		)
+		return nil
+	case !ok || entry.Info == nil:
+		return nil
+	case entry.MinLength != minLength:
+		logger.Info("disc scan cache entry stale",
+			"decision_type", logs.DecisionScanCache,
+			"decision_result", "stale",
+			"decision_reason", fmt.Sprintf("scanned with min_title_length=%d, want %d", entry.MinLength, minLength),
+			"fingerprint", fingerprint,
+		)
+		return nil
+	}
+	logger.Info("disc scan cache hit",
+		"decision_type", logs.DecisionScanCache,
+		"decision_result", "hit",
+		"decision_reason", "disc scanned within ttl",
+		"fingerprint", fingerprint,
+		"titles", len(entry.Info.Titles),
+	)
+	return entry.Info
+}
+
+// storeScan caches the scan of device's disc under fingerprint, dropping any
+// earlier scan of a different disc in the same device so a disc change
+// invalidates the old scan.
+func storeScan(cache *ScanCache, device, fingerprint string, minLength int, info *makemkv.DiscInfo) error {
+	entry := scanEntry{Device: device, MinLength: minLength, Info: info}
+	return cache.Put(fingerprint, entry, func(_ string, other scanEntry) bool {
+		return other.Device == device
+	})
+}
@@ -12,7 +12,7 @@ import (
 
 // TV title selection keeps every title that could plausibly be an episode and
 // leaves final arbitration to content identification. Titles are dropped only
-// on structural evidence (length gate, duplicates, gross outliers, proven
+// on structural evidence (length gates, duplicates, gross outliers, proven
 // play-all composites) or on a strong mismatch against TMDB expected episode
 // runtimes. Intra-disc runtime statistics order titles; they never drop them
 // on their own.
@@ -71,7 +71,15 @@ type compositeTitle struct {
 	components []tvTitleCandidate
 }
 
-func selectTVEpisodeTitles(titles []ripspec.Title, minTitleLength int, expected []tmdb.Episode) tvTitleSelectionResult {
+// tvTitleLimits are the configured length gates for TV title selection, in
+// seconds. MaxEpisodeLength 0 means no upper bound.
+type tvTitleLimits struct {
+	MinTitleLength   int
+	MinEpisodeLength int
+	MaxEpisodeLength int
+}
+
+func selectTVEpisodeTitles(titles []ripspec.Title, limits tvTitleLimits, expected []tmdb.Episode) tvTitleSelectionResult {
 	result := tvTitleSelectionResult{Decisions: make([]tvTitleDecision, 0, len(titles))}
 	candidates := make([]tvTitleCandidate, 0, len(titles))
 	seen := make(map[string]int)
@@ -80,8 +88,12 @@ func selectTVEpisodeTitles(titles []ripspec.Title, minTitleLength int, expected
 		decision := tvTitleDecision{Title: title}
 		key := dedupKey(title)
 		switch {
-		case title.Duration < minTitleLength:
+		case title.Duration < limits.MinTitleLength:
 			decision.Reason = "below_min_title_length"
+		case title.Duration < limits.MinEpisodeLength:
+			decision.Reason = "below_min_episode_length"
+		case limits.MaxEpisodeLength > 0 && title.Duration > limits.MaxEpisodeLength:
+			decision.Reason = "above_max_episode_length"
 		case key != "":
 			if firstID, dup := seen[key]; dup {
 				decision.Reason = "duplicate_title"
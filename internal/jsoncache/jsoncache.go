@@ -0,0 +1,129 @@
+// Package jsoncache provides the JSON file-backed key/value store behind the
+// TMDB response, disc scan, and commentary transcript caches.
+package jsoncache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// entry is one stored value and when it was written.
+type entry[V any] struct {
+	Value    V         `json:"value"`
+	StoredAt time.Time `json:"stored_at"`
+}
+
+// Store is a JSON file-backed cache. Entries older than the TTL are treated
+// as misses and dropped on the next write; a zero TTL never expires.
+type Store[V any] struct {
+	path    string
+	ttl     time.Duration
+	mu      sync.RWMutex
+	entries map[string]entry[V]
+	now     func() time.Time
+}
+
+// Open loads or creates a cache at path.
+func Open[V any](path string, ttl time.Duration) (*Store[V], error) {
+	s := &Store[V]{
+		path:    path,
+		ttl:     ttl,
+		entries: make(map[string]entry[V]),
+		now:     time.Now,
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		// New cache; persist an empty file.
+		if err := s.persist(); err != nil {
+			return nil, fmt.Errorf("initialize cache: %w", err)
+		}
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read cache: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &s.entries); err != nil {
+		return nil, fmt.Errorf("parse cache: %w", err)
+	}
+
+	return s, nil
+}
+
+// TTL returns how long entries stay valid.
+func (s *Store[V]) TTL() time.Duration {
+	return s.ttl
+}
+
+// Get returns the value stored under key. An entry past the TTL is a miss
+// with expired set, so callers can log why they went back to the source.
+func (s *Store[V]) Get(key string) (value V, ok, expired bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	e, found := s.entries[key]
+	if !found {
+		return value, false, false
+	}
+	if s.expired(e) {
+		return value, false, true
+	}
+	return e.Value, true, false
+}
+
+// Put stores value under key, dropping expired entries and any other entry
+// for which drop returns true. drop may be nil.
+func (s *Store[V]) Put(key string, value V, drop func(key string, value V) bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for k, e := range s.entries {
+		if s.expired(e) || (drop != nil && k != key && drop(k, e.Value)) {
+			delete(s.entries, k)
+		}
+	}
+	s.entries[key] = entry[V]{Value: value, StoredAt: s.now().UTC()}
+	return s.persist()
+}
+
+// Size returns the number of cached entries, including expired ones not yet
+// dropped.
+func (s *Store[V]) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.entries)
+}
+
+func (s *Store[V]) expired(e entry[V]) bool {
+	return s.ttl > 0 && s.now().Sub(e.StoredAt) > s.ttl
+}
+
+// persist writes the cache to disk atomically (write tmp, rename).
+func (s *Store[V]) persist() error {
+	data, err := json.MarshalIndent(s.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal cache: %w", err)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create cache dir: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("write tmp: %w", err)
+	}
+
+	if err := os.Rename(tmp, s.path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("rename tmp: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package jsoncache
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPersistenceAcrossOpenCalls(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.json")
+	store1, err := Open[string](path, time.Hour)
+	if err != nil {
+		t.Fatalf("Open (1): %v", err)
+	}
+	if _, ok, _ := store1.Get("k"); ok {
+		t.Fatal("expected miss on empty cache")
+	}
+	if err := store1.Put("k", "v", nil); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	store2, err := Open[string](path, time.Hour)
+	if err != nil {
+		t.Fatalf("Open (2): %v", err)
+	}
+	if value, ok, _ := store2.Get("k"); !ok || value != "v" {
+		t.Fatalf("Get = %q, %v; want entry to survive reopen", value, ok)
+	}
+}
+
+func TestExpiredEntriesMissAndArePruned(t *testing.T) {
+	store, err := Open[string](filepath.Join(t.TempDir(), "cache.json"), time.Hour)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	if err := store.Put("old", "x", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, ok, expired := store.Get("old"); ok || !expired {
+		t.Fatalf("Get past ttl = ok %v, expired %v; want expired miss", ok, expired)
+	}
+	if err := store.Put("new", "y", nil); err != nil {
+		t.Fatal(err)
+	}
+	if store.Size() != 1 {
+		t.Fatalf("Size = %d, want expired entry pruned on write", store.Size())
+	}
+}
+
+func TestPutDropsSupersededEntries(t *testing.T) {
+	store, err := Open[string](filepath.Join(t.TempDir(), "cache.json"), 0)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	for key, value := range map[string]string{"a1": "sr0", "b1": "sr1"} {
+		if err := store.Put(key, value, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sameDevice := func(_ string, value string) bool { return value == "sr0" }
+	if err := store.Put("a2", "sr0", sameDevice); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := store.Get("a1"); ok {
+		t.Error("superseded entry a1 still cached")
+	}
+	for _, key := range []string{"a2", "b1"} {
+		if _, ok, _ := store.Get(key); !ok {
+			t.Errorf("entry %s dropped, want kept", key)
+		}
+	}
+}
@@ -3,21 +3,30 @@ package logs
 // Decision type constants for structured logging.
 // Use these as the value for "decision_type" in slog calls.
 const (
+	DecisionArtworkDownload          = "artwork_download"
 	DecisionAssetMapping             = "asset_mapping"
+	DecisionAudioDescription         = "audio_description"
 	DecisionAudioRefinement          = "audio_refinement"
 	DecisionAudioRemux               = "audio_remux"
 	DecisionAudioSelection           = "audio_selection"
 	DecisionBDInfoAvailability       = "bdinfo_availability"
 	DecisionBDInfoScan               = "bdinfo_scan"
+	DecisionChapterNames             = "chapter_names"
 	DecisionCommentaryClassification = "commentary_classification"
 	DecisionCommentaryDisposition    = "commentary_disposition"
+	DecisionCommentaryFormat         = "commentary_format"
 	DecisionCommentaryRemapping      = "commentary_remapping"
+	DecisionCommentarySpeechOverlap  = "commentary_speech_overlap"
 	DecisionCommentaryStereoFilter   = "commentary_stereo_filter"
+	DecisionCommentaryStreamCache    = "commentary_stream_cache"
 	DecisionConfigLoad               = "config_load"
 	DecisionContentIDCandidates      = "contentid_candidates"
 	DecisionContentIDMatches         = "contentid_matches"
+	DecisionContentIDTiebreak        = "contentid_llm_tiebreak"
+	DecisionContentIDTranscriptGate  = "contentid_transcript_gate"
 	DecisionCropDetection            = "crop_detection"
 	DecisionDetectGuard              = "detect_guard"
+	DecisionDiscEject                = "disc_eject"
 	DecisionDiscEnqueue              = "disc_enqueue"
 	DecisionDiscEventHandling        = "disc_event_handling"
 	DecisionDiscIDCache              = "disc_id_cache"
@@ -37,27 +46,41 @@ const (
 	DecisionFileProbe                = "file_probe"
 	DecisionFingerprintStrategy      = "fingerprint_strategy"
 	DecisionHallucinationFilter      = "hallucination_filter"
+	DecisionInterlaceDetection       = "interlace_detection"
 	DecisionKeyDBLookup              = "keydb_lookup"
+	DecisionLanePause                = "lane_pause"
+	DecisionLoudnessNormalization    = "loudness_normalization"
 	DecisionMakeMKVSettings          = "makemkv_settings"
 	DecisionMountResolution          = "mount_resolution"
+	DecisionMovieVersions            = "movie_versions"
+	DecisionNFOWrite                 = "nfo_write"
 	DecisionOpenSubtitlesRefSearch   = "opensubtitles_reference_search"
+	DecisionOrganizeCollision        = "organize_collision"
+	DecisionOrganizeDuplicate        = "organize_duplicate"
 	DecisionOrganizeRoute            = "organize_route"
 	DecisionOrganizeSkip             = "organize_skip"
 	DecisionPartialCleanup           = "partial_cleanup"
 	DecisionReferenceDownload        = "reference_download"
 	DecisionReferenceSearch          = "reference_search"
+	DecisionReferenceSync            = "reference_sync"
 	DecisionRipCache                 = "rip_cache"
 	DecisionRipCacheTitles           = "rip_cache_titles"
+	DecisionRipTimeout               = "rip_timeout"
+	DecisionResolutionCheck          = "resolution_check"
+	DecisionScanCache                = "scan_cache"
+	DecisionSeasonInference          = "season_inference"
 	DecisionSidecarSubtitleCopy      = "sidecar_subtitle_copy"
 	DecisionSourceStageSelection     = "source_stage_selection"
 	DecisionSRTValidation            = "srt_validation"
 	DecisionStageExecution           = "stage_execution"
 	DecisionStagingCleanup           = "staging_cleanup"
+	DecisionSubtitleConcurrency      = "subtitle_concurrency"
 	DecisionSubtitleFormatting       = "subtitle_formatting"
 	DecisionSubtitleMux              = "subtitle_mux"
 	DecisionSubtitleRank             = "subtitle_rank"
 	DecisionSubtitleResume           = "subtitle_resume"
 	DecisionSubtitleSkip             = "subtitle_skip"
+	DecisionSubtitleSource           = "subtitle_source"
 	DecisionTitleRefresh             = "title_refresh"
 	DecisionTitleResolution          = "title_resolution"
 	DecisionTitleRip                 = "title_rip"
@@ -65,11 +88,13 @@ const (
 	DecisionTitleSelection           = "title_selection"
 	DecisionTitleSelectionFunnel     = "title_selection_funnel"
 	DecisionTitleSource              = "title_source"
+	DecisionTMDBCache                = "tmdb_cache"
 	DecisionTMDBMatch                = "tmdb_match"
 	DecisionTMDBMatchPreference      = "tmdb_match_preference"
 	DecisionTMDBSearch               = "tmdb_search"
 	DecisionTrackSelect              = "track_select"
 	DecisionTranscriptionAsset       = "transcription_asset"
 	DecisionValidationFailureRoute   = "validation_failure_route"
+	DecisionWatchFolder              = "watch_folder"
 	DecisionYearSource               = "year_source"
 )
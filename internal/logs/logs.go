@@ -2,11 +2,14 @@ package logs
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"sort"
 	"strings"
+	"syscall"
 	"time"
 )
 
@@ -89,3 +92,97 @@ func Tail(path string, limit int) ([]string, error) {
 	copy(result[limit-start:], lines[:start])
 	return result, nil
 }
+
+// Cursor is a resume point in a log file: the byte offset just past the
+// last line returned, plus the identity of the file it belongs to. The
+// daemon log path is a symlink re-pointed on every start, so identity
+// rather than path tells a resumed read whether the offset still applies.
+type Cursor struct {
+	Device uint64
+	Inode  uint64
+	Offset int64
+}
+
+// TailFrom returns the lines written to path since cur, and the cursor to
+// resume from. A zero cursor starts like Tail, with the last limit lines.
+// When path now names a different file (rotation, daemon restart) or the
+// file shrank below the offset, reading restarts at the start of the
+// current file. A final line without its newline is left for the next call,
+// so a line being written is never returned in pieces.
+func TailFrom(path string, cur Cursor, limit int) ([]string, Cursor, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, cur, fmt.Errorf("open log: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, cur, fmt.Errorf("stat log: %w", err)
+	}
+	next := Cursor{}
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		next.Device, next.Inode = uint64(st.Dev), st.Ino
+	}
+
+	if limit <= 0 {
+		limit = 1000
+	}
+	resume := cur != (Cursor{})
+	var start int64
+	if resume && cur.Device == next.Device && cur.Inode == next.Inode && cur.Offset <= info.Size() {
+		start = cur.Offset
+	} else if !resume {
+		if start, err = tailOffset(f, info.Size(), limit); err != nil {
+			return nil, cur, fmt.Errorf("read log: %w", err)
+		}
+	}
+	if _, err := f.Seek(start, io.SeekStart); err != nil {
+		return nil, cur, fmt.Errorf("seek log: %w", err)
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, cur, fmt.Errorf("read log: %w", err)
+	}
+
+	end := bytes.LastIndexByte(data, '\n') + 1
+	next.Offset = start + int64(end)
+	if end == 0 {
+		return nil, next, nil
+	}
+	lines := strings.Split(string(data[:end-1]), "\n")
+	if !resume && len(lines) > limit {
+		lines = lines[len(lines)-limit:]
+	}
+	return lines, next, nil
+}
+
+// tailBlockSize is how much of the file tailOffset reads per step.
+const tailBlockSize = 64 << 10
+
+// tailOffset returns the offset of the first of the last limit complete
+// lines in f, reading backwards from size so a large log is not read in
+// full. The first newline found from the end closes the last complete
+// line; the limit+1th is the one just before the first line wanted.
+func tailOffset(f *os.File, size int64, limit int) (int64, error) {
+	buf := make([]byte, tailBlockSize)
+	newlines := 0
+	for end := size; end > 0; {
+		start := max(end-tailBlockSize, 0)
+		block := buf[:end-start]
+		if _, err := f.ReadAt(block, start); err != nil {
+			return 0, err
+		}
+		for i := len(block) - 1; i >= 0; i-- {
+			if block[i] != '\n' {
+				continue
+			}
+			newlines++
+			if newlines == limit+1 {
+				return start + int64(i) + 1, nil
+			}
+		}
+		end = start
+	}
+	return 0, nil
+}
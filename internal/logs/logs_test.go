@@ -1,9 +1,11 @@
 package logs
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -40,3 +42,115 @@ func TestTailReturnsAllLinesWhenLimitExceedsFile(t *testing.T) {
 		t.Fatalf("Tail = %#v, want %#v", got, want)
 	}
 }
+
+func appendLog(t *testing.T, path, content string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("open log: %v", err)
+	}
+	if _, err := f.WriteString(content); err != nil {
+		t.Fatalf("append log: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("close log: %v", err)
+	}
+}
+
+func TestTailFromResumesWithoutReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spindle.log")
+	appendLog(t, path, "one\ntwo\nthree\n")
+
+	got, cur, err := TailFrom(path, Cursor{}, 2)
+	if err != nil {
+		t.Fatalf("TailFrom: %v", err)
+	}
+	if want := []string{"two", "three"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("initial TailFrom = %#v, want %#v", got, want)
+	}
+
+	// A half-written line is held back until its newline arrives.
+	appendLog(t, path, "four\nfi")
+	got, cur, err = TailFrom(path, cur, 2)
+	if err != nil {
+		t.Fatalf("TailFrom: %v", err)
+	}
+	if want := []string{"four"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("resumed TailFrom = %#v, want %#v", got, want)
+	}
+
+	// Nothing new: nothing replayed.
+	if got, cur, err = TailFrom(path, cur, 2); err != nil || len(got) != 0 {
+		t.Fatalf("idle TailFrom = %#v, %v; want no lines", got, err)
+	}
+
+	appendLog(t, path, "ve\nsix\n")
+	got, _, err = TailFrom(path, cur, 2)
+	if err != nil {
+		t.Fatalf("TailFrom: %v", err)
+	}
+	if want := []string{"five", "six"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("resumed TailFrom = %#v, want %#v", got, want)
+	}
+}
+
+func TestTailFromReadsOnlyTheTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spindle.log")
+	var b strings.Builder
+	for i := range 20000 {
+		fmt.Fprintf(&b, "line %05d\n", i)
+	}
+	b.WriteString("partial")
+	appendLog(t, path, b.String())
+
+	got, cur, err := TailFrom(path, Cursor{}, 3)
+	if err != nil {
+		t.Fatalf("TailFrom: %v", err)
+	}
+	if want := []string{"line 19997", "line 19998", "line 19999"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("TailFrom = %#v, want %#v", got, want)
+	}
+	if info, _ := os.Stat(path); cur.Offset != info.Size()-int64(len("partial")) {
+		t.Fatalf("cursor offset = %d, want just before the partial line", cur.Offset)
+	}
+
+	// A limit larger than the file returns every line.
+	if got, _, err = TailFrom(path, Cursor{}, 50000); err != nil || len(got) != 20000 || got[0] != "line 00000" {
+		t.Fatalf("TailFrom(whole file) = %d lines, %v", len(got), err)
+	}
+}
+
+func TestTailFromFollowsRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "daemon.log")
+	first := filepath.Join(dir, "spindle-1.log")
+	appendLog(t, first, "old one\nold two\n")
+	if err := os.Symlink(first, path); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+	_, cur, err := TailFrom(path, Cursor{}, 10)
+	if err != nil {
+		t.Fatalf("TailFrom: %v", err)
+	}
+
+	// A daemon restart points the symlink at a new file that is already
+	// longer than the old offset; the offset must not be applied to it.
+	second := filepath.Join(dir, "spindle-2.log")
+	appendLog(t, second, "new one\nnew two\nnew three\n")
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("remove symlink: %v", err)
+	}
+	if err := os.Symlink(second, path); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+	got, cur, err := TailFrom(path, cur, 10)
+	if err != nil {
+		t.Fatalf("TailFrom: %v", err)
+	}
+	if want := []string{"new one", "new two", "new three"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("rotated TailFrom = %#v, want %#v", got, want)
+	}
+	if got, _, err = TailFrom(path, cur, 10); err != nil || len(got) != 0 {
+		t.Fatalf("TailFrom after rotation = %#v, %v; want no replay", got, err)
+	}
+}
@@ -0,0 +1,58 @@
+package makemkv
+
+import "time"
+
+// ETA estimation tuning. A rip opens with a burst of key processing and
+// drive spin-up during which PRGV barely moves, then settles into a steady
+// read rate; extrapolating from that opening produces ETAs of many hours.
+// The estimator therefore waits for progress past etaMinPercent and for
+// etaMinSpan of samples after it, and rates only the last etaWindow so an
+// early slow stretch ages out.
+const (
+	etaWindow     = 60 * time.Second
+	etaMinSpan    = 10 * time.Second
+	etaMinPercent = 1.0
+)
+
+type etaSample struct {
+	at      time.Time
+	percent float64
+}
+
+// etaEstimator derives a rip's remaining time from its progress rate over a
+// sliding window.
+type etaEstimator struct {
+	samples []etaSample
+}
+
+// observe records percent at now and returns the estimated time remaining,
+// or 0 while there is not yet enough steady progress to estimate.
+func (e *etaEstimator) observe(now time.Time, percent float64) time.Duration {
+	if percent < etaMinPercent {
+		return 0
+	}
+	if n := len(e.samples); n > 0 && percent < e.samples[n-1].percent {
+		// Progress went backwards (a new pass); start over.
+		e.samples = e.samples[:0]
+	}
+	e.samples = append(e.samples, etaSample{at: now, percent: percent})
+
+	cutoff := now.Add(-etaWindow)
+	drop := 0
+	for drop < len(e.samples)-2 && e.samples[drop+1].at.Before(cutoff) {
+		drop++
+	}
+	e.samples = e.samples[drop:]
+
+	first := e.samples[0]
+	span := now.Sub(first.at)
+	gained := percent - first.percent
+	if span < etaMinSpan || gained <= 0 {
+		return 0
+	}
+	if percent >= 100 {
+		return 0
+	}
+	rate := gained / span.Seconds() // percent per second
+	return time.Duration((100 - percent) / rate * float64(time.Second)).Round(time.Second)
+}
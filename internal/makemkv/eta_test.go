@@ -0,0 +1,76 @@
+package makemkv
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestETAWaitsOutKeyProcessing(t *testing.T) {
+	var e etaEstimator
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Key processing: PRGV sits near zero for a while.
+	for i := range 20 {
+		if eta := e.observe(start.Add(time.Duration(i)*3*time.Second), 0.2); eta != 0 {
+			t.Fatalf("ETA during key processing = %v, want none", eta)
+		}
+	}
+	// First real progress: too little history to extrapolate yet.
+	now := start.Add(60 * time.Second)
+	if eta := e.observe(now, 1.5); eta != 0 {
+		t.Fatalf("ETA on first progress sample = %v, want none", eta)
+	}
+	if eta := e.observe(now.Add(3*time.Second), 2.0); eta != 0 {
+		t.Fatalf("ETA before the minimum span = %v, want none", eta)
+	}
+}
+
+func TestETAStabilizesAsProgressAdvances(t *testing.T) {
+	var e etaEstimator
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := start
+	percent := 1.0
+
+	// A slow opening stretch (0.1% per 3s) followed by the steady read
+	// rate (0.5% per 3s, so 100% takes 10 minutes).
+	for range 20 {
+		e.observe(now, percent)
+		now = now.Add(3 * time.Second)
+		percent += 0.1
+	}
+	var etas []time.Duration
+	for percent < 90 {
+		etas = append(etas, e.observe(now, percent))
+		now = now.Add(3 * time.Second)
+		percent += 0.5
+	}
+
+	if etas[5] == 0 {
+		t.Fatal("expected an ETA once steady progress spans the minimum window")
+	}
+	// Once the slow stretch ages out of the window, the ETA tracks the
+	// steady rate: remaining percent at 6s per percent.
+	for i := 30; i < len(etas); i++ {
+		p := 3.0 + float64(i)*0.5 // percent at sample i
+		want := (100 - p) * 6
+		if got := etas[i].Seconds(); math.Abs(got-want) > 2 {
+			t.Fatalf("sample %d at %.1f%%: ETA %.0fs, want %.0fs", i, p, got, want)
+		}
+		// Stable: each 3s step shortens the estimate by about 3s.
+		if step := (etas[i-1] - etas[i]).Seconds(); step < 1 || step > 5 {
+			t.Fatalf("sample %d: ETA moved %.0fs in a 3s step", i, step)
+		}
+	}
+}
+
+func TestETAResetsWhenProgressRestarts(t *testing.T) {
+	var e etaEstimator
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := range 10 {
+		e.observe(start.Add(time.Duration(i)*3*time.Second), 10+float64(i))
+	}
+	if eta := e.observe(start.Add(30*time.Second), 2); eta != 0 {
+		t.Fatalf("ETA right after progress went backwards = %v, want none", eta)
+	}
+}
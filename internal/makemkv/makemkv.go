@@ -3,6 +3,7 @@ package makemkv
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -10,6 +11,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/five82/spindle/internal/logs"
@@ -42,6 +44,9 @@ type RipProgress struct {
 	Total   int
 	Percent float64
 	Message string
+	// ETA is the estimated time left in this title's rip, from the recent
+	// progress rate; 0 until the rate is steady enough to estimate.
+	ETA time.Duration
 }
 
 // Scan runs makemkvcon info on the given device and parses disc information.
@@ -97,12 +102,19 @@ func Scan(ctx context.Context, device string, timeout time.Duration, minLength i
 	}
 
 	if err := cmd.Wait(); err != nil {
+		var msgs []ripMessage
+		for _, line := range lines {
+			if msg, ok := parseMSG(line); ok {
+				msgs = append(msgs, msg)
+			}
+		}
+		err = withCause(fmt.Errorf("makemkv scan: %w", err), msgs)
 		logger.Error("MakeMKV scan failed",
 			"event_type", "makemkv_scan_error",
 			"error_hint", "makemkvcon exited with error",
 			"error", err,
 		)
-		return nil, fmt.Errorf("makemkv scan: %w", err)
+		return nil, err
 	}
 
 	info := parseRobotOutput(lines)
@@ -139,6 +151,82 @@ type ripMessage struct {
 func (m ripMessage) isError() bool   { return m.flags&msgFlagError != 0 }
 func (m ripMessage) isWarning() bool { return m.flags&msgFlagWarning != 0 }
 
+// ErrDecryption reports that MakeMKV could not decrypt the disc: missing or
+// outdated keys, an expired MakeMKV release, or a drive/disc region
+// mismatch. Retrying the same disc fails the same way until that changes.
+var ErrDecryption = errors.New("disc appears region-locked or requires updated keys")
+
+// ErrReadFailure reports that MakeMKV could not read the disc media, e.g. a
+// scratched or dirty disc. Cleaning the disc and retrying may help.
+var ErrReadFailure = errors.New("disc could not be read")
+
+// MakeMKV message codes that explain a disc failure. msgReadError carries
+// the drive's SCSI sense text as its first parameter; key exchange failures
+// arrive through it too, so its sense text decides the class.
+const (
+	msgReadError         = 2003 // Error '%1' occurred while reading '%2' at offset '%3'
+	msgRegionMismatch    = 2020 // drive region does not match the disc
+	msgAppTooOld         = 5021 // application version is too old
+	msgEvaluationExpired = 5055 // evaluation period has expired
+	msgAACSFailed        = 5069 // AACS: %1
+)
+
+// SCSI sense texts in msgReadError parameters.
+const (
+	senseKeyExchange = "COPY PROTECTION KEY EXCHANGE FAILURE"
+	senseMediumError = "MEDIUM ERROR"
+)
+
+// classifyMessages maps MakeMKV diagnostics to ErrDecryption or
+// ErrReadFailure by message code, or nil when none of them explains a
+// failure. Decryption wins when both appear.
+func classifyMessages(msgs []ripMessage) error {
+	var read bool
+	for _, m := range msgs {
+		switch m.code {
+		case msgRegionMismatch, msgAppTooOld, msgEvaluationExpired, msgAACSFailed:
+			return ErrDecryption
+		case msgReadError:
+			if len(m.params) == 0 {
+				continue
+			}
+			switch sense := strings.ToUpper(m.params[0]); {
+			case strings.Contains(sense, senseKeyExchange):
+				return ErrDecryption
+			case strings.Contains(sense, senseMediumError):
+				read = true
+			}
+		}
+	}
+	if read {
+		return ErrReadFailure
+	}
+	return nil
+}
+
+// DiscFailureAdvice returns a review reason and the operator's remedy for an
+// error wrapping ErrDecryption or ErrReadFailure; ok is false otherwise.
+func DiscFailureAdvice(err error) (reason, remedy string, ok bool) {
+	switch {
+	case errors.Is(err, ErrDecryption):
+		return "Disc appears region-locked or requires updated keys",
+			"update MakeMKV or its beta key and KEYDB.cfg, or check the drive region, then retry", true
+	case errors.Is(err, ErrReadFailure):
+		return "Disc read error",
+			"clean or inspect the disc, then retry", true
+	}
+	return "", "", false
+}
+
+// withCause prefixes err with the disc failure class msgs explain, so
+// callers can test it with errors.Is and operators read the cause first.
+func withCause(err error, msgs []ripMessage) error {
+	if cause := classifyMessages(msgs); cause != nil {
+		return fmt.Errorf("%w: %w", cause, err)
+	}
+	return err
+}
+
 // Rip runs makemkvcon mkv to rip a single title from disc to outputDir.
 // The progress callback, if non-nil, is called with progress updates.
 //
@@ -170,6 +258,7 @@ func Rip(ctx context.Context, device string, titleID int, outputDir string, time
 	existing := snapshotMKVFiles(outputDir)
 
 	cmd := exec.CommandContext(ctx, "makemkvcon", "--robot", "--progress=-same", "mkv", src, titleStr, outputDir, minLenFlag)
+	stopGracefully(cmd)
 
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
@@ -198,12 +287,14 @@ func Rip(ctx context.Context, device string, titleID int, outputDir string, time
 		lastErrorText string
 	)
 
+	var eta etaEstimator
 	scanner := bufio.NewScanner(stdout)
 	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
 	for scanner.Scan() {
 		line := scanner.Text()
 		if progress != nil {
 			if p, ok := parsePRGV(line, titleID); ok {
+				p.ETA = eta.observe(time.Now(), p.Percent)
 				progress(p)
 				continue
 			}
@@ -240,6 +331,20 @@ func Rip(ctx context.Context, device string, titleID int, outputDir string, time
 			}
 		}
 	}
+	if ctx.Err() != nil {
+		_ = cmd.Wait()
+		removed := removePartialRips(outputDir, existing)
+		logger.Info("MakeMKV rip cancelled",
+			"event_type", "makemkv_rip_cancelled",
+			"title_id", titleID,
+			"reason", ctx.Err().Error(),
+			"partial_files_removed", len(removed),
+		)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("makemkv rip: timed out after %s: %w", timeout, ctx.Err())
+		}
+		return fmt.Errorf("makemkv rip: %w", ctx.Err())
+	}
 	if err := scanner.Err(); err != nil {
 		logger.Error("MakeMKV rip read failed",
 			"event_type", "makemkv_rip_error",
@@ -259,7 +364,7 @@ func Rip(ctx context.Context, device string, titleID int, outputDir string, time
 			"error_msg_count", len(errorMsgs),
 			"last_error_message", lastErrorText,
 		)
-		return fmt.Errorf("makemkv rip: %w (error_messages=%d, last=%q)", waitErr, len(errorMsgs), lastErrorText)
+		return withCause(fmt.Errorf("makemkv rip: %w (error_messages=%d, last=%q)", waitErr, len(errorMsgs), lastErrorText), append(errorMsgs, warningMsgs...))
 	}
 
 	// Verify output: exit 0 is not sufficient. A successful rip must
@@ -278,8 +383,8 @@ func Rip(ctx context.Context, device string, titleID int, outputDir string, time
 			"warning_msg_count", len(warningMsgs),
 			"last_error_message", lastErrorText,
 		)
-		return fmt.Errorf("makemkv rip: makemkvcon exited 0 but produced no output (saved=%d failed=%d errors=%d last=%q)",
-			savedCount, failedCount, len(errorMsgs), lastErrorText)
+		return withCause(fmt.Errorf("makemkv rip: makemkvcon exited 0 but produced no output (saved=%d failed=%d errors=%d last=%q)",
+			savedCount, failedCount, len(errorMsgs), lastErrorText), append(errorMsgs, warningMsgs...))
 	}
 	if savedCount == 0 {
 		logger.Error("MakeMKV rip summary reports zero saved",
@@ -291,8 +396,8 @@ func Rip(ctx context.Context, device string, titleID int, outputDir string, time
 			"new_files", len(newFiles),
 			"last_error_message", lastErrorText,
 		)
-		return fmt.Errorf("makemkv rip: summary reports zero saved (failed=%d errors=%d last=%q)",
-			failedCount, len(errorMsgs), lastErrorText)
+		return withCause(fmt.Errorf("makemkv rip: summary reports zero saved (failed=%d errors=%d last=%q)",
+			failedCount, len(errorMsgs), lastErrorText), append(errorMsgs, warningMsgs...))
 	}
 
 	logger.Info("MakeMKV rip completed",
@@ -308,6 +413,32 @@ func Rip(ctx context.Context, device string, titleID int, outputDir string, time
 	return nil
 }
 
+// ripStopGrace is how long makemkvcon gets to exit after SIGTERM before it
+// is killed. Exiting on its own lets it release the drive cleanly.
+var ripStopGrace = 15 * time.Second
+
+// stopGracefully makes context cancellation send SIGTERM instead of
+// SIGKILL, escalating to a kill after ripStopGrace.
+func stopGracefully(cmd *exec.Cmd) {
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(syscall.SIGTERM)
+	}
+	cmd.WaitDelay = ripStopGrace
+}
+
+// removePartialRips deletes .mkv files that appeared in dir since existing
+// was taken: the incomplete output of an interrupted rip. It returns the
+// names removed.
+func removePartialRips(dir string, existing map[string]struct{}) []string {
+	var removed []string
+	for _, name := range newMKVFiles(dir, existing) {
+		if err := os.Remove(filepath.Join(dir, name)); err == nil {
+			removed = append(removed, name)
+		}
+	}
+	return removed
+}
+
 // snapshotMKVFiles returns the set of .mkv file names present in dir.
 // Returns an empty set if the directory does not exist yet.
 func snapshotMKVFiles(dir string) map[string]struct{} {
@@ -1,9 +1,12 @@
 package makemkv
 
 import (
+	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestDefaultSelectionStringKeepsEnglishAndUnknownTracks(t *testing.T) {
@@ -441,6 +444,93 @@ func TestParseMSG(t *testing.T) {
 	}
 }
 
+func TestClassifyMessages(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  error
+	}{
+		{
+			name: "aacs volume key missing",
+			lines: []string{
+				`MSG:3007,0,0,"Using direct disc access mode","Using direct disc access mode"`,
+				`MSG:5069,1,1,"AACS: unable to obtain volume unique key for disc","AACS: %1","unable to obtain volume unique key for disc"`,
+			},
+			want: ErrDecryption,
+		},
+		{
+			name:  "key exchange reported as scsi error",
+			lines: []string{`MSG:2003,1,3,"Error 'Scsi error - ILLEGAL REQUEST:COPY PROTECTION KEY EXCHANGE FAILURE - KEY NOT ESTABLISHED' occurred while reading '/BDMV/STREAM/00800.m2ts' at offset '0'","Error '%1' occurred while reading '%2' at offset '%3'","Scsi error - ILLEGAL REQUEST:COPY PROTECTION KEY EXCHANGE FAILURE - KEY NOT ESTABLISHED","/BDMV/STREAM/00800.m2ts","0"`},
+			want:  ErrDecryption,
+		},
+		{
+			name:  "dvd region mismatch",
+			lines: []string{`MSG:2020,2,0,"The drive region setting does not match the region of the current disc","The drive region setting does not match the region of the current disc"`},
+			want:  ErrDecryption,
+		},
+		{
+			name:  "expired release",
+			lines: []string{`MSG:5021,1,0,"This application version is too old. Please download the latest version at http://www.makemkv.com/","This application version is too old."`},
+			want:  ErrDecryption,
+		},
+		{
+			name:  "medium read error",
+			lines: []string{`MSG:2003,1,3,"Error 'Scsi error - MEDIUM ERROR:L-EC UNCORRECTABLE ERROR' occurred while reading '/BDMV/STREAM/00800.m2ts' at offset '1073741824'","Error '%1' occurred while reading '%2' at offset '%3'","Scsi error - MEDIUM ERROR:L-EC UNCORRECTABLE ERROR","/BDMV/STREAM/00800.m2ts","1073741824"`},
+			want:  ErrReadFailure,
+		},
+		{
+			name:  "evaluation expired",
+			lines: []string{`MSG:5055,0,0,"Evaluation period has expired. Please purchase an activation key.","Evaluation period has expired. Please purchase an activation key."`},
+			want:  ErrDecryption,
+		},
+		{
+			name:  "unrelated failure",
+			lines: []string{`MSG:2024,1,1,"Failed to save title","Failed to save title %1","1"`},
+			want:  nil,
+		},
+		{
+			name:  "key wording under an informational code",
+			lines: []string{`MSG:1011,0,1,"Using LibreDrive mode (v06.3 id=0123) with AACS keys from KEYDB.cfg","Using %1 with AACS keys from %2","LibreDrive mode","KEYDB.cfg"`},
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var msgs []ripMessage
+			for _, line := range tt.lines {
+				msg, ok := parseMSG(line)
+				if !ok {
+					t.Fatalf("parseMSG(%q) failed", line)
+				}
+				msgs = append(msgs, msg)
+			}
+			if got := classifyMessages(msgs); got != tt.want {
+				t.Fatalf("classifyMessages = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRipReportsDecryptionFailure(t *testing.T) {
+	binDir, outDir := t.TempDir(), t.TempDir()
+	script := "#!/bin/sh\n" +
+		"echo 'MSG:5069,1,1,\"AACS: unable to obtain volume unique key for disc\",\"AACS: %1\",\"unable to obtain volume unique key for disc\"'\n" +
+		"echo 'MSG:5036,0,2,\"Copy complete. 0 titles saved, 1 failed.\",\"Copy complete. %1 titles saved, %2 failed.\",\"0\",\"1\"'\n" +
+		"exit 0\n"
+	if err := os.WriteFile(filepath.Join(binDir, "makemkvcon"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	err := Rip(context.Background(), "/dev/sr0", 0, outDir, time.Minute, 0, nil, nil)
+	if !errors.Is(err, ErrDecryption) {
+		t.Fatalf("Rip error = %v, want ErrDecryption", err)
+	}
+	if errors.Is(err, ErrReadFailure) {
+		t.Fatalf("Rip error = %v, must not also be a read failure", err)
+	}
+}
+
 func TestSplitRobotFieldsUnlimitedQuotedCommas(t *testing.T) {
 	// MSG lines can have many comma-separated params; unlimited splitting
 	// must preserve quoted commas as literal characters inside a field.
@@ -491,3 +581,60 @@ func TestSnapshotAndNewMKVFiles(t *testing.T) {
 		t.Errorf("expected [title_t00.mkv], got %v", got)
 	}
 }
+
+func TestRipCancellationStopsMakeMKVAndRemovesPartialOutput(t *testing.T) {
+	binDir, outDir := t.TempDir(), t.TempDir()
+	marker := filepath.Join(binDir, "terminated")
+	// The fake makemkvcon writes a partial title, then waits to be stopped;
+	// it records SIGTERM so the test can tell a clean stop from a kill.
+	script := "#!/bin/sh\n" +
+		"trap 'touch " + marker + "; exit 143' TERM\n" +
+		"echo partial > \"$6/title_t00.mkv\"\n" +
+		"echo 'PRGV:1,1,100'\n" +
+		"while :; do sleep 0.05; done\n"
+	if err := os.WriteFile(filepath.Join(binDir, "makemkvcon"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	if err := os.WriteFile(filepath.Join(outDir, "title_t01.mkv"), []byte("done"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{}, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- Rip(ctx, "/dev/sr0", 0, outDir, time.Minute, 0, func(RipProgress) {
+			select {
+			case started <- struct{}{}:
+			default:
+			}
+		}, nil)
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("fake makemkvcon never reported progress")
+	}
+	cancel()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Rip did not return after cancellation")
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Rip error = %v, want context.Canceled", err)
+	}
+	if _, statErr := os.Stat(marker); statErr != nil {
+		t.Error("makemkvcon was not sent SIGTERM")
+	}
+	if _, statErr := os.Stat(filepath.Join(outDir, "title_t00.mkv")); !os.IsNotExist(statErr) {
+		t.Error("partial rip output was not removed")
+	}
+	if _, statErr := os.Stat(filepath.Join(outDir, "title_t01.mkv")); statErr != nil {
+		t.Error("pre-existing rip output was removed")
+	}
+}
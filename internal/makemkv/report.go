@@ -0,0 +1,92 @@
+package makemkv
+
+// MakeMKV SINFO attribute IDs the report reads beyond the parsed Track fields.
+const (
+	attrVideoSize      = 19
+	attrVideoFrameRate = 21
+)
+
+// DiscReport is a scanned disc's contents shaped for display: each title
+// with its streams grouped by type.
+type DiscReport struct {
+	Name   string        `json:"name"`
+	Titles []TitleReport `json:"titles"`
+}
+
+// TitleReport describes one scanned title.
+type TitleReport struct {
+	ID              int            `json:"id"`
+	Name            string         `json:"name,omitempty"`
+	DurationSeconds int            `json:"duration_seconds"`
+	Chapters        int            `json:"chapters"`
+	SizeBytes       int64          `json:"size_bytes"`
+	Playlist        string         `json:"playlist,omitempty"`
+	Segments        string         `json:"segments,omitempty"`
+	Video           []StreamReport `json:"video"`
+	Audio           []StreamReport `json:"audio"`
+	Subtitles       []StreamReport `json:"subtitles"`
+}
+
+// StreamReport describes one stream of a title. Empty fields were not
+// reported by MakeMKV for that stream.
+type StreamReport struct {
+	Codec         string `json:"codec"`
+	Language      string `json:"language,omitempty"`
+	LanguageName  string `json:"language_name,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Channels      int    `json:"channels,omitempty"`
+	ChannelLayout string `json:"channel_layout,omitempty"`
+	Resolution    string `json:"resolution,omitempty"`
+	FrameRate     string `json:"frame_rate,omitempty"`
+	BitRate       string `json:"bit_rate,omitempty"`
+}
+
+// NewDiscReport builds the report for a scan. Streams keep their scan order
+// within each type; data and unknown streams are left out.
+func NewDiscReport(info *DiscInfo) DiscReport {
+	report := DiscReport{Titles: []TitleReport{}}
+	if info == nil {
+		return report
+	}
+	report.Name = info.Name
+	for _, t := range info.Titles {
+		title := TitleReport{
+			ID:              t.ID,
+			Name:            t.Name,
+			DurationSeconds: t.Duration,
+			Chapters:        t.Chapters,
+			SizeBytes:       t.SizeBytes,
+			Playlist:        t.Playlist,
+			Segments:        t.SegmentMap,
+			Video:           []StreamReport{},
+			Audio:           []StreamReport{},
+			Subtitles:       []StreamReport{},
+		}
+		for _, track := range t.Tracks {
+			stream := StreamReport{
+				Codec:         track.CodecShort,
+				Language:      track.Language,
+				LanguageName:  track.LanguageName,
+				Name:          track.Name,
+				Channels:      track.ChannelCount,
+				ChannelLayout: track.ChannelLayout,
+				BitRate:       track.BitRate,
+			}
+			if stream.Codec == "" {
+				stream.Codec = track.CodecID
+			}
+			switch track.Type {
+			case TrackTypeVideo:
+				stream.Resolution = track.Attributes[attrVideoSize]
+				stream.FrameRate = track.Attributes[attrVideoFrameRate]
+				title.Video = append(title.Video, stream)
+			case TrackTypeAudio:
+				title.Audio = append(title.Audio, stream)
+			case TrackTypeSubtitle:
+				title.Subtitles = append(title.Subtitles, stream)
+			}
+		}
+		report.Titles = append(report.Titles, title)
+	}
+	return report
+}
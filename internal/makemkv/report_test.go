@@ -0,0 +1,71 @@
+package makemkv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewDiscReportGroupsScannedStreams(t *testing.T) {
+	info := parseRobotOutput([]string{
+		`CINFO:2,0,"HEAT"`,
+		`TINFO:0,2,0,"Heat"`,
+		`TINFO:0,8,0,"32"`,
+		`TINFO:0,9,0,"2:50:10"`,
+		`TINFO:0,10,0,"45000000000"`,
+		`TINFO:0,16,0,"00800.mpls"`,
+		`TINFO:0,26,0,"00001.m2ts"`,
+		`SINFO:0,0,1,6201,"Video"`,
+		`SINFO:0,0,6,0,"Mpeg4"`,
+		`SINFO:0,0,19,0,"1920x1080"`,
+		`SINFO:0,0,21,0,"23.976"`,
+		`SINFO:0,1,1,6202,"Audio"`,
+		`SINFO:0,1,3,0,"eng"`,
+		`SINFO:0,1,4,0,"English"`,
+		`SINFO:0,1,6,0,"DTS-HD MA"`,
+		`SINFO:0,1,14,0,"6"`,
+		`SINFO:0,1,40,0,"5.1(side)"`,
+		`SINFO:0,2,1,6202,"Audio"`,
+		`SINFO:0,2,3,0,"eng"`,
+		`SINFO:0,2,4,0,"English"`,
+		`SINFO:0,2,6,0,"AC3"`,
+		`SINFO:0,2,14,0,"2"`,
+		`SINFO:0,2,30,0,"Director's Commentary"`,
+		`SINFO:0,3,1,6203,"Subtitles"`,
+		`SINFO:0,3,3,0,"fre"`,
+		`SINFO:0,3,4,0,"French"`,
+		`SINFO:0,3,6,0,"PGS"`,
+		`SINFO:0,4,1,0,"Data"`,
+		`TINFO:1,9,0,"0:04:12"`,
+	})
+
+	report := NewDiscReport(info)
+	if report.Name != "HEAT" || len(report.Titles) != 2 {
+		t.Fatalf("report = %q with %d titles, want HEAT with 2", report.Name, len(report.Titles))
+	}
+
+	main := report.Titles[0]
+	if main.ID != 0 || main.Name != "Heat" || main.DurationSeconds != 10210 || main.Chapters != 32 ||
+		main.SizeBytes != 45000000000 || main.Playlist != "00800.mpls" || main.Segments != "00001.m2ts" {
+		t.Errorf("title 0 = %+v, want the scanned title attributes", main)
+	}
+	wantVideo := []StreamReport{{Codec: "Mpeg4", Resolution: "1920x1080", FrameRate: "23.976"}}
+	if !reflect.DeepEqual(main.Video, wantVideo) {
+		t.Errorf("video = %+v, want %+v", main.Video, wantVideo)
+	}
+	wantAudio := []StreamReport{
+		{Codec: "DTS-HD MA", Language: "eng", LanguageName: "English", Channels: 6, ChannelLayout: "5.1(side)"},
+		{Codec: "AC3", Language: "eng", LanguageName: "English", Name: "Director's Commentary", Channels: 2},
+	}
+	if !reflect.DeepEqual(main.Audio, wantAudio) {
+		t.Errorf("audio = %+v, want %+v", main.Audio, wantAudio)
+	}
+	wantSubs := []StreamReport{{Codec: "PGS", Language: "fre", LanguageName: "French"}}
+	if !reflect.DeepEqual(main.Subtitles, wantSubs) {
+		t.Errorf("subtitles = %+v, want %+v (data stream left out)", main.Subtitles, wantSubs)
+	}
+
+	// A title without stream info still reports empty stream lists.
+	if extra := report.Titles[1]; extra.DurationSeconds != 252 || extra.Video == nil || len(extra.Audio) != 0 {
+		t.Errorf("title 1 = %+v, want 252s with empty stream lists", extra)
+	}
+}
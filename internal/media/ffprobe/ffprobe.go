@@ -3,13 +3,30 @@
 package ffprobe
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os/exec"
 	"strconv"
+	"strings"
+	"time"
 )
 
+// ErrTimeout reports that ffprobe did not finish within Options.Timeout.
+var ErrTimeout = errors.New("ffprobe timed out")
+
+// Options controls how InspectWithOptions treats slow or malformed files.
+type Options struct {
+	// Timeout bounds each ffprobe run. Zero leaves only the caller's context.
+	Timeout time.Duration
+	// Tolerant accepts truncated output (a crashed or killed ffprobe, or a
+	// file whose format block cannot be read), returning the streams parsed
+	// before the break with Result.Partial set instead of an error.
+	Tolerant bool
+}
+
 // FlexString unmarshals from both JSON strings and numbers, storing the result
 // as a string. ffprobe is inconsistent: mastering display fields (max_luminance)
 // are strings like "1000/1", but content light level fields (max_content,
@@ -44,11 +61,14 @@ type Stream struct {
 	BitRate        string            `json:"bit_rate"`
 	Width          int               `json:"width"`
 	Height         int               `json:"height"`
+	AvgFrameRate   string            `json:"avg_frame_rate"`
+	RFrameRate     string            `json:"r_frame_rate"`
 	SampleRate     string            `json:"sample_rate"`
 	Channels       int               `json:"channels"`
 	ChannelLayout  string            `json:"channel_layout"`
 	Profile        string            `json:"profile"`
 	PixFmt         string            `json:"pix_fmt"`
+	FieldOrder     string            `json:"field_order"`
 	ColorRange     string            `json:"color_range"`
 	ColorSpace     string            `json:"color_space"`
 	ColorTransfer  string            `json:"color_transfer"`
@@ -87,10 +107,40 @@ type Format struct {
 	FormatName string `json:"format_name"`
 }
 
+// Chapter is one chapter marker as reported by ffprobe.
+type Chapter struct {
+	ID        int64             `json:"id"`
+	StartTime string            `json:"start_time"`
+	EndTime   string            `json:"end_time"`
+	Tags      map[string]string `json:"tags"`
+}
+
+// Title returns the chapter's title tag, or "" when it has none.
+func (c Chapter) Title() string {
+	return strings.TrimSpace(c.Tags["title"])
+}
+
+// StartSeconds returns the chapter start in seconds, or 0 if unparseable.
+func (c Chapter) StartSeconds() float64 {
+	v, _ := strconv.ParseFloat(c.StartTime, 64)
+	return v
+}
+
+// EndSeconds returns the chapter end in seconds, or 0 if unparseable.
+func (c Chapter) EndSeconds() float64 {
+	v, _ := strconv.ParseFloat(c.EndTime, 64)
+	return v
+}
+
 // Result holds the parsed ffprobe output for a single media file.
 type Result struct {
-	Streams []Stream `json:"streams"`
-	Format  Format   `json:"format"`
+	Streams  []Stream  `json:"streams"`
+	Format   Format    `json:"format"`
+	Chapters []Chapter `json:"chapters"`
+	// Partial is set when tolerant parsing recovered the result from
+	// truncated output; Format may be empty. Callers that build ffmpeg
+	// stream maps must not use a partial result.
+	Partial bool `json:"partial,omitempty"`
 }
 
 // VideoStreamCount returns the number of streams with codec_type "video".
@@ -104,6 +154,63 @@ func (r *Result) VideoStreamCount() int {
 	return n
 }
 
+// VideoFrameRate returns the first video stream's frame rate in frames per
+// second, preferring avg_frame_rate over r_frame_rate. Returns 0 when no
+// video stream reports a usable rate.
+func (r *Result) VideoFrameRate() float64 {
+	for _, s := range r.Streams {
+		if s.CodecType != "video" {
+			continue
+		}
+		if fps := parseFrameRate(s.AvgFrameRate); fps > 0 {
+			return fps
+		}
+		return parseFrameRate(s.RFrameRate)
+	}
+	return 0
+}
+
+// VideoFieldOrder returns the first video stream's field_order: "progressive",
+// an interlaced order ("tt", "bb", "tb", "bt"), or "" / "unknown" when the
+// container does not say.
+func (r *Result) VideoFieldOrder() string {
+	for _, s := range r.Streams {
+		if s.CodecType == "video" {
+			return s.FieldOrder
+		}
+	}
+	return ""
+}
+
+// Interlaced reports whether the first video stream is stored as interlaced
+// fields. Telecined film reports an interlaced order too; field_order alone
+// cannot tell the two apart.
+func (r *Result) Interlaced() bool {
+	switch r.VideoFieldOrder() {
+	case "tt", "bb", "tb", "bt":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseFrameRate parses an ffprobe rational ("24000/1001") or decimal rate.
+func parseFrameRate(rate string) float64 {
+	num, den, ok := strings.Cut(strings.TrimSpace(rate), "/")
+	n, err := strconv.ParseFloat(num, 64)
+	if err != nil || n <= 0 {
+		return 0
+	}
+	if !ok {
+		return n
+	}
+	d, err := strconv.ParseFloat(den, 64)
+	if err != nil || d <= 0 {
+		return 0
+	}
+	return n / d
+}
+
 // AudioStreams returns only the audio streams from the probe result.
 func (r *Result) AudioStreams() []Stream {
 	var out []Stream
@@ -148,28 +255,103 @@ func (r *Result) BitRate() int64 {
 }
 
 // Inspect runs ffprobe against the file at path and returns the parsed result.
-// If binary is empty it defaults to "ffprobe".
+// It is strict and never returns a Partial result. If binary is empty it
+// defaults to "ffprobe".
 func Inspect(ctx context.Context, binary, path string) (*Result, error) {
+	return InspectWithOptions(ctx, binary, path, Options{})
+}
+
+// InspectWithOptions is Inspect with explicit options. A run that exceeds
+// o.Timeout is killed and returns an error wrapping ErrTimeout unless
+// o.Tolerant recovers streams from the output written before the kill.
+func InspectWithOptions(ctx context.Context, binary, path string, o Options) (*Result, error) {
 	if binary == "" {
 		binary = "ffprobe"
 	}
+	runCtx := ctx
+	if o.Timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
 
-	cmd := exec.CommandContext(ctx, binary,
+	cmd := exec.CommandContext(runCtx, binary,
 		"-v", "quiet",
 		"-print_format", "json",
 		"-show_format",
 		"-show_streams",
+		"-show_chapters",
 		path,
 	)
+	cmd.WaitDelay = 5 * time.Second
 
 	out, err := cmd.Output()
 	if err != nil {
+		if ctx.Err() == nil && errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("%w after %s", ErrTimeout, o.Timeout)
+		}
+		if o.Tolerant {
+			if partial, ok := parsePartial(out); ok {
+				return partial, nil
+			}
+		}
 		return nil, fmt.Errorf("ffprobe %s: %w", path, err)
 	}
 
 	var result Result
 	if err := json.Unmarshal(out, &result); err != nil {
+		if o.Tolerant {
+			if partial, ok := parsePartial(out); ok {
+				return partial, nil
+			}
+		}
 		return nil, fmt.Errorf("parse ffprobe output: %w", err)
 	}
 	return &result, nil
 }
+
+// parsePartial decodes as much of truncated ffprobe JSON as it can: every
+// complete stream, and the format block when it is complete. It reports
+// false when no stream could be recovered.
+func parsePartial(out []byte) (*Result, bool) {
+	dec := json.NewDecoder(bytes.NewReader(out))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, false
+	}
+	result := &Result{Partial: true}
+decode:
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			break
+		}
+		switch tok {
+		case "streams":
+			if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+				break decode
+			}
+			for dec.More() {
+				var s Stream
+				if err := dec.Decode(&s); err != nil {
+					break decode
+				}
+				result.Streams = append(result.Streams, s)
+			}
+			if _, err := dec.Token(); err != nil {
+				break decode
+			}
+		case "format":
+			var f Format
+			if err := dec.Decode(&f); err != nil {
+				break decode
+			}
+			result.Format = f
+		default:
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				break decode
+			}
+		}
+	}
+	return result, len(result.Streams) > 0
+}
@@ -1,8 +1,13 @@
 package ffprobe
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestVideoStreamCount(t *testing.T) {
@@ -19,6 +24,49 @@ func TestVideoStreamCount(t *testing.T) {
 	}
 }
 
+func TestVideoFrameRate(t *testing.T) {
+	tests := []struct {
+		name    string
+		streams []Stream
+		want    float64
+	}{
+		{"ntsc film", []Stream{{CodecType: "audio"}, {CodecType: "video", AvgFrameRate: "24000/1001"}}, 24000.0 / 1001},
+		{"pal", []Stream{{CodecType: "video", AvgFrameRate: "25/1"}}, 25},
+		{"r_frame_rate fallback", []Stream{{CodecType: "video", AvgFrameRate: "0/0", RFrameRate: "24/1"}}, 24},
+		{"no video", []Stream{{CodecType: "audio"}}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Result{Streams: tt.streams}
+			if got := r.VideoFrameRate(); got != tt.want {
+				t.Errorf("VideoFrameRate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInterlaced(t *testing.T) {
+	tests := []struct {
+		name    string
+		streams []Stream
+		want    bool
+	}{
+		{"top field first", []Stream{{CodecType: "audio"}, {CodecType: "video", FieldOrder: "tt"}}, true},
+		{"bottom field first", []Stream{{CodecType: "video", FieldOrder: "bb"}}, true},
+		{"progressive", []Stream{{CodecType: "video", FieldOrder: "progressive"}}, false},
+		{"unflagged", []Stream{{CodecType: "video"}}, false},
+		{"no video", []Stream{{CodecType: "audio", FieldOrder: "tt"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Result{Streams: tt.streams}
+			if got := r.Interlaced(); got != tt.want {
+				t.Errorf("Interlaced() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestAudioStreamCount(t *testing.T) {
 	r := &Result{
 		Streams: []Stream{
@@ -211,6 +259,10 @@ func TestJSONParsing(t *testing.T) {
 				"disposition": {"default": 0, "forced": 0}
 			}
 		],
+		"chapters": [
+			{"id": 0, "start_time": "0.000000", "end_time": "312.250000", "tags": {"title": "Main Titles"}},
+			{"id": 1, "start_time": "312.250000", "end_time": "7200.500000"}
+		],
 		"format": {
 			"filename": "/media/disc.mkv",
 			"nb_streams": 3,
@@ -272,4 +324,67 @@ func TestJSONParsing(t *testing.T) {
 	if def, ok := result.Streams[0].Disposition["default"]; !ok || def != 1 {
 		t.Errorf("stream 0 default disposition = %d, want 1", def)
 	}
+
+	if got := len(result.Chapters); got != 2 {
+		t.Fatalf("chapter count = %d, want 2", got)
+	}
+	if ch := result.Chapters[0]; ch.Title() != "Main Titles" || ch.StartSeconds() != 0 || ch.EndSeconds() != 312.25 {
+		t.Errorf("chapter 0 = %q %v-%v, want Main Titles 0-312.25", ch.Title(), ch.StartSeconds(), ch.EndSeconds())
+	}
+	if got := result.Chapters[1].Title(); got != "" {
+		t.Errorf("untitled chapter title = %q, want empty", got)
+	}
+}
+
+// fakeProbe writes an executable script standing in for ffprobe.
+func fakeProbe(t *testing.T, script string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ffprobe")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestInspectTimeout(t *testing.T) {
+	bin := fakeProbe(t, "exec sleep 10\n")
+	start := time.Now()
+	_, err := InspectWithOptions(context.Background(), bin, "/media/hung.mkv", Options{Timeout: 100 * time.Millisecond})
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("err = %v, want ErrTimeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("timed-out probe took %v to return", elapsed)
+	}
+}
+
+func TestInspectTolerantPartialParse(t *testing.T) {
+	// Streams complete, format block cut off mid-object, non-zero exit.
+	truncated := `{"streams": [{"index": 0, "codec_type": "video", "codec_name": "h264"}, {"index": 1, "codec_type": "audio", "channels": 6}], "format": {"filename": "/media/bad.mkv", "dura`
+	bin := fakeProbe(t, "printf '%s' '"+truncated+"'\nexit 1\n")
+
+	if _, err := InspectWithOptions(context.Background(), bin, "/media/bad.mkv", Options{}); err == nil {
+		t.Fatal("strict mode accepted truncated output")
+	}
+
+	result, err := InspectWithOptions(context.Background(), bin, "/media/bad.mkv", Options{Tolerant: true})
+	if err != nil {
+		t.Fatalf("tolerant mode: %v", err)
+	}
+	if !result.Partial {
+		t.Error("Partial not set on recovered result")
+	}
+	if result.VideoStreamCount() != 1 || result.AudioStreamCount() != 1 {
+		t.Errorf("streams = %+v, want one video and one audio", result.Streams)
+	}
+	if result.Format.Filename != "" {
+		t.Errorf("incomplete format block decoded: %+v", result.Format)
+	}
+}
+
+func TestInspectTolerantRejectsOutputWithoutStreams(t *testing.T) {
+	bin := fakeProbe(t, "printf '%s' '{\"streams\": [{\"index\": 0, \"codec'\nexit 1\n")
+	if _, err := InspectWithOptions(context.Background(), bin, "/media/bad.mkv", Options{Tolerant: true}); err == nil {
+		t.Fatal("tolerant mode accepted output with no complete stream")
+	}
 }
@@ -0,0 +1,79 @@
+package mediameta
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultEpisodeFormat numbers episodes the Plex way: "S01E01".
+const DefaultEpisodeFormat = "S{season:02}E{episode:02}"
+
+// episodePlaceholderRe matches {season} and {episode}, optionally zero-padded
+// to a width of 1-4 digits, e.g. {episode:03}.
+var episodePlaceholderRe = regexp.MustCompile(`^\{(season|episode)(?::0([1-4]))?\}$`)
+
+// ValidateEpisodeFormat checks an episode-numbering format: it must use
+// {season} once, followed later by {episode} once, each optionally padded
+// like {episode:02}, and must not contain path separators.
+func ValidateEpisodeFormat(format string) error {
+	if strings.TrimSpace(format) == "" {
+		return fmt.Errorf("format is empty")
+	}
+	if strings.ContainsAny(format, `/\`) {
+		return fmt.Errorf("format %q must not contain path separators", format)
+	}
+	seasonAt, episodeAt := -1, -1
+	for _, loc := range placeholderRe.FindAllStringIndex(format, -1) {
+		m := episodePlaceholderRe.FindStringSubmatch(format[loc[0]:loc[1]])
+		if m == nil {
+			return fmt.Errorf("format %q uses unknown placeholder %s (want {season} or {episode}, optionally padded like {episode:02})",
+				format, format[loc[0]:loc[1]])
+		}
+		at := &seasonAt
+		if m[1] == "episode" {
+			at = &episodeAt
+		}
+		if *at >= 0 {
+			return fmt.Errorf("format %q uses {%s} more than once", format, m[1])
+		}
+		*at = loc[0]
+	}
+	if seasonAt < 0 || episodeAt < 0 {
+		return fmt.Errorf("format %q must contain both {season} and {episode}", format)
+	}
+	if episodeAt < seasonAt {
+		return fmt.Errorf("format %q must place {season} before {episode}", format)
+	}
+	return nil
+}
+
+// FormatEpisodeNumber renders season and episode with format, e.g. "S01E01",
+// "1x01", or "101". A multi-episode range (episodeEnd > episode) repeats the
+// part of the format after {season} for the last episode: "S01E01-E02",
+// "1x01-x02", "101-02". An invalid format falls back to DefaultEpisodeFormat.
+func FormatEpisodeNumber(format string, season, episode, episodeEnd int) string {
+	if ValidateEpisodeFormat(format) != nil {
+		format = DefaultEpisodeFormat
+	}
+	name := expandEpisodeFormat(format, season, episode)
+	if episodeEnd > episode {
+		seasonEnd := placeholderRe.FindStringIndex(format)[1]
+		name += "-" + expandEpisodeFormat(format[seasonEnd:], season, episodeEnd)
+	}
+	return name
+}
+
+// expandEpisodeFormat replaces each placeholder in a validated format.
+func expandEpisodeFormat(format string, season, episode int) string {
+	return placeholderRe.ReplaceAllStringFunc(format, func(ph string) string {
+		m := episodePlaceholderRe.FindStringSubmatch(ph)
+		n := season
+		if m[1] == "episode" {
+			n = episode
+		}
+		width, _ := strconv.Atoi(m[2])
+		return fmt.Sprintf("%0*d", width, n)
+	})
+}
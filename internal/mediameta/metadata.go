@@ -17,6 +17,7 @@ type Metadata struct {
 	MediaType    string    `json:"media_type,omitempty"`
 	ShowTitle    string    `json:"show_title,omitempty"`
 	Year         string    `json:"year,omitempty"`
+	Genre        string    `json:"genre,omitempty"`
 	SeasonNumber int       `json:"season_number,omitempty"`
 	Movie        bool      `json:"movie,omitempty"`
 	Episodes     []Episode `json:"episodes,omitempty"`
@@ -72,16 +73,19 @@ func (m *Metadata) IsMovie() bool {
 }
 
 // LibraryPath computes the target library folder via SafeJoin.
-// Movies: {root}/{moviesDir}/{baseFilename}
+// Movies: {root}/{moviesDir}/{movieTemplate expanded by MovieDir}
 // TV: {root}/{tvDir}/{show}/Season {NN}
-func (m *Metadata) LibraryPath(root, moviesDir, tvDir string) (string, error) {
+func (m *Metadata) LibraryPath(root, moviesDir, tvDir, movieTemplate string) (string, error) {
 	if m.IsMovie() {
-		base := m.BaseFilename()
+		rel, err := m.MovieDir(movieTemplate)
+		if err != nil {
+			return "", err
+		}
 		dir, err := textutil.SafeJoin(root, moviesDir)
 		if err != nil {
 			return "", err
 		}
-		return textutil.SafeJoin(dir, base)
+		return textutil.SafeJoin(dir, rel)
 	}
 
 	show := textutil.SanitizeDisplayName(m.ShowTitle)
@@ -97,8 +101,13 @@ func (m *Metadata) LibraryPath(root, moviesDir, tvDir string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	season := fmt.Sprintf("Season %02d", m.SeasonNumber)
-	return textutil.SafeJoin(dir, season)
+	return textutil.SafeJoin(dir, SeasonFolder(m.SeasonNumber))
+}
+
+// SeasonFolder names a TV season's library folder, "Season 01". Specials
+// (season 0) use "Season 00", which Plex and Jellyfin both recognize.
+func SeasonFolder(season int) string {
+	return fmt.Sprintf("Season %02d", season)
 }
 
 // Filename returns the final output filename without extension.
@@ -106,7 +115,7 @@ func (m *Metadata) Filename() string {
 	if m.IsMovie() {
 		return m.BaseFilename()
 	}
-	return buildEpisodeFilename(m)
+	return buildEpisodeFilename(m, DefaultEpisodeFormat)
 }
 
 // BaseFilename returns the movie/base filename: "Title (Year)".
@@ -126,10 +135,11 @@ func (m *Metadata) BaseFilename() string {
 }
 
 // DestFilename builds the destination filename, including ext, for an asset key.
-// When season and episode are resolved (both > 0), they are used to build the
-// TV episode filename directly; otherwise the sanitized "show - key" fallback
-// is used for unresolved placeholder keys.
-func DestFilename(meta *Metadata, key, ext string, season, episode, episodeEnd int) string {
+// When the episode is resolved (episode > 0; season 0 is a special), season
+// and episode are used to build the TV episode filename directly, numbered by episodeFormat (see
+// FormatEpisodeNumber); otherwise the sanitized "show - key" fallback is used
+// for unresolved placeholder keys.
+func DestFilename(meta *Metadata, key, ext string, season, episode, episodeEnd int, episodeFormat string) string {
 	if meta == nil {
 		return textutil.SanitizeDisplayName(key) + ext
 	}
@@ -137,7 +147,7 @@ func DestFilename(meta *Metadata, key, ext string, season, episode, episodeEnd i
 		return meta.Filename() + ext
 	}
 
-	if season > 0 && episode > 0 {
+	if season >= 0 && episode > 0 {
 		epMeta := Metadata{
 			Title:        meta.Title,
 			ShowTitle:    meta.ShowTitle,
@@ -146,7 +156,7 @@ func DestFilename(meta *Metadata, key, ext string, season, episode, episodeEnd i
 			Episodes:     []Episode{{Season: season, Episode: episode, EpisodeEnd: episodeEnd}},
 			DisplayTitle: meta.DisplayTitle,
 		}
-		return textutil.SanitizeDisplayName(epMeta.Filename()) + ext
+		return textutil.SanitizeDisplayName(buildEpisodeFilename(&epMeta, episodeFormat)) + ext
 	}
 
 	show := textutil.SanitizeDisplayName(meta.ShowTitle)
@@ -156,7 +166,18 @@ func DestFilename(meta *Metadata, key, ext string, season, episode, episodeEnd i
 	return textutil.SanitizeDisplayName(show+" - "+key) + ext
 }
 
-func buildEpisodeFilename(m *Metadata) string {
+// WithEdition tags a movie filename stem with its edition the way Plex
+// groups cuts of one film: "Heat (1995) {edition-Extended}". An empty
+// edition leaves the stem unchanged.
+func WithEdition(stem, edition string) string {
+	edition = textutil.SanitizeDisplayName(edition)
+	if edition == "" {
+		return stem
+	}
+	return stem + " {edition-" + edition + "}"
+}
+
+func buildEpisodeFilename(m *Metadata, episodeFormat string) string {
 	show := textutil.SanitizeDisplayName(m.ShowTitle)
 	if show == "" || show == "manual-import" {
 		show = textutil.SanitizeDisplayName(m.Title)
@@ -171,19 +192,8 @@ func buildEpisodeFilename(m *Metadata) string {
 		return fmt.Sprintf("%s - Season %02d", show, season)
 	}
 
-	if len(m.Episodes) == 1 {
-		ep := m.Episodes[0]
-		if ep.EpisodeEnd > ep.Episode {
-			return fmt.Sprintf("%s - S%02dE%02d-E%02d", show, ep.Season, ep.Episode, ep.EpisodeEnd)
-		}
-		return fmt.Sprintf("%s - S%02dE%02d", show, ep.Season, ep.Episode)
-	}
-
 	first := m.Episodes[0]
 	last := m.Episodes[len(m.Episodes)-1]
-	lastEpisode := last.Episode
-	if last.EpisodeEnd > lastEpisode {
-		lastEpisode = last.EpisodeEnd
-	}
-	return fmt.Sprintf("%s - S%02dE%02d-E%02d", show, first.Season, first.Episode, lastEpisode)
+	lastEpisode := max(last.Episode, last.EpisodeEnd)
+	return show + " - " + FormatEpisodeNumber(episodeFormat, first.Season, first.Episode, lastEpisode)
 }
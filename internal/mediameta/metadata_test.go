@@ -86,7 +86,7 @@ func TestLibraryPathMovie(t *testing.T) {
 		MediaType: "movie",
 		Year:      "2010",
 	}
-	got, err := m.LibraryPath("/media", "movies", "tv")
+	got, err := m.LibraryPath("/media", "movies", "tv", "")
 	if err != nil {
 		t.Fatalf("LibraryPath: %v", err)
 	}
@@ -96,13 +96,107 @@ func TestLibraryPathMovie(t *testing.T) {
 	}
 }
 
+func TestLibraryPathMovieStructures(t *testing.T) {
+	m := Metadata{Title: "Inception", MediaType: "movie", Year: "2010", Genre: "Science Fiction"}
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"flat", "{name}", "/media/movies/Inception (2010)"},
+		{"by year", "{year}/{name}", "/media/movies/2010/Inception (2010)"},
+		{"by genre", "{genre}/{name}", "/media/movies/Science Fiction/Inception (2010)"},
+		{"custom", "{genre}/{decade}/{title} [{year}]", "/media/movies/Science Fiction/2010s/Inception [2010]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.LibraryPath("/media", "movies", "tv", tt.template)
+			if err != nil {
+				t.Fatalf("LibraryPath: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("LibraryPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	unknown := Metadata{Title: "Some/Film", MediaType: "movie"}
+	got, err := unknown.LibraryPath("/media", "movies", "tv", "{genre}/{year}/{name}")
+	if err != nil {
+		t.Fatalf("LibraryPath: %v", err)
+	}
+	if want := "/media/movies/Unknown Genre/Unknown Year/Some Film"; got != want {
+		t.Errorf("LibraryPath() = %q, want %q", got, want)
+	}
+}
+
+func TestValidateMovieDirTemplate(t *testing.T) {
+	valid := []string{"{name}", "{year}/{name}", "{genre}/{decade}/{title} ({year})"}
+	for _, template := range valid {
+		if err := ValidateMovieDirTemplate(template); err != nil {
+			t.Errorf("ValidateMovieDirTemplate(%q) = %v, want nil", template, err)
+		}
+	}
+	invalid := []string{"", "/{name}", "{genre}//{name}", "../{name}", "{name}/{genre}", "{title}", "{studio}/{name}"}
+	for _, template := range invalid {
+		if err := ValidateMovieDirTemplate(template); err == nil {
+			t.Errorf("ValidateMovieDirTemplate(%q) = nil, want error", template)
+		}
+	}
+}
+
+func TestFormatEpisodeNumber(t *testing.T) {
+	tests := []struct {
+		format                  string
+		season, episode, lastEp int
+		want                    string
+	}{
+		{DefaultEpisodeFormat, 1, 1, 0, "S01E01"},
+		{DefaultEpisodeFormat, 1, 1, 2, "S01E01-E02"},
+		{"{season}x{episode:02}", 1, 1, 0, "1x01"},
+		{"{season}x{episode:02}", 10, 5, 6, "10x05-x06"},
+		{"{season}{episode:02}", 1, 1, 0, "101"},
+		{"{season}{episode:02}", 2, 9, 10, "209-10"},
+		{"s{season:02}e{episode:03}", 1, 100, 0, "s01e100"},
+		{"{episode}", 1, 1, 0, "S01E01"}, // invalid formats fall back to the default
+	}
+	for _, tt := range tests {
+		if got := FormatEpisodeNumber(tt.format, tt.season, tt.episode, tt.lastEp); got != tt.want {
+			t.Errorf("FormatEpisodeNumber(%q, %d, %d, %d) = %q, want %q",
+				tt.format, tt.season, tt.episode, tt.lastEp, got, tt.want)
+		}
+	}
+}
+
+func TestValidateEpisodeFormat(t *testing.T) {
+	valid := []string{DefaultEpisodeFormat, "{season}x{episode:02}", "{season}{episode:02}", "Season {season} Episode {episode}"}
+	for _, format := range valid {
+		if err := ValidateEpisodeFormat(format); err != nil {
+			t.Errorf("ValidateEpisodeFormat(%q) = %v, want nil", format, err)
+		}
+	}
+	invalid := []string{"", "E{episode:02}", "{episode}x{season}", "S{season}E{episode}E{episode}", "{season}/{episode}", "S{season:2}E{episode}", "{season}{episode}{title}"}
+	for _, format := range invalid {
+		if err := ValidateEpisodeFormat(format); err == nil {
+			t.Errorf("ValidateEpisodeFormat(%q) = nil, want error", format)
+		}
+	}
+}
+
+func TestDestFilenameUsesEpisodeFormat(t *testing.T) {
+	meta := &Metadata{ShowTitle: "Firefly", MediaType: "tv", SeasonNumber: 1}
+	if got := DestFilename(meta, "s01e02", ".mkv", 1, 2, 0, "{season}x{episode:02}"); got != "Firefly - 1x02.mkv" {
+		t.Errorf("DestFilename = %q, want %q", got, "Firefly - 1x02.mkv")
+	}
+}
+
 func TestLibraryPathTV(t *testing.T) {
 	m := Metadata{
 		ShowTitle:    "The Office",
 		MediaType:    "tv",
 		SeasonNumber: 3,
 	}
-	got, err := m.LibraryPath("/media", "movies", "tv")
+	got, err := m.LibraryPath("/media", "movies", "tv", "")
 	if err != nil {
 		t.Fatalf("LibraryPath: %v", err)
 	}
@@ -0,0 +1,94 @@
+package mediameta
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/five82/spindle/internal/textutil"
+)
+
+// DefaultMovieDirTemplate places each movie in its own "Title (Year)" folder
+// directly under the movies directory.
+const DefaultMovieDirTemplate = "{name}"
+
+// movieDirPlaceholders lists the placeholders a movie directory template
+// may use: {name} is "Title (Year)", {decade} is e.g. "1990s".
+var movieDirPlaceholders = []string{"{name}", "{title}", "{year}", "{decade}", "{genre}"}
+
+var placeholderRe = regexp.MustCompile(`\{[^{}]*\}`)
+
+// ValidateMovieDirTemplate checks a movie directory template: "/"-separated
+// relative segments using only known placeholders, whose last segment names
+// the movie by {name} or by {title} with {year} so every movie gets its own
+// folder.
+func ValidateMovieDirTemplate(template string) error {
+	if strings.TrimSpace(template) == "" {
+		return fmt.Errorf("template is empty")
+	}
+	if strings.HasPrefix(template, "/") {
+		return fmt.Errorf("template %q must be relative", template)
+	}
+	segments := strings.Split(template, "/")
+	for _, segment := range segments {
+		switch strings.TrimSpace(segment) {
+		case "":
+			return fmt.Errorf("template %q has an empty segment", template)
+		case ".", "..":
+			return fmt.Errorf("template %q must not use %q segments", template, segment)
+		}
+		for _, ph := range placeholderRe.FindAllString(segment, -1) {
+			if !slices.Contains(movieDirPlaceholders, ph) {
+				return fmt.Errorf("template %q uses unknown placeholder %s (want one of %s)",
+					template, ph, strings.Join(movieDirPlaceholders, ", "))
+			}
+		}
+	}
+	last := segments[len(segments)-1]
+	if !strings.Contains(last, "{name}") && !(strings.Contains(last, "{title}") && strings.Contains(last, "{year}")) {
+		return fmt.Errorf("template %q must end in a segment with {name} or {title} and {year} so each movie gets its own folder", template)
+	}
+	return nil
+}
+
+// MovieDir expands a movie directory template into a relative path. Each
+// segment is sanitized; a missing year or genre becomes "Unknown Year" or
+// "Unknown Genre" so grouping folders stay navigable. An empty template
+// uses DefaultMovieDirTemplate.
+func (m *Metadata) MovieDir(template string) (string, error) {
+	if template == "" {
+		template = DefaultMovieDirTemplate
+	}
+	if err := ValidateMovieDirTemplate(template); err != nil {
+		return "", err
+	}
+	title := m.Title
+	if m.DisplayTitle != "" {
+		title = m.DisplayTitle
+	}
+	if title == "" {
+		title = "Manual Import"
+	}
+	year, decade := "Unknown Year", "Unknown Decade"
+	if len(m.Year) == 4 {
+		year = m.Year
+		decade = m.Year[:3] + "0s"
+	}
+	genre := m.Genre
+	if genre == "" {
+		genre = "Unknown Genre"
+	}
+	r := strings.NewReplacer(
+		"{name}", m.BaseFilename(),
+		"{title}", title,
+		"{year}", year,
+		"{decade}", decade,
+		"{genre}", genre,
+	)
+	segments := strings.Split(template, "/")
+	for i, segment := range segments {
+		segments[i] = textutil.SanitizeDisplayName(r.Replace(segment))
+	}
+	return strings.Join(segments, "/"), nil
+}
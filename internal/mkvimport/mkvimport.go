@@ -0,0 +1,328 @@
+// Package mkvimport turns a folder of already-ripped MKV files into a rip
+// spec so the files can enter the pipeline after the ripping stage.
+package mkvimport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/five82/spindle/internal/fingerprint"
+	"github.com/five82/spindle/internal/media/ffprobe"
+	"github.com/five82/spindle/internal/queue"
+	"github.com/five82/spindle/internal/ripspec"
+	"github.com/five82/spindle/internal/tmdb"
+)
+
+var probeFile = ffprobe.Inspect
+
+// File is one MKV found in an import folder.
+type File struct {
+	Path            string
+	Name            string
+	DurationSeconds int
+	SizeBytes       int64
+}
+
+// Scan lists the MKV files directly inside dir in name order and probes
+// each for its duration. Subdirectories are not descended into.
+func Scan(ctx context.Context, dir string) ([]File, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("resolve import dir: %w", err)
+	}
+	entries, err := os.ReadDir(abs)
+	if err != nil {
+		return nil, fmt.Errorf("read import dir: %w", err)
+	}
+
+	var files []File
+	for _, entry := range entries {
+		if entry.IsDir() || !IsMKV(entry.Name()) {
+			continue
+		}
+		f, err := ScanFile(ctx, filepath.Join(abs, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no .mkv files in %s", abs)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+// ScanFile stats and probes one MKV file for import.
+func ScanFile(ctx context.Context, path string) (File, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return File{}, fmt.Errorf("stat %s: %w", filepath.Base(path), err)
+	}
+	probe, err := probeFile(ctx, "", path)
+	if err != nil {
+		return File{}, fmt.Errorf("probe %s: %w", filepath.Base(path), err)
+	}
+	name := filepath.Base(path)
+	return File{
+		Path:            path,
+		Name:            strings.TrimSuffix(name, filepath.Ext(name)),
+		DurationSeconds: int(probe.DurationSeconds()),
+		SizeBytes:       info.Size(),
+	}, nil
+}
+
+// IsMKV reports whether name has an .mkv extension, in any case.
+func IsMKV(name string) bool {
+	return strings.EqualFold(filepath.Ext(name), ".mkv")
+}
+
+// Fingerprint derives the queue fingerprint from the files' content, so
+// importing the same files again, from any folder or under any names, is
+// caught as a duplicate.
+func Fingerprint(files []File) (string, error) {
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		paths = append(paths, f.Path)
+	}
+	fp, err := fingerprint.ContentHash(paths)
+	if err != nil {
+		return "", fmt.Errorf("fingerprint import files: %w", err)
+	}
+	return fp, nil
+}
+
+var titleYearPattern = regexp.MustCompile(`^(.*?)[\s._-]*[(\[]?((?:19|20)\d{2})[)\]]?$`)
+
+// InferTitle guesses a search title and year from a folder name such as
+// "Movie Name (2009)" or "Show.Name.2004".
+func InferTitle(dir string) (title, year string) {
+	name := strings.TrimSpace(filepath.Base(filepath.Clean(dir)))
+	if m := titleYearPattern.FindStringSubmatch(name); m != nil && strings.TrimSpace(m[1]) != "" {
+		name, year = m[1], m[2]
+	}
+	name = strings.NewReplacer(".", " ", "_", " ").Replace(name)
+	return strings.Join(strings.Fields(name), " "), year
+}
+
+// Hints are what the user states about an import up front. Any field left
+// zero is inferred or identified instead.
+type Hints struct {
+	MediaType string // "movie" or "tv"
+	Title     string
+	Year      string
+	TMDBID    int
+	Season    int
+	Episode   int // first episode number, assigned to files in name order
+}
+
+// Resolve produces the import's metadata. A TMDB ID is looked up directly
+// and must exist as the hinted media type; fuzzy title search runs only
+// without one. A hinted title replaces the TMDB title. The hinted season
+// applies only when the import resolves to TV.
+func Resolve(ctx context.Context, client *tmdb.Client, hints Hints, logger *slog.Logger) (ripspec.Metadata, error) {
+	if hints.TMDBID <= 0 {
+		meta, err := Identify(ctx, client, hints.Title, hints.Year, hints.MediaType, logger)
+		if meta.MediaType == "tv" {
+			meta.SeasonNumber = hints.Season
+		}
+		return meta, err
+	}
+	if hints.MediaType == "" {
+		return ripspec.Metadata{}, errors.New("a TMDB ID hint requires a media type")
+	}
+	details, err := client.GetDetails(ctx, hints.MediaType, hints.TMDBID)
+	if err != nil {
+		return ripspec.Metadata{}, fmt.Errorf("validate TMDB %s %d: %w", hints.MediaType, hints.TMDBID, err)
+	}
+	meta := details.Candidate(hints.MediaType).Metadata()
+	if hints.Title != "" {
+		meta.Title = hints.Title
+		if meta.MediaType == "tv" {
+			meta.ShowTitle = hints.Title
+		}
+	}
+	if hints.Year != "" {
+		meta.Year = hints.Year
+	}
+	if meta.MediaType == "tv" {
+		meta.SeasonNumber = hints.Season
+	}
+	return meta, nil
+}
+
+// SeedEpisodes numbers a TV import's episodes from first in file order and
+// fills titles and air dates from the TMDB season when it is available.
+// Seeded items need no episode identification; see EntryStage.
+func SeedEpisodes(env *ripspec.Envelope, first int, season *tmdb.Season) error {
+	if env.Metadata.MediaType != "tv" || first <= 0 {
+		return nil
+	}
+	details := make(map[int]tmdb.Episode)
+	if season != nil {
+		for _, ep := range season.Episodes {
+			details[ep.EpisodeNumber] = ep
+		}
+		if last := first + len(env.Episodes) - 1; len(details) > 0 && details[last].EpisodeNumber != last {
+			return fmt.Errorf("season %d has no episode %d for %d files starting at episode %d",
+				env.Metadata.SeasonNumber, last, len(env.Episodes), first)
+		}
+	}
+	for i := range env.Episodes {
+		ep := &env.Episodes[i]
+		ep.Episode = first + i
+		ep.EpisodeTitle = strings.TrimSpace(details[ep.Episode].Name)
+		ep.EpisodeAirDate = strings.TrimSpace(details[ep.Episode].AirDate)
+	}
+	return nil
+}
+
+// EntryStage is the stage an imported item starts at: after ripping, or
+// after episode identification when every TV episode number was given.
+func EntryStage(env *ripspec.Envelope) queue.Stage {
+	if env.Metadata.MediaType != "tv" || len(env.Episodes) == 0 {
+		return queue.StageEpisodeIdentification
+	}
+	for _, ep := range env.Episodes {
+		if ep.Episode <= 0 {
+			return queue.StageEpisodeIdentification
+		}
+	}
+	return queue.StageEncoding
+}
+
+// Identify searches TMDB for the import's title. mediaType "tv" runs a TV
+// search; otherwise a multi search runs and, when mediaType is "movie",
+// only movie results are considered. An error is returned when no result
+// is confident enough to use.
+func Identify(ctx context.Context, client *tmdb.Client, query, year, mediaType string, logger *slog.Logger) (ripspec.Metadata, error) {
+	var (
+		results []tmdb.SearchResult
+		err     error
+	)
+	if mediaType == "tv" {
+		results, err = client.SearchTV(ctx, query, year)
+	} else {
+		results, err = client.SearchMulti(ctx, query)
+	}
+	if err != nil {
+		return ripspec.Metadata{}, fmt.Errorf("tmdb search: %w", err)
+	}
+	if mediaType != "" {
+		results = slices.DeleteFunc(results, func(r tmdb.SearchResult) bool {
+			return r.MediaType != "" && r.MediaType != mediaType
+		})
+	}
+	yearNum, _ := strconv.Atoi(year)
+	best := tmdb.SelectBestResult(results, query, yearNum, tmdb.DefaultYearTolerance, 5, tmdb.DefaultMatchThreshold, logger)
+	if best == nil {
+		return ripspec.Metadata{}, fmt.Errorf("no confident TMDB match for %q", query)
+	}
+	resolved := best.MediaType
+	if resolved != "tv" {
+		resolved = "movie"
+	}
+	return best.Candidate(resolved).Metadata(), nil
+}
+
+// NewEnvelope fingerprints files by content (see Fingerprint) and builds
+// their rip spec under that fingerprint (see BuildEnvelope).
+func NewEnvelope(files []File, meta ripspec.Metadata) (ripspec.Envelope, error) {
+	fp, err := Fingerprint(files)
+	if err != nil {
+		return ripspec.Envelope{}, err
+	}
+	return BuildEnvelope(fp, files, meta)
+}
+
+// Encode returns the rip spec data and metadata_json an import is queued
+// with.
+func Encode(env *ripspec.Envelope) (ripSpecData, metadataJSON string, err error) {
+	ripSpecData, err = env.Encode()
+	if err != nil {
+		return "", "", err
+	}
+	metadataJSON, err = env.Metadata.ItemMetadataJSON()
+	if err != nil {
+		return "", "", err
+	}
+	return ripSpecData, metadataJSON, nil
+}
+
+// BuildEnvelope synthesizes the rip spec for imported files. Every file
+// becomes a title. Movies use the longest file as the main feature; TV
+// imports get one episode placeholder per file in name order for episode
+// identification to resolve. The chosen files are recorded as completed
+// ripped assets in place, so later stages read them without copying.
+func BuildEnvelope(fingerprint string, files []File, meta ripspec.Metadata) (ripspec.Envelope, error) {
+	if len(files) == 0 {
+		return ripspec.Envelope{}, errors.New("no files to import")
+	}
+	switch meta.MediaType {
+	case "movie", "tv":
+	default:
+		return ripspec.Envelope{}, fmt.Errorf("unsupported media type %q", meta.MediaType)
+	}
+	meta.Movie = meta.MediaType == "movie"
+
+	env := ripspec.Envelope{
+		Version:     ripspec.CurrentVersion,
+		Fingerprint: fingerprint,
+		Metadata:    meta,
+	}
+	for i, f := range files {
+		env.Titles = append(env.Titles, ripspec.Title{
+			ID:        i,
+			Name:      f.Name,
+			Duration:  f.DurationSeconds,
+			SizeBytes: f.SizeBytes,
+		})
+	}
+
+	if meta.MediaType == "movie" {
+		main := 0
+		for i, f := range files {
+			if f.DurationSeconds > files[main].DurationSeconds {
+				main = i
+			}
+		}
+		env.Assets.AddAsset(ripspec.AssetKindRipped, ripspec.Asset{
+			EpisodeKey: "main",
+			TitleID:    main,
+			Path:       files[main].Path,
+			Status:     ripspec.AssetStatusCompleted,
+		})
+		return env, nil
+	}
+
+	season := meta.SeasonNumber
+	if season <= 0 {
+		season = 1
+		env.Metadata.SeasonNumber = season
+	}
+	for i, f := range files {
+		key := ripspec.PlaceholderKey(season, i+1)
+		env.Episodes = append(env.Episodes, ripspec.Episode{
+			Key:            key,
+			TitleID:        i,
+			Season:         season,
+			RuntimeSeconds: f.DurationSeconds,
+		})
+		env.Assets.AddAsset(ripspec.AssetKindRipped, ripspec.Asset{
+			EpisodeKey: key,
+			TitleID:    i,
+			Path:       f.Path,
+			Status:     ripspec.AssetStatusCompleted,
+		})
+	}
+	return env, nil
+}
@@ -0,0 +1,236 @@
+package mkvimport
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/five82/spindle/internal/media/ffprobe"
+	"github.com/five82/spindle/internal/mediameta"
+	"github.com/five82/spindle/internal/queue"
+	"github.com/five82/spindle/internal/ripspec"
+	"github.com/five82/spindle/internal/tmdb"
+)
+
+// stubProbe reports durations by file name for the duration of the test.
+func stubProbe(t *testing.T, durations map[string]string) {
+	t.Helper()
+	orig := probeFile
+	probeFile = func(_ context.Context, _ string, path string) (*ffprobe.Result, error) {
+		return &ffprobe.Result{Format: ffprobe.Format{Duration: durations[filepath.Base(path)]}}, nil
+	}
+	t.Cleanup(func() { probeFile = orig })
+}
+
+func writeFiles(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFolderOfMKVsEntersAfterRipping(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "The Show (2004)")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFiles(t, dir, "b.mkv", "a.MKV", "notes.txt")
+	stubProbe(t, map[string]string{"a.MKV": "1320.4", "b.mkv": "1290.0"})
+
+	files, err := Scan(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if len(files) != 2 || files[0].Name != "a" || files[0].DurationSeconds != 1320 {
+		t.Fatalf("files = %+v, want a.MKV then b.mkv with probed durations", files)
+	}
+
+	title, year := InferTitle(dir)
+	if title != "The Show" || year != "2004" {
+		t.Fatalf("InferTitle = %q %q", title, year)
+	}
+
+	fp, err := Fingerprint(files)
+	if err != nil {
+		t.Fatalf("Fingerprint: %v", err)
+	}
+	env, err := NewEnvelope(files, ripspec.Metadata{ID: 42, Title: title, ShowTitle: title, MediaType: "tv", SeasonNumber: 2})
+	if err != nil {
+		t.Fatalf("NewEnvelope: %v", err)
+	}
+	if env.Fingerprint != fp {
+		t.Fatalf("envelope fingerprint = %q, want %q", env.Fingerprint, fp)
+	}
+	keys := env.AssetKeys()
+	if len(keys) != 2 || keys[0] != ripspec.PlaceholderKey(2, 1) {
+		t.Fatalf("asset keys = %v, want one placeholder per file", keys)
+	}
+	for _, key := range keys {
+		asset, ok := env.Assets.FindAsset(ripspec.AssetKindRipped, key)
+		if !ok || !asset.IsCompleted() {
+			t.Fatalf("ripped asset %s = %+v, want completed", key, asset)
+		}
+	}
+	data, metaJSON, err := Encode(&env)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := queue.Open(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	item, err := store.NewImportedRip(env.Metadata.QueueTitle(), fp, EntryStage(&env), data, metaJSON)
+	if err != nil {
+		t.Fatalf("NewImportedRip: %v", err)
+	}
+	specs := []queue.TaskSpec{
+		{Type: queue.StageIdentification},
+		{Type: queue.StageRipping, DependsOn: []queue.Stage{queue.StageIdentification}},
+		{Type: queue.StageEpisodeIdentification, DependsOn: []queue.Stage{queue.StageRipping}},
+		{Type: queue.StageEncoding, DependsOn: []queue.Stage{queue.StageIdentification}},
+		{Type: queue.StageAnalysis, DependsOn: []queue.Stage{queue.StageEpisodeIdentification}},
+	}
+	if err := store.EnsureTasks(item, specs); err != nil {
+		t.Fatal(err)
+	}
+	tasks, err := store.TasksForItem(item.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[queue.Stage]queue.TaskState{
+		queue.StageIdentification:        queue.TaskDone,
+		queue.StageRipping:               queue.TaskDone,
+		queue.StageEpisodeIdentification: queue.TaskPending,
+		queue.StageEncoding:              queue.TaskPending,
+		queue.StageAnalysis:              queue.TaskPending,
+	}
+	for _, task := range tasks {
+		if task.State != want[task.Type] {
+			t.Errorf("task %s = %s, want %s", task.Type, task.State, want[task.Type])
+		}
+	}
+
+	parsed, err := ripspec.Parse(item.RipSpecData)
+	if err != nil || parsed.Fingerprint != fp || len(parsed.Titles) != 2 {
+		t.Fatalf("persisted rip spec = %+v err=%v", parsed, err)
+	}
+	meta := mediameta.FromJSON(item.MetadataJSON, "")
+	if meta.ID != 42 || meta.ShowTitle != "The Show" || meta.MediaType != "tv" || meta.SeasonNumber != 2 {
+		t.Fatalf("persisted metadata = %+v", meta)
+	}
+}
+
+func TestBuildEnvelopeMovieUsesLongestFile(t *testing.T) {
+	files := []File{
+		{Path: "/rips/extra.mkv", DurationSeconds: 600},
+		{Path: "/rips/feature.mkv", DurationSeconds: 7200},
+	}
+	env, err := BuildEnvelope("fp", files, ripspec.Metadata{Title: "Heat", MediaType: "movie"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	asset, ok := env.Assets.FindAsset(ripspec.AssetKindRipped, "main")
+	if !ok || asset.Path != "/rips/feature.mkv" || asset.TitleID != 1 {
+		t.Fatalf("main asset = %+v, want the longest file", asset)
+	}
+	if _, err := BuildEnvelope("fp", files, ripspec.Metadata{MediaType: "music"}); err == nil {
+		t.Fatal("expected error for unsupported media type")
+	}
+}
+
+func TestResolveWithTMDBIDHintSkipsSearch(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/tv/1396":
+			_, _ = w.Write([]byte(`{"id":1396,"name":"Breaking Bad","first_air_date":"2008-01-20","poster_path":"/p.jpg"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	client := tmdb.New("key", srv.URL, "en-US", nil)
+	ctx := context.Background()
+
+	meta, err := Resolve(ctx, client, Hints{MediaType: "tv", TMDBID: 1396, Season: 2, Title: "Breaking Bad (Remastered)"}, nil)
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if meta.ID != 1396 || meta.MediaType != "tv" || meta.Year != "2008" || meta.PosterPath != "/p.jpg" || meta.SeasonNumber != 2 {
+		t.Fatalf("metadata = %+v, want the TMDB details for 1396 season 2", meta)
+	}
+	if meta.Title != "Breaking Bad (Remastered)" || meta.ShowTitle != meta.Title {
+		t.Fatalf("title = %q show = %q, want the hinted title", meta.Title, meta.ShowTitle)
+	}
+	for _, p := range paths {
+		if strings.HasPrefix(p, "/search/") {
+			t.Fatalf("fuzzy search %s ran despite a TMDB ID hint", p)
+		}
+	}
+
+	files := []File{{Path: "/rips/x.mkv", DurationSeconds: 2800}, {Path: "/rips/y.mkv", DurationSeconds: 2810}}
+	env, err := BuildEnvelope("fp", files, meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	season := &tmdb.Season{SeasonNumber: 2, Episodes: []tmdb.Episode{
+		{EpisodeNumber: 4, Name: "Down"},
+		{EpisodeNumber: 5, Name: "Breakage", AirDate: "2009-04-05"},
+	}}
+	if err := SeedEpisodes(&env, 4, season); err != nil {
+		t.Fatalf("SeedEpisodes: %v", err)
+	}
+	if ep := env.Episodes[1]; ep.Season != 2 || ep.Episode != 5 || ep.EpisodeTitle != "Breakage" || ep.EpisodeAirDate != "2009-04-05" {
+		t.Fatalf("second episode = %+v, want S02E05 Breakage", ep)
+	}
+	if got := EntryStage(&env); got != queue.StageEncoding {
+		t.Fatalf("entry stage = %s, want %s for fully numbered episodes", got, queue.StageEncoding)
+	}
+	if err := SeedEpisodes(&env, 5, season); err == nil {
+		t.Fatal("expected error when files run past the season's last episode")
+	}
+
+	if _, err := Resolve(ctx, client, Hints{MediaType: "movie", TMDBID: 999}, nil); err == nil {
+		t.Fatal("expected error for a TMDB ID the API does not know")
+	}
+}
+
+func TestResolveMovieIgnoresSeasonHint(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/movie/949":
+			_, _ = w.Write([]byte(`{"id":949,"title":"Heat","release_date":"1995-12-15"}`))
+		case "/search/multi":
+			_, _ = w.Write([]byte(`{"results":[{"id":949,"title":"Heat","release_date":"1995-12-15","media_type":"movie","vote_count":9000,"vote_average":7.9}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	client := tmdb.New("key", srv.URL, "en-US", nil)
+
+	// The CLI's --season defaults to 1 for every import.
+	for _, hints := range []Hints{
+		{MediaType: "movie", TMDBID: 949, Season: 1},
+		{Title: "Heat", Year: "1995", Season: 1},
+	} {
+		meta, err := Resolve(context.Background(), client, hints, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		if err != nil {
+			t.Fatalf("Resolve(%+v): %v", hints, err)
+		}
+		if meta.MediaType != "movie" || meta.SeasonNumber != 0 {
+			t.Fatalf("Resolve(%+v) = %s season %d, want a movie without a season", hints, meta.MediaType, meta.SeasonNumber)
+		}
+	}
+}
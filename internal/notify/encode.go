@@ -0,0 +1,71 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// EncodeStats summarizes a finished encode for the encode_complete
+// notification. Templates reach it as {{.Encode}}, e.g.
+// {{.Encode.Reduction}} or {{.Encode.Summary}}.
+type EncodeStats struct {
+	Files         int
+	Resolution    string
+	OriginalBytes int64
+	EncodedBytes  int64
+	Duration      time.Duration
+	Speed         float64 // average encode speed as a multiple of realtime
+}
+
+// Reduction returns how much smaller the output is than the input, in
+// percent, or 0 when the input size is unknown.
+func (s EncodeStats) Reduction() float64 {
+	if s.OriginalBytes <= 0 {
+		return 0
+	}
+	return (1 - float64(s.EncodedBytes)/float64(s.OriginalBytes)) * 100
+}
+
+// Summary renders the stats on one line, for example
+// "3 files, 1920x1080, 8.2 GiB -> 3.1 GiB (62.2% smaller), 1h12m5s at 2.35x".
+// Unknown values are left out.
+func (s EncodeStats) Summary() string {
+	var parts []string
+	switch s.Files {
+	case 0:
+	case 1:
+		parts = append(parts, "1 file")
+	default:
+		parts = append(parts, fmt.Sprintf("%d files", s.Files))
+	}
+	if s.Resolution != "" {
+		parts = append(parts, s.Resolution)
+	}
+	if s.OriginalBytes > 0 {
+		parts = append(parts, fmt.Sprintf("%s -> %s (%.1f%% smaller)", formatBytes(s.OriginalBytes), formatBytes(s.EncodedBytes), s.Reduction()))
+	}
+	if s.Duration > 0 {
+		timing := s.Duration.Round(time.Second).String()
+		if s.Speed > 0 {
+			timing += fmt.Sprintf(" at %.2fx", s.Speed)
+		}
+		parts = append(parts, timing)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatBytes(b int64) string {
+	const (
+		gib = 1024 * 1024 * 1024
+		mib = 1024 * 1024
+	)
+	switch {
+	case b >= gib:
+		return fmt.Sprintf("%.1f GiB", float64(b)/float64(gib))
+	case b >= mib:
+		return fmt.Sprintf("%.1f MiB", float64(b)/float64(mib))
+	default:
+		return fmt.Sprintf("%d B", b)
+	}
+}
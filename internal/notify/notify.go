@@ -19,21 +19,25 @@ const (
 	EventIdentificationComplete Event = "identification_complete"
 	EventRipCacheHit            Event = "rip_cache_hit"
 	EventRipComplete            Event = "rip_complete"
+	EventEncodeProgress         Event = "encode_progress"
 	EventEncodeComplete         Event = "encode_complete"
 	EventReviewRequired         Event = "review_required"
 	EventPipelineComplete       Event = "pipeline_complete"
 	EventQueueStarted           Event = "queue_started"
 	EventQueueCompleted         Event = "queue_completed"
+	EventLanePaused             Event = "lane_paused"
+	EventLaneResumed            Event = "lane_resumed"
 	EventError                  Event = "error"
 	EventTest                   Event = "test"
 )
 
 // Notifier sends notifications via ntfy.
 type Notifier struct {
-	topic   string
-	timeout time.Duration
-	client  *http.Client
-	logger  *slog.Logger
+	topic     string
+	timeout   time.Duration
+	client    *http.Client
+	logger    *slog.Logger
+	templates Templates
 }
 
 // New creates a Notifier. Returns nil if topic is empty (notifications disabled).
@@ -54,13 +58,44 @@ func New(topic string, timeoutSeconds int, logger *slog.Logger) *Notifier {
 	}
 }
 
+// WithTemplates compiles notifications.templates (see ParseTemplates) into
+// the notifier and returns it. A nil Notifier stays nil.
+func (n *Notifier) WithTemplates(raw map[string]string) (*Notifier, error) {
+	if n == nil {
+		return nil, nil
+	}
+	templates, err := ParseTemplates(raw)
+	if err != nil {
+		return nil, fmt.Errorf("notify: templates: %w", err)
+	}
+	n.templates = templates
+	return n, nil
+}
+
 // Send sends a notification. Returns nil if Notifier is nil (disabled).
 func (n *Notifier) Send(ctx context.Context, event Event, title, message string) error {
+	return n.SendFields(ctx, event, title, message, Fields{})
+}
+
+// SendFields sends a notification whose message template may use fields.
+// When the event's template fails to render, the built-in message is sent
+// instead. Returns nil if Notifier is nil (disabled).
+func (n *Notifier) SendFields(ctx context.Context, event Event, title, message string, fields Fields) error {
 	if n == nil {
 		return nil
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.topic, strings.NewReader(message))
+	body, err := n.templates.render(event, title, message, fields)
+	if err != nil {
+		n.logger.Warn("notification template failed; sending built-in message",
+			"event_type", "notification_template_failed",
+			"notification_event", string(event),
+			"error_hint", err.Error(),
+			"impact", "notification uses the default wording",
+		)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.topic, strings.NewReader(body))
 	if err != nil {
 		return fmt.Errorf("notify: create request: %w", err)
 	}
@@ -86,9 +121,9 @@ func (n *Notifier) Send(ctx context.Context, event Event, title, message string)
 
 func priority(event Event) string {
 	switch event {
-	case EventReviewRequired, EventError:
+	case EventReviewRequired, EventLanePaused, EventError:
 		return "high"
-	case EventRipCacheHit, EventTest:
+	case EventRipCacheHit, EventEncodeProgress, EventTest:
 		return "low"
 	default:
 		return "default"
@@ -97,14 +132,18 @@ func priority(event Event) string {
 
 func tags(event Event) string {
 	switch event {
-	case EventItemQueued, EventQueueStarted, EventQueueCompleted:
+	case EventItemQueued, EventQueueStarted, EventQueueCompleted, EventLaneResumed:
 		return "queue"
+	case EventLanePaused:
+		return "queue,warning"
 	case EventIdentificationComplete:
 		return "identify"
 	case EventRipCacheHit:
 		return "rip,cache"
 	case EventRipComplete:
 		return "rip"
+	case EventEncodeProgress:
+		return "encode,progress"
 	case EventEncodeComplete:
 		return "encode"
 	case EventReviewRequired:
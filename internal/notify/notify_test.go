@@ -5,7 +5,9 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewEmptyTopic(t *testing.T) {
@@ -42,11 +44,14 @@ func TestPriority(t *testing.T) {
 		{EventIdentificationComplete, "default"},
 		{EventRipCacheHit, "low"},
 		{EventRipComplete, "default"},
+		{EventEncodeProgress, "low"},
 		{EventEncodeComplete, "default"},
 		{EventReviewRequired, "high"},
 		{EventPipelineComplete, "default"},
 		{EventQueueStarted, "default"},
 		{EventQueueCompleted, "default"},
+		{EventLanePaused, "high"},
+		{EventLaneResumed, "default"},
 		{EventError, "high"},
 		{EventTest, "low"},
 	}
@@ -67,11 +72,14 @@ func TestTags(t *testing.T) {
 		{EventIdentificationComplete, "identify"},
 		{EventRipCacheHit, "rip,cache"},
 		{EventRipComplete, "rip"},
+		{EventEncodeProgress, "encode,progress"},
 		{EventEncodeComplete, "encode"},
 		{EventReviewRequired, "review,warning"},
 		{EventPipelineComplete, "complete"},
 		{EventQueueStarted, "queue"},
 		{EventQueueCompleted, "queue"},
+		{EventLanePaused, "queue,warning"},
+		{EventLaneResumed, "queue"},
 		{EventError, "error"},
 		{EventTest, "test"},
 	}
@@ -126,6 +134,97 @@ func TestSendHTTP(t *testing.T) {
 	}
 }
 
+func TestSendRendersTemplate(t *testing.T) {
+	var gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	n, err := New(srv.URL, 5, nil).WithTemplates(map[string]string{
+		"error": "#{{.Item}} {{.Title}} [{{.Stage}}] {{.Error}} ({{.Event}}) / {{.Message}}",
+	})
+	if err != nil {
+		t.Fatalf("WithTemplates: %v", err)
+	}
+	fields := Fields{Item: 7, Stage: "encoding", Error: "disk full"}
+	if err := n.SendFields(context.Background(), EventError, "Failed: Movie", "Processing stopped.", fields); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	want := "#7 Failed: Movie [encoding] disk full (error) / Processing stopped."
+	if gotBody != want {
+		t.Errorf("body = %q, want %q", gotBody, want)
+	}
+
+	// Events without a template keep the built-in message.
+	if err := n.Send(context.Background(), EventRipComplete, "Rip Complete", "Ripped Movie"); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if gotBody != "Ripped Movie" {
+		t.Errorf("untemplated body = %q, want built-in message", gotBody)
+	}
+}
+
+func TestEncodeStatsPopulateNotification(t *testing.T) {
+	var gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	stats := EncodeStats{
+		Files:         3,
+		Resolution:    "1920x1080",
+		OriginalBytes: 8 << 30,
+		EncodedBytes:  2 << 30,
+		Duration:      72*time.Minute + 5*time.Second,
+		Speed:         2.345,
+	}
+	if got, want := stats.Summary(), "3 files, 1920x1080, 8.0 GiB -> 2.0 GiB (75.0% smaller), 1h12m5s at 2.35x"; got != want {
+		t.Errorf("Summary = %q, want %q", got, want)
+	}
+	if got := (EncodeStats{Files: 1}).Summary(); got != "1 file" {
+		t.Errorf("sparse Summary = %q, want only the file count", got)
+	}
+
+	n, err := New(srv.URL, 5, nil).WithTemplates(map[string]string{
+		"encode_complete": "{{.Encode.Resolution}} {{printf \"%.0f\" .Encode.Reduction}}% {{.Encode.Speed}}x | {{.Encode.Summary}}",
+	})
+	if err != nil {
+		t.Fatalf("WithTemplates: %v", err)
+	}
+	if err := n.SendFields(context.Background(), EventEncodeComplete, "Encode Complete: Movie", "Encoded Movie", Fields{Item: 4, Encode: stats}); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if want := "1920x1080 75% 2.345x | " + stats.Summary(); gotBody != want {
+		t.Errorf("body = %q, want %q", gotBody, want)
+	}
+}
+
+func TestParseTemplatesRejectsInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  map[string]string
+		want string
+	}{
+		{"unknown field", map[string]string{"error": "{{.Reason}}"}, "Reason"},
+		{"unknown event", map[string]string{"disc_ejected": "{{.Title}}"}, "unknown event"},
+		{"malformed", map[string]string{"test": "{{.Title"}, "test"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTemplates(tt.raw)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("ParseTemplates error = %v, want mention of %q", err, tt.want)
+			}
+		})
+	}
+}
+
 func TestSendHTTPError(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -157,3 +256,69 @@ func TestSendNoTagsHeader(t *testing.T) {
 		t.Error("Tags header should not be set for unknown event")
 	}
 }
+
+func TestProgressPingerThrottle(t *testing.T) {
+	var sent int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+	n := New(srv.URL, 5, nil)
+	ctx := context.Background()
+
+	if NewProgressPinger(nil, time.Minute) != nil {
+		t.Fatal("pinger without a notifier should be nil")
+	}
+	if NewProgressPinger(n, 0) != nil {
+		t.Fatal("pinger with no interval should be nil")
+	}
+	var disabled *ProgressPinger
+	if disabled.Update(ctx, nil, "t", 50, "m") {
+		t.Fatal("nil pinger sent a ping")
+	}
+
+	t.Run("spacing", func(t *testing.T) {
+		sent = 0
+		clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		p := NewProgressPinger(n, time.Second)
+		p.now = func() time.Time { return clock }
+		p.lastSent = clock
+
+		steps := []struct {
+			advance time.Duration
+			percent float64
+			want    bool
+		}{
+			{10 * time.Second, 10, false}, // rate-limited despite the short interval
+			{time.Minute, 26, true},       // spaced out
+			{30 * time.Second, 60, false}, // too soon after the last ping
+			{time.Minute, 100, false},     // completion has its own notification
+		}
+		for i, s := range steps {
+			clock = clock.Add(s.advance)
+			if got := p.Update(ctx, nil, "Encoding", s.percent, "progress"); got != s.want {
+				t.Errorf("step %d (%.0f%%): sent = %v, want %v", i, s.percent, got, s.want)
+			}
+		}
+		if sent != 1 {
+			t.Fatalf("delivered %d pings, want 1", sent)
+		}
+	})
+
+	t.Run("interval", func(t *testing.T) {
+		sent = 0
+		clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		p := NewProgressPinger(n, 15*time.Minute)
+		p.now = func() time.Time { return clock }
+		p.lastSent = clock
+
+		for minute := 1; minute <= 60; minute++ {
+			clock = clock.Add(time.Minute)
+			p.Update(ctx, nil, "Encoding", float64(minute), "progress")
+		}
+		if sent != 4 {
+			t.Fatalf("delivered %d pings over an hour, want 4", sent)
+		}
+	})
+}
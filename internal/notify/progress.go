@@ -0,0 +1,60 @@
+package notify
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// minProgressSpacing is the shortest gap between two progress pings,
+// whatever the configured interval, so the topic cannot be flooded.
+const minProgressSpacing = time.Minute
+
+// ProgressPinger sends throttled progress notifications for a long-running
+// task, so a daemon that is busy can be told apart from one that is stuck.
+// A ping is due once interval has passed since the previous ping (or since
+// the pinger was created), and never sooner than minProgressSpacing after
+// the previous one. A nil ProgressPinger sends nothing.
+type ProgressPinger struct {
+	notifier *Notifier
+	interval time.Duration
+	lastSent time.Time
+	now      func() time.Time // injectable clock for testing
+}
+
+// NewProgressPinger returns a pinger for one task, or nil when notifications
+// are disabled or no interval is set.
+func NewProgressPinger(n *Notifier, interval time.Duration) *ProgressPinger {
+	if n == nil || interval <= 0 {
+		return nil
+	}
+	p := &ProgressPinger{notifier: n, interval: interval, now: time.Now}
+	p.lastSent = p.now()
+	return p
+}
+
+// due reports whether a ping should go out at percent.
+func (p *ProgressPinger) due(percent float64, now time.Time) bool {
+	if percent >= 100 {
+		return false
+	}
+	gap := now.Sub(p.lastSent)
+	return gap >= minProgressSpacing && gap >= p.interval
+}
+
+// Update records progress (0-100) and sends message as an encode progress
+// notification when a ping is due. It reports whether a ping was sent; a
+// failed delivery still counts, so an unreachable topic is not retried on
+// every update.
+func (p *ProgressPinger) Update(ctx context.Context, logger *slog.Logger, title string, percent float64, message string, attrs ...any) bool {
+	if p == nil {
+		return false
+	}
+	now := p.now()
+	if !p.due(percent, now) {
+		return false
+	}
+	p.lastSent = now
+	_ = SendLogged(ctx, p.notifier, logger, EventEncodeProgress, title, message, attrs...)
+	return true
+}
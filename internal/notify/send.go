@@ -10,12 +10,19 @@ import (
 // SendLogged sends a notification and records the outcome in the supplied logger.
 // attrs may include item_id or any other context that should accompany the log.
 func SendLogged(ctx context.Context, notifier *Notifier, logger *slog.Logger, event Event, title, message string, attrs ...any) error {
+	return SendItemLogged(ctx, notifier, logger, event, Fields{}, title, message, attrs...)
+}
+
+// SendItemLogged is SendLogged for a notification about one queue item:
+// fields feed the event's message template and are not logged, so callers
+// whose logger already carries item_id do not repeat it.
+func SendItemLogged(ctx context.Context, notifier *Notifier, logger *slog.Logger, event Event, fields Fields, title, message string, attrs ...any) error {
 	if notifier == nil {
 		return nil
 	}
 	logger = logs.Default(logger)
 
-	if err := notifier.Send(ctx, event, title, message); err != nil {
+	if err := notifier.SendFields(ctx, event, title, message, fields); err != nil {
 		base := []any{
 			"event_type", "notification_failed",
 			"notification_event", string(event),
@@ -0,0 +1,119 @@
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// Events lists every notification event type, the keys accepted by
+// notifications.templates.
+var Events = []Event{
+	EventItemQueued,
+	EventIdentificationComplete,
+	EventRipCacheHit,
+	EventRipComplete,
+	EventEncodeProgress,
+	EventEncodeComplete,
+	EventReviewRequired,
+	EventPipelineComplete,
+	EventQueueStarted,
+	EventQueueCompleted,
+	EventLanePaused,
+	EventLaneResumed,
+	EventError,
+	EventTest,
+}
+
+// Fields carries the facts about a notification's subject that a message
+// template may use beyond its title and built-in message. Zero values mean
+// the event has no such fact.
+type Fields struct {
+	Item   int64       // queue item ID
+	Stage  string      // pipeline stage
+	Error  string      // failure reason
+	Encode EncodeStats // encode results, for encode_complete
+}
+
+// templateData is the documented template field set: {{.Event}}, {{.Title}},
+// {{.Message}}, {{.Item}}, {{.Stage}}, {{.Error}}, and {{.Encode}} (see
+// EncodeStats). Message is the built-in text, so a template can wrap it
+// rather than replace it.
+type templateData struct {
+	Event   string
+	Title   string
+	Message string
+	Item    int64
+	Stage   string
+	Error   string
+	Encode  EncodeStats
+}
+
+// Templates maps an event to the template that renders its message.
+type Templates map[Event]*template.Template
+
+// ParseTemplates compiles message templates keyed by event name. It rejects
+// unknown events, malformed templates, and references to fields outside the
+// documented set, so a bad template fails at config load instead of at the
+// first notification.
+func ParseTemplates(raw map[string]string) (Templates, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	names := make([]string, 0, len(raw))
+	for name := range raw {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	templates := make(Templates, len(raw))
+	for _, name := range names {
+		event := Event(name)
+		if !slices.Contains(Events, event) {
+			return nil, fmt.Errorf("unknown event %q (want one of %s)", name, eventNames())
+		}
+		tmpl, err := template.New(name).Option("missingkey=error").Parse(raw[name])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if err := tmpl.Execute(&bytes.Buffer{}, templateData{}); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		templates[event] = tmpl
+	}
+	return templates, nil
+}
+
+func eventNames() string {
+	names := make([]string, len(Events))
+	for i, event := range Events {
+		names[i] = string(event)
+	}
+	return strings.Join(names, ", ")
+}
+
+// render returns the message for event: the configured template's output, or
+// message unchanged when the event has no template. A template that fails at
+// send time also yields message, along with the error.
+func (t Templates) render(event Event, title, message string, fields Fields) (string, error) {
+	tmpl, ok := t[event]
+	if !ok {
+		return message, nil
+	}
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, templateData{
+		Event:   string(event),
+		Title:   title,
+		Message: message,
+		Item:    fields.Item,
+		Stage:   fields.Stage,
+		Error:   fields.Error,
+		Encode:  fields.Encode,
+	})
+	if err != nil {
+		return message, fmt.Errorf("render %s template: %w", event, err)
+	}
+	return buf.String(), nil
+}
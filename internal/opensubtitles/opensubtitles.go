@@ -15,6 +15,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -29,6 +30,7 @@ type Client struct {
 	baseURL    string
 	logger     *slog.Logger
 	client     *http.Client
+	rateMu     sync.Mutex // serializes rateLimit across concurrent episodes
 	lastCall   time.Time
 	rateDelay  time.Duration
 	maxRetries int
@@ -83,6 +85,8 @@ type SubtitleAttributes struct {
 	Language         string         `json:"language"`
 	Release          string         `json:"release"`
 	DownloadCount    int            `json:"download_count"`
+	Ratings          float64        `json:"ratings"`
+	Votes            int            `json:"votes"`
 	ForeignPartsOnly bool           `json:"foreign_parts_only"`
 	HearingImpaired  bool           `json:"hearing_impaired"`
 	Files            []SubtitleFile `json:"files"`
@@ -105,6 +109,7 @@ type searchResponse struct {
 }
 
 // Search queries for subtitles by TMDB ID, season/episode, and languages.
+// An episode search always sends its season, so season 0 finds specials.
 func (c *Client) Search(ctx context.Context, tmdbID int, season, episode int, languages []string) ([]SubtitleResult, error) {
 	if c == nil {
 		return nil, fmt.Errorf("opensubtitles: client not configured")
@@ -117,7 +122,7 @@ func (c *Client) Search(ctx context.Context, tmdbID int, season, episode int, la
 
 	params := url.Values{}
 	params.Set("tmdb_id", fmt.Sprintf("%d", tmdbID))
-	if season > 0 {
+	if season > 0 || episode > 0 {
 		params.Set("season_number", fmt.Sprintf("%d", season))
 	}
 	if episode > 0 {
@@ -212,6 +217,8 @@ func (c *Client) CheckHealth(ctx context.Context) error {
 
 // rateLimit sleeps if needed to maintain the minimum delay between API calls.
 func (c *Client) rateLimit() {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
 	if c.lastCall.IsZero() {
 		c.lastCall = time.Now()
 		return
@@ -0,0 +1,110 @@
+package organizer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/five82/spindle/internal/logs"
+	"github.com/five82/spindle/internal/ripspec"
+)
+
+// artworkDir returns where local artwork belongs: the movie folder itself,
+// or the series folder above a TV library path's season folder.
+func artworkDir(meta ripspec.Metadata, libraryPath string) string {
+	if meta.MediaType == "tv" {
+		return filepath.Dir(libraryPath)
+	}
+	return libraryPath
+}
+
+// downloadArtwork saves the TMDB poster and backdrop as poster.jpg and
+// fanart.jpg, the names Jellyfin and Plex pick up as local artwork. Missing
+// images and download failures are logged and never fail the stage.
+func (h *Handler) downloadArtwork(ctx context.Context, logger *slog.Logger, meta ripspec.Metadata, libraryPath string) {
+	if !h.cfg.Library.DownloadArtwork {
+		return
+	}
+	if h.tmdbClient == nil {
+		if h.cfg.Offline {
+			logger.Info("artwork download skipped",
+				"decision_type", logs.DecisionArtworkDownload,
+				"decision_result", "skipped",
+				"decision_reason", "offline mode: TMDB client disabled",
+			)
+		}
+		return
+	}
+	if meta.PosterPath == "" && meta.BackdropPath == "" {
+		logger.Info("artwork download skipped",
+			"decision_type", logs.DecisionArtworkDownload,
+			"decision_result", "skipped",
+			"decision_reason", "identification has no TMDB images",
+		)
+		return
+	}
+
+	images, err := h.tmdbClient.GetImageConfig(ctx)
+	if err != nil {
+		logger.Warn("TMDB image configuration unavailable",
+			"event_type", "artwork_config_error",
+			"error_hint", err.Error(),
+			"impact", "library item has no local artwork",
+		)
+		return
+	}
+
+	size := h.cfg.Library.ArtworkSize
+	dir := artworkDir(meta, libraryPath)
+	for _, art := range []struct {
+		name string
+		url  string
+	}{
+		{"poster.jpg", images.PosterURL(meta.PosterPath, size)},
+		{"fanart.jpg", images.BackdropURL(meta.BackdropPath, size)},
+	} {
+		h.saveArtwork(ctx, logger, art.url, filepath.Join(dir, art.name))
+	}
+}
+
+// saveArtwork downloads one image unless it has no URL or the file is
+// already present and overwriting is disabled.
+func (h *Handler) saveArtwork(ctx context.Context, logger *slog.Logger, imageURL, destPath string) {
+	if imageURL == "" {
+		logger.Info("artwork image skipped",
+			"decision_type", logs.DecisionArtworkDownload,
+			"decision_result", "skipped",
+			"decision_reason", "TMDB has no image of this type",
+			"path", destPath,
+		)
+		return
+	}
+	if !h.cfg.Library.OverwriteExisting {
+		if _, err := os.Stat(destPath); err == nil {
+			logger.Info("artwork image skipped",
+				"decision_type", logs.DecisionArtworkDownload,
+				"decision_result", "skipped",
+				"decision_reason", "file already exists",
+				"path", destPath,
+			)
+			return
+		}
+	}
+	if err := h.tmdbClient.DownloadImage(ctx, imageURL, destPath); err != nil {
+		logger.Warn("artwork download failed",
+			"event_type", "artwork_download_error",
+			"error_hint", err.Error(),
+			"impact", "library item is missing local artwork",
+			"path", destPath,
+		)
+		return
+	}
+	logger.Info("artwork image saved",
+		"decision_type", logs.DecisionArtworkDownload,
+		"decision_result", "downloaded",
+		"decision_reason", fmt.Sprintf("library.download_artwork enabled (%s)", h.cfg.Library.ArtworkSize),
+		"path", destPath,
+	)
+}
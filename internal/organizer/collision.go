@@ -0,0 +1,72 @@
+package organizer
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/five82/spindle/internal/config"
+	"github.com/five82/spindle/internal/fingerprint"
+)
+
+// collisionNow is the clock behind the timestamp collision suffix.
+var collisionNow = time.Now
+
+// maxCollisionSuffix bounds the numeric suffix search.
+const maxCollisionSuffix = 1000
+
+// uniqueDestPath returns dst when nothing exists there. Otherwise it inserts
+// a suffix chosen by strategy before the extension and, when that name is
+// taken as well, appends " (1)", " (2)", ... until a free name is found, so
+// the result never names an existing file.
+func uniqueDestPath(dst, src, strategy string) (string, error) {
+	free, err := pathFree(dst)
+	if err != nil || free {
+		return dst, err
+	}
+	ext := filepath.Ext(dst)
+	base := strings.TrimSuffix(dst, ext)
+
+	start := 1
+	switch strategy {
+	case config.CollisionSuffixHash:
+		sum, err := fingerprint.ContentHash([]string{src})
+		if err != nil {
+			return "", fmt.Errorf("hash %s: %w", src, err)
+		}
+		base += " [" + sum[:8] + "]"
+		start = 0
+	case config.CollisionSuffixTimestamp:
+		base += " (" + collisionNow().UTC().Format("20060102-150405") + ")"
+		start = 0
+	}
+	for n := start; n <= maxCollisionSuffix; n++ {
+		candidate := base + ext
+		if n > 0 {
+			candidate = fmt.Sprintf("%s (%d)%s", base, n, ext)
+		}
+		free, err := pathFree(candidate)
+		if err != nil {
+			return "", err
+		}
+		if free {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("no free name for %s after %d attempts", dst, maxCollisionSuffix)
+}
+
+func pathFree(path string) (bool, error) {
+	_, err := os.Lstat(path)
+	if err == nil {
+		return false, nil
+	}
+	if errors.Is(err, fs.ErrNotExist) {
+		return true, nil
+	}
+	return false, err
+}
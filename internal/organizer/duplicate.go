@@ -0,0 +1,89 @@
+package organizer
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/five82/spindle/internal/logs"
+	"github.com/five82/spindle/internal/mediameta"
+	"github.com/five82/spindle/internal/ripspec"
+	"github.com/five82/spindle/internal/stage"
+)
+
+// duplicateReviewReason is the review reason for an item whose library
+// destination is already taken.
+const duplicateReviewReason = "already in library"
+
+// splitLibraryDuplicates partitions keys into those free to place in the
+// library and those whose library file already exists, e.g. a re-rip of a
+// disc organized before. Duplicates flag the item for review rather than
+// being skipped or placed under a suffixed name; a reviewer approves with
+// replace to overwrite the library copy or rejects to keep it. Nothing is
+// held back when overwrite_existing or a replace approval is in effect.
+func (h *Handler) splitLibraryDuplicates(
+	logger *slog.Logger,
+	sess *stage.Session,
+	meta *mediameta.Metadata,
+	sourceStage string,
+	keys []string,
+) (libraryKeys, duplicateKeys []string) {
+	env := sess.Env
+	if h.cfg.Library.OverwriteExisting || env.Attributes.ReplaceExisting || len(keys) == 0 {
+		return keys, nil
+	}
+	libraryPath, err := meta.LibraryPath(h.cfg.Paths.LibraryDir, h.cfg.Library.MoviesDir, h.cfg.Library.TVDir, h.cfg.Library.MovieDirTemplate())
+	if err != nil {
+		// placeInLibrary reports the unresolvable path.
+		return keys, nil
+	}
+	var existing []string
+	for _, key := range keys {
+		path, ok := existingLibraryFile(env, meta, sourceStage, libraryPath, key, h.cfg.Library.EpisodeFormat)
+		if !ok {
+			libraryKeys = append(libraryKeys, key)
+			continue
+		}
+		duplicateKeys = append(duplicateKeys, key)
+		existing = append(existing, filepath.Base(path))
+		logger.Info("library file already exists; holding for review",
+			"decision_type", logs.DecisionOrganizeDuplicate,
+			"decision_result", "review",
+			"decision_reason", duplicateReviewReason,
+			"episode_key", key,
+			"path", path,
+		)
+	}
+	if len(duplicateKeys) > 0 {
+		sess.AddReviewReason(fmt.Sprintf("%s: %s", duplicateReviewReason, strings.Join(existing, ", ")))
+	}
+	return libraryKeys, duplicateKeys
+}
+
+// existingLibraryFile returns the file already at key's library destination
+// that this item did not put there. The item's own recorded placement and a
+// file smaller than the source (a partial copy from an interrupted attempt)
+// do not count.
+func existingLibraryFile(env *ripspec.Envelope, meta *mediameta.Metadata, sourceStage, libraryPath, key, episodeFormat string) (string, bool) {
+	asset, ok := env.Assets.FindAsset(sourceStage, key)
+	if !ok || !asset.IsCompleted() {
+		return "", false
+	}
+	destPath := filepath.Join(episodeLibraryDir(env, libraryPath, key), destFilename(env, meta, key, filepath.Ext(asset.Path), episodeFormat))
+	if filepath.Clean(asset.Path) == filepath.Clean(destPath) {
+		return "", false
+	}
+	if final, ok := env.Assets.FindAsset(ripspec.AssetKindFinal, key); ok && filepath.Clean(final.Path) == filepath.Clean(destPath) {
+		return "", false
+	}
+	info, err := os.Stat(destPath)
+	if err != nil {
+		return "", false
+	}
+	if srcInfo, err := os.Stat(asset.Path); err == nil && info.Size() < srcInfo.Size() {
+		return "", false
+	}
+	return destPath, true
+}
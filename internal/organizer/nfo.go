@@ -0,0 +1,215 @@
+package organizer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/five82/spindle/internal/logs"
+	"github.com/five82/spindle/internal/ripspec"
+)
+
+// NFO documents follow the Kodi schema that Jellyfin and Emby also read:
+// <movie> and <episodedetails> sit next to the media file with the same
+// basename, and <tvshow> lives in the series folder as tvshow.nfo.
+
+type nfoUniqueID struct {
+	Type    string `xml:"type,attr"`
+	Default bool   `xml:"default,attr,omitempty"`
+	Value   string `xml:",chardata"`
+}
+
+type movieNFO struct {
+	XMLName   xml.Name      `xml:"movie"`
+	Title     string        `xml:"title"`
+	Year      string        `xml:"year,omitempty"`
+	Premiered string        `xml:"premiered,omitempty"`
+	Plot      string        `xml:"plot,omitempty"`
+	UniqueIDs []nfoUniqueID `xml:"uniqueid"`
+}
+
+type tvShowNFO struct {
+	XMLName   xml.Name      `xml:"tvshow"`
+	Title     string        `xml:"title"`
+	Year      string        `xml:"year,omitempty"`
+	Premiered string        `xml:"premiered,omitempty"`
+	Plot      string        `xml:"plot,omitempty"`
+	UniqueIDs []nfoUniqueID `xml:"uniqueid"`
+}
+
+type episodeNFO struct {
+	XMLName   xml.Name `xml:"episodedetails"`
+	Title     string   `xml:"title"`
+	ShowTitle string   `xml:"showtitle,omitempty"`
+	Season    int      `xml:"season"`
+	Episode   int      `xml:"episode"`
+	Aired     string   `xml:"aired,omitempty"`
+}
+
+// buildMovieNFO renders the <movie> document for the identified title.
+func buildMovieNFO(meta ripspec.Metadata) ([]byte, error) {
+	return marshalNFO(movieNFO{
+		Title:     nfoText(meta.Title),
+		Year:      nfoText(meta.Year),
+		Premiered: nfoText(meta.ReleaseDate),
+		Plot:      nfoText(meta.Overview),
+		UniqueIDs: nfoUniqueIDs(meta),
+	})
+}
+
+// buildTVShowNFO renders the series-level <tvshow> document.
+func buildTVShowNFO(meta ripspec.Metadata) ([]byte, error) {
+	return marshalNFO(tvShowNFO{
+		Title:     nfoText(showTitle(meta)),
+		Year:      nfoText(meta.Year),
+		Premiered: nfoText(meta.FirstAirDate),
+		Plot:      nfoText(meta.Overview),
+		UniqueIDs: nfoUniqueIDs(meta),
+	})
+}
+
+// buildEpisodeNFO renders the <episodedetails> document for one episode.
+func buildEpisodeNFO(meta ripspec.Metadata, ep ripspec.Episode) ([]byte, error) {
+	return marshalNFO(episodeNFO{
+		Title:     nfoText(ep.EpisodeTitle),
+		ShowTitle: nfoText(showTitle(meta)),
+		Season:    ep.Season,
+		Episode:   ep.Episode,
+		Aired:     nfoText(ep.EpisodeAirDate),
+	})
+}
+
+func showTitle(meta ripspec.Metadata) string {
+	if meta.ShowTitle != "" {
+		return meta.ShowTitle
+	}
+	return meta.Title
+}
+
+func nfoUniqueIDs(meta ripspec.Metadata) []nfoUniqueID {
+	var ids []nfoUniqueID
+	if meta.ID > 0 {
+		ids = append(ids, nfoUniqueID{Type: "tmdb", Default: true, Value: strconv.Itoa(meta.ID)})
+	}
+	if imdb := nfoText(meta.IMDBID); imdb != "" {
+		ids = append(ids, nfoUniqueID{Type: "imdb", Value: imdb})
+	}
+	return ids
+}
+
+func marshalNFO(doc any) ([]byte, error) {
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal nfo: %w", err)
+	}
+	return append([]byte(xml.Header), append(body, '\n')...), nil
+}
+
+// nfoText strips characters XML 1.0 cannot carry (control characters other
+// than tab and newline) and surrounding whitespace. Markup characters are
+// escaped by the encoder.
+func nfoText(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' {
+			return r
+		}
+		if unicode.IsControl(r) || r == unicode.ReplacementChar || r == 0xFFFE || r == 0xFFFF {
+			return -1
+		}
+		return r
+	}, s)
+	return strings.TrimSpace(s)
+}
+
+// nfoPathFor returns the sidecar path sharing the media file's basename.
+func nfoPathFor(mediaPath string) string {
+	return strings.TrimSuffix(mediaPath, filepath.Ext(mediaPath)) + ".nfo"
+}
+
+// writeMediaNFO writes the movie or episode sidecar for a copied library
+// file. Failures are logged and never fail the stage.
+func (h *Handler) writeMediaNFO(logger *slog.Logger, env *ripspec.Envelope, key, mediaPath string) {
+	if !h.cfg.Library.WriteNFO {
+		return
+	}
+	var (
+		body []byte
+		err  error
+	)
+	if env.Metadata.MediaType == "tv" {
+		ep := env.EpisodeByKey(key)
+		if ep == nil || ep.Episode <= 0 {
+			logger.Info("nfo sidecar skipped",
+				"decision_type", logs.DecisionNFOWrite,
+				"decision_result", "skipped",
+				"decision_reason", "episode not identified",
+				"episode_key", key,
+			)
+			return
+		}
+		body, err = buildEpisodeNFO(env.Metadata, *ep)
+	} else {
+		body, err = buildMovieNFO(env.Metadata)
+	}
+	if err == nil {
+		err = h.writeNFOFile(logger, nfoPathFor(mediaPath), body)
+	}
+	if err != nil {
+		logger.Warn("nfo sidecar write failed",
+			"event_type", "nfo_write_error",
+			"error_hint", err.Error(),
+			"impact", "media server falls back to its own metadata lookup",
+			"episode_key", key,
+		)
+	}
+}
+
+// writeShowNFO writes tvshow.nfo into the series folder above a TV library
+// path's season folder.
+func (h *Handler) writeShowNFO(logger *slog.Logger, meta ripspec.Metadata, libraryPath string) {
+	if !h.cfg.Library.WriteNFO || meta.MediaType != "tv" {
+		return
+	}
+	body, err := buildTVShowNFO(meta)
+	if err == nil {
+		err = h.writeNFOFile(logger, filepath.Join(filepath.Dir(libraryPath), "tvshow.nfo"), body)
+	}
+	if err != nil {
+		logger.Warn("nfo sidecar write failed",
+			"event_type", "nfo_write_error",
+			"error_hint", err.Error(),
+			"impact", "media server falls back to its own metadata lookup",
+		)
+	}
+}
+
+// writeNFOFile writes body to path unless an NFO is already present and
+// overwriting is disabled, so hand-edited sidecars survive re-imports.
+func (h *Handler) writeNFOFile(logger *slog.Logger, path string, body []byte) error {
+	if !h.cfg.Library.OverwriteExisting {
+		if _, err := os.Stat(path); err == nil {
+			logger.Info("nfo sidecar skipped",
+				"decision_type", logs.DecisionNFOWrite,
+				"decision_result", "skipped",
+				"decision_reason", "file already exists",
+				"path", path,
+			)
+			return nil
+		}
+	}
+	if err := os.WriteFile(path, body, 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	logger.Info("nfo sidecar written",
+		"decision_type", logs.DecisionNFOWrite,
+		"decision_result", "written",
+		"decision_reason", "library.write_nfo enabled",
+		"path", path,
+	)
+	return nil
+}
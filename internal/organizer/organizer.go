@@ -22,7 +22,9 @@ import (
 	"github.com/five82/spindle/internal/queue"
 	"github.com/five82/spindle/internal/ripspec"
 	"github.com/five82/spindle/internal/stage"
+	"github.com/five82/spindle/internal/stagingdir"
 	"github.com/five82/spindle/internal/textutil"
+	"github.com/five82/spindle/internal/tmdb"
 )
 
 const reviewReasonDirMaxBytes = 96
@@ -31,14 +33,15 @@ const copyProgressLogInterval = 3 * time.Minute
 
 // Handler implements stage.Handler for organization.
 type Handler struct {
-	cfg      *config.Config
-	jfClient *jellyfin.Client
-	notifier *notify.Notifier
+	cfg        *config.Config
+	jfClient   *jellyfin.Client
+	tmdbClient *tmdb.Client // artwork downloads; nil disables them
+	notifier   *notify.Notifier
 }
 
 // New creates an organization handler.
-func New(cfg *config.Config, jfClient *jellyfin.Client, notifier *notify.Notifier) *Handler {
-	return &Handler{cfg: cfg, jfClient: jfClient, notifier: notifier}
+func New(cfg *config.Config, jfClient *jellyfin.Client, tmdbClient *tmdb.Client, notifier *notify.Notifier) *Handler {
+	return &Handler{cfg: cfg, jfClient: jfClient, tmdbClient: tmdbClient, notifier: notifier}
 }
 
 // Run executes the organization stage.
@@ -89,6 +92,8 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 		}
 
 		libraryKeys, reviewKeys := partitionTVOrganizationKeys(env)
+		libraryKeys, duplicateKeys := h.splitLibraryDuplicates(logger, sess, &meta, sourceStage, libraryKeys)
+		reviewKeys = append(reviewKeys, duplicateKeys...)
 		if len(libraryKeys) == 0 {
 			logger.Info("item routed to review",
 				"decision_type", logs.DecisionOrganizeRoute,
@@ -115,7 +120,7 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 		)
 
 		if _, err := h.placeInLibrary(ctx, logger, sess, &meta, sourceStage, libraryKeys); err != nil {
-			return err
+			return h.reviewAfterFSFailure(ctx, logger, sess, &meta, sourceStage, err)
 		}
 		if len(reviewKeys) > 0 {
 			if _, _, err := h.copyAssetsToDir(ctx, logger, sess, &meta, sourceStage, reviewPathForItem(h.cfg.Paths.ReviewDir, item), reviewKeys, "review"); err != nil {
@@ -129,11 +134,32 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 		reviewCount = len(reviewKeys)
 		_ = sess.Progress(100, fmt.Sprintf("Available in library (%d episodes, %d to review)", libraryCount, reviewCount))
 	} else {
-		copied, err := h.placeInLibrary(ctx, logger, sess, &meta, sourceStage, keys)
+		libraryKeys, duplicateKeys := h.splitLibraryDuplicates(logger, sess, &meta, sourceStage, keys)
+		if len(libraryKeys) == 0 {
+			if err := h.routeToReview(ctx, logger, sess, &meta, sourceStage, duplicateKeys); err != nil {
+				return err
+			}
+			reviewCount = len(duplicateKeys)
+			h.sendTerminalNotification(ctx, logger, sess, libraryCount, reviewCount)
+			logger.Debug("organization stage completed",
+				"event_type", "stage_complete",
+				"stage", "organizing",
+				"library_count", libraryCount,
+				"review_count", reviewCount,
+			)
+			return nil
+		}
+		copied, err := h.placeInLibrary(ctx, logger, sess, &meta, sourceStage, libraryKeys)
 		if err != nil {
-			return err
+			return h.reviewAfterFSFailure(ctx, logger, sess, &meta, sourceStage, err)
+		}
+		if len(duplicateKeys) > 0 {
+			if _, _, err := h.copyAssetsToDir(ctx, logger, sess, &meta, sourceStage, reviewPathForItem(h.cfg.Paths.ReviewDir, item), duplicateKeys, "review"); err != nil {
+				return err
+			}
 		}
 		libraryCount = copied
+		reviewCount = len(duplicateKeys)
 		if err := sess.Save(); err != nil {
 			return err
 		}
@@ -144,7 +170,8 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 
 // placeInLibrary copies the given asset keys into the resolved library
 // destination (task: organize). It resolves the library path from metadata,
-// ensures the directory exists, and runs the per-asset verified copy loop.
+// ensures the directory exists, runs the per-asset verified copy loop, and
+// then writes the series NFO and fetches TMDB artwork when enabled.
 func (h *Handler) placeInLibrary(
 	ctx context.Context,
 	logger *slog.Logger,
@@ -157,17 +184,24 @@ func (h *Handler) placeInLibrary(
 		h.cfg.Paths.LibraryDir,
 		h.cfg.Library.MoviesDir,
 		h.cfg.Library.TVDir,
+		h.cfg.Library.MovieDirTemplate(),
 	)
 	if err != nil {
 		return 0, fmt.Errorf("resolve library path: %w", err)
 	}
-	if err := os.MkdirAll(libraryPath, 0o755); err != nil {
+	if err := retryFS(ctx, logger, "create library dir", func() error {
+		return os.MkdirAll(libraryPath, 0o755)
+	}); err != nil {
 		return 0, fmt.Errorf("create library dir: %w", err)
 	}
 	_, copied, err := h.copyAssetsToDir(ctx, logger, sess, meta, sourceStage, libraryPath, keys, "library")
 	if err != nil {
 		return 0, err
 	}
+	if copied > 0 {
+		h.writeShowNFO(logger, sess.Env.Metadata, libraryPath)
+		h.downloadArtwork(ctx, logger, sess.Env.Metadata, libraryPath)
+	}
 	return copied, nil
 }
 
@@ -184,10 +218,16 @@ func (h *Handler) finalize(ctx context.Context, logger *slog.Logger, sess *stage
 			)
 			// Degraded, not fatal.
 		}
+	} else if h.cfg.Offline {
+		logger.Info("jellyfin refresh skipped",
+			"decision_type", logs.DecisionIntegrationConfig,
+			"decision_result", "skipped",
+			"decision_reason", "offline mode: Jellyfin client disabled",
+		)
 	}
 
 	h.sendTerminalNotification(ctx, logger, sess, libraryCount, reviewCount)
-	h.cleanupStaging(logger, sess.Item)
+	h.cleanupStaging(logger, sess)
 
 	logger.Debug("organization stage completed",
 		"event_type", "stage_complete",
@@ -294,6 +334,15 @@ func throttledProgressUpdater(sess *stage.Session, minInterval time.Duration) fu
 	}
 }
 
+// pathWithinDir reports whether path lies under dir.
+func pathWithinDir(path, dir string) bool {
+	if path == "" || dir == "" {
+		return false
+	}
+	rel, err := filepath.Rel(filepath.Clean(dir), filepath.Clean(path))
+	return err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
 func moveOrCopyWithProgress(src, dst string, progress fileutil.ProgressFunc) error {
 	if err := os.Rename(src, dst); err == nil {
 		if progress != nil {
@@ -317,14 +366,44 @@ func moveOrCopyWithProgress(src, dst string, progress fileutil.ProgressFunc) err
 	return nil
 }
 
+// destFilename names key's placed file: the episode's numbered name for TV,
+// and for a movie ripped in several cuts, the movie's name tagged with the
+// cut's edition.
+func destFilename(env *ripspec.Envelope, meta *mediameta.Metadata, key, ext, episodeFormat string) string {
+	var season, episode, episodeEnd int
+	if ep := env.EpisodeByKey(key); ep != nil {
+		season, episode, episodeEnd = ep.Season, ep.Episode, ep.EpisodeEnd
+	}
+	name := mediameta.DestFilename(meta, key, ext, season, episode, episodeEnd, episodeFormat)
+	if ed := env.EditionByKey(key); ed != nil && len(env.Editions) > 1 {
+		name = mediameta.WithEdition(strings.TrimSuffix(name, ext), ed.Name) + ext
+	}
+	return name
+}
+
+// episodeLibraryDir returns the library folder for key: libraryPath, the
+// disc's season folder, except for a special, which goes to the show's
+// "Season 00" folder beside it.
+func episodeLibraryDir(env *ripspec.Envelope, libraryPath, key string) string {
+	if ep := env.EpisodeByKey(key); ep != nil && ep.IsSpecial() {
+		return filepath.Join(filepath.Dir(libraryPath), mediameta.SeasonFolder(ripspec.SpecialsSeason))
+	}
+	return libraryPath
+}
+
 func (h *Handler) copyAssetsToDir(ctx context.Context, logger *slog.Logger, sess *stage.Session, meta *mediameta.Metadata, sourceStage, destDir string, keys []string, target string) (string, int, error) {
 	env := sess.Env
 	if len(keys) == 0 {
 		return "", 0, nil
 	}
-	if err := os.MkdirAll(destDir, 0o755); err != nil {
+	if err := retryFS(ctx, logger, "create "+target+" dir", func() error {
+		return os.MkdirAll(destDir, 0o755)
+	}); err != nil {
 		return "", 0, fmt.Errorf("create %s dir: %w", target, err)
 	}
+	if err := h.checkPlacementSpace(logger, env, sourceStage, destDir, keys, target); err != nil {
+		return "", 0, err
+	}
 
 	totalBytes := totalCompletedStageBytes(env, sourceStage, keys)
 	var completedBytes int64
@@ -346,13 +425,38 @@ func (h *Handler) copyAssetsToDir(ctx context.Context, logger *slog.Logger, sess
 			continue
 		}
 
-		var season, episode, episodeEnd int
-		if ep := env.EpisodeByKey(key); ep != nil {
-			season, episode, episodeEnd = ep.Season, ep.Episode, ep.EpisodeEnd
+		destName := destFilename(env, meta, key, filepath.Ext(asset.Path), h.cfg.Library.EpisodeFormat)
+		dir := destDir
+		if target == "library" {
+			if dir = episodeLibraryDir(env, destDir, key); dir != destDir {
+				if err := retryFS(ctx, logger, "create specials dir", func() error {
+					return os.MkdirAll(dir, 0o755)
+				}); err != nil {
+					return "", copied, fmt.Errorf("create specials dir: %w", err)
+				}
+			}
 		}
-		destName := mediameta.DestFilename(meta, key, filepath.Ext(asset.Path), season, episode, episodeEnd)
-		destPath := filepath.Join(destDir, destName)
-		if target == "library" && !h.cfg.Library.OverwriteExisting {
+		destPath := filepath.Join(dir, destName)
+		if filepath.Clean(asset.Path) == filepath.Clean(destPath) {
+			// An approved review re-runs organizing; episodes placed in the
+			// library the first time are sourced from that library file.
+			logger.Info("file already in place, skipping",
+				"decision_type", logs.DecisionOrganizeSkip,
+				"decision_result", "skipped",
+				"decision_reason", "source is the destination",
+				"path", destPath,
+			)
+			if err := sess.SaveAssetSuccess(ripspec.AssetKindFinal, ripspec.Asset{EpisodeKey: key, Path: destPath}); err != nil {
+				return "", copied, err
+			}
+			lastPath = destPath
+			continue
+		}
+		// Review copies never replace an existing file; a taken name gets a
+		// suffix. Library copies keep an existing file unless
+		// overwrite_existing is set.
+		placeAside := target == "review"
+		if target == "library" && !h.cfg.Library.OverwriteExisting && !env.Attributes.ReplaceExisting {
 			if info, err := os.Stat(destPath); err == nil {
 				srcInfo, srcErr := os.Stat(asset.Path)
 				if srcErr == nil && info.Size() < srcInfo.Size() {
@@ -376,6 +480,21 @@ func (h *Handler) copyAssetsToDir(ctx context.Context, logger *slog.Logger, sess
 				}
 			}
 		}
+		if placeAside {
+			unique, err := uniqueDestPath(destPath, asset.Path, h.cfg.Paths.ReviewCollisionSuffix)
+			if err != nil {
+				return "", copied, fmt.Errorf("resolve destination for %s: %w", key, err)
+			}
+			if unique != destPath {
+				logger.Info("destination taken, using suffixed name",
+					"decision_type", logs.DecisionOrganizeCollision,
+					"decision_result", filepath.Base(unique),
+					"decision_reason", fmt.Sprintf("%s already exists (%s suffix)", filepath.Base(destPath), h.cfg.Paths.ReviewCollisionSuffix),
+					"path", unique,
+				)
+				destPath = unique
+			}
+		}
 
 		eventType := "organize_copy"
 		if target == "review" {
@@ -389,13 +508,16 @@ func (h *Handler) copyAssetsToDir(ctx context.Context, logger *slog.Logger, sess
 		)
 		_ = sess.Progress(overallBytePercent(completedBytes, totalBytes), fmt.Sprintf("Phase %d/%d - Copying to %s (%s)", i+1, len(keys), target, key), stage.WithProgressBytes(completedBytes, totalBytes))
 
+		// Review files are the only copy once staging is cleaned, so they are
+		// moved rather than duplicated, both into review and back out of it
+		// after an approved review.
 		transfer := fileutil.CopyFileVerifiedWithProgress
-		if target == "review" {
+		if target == "review" || pathWithinDir(asset.Path, h.cfg.Paths.ReviewDir) {
 			transfer = moveOrCopyWithProgress
 		}
 		copyStart := time.Now()
 		var lastCopyLog time.Time
-		if err := transfer(asset.Path, destPath, func(p fileutil.CopyProgress) {
+		onProgress := func(p fileutil.CopyProgress) {
 			sess.Task.ProgressBytesCopied = completedBytes + p.BytesCopied
 			sess.Task.ProgressTotalBytes = totalBytes
 			sess.Task.ProgressPercent = overallBytePercent(sess.Task.ProgressBytesCopied, totalBytes)
@@ -415,6 +537,9 @@ func (h *Handler) copyAssetsToDir(ctx context.Context, logger *slog.Logger, sess
 					"overall_percent", math.Round(sess.Task.ProgressPercent*10)/10,
 				)
 			}
+		}
+		if err := retryFS(ctx, logger, "copy "+key+" to "+target, func() error {
+			return transfer(asset.Path, destPath, onProgress)
 		}); err != nil {
 			if ctx.Err() != nil {
 				_ = os.Remove(destPath)
@@ -431,6 +556,9 @@ func (h *Handler) copyAssetsToDir(ctx context.Context, logger *slog.Logger, sess
 			"duration_ms", time.Since(copyStart).Milliseconds(),
 		)
 		copySidecarSubtitle(logger, asset.Path, destPath)
+		if target == "library" {
+			h.writeMediaNFO(logger, env, key, destPath)
+		}
 		if err := sess.SaveAssetSuccess(ripspec.AssetKindFinal, ripspec.Asset{EpisodeKey: key, Path: destPath}); err != nil {
 			return "", copied, err
 		}
@@ -444,6 +572,44 @@ func (h *Handler) copyAssetsToDir(ctx context.Context, logger *slog.Logger, sess
 	return lastPath, copied, nil
 }
 
+// checkPlacementSpace runs the free-space guard against the library or review
+// floor before any asset is placed. Only bytes that land as new data count: a
+// move within one filesystem and a file already under destDir add nothing.
+func (h *Handler) checkPlacementSpace(logger *slog.Logger, env *ripspec.Envelope, sourceStage, destDir string, keys []string, target string) error {
+	floorGiB := h.cfg.FreeSpace.LibraryGiB
+	if target == "review" {
+		floorGiB = h.cfg.FreeSpace.ReviewGiB
+	}
+	var need int64
+	for _, key := range keys {
+		asset, ok := env.Assets.FindAsset(sourceStage, key)
+		if !ok || !asset.IsCompleted() || pathWithinDir(asset.Path, destDir) {
+			continue
+		}
+		moves := target == "review" || pathWithinDir(asset.Path, h.cfg.Paths.ReviewDir)
+		if moves && sameFilesystem(asset.Path, destDir) {
+			continue
+		}
+		if info, err := os.Stat(asset.Path); err == nil {
+			need += info.Size()
+		}
+	}
+	return stage.EnsureFreeSpace(logger, target, destDir, need, int64(floorGiB)<<30)
+}
+
+// sameFilesystem reports whether a and b live on one device, so a rename
+// between them moves no data.
+func sameFilesystem(a, b string) bool {
+	ai, errA := os.Stat(a)
+	bi, errB := os.Stat(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	as, okA := ai.Sys().(*syscall.Stat_t)
+	bs, okB := bi.Sys().(*syscall.Stat_t)
+	return okA && okB && as.Dev == bs.Dev
+}
+
 // routeToReview copies assets to the review directory for manual inspection.
 // Directory structure: review_dir/{reason}_{fingerprint_prefix}/
 func (h *Handler) routeToReview(ctx context.Context, logger *slog.Logger, sess *stage.Session, meta *mediameta.Metadata, sourceStage string, keys []string) error {
@@ -462,20 +628,53 @@ func (h *Handler) routeToReview(ctx context.Context, logger *slog.Logger, sess *
 		return err
 	}
 
-	h.cleanupStaging(logger, item)
+	h.cleanupStaging(logger, sess)
 
 	logger.Info("review routing completed", "event_type", "stage_complete", "stage", "organizing", "review_path", reviewPath)
 	return nil
 }
 
-// cleanupStaging removes the staging directory for a completed item.
-// Failures are logged as warnings (non-fatal) — disk space reclamation is
-// best-effort.
-func (h *Handler) cleanupStaging(logger *slog.Logger, item *queue.Item) {
+// reviewAfterFSFailure handles a failed library placement. When the library
+// filesystem kept failing transiently through every retry, the assets not yet
+// confirmed in the library are routed to review with the failure as the
+// reason, so the item completes instead of failing on a flaky mount. Any other
+// error is returned unchanged.
+func (h *Handler) reviewAfterFSFailure(
+	ctx context.Context,
+	logger *slog.Logger,
+	sess *stage.Session,
+	meta *mediameta.Metadata,
+	sourceStage string,
+	err error,
+) error {
+	var exhausted *fsRetriesExhaustedError
+	if !errors.As(err, &exhausted) {
+		return err
+	}
+	keys := unconfirmedFinalKeys(sess.Env)
+	logger.Warn("library filesystem unavailable; routing to review",
+		"event_type", "organize_fs_retries_exhausted",
+		"error_hint", exhausted.Error(),
+		"impact", fmt.Sprintf("%d unplaced assets moved to review instead of the library", len(keys)),
+	)
+	sess.AddReviewReason(fmt.Sprintf("library filesystem unavailable: %s", exhausted.Error()))
+	if err := h.routeToReview(ctx, logger, sess, meta, sourceStage, keys); err != nil {
+		return err
+	}
+	h.sendTerminalNotification(ctx, logger, sess, len(sess.Env.AssetKeys())-len(keys), len(keys))
+	return nil
+}
+
+// cleanupStaging applies staging.cleanup_policy to a completed item's
+// staging directory. Nothing is removed or marked until every organized asset
+// has its final file on disk, so an unconfirmed placement never loses the
+// only copy. Failures are logged as warnings (non-fatal) — disk space
+// reclamation is best-effort.
+func (h *Handler) cleanupStaging(logger *slog.Logger, sess *stage.Session) {
 	if logger == nil {
 		logger = slog.Default()
 	}
-	root, err := item.StagingRoot(h.cfg.Paths.StagingDir)
+	root, err := sess.Item.StagingRoot(h.cfg.Paths.StagingDir)
 	if err != nil {
 		logger.Warn("cannot resolve staging root for cleanup",
 			"event_type", "staging_cleanup_failed",
@@ -484,6 +683,21 @@ func (h *Handler) cleanupStaging(logger *slog.Logger, item *queue.Item) {
 		)
 		return
 	}
+	if missing := unconfirmedFinalKeys(sess.Env); len(missing) > 0 {
+		logger.Warn("staging cleanup skipped; final placement not confirmed",
+			"staging_root", root,
+			"event_type", "staging_cleanup_skipped",
+			"error_hint", "no final file on disk for "+strings.Join(missing, ", "),
+			"impact", "staging directory kept so the item can be recovered",
+		)
+		return
+	}
+
+	policy := h.cfg.Staging.CleanupPolicy
+	if policy == config.StagingCleanupKeepRecent || policy == config.StagingCleanupKeepUntilLowSpace {
+		h.retainStaging(logger, root, policy)
+		return
+	}
 	if err := os.RemoveAll(root); err != nil {
 		logger.Warn("failed to clean staging directory; leftover files remain",
 			"staging_root", root,
@@ -499,9 +713,63 @@ func (h *Handler) cleanupStaging(logger *slog.Logger, item *queue.Item) {
 	)
 }
 
+// retainStaging marks an organized item's staging directory and prunes older
+// organized directories according to the keep_recent or keep_until_low_space
+// policy.
+func (h *Handler) retainStaging(logger *slog.Logger, root, policy string) {
+	if err := stagingdir.MarkOrganized(root); err != nil {
+		logger.Warn("failed to mark staging directory organized",
+			"staging_root", root,
+			"event_type", "staging_cleanup_failed",
+			"error_hint", err.Error(),
+			"impact", "directory is not pruned by the staging cleanup policy; manual cleanup needed",
+		)
+		return
+	}
+	var result stagingdir.CleanStaleResult
+	reason := fmt.Sprintf("policy=%s keep_recent=%d", policy, h.cfg.Staging.KeepRecent)
+	if policy == config.StagingCleanupKeepRecent {
+		result = stagingdir.PruneOrganized(h.cfg.Paths.StagingDir, h.cfg.Staging.KeepRecent, logger)
+	} else {
+		reason = fmt.Sprintf("policy=%s min_free_gib=%d", policy, h.cfg.Staging.MinFreeGiB)
+		result = stagingdir.PruneOrganizedForSpace(h.cfg.Paths.StagingDir, int64(h.cfg.Staging.MinFreeGiB)<<30, logger)
+	}
+	for _, err := range result.Errors {
+		logger.Warn("organized staging directory not pruned",
+			"event_type", "staging_cleanup_failed",
+			"error_hint", err.Error(),
+			"impact", "disk space not reclaimed; manual cleanup needed",
+		)
+	}
+	logger.Info("staging cleanup policy applied",
+		"decision_type", logs.DecisionStagingCleanup,
+		"decision_result", "retained",
+		"decision_reason", reason,
+		"staging_root", root,
+		"removed", result.Removed,
+	)
+}
+
+// unconfirmedFinalKeys returns the asset keys that lack a final asset whose
+// file exists, i.e. the placements that cannot be trusted yet.
+func unconfirmedFinalKeys(env *ripspec.Envelope) []string {
+	var missing []string
+	for _, key := range env.AssetKeys() {
+		asset, ok := env.Assets.FindAsset(ripspec.AssetKindFinal, key)
+		if ok && asset.IsCompleted() {
+			if _, err := os.Stat(asset.Path); err == nil {
+				continue
+			}
+		}
+		missing = append(missing, key)
+	}
+	return missing
+}
+
 func (h *Handler) sendTerminalNotification(ctx context.Context, logger *slog.Logger, sess *stage.Session, libraryCount, reviewCount int) {
 	item := sess.Item
 	alsoProcessing := queue.FormatAlsoProcessing(sess.Store, item.ID)
+	fields := notify.Fields{Item: item.ID, Stage: string(queue.StageOrganizing)}
 
 	if reviewCount > 0 || item.NeedsReview == 1 {
 		title := "Review required: " + item.DisplayTitle()
@@ -517,8 +785,11 @@ func (h *Handler) sendTerminalNotification(ctx context.Context, logger *slog.Log
 		if reason := item.ReviewSummary(2); reason != "" {
 			msg += "\nReason: " + reason
 		}
+		if strings.Contains(item.ReviewReason, duplicateReviewReason) {
+			msg += "\nApprove with --replace to overwrite the library copy, or reject to keep it."
+		}
 		msg += alsoProcessing
-		_ = notify.SendLogged(ctx, h.notifier, logger, notify.EventReviewRequired, title, msg,
+		_ = notify.SendItemLogged(ctx, h.notifier, logger, notify.EventReviewRequired, fields, title, msg,
 			"library_count", libraryCount,
 			"review_count", reviewCount,
 		)
@@ -531,7 +802,7 @@ func (h *Handler) sendTerminalNotification(ctx context.Context, logger *slog.Log
 		msg = fmt.Sprintf("Imported %d items to the library.", libraryCount)
 	}
 	msg += alsoProcessing
-	_ = notify.SendLogged(ctx, h.notifier, logger, notify.EventPipelineComplete, title, msg,
+	_ = notify.SendItemLogged(ctx, h.notifier, logger, notify.EventPipelineComplete, fields, title, msg,
 		"library_count", libraryCount,
 	)
 }
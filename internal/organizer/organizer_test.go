@@ -2,6 +2,9 @@ package organizer
 
 import (
 	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"math"
@@ -10,14 +13,20 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"testing"
+	"time"
 
+	"github.com/five82/spindle/internal/config"
 	"github.com/five82/spindle/internal/fileutil"
+	"github.com/five82/spindle/internal/fingerprint"
 	"github.com/five82/spindle/internal/mediameta"
 	"github.com/five82/spindle/internal/notify"
 	"github.com/five82/spindle/internal/queue"
 	"github.com/five82/spindle/internal/ripspec"
 	"github.com/five82/spindle/internal/stage"
+	"github.com/five82/spindle/internal/stagingdir"
+	"github.com/five82/spindle/internal/tmdb"
 )
 
 func TestAssetKeys_Movie(t *testing.T) {
@@ -75,7 +84,7 @@ func TestDestFilename_Movie(t *testing.T) {
 		Movie:     true,
 	}
 
-	got := mediameta.DestFilename(meta, "main", ".mkv", 0, 0, 0)
+	got := mediameta.DestFilename(meta, "main", ".mkv", 0, 0, 0, mediameta.DefaultEpisodeFormat)
 	want := "The Matrix (1999).mkv"
 	if got != want {
 		t.Errorf("expected %q, got %q", want, got)
@@ -90,7 +99,7 @@ func TestDestFilename_TVEpisode(t *testing.T) {
 		SeasonNumber: 1,
 	}
 
-	got := mediameta.DestFilename(meta, "s01_001", ".mkv", 1, 3, 0)
+	got := mediameta.DestFilename(meta, "s01_001", ".mkv", 1, 3, 0, mediameta.DefaultEpisodeFormat)
 	want := "Breaking Bad - S01E03.mkv"
 	if got != want {
 		t.Errorf("expected %q, got %q", want, got)
@@ -105,7 +114,7 @@ func TestDestFilename_TVRange(t *testing.T) {
 		SeasonNumber: 1,
 	}
 
-	got := mediameta.DestFilename(meta, "s01_001", ".mkv", 1, 1, 2)
+	got := mediameta.DestFilename(meta, "s01_001", ".mkv", 1, 1, 2, mediameta.DefaultEpisodeFormat)
 	want := "Breaking Bad - S01E01-E02.mkv"
 	if got != want {
 		t.Errorf("expected %q, got %q", want, got)
@@ -121,13 +130,53 @@ func TestDestFilename_TVFallback(t *testing.T) {
 	}
 
 	// Unresolved episode: season/episode not yet set, so the key is used as-is.
-	got := mediameta.DestFilename(meta, "s01_001", ".mkv", 0, 0, 0)
+	got := mediameta.DestFilename(meta, "s01_001", ".mkv", 0, 0, 0, mediameta.DefaultEpisodeFormat)
 	want := "Some Show - s01_001.mkv"
 	if got != want {
 		t.Errorf("expected %q, got %q", want, got)
 	}
 }
 
+func TestEpisodeLibraryDirFilesSpecialUnderSeasonZero(t *testing.T) {
+	env := &ripspec.Envelope{Episodes: []ripspec.Episode{
+		{Key: "s01e01", Season: 1, Episode: 1},
+		{Key: "s00e05", Season: 0, Episode: 5},
+	}}
+	libraryPath := filepath.Join("/library", "tv", "Firefly", "Season 01")
+	if got := episodeLibraryDir(env, libraryPath, "s01e01"); got != libraryPath {
+		t.Fatalf("regular episode dir = %q, want %q", got, libraryPath)
+	}
+	specialDir := episodeLibraryDir(env, libraryPath, "s00e05")
+	if want := filepath.Join("/library", "tv", "Firefly", "Season 00"); specialDir != want {
+		t.Fatalf("special dir = %q, want %q", specialDir, want)
+	}
+	meta := &mediameta.Metadata{ShowTitle: "Firefly", MediaType: "tv", SeasonNumber: 1}
+	if got := mediameta.DestFilename(meta, "s00e05", ".mkv", 0, 5, 0, mediameta.DefaultEpisodeFormat); got != "Firefly - S00E05.mkv" {
+		t.Fatalf("special filename = %q, want Firefly - S00E05.mkv", got)
+	}
+}
+
+func TestDestFilenameTagsMovieEditions(t *testing.T) {
+	meta := &mediameta.Metadata{Title: "Aliens", Year: "1986", MediaType: "movie"}
+	env := &ripspec.Envelope{
+		Metadata: ripspec.Metadata{MediaType: "movie"},
+		Editions: []ripspec.Edition{
+			{Key: "main", TitleID: 1, Name: "Extended"},
+			{Key: "edition_theatrical", TitleID: 0, Name: "Theatrical"},
+		},
+	}
+	if got := destFilename(env, meta, "edition_theatrical", ".mkv", ""); got != "Aliens (1986) {edition-Theatrical}.mkv" {
+		t.Fatalf("theatrical = %q", got)
+	}
+	if got := destFilename(env, meta, "main", ".mkv", ""); got != "Aliens (1986) {edition-Extended}.mkv" {
+		t.Fatalf("extended = %q", got)
+	}
+	env.Editions = env.Editions[:1]
+	if got := destFilename(env, meta, "main", ".mkv", ""); got != "Aliens (1986).mkv" {
+		t.Fatalf("single kept cut = %q, want no edition tag", got)
+	}
+}
+
 func TestOverallBytePercent(t *testing.T) {
 	if got := overallBytePercent(50, 200); math.Abs(got-25) > 1e-9 {
 		t.Fatalf("overallBytePercent() = %f, want 25", got)
@@ -309,3 +358,511 @@ func TestSendTerminalNotificationReviewRequired(t *testing.T) {
 		t.Fatalf("body = %q, want %q", gotBody, want)
 	}
 }
+
+func TestDownloadArtworkWritesPosterAndSkipsMissingBackdrop(t *testing.T) {
+	var srvURL string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/configuration":
+			_, _ = w.Write([]byte(`{"images":{"secure_base_url":"` + srvURL + `/img/","poster_sizes":["w500","original"],"backdrop_sizes":["original"]}}`))
+		case "/img/w500/poster.jpg":
+			_, _ = w.Write([]byte("poster-bytes"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	srvURL = srv.URL
+
+	cfg := &config.Config{Library: config.LibraryConfig{DownloadArtwork: true, ArtworkSize: "w500"}}
+	h := New(cfg, nil, tmdb.New("key", srv.URL, "en-US", nil), nil)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	showDir := filepath.Join(t.TempDir(), "Show")
+	seasonDir := filepath.Join(showDir, "Season 01")
+	if err := os.MkdirAll(seasonDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	meta := ripspec.Metadata{MediaType: "tv", PosterPath: "/poster.jpg"}
+	h.downloadArtwork(context.Background(), logger, meta, seasonDir)
+
+	data, err := os.ReadFile(filepath.Join(showDir, "poster.jpg"))
+	if err != nil {
+		t.Fatalf("poster not written to series folder: %v", err)
+	}
+	if string(data) != "poster-bytes" {
+		t.Errorf("poster = %q, want poster-bytes", data)
+	}
+	if _, err := os.Stat(filepath.Join(showDir, "fanart.jpg")); !os.IsNotExist(err) {
+		t.Errorf("fanart.jpg should not exist without a backdrop, stat err = %v", err)
+	}
+}
+
+func TestBuildMovieNFO(t *testing.T) {
+	body, err := buildMovieNFO(ripspec.Metadata{
+		ID:          603,
+		Title:       "The Matrix",
+		Year:        "1999",
+		ReleaseDate: "1999-03-30",
+		Overview:    "Neo <learns> the truth & more\x00\x1b",
+		IMDBID:      "tt0133093",
+		MediaType:   "movie",
+	})
+	if err != nil {
+		t.Fatalf("buildMovieNFO: %v", err)
+	}
+	var got struct {
+		XMLName   xml.Name `xml:"movie"`
+		Title     string   `xml:"title"`
+		Year      string   `xml:"year"`
+		Premiered string   `xml:"premiered"`
+		Plot      string   `xml:"plot"`
+		UniqueIDs []struct {
+			Type    string `xml:"type,attr"`
+			Default bool   `xml:"default,attr"`
+			Value   string `xml:",chardata"`
+		} `xml:"uniqueid"`
+	}
+	if err := xml.Unmarshal(body, &got); err != nil {
+		t.Fatalf("generated NFO is not valid XML: %v\n%s", err, body)
+	}
+	if !strings.HasPrefix(string(body), xml.Header) {
+		t.Error("NFO should start with the XML declaration")
+	}
+	if got.Title != "The Matrix" || got.Year != "1999" || got.Premiered != "1999-03-30" {
+		t.Errorf("movie fields = %+v", got)
+	}
+	if got.Plot != "Neo <learns> the truth & more" {
+		t.Errorf("plot = %q, want control characters stripped and markup round-tripped", got.Plot)
+	}
+	if len(got.UniqueIDs) != 2 || got.UniqueIDs[0].Type != "tmdb" || !got.UniqueIDs[0].Default || got.UniqueIDs[0].Value != "603" || got.UniqueIDs[1].Value != "tt0133093" {
+		t.Errorf("uniqueids = %+v", got.UniqueIDs)
+	}
+}
+
+func TestWriteMediaNFOForEpisode(t *testing.T) {
+	cfg := &config.Config{Library: config.LibraryConfig{WriteNFO: true}}
+	h := New(cfg, nil, nil, nil)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	env := &ripspec.Envelope{
+		Metadata: ripspec.Metadata{ID: 1396, Title: "Breaking Bad", ShowTitle: "Breaking Bad", MediaType: "tv"},
+		Episodes: []ripspec.Episode{{Key: "s01e02", Season: 1, Episode: 2, EpisodeTitle: "Cat's in the Bag...", EpisodeAirDate: "2008-01-27"}},
+	}
+	mediaPath := filepath.Join(t.TempDir(), "Breaking Bad - S01E02.mkv")
+
+	h.writeMediaNFO(logger, env, "s01e02", mediaPath)
+
+	body, err := os.ReadFile(strings.TrimSuffix(mediaPath, ".mkv") + ".nfo")
+	if err != nil {
+		t.Fatalf("episode NFO not written: %v", err)
+	}
+	var got struct {
+		XMLName   xml.Name `xml:"episodedetails"`
+		Title     string   `xml:"title"`
+		ShowTitle string   `xml:"showtitle"`
+		Season    int      `xml:"season"`
+		Episode   int      `xml:"episode"`
+		Aired     string   `xml:"aired"`
+	}
+	if err := xml.Unmarshal(body, &got); err != nil {
+		t.Fatalf("generated NFO is not valid XML: %v\n%s", err, body)
+	}
+	if got.Title != "Cat's in the Bag..." || got.ShowTitle != "Breaking Bad" || got.Season != 1 || got.Episode != 2 || got.Aired != "2008-01-27" {
+		t.Errorf("episode fields = %+v", got)
+	}
+}
+
+// stagedMovieSession returns a session for a movie item with a staging
+// directory under stagingDir and a final asset at finalPath.
+func stagedMovieSession(t *testing.T, stagingDir, fingerprint, finalPath string) (*stage.Session, string) {
+	t.Helper()
+	item := &queue.Item{DiscFingerprint: fingerprint}
+	root, err := item.StagingRoot(stagingDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	env := &ripspec.Envelope{Metadata: ripspec.Metadata{MediaType: "movie"}}
+	env.Assets.AddAsset(ripspec.AssetKindFinal, ripspec.Asset{EpisodeKey: "main", Path: finalPath, Status: ripspec.AssetStatusCompleted})
+	return &stage.Session{Item: item, Env: env}, root
+}
+
+func TestCleanupStagingRequiresConfirmedFinalAssets(t *testing.T) {
+	stagingDir := t.TempDir()
+	finalPath := filepath.Join(t.TempDir(), "Movie (2020).mkv")
+	cfg := &config.Config{}
+	cfg.Paths.StagingDir = stagingDir
+	cfg.Staging.CleanupPolicy = config.StagingCleanupImmediate
+	h := &Handler{cfg: cfg}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	sess, root := stagedMovieSession(t, stagingDir, "fp1", finalPath)
+
+	h.cleanupStaging(logger, sess)
+	if _, err := os.Stat(root); err != nil {
+		t.Fatalf("staging removed without a final file on disk: %v", err)
+	}
+
+	if err := os.WriteFile(finalPath, []byte("movie"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	h.cleanupStaging(logger, sess)
+	if _, err := os.Stat(root); !os.IsNotExist(err) {
+		t.Fatalf("staging not removed after confirmed placement, err=%v", err)
+	}
+}
+
+func TestCleanupStagingKeepRecent(t *testing.T) {
+	stagingDir := t.TempDir()
+	libraryDir := t.TempDir()
+	cfg := &config.Config{}
+	cfg.Paths.StagingDir = stagingDir
+	cfg.Staging.CleanupPolicy = config.StagingCleanupKeepRecent
+	cfg.Staging.KeepRecent = 2
+	h := &Handler{cfg: cfg}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	// An unorganized item still in flight is never pruned.
+	inFlight := filepath.Join(stagingDir, "FP0")
+	if err := os.MkdirAll(inFlight, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	var roots []string
+	for _, fp := range []string{"fp1", "fp2", "fp3"} {
+		finalPath := filepath.Join(libraryDir, fp+".mkv")
+		if err := os.WriteFile(finalPath, []byte("movie"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		sess, root := stagedMovieSession(t, stagingDir, fp, finalPath)
+		h.cleanupStaging(logger, sess)
+		if _, err := os.Stat(filepath.Join(root, stagingdir.OrganizedMarker)); err != nil {
+			t.Fatalf("%s not marked organized: %v", fp, err)
+		}
+		roots = append(roots, root)
+	}
+
+	if _, err := os.Stat(roots[0]); !os.IsNotExist(err) {
+		t.Errorf("oldest organized staging directory kept, err=%v", err)
+	}
+	for _, kept := range append(roots[1:], inFlight) {
+		if _, err := os.Stat(kept); err != nil {
+			t.Errorf("%s should be kept: %v", filepath.Base(kept), err)
+		}
+	}
+}
+
+func TestRetryFSRecoversFromTransientError(t *testing.T) {
+	orig := fsRetryBackoff
+	fsRetryBackoff = time.Millisecond
+	t.Cleanup(func() { fsRetryBackoff = orig })
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	calls := 0
+	err := retryFS(context.Background(), logger, "copy", func() error {
+		calls++
+		if calls < 3 {
+			return &os.PathError{Op: "open", Path: "/mnt/nfs/movie.mkv", Err: syscall.ESTALE}
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Fatalf("retryFS = %v after %d calls, want success on the third", err, calls)
+	}
+
+	calls = 0
+	err = retryFS(context.Background(), logger, "copy", func() error {
+		calls++
+		return &os.PathError{Op: "write", Path: "/mnt/nfs/movie.mkv", Err: syscall.ENOSPC}
+	})
+	if !errors.Is(err, syscall.ENOSPC) || calls != 1 {
+		t.Fatalf("ENOSPC: err=%v calls=%d, want immediate failure", err, calls)
+	}
+
+	calls = 0
+	err = retryFS(context.Background(), logger, "copy", func() error {
+		calls++
+		return syscall.EAGAIN
+	})
+	var exhausted *fsRetriesExhaustedError
+	if !errors.As(err, &exhausted) || calls != 4 {
+		t.Fatalf("persistent EAGAIN: err=%v calls=%d, want exhausted after 4 attempts", err, calls)
+	}
+}
+
+func TestIsTransientFSError(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{&os.PathError{Op: "open", Path: "/mnt/nfs/a.mkv", Err: syscall.ESTALE}, true},
+		{&os.LinkError{Op: "rename", Old: "a", New: "b", Err: syscall.EAGAIN}, true},
+		{fmt.Errorf("copy data: %w", syscall.ETIMEDOUT), true},
+		{&os.PathError{Op: "write", Path: "/mnt/nfs/a.mkv", Err: syscall.ENOSPC}, false},
+		{&os.PathError{Op: "open", Path: "/mnt/nfs/a.mkv", Err: syscall.EACCES}, false},
+		{errors.New("verification failed: size mismatch"), false},
+	} {
+		if got := isTransientFSError(tc.err); got != tc.want {
+			t.Errorf("isTransientFSError(%v) = %v, want %v", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestUniqueDestPathStrategies(t *testing.T) {
+	origNow := collisionNow
+	t.Cleanup(func() { collisionNow = origNow })
+	collisionNow = func() time.Time { return time.Date(2026, 3, 14, 20, 15, 0, 0, time.UTC) }
+
+	for _, strategy := range []string{config.CollisionSuffixNumeric, config.CollisionSuffixHash, config.CollisionSuffixTimestamp} {
+		t.Run(strategy, func(t *testing.T) {
+			dir := t.TempDir()
+			src := filepath.Join(dir, "src.mkv")
+			if err := os.WriteFile(src, []byte("new rip"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			dst := filepath.Join(dir, "Movie (2024).mkv")
+
+			got, err := uniqueDestPath(dst, src, strategy)
+			if err != nil || got != dst {
+				t.Fatalf("free destination: got %q, %v; want %q", got, err, dst)
+			}
+
+			// Each placement takes the name it was given; none may repeat an
+			// existing name.
+			seen := map[string]bool{}
+			if err := os.WriteFile(dst, []byte("existing"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			seen[dst] = true
+			for range 3 {
+				got, err := uniqueDestPath(dst, src, strategy)
+				if err != nil {
+					t.Fatalf("uniqueDestPath: %v", err)
+				}
+				if seen[got] {
+					t.Fatalf("name %q reused", got)
+				}
+				if filepath.Dir(got) != dir || filepath.Ext(got) != ".mkv" || !strings.HasPrefix(filepath.Base(got), "Movie (2024) ") {
+					t.Fatalf("unexpected name %q", got)
+				}
+				seen[got] = true
+				if err := os.WriteFile(got, []byte("placed"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			data, err := os.ReadFile(dst)
+			if err != nil || string(data) != "existing" {
+				t.Fatalf("existing file changed: %q, %v", data, err)
+			}
+
+			first := map[string]string{
+				config.CollisionSuffixNumeric:   "Movie (2024) (1).mkv",
+				config.CollisionSuffixTimestamp: "Movie (2024) (20260314-201500).mkv",
+			}[strategy]
+			if strategy == config.CollisionSuffixHash {
+				sum, err := fingerprint.ContentHash([]string{src})
+				if err != nil {
+					t.Fatal(err)
+				}
+				first = "Movie (2024) [" + sum[:8] + "].mkv"
+			}
+			if !seen[filepath.Join(dir, first)] {
+				t.Errorf("first suffixed name %q not produced; got %v", first, seen)
+			}
+		})
+	}
+}
+
+func TestCopyToReviewSuffixesTakenName(t *testing.T) {
+	origNow := collisionNow
+	t.Cleanup(func() { collisionNow = origNow })
+	collisionNow = func() time.Time { return time.Date(2026, 3, 14, 20, 15, 0, 0, time.UTC) }
+
+	dir := t.TempDir()
+	cfg := &config.Config{}
+	cfg.Paths.ReviewDir = filepath.Join(dir, "review")
+	cfg.Paths.ReviewCollisionSuffix = config.CollisionSuffixTimestamp
+	h := &Handler{cfg: cfg}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	reviewDir := filepath.Join(cfg.Paths.ReviewDir, "duplicate_fp-heat")
+	if err := os.MkdirAll(reviewDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	taken := filepath.Join(reviewDir, "Heat (1995).mkv")
+	if err := os.WriteFile(taken, []byte("earlier review copy"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	encoded := filepath.Join(dir, "encoded", "main.mkv")
+	if err := os.MkdirAll(filepath.Dir(encoded), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(encoded, []byte("new rip"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := queue.Open(filepath.Join(dir, "queue.db"))
+	if err != nil {
+		t.Fatalf("open queue: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	item, err := store.NewDisc("Heat", "fp-heat")
+	if err != nil {
+		t.Fatalf("new disc: %v", err)
+	}
+	env := ripspec.Envelope{Version: ripspec.CurrentVersion, Metadata: ripspec.Metadata{MediaType: "movie", Title: "Heat", Year: "1995", Movie: true}}
+	env.Assets.AddAsset(ripspec.AssetKindEncoded, ripspec.Asset{EpisodeKey: "main", Path: encoded, Status: ripspec.AssetStatusCompleted})
+	if item.RipSpecData, err = env.Encode(); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.UpdateWorkState(item); err != nil {
+		t.Fatalf("update work state: %v", err)
+	}
+	sess, err := stage.NewSession(context.Background(), store, item, nil)
+	if err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	sess.Logger = logger
+	meta := &mediameta.Metadata{Title: "Heat", Year: "1995", MediaType: "movie", Movie: true}
+
+	if _, _, err := h.copyAssetsToDir(context.Background(), logger, sess, meta, ripspec.AssetKindEncoded, reviewDir, []string{"main"}, "review"); err != nil {
+		t.Fatalf("copyAssetsToDir: %v", err)
+	}
+
+	data, err := os.ReadFile(taken)
+	if err != nil || string(data) != "earlier review copy" {
+		t.Fatalf("existing review file changed: %q, %v", data, err)
+	}
+	want := filepath.Join(reviewDir, "Heat (1995) (20260314-201500).mkv")
+	if data, err := os.ReadFile(want); err != nil || string(data) != "new rip" {
+		t.Fatalf("suffixed review copy: %q, %v", data, err)
+	}
+	final, ok := sess.Env.Assets.FindAsset(ripspec.AssetKindFinal, "main")
+	if !ok || final.Path != want {
+		t.Fatalf("final asset = %+v, want %s", final, want)
+	}
+}
+
+func TestRunRoutesExistingLibraryFileToReview(t *testing.T) {
+	var gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	cfg := &config.Config{}
+	cfg.Paths.StagingDir = filepath.Join(dir, "staging")
+	cfg.Paths.LibraryDir = filepath.Join(dir, "library")
+	cfg.Paths.ReviewDir = filepath.Join(dir, "review")
+	cfg.Library.MoviesDir = "movies"
+	cfg.Paths.ReviewCollisionSuffix = config.CollisionSuffixNumeric
+	cfg.Staging.CleanupPolicy = config.StagingCleanupImmediate
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	h := New(cfg, nil, nil, notify.New(srv.URL, 5, logger))
+
+	existing := filepath.Join(cfg.Paths.LibraryDir, "movies", "Heat (1995)", "Heat (1995).mkv")
+	if err := os.MkdirAll(filepath.Dir(existing), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(existing, []byte("earlier rip"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	encoded := filepath.Join(dir, "encoded", "main.mkv")
+	if err := os.MkdirAll(filepath.Dir(encoded), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(encoded, []byte("new rip"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := queue.Open(filepath.Join(dir, "queue.db"))
+	if err != nil {
+		t.Fatalf("open queue: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	item, err := store.NewDisc("Heat", "fp-heat")
+	if err != nil {
+		t.Fatalf("new disc: %v", err)
+	}
+	env := ripspec.Envelope{Version: ripspec.CurrentVersion, Metadata: ripspec.Metadata{MediaType: "movie", Title: "Heat", Year: "1995", Movie: true}}
+	env.Assets.AddAsset(ripspec.AssetKindEncoded, ripspec.Asset{EpisodeKey: "main", Path: encoded, Status: ripspec.AssetStatusCompleted})
+	if item.RipSpecData, err = env.Encode(); err != nil {
+		t.Fatal(err)
+	}
+	item.MetadataJSON = `{"title":"Heat","media_type":"movie","year":"1995","movie":true}`
+	if err := store.UpdateWorkState(item); err != nil {
+		t.Fatalf("update work state: %v", err)
+	}
+	sess, err := stage.NewSession(context.Background(), store, item, nil)
+	if err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	sess.Logger = logger
+
+	if err := h.Run(context.Background(), sess); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	data, err := os.ReadFile(existing)
+	if err != nil || string(data) != "earlier rip" {
+		t.Fatalf("library copy changed: %q, %v", data, err)
+	}
+	suffixed, _ := filepath.Glob(filepath.Join(filepath.Dir(existing), "Heat (1995) *.mkv"))
+	if len(suffixed) != 0 {
+		t.Fatalf("duplicate placed under a suffixed name: %v", suffixed)
+	}
+	if item.NeedsReview != 1 || !strings.Contains(item.ReviewReason, duplicateReviewReason) {
+		t.Fatalf("review flag = %d, reason %q", item.NeedsReview, item.ReviewReason)
+	}
+	final, ok := sess.Env.Assets.FindAsset(ripspec.AssetKindFinal, "main")
+	if !ok || !pathWithinDir(final.Path, cfg.Paths.ReviewDir) {
+		t.Fatalf("final asset = %+v, want a path under review", final)
+	}
+	if !strings.Contains(gotBody, "already in library") || !strings.Contains(gotBody, "--replace") {
+		t.Fatalf("notification = %q, want the duplicate reason and replace hint", gotBody)
+	}
+}
+
+func TestSplitLibraryDuplicatesHonorsReplace(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{}
+	cfg.Paths.LibraryDir = dir
+	cfg.Library.MoviesDir = "movies"
+	h := &Handler{cfg: cfg}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	meta := &mediameta.Metadata{Title: "Heat", Year: "1995", MediaType: "movie"}
+
+	src := filepath.Join(dir, "main.mkv")
+	if err := os.WriteFile(src, []byte("new rip"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	existing := filepath.Join(dir, "movies", "Heat (1995)", "Heat (1995).mkv")
+	if err := os.MkdirAll(filepath.Dir(existing), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(existing, []byte("earlier rip"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	env := &ripspec.Envelope{Metadata: ripspec.Metadata{MediaType: "movie"}}
+	env.Assets.AddAsset(ripspec.AssetKindEncoded, ripspec.Asset{EpisodeKey: "main", Path: src, Status: ripspec.AssetStatusCompleted})
+	sess := &stage.Session{Item: &queue.Item{}, Env: env}
+
+	if lib, dup := h.splitLibraryDuplicates(logger, sess, meta, ripspec.AssetKindEncoded, []string{"main"}); len(lib) != 0 || len(dup) != 1 {
+		t.Fatalf("library=%v duplicates=%v, want main held as a duplicate", lib, dup)
+	}
+
+	env.Attributes.ReplaceExisting = true
+	if lib, dup := h.splitLibraryDuplicates(logger, sess, meta, ripspec.AssetKindEncoded, []string{"main"}); len(lib) != 1 || len(dup) != 0 {
+		t.Fatalf("library=%v duplicates=%v, want main placed when replacing", lib, dup)
+	}
+
+	// The item's own earlier placement is not a duplicate.
+	env.Attributes.ReplaceExisting = false
+	env.Assets.AddAsset(ripspec.AssetKindFinal, ripspec.Asset{EpisodeKey: "main", Path: existing, Status: ripspec.AssetStatusCompleted})
+	if lib, dup := h.splitLibraryDuplicates(logger, sess, meta, ripspec.AssetKindEncoded, []string{"main"}); len(lib) != 1 || len(dup) != 0 {
+		t.Fatalf("library=%v duplicates=%v, want own placement treated as placed", lib, dup)
+	}
+}
@@ -0,0 +1,85 @@
+package organizer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"syscall"
+	"time"
+)
+
+// fsRetryAttempts is how many times a transient filesystem failure is
+// retried before the item goes to review.
+const fsRetryAttempts = 3
+
+// fsRetryBackoff is the delay before the first retry of a transient
+// filesystem failure; each further retry doubles it.
+var fsRetryBackoff = 2 * time.Second
+
+// transientFSErrnos are the errors network mounts (NFS/SMB) return for
+// conditions that clear on their own. Anything else, notably ENOSPC, EDQUOT,
+// EROFS, and permission errors, fails immediately.
+var transientFSErrnos = []syscall.Errno{
+	syscall.EAGAIN,
+	syscall.ESTALE,
+	syscall.EINTR,
+	syscall.EBUSY,
+	syscall.ETIMEDOUT,
+	syscall.EHOSTDOWN,
+	syscall.EHOSTUNREACH,
+	syscall.ECONNRESET,
+}
+
+func isTransientFSError(err error) bool {
+	for _, errno := range transientFSErrnos {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+	return false
+}
+
+// fsRetriesExhaustedError reports a transient filesystem failure that
+// persisted through every retry.
+type fsRetriesExhaustedError struct {
+	Op       string
+	Attempts int
+	Err      error
+}
+
+func (e *fsRetriesExhaustedError) Error() string {
+	return fmt.Sprintf("%s failed after %d attempts: %v", e.Op, e.Attempts, e.Err)
+}
+
+func (e *fsRetriesExhaustedError) Unwrap() error { return e.Err }
+
+// retryFS runs fn, retrying transient filesystem failures up to
+// fsRetryAttempts times with exponential backoff. Permanent failures return
+// at once; a transient failure that outlasts the retries is returned as
+// *fsRetriesExhaustedError.
+func retryFS(ctx context.Context, logger *slog.Logger, op string, fn func() error) error {
+	delay := fsRetryBackoff
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || !isTransientFSError(err) {
+			return err
+		}
+		if attempt > fsRetryAttempts {
+			return &fsRetriesExhaustedError{Op: op, Attempts: attempt, Err: err}
+		}
+		logger.Warn("transient filesystem error; retrying",
+			"event_type", "organize_fs_retry",
+			"error_hint", err.Error(),
+			"impact", fmt.Sprintf("%s retried in %s", op, delay),
+			"attempt", attempt,
+			"max_attempts", fsRetryAttempts+1,
+		)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
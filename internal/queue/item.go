@@ -44,7 +44,14 @@ type Item struct {
 	NeedsReview         int
 	ReviewReason        string
 	EncodingDetailsJSON string
-	userStopped         int
+	// EstimatedEncodeCost is the identification-time encode cost estimate
+	// in 1080p SDR runtime-second equivalents (0 when unknown). The
+	// scheduler may order the encoding lane by it.
+	EstimatedEncodeCost float64
+	// Notes is free text an operator attached to the item, e.g. why it is
+	// in review or how the disc behaves. Only Store.SetNotes writes it.
+	Notes       string
+	userStopped int
 }
 
 // UserStopped reports whether the item was explicitly stopped by the user.
@@ -76,6 +83,16 @@ func (it *Item) CreatedTime() (created time.Time, ok bool) {
 	return t, true
 }
 
+// UpdatedTime parses the item's last-update timestamp; ok is false when the
+// stored value is missing or unparseable.
+func (it *Item) UpdatedTime() (updated time.Time, ok bool) {
+	t, err := parseTimestamp(it.UpdatedAt)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
 // StagingRoot computes the per-item working directory under base.
 // If DiscFingerprint is non-empty, the uppercase fingerprint is used as the
 // directory name. Otherwise "queue-{ID}" is used.
@@ -149,6 +149,57 @@ func TestLifecycleAndTitleUpdates(t *testing.T) {
 	}
 }
 
+func TestNotesSurviveTransitionsAndRetry(t *testing.T) {
+	store := openTestStore(t)
+
+	item, err := store.NewDisc("Warped Disc", "fp1")
+	if err != nil {
+		t.Fatalf("new disc: %v", err)
+	}
+	found, err := store.SetNotes(item.ID, "warped, read slowly")
+	if err != nil || !found {
+		t.Fatalf("set notes: found=%v err=%v", found, err)
+	}
+	if found, err := store.SetNotes(item.ID+100, "x"); err != nil || found {
+		t.Fatalf("set notes on missing item: found=%v err=%v", found, err)
+	}
+
+	// item still holds the pre-notes row, as a running stage's copy would;
+	// its writes must not clobber the column.
+	steps := []struct {
+		name string
+		run  func() error
+	}{
+		{"move", func() error { return store.MoveToStage(item, StageRipping) }},
+		{"start", func() error { return store.StartStage(item) }},
+		{"work state", func() error { item.ReviewReason = "low confidence"; return store.UpdateWorkState(item) }},
+		{"complete", func() error { return store.CompleteStage(item, StageEncoding, true) }},
+		{"fail", func() error { return store.FailStage(item, StageEncoding, "boom") }},
+		{"retry", func() error { _, err := store.RetryFailed(item.ID); return err }},
+		{"stop", func() error { _, err := store.StopItems(item.ID); return err }},
+	}
+	for _, step := range steps {
+		if err := step.run(); err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		got, err := store.GetByID(item.ID)
+		if err != nil {
+			t.Fatalf("get after %s: %v", step.name, err)
+		}
+		if got.Notes != "warped, read slowly" {
+			t.Fatalf("notes after %s = %q", step.name, got.Notes)
+		}
+	}
+
+	if _, err := store.SetNotes(item.ID, ""); err != nil {
+		t.Fatalf("clear notes: %v", err)
+	}
+	got, _ := store.GetByID(item.ID)
+	if got.Notes != "" {
+		t.Fatalf("notes after clear = %q", got.Notes)
+	}
+}
+
 func TestUpdateTaskProgress(t *testing.T) {
 	store := openTestStore(t)
 
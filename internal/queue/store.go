@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -30,7 +31,9 @@ CREATE TABLE IF NOT EXISTS queue_items (
     needs_review INTEGER NOT NULL DEFAULT 0,
     review_reason TEXT,
     encoding_details_json TEXT,
-    user_stopped INTEGER NOT NULL DEFAULT 0
+    estimated_encode_cost REAL NOT NULL DEFAULT 0,
+    user_stopped INTEGER NOT NULL DEFAULT 0,
+    notes TEXT
 );
 
 CREATE INDEX IF NOT EXISTS idx_queue_stage ON queue_items(stage);
@@ -128,7 +131,8 @@ func isBusyError(err error) bool {
 // allColumns is the column list for SELECT queries.
 const allColumns = `id, disc_title, stage, in_progress, failed_at_stage, error_message,
     created_at, updated_at, rip_spec_data, disc_fingerprint, metadata_json,
-    needs_review, review_reason, encoding_details_json, user_stopped`
+    needs_review, review_reason, encoding_details_json, estimated_encode_cost, user_stopped,
+    notes`
 
 // scanItem scans a row into an Item.
 func scanItem(row interface{ Scan(...any) error }) (*Item, error) {
@@ -136,7 +140,7 @@ func scanItem(row interface{ Scan(...any) error }) (*Item, error) {
 	var discTitle, failedAtStage, errorMessage sql.NullString
 	var createdAt, updatedAt sql.NullString
 	var ripSpecData, discFingerprint, metadataJSON sql.NullString
-	var reviewReason, encodingDetailsJSON sql.NullString
+	var reviewReason, encodingDetailsJSON, notes sql.NullString
 	var stage string
 
 	err := row.Scan(
@@ -145,7 +149,8 @@ func scanItem(row interface{ Scan(...any) error }) (*Item, error) {
 		&createdAt, &updatedAt,
 		&ripSpecData, &discFingerprint, &metadataJSON,
 		&it.NeedsReview, &reviewReason,
-		&encodingDetailsJSON, &it.userStopped,
+		&encodingDetailsJSON, &it.EstimatedEncodeCost, &it.userStopped,
+		&notes,
 	)
 	if err != nil {
 		return nil, err
@@ -162,6 +167,7 @@ func scanItem(row interface{ Scan(...any) error }) (*Item, error) {
 	it.MetadataJSON = metadataJSON.String
 	it.ReviewReason = reviewReason.String
 	it.EncodingDetailsJSON = encodingDetailsJSON.String
+	it.Notes = notes.String
 
 	return &it, nil
 }
@@ -195,6 +201,15 @@ func (s *Store) NewCachedRip(title, fingerprint, ripSpecData, metadataJSON strin
 	return s.insertItem(title, fingerprint, StageRipping, ripSpecData, metadataJSON)
 }
 
+// NewImportedRip inserts an item whose rip already exists on disk (an
+// imported MKV folder) at stage, which must come after ripping.
+func (s *Store) NewImportedRip(title, fingerprint string, stage Stage, ripSpecData, metadataJSON string) (*Item, error) {
+	if slices.Index(StageOrder, stage) <= slices.Index(StageOrder, StageRipping) {
+		return nil, fmt.Errorf("new imported item: stage %q does not follow ripping", stage)
+	}
+	return s.insertItem(title, fingerprint, stage, ripSpecData, metadataJSON)
+}
+
 func (s *Store) insertItem(title, fingerprint string, stage Stage, ripSpecData, metadataJSON string) (*Item, error) {
 	var id int64
 	err := retryOnBusy(func() error {
@@ -364,6 +379,24 @@ func (s *Store) FailStage(item *Item, failedAt Stage, errMsg string) error {
 	)
 }
 
+// FlagReview appends reason to an item's review reasons. Only the review
+// columns are written, so sibling workers' work-state writes survive, and a
+// user stop that raced the write wins.
+func (s *Store) FlagReview(item *Item, reason string) error {
+	flagged := *item
+	flagged.AppendReviewReason(reason)
+	return s.execUnlessStopped(item, fmt.Sprintf("flag review item %d", item.ID), func() {
+		item.NeedsReview = flagged.NeedsReview
+		item.ReviewReason = flagged.ReviewReason
+	}, `
+		UPDATE queue_items SET
+			needs_review = ?, review_reason = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND user_stopped = 0`,
+		flagged.NeedsReview, flagged.ReviewReason, item.ID,
+	)
+}
+
 // UpdateDiscTitle changes only the queue item's display title.
 func (s *Store) UpdateDiscTitle(item *Item, title string) error {
 	item.DiscTitle = title
@@ -376,6 +409,24 @@ func (s *Store) UpdateDiscTitle(item *Item, title string) error {
 	})
 }
 
+// SetNotes replaces an item's operator notes. Notes are the operator's
+// alone: no lifecycle or work-state write touches the column, so they
+// survive stage transitions, stops, and retries. It reports false when no
+// item has the ID.
+func (s *Store) SetNotes(id int64, notes string) (bool, error) {
+	var found bool
+	err := retryOnBusy(func() error {
+		res, err := s.db.Exec(`UPDATE queue_items SET notes = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`, notes, id)
+		if err != nil {
+			return fmt.Errorf("set notes item %d: %w", id, err)
+		}
+		n, err := res.RowsAffected()
+		found = n > 0
+		return err
+	})
+	return found, err
+}
+
 // UpdateWorkState updates queue-visible work products without changing
 // lifecycle-owned fields such as stage, in_progress, failed_at_stage, or
 // error_message. Stage handlers use this through stage.Session so saving a
@@ -388,12 +439,12 @@ func (s *Store) UpdateWorkState(item *Item) error {
 			updated_at = CURRENT_TIMESTAMP,
 			rip_spec_data = ?, disc_fingerprint = ?, metadata_json = ?,
 			needs_review = ?, review_reason = ?,
-			encoding_details_json = ?
+			encoding_details_json = ?, estimated_encode_cost = ?
 		WHERE id = ? AND user_stopped = 0`,
 		item.DiscTitle,
 		item.RipSpecData, item.DiscFingerprint, item.MetadataJSON,
 		item.NeedsReview, item.ReviewReason,
-		item.EncodingDetailsJSON,
+		item.EncodingDetailsJSON, item.EstimatedEncodeCost,
 		item.ID,
 	)
 }
@@ -644,6 +695,75 @@ func (s *Store) RetryWithRipSpec(id int64, targetStage Stage, ripSpecData string
 	})
 }
 
+// ApproveReview routes a completed item awaiting review back to organizing
+// with its corrected RipSpec, metadata, and title. The review flags are
+// cleared so the organizer places the item in the library. It reports false
+// when the item is no longer a completed, pending review.
+func (s *Store) ApproveReview(id int64, ripSpecData, metadataJSON, discTitle string) (bool, error) {
+	var updated bool
+	err := retryOnBusy(func() error {
+		updated = false
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		res, err := tx.Exec(`
+			UPDATE queue_items SET
+				stage = ?, in_progress = 0,
+				failed_at_stage = NULL, error_message = NULL,
+				needs_review = 0, review_reason = NULL,
+				rip_spec_data = ?, metadata_json = ?, disc_title = ?,
+				updated_at = CURRENT_TIMESTAMP
+			WHERE id = ? AND stage = ? AND needs_review = 1 AND in_progress = 0`,
+			string(StageOrganizing), ripSpecData, metadataJSON, discTitle,
+			id, string(StageCompleted),
+		)
+		if err != nil {
+			return fmt.Errorf("approve review %d: %w", id, err)
+		}
+		n, _ := res.RowsAffected()
+		if n == 0 {
+			return nil
+		}
+		if _, err := tx.Exec("DELETE FROM tasks WHERE item_id = ?", id); err != nil {
+			return fmt.Errorf("approve review %d tasks: %w", id, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		updated = true
+		return nil
+	})
+	return updated, err
+}
+
+// RejectReview fails a completed item awaiting review with errMsg. The item
+// fails at organizing, so a later retry re-runs placement. It reports false
+// when the item is no longer a completed, pending review.
+func (s *Store) RejectReview(id int64, errMsg string) (bool, error) {
+	var updated bool
+	err := retryOnBusy(func() error {
+		res, err := s.db.Exec(`
+			UPDATE queue_items SET
+				stage = ?, in_progress = 0,
+				failed_at_stage = ?, error_message = ?,
+				updated_at = CURRENT_TIMESTAMP
+			WHERE id = ? AND stage = ? AND needs_review = 1 AND in_progress = 0`,
+			string(StageFailed), string(StageOrganizing), errMsg,
+			id, string(StageCompleted),
+		)
+		if err != nil {
+			return fmt.Errorf("reject review %d: %w", id, err)
+		}
+		n, _ := res.RowsAffected()
+		updated = n > 0
+		return nil
+	})
+	return updated, err
+}
+
 // StopItems marks items as failed with a "Stop requested by user" review reason.
 // Returns the number of items actually stopped.
 func (s *Store) StopItems(ids ...int64) (int, error) {
@@ -35,6 +35,7 @@ CREATE TABLE IF NOT EXISTS tasks (
     progress_message TEXT NOT NULL DEFAULT '',
     progress_bytes_copied INTEGER NOT NULL DEFAULT 0,
     progress_total_bytes INTEGER NOT NULL DEFAULT 0,
+    progress_eta_seconds REAL NOT NULL DEFAULT 0,
     active_asset_key TEXT NOT NULL DEFAULT '',
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     started_at TIMESTAMP,
@@ -68,6 +69,7 @@ type Task struct {
 	ProgressMessage     string
 	ProgressBytesCopied int64
 	ProgressTotalBytes  int64
+	ProgressETASeconds  float64
 	ActiveAssetKey      string
 	StartedAt           string
 	FinishedAt          string
@@ -237,11 +239,11 @@ func (s *Store) taskStates() (map[int64]TaskState, error) {
 // taskColumns is the column list scanTask expects, in order.
 const taskColumns = `id, item_id, type, asset_key, state, attempts, error_message, deps,
     progress_percent, progress_message, progress_bytes_copied, progress_total_bytes,
-    active_asset_key, started_at, finished_at`
+    progress_eta_seconds, active_asset_key, started_at, finished_at`
 
 const taskColumnsPrefixed = `t.id, t.item_id, t.type, t.asset_key, t.state, t.attempts, t.error_message, t.deps,
     t.progress_percent, t.progress_message, t.progress_bytes_copied, t.progress_total_bytes,
-    t.active_asset_key, t.started_at, t.finished_at`
+    t.progress_eta_seconds, t.active_asset_key, t.started_at, t.finished_at`
 
 func scanTask(rows *sql.Rows) (*Task, error) {
 	t := &Task{}
@@ -249,7 +251,7 @@ func scanTask(rows *sql.Rows) (*Task, error) {
 	var startedAt, finishedAt sql.NullString
 	if err := rows.Scan(&t.ID, &t.ItemID, &typ, &t.AssetKey, &state, &t.Attempts, &t.ErrorMsg, &deps,
 		&t.ProgressPercent, &t.ProgressMessage, &t.ProgressBytesCopied, &t.ProgressTotalBytes,
-		&t.ActiveAssetKey, &startedAt, &finishedAt); err != nil {
+		&t.ProgressETASeconds, &t.ActiveAssetKey, &startedAt, &finishedAt); err != nil {
 		return nil, fmt.Errorf("scan task: %w", err)
 	}
 	t.Type = Stage(typ)
@@ -328,11 +330,11 @@ func (s *Store) UpdateTaskProgress(t *Task) error {
 			UPDATE tasks SET
 				progress_percent = ?, progress_message = ?,
 				progress_bytes_copied = ?, progress_total_bytes = ?,
-				active_asset_key = ?
+				progress_eta_seconds = ?, active_asset_key = ?
 			WHERE id = ?`,
 			t.ProgressPercent, t.ProgressMessage,
 			t.ProgressBytesCopied, t.ProgressTotalBytes,
-			t.ActiveAssetKey, t.ID)
+			t.ProgressETASeconds, t.ActiveAssetKey, t.ID)
 		if err != nil {
 			return fmt.Errorf("update task %d progress: %w", t.ID, err)
 		}
@@ -359,3 +361,27 @@ func (s *Store) TasksForItem(itemID int64) ([]*Task, error) {
 	}
 	return tasks, rows.Err()
 }
+
+// TasksFinishedSince returns done tasks that ran (have a start time) and
+// finished at or after since, oldest first. Tasks compiled as done without
+// running are left out, so the result reflects measured stage work.
+func (s *Store) TasksFinishedSince(since time.Time) ([]*Task, error) {
+	rows, err := s.db.Query(`
+		SELECT `+taskColumns+`
+		FROM tasks WHERE state = ? AND started_at IS NOT NULL AND finished_at >= ?
+		ORDER BY finished_at, id`,
+		string(TaskDone), since.UTC().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return nil, fmt.Errorf("query finished tasks: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	var tasks []*Task
+	for rows.Next() {
+		t, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, rows.Err()
+}
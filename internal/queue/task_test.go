@@ -355,3 +355,35 @@ func TestItemCreatedTimeFromStore(t *testing.T) {
 		t.Fatalf("CreatedTime %v implausible for a just-created item", created)
 	}
 }
+
+func TestTasksFinishedSinceSkipsCompiledDoneTasks(t *testing.T) {
+	store := openTestStore(t)
+	item, _ := store.NewCachedRip("A", "fp1", `{"version":1}`, "")
+	if err := store.EnsureTasks(item, testSpecs); err != nil {
+		t.Fatalf("ensure tasks: %v", err)
+	}
+	ready, err := store.ReadyTasks()
+	if err != nil || len(ready) != 1 || ready[0].Type != StageRipping {
+		t.Fatalf("ready = %v err=%v, want ripping", ready, err)
+	}
+	if err := store.StartTask(ready[0]); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if err := store.FinishTask(ready[0], TaskDone, ""); err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+
+	finished, err := store.TasksFinishedSince(time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("finished since: %v", err)
+	}
+	if len(finished) != 1 || finished[0].Type != StageRipping {
+		t.Fatalf("finished = %v, want only the ripping task that ran", finished)
+	}
+	if _, ok := finished[0].Duration(); !ok {
+		t.Fatalf("finished task has no duration: %+v", finished[0])
+	}
+	if later, _ := store.TasksFinishedSince(time.Now().Add(time.Hour)); len(later) != 0 {
+		t.Fatalf("tasks finished in the future = %v, want none", later)
+	}
+}
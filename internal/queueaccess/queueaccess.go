@@ -2,28 +2,45 @@ package queueaccess
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"strconv"
 	"time"
 
+	"github.com/five82/spindle/internal/daemonctl"
 	"github.com/five82/spindle/internal/httpapi"
 	"github.com/five82/spindle/internal/queue"
 	"github.com/five82/spindle/internal/queueops"
+	"github.com/five82/spindle/internal/ripspec"
 	"github.com/five82/spindle/internal/sockhttp"
 )
 
 // ErrDaemonUnavailable is returned when the daemon HTTP API cannot be reached.
 var ErrDaemonUnavailable = errors.New("daemon is not running; run spindle start")
 
+// Reconnect backoff bounds. Retries start fast so a daemon that is just
+// binding its socket is picked up quickly, then slow down.
+var (
+	minReconnectBackoff = 100 * time.Millisecond
+	maxReconnectBackoff = time.Second
+)
+
 // HTTPAccess connects to the daemon HTTP API.
 type HTTPAccess struct {
 	token  string
 	client *http.Client
+	// reconnect is how long requests keep retrying a daemon socket that
+	// refuses connections; zero fails immediately.
+	reconnect time.Duration
+	// lockPath is the daemon lock file. Dial failures are only retried
+	// while another process holds it, i.e. while a daemon is starting up.
+	lockPath string
 }
 
 // NewHTTPAccess creates an HTTP-based queue accessor.
@@ -34,16 +51,21 @@ func NewHTTPAccess(socketPath, token string) *HTTPAccess {
 	}
 }
 
-// OpenHTTP verifies that the daemon API is reachable and returns an HTTP accessor.
-func OpenHTTP(socketPath, token string) (*HTTPAccess, error) {
+// OpenHTTP verifies that the daemon API is reachable and returns an HTTP
+// accessor. While a daemon holds lockPath but is not yet serving, the health
+// check and later requests retry with backoff for up to reconnect before
+// giving up; with no daemon running they fail immediately.
+func OpenHTTP(socketPath, lockPath, token string, reconnect time.Duration) (*HTTPAccess, error) {
 	a := NewHTTPAccess(socketPath, token)
+	a.reconnect = reconnect
+	a.lockPath = lockPath
 	req, err := http.NewRequest(http.MethodGet, "http://localhost/api/health", nil)
 	if err != nil {
 		return nil, fmt.Errorf("create health request: %w", err)
 	}
-	resp, err := a.client.Do(req)
+	resp, err := a.do(a.client, req)
 	if err != nil {
-		return nil, ErrDaemonUnavailable
+		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 	if resp.StatusCode != http.StatusOK {
@@ -64,7 +86,13 @@ type queueGetResponse struct {
 }
 
 type queueRetryResponse struct {
-	Updated int `json:"updated"`
+	Updated int                `json:"updated"`
+	Items   []queueops.Resumed `json:"items"`
+}
+
+type queueStopResponse struct {
+	Updated   int `json:"updated"`
+	Cancelled int `json:"cancelled"`
 }
 
 type queueClearResponse struct {
@@ -75,6 +103,25 @@ type queueRetryEpisodeResponse struct {
 	Result queueops.RetryResult `json:"result"`
 }
 
+type queueOptionsResponse struct {
+	Result queueops.OptionsResult `json:"result"`
+}
+
+type queueNotesResponse struct {
+	Result queueops.NotesResult `json:"result"`
+}
+
+// Review is an item awaiting a review decision, with its match candidates.
+type Review = httpapi.ReviewResponse
+
+type reviewListResponse struct {
+	Reviews []Review `json:"reviews"`
+}
+
+type reviewDecideResponse struct {
+	Result queueops.ReviewResult `json:"result"`
+}
+
 type queueEnqueueCachedResponse struct {
 	Item Item `json:"item"`
 }
@@ -96,6 +143,9 @@ type Status struct {
 	LockFilePath string
 	Workflow     WorkflowStatus
 	Dependencies []DependencyStatus
+	// Response is the API DTO the fields above were decoded from, printed
+	// as-is by --json output.
+	Response httpapi.StatusAPIResponse
 }
 
 // WorkflowStatus is the daemon workflow status used by CLI rendering.
@@ -103,6 +153,7 @@ type WorkflowStatus struct {
 	Running    bool
 	QueueStats map[queue.Stage]int
 	LastError  string
+	Throughput *httpapi.ThroughputStatus
 }
 
 // DependencyStatus reports an external dependency health check.
@@ -120,6 +171,7 @@ type LogsQuery struct {
 	Component  string
 	Lane       string
 	Request    string
+	Stage      string
 	Level      string
 	DaemonOnly bool
 }
@@ -132,6 +184,56 @@ type logsResponse struct {
 // Logs fetches log events via HTTP. The returned cursor is fed back as
 // q.Since on the next poll.
 func (a *HTTPAccess) Logs(q LogsQuery) ([]LogEntry, uint64, error) {
+	var resp logsResponse
+	if err := a.getJSON("/api/logs?"+q.params().Encode(), &resp); err != nil {
+		return nil, 0, err
+	}
+	return resp.Events, resp.Next, nil
+}
+
+// StreamLogs streams matching log events from the daemon, calling fn for
+// each: first the buffered window selected by q, then live events as they
+// are logged. It returns nil once ctx is cancelled and an error if the
+// stream drops; resume by setting q.Since past the last event seen.
+func (a *HTTPAccess) StreamLogs(ctx context.Context, q LogsQuery, fn func(LogEntry)) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost/api/logs/stream?"+q.params().Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	sockhttp.SetAuth(req, a.token)
+	// The stream stays open indefinitely, so it cannot share the
+	// request-timeout client.
+	stream := &http.Client{Transport: a.client.Transport}
+	resp, err := a.do(stream, req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return decodeResponse(req.URL.Path, resp.StatusCode, body, nil)
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var entry LogEntry
+		if err := dec.Decode(&entry); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if errors.Is(err, io.EOF) {
+				return errors.New("log stream closed by daemon")
+			}
+			return fmt.Errorf("read log stream: %w", err)
+		}
+		fn(entry)
+	}
+}
+
+func (q LogsQuery) params() url.Values {
 	params := url.Values{}
 	if q.Since > 0 {
 		params.Set("since", strconv.FormatUint(q.Since, 10))
@@ -154,17 +256,16 @@ func (a *HTTPAccess) Logs(q LogsQuery) ([]LogEntry, uint64, error) {
 	if q.Request != "" {
 		params.Set("request", q.Request)
 	}
+	if q.Stage != "" {
+		params.Set("stage", q.Stage)
+	}
 	if q.Level != "" {
 		params.Set("level", q.Level)
 	}
 	if q.DaemonOnly {
 		params.Set("daemon_only", "1")
 	}
-	var resp logsResponse
-	if err := a.getJSON("/api/logs?"+params.Encode(), &resp); err != nil {
-		return nil, 0, err
-	}
-	return resp.Events, resp.Next, nil
+	return params
 }
 
 // List returns queue items via HTTP, optionally filtered by stages.
@@ -208,7 +309,58 @@ func (a *HTTPAccess) Status() (*Status, error) {
 	if err := a.getJSON("/api/status", &resp); err != nil {
 		return nil, err
 	}
+	return statusFromResponse(resp), nil
+}
+
+// Snapshot is daemon status plus the full queue, fetched together.
+type Snapshot struct {
+	Status *Status
+	Items  []Item
+	// ItemsErr is set when the queue listing failed; Items is then nil.
+	ItemsErr error
+}
+
+// Snapshot returns daemon status and queue items in a single /api/batch
+// round trip. Only a status failure fails the call; a queue listing error
+// is reported in ItemsErr.
+func (a *HTTPAccess) Snapshot() (*Snapshot, error) {
+	responses, err := a.Batch([]httpapi.BatchRequest{
+		{Path: "/api/status"},
+		{Path: "/api/queue"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(responses) != 2 {
+		return nil, fmt.Errorf("http /api/batch: got %d responses, want 2", len(responses))
+	}
+	var status httpapi.StatusAPIResponse
+	if err := decodeResponse("/api/status", responses[0].Status, responses[0].Body, &status); err != nil {
+		return nil, err
+	}
+	snap := &Snapshot{Status: statusFromResponse(status)}
+	var list queueListResponse
+	if err := decodeResponse("/api/queue", responses[1].Status, responses[1].Body, &list); err != nil {
+		snap.ItemsErr = err
+	} else {
+		snap.Items = list.Items
+	}
+	return snap, nil
+}
+
+// Batch sends several requests in one /api/batch call. Responses are in
+// request order, each carrying its own status and body.
+func (a *HTTPAccess) Batch(reqs []httpapi.BatchRequest) ([]httpapi.BatchResponse, error) {
+	var resp struct {
+		Responses []httpapi.BatchResponse `json:"responses"`
+	}
+	if err := a.postJSON("/api/batch", map[string]any{"requests": reqs}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Responses, nil
+}
 
+func statusFromResponse(resp httpapi.StatusAPIResponse) *Status {
 	stats := make(map[queue.Stage]int, len(resp.Workflow.QueueStats))
 	for k, v := range resp.Workflow.QueueStats {
 		stats[queue.Stage(k)] = v
@@ -228,9 +380,11 @@ func (a *HTTPAccess) Status() (*Status, error) {
 			Running:    resp.Workflow.Running,
 			QueueStats: stats,
 			LastError:  resp.Workflow.LastError,
+			Throughput: resp.Workflow.Throughput,
 		},
 		Dependencies: deps,
-	}, nil
+		Response:     resp,
+	}
 }
 
 // Retry retries failed queue items via HTTP. No IDs means retry all failed items.
@@ -242,6 +396,17 @@ func (a *HTTPAccess) Retry(ids ...int64) (int, error) {
 	return resp.Updated, nil
 }
 
+// RetryResume retries failed queue items via HTTP, restoring missing rips
+// from the rip cache so they resume where they failed. No IDs means every
+// failed item. See queueops.ResumeFailed.
+func (a *HTTPAccess) RetryResume(ids ...int64) ([]queueops.Resumed, error) {
+	var resp queueRetryResponse
+	if err := a.postJSON("/api/queue/retry", map[string]any{"ids": ids, "resume": true}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
 // RetryEpisode retries a single failed episode via HTTP.
 func (a *HTTPAccess) RetryEpisode(id int64, episodeKey string) (queueops.RetryResult, error) {
 	var resp queueRetryEpisodeResponse
@@ -252,13 +417,65 @@ func (a *HTTPAccess) RetryEpisode(id int64, episodeKey string) (queueops.RetryRe
 	return resp.Result, nil
 }
 
-// Stop marks queue items stopped via HTTP.
-func (a *HTTPAccess) Stop(ids ...int64) (int, error) {
-	var resp queueRetryResponse
+// SetOptions replaces an item's per-item processing options via HTTP.
+func (a *HTTPAccess) SetOptions(id int64, opts ripspec.ItemOptions) (queueops.OptionsResult, error) {
+	var resp queueOptionsResponse
+	body := map[string]any{
+		"id":                id,
+		"skip_subtitles":    opts.SkipSubtitles,
+		"skip_commentary":   opts.SkipCommentary,
+		"encode_crf":        opts.EncodeCRF,
+		"pinned_tmdb_id":    opts.PinnedTMDBID,
+		"pinned_media_type": opts.PinnedMediaType,
+	}
+	if err := a.postJSON("/api/queue/options", body, &resp); err != nil {
+		return "", err
+	}
+	return resp.Result, nil
+}
+
+// SetNotes replaces an item's operator notes via HTTP.
+func (a *HTTPAccess) SetNotes(id int64, notes string) (queueops.NotesResult, error) {
+	var resp queueNotesResponse
+	if err := a.postJSON("/api/queue/notes", map[string]any{"id": id, "notes": notes}, &resp); err != nil {
+		return "", err
+	}
+	return resp.Result, nil
+}
+
+// Stop marks queue items stopped via HTTP. cancelled counts the items whose
+// running stage was interrupted.
+func (a *HTTPAccess) Stop(ids ...int64) (updated, cancelled int, err error) {
+	var resp queueStopResponse
 	if err := a.postJSON("/api/queue/stop", map[string]any{"ids": ids}, &resp); err != nil {
-		return 0, err
+		return 0, 0, err
 	}
-	return resp.Updated, nil
+	return resp.Updated, resp.Cancelled, nil
+}
+
+// Reviews returns the items awaiting a review decision via HTTP.
+func (a *HTTPAccess) Reviews() ([]Review, error) {
+	var resp reviewListResponse
+	if err := a.getJSON("/api/review", &resp); err != nil {
+		return nil, err
+	}
+	return resp.Reviews, nil
+}
+
+// DecideReview applies a review decision to an item via HTTP.
+func (a *HTTPAccess) DecideReview(id int64, d queueops.ReviewDecision) (queueops.ReviewResult, error) {
+	var resp reviewDecideResponse
+	body := map[string]any{
+		"decision": string(d.Action),
+		"tmdb_id":  d.CandidateID,
+		"episodes": d.Episodes,
+		"note":     d.Note,
+		"replace":  d.Replace,
+	}
+	if err := a.postJSON(fmt.Sprintf("/api/review/%d", id), body, &resp); err != nil {
+		return "", err
+	}
+	return resp.Result, nil
 }
 
 // EnqueueCached queues a cached rip for processing via HTTP.
@@ -270,6 +487,16 @@ func (a *HTTPAccess) EnqueueCached(req EnqueueCachedRequest) (*Item, error) {
 	return &resp.Item, nil
 }
 
+// EnqueueImport queues an imported MKV folder via HTTP. The request carries
+// the synthesized rip spec; the item starts after ripping.
+func (a *HTTPAccess) EnqueueImport(req EnqueueCachedRequest) (*Item, error) {
+	var resp queueEnqueueCachedResponse
+	if err := a.postJSON("/api/queue/import", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Item, nil
+}
+
 // Clear clears queue items by scope via HTTP.
 func (a *HTTPAccess) Clear(scope string) (int64, error) {
 	var resp queueClearResponse
@@ -322,9 +549,9 @@ func (a *HTTPAccess) deleteJSON(path string, dest any) error {
 func (a *HTTPAccess) doJSON(req *http.Request, dest any) error {
 	sockhttp.SetAuth(req, a.token)
 
-	resp, err := a.client.Do(req)
+	resp, err := a.do(a.client, req)
 	if err != nil {
-		return ErrDaemonUnavailable
+		return err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
@@ -333,24 +560,67 @@ func (a *HTTPAccess) doJSON(req *http.Request, dest any) error {
 		return fmt.Errorf("read response from %s: %w", req.URL.Path, err)
 	}
 
-	if resp.StatusCode == http.StatusUnauthorized {
+	return decodeResponse(req.URL.Path, resp.StatusCode, body, dest)
+}
+
+// do sends req, retrying with backoff while a starting daemon refuses
+// connections and the reconnect deadline has not passed. Only dial failures
+// are retried: the request never reached the daemon, so replaying it is safe.
+// A failed dial with the daemon lock free means nothing is coming up, so it
+// fails at once instead of waiting out the deadline.
+func (a *HTTPAccess) do(client *http.Client, req *http.Request) (*http.Response, error) {
+	deadline := time.Now().Add(a.reconnect)
+	backoff := minReconnectBackoff
+	for {
+		resp, err := client.Do(req)
+		if err == nil {
+			return resp, nil
+		}
+		if a.reconnect <= 0 || !isDialError(err) || !daemonctl.LockHeld(a.lockPath) {
+			return nil, ErrDaemonUnavailable
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, fmt.Errorf("%w (still unreachable after %s)", ErrDaemonUnavailable, a.reconnect)
+		}
+		time.Sleep(min(backoff, remaining))
+		backoff = min(backoff*2, maxReconnectBackoff)
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("rewind request body: %w", err)
+			}
+			req.Body = body
+		}
+	}
+}
+
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// decodeResponse maps an API status and body to an error or decodes the
+// body into dest. Batch entries go through the same path as direct calls.
+func decodeResponse(path string, status int, body []byte, dest any) error {
+	if status == http.StatusUnauthorized {
 		return errors.New("daemon rejected the API token; check the api token in the config")
 	}
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+	if status < 200 || status >= 300 {
 		var errResp struct {
 			Error string `json:"error"`
 		}
 		if json.Unmarshal(body, &errResp) == nil && errResp.Error != "" {
-			return fmt.Errorf("http %s: status %d: %s", req.URL.Path, resp.StatusCode, errResp.Error)
+			return fmt.Errorf("http %s: status %d: %s", path, status, errResp.Error)
 		}
-		return fmt.Errorf("http %s: status %d: %s", req.URL.Path, resp.StatusCode, string(body))
+		return fmt.Errorf("http %s: status %d: %s", path, status, string(body))
 	}
 
 	if dest == nil {
 		return nil
 	}
 	if err := json.Unmarshal(body, dest); err != nil {
-		return fmt.Errorf("decode response from %s: %w", req.URL.Path, err)
+		return fmt.Errorf("decode response from %s: %w", path, err)
 	}
 	return nil
 }
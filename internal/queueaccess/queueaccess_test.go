@@ -3,20 +3,128 @@ package queueaccess
 import (
 	"errors"
 	"io"
+	"net"
 	"net/http"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/gofrs/flock"
 )
 
 func TestOpenHTTPDaemonUnavailable(t *testing.T) {
 	socketPath := filepath.Join(t.TempDir(), "missing.sock")
-	_, err := OpenHTTP(socketPath, "")
+	_, err := OpenHTTP(socketPath, "", "", 0)
 	if !errors.Is(err, ErrDaemonUnavailable) {
 		t.Fatalf("OpenHTTP error = %v, want ErrDaemonUnavailable", err)
 	}
 }
 
+// holdDaemonLock takes the daemon lock next to socketPath, standing in
+// for a daemon process that has started but is not serving yet.
+func holdDaemonLock(t *testing.T, socketPath string) string {
+	t.Helper()
+	lockPath := socketPath + ".lock"
+	fl := flock.New(lockPath)
+	if err := fl.Lock(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = fl.Unlock() })
+	return lockPath
+}
+
+// shortSocketPath returns a socket path under the OS temp dir; t.TempDir
+// paths can exceed the unix socket path limit.
+func shortSocketPath(t *testing.T) string {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "qa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	return filepath.Join(dir, "d.sock")
+}
+
+func TestOpenHTTPWaitsForRestartingDaemon(t *testing.T) {
+	socketPath := shortSocketPath(t)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/health", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	})
+	mux.HandleFunc("POST /api/queue/retry", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), "[3]") {
+			t.Errorf("retried request body = %q, want ids preserved", body)
+		}
+		_, _ = w.Write([]byte(`{"updated":1}`))
+	})
+	srv := &http.Server{Handler: mux}
+	t.Cleanup(func() { _ = srv.Close() })
+
+	// The socket appears only after the client has started retrying.
+	go func() {
+		time.Sleep(300 * time.Millisecond)
+		ln, err := net.Listen("unix", socketPath)
+		if err != nil {
+			t.Errorf("listen: %v", err)
+			return
+		}
+		_ = srv.Serve(ln)
+	}()
+
+	access, err := OpenHTTP(socketPath, holdDaemonLock(t, socketPath), "", 5*time.Second)
+	if err != nil {
+		t.Fatalf("OpenHTTP: %v", err)
+	}
+	updated, err := access.Retry(3)
+	if err != nil || updated != 1 {
+		t.Fatalf("Retry = %d, %v; want 1, nil", updated, err)
+	}
+}
+
+func TestOpenHTTPGivesUpAtReconnectDeadline(t *testing.T) {
+	socketPath := shortSocketPath(t)
+	lockPath := holdDaemonLock(t, socketPath)
+	start := time.Now()
+	_, err := OpenHTTP(socketPath, lockPath, "", 300*time.Millisecond)
+	elapsed := time.Since(start)
+	if !errors.Is(err, ErrDaemonUnavailable) {
+		t.Fatalf("OpenHTTP error = %v, want ErrDaemonUnavailable", err)
+	}
+	if !strings.Contains(err.Error(), "still unreachable after 300ms") {
+		t.Errorf("error = %q, want deadline in message", err)
+	}
+	if elapsed < 300*time.Millisecond || elapsed > 2*time.Second {
+		t.Errorf("gave up after %s, want about 300ms", elapsed)
+	}
+}
+
+func TestOpenHTTPFailsFastWithoutDaemon(t *testing.T) {
+	socketPath := shortSocketPath(t)
+	// A stale socket file with nothing listening and no lock holder.
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	_ = ln.Close()
+	lockPath := socketPath + ".lock"
+	if err := os.WriteFile(lockPath, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	_, err = OpenHTTP(socketPath, lockPath, "", 5*time.Second)
+	if !errors.Is(err, ErrDaemonUnavailable) {
+		t.Fatalf("OpenHTTP error = %v, want ErrDaemonUnavailable", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("gave up after %s, want an immediate failure with the lock free", elapsed)
+	}
+}
+
 func TestListReturnsAPIItemShape(t *testing.T) {
 	access := &HTTPAccess{client: &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
 		if r.URL.Path != "/api/queue" {
@@ -45,6 +153,27 @@ func TestListReturnsAPIItemShape(t *testing.T) {
 	}
 }
 
+func TestSnapshotToleratesFailedQueueListing(t *testing.T) {
+	access := &HTTPAccess{client: &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path != "/api/batch" {
+			t.Fatalf("path = %s, want /api/batch", r.URL.Path)
+		}
+		body := `{"responses":[{"status":200,"body":{"running":true,"pid":42,"workflow":{"running":true,"queueStats":{"encoding":2}}}},{"status":500,"body":{"error":"database is locked"}}]}`
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})}}
+
+	snap, err := access.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+	if snap.Status.PID != 42 || snap.Status.Workflow.QueueStats["encoding"] != 2 {
+		t.Fatalf("status = %+v, want decoded status entry", snap.Status)
+	}
+	if snap.Items != nil || snap.ItemsErr == nil || !strings.Contains(snap.ItemsErr.Error(), "database is locked") {
+		t.Fatalf("items = %v err = %v, want listing error isolated", snap.Items, snap.ItemsErr)
+	}
+}
+
 type roundTripFunc func(*http.Request) (*http.Response, error)
 
 func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
@@ -0,0 +1,38 @@
+package queueops
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/five82/spindle/internal/queue"
+)
+
+// MaxNotesBytes caps an item's notes; they are annotations, not logs.
+const MaxNotesBytes = 4096
+
+// NotesResult describes the outcome of a SetNotes operation.
+type NotesResult string
+
+const (
+	NotesResultUpdated  NotesResult = "updated"
+	NotesResultNotFound NotesResult = "not_found"
+	NotesResultTooLong  NotesResult = "too_long"
+)
+
+// SetNotes replaces an item's operator notes; empty notes clear them. Unlike
+// processing options, notes may change at any time, including while a stage
+// runs or after the item is stopped, because no stage writes them.
+func SetNotes(store *queue.Store, id int64, notes string) (NotesResult, error) {
+	notes = strings.TrimSpace(notes)
+	if len(notes) > MaxNotesBytes {
+		return NotesResultTooLong, nil
+	}
+	found, err := store.SetNotes(id, notes)
+	if err != nil {
+		return "", fmt.Errorf("set notes %d: %w", id, err)
+	}
+	if !found {
+		return NotesResultNotFound, nil
+	}
+	return NotesResultUpdated, nil
+}
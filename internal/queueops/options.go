@@ -0,0 +1,60 @@
+package queueops
+
+import (
+	"fmt"
+
+	"github.com/five82/spindle/internal/queue"
+	"github.com/five82/spindle/internal/ripspec"
+)
+
+// OptionsResult describes the outcome of a SetOptions operation.
+type OptionsResult string
+
+const (
+	OptionsResultUpdated    OptionsResult = "updated"
+	OptionsResultNotFound   OptionsResult = "not_found"
+	OptionsResultInProgress OptionsResult = "in_progress"
+	OptionsResultStopped    OptionsResult = "stopped"
+)
+
+// SetOptions replaces an item's per-item processing options. Items that
+// have not been identified yet get a bare envelope holding only the options;
+// identification carries them into the envelope it builds. A running stage
+// persists its whole envelope when it finishes, so options cannot change
+// while the item is in progress; user-stopped items reject work-state
+// writes until they are retried.
+func SetOptions(store *queue.Store, id int64, opts ripspec.ItemOptions) (OptionsResult, error) {
+	item, err := store.GetByID(id)
+	if err != nil {
+		return "", fmt.Errorf("set options get %d: %w", id, err)
+	}
+	if item == nil {
+		return OptionsResultNotFound, nil
+	}
+	if item.InProgress != 0 {
+		return OptionsResultInProgress, nil
+	}
+	if item.UserStopped() {
+		return OptionsResultStopped, nil
+	}
+
+	env, err := ripspec.Parse(item.RipSpecData)
+	if err != nil {
+		return "", fmt.Errorf("set options parse ripspec %d: %w", id, err)
+	}
+	if env.Version == 0 {
+		env.Version = ripspec.CurrentVersion
+		env.Fingerprint = item.DiscFingerprint
+	}
+	env.Options = opts
+
+	encoded, err := env.Encode()
+	if err != nil {
+		return "", fmt.Errorf("set options encode ripspec %d: %w", id, err)
+	}
+	item.RipSpecData = encoded
+	if err := store.UpdateWorkState(item); err != nil {
+		return "", fmt.Errorf("set options update %d: %w", id, err)
+	}
+	return OptionsResultUpdated, nil
+}
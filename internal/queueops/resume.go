@@ -0,0 +1,253 @@
+package queueops
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/five82/spindle/internal/queue"
+	"github.com/five82/spindle/internal/ripcache"
+	"github.com/five82/spindle/internal/ripspec"
+)
+
+// stageInputs names the asset kinds a stage reads, in preference order: the
+// first kind with any completed asset is the one the stage will use.
+// Identification and ripping read the disc and have no recorded inputs.
+var stageInputs = map[queue.Stage][]string{
+	queue.StageEpisodeIdentification: {ripspec.AssetKindRipped},
+	queue.StageEncoding:              {ripspec.AssetKindRipped},
+	queue.StageAnalysis:              {ripspec.AssetKindRipped},
+	queue.StageSubtitling:            {ripspec.AssetKindRipped},
+	queue.StageApply:                 {ripspec.AssetKindEncoded},
+	queue.StageOrganizing:            {ripspec.AssetKindSubtitled, ripspec.AssetKindEncoded},
+}
+
+// assetProducers maps each input asset kind to the stage that records it, so
+// a missing input rewinds the retry to the stage that can rebuild it.
+var assetProducers = map[string]queue.Stage{
+	ripspec.AssetKindRipped:    queue.StageRipping,
+	ripspec.AssetKindEncoded:   queue.StageEncoding,
+	ripspec.AssetKindSubtitled: queue.StageApply,
+}
+
+// Resumed reports where a failed item was routed on retry. Missing lists the
+// recorded asset paths that no longer exist and forced a rewind from the
+// failed stage; it is empty when the item resumed exactly where it failed.
+//
+// CacheRestored and CacheError are set only by ResumeFailed when the rewind
+// would reach ripping: either the ripped files came back from the rip cache,
+// or CacheError says why they could not and the item re-rips from disc.
+type Resumed struct {
+	ID            int64       `json:"id"`
+	Failed        queue.Stage `json:"failed_stage"`
+	Stage         queue.Stage `json:"stage"`
+	Missing       []string    `json:"missing,omitempty"`
+	CacheRestored bool        `json:"cache_restored,omitempty"`
+	CacheError    string      `json:"cache_error,omitempty"`
+}
+
+// Rewound reports whether missing assets moved the retry before the stage
+// that failed.
+func (r Resumed) Rewound() bool {
+	return len(r.Missing) > 0
+}
+
+// RetryFailed routes failed items back into the pipeline at the stage that
+// failed, reusing the assets already recorded in the rip spec. Before
+// resuming it checks that the stage's input files still exist; when they do
+// not, the missing records are cleared and the retry rewinds to the stage
+// that produces them, repeating until every input is present. No IDs means
+// every failed item. Items that are missing or not failed are skipped.
+func RetryFailed(store *queue.Store, ids ...int64) ([]Resumed, error) {
+	return retryFailed(store, nil, ids)
+}
+
+// ResumeFailed is RetryFailed that also reuses the rip cache: when missing
+// ripped files would rewind an item to ripping, an intact cache entry for
+// the disc is restored in their place so the item resumes at the failed
+// stage without re-ripping. A missing or incomplete entry leaves the rewind
+// in place and is reported in CacheError. A nil cache behaves like
+// RetryFailed.
+func ResumeFailed(store *queue.Store, cache *ripcache.Store, ids ...int64) ([]Resumed, error) {
+	return retryFailed(store, cache, ids)
+}
+
+func retryFailed(store *queue.Store, cache *ripcache.Store, ids []int64) ([]Resumed, error) {
+	if len(ids) == 0 {
+		items, err := store.List(queue.StageFailed)
+		if err != nil {
+			return nil, fmt.Errorf("list failed items: %w", err)
+		}
+		for _, item := range items {
+			ids = append(ids, item.ID)
+		}
+	}
+	var resumed []Resumed
+	for _, id := range ids {
+		item, err := store.GetByID(id)
+		if err != nil {
+			return resumed, fmt.Errorf("retry get %d: %w", id, err)
+		}
+		if item == nil || item.Stage != queue.StageFailed {
+			continue
+		}
+		r := Resumed{ID: id, Failed: item.ResumeStage()}
+		ripSpecData := item.RipSpecData
+		if ripSpecData == "" {
+			r.Stage = r.Failed
+		} else {
+			env, err := ripspec.Parse(ripSpecData)
+			if err != nil {
+				return resumed, fmt.Errorf("retry parse ripspec %d: %w", id, err)
+			}
+			if cache != nil {
+				restoreRippedFromCache(cache, item, &env, &r)
+			}
+			r.Stage, r.Missing = resumeStage(&env, r.Failed)
+			if r.Rewound() {
+				if ripSpecData, err = env.Encode(); err != nil {
+					return resumed, fmt.Errorf("retry encode ripspec %d: %w", id, err)
+				}
+			}
+		}
+		if err := store.RetryWithRipSpec(id, r.Stage, ripSpecData); err != nil {
+			return resumed, fmt.Errorf("retry update %d: %w", id, err)
+		}
+		resumed = append(resumed, r)
+	}
+	return resumed, nil
+}
+
+// restoreRippedFromCache puts the disc's cached rip back where the ripped
+// assets were recorded when a retry would otherwise rewind to ripping.
+func restoreRippedFromCache(cache *ripcache.Store, item *queue.Item, env *ripspec.Envelope, r *Resumed) {
+	if r.Failed == queue.StageRipping {
+		return // the rip itself failed; there is nothing to resume past
+	}
+	trial, err := ripspec.Parse(item.RipSpecData)
+	if err != nil {
+		return
+	}
+	if stage, _ := resumeStage(&trial, r.Failed); stage != queue.StageRipping {
+		return
+	}
+	missing := missingRipped(env)
+	if len(missing) == 0 {
+		return
+	}
+	fingerprint := cacheEntryFor(cache, item.DiscFingerprint, env)
+	if fingerprint == "" {
+		r.CacheError = "no rip cache entry for this disc"
+		return
+	}
+	if err := cache.Verify(fingerprint); err != nil {
+		r.CacheError = "rip cache entry incomplete: " + err.Error()
+		return
+	}
+	if _, err := cache.Restore(fingerprint, filepath.Dir(missing[0]), nil); err != nil {
+		r.CacheError = "rip cache restore failed: " + err.Error()
+		return
+	}
+	if still := missingRipped(env); len(still) > 0 {
+		r.CacheError = fmt.Sprintf("rip cache lacks %d ripped file(s), e.g. %s", len(still), still[0])
+		return
+	}
+	r.CacheRestored = true
+}
+
+// cacheEntryFor returns the cache entry holding the disc's rip: its own
+// fingerprint, else a confident match on title content hashes, as the
+// ripper looks it up. It returns "" when the disc is not cached.
+func cacheEntryFor(cache *ripcache.Store, fingerprint string, env *ripspec.Envelope) string {
+	if fingerprint != "" && cache.HasCache(fingerprint) {
+		return fingerprint
+	}
+	hashes := make(map[int]string, len(env.Titles))
+	for _, t := range env.Titles {
+		if t.TitleHash != "" {
+			hashes[t.ID] = t.TitleHash
+		}
+	}
+	if meta, _, err := cache.MatchContent(hashes); err == nil && meta != nil {
+		return meta.Fingerprint
+	}
+	return ""
+}
+
+// missingRipped lists completed ripped asset paths that are not on disk.
+func missingRipped(env *ripspec.Envelope) []string {
+	var missing []string
+	for _, asset := range env.Assets.Ripped {
+		if !asset.IsCompleted() || placed(env, asset.EpisodeKey) {
+			continue
+		}
+		if _, err := os.Stat(asset.Path); err != nil {
+			missing = append(missing, asset.Path)
+		}
+	}
+	return missing
+}
+
+// resumeStage walks back from stage until the stage's recorded inputs all
+// exist on disk. Records whose files are gone are cleared from env so the
+// producing stage rebuilds them instead of skipping them as complete.
+func resumeStage(env *ripspec.Envelope, stage queue.Stage) (queue.Stage, []string) {
+	var missing []string
+	for {
+		kind, ok := inputKind(env, stage)
+		if !ok {
+			return stage, missing
+		}
+		var gone []string
+		for _, asset := range assetsOfKind(env, kind) {
+			if !asset.IsCompleted() || placed(env, asset.EpisodeKey) {
+				continue
+			}
+			if _, err := os.Stat(asset.Path); err != nil {
+				gone = append(gone, asset.Path)
+				env.Assets.ClearFailedAsset(kind, asset.EpisodeKey)
+			}
+		}
+		if len(gone) == 0 {
+			return stage, missing
+		}
+		missing = append(missing, gone...)
+		stage = assetProducers[kind]
+	}
+}
+
+// inputKind returns the asset kind stage will read from env, mirroring the
+// handlers' own source selection. It reports false for stages without
+// recorded inputs.
+func inputKind(env *ripspec.Envelope, stage queue.Stage) (string, bool) {
+	for _, kind := range stageInputs[stage] {
+		if env.Assets.CompletedAssetCount(kind) > 0 {
+			return kind, true
+		}
+	}
+	return "", false
+}
+
+// placed reports whether key already has a final library file, which the
+// organizer moved its source into; the vanished source is not missing.
+func placed(env *ripspec.Envelope, key string) bool {
+	final, ok := env.Assets.FindAsset(ripspec.AssetKindFinal, key)
+	if !ok || !final.IsCompleted() {
+		return false
+	}
+	_, err := os.Stat(final.Path)
+	return err == nil
+}
+
+func assetsOfKind(env *ripspec.Envelope, kind string) []ripspec.Asset {
+	switch kind {
+	case ripspec.AssetKindRipped:
+		return env.Assets.Ripped
+	case ripspec.AssetKindEncoded:
+		return env.Assets.Encoded
+	case ripspec.AssetKindSubtitled:
+		return env.Assets.Subtitled
+	default:
+		return nil
+	}
+}
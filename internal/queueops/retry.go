@@ -21,7 +21,8 @@ const (
 )
 
 // RetryEpisode clears the failed status of a single episode within a queue item
-// and resets the item for reprocessing from its failed stage.
+// and resets the item for reprocessing from its failed stage, rewinding when
+// that stage's recorded inputs are gone (see RetryFailed).
 func RetryEpisode(store *queue.Store, id int64, episodeKey string) (RetryResult, error) {
 	item, err := store.GetByID(id)
 	if err != nil {
@@ -57,12 +58,13 @@ func RetryEpisode(store *queue.Store, id int64, episodeKey string) (RetryResult,
 		env.Assets.ClearFailedAsset(kind, episodeKey)
 	}
 
+	target, _ := resumeStage(&env, item.ResumeStage())
 	encoded, err := env.Encode()
 	if err != nil {
 		return "", fmt.Errorf("retry episode encode ripspec %d: %w", id, err)
 	}
 
-	if err := store.RetryWithRipSpec(id, item.ResumeStage(), encoded); err != nil {
+	if err := store.RetryWithRipSpec(id, target, encoded); err != nil {
 		return "", fmt.Errorf("retry episode update %d: %w", id, err)
 	}
 	return RetryResultRetried, nil
@@ -1,9 +1,12 @@
 package queueops
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/five82/spindle/internal/queue"
+	"github.com/five82/spindle/internal/ripcache"
 	"github.com/five82/spindle/internal/ripspec"
 )
 
@@ -69,3 +72,204 @@ func TestRetryEpisodeClearsFailedAssets(t *testing.T) {
 		t.Fatalf("asset not cleared: %+v", asset)
 	}
 }
+
+// failMovieAtOrganizing records ripped, encoded, and subtitled files for a
+// movie and fails it in organizing. It returns the subtitled path.
+func failMovieAtOrganizing(t *testing.T, store *queue.Store) (*queue.Item, string) {
+	t.Helper()
+	dir := t.TempDir()
+	env := ripspec.Envelope{Version: ripspec.CurrentVersion, Metadata: ripspec.Metadata{MediaType: "movie"}}
+	paths := map[string]string{}
+	for _, kind := range []string{ripspec.AssetKindRipped, ripspec.AssetKindEncoded, ripspec.AssetKindSubtitled} {
+		path := filepath.Join(dir, kind+".mkv")
+		if err := os.WriteFile(path, []byte(kind), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		paths[kind] = path
+		env.Assets.AddAsset(kind, ripspec.Asset{EpisodeKey: "main", Path: path, Status: ripspec.AssetStatusCompleted})
+	}
+	item, _ := store.NewDisc("Movie", "fp1")
+	data, err := env.Encode()
+	if err != nil {
+		t.Fatalf("encode ripspec: %v", err)
+	}
+	item.RipSpecData = data
+	if err := store.UpdateWorkState(item); err != nil {
+		t.Fatalf("persist work state: %v", err)
+	}
+	if err := store.FailStage(item, queue.StageOrganizing, "library unreachable"); err != nil {
+		t.Fatalf("fail item: %v", err)
+	}
+	return item, paths[ripspec.AssetKindSubtitled]
+}
+
+func TestRetryFailedResumesAtOrganizing(t *testing.T) {
+	store := openTestStore(t)
+	item, _ := failMovieAtOrganizing(t, store)
+
+	resumed, err := RetryFailed(store, item.ID)
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if len(resumed) != 1 || resumed[0].Rewound() {
+		t.Fatalf("resumed = %+v, want one item without rewind", resumed)
+	}
+	got, _ := store.GetByID(item.ID)
+	if got.Stage != queue.StageOrganizing {
+		t.Fatalf("stage = %q, want %q (not ripping)", got.Stage, queue.StageOrganizing)
+	}
+	if got.RipSpecData != item.RipSpecData {
+		t.Fatalf("rip spec changed on a clean resume:\n%s", got.RipSpecData)
+	}
+}
+
+func TestRetryFailedRewindsWhenInputsMissing(t *testing.T) {
+	store := openTestStore(t)
+	item, subtitled := failMovieAtOrganizing(t, store)
+	if err := os.Remove(subtitled); err != nil {
+		t.Fatal(err)
+	}
+
+	resumed, err := RetryFailed(store, item.ID)
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if len(resumed) != 1 || resumed[0].Stage != queue.StageApply || len(resumed[0].Missing) != 1 {
+		t.Fatalf("resumed = %+v, want rewind to apply for the subtitled file", resumed)
+	}
+	got, _ := store.GetByID(item.ID)
+	if got.Stage != queue.StageApply {
+		t.Fatalf("stage = %q, want %q", got.Stage, queue.StageApply)
+	}
+	env, err := ripspec.Parse(got.RipSpecData)
+	if err != nil {
+		t.Fatalf("parse ripspec: %v", err)
+	}
+	if asset, _ := env.Assets.FindAsset(ripspec.AssetKindSubtitled, "main"); asset.IsCompleted() {
+		t.Fatalf("missing subtitled asset still recorded as completed: %+v", asset)
+	}
+	if asset, _ := env.Assets.FindAsset(ripspec.AssetKindEncoded, "main"); !asset.IsCompleted() {
+		t.Fatalf("encoded asset lost: %+v", asset)
+	}
+}
+
+func TestSetOptionsPersistsBeforeIdentification(t *testing.T) {
+	store := openTestStore(t)
+	item, _ := store.NewDisc("Nosferatu", "fp1")
+
+	result, err := SetOptions(store, item.ID, ripspec.ItemOptions{SkipSubtitles: true})
+	if err != nil {
+		t.Fatalf("set options: %v", err)
+	}
+	if result != OptionsResultUpdated {
+		t.Fatalf("result = %q, want %q", result, OptionsResultUpdated)
+	}
+	got, err := store.GetByID(item.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	env, err := ripspec.Parse(got.RipSpecData)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if !env.Options.SkipSubtitles || env.Options.SkipCommentary || env.Fingerprint != "fp1" {
+		t.Fatalf("envelope = %+v, want skip_subtitles only", env)
+	}
+
+	if _, err := SetOptions(store, item.ID, ripspec.ItemOptions{SkipSubtitles: true, EncodeCRF: 20}); err != nil {
+		t.Fatalf("set crf: %v", err)
+	}
+	if got, err = store.GetByID(item.ID); err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if env, err = ripspec.Parse(got.RipSpecData); err != nil || env.Options.EncodeCRF != 20 || !env.Options.SkipSubtitles {
+		t.Fatalf("options = %+v (%v), want crf 20 with skip_subtitles kept", env.Options, err)
+	}
+
+	if err := store.StartStage(got); err != nil {
+		t.Fatalf("start stage: %v", err)
+	}
+	if result, err := SetOptions(store, item.ID, ripspec.ItemOptions{}); err != nil || result != OptionsResultInProgress {
+		t.Fatalf("in-progress result = %q, %v; want %q", result, err, OptionsResultInProgress)
+	}
+}
+
+// failMovieAtSubtitling records a ripped file for a movie, caches the rip
+// under the disc fingerprint, fails the item in subtitling, and then deletes
+// the staged rip. It returns the item and the ripped path.
+func failMovieAtSubtitling(t *testing.T, store *queue.Store, cache *ripcache.Store) (*queue.Item, string) {
+	t.Helper()
+	rippedDir := t.TempDir()
+	ripped := filepath.Join(rippedDir, "title_t00.mkv")
+	if err := os.WriteFile(ripped, []byte("ripped video"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.Register("fp-movie", rippedDir, nil); err != nil {
+		t.Fatalf("register cache: %v", err)
+	}
+	if err := cache.WriteMetadata("fp-movie", ripcache.EntryMetadata{Fingerprint: "fp-movie", TitleCount: 1, TotalBytes: 12}); err != nil {
+		t.Fatalf("write cache metadata: %v", err)
+	}
+
+	env := ripspec.Envelope{Version: ripspec.CurrentVersion, Metadata: ripspec.Metadata{MediaType: "movie"}}
+	env.Assets.AddAsset(ripspec.AssetKindRipped, ripspec.Asset{EpisodeKey: "main", Path: ripped, Status: ripspec.AssetStatusCompleted})
+	item, _ := store.NewDisc("Movie", "fp-movie")
+	data, err := env.Encode()
+	if err != nil {
+		t.Fatalf("encode ripspec: %v", err)
+	}
+	item.RipSpecData = data
+	if err := store.UpdateWorkState(item); err != nil {
+		t.Fatalf("persist work state: %v", err)
+	}
+	if err := store.FailStage(item, queue.StageSubtitling, "whisperx crashed"); err != nil {
+		t.Fatalf("fail item: %v", err)
+	}
+	if err := os.Remove(ripped); err != nil {
+		t.Fatal(err)
+	}
+	return item, ripped
+}
+
+func TestResumeFailedRestoresRipFromCache(t *testing.T) {
+	store := openTestStore(t)
+	cache := ripcache.New(t.TempDir(), 10)
+	item, ripped := failMovieAtSubtitling(t, store, cache)
+
+	resumed, err := ResumeFailed(store, cache, item.ID)
+	if err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if len(resumed) != 1 || !resumed[0].CacheRestored || resumed[0].Stage != queue.StageSubtitling || resumed[0].Rewound() {
+		t.Fatalf("resumed = %+v, want subtitling restored from cache", resumed)
+	}
+	if data, err := os.ReadFile(ripped); err != nil || string(data) != "ripped video" {
+		t.Fatalf("ripped file not restored: %q, %v", data, err)
+	}
+	got, _ := store.GetByID(item.ID)
+	if got.Stage != queue.StageSubtitling {
+		t.Fatalf("stage = %q, want %q without re-ripping", got.Stage, queue.StageSubtitling)
+	}
+}
+
+func TestResumeFailedFallsBackWhenCacheIncomplete(t *testing.T) {
+	store := openTestStore(t)
+	cacheDir := t.TempDir()
+	cache := ripcache.New(cacheDir, 10)
+	item, _ := failMovieAtSubtitling(t, store, cache)
+	if err := os.Truncate(filepath.Join(cacheDir, "fp-movie", "title_t00.mkv"), 3); err != nil {
+		t.Fatal(err)
+	}
+
+	resumed, err := ResumeFailed(store, cache, item.ID)
+	if err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if len(resumed) != 1 || resumed[0].CacheRestored || resumed[0].CacheError == "" || resumed[0].Stage != queue.StageRipping {
+		t.Fatalf("resumed = %+v, want a rewind to ripping with a cache warning", resumed)
+	}
+	got, _ := store.GetByID(item.ID)
+	if got.Stage != queue.StageRipping {
+		t.Fatalf("stage = %q, want %q", got.Stage, queue.StageRipping)
+	}
+}
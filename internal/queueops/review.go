@@ -0,0 +1,253 @@
+package queueops
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/five82/spindle/internal/queue"
+	"github.com/five82/spindle/internal/ripspec"
+)
+
+// ReviewAction is a reviewer's decision on an item awaiting review.
+type ReviewAction string
+
+const (
+	// ReviewActionApprove accepts the item, optionally switching it to one
+	// of its recorded TMDB match candidates.
+	ReviewActionApprove ReviewAction = "approve"
+	// ReviewActionOverride approves with corrected episode mappings and/or
+	// a different candidate.
+	ReviewActionOverride ReviewAction = "override"
+	// ReviewActionReject fails the item and leaves its files in review.
+	ReviewActionReject ReviewAction = "reject"
+)
+
+// EpisodeMapping is a reviewer's season/episode assignment for one episode
+// key. Season 0 files the episode as a special.
+type EpisodeMapping struct {
+	Season     int `json:"season"`
+	Episode    int `json:"episode"`
+	EpisodeEnd int `json:"episode_end,omitempty"`
+}
+
+// ReviewDecision is the input to DecideReview.
+type ReviewDecision struct {
+	Action ReviewAction
+	// CandidateID selects one of the item's recorded TMDB match candidates
+	// by TMDB ID. Zero keeps the current match.
+	CandidateID int
+	// Episodes remaps episode keys; override only.
+	Episodes map[string]EpisodeMapping
+	// Note is recorded in the error message of a rejected item.
+	Note string
+	// Replace lets the organizer overwrite files already in the library;
+	// approve and override only. Without it a duplicate returns to review.
+	Replace bool
+}
+
+// ReviewEnricher refreshes a reviewer's selection in env from TMDB: the
+// chosen title's full details when details is set, and episode titles and
+// air dates for every mapped TV episode. Lookup failures keep the recorded
+// data. The daemon supplies it so queueops stays free of TMDB.
+type ReviewEnricher func(ctx context.Context, env *ripspec.Envelope, details bool)
+
+// ReviewResult describes the outcome of a DecideReview operation.
+type ReviewResult string
+
+const (
+	ReviewResultApproved           ReviewResult = "approved"
+	ReviewResultRejected           ReviewResult = "rejected"
+	ReviewResultNotFound           ReviewResult = "not_found"
+	ReviewResultNotPending         ReviewResult = "not_pending"
+	ReviewResultInvalid            ReviewResult = "invalid"
+	ReviewResultUnknownCandidate   ReviewResult = "unknown_candidate"
+	ReviewResultEpisodeNotFound    ReviewResult = "episode_not_found"
+	ReviewResultUnidentified       ReviewResult = "unidentified"
+	ReviewResultUnresolvedEpisodes ReviewResult = "unresolved_episodes"
+	ReviewResultMissingFiles       ReviewResult = "missing_files"
+)
+
+// PendingReviews returns the items awaiting a review decision: completed
+// items the organizer routed (wholly or partly) to the review directory.
+func PendingReviews(store *queue.Store) ([]*queue.Item, error) {
+	items, err := store.List(queue.StageCompleted)
+	if err != nil {
+		return nil, fmt.Errorf("list pending reviews: %w", err)
+	}
+	pending := make([]*queue.Item, 0, len(items))
+	for _, item := range items {
+		if isPendingReview(item) {
+			pending = append(pending, item)
+		}
+	}
+	return pending, nil
+}
+
+func isPendingReview(item *queue.Item) bool {
+	return item.Stage == queue.StageCompleted && item.NeedsReview != 0 && item.InProgress == 0
+}
+
+// DecideReview applies a reviewer's decision to an item awaiting review.
+//
+// Approve and override rewrite the item's metadata and episode mappings,
+// clear every review flag, and send the item back to organizing with the
+// files review left behind as its source, so the organizer places them in
+// the library. A selected candidate is re-enriched when enrich is non-nil.
+// Reject fails the item at organizing and leaves its files where they are.
+func DecideReview(ctx context.Context, store *queue.Store, id int64, d ReviewDecision, enrich ReviewEnricher) (ReviewResult, error) {
+	item, err := store.GetByID(id)
+	if err != nil {
+		return "", fmt.Errorf("review get %d: %w", id, err)
+	}
+	if item == nil {
+		return ReviewResultNotFound, nil
+	}
+	if !isPendingReview(item) {
+		return ReviewResultNotPending, nil
+	}
+
+	switch d.Action {
+	case ReviewActionReject:
+		msg := "Rejected in review"
+		if note := strings.TrimSpace(d.Note); note != "" {
+			msg += ": " + note
+		}
+		ok, err := store.RejectReview(id, msg)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			return ReviewResultNotPending, nil
+		}
+		return ReviewResultRejected, nil
+	case ReviewActionApprove:
+		if len(d.Episodes) > 0 {
+			return ReviewResultInvalid, nil
+		}
+	case ReviewActionOverride:
+		if d.CandidateID == 0 && len(d.Episodes) == 0 {
+			return ReviewResultInvalid, nil
+		}
+	default:
+		return ReviewResultInvalid, nil
+	}
+
+	env, err := ripspec.Parse(item.RipSpecData)
+	if err != nil {
+		return "", fmt.Errorf("review parse ripspec %d: %w", id, err)
+	}
+	if env.Version == 0 {
+		return ReviewResultMissingFiles, nil
+	}
+
+	if d.CandidateID != 0 {
+		candidate, ok := findCandidate(env.Attributes.MatchCandidates, d.CandidateID)
+		if !ok {
+			return ReviewResultUnknownCandidate, nil
+		}
+		env.Metadata = env.Metadata.Rematched(candidate)
+	}
+	for key, m := range d.Episodes {
+		ep := env.EpisodeByKey(key)
+		if ep == nil {
+			return ReviewResultEpisodeNotFound, nil
+		}
+		if m.Season < 0 || m.Episode <= 0 || (m.EpisodeEnd != 0 && m.EpisodeEnd < m.Episode) {
+			return ReviewResultInvalid, nil
+		}
+		if ep.Season != m.Season || ep.Episode != m.Episode || ep.EpisodeEnd != m.EpisodeEnd {
+			// TMDB episode details belonged to the old mapping.
+			ep.EpisodeTitle = ""
+			ep.EpisodeAirDate = ""
+		}
+		ep.Season, ep.Episode, ep.EpisodeEnd = m.Season, m.Episode, m.EpisodeEnd
+	}
+	if enrich != nil && (d.CandidateID != 0 || len(d.Episodes) > 0) {
+		enrich(ctx, &env, d.CandidateID != 0)
+	}
+
+	switch env.Metadata.MediaType {
+	case "movie":
+	case "tv":
+		if ripspec.CountUnresolvedEpisodes(env.Episodes) > 0 {
+			return ReviewResultUnresolvedEpisodes, nil
+		}
+	default:
+		// An unidentified item needs a candidate before it can be placed.
+		return ReviewResultUnidentified, nil
+	}
+	if d.CandidateID != 0 {
+		// Pin the reviewer's choice so re-identification keeps it.
+		env.Options.PinnedTMDBID, env.Options.PinnedMediaType = env.Metadata.ID, env.Metadata.MediaType
+	}
+	for i := range env.Episodes {
+		env.Episodes[i].NeedsReview = false
+		env.Episodes[i].ReviewReason = ""
+	}
+	if d.Replace {
+		env.Attributes.ReplaceExisting = true
+	}
+	if !rebaseReviewedAssets(&env) {
+		return ReviewResultMissingFiles, nil
+	}
+
+	encoded, err := env.Encode()
+	if err != nil {
+		return "", fmt.Errorf("review encode ripspec %d: %w", id, err)
+	}
+	metaJSON, err := env.Metadata.ItemMetadataJSON()
+	if err != nil {
+		return "", fmt.Errorf("review metadata %d: %w", id, err)
+	}
+	ok, err := store.ApproveReview(id, encoded, metaJSON, env.Metadata.QueueTitle())
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return ReviewResultNotPending, nil
+	}
+	return ReviewResultApproved, nil
+}
+
+func findCandidate(candidates []ripspec.MatchCandidate, tmdbID int) (ripspec.MatchCandidate, bool) {
+	for _, c := range candidates {
+		if c.ID == tmdbID {
+			return c, true
+		}
+	}
+	return ripspec.MatchCandidate{}, false
+}
+
+// rebaseReviewedAssets points each key's organizer source asset at the file
+// the previous organize placed (in review or, for partly placed TV, in the
+// library) and drops the final assets so organizing runs again. It reports
+// false when a key has no placed file to organize from.
+func rebaseReviewedAssets(env *ripspec.Envelope) bool {
+	keys := env.AssetKeys()
+	if len(keys) == 0 {
+		return false
+	}
+	sourceKind := ripspec.AssetKindSubtitled
+	if _, ok := env.Assets.FindAsset(sourceKind, keys[0]); !ok {
+		sourceKind = ripspec.AssetKindEncoded
+	}
+	for _, key := range keys {
+		final, ok := env.Assets.FindAsset(ripspec.AssetKindFinal, key)
+		if !ok || !final.IsCompleted() {
+			return false
+		}
+		if _, err := os.Stat(final.Path); err != nil {
+			return false
+		}
+		src, _ := env.Assets.FindAsset(sourceKind, key)
+		src.EpisodeKey = key
+		src.Path = final.Path
+		src.Status = ripspec.AssetStatusCompleted
+		src.ErrorMsg = ""
+		env.Assets.AddAsset(sourceKind, src)
+	}
+	env.Assets.Final = nil
+	return true
+}
@@ -0,0 +1,249 @@
+package queueops
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/five82/spindle/internal/mediameta"
+	"github.com/five82/spindle/internal/queue"
+	"github.com/five82/spindle/internal/ripspec"
+)
+
+// reviewedMovie queues a movie that finished organizing into review with no
+// confident TMDB match and two recorded candidates.
+func reviewedMovie(t *testing.T, store *queue.Store) (*queue.Item, string) {
+	t.Helper()
+	item, _ := store.NewDisc("HEAT", "fp1")
+	reviewFile := filepath.Join(t.TempDir(), "HEAT.mkv")
+	if err := os.WriteFile(reviewFile, []byte("video"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	env := ripspec.Envelope{
+		Version:  ripspec.CurrentVersion,
+		Metadata: ripspec.Metadata{ID: 111, Title: "Heat", MediaType: "movie", Movie: true, Year: "1986", DiscSource: "bluray"},
+	}
+	env.Attributes.MatchCandidates = []ripspec.MatchCandidate{
+		{ID: 111, Title: "Heat", MediaType: "movie", Year: "1986"},
+		{ID: 949, Title: "Heat", MediaType: "movie", Year: "1995", ReleaseDate: "1995-12-15"},
+	}
+	env.Assets.AddAsset(ripspec.AssetKindEncoded, ripspec.Asset{EpisodeKey: "main", TitleID: 3, Path: "/staging/fp1/encoded/HEAT.mkv", Status: ripspec.AssetStatusCompleted})
+	env.Assets.AddAsset(ripspec.AssetKindFinal, ripspec.Asset{EpisodeKey: "main", Path: reviewFile, Status: ripspec.AssetStatusCompleted})
+
+	item.AppendReviewReason("TMDB: no confident match found")
+	data, err := env.Encode()
+	if err != nil {
+		t.Fatalf("encode ripspec: %v", err)
+	}
+	item.RipSpecData = data
+	if err := store.UpdateWorkState(item); err != nil {
+		t.Fatalf("persist work state: %v", err)
+	}
+	if err := store.MoveToStage(item, queue.StageCompleted); err != nil {
+		t.Fatalf("complete item: %v", err)
+	}
+	return item, reviewFile
+}
+
+func TestDecideReviewApproveCandidateAdvancesWithChosenMetadata(t *testing.T) {
+	store := openTestStore(t)
+	item, reviewFile := reviewedMovie(t, store)
+
+	pending, err := PendingReviews(store)
+	if err != nil {
+		t.Fatalf("pending reviews: %v", err)
+	}
+	if len(pending) != 1 || pending[0].ID != item.ID {
+		t.Fatalf("pending = %v, want item %d", pending, item.ID)
+	}
+
+	result, err := DecideReview(context.Background(), store, item.ID, ReviewDecision{Action: ReviewActionApprove, CandidateID: 949}, nil)
+	if err != nil {
+		t.Fatalf("decide review: %v", err)
+	}
+	if result != ReviewResultApproved {
+		t.Fatalf("result = %q, want %q", result, ReviewResultApproved)
+	}
+
+	got, _ := store.GetByID(item.ID)
+	if got.Stage != queue.StageOrganizing {
+		t.Fatalf("stage = %q, want %q", got.Stage, queue.StageOrganizing)
+	}
+	if got.NeedsReview != 0 || got.ReviewReason != "" {
+		t.Fatalf("review fields not cleared: needs_review=%d reason=%q", got.NeedsReview, got.ReviewReason)
+	}
+	if got.DiscTitle != "Heat (1995)" {
+		t.Fatalf("disc title = %q, want %q", got.DiscTitle, "Heat (1995)")
+	}
+	meta := mediameta.FromJSON(got.MetadataJSON, "")
+	if meta.ID != 949 || meta.Year != "1995" || !meta.Movie {
+		t.Fatalf("metadata_json = %s, want the chosen candidate", got.MetadataJSON)
+	}
+
+	env, err := ripspec.Parse(got.RipSpecData)
+	if err != nil {
+		t.Fatalf("parse updated ripspec: %v", err)
+	}
+	if env.Metadata.ID != 949 || env.Metadata.ReleaseDate != "1995-12-15" || env.Metadata.DiscSource != "bluray" {
+		t.Fatalf("envelope metadata = %+v, want candidate fields with disc source kept", env.Metadata)
+	}
+	if env.Options.PinnedTMDBID != 949 || env.Options.PinnedMediaType != "movie" {
+		t.Fatalf("options = %+v, want the chosen candidate pinned", env.Options)
+	}
+	src, ok := env.Assets.FindAsset(ripspec.AssetKindEncoded, "main")
+	if !ok || src.Path != reviewFile || src.TitleID != 3 {
+		t.Fatalf("encoded asset = %+v, want it sourced from the review file", src)
+	}
+	if len(env.Assets.Final) != 0 {
+		t.Fatalf("final assets = %+v, want cleared for re-organization", env.Assets.Final)
+	}
+	if env.Attributes.ReplaceExisting {
+		t.Fatal("plain approval must not allow replacing library files")
+	}
+
+	// The item is no longer pending, so a second decision is refused.
+	result, err = DecideReview(context.Background(), store, item.ID, ReviewDecision{Action: ReviewActionReject}, nil)
+	if err != nil {
+		t.Fatalf("second decision: %v", err)
+	}
+	if result != ReviewResultNotPending {
+		t.Fatalf("second result = %q, want %q", result, ReviewResultNotPending)
+	}
+}
+
+func TestDecideReviewApproveWithReplace(t *testing.T) {
+	store := openTestStore(t)
+	item, _ := reviewedMovie(t, store)
+
+	result, err := DecideReview(context.Background(), store, item.ID, ReviewDecision{Action: ReviewActionApprove, Replace: true}, nil)
+	if err != nil {
+		t.Fatalf("decide review: %v", err)
+	}
+	if result != ReviewResultApproved {
+		t.Fatalf("result = %q, want %q", result, ReviewResultApproved)
+	}
+	got, _ := store.GetByID(item.ID)
+	env, err := ripspec.Parse(got.RipSpecData)
+	if err != nil {
+		t.Fatalf("parse updated ripspec: %v", err)
+	}
+	if !env.Attributes.ReplaceExisting {
+		t.Fatal("approval with replace not recorded for the organizer")
+	}
+}
+
+func TestDecideReviewRejectsUnknownCandidateAndRejects(t *testing.T) {
+	store := openTestStore(t)
+	item, reviewFile := reviewedMovie(t, store)
+
+	result, err := DecideReview(context.Background(), store, item.ID, ReviewDecision{Action: ReviewActionApprove, CandidateID: 12}, nil)
+	if err != nil {
+		t.Fatalf("decide review: %v", err)
+	}
+	if result != ReviewResultUnknownCandidate {
+		t.Fatalf("result = %q, want %q", result, ReviewResultUnknownCandidate)
+	}
+
+	result, err = DecideReview(context.Background(), store, item.ID, ReviewDecision{Action: ReviewActionReject, Note: "wrong disc"}, nil)
+	if err != nil {
+		t.Fatalf("reject review: %v", err)
+	}
+	if result != ReviewResultRejected {
+		t.Fatalf("result = %q, want %q", result, ReviewResultRejected)
+	}
+	got, _ := store.GetByID(item.ID)
+	if got.Stage != queue.StageFailed || got.FailedAtStage != queue.StageOrganizing {
+		t.Fatalf("stage = %q failed_at = %q, want failed at organizing", got.Stage, got.FailedAtStage)
+	}
+	if got.ErrorMessage != "Rejected in review: wrong disc" {
+		t.Fatalf("error message = %q", got.ErrorMessage)
+	}
+	if _, err := os.Stat(reviewFile); err != nil {
+		t.Fatalf("review file removed on reject: %v", err)
+	}
+}
+
+func TestDecideReviewOverrideRemapsEpisodes(t *testing.T) {
+	store := openTestStore(t)
+	item, _ := store.NewDisc("Show Season 01", "fp2")
+	dir := t.TempDir()
+	env := ripspec.Envelope{
+		Version:  ripspec.CurrentVersion,
+		Metadata: ripspec.Metadata{ID: 7, Title: "Show", ShowTitle: "Show", MediaType: "tv", SeasonNumber: 1},
+		Episodes: []ripspec.Episode{
+			{Key: "s01e01", Season: 1, Episode: 1},
+			{Key: "s01_002", Season: 1, NeedsReview: true, ReviewReason: "unresolved"},
+		},
+	}
+	for _, key := range []string{"s01e01", "s01_002"} {
+		path := filepath.Join(dir, key+".mkv")
+		if err := os.WriteFile(path, []byte("video"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		env.Assets.AddAsset(ripspec.AssetKindSubtitled, ripspec.Asset{EpisodeKey: key, Path: "/staging/" + key + ".mkv", Status: ripspec.AssetStatusCompleted})
+		env.Assets.AddAsset(ripspec.AssetKindFinal, ripspec.Asset{EpisodeKey: key, Path: path, Status: ripspec.AssetStatusCompleted})
+	}
+	item.AppendReviewReason("unresolved episodes")
+	item.RipSpecData, _ = env.Encode()
+	if err := store.UpdateWorkState(item); err != nil {
+		t.Fatalf("persist work state: %v", err)
+	}
+	if err := store.MoveToStage(item, queue.StageCompleted); err != nil {
+		t.Fatalf("complete item: %v", err)
+	}
+
+	result, err := DecideReview(context.Background(), store, item.ID, ReviewDecision{Action: ReviewActionApprove}, nil)
+	if err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if result != ReviewResultUnresolvedEpisodes {
+		t.Fatalf("approve result = %q, want %q", result, ReviewResultUnresolvedEpisodes)
+	}
+
+	result, err = DecideReview(context.Background(), store, item.ID, ReviewDecision{
+		Action:   ReviewActionOverride,
+		Episodes: map[string]EpisodeMapping{"s01_002": {Season: 1, Episode: 2}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	if result != ReviewResultApproved {
+		t.Fatalf("override result = %q, want %q", result, ReviewResultApproved)
+	}
+	got, _ := store.GetByID(item.ID)
+	gotEnv, _ := ripspec.Parse(got.RipSpecData)
+	ep := gotEnv.EpisodeByKey("s01_002")
+	if ep.Episode != 2 || ep.NeedsReview || ep.ReviewReason != "" {
+		t.Fatalf("episode = %+v, want mapped to E02 with review cleared", *ep)
+	}
+	if src, _ := gotEnv.Assets.FindAsset(ripspec.AssetKindSubtitled, "s01_002"); src.Path != filepath.Join(dir, "s01_002.mkv") {
+		t.Fatalf("subtitled source = %q, want the review file", src.Path)
+	}
+}
+
+func TestDecideReviewSelectionReenriches(t *testing.T) {
+	store := openTestStore(t)
+	item, _ := reviewedMovie(t, store)
+
+	var gotDetails bool
+	enrich := func(_ context.Context, env *ripspec.Envelope, details bool) {
+		gotDetails = details
+		env.Metadata.Overview = "Obsessive master thief."
+	}
+	result, err := DecideReview(context.Background(), store, item.ID, ReviewDecision{Action: ReviewActionApprove, CandidateID: 949}, enrich)
+	if err != nil {
+		t.Fatalf("decide review: %v", err)
+	}
+	if result != ReviewResultApproved {
+		t.Fatalf("result = %q, want %q", result, ReviewResultApproved)
+	}
+	if !gotDetails {
+		t.Fatal("enricher called without details for a candidate switch")
+	}
+	got, _ := store.GetByID(item.ID)
+	env, _ := ripspec.Parse(got.RipSpecData)
+	if env.Metadata.Overview != "Obsessive master thief." || env.Metadata.ID != 949 {
+		t.Fatalf("metadata = %+v, want the enriched candidate", env.Metadata)
+	}
+}
@@ -3,6 +3,7 @@ package ripcache
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -30,12 +31,45 @@ type EntryMetadata struct {
 	TotalBytes   int64     `json:"total_bytes"`
 	RipSpecData  string    `json:"ripspec_data,omitempty"`
 	MetadataJSON string    `json:"metadata_json,omitempty"`
+	// TitleHashes maps each scanned title ID to its makemkv.TitleHash so a
+	// disc with a different fingerprint but the same content can reuse the
+	// entry (see MatchContent).
+	TitleHashes map[int]string `json:"title_hashes,omitempty"`
+}
+
+// MinContentMatchScore is the share of title IDs whose content hashes must
+// agree between a disc and a cache entry before MatchContent reuses the
+// entry. Cached files are named by title ID, so a hash only counts when it
+// sits at the same ID.
+const MinContentMatchScore = 0.9
+
+// contentMatchScore is the number of title IDs carrying the same hash in
+// both maps divided by the number of IDs in either.
+func contentMatchScore(a, b map[int]string) float64 {
+	union := len(a)
+	matched := 0
+	for id, hash := range b {
+		other, ok := a[id]
+		if !ok {
+			union++
+			continue
+		}
+		if hash != "" && hash == other {
+			matched++
+		}
+	}
+	if union == 0 {
+		return 0
+	}
+	return float64(matched) / float64(union)
 }
 
 // Store manages the rip cache directory.
 type Store struct {
 	cacheDir string
 	maxBytes int64
+	transfer fileutil.TransferMode
+	minFree  int64
 }
 
 // New creates a rip cache store.
@@ -46,15 +80,24 @@ func New(cacheDir string, maxGiB int) *Store {
 	}
 }
 
+// SetTransfer selects how Register and Restore move files between staging
+// and the cache. The default, fileutil.TransferHardlink, shares inodes on
+// one filesystem; fileutil.TransferReflink clones copy-on-write; and
+// fileutil.TransferCopy always writes separate bytes.
+func (s *Store) SetTransfer(mode fileutil.TransferMode) {
+	s.transfer = mode
+}
+
+// SetMinFree sets the free space, in bytes, Register leaves on the cache
+// filesystem. Zero disables the floor.
+func (s *Store) SetMinFree(bytes int64) {
+	s.minFree = bytes
+}
+
 // Register copies ripped files from srcDir into the cache under fingerprint.
 // If progress is non-nil, it is called during file copies to report progress.
 // Metadata is NOT written here; call WriteMetadata separately.
 func (s *Store) Register(fingerprint, srcDir string, progress ProgressFunc) error {
-	entryDir := filepath.Join(s.cacheDir, fingerprint)
-	if err := os.MkdirAll(entryDir, 0o755); err != nil {
-		return fmt.Errorf("create cache entry dir: %w", err)
-	}
-
 	entries, err := os.ReadDir(srcDir)
 	if err != nil {
 		return fmt.Errorf("read source dir: %w", err)
@@ -71,6 +114,14 @@ func (s *Store) Register(fingerprint, srcDir string, progress ProgressFunc) erro
 		}
 	}
 
+	if err := s.checkSpace(totalBytes); err != nil {
+		return err
+	}
+	entryDir := filepath.Join(s.cacheDir, fingerprint)
+	if err := os.MkdirAll(entryDir, 0o755); err != nil {
+		return fmt.Errorf("create cache entry dir: %w", err)
+	}
+
 	var bytesCopied int64
 	for _, e := range entries {
 		if e.IsDir() {
@@ -78,7 +129,7 @@ func (s *Store) Register(fingerprint, srcDir string, progress ProgressFunc) erro
 		}
 		srcPath := filepath.Join(srcDir, e.Name())
 		dstPath := filepath.Join(entryDir, e.Name())
-		n, err := copyFileWithProgress(srcPath, dstPath, bytesCopied, totalBytes, progress)
+		n, err := s.copyFileWithProgress(srcPath, dstPath, bytesCopied, totalBytes, progress)
 		if err != nil {
 			return fmt.Errorf("copy %s: %w", e.Name(), err)
 		}
@@ -88,6 +139,29 @@ func (s *Store) Register(fingerprint, srcDir string, progress ProgressFunc) erro
 	return nil
 }
 
+// checkSpace refuses a store that would leave the cache filesystem below
+// its floor. Only copy mode is charged the entry size up front: links share
+// or clone the staging blocks. A cache filesystem that cannot be measured
+// does not block the store.
+func (s *Store) checkSpace(totalBytes int64) error {
+	if s.minFree <= 0 {
+		return nil
+	}
+	need := int64(0)
+	if s.transfer == fileutil.TransferCopy {
+		need = totalBytes
+	}
+	dir := s.cacheDir
+	if _, err := os.Stat(dir); err != nil {
+		dir = filepath.Dir(dir)
+	}
+	_, err := fileutil.CheckFreeSpace("rip cache", dir, need, s.minFree)
+	if errors.Is(err, fileutil.ErrInsufficientSpace) {
+		return err
+	}
+	return nil
+}
+
 // WriteMetadata writes the metadata sidecar for a cache entry via atomic
 // temp-file + rename. Returns error but callers should treat failure as
 // non-fatal (the cached files are still usable without metadata).
@@ -145,7 +219,7 @@ func (s *Store) Restore(fingerprint, destDir string, progress ProgressFunc) (*En
 		}
 		srcPath := filepath.Join(entryDir, e.Name())
 		dstPath := filepath.Join(destDir, e.Name())
-		n, err := copyFileWithProgress(srcPath, dstPath, bytesCopied, meta.TotalBytes, progress)
+		n, err := s.copyFileWithProgress(srcPath, dstPath, bytesCopied, meta.TotalBytes, progress)
 		if err != nil {
 			return nil, fmt.Errorf("copy %s: %w", e.Name(), err)
 		}
@@ -162,6 +236,40 @@ func (s *Store) HasCache(fingerprint string) bool {
 	return err == nil && info.IsDir()
 }
 
+// Verify reports whether fingerprint's cache entry is intact: its metadata
+// reads and its files add up to the recorded size. An entry left behind by
+// an interrupted Register fails the check.
+func (s *Store) Verify(fingerprint string) error {
+	meta, err := s.GetMetadata(fingerprint)
+	if err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(filepath.Join(s.cacheDir, fingerprint))
+	if err != nil {
+		return fmt.Errorf("read cache entry dir: %w", err)
+	}
+	var total int64
+	files := 0
+	for _, e := range entries {
+		if e.IsDir() || e.Name() == metadataFileName {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return fmt.Errorf("stat %s: %w", e.Name(), err)
+		}
+		total += info.Size()
+		files++
+	}
+	if files == 0 {
+		return errors.New("cache entry has no files")
+	}
+	if meta.TotalBytes > 0 && total != meta.TotalBytes {
+		return fmt.Errorf("cache entry holds %d bytes, metadata records %d", total, meta.TotalBytes)
+	}
+	return nil
+}
+
 // Prune removes the oldest cache entries until total size is under maxBytes.
 func (s *Store) Prune() error {
 	entries, err := os.ReadDir(s.cacheDir)
@@ -265,6 +373,32 @@ func (s *Store) List() ([]EntryMetadata, error) {
 	return result, nil
 }
 
+// MatchContent finds the cache entry holding the same content as a disc
+// whose own fingerprint has no entry, as happens across pressings of one
+// release. titleHashes maps the disc's title IDs to their content hashes.
+// The best entry scoring at least MinContentMatchScore wins, the newest on a
+// tie; nil means no entry is a confident match.
+func (s *Store) MatchContent(titleHashes map[int]string) (*EntryMetadata, float64, error) {
+	if len(titleHashes) == 0 {
+		return nil, 0, nil
+	}
+	entries, err := s.List()
+	if err != nil {
+		return nil, 0, err
+	}
+	var best *EntryMetadata
+	var bestScore float64
+	for i := range entries {
+		score := contentMatchScore(titleHashes, entries[i].TitleHashes)
+		// List is newest first, so a strict comparison keeps the newest tie.
+		if score >= MinContentMatchScore && score > bestScore {
+			best = &entries[i]
+			bestScore = score
+		}
+	}
+	return best, bestScore, nil
+}
+
 // Remove deletes a cache entry by fingerprint.
 func (s *Store) Remove(fingerprint string) error {
 	entryDir := filepath.Join(s.cacheDir, fingerprint)
@@ -296,14 +430,16 @@ func (s *Store) Clear() error {
 	return nil
 }
 
-// copyFileWithProgress transfers src to dst, hardlinking when cache and
-// staging share a filesystem so cache hits and cache stores are near-instant,
-// with a verified copy as the cross-device fallback. Cache entries and
-// staging ripped files may therefore share inodes: ripped files are only
-// ever read downstream (encodes and remuxes write new files), never modified
-// in place. baseOffset is the cumulative bytes already transferred in a
-// multi-file operation. Returns the number of bytes this file contributed.
-func copyFileWithProgress(src, dst string, baseOffset, totalBytes int64, progress ProgressFunc) (int64, error) {
+// copyFileWithProgress transfers src to dst with the store's transfer mode.
+// By default it hardlinks when cache and staging share a filesystem so cache
+// hits and cache stores are near-instant, with a verified copy as the
+// cross-device fallback. Cache entries and staging ripped files may
+// therefore share inodes: ripped files are only ever read downstream
+// (encodes and remuxes write new files), never modified in place. Reflinks
+// keep that sharing of disk blocks without sharing the inode. baseOffset is
+// the cumulative bytes already transferred in a multi-file operation.
+// Returns the number of bytes this file contributed.
+func (s *Store) copyFileWithProgress(src, dst string, baseOffset, totalBytes int64, progress ProgressFunc) (int64, error) {
 	info, err := os.Stat(src)
 	if err != nil {
 		return 0, fmt.Errorf("stat source: %w", err)
@@ -316,7 +452,7 @@ func copyFileWithProgress(src, dst string, baseOffset, totalBytes int64, progres
 		}
 	}
 
-	if err := fileutil.LinkOrCopyFileVerified(src, dst, wrapped); err != nil {
+	if err := fileutil.TransferFileVerified(src, dst, s.transfer, wrapped); err != nil {
 		return 0, err
 	}
 	return info.Size(), nil
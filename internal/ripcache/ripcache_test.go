@@ -1,10 +1,13 @@
 package ripcache
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/five82/spindle/internal/fileutil"
 )
 
 func TestHasCacheEmptyStore(t *testing.T) {
@@ -45,6 +48,34 @@ func TestRegisterAndHasCache(t *testing.T) {
 	}
 }
 
+func TestRegisterBlockedBelowFreeSpaceFloor(t *testing.T) {
+	orig := fileutil.FreeSpace
+	t.Cleanup(func() { fileutil.FreeSpace = orig })
+	fileutil.FreeSpace = func(string) (int64, error) { return 3 << 30, nil }
+
+	cacheDir := t.TempDir()
+	srcDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(srcDir, "title01.mkv"), []byte("video data"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	store := New(cacheDir, 10)
+	store.SetTransfer(fileutil.TransferCopy)
+	store.SetMinFree(4 << 30)
+
+	err := store.Register("abc123", srcDir, nil)
+	if !errors.Is(err, fileutil.ErrInsufficientSpace) {
+		t.Fatalf("Register err = %v, want ErrInsufficientSpace", err)
+	}
+	if _, statErr := os.Stat(filepath.Join(cacheDir, "abc123")); !os.IsNotExist(statErr) {
+		t.Fatalf("blocked store created an entry dir (stat err %v)", statErr)
+	}
+
+	store.SetMinFree(2 << 30)
+	if err := store.Register("abc123", srcDir, nil); err != nil {
+		t.Fatalf("Register above the floor: %v", err)
+	}
+}
+
 func TestRegisterAndRestoreRoundTrip(t *testing.T) {
 	cacheDir := t.TempDir()
 	srcDir := t.TempDir()
@@ -91,6 +122,57 @@ func TestRegisterAndRestoreRoundTrip(t *testing.T) {
 	}
 }
 
+func TestRestoreTransferModes(t *testing.T) {
+	tests := []struct {
+		mode     fileutil.TransferMode
+		wantLink bool
+	}{
+		{"", true},
+		{fileutil.TransferHardlink, true},
+		{fileutil.TransferReflink, false},
+		{fileutil.TransferCopy, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			// All temp dirs share one filesystem, so link modes apply.
+			srcDir := t.TempDir()
+			destDir := t.TempDir()
+			store := New(t.TempDir(), 10)
+			store.SetTransfer(tt.mode)
+
+			content := []byte("ripped video content")
+			if err := os.WriteFile(filepath.Join(srcDir, "title01.mkv"), content, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if err := store.Register("fp001", srcDir, nil); err != nil {
+				t.Fatalf("Register: %v", err)
+			}
+			if err := store.WriteMetadata("fp001", EntryMetadata{Fingerprint: "fp001", TotalBytes: int64(len(content))}); err != nil {
+				t.Fatalf("WriteMetadata: %v", err)
+			}
+			if _, err := store.Restore("fp001", destDir, nil); err != nil {
+				t.Fatalf("Restore: %v", err)
+			}
+
+			cached, err := os.Stat(filepath.Join(store.cacheDir, "fp001", "title01.mkv"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			staged, err := os.Stat(filepath.Join(destDir, "title01.mkv"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := os.SameFile(cached, staged); got != tt.wantLink {
+				t.Fatalf("staged file shares the cache inode = %v, want %v", got, tt.wantLink)
+			}
+			got, err := os.ReadFile(filepath.Join(destDir, "title01.mkv"))
+			if err != nil || string(got) != string(content) {
+				t.Fatalf("staged content = %q, %v; want %q", got, err, content)
+			}
+		})
+	}
+}
+
 func TestRestoreMissReturnsNil(t *testing.T) {
 	cacheDir := t.TempDir()
 	store := New(cacheDir, 10)
@@ -356,3 +438,71 @@ func TestPruneMissingCacheDir(t *testing.T) {
 		t.Fatalf("Prune on missing dir: %v", err)
 	}
 }
+
+func TestMatchContentAcrossFingerprints(t *testing.T) {
+	cacheDir := t.TempDir()
+	srcDir := t.TempDir()
+	store := New(cacheDir, 10)
+	if err := os.WriteFile(filepath.Join(srcDir, "title_t00.mkv"), []byte("video"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Register("PRESSING-A", srcDir, nil); err != nil {
+		t.Fatal(err)
+	}
+	cached := map[int]string{0: "h-main", 1: "h-extra", 2: "h-trailer"}
+	if err := store.WriteMetadata("PRESSING-A", EntryMetadata{
+		Fingerprint: "PRESSING-A",
+		CachedAt:    time.Now(),
+		TitleCount:  1,
+		TotalBytes:  5,
+		TitleHashes: cached,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A second pressing: different fingerprint, identical content.
+	meta, score, err := store.MatchContent(map[int]string{0: "h-main", 1: "h-extra", 2: "h-trailer"})
+	if err != nil {
+		t.Fatalf("MatchContent: %v", err)
+	}
+	if meta == nil || meta.Fingerprint != "PRESSING-A" {
+		t.Fatalf("MatchContent = %+v, want entry PRESSING-A", meta)
+	}
+	if score != 1 {
+		t.Errorf("score = %v, want 1", score)
+	}
+
+	for name, hashes := range map[string]map[int]string{
+		"one title differs":    {0: "h-main", 1: "h-other", 2: "h-trailer"},
+		"same content, new id": {1: "h-main", 2: "h-extra", 3: "h-trailer"},
+		"no hashes":            nil,
+	} {
+		meta, _, err := store.MatchContent(hashes)
+		if err != nil {
+			t.Fatalf("%s: MatchContent: %v", name, err)
+		}
+		if meta != nil {
+			t.Errorf("%s: matched %s, want no confident match", name, meta.Fingerprint)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	store := New(t.TempDir(), 10)
+	registerEntry(t, store, "intact", 64, time.Now())
+	if err := store.Verify("intact"); err != nil {
+		t.Fatalf("Verify(intact): %v", err)
+	}
+
+	registerEntry(t, store, "truncated", 64, time.Now())
+	if err := os.Truncate(filepath.Join(store.cacheDir, "truncated", "title01.mkv"), 10); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Verify("truncated"); err == nil {
+		t.Fatal("Verify accepted an entry smaller than its recorded size")
+	}
+
+	if err := store.Verify("absent"); err == nil {
+		t.Fatal("Verify accepted a missing entry")
+	}
+}
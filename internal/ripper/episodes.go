@@ -71,8 +71,14 @@ func assignEpisodeAssets(env *ripspec.Envelope, dir string, titleFiles map[int]s
 
 // assignMovieAssets maps ripped files in dir to the "main" asset, recovering
 // each title ID from the MakeMKV filename (e.g. "Inside Out_t02.mkv" -> 2).
-// TitleID is -1 when the filename carries no parseable title number.
+// TitleID is -1 when the filename carries no parseable title number. A file
+// whose title is a kept edition of a multi-version rip maps to that
+// edition's key instead.
 func assignMovieAssets(env *ripspec.Envelope, dir string) error {
+	titleKeys := make(map[int]string, len(env.Editions))
+	for _, ed := range env.Editions {
+		titleKeys[ed.TitleID] = ed.Key
+	}
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return fmt.Errorf("asset mapping: read dir: %w", err)
@@ -85,8 +91,12 @@ func assignMovieAssets(env *ripspec.Envelope, dir string) error {
 		if !ok {
 			titleID = -1
 		}
+		key, ok := titleKeys[titleID]
+		if !ok {
+			key = ripspec.MainEditionKey
+		}
 		env.Assets.AddAsset(ripspec.AssetKindRipped, ripspec.Asset{
-			EpisodeKey: "main",
+			EpisodeKey: key,
 			TitleID:    titleID,
 			Path:       filepath.Join(dir, entry.Name()),
 			Status:     ripspec.AssetStatusCompleted,
@@ -83,6 +83,32 @@ func TestAssignMovieAssets(t *testing.T) {
 	}
 }
 
+func TestAssignMovieAssetsMapsEditions(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"Aliens_t00.mkv", "Aliens_t01.mkv"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("fake"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	env := &ripspec.Envelope{
+		Metadata: ripspec.Metadata{MediaType: "movie"},
+		Editions: []ripspec.Edition{
+			{Key: "main", TitleID: 1, Name: "Extended"},
+			{Key: "edition_theatrical", TitleID: 0, Name: "Theatrical"},
+		},
+	}
+	if err := assignMovieAssets(env, dir); err != nil {
+		t.Fatalf("assignMovieAssets: %v", err)
+	}
+	for key, titleID := range map[string]int{"main": 1, "edition_theatrical": 0} {
+		asset, ok := env.Assets.FindAsset(ripspec.AssetKindRipped, key)
+		if !ok || asset.TitleID != titleID {
+			t.Errorf("%s asset = %+v (found %v), want title %d", key, asset, ok, titleID)
+		}
+	}
+}
+
 func TestAssignMovieAssets_UnparseableFilename(t *testing.T) {
 	dir := t.TempDir()
 	if err := os.WriteFile(filepath.Join(dir, "movie.mkv"), []byte("fake"), 0o644); err != nil {
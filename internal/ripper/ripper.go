@@ -9,8 +9,6 @@ import (
 	"strings"
 	"time"
 
-	"golang.org/x/sys/unix"
-
 	"github.com/five82/spindle/internal/config"
 	"github.com/five82/spindle/internal/discmonitor"
 	"github.com/five82/spindle/internal/logs"
@@ -68,11 +66,31 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 	if err != nil {
 		return err
 	}
-	defer cleanup()
+	targets, err := h.ripFresh(ctx, sess, rippedDir)
+	h.ejectAfterRip(ctx, logger, err)
+	cleanup()
+	if err != nil {
+		return err
+	}
 
+	h.cacheFreshRip(logger, sess, rippedDir, len(targets))
+	h.notifyRipComplete(ctx, logger, sess, len(targets))
+
+	logger.Debug("ripping stage completed",
+		"event_type", "stage_complete",
+		"stage", "ripping",
+		"titles_ripped", len(targets),
+	)
+	return nil
+}
+
+// ripFresh rips the selected titles from the disc, then maps and validates
+// the ripped files. It returns the titles it ripped.
+func (h *Handler) ripFresh(ctx context.Context, sess *stage.Session, rippedDir string) ([]ripspec.Title, error) {
+	logger := sess.Logger
 	targets, err := h.selectRipTargets(logger, sess.Env)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	logger.Info("ripping plan",
 		"event_type", "ripping_plan",
@@ -81,30 +99,75 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 	)
 
 	if err := h.checkStagingSpace(logger, targets); err != nil {
-		return err
+		return nil, err
 	}
 
 	if err := h.ripTitles(ctx, sess, rippedDir, targets); err != nil {
-		return err
+		return nil, err
 	}
 	_ = sess.ClearActiveEpisode()
 
 	if err := h.mapAndValidateAssets(ctx, logger, sess, rippedDir, nil); err != nil {
-		return err
+		return nil, err
 	}
 	if err := sess.Save(); err != nil {
-		return err
+		return nil, err
 	}
+	return targets, nil
+}
 
-	h.cacheFreshRip(logger, sess, rippedDir, len(targets))
-	h.notifyRipComplete(ctx, logger, sess, len(targets))
+// ejectDisc opens the drive tray. It is a variable so tests can observe
+// ejection without a drive.
+var ejectDisc = discmonitor.Eject
+
+// ejectAfterRip applies makemkv.eject_after_rip once the drive is no longer
+// needed. ripErr is the outcome of the rip; a cancelled rip never ejects,
+// since the stop came from the user rather than the end of the disc. An
+// eject failure is logged and never fails the stage.
+func (h *Handler) ejectAfterRip(ctx context.Context, logger *slog.Logger, ripErr error) {
+	mode := h.cfg.MakeMKV.EjectAfterRip
+	device := h.cfg.MakeMKV.OpticalDrive
+	var skip string
+	switch {
+	case mode == "" || mode == config.EjectAfterRipKeep:
+		return
+	case ctx.Err() != nil:
+		skip = "rip cancelled"
+	case !strings.HasPrefix(device, "/dev/"):
+		skip = "optical_drive is not a device path"
+	case ripErr != nil && mode == config.EjectAfterRipOnSuccess:
+		skip = "rip failed; disc kept for retry"
+	}
+	if skip != "" {
+		logger.Info("disc ejection skipped",
+			"decision_type", logs.DecisionDiscEject,
+			"decision_result", "kept",
+			"decision_reason", skip,
+			"eject_after_rip", mode,
+		)
+		return
+	}
 
-	logger.Debug("ripping stage completed",
-		"event_type", "stage_complete",
-		"stage", "ripping",
-		"titles_ripped", len(targets),
+	if err := ejectDisc(ctx, device); err != nil {
+		logger.Warn("disc ejection failed",
+			"event_type", "disc_eject_failed",
+			"error_hint", err.Error(),
+			"impact", "disc stays in the drive; eject it manually",
+			"device", device,
+		)
+		return
+	}
+	reason := "rip succeeded"
+	if ripErr != nil {
+		reason = "rip failed"
+	}
+	logger.Info("disc ejected after rip",
+		"decision_type", logs.DecisionDiscEject,
+		"decision_result", "ejected",
+		"decision_reason", reason,
+		"eject_after_rip", mode,
+		"device", device,
 	)
-	return nil
 }
 
 func (h *Handler) prepareRipStaging(sess *stage.Session) (string, error) {
@@ -139,7 +202,18 @@ func (h *Handler) restoreFromRipCache(ctx context.Context, sess *stage.Session,
 		return false, nil
 	}
 
-	meta, err := h.cache.Restore(item.DiscFingerprint, rippedDir, h.cacheProgressFunc(sess, "Restoring from cache..."))
+	fingerprint := h.cacheFingerprint(logger, item.DiscFingerprint, env)
+	if h.cache.HasCache(fingerprint) {
+		if err := h.cache.Verify(fingerprint); err != nil {
+			logger.Warn("rip cache entry incomplete",
+				"event_type", "cache_verify_error",
+				"error_hint", err.Error(),
+				"impact", "cache entry ignored; ripping from disc",
+			)
+			return false, nil
+		}
+	}
+	meta, err := h.cache.Restore(fingerprint, rippedDir, h.cacheProgressFunc(sess, "Restoring from cache..."))
 	if err != nil || meta == nil {
 		attrs := []any{
 			"decision_type", logs.DecisionRipCache,
@@ -185,7 +259,8 @@ func (h *Handler) restoreFromRipCache(ctx context.Context, sess *stage.Session,
 	msg := fmt.Sprintf("%s (%d titles from cache)", item.DisplayTitle(), meta.TitleCount)
 	msg += "\n" + driveAvailableMsg
 	msg += queue.FormatAlsoProcessing(sess.Store, item.ID)
-	_ = notify.SendLogged(ctx, h.notifier, logger, notify.EventRipCacheHit,
+	_ = notify.SendItemLogged(ctx, h.notifier, logger, notify.EventRipCacheHit,
+		notify.Fields{Item: item.ID, Stage: string(queue.StageRipping)},
 		"Rip Cache Hit: "+item.DisplayTitle(),
 		msg,
 	)
@@ -200,6 +275,44 @@ func (h *Handler) restoreFromRipCache(ctx context.Context, sess *stage.Session,
 	return true, nil
 }
 
+// cacheFingerprint returns the rip cache entry to restore for a disc: its own
+// fingerprint when cached, otherwise a confident content match on title
+// hashes, so another pressing of the same release reuses the cached rip.
+func (h *Handler) cacheFingerprint(logger *slog.Logger, fingerprint string, env *ripspec.Envelope) string {
+	if h.cache.HasCache(fingerprint) {
+		return fingerprint
+	}
+	meta, score, err := h.cache.MatchContent(titleHashes(env))
+	if err != nil {
+		logger.Warn("rip cache content match failed",
+			"event_type", "cache_match_error",
+			"error_hint", err.Error(),
+			"impact", "only an exact fingerprint can hit the rip cache",
+		)
+		return fingerprint
+	}
+	if meta == nil {
+		return fingerprint
+	}
+	logger.Info("rip cache content match",
+		"decision_type", logs.DecisionRipCache,
+		"decision_result", "content_match",
+		"decision_reason", fmt.Sprintf("title hashes match cached fingerprint %s (score %.2f, min %.2f)", meta.Fingerprint, score, ripcache.MinContentMatchScore),
+	)
+	return meta.Fingerprint
+}
+
+// titleHashes maps the envelope's title IDs to their content hashes.
+func titleHashes(env *ripspec.Envelope) map[int]string {
+	hashes := make(map[int]string, len(env.Titles))
+	for _, t := range env.Titles {
+		if t.TitleHash != "" {
+			hashes[t.ID] = t.TitleHash
+		}
+	}
+	return hashes
+}
+
 func (h *Handler) restoreTitlesFromCachedEnvelope(logger *slog.Logger, env *ripspec.Envelope, ripSpecData string) {
 	// Restore titles from cached envelope when identification used the disc ID
 	// cache fast-path (no MakeMKV scan).
@@ -266,6 +379,9 @@ func (h *Handler) ripTitles(ctx context.Context, sess *stage.Session, rippedDir
 	for _, ep := range sess.Env.Episodes {
 		titleEpisodeKey[ep.TitleID] = ep.Key
 	}
+	for _, ed := range sess.Env.Editions {
+		titleEpisodeKey[ed.TitleID] = ed.Key
+	}
 
 	// Rip selected titles one by one, persisting per-title progress so external
 	// consumers can show both aggregate stage progress and completed episode
@@ -288,7 +404,7 @@ func (h *Handler) ripTitle(ctx context.Context, sess *stage.Session, rippedDir s
 		"event_type", "rip_title_start",
 	)
 
-	if err := sess.Progress(overallRipPercent(index, total, 0), fmt.Sprintf("Phase %d/%d - Ripping title %d", index+1, total, title.ID), stage.WithActiveEpisode(episodeKey)); err != nil {
+	if err := sess.Progress(overallRipPercent(index, total, 0), fmt.Sprintf("Phase %d/%d - Ripping title %d", index+1, total, title.ID), stage.WithActiveEpisode(episodeKey), stage.WithProgressETA(0)); err != nil {
 		logger.Warn("progress persistence failed",
 			"event_type", "progress_persist_failed",
 			"error_hint", "rip progress message not persisted",
@@ -297,17 +413,26 @@ func (h *Handler) ripTitle(ctx context.Context, sess *stage.Session, rippedDir s
 		)
 	}
 
+	timeout := scaledRipTimeout(time.Duration(h.cfg.MakeMKV.RipTimeout)*time.Second, title.SizeBytes)
+	logger.Info("rip timeout computed",
+		"decision_type", logs.DecisionRipTimeout,
+		"decision_result", timeout.String(),
+		"decision_reason", ripTimeoutReason(title.SizeBytes),
+		"title_id", title.ID,
+		"size_bytes", title.SizeBytes,
+	)
+
 	before := listMKVFiles(rippedDir)
 	var lastRipLog time.Time
 	err := makemkv.Rip(ctx, h.cfg.MakeMKV.OpticalDrive, title.ID, rippedDir,
-		time.Duration(h.cfg.MakeMKV.RipTimeout)*time.Second,
+		timeout,
 		h.cfg.MakeMKV.MinTitleLength,
 		func(p makemkv.RipProgress) {
 			message := sess.Task.ProgressMessage
 			if strings.TrimSpace(p.Message) != "" {
 				message = p.Message
 			}
-			_ = sess.Progress(overallRipPercent(index, total, p.Percent), message)
+			_ = sess.Progress(overallRipPercent(index, total, p.Percent), message, stage.WithProgressETA(p.ETA))
 
 			now := time.Now()
 			if lastRipLog.IsZero() || now.Sub(lastRipLog) >= ripProgressLogInterval || p.Percent >= 100 {
@@ -319,12 +444,14 @@ func (h *Handler) ripTitle(ctx context.Context, sess *stage.Session, rippedDir s
 					"percent", p.Percent,
 					"current", p.Current,
 					"total", p.Total,
+					"eta", p.ETA.String(),
 					"message", message,
 				)
 			}
 		}, logger,
 	)
 	if err != nil {
+		flagDiscFailure(sess, title.ID, err)
 		return fmt.Errorf("rip title %d: %w", title.ID, err)
 	}
 
@@ -353,6 +480,33 @@ func (h *Handler) ripTitle(ctx context.Context, sess *stage.Session, rippedDir s
 	return nil
 }
 
+// singleLayerBytes is the capacity of a single-layer Blu-ray (BD-25). The
+// configured rip timeout is sized for a title that fills one; a larger title
+// spans both layers of a BD-50, where reads slow down near the layer break
+// and the outer edge, so its timeout grows in proportion to its size.
+const singleLayerBytes = 25_000_000_000
+
+// scaledRipTimeout returns the rip timeout for a title of sizeBytes. Titles
+// of unknown size or that fit on one layer keep base.
+func scaledRipTimeout(base time.Duration, sizeBytes int64) time.Duration {
+	if sizeBytes <= singleLayerBytes {
+		return base
+	}
+	return time.Duration(float64(base) * float64(sizeBytes) / singleLayerBytes).Round(time.Second)
+}
+
+func ripTimeoutReason(sizeBytes int64) string {
+	switch {
+	case sizeBytes <= 0:
+		return "title size unknown; using configured rip_timeout"
+	case sizeBytes <= singleLayerBytes:
+		return fmt.Sprintf("%.1f GiB title fits a single layer; using configured rip_timeout", gib(sizeBytes))
+	default:
+		return fmt.Sprintf("%.1f GiB title spans a dual-layer disc; rip_timeout scaled by %.2fx",
+			gib(sizeBytes), float64(sizeBytes)/singleLayerBytes)
+	}
+}
+
 func (h *Handler) discoverNewRippedFile(logger *slog.Logger, rippedDir string, titleID int, before map[string]bool) (string, error) {
 	after := listMKVFiles(rippedDir)
 	newFile := findNewFile(before, after)
@@ -393,6 +547,9 @@ func (h *Handler) cacheFreshRip(logger *slog.Logger, sess *stage.Session, ripped
 	var totalBytes int64
 	if dirEntries, err := os.ReadDir(rippedDir); err == nil {
 		for _, de := range dirEntries {
+			if de.IsDir() {
+				continue // Register copies files only; Verify sums the same set
+			}
 			if info, err := de.Info(); err == nil {
 				totalBytes += info.Size()
 			}
@@ -407,6 +564,7 @@ func (h *Handler) cacheFreshRip(logger *slog.Logger, sess *stage.Session, ripped
 		TotalBytes:   totalBytes,
 		RipSpecData:  item.RipSpecData,
 		MetadataJSON: item.MetadataJSON,
+		TitleHashes:  titleHashes(sess.Env),
 	}
 	if err := h.cache.Register(item.DiscFingerprint, rippedDir, h.cacheProgressFunc(sess, "Caching rip...")); err != nil {
 		logger.Warn("rip cache write failed",
@@ -447,14 +605,41 @@ func (h *Handler) notifyRipComplete(ctx context.Context, logger *slog.Logger, se
 	msg := fmt.Sprintf("Ripped %s (%d titles)", item.DisplayTitle(), rippedCount)
 	msg += "\n" + driveAvailableMsg
 	msg += queue.FormatAlsoProcessing(sess.Store, item.ID)
-	_ = notify.SendLogged(ctx, h.notifier, logger, notify.EventRipComplete,
+	_ = notify.SendItemLogged(ctx, h.notifier, logger, notify.EventRipComplete,
+		notify.Fields{Item: item.ID, Stage: string(queue.StageRipping)},
 		"Rip Complete: "+item.DisplayTitle(),
 		msg,
 	)
 }
 
+// selectMovieVersions applies makemkv.movie_versions when the disc holds
+// several full-length cuts of the movie; otherwise only the primary title
+// is ripped.
+func (h *Handler) selectMovieVersions(logger *slog.Logger, env *ripspec.Envelope, primary ripspec.Title) []ripspec.Title {
+	versions := detectMovieVersions(env.Titles, primary)
+	if len(versions) == 0 {
+		return []ripspec.Title{primary}
+	}
+	targets := applyMovieVersionPolicy(env, versions, h.cfg.MakeMKV.MovieVersions)
+	found := make([]string, 0, len(versions))
+	for _, v := range env.Attributes.MovieVersions.Versions {
+		found = append(found, fmt.Sprintf("%s=title %d (%ds)", v.Name, v.TitleID, v.Duration))
+	}
+	logger.Info("multiple movie versions detected",
+		"decision_type", logs.DecisionMovieVersions,
+		"decision_result", fmt.Sprintf("%d of %d versions ripped", len(targets), len(versions)),
+		"decision_reason", "movie_versions="+env.Attributes.MovieVersions.Policy,
+		"versions", strings.Join(found, ", "),
+	)
+	return targets
+}
+
 // selectRipTargets determines which titles to rip based on media type.
 func (h *Handler) selectRipTargets(logger *slog.Logger, env *ripspec.Envelope) ([]ripspec.Title, error) {
+	// A re-rip decides the movie's cuts afresh.
+	env.Editions = nil
+	env.Attributes.MovieVersions = nil
+
 	// User-specified title override bypasses media-type selection.
 	if h.titleOverride >= 0 {
 		for _, t := range env.Titles {
@@ -516,7 +701,7 @@ func (h *Handler) selectRipTargets(logger *slog.Logger, env *ripspec.Envelope) (
 				attrs = append(attrs, fmt.Sprintf("rejected_%d", i+1), r)
 			}
 			logger.Info("primary title decision", attrs...)
-			return []ripspec.Title{selection}, nil
+			return h.selectMovieVersions(logger, env, selection), nil
 		}
 		logger.Warn("no titles above minimum duration for movie",
 			"event_type", "title_selection_empty",
@@ -585,49 +770,50 @@ func (h *Handler) selectRipTargets(logger *slog.Logger, env *ripspec.Envelope) (
 const stagingSpaceMargin = 1.1
 
 // checkStagingSpace fails the rip immediately when the staging volume cannot
-// hold the selected titles, instead of surfacing a confusing copy error deep
-// into the rip. The check is advisory-only in the other direction: unknown
-// title sizes or a statfs failure never block a rip that might succeed.
+// hold the selected titles above the staging free-space floor, instead of
+// surfacing a confusing copy error deep into the rip. Unknown title sizes
+// drop the estimate but still enforce the floor.
 func (h *Handler) checkStagingSpace(logger *slog.Logger, targets []ripspec.Title) error {
 	var estimated int64
 	for _, t := range targets {
 		if t.SizeBytes <= 0 {
-			logger.Debug("staging space preflight skipped",
+			logger.Debug("staging space estimate skipped",
 				"event_type", "staging_space_preflight",
 				"reason", "title size unknown",
 				"title_id", t.ID,
 			)
-			return nil
+			estimated = 0
+			break
 		}
 		estimated += t.SizeBytes
 	}
-	if estimated == 0 {
-		return nil
-	}
+	required := int64(float64(estimated) * stagingSpaceMargin)
+	floor := int64(h.cfg.FreeSpace.StagingGiB) << 30
+	return stage.EnsureFreeSpace(logger, "staging", h.cfg.Paths.StagingDir, required, floor)
+}
 
-	var fs unix.Statfs_t
-	if err := unix.Statfs(h.cfg.Paths.StagingDir, &fs); err != nil {
-		logger.Debug("staging space preflight skipped",
-			"event_type", "staging_space_preflight",
-			"reason", "statfs failed",
-			"error", err.Error(),
-		)
-		return nil
+// flagDiscFailure flags the item for review when a rip failed because the
+// disc could not be decrypted or read, so the failure names its cause and
+// remedy rather than only MakeMKV's exit status.
+func flagDiscFailure(sess *stage.Session, titleID int, err error) {
+	reason, remedy, ok := makemkv.DiscFailureAdvice(err)
+	if !ok {
+		return
 	}
-	free := int64(fs.Bavail) * int64(fs.Bsize)
-	required := int64(float64(estimated) * stagingSpaceMargin)
-	if free < required {
-		return fmt.Errorf(
-			"insufficient staging space: rip needs about %.1f GiB (%.1f GiB estimated plus margin) but %s has %.1f GiB free; free up space and retry",
-			gib(required), gib(estimated), h.cfg.Paths.StagingDir, gib(free))
-	}
-	logger.Debug("staging space preflight passed",
-		"event_type", "staging_space_preflight",
-		"estimated_bytes", estimated,
-		"required_bytes", required,
-		"free_bytes", free,
+	sess.Logger.Warn("disc failure recognized in MakeMKV output",
+		"event_type", "disc_failure",
+		"error_hint", remedy,
+		"impact", "item failed and flagged for review",
+		"title_id", titleID,
+		"reason", reason,
 	)
-	return nil
+	if mergeErr := sess.MergeAddReviewReason(reason); mergeErr != nil {
+		sess.Logger.Warn("review reason persistence failed",
+			"event_type", "review_reason_persist_failed",
+			"error_hint", mergeErr.Error(),
+			"impact", "failure reason only recorded in the error message",
+		)
+	}
 }
 
 func gib(bytes int64) float64 { return float64(bytes) / (1 << 30) }
@@ -680,12 +866,14 @@ func (h *Handler) mapAndValidateAssets(ctx context.Context, logger *slog.Logger,
 	// rip-cache-restore paths funnel through this function.
 	visited := make(map[string]struct{})
 	var validationErrors int
+	var interlaced []string
 	for i, asset := range env.Assets.Ripped {
 		if _, seen := visited[asset.Path]; seen {
 			continue
 		}
 		visited[asset.Path] = struct{}{}
-		if err := h.validateRippedArtifact(ctx, asset.Path); err != nil {
+		probe, err := h.validateRippedArtifact(ctx, asset.Path, titleDuration(env, asset.TitleID))
+		if err != nil {
 			if env.Metadata.MediaType == "tv" && len(env.Episodes) > 0 {
 				// Per-episode failure isolation: mark failed, continue.
 				logger.Warn("ripped episode failed validation",
@@ -703,7 +891,11 @@ func (h *Handler) mapAndValidateAssets(ctx context.Context, logger *slog.Logger,
 			// Movies: fatal (single title).
 			return fmt.Errorf("ripped artifact invalid (%s): %w", filepath.Base(asset.Path), err)
 		}
+		if recordScanType(logger, env, i, probe) {
+			interlaced = append(interlaced, asset.EpisodeKey)
+		}
 	}
+	flagInterlacedForReview(sess, interlaced)
 
 	if env.Metadata.MediaType == "tv" && validationErrors > 0 {
 		valid := len(visited) - validationErrors
@@ -719,7 +911,7 @@ func (h *Handler) mapAndValidateAssets(ctx context.Context, logger *slog.Logger,
 		sess.AddReviewReason(reason)
 		logger.Warn("partial rip validation",
 			"event_type", "rip_validation_partial",
-			"error_hint", "some episodes failed ffprobe validation",
+			"error_hint", "some episodes failed ffprobe validation or were truncated",
 			"impact", fmt.Sprintf("%d of %d episodes excluded", validationErrors, len(visited)),
 		)
 	}
@@ -1,7 +1,9 @@
 package ripper
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"log/slog"
 	"math"
@@ -9,8 +11,10 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/five82/spindle/internal/config"
+	"github.com/five82/spindle/internal/fileutil"
 	"github.com/five82/spindle/internal/ripcache"
 	"github.com/five82/spindle/internal/ripspec"
 )
@@ -253,6 +257,40 @@ func TestRestoreTitlesFromCachedRipSpec(t *testing.T) {
 	}
 }
 
+func TestCacheFingerprintFallsBackToContentMatch(t *testing.T) {
+	cacheDir := t.TempDir()
+	srcDir := t.TempDir()
+	cache := ripcache.New(cacheDir, 10)
+	if err := os.WriteFile(filepath.Join(srcDir, "title_t00.mkv"), []byte("video"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.Register("PRESSING-A", srcDir, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.WriteMetadata("PRESSING-A", ripcache.EntryMetadata{
+		Fingerprint: "PRESSING-A",
+		TitleHashes: map[int]string{0: "h-main", 1: "h-extra"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	h := &Handler{cache: cache}
+	env := &ripspec.Envelope{Titles: []ripspec.Title{
+		{ID: 0, TitleHash: "h-main"},
+		{ID: 1, TitleHash: "h-extra"},
+	}}
+
+	if got := h.cacheFingerprint(testLogger(), "PRESSING-B", env); got != "PRESSING-A" {
+		t.Fatalf("content match: fingerprint = %q, want PRESSING-A", got)
+	}
+	if got := h.cacheFingerprint(testLogger(), "PRESSING-A", env); got != "PRESSING-A" {
+		t.Fatalf("exact fingerprint: got %q, want PRESSING-A", got)
+	}
+	env.Titles[1].TitleHash = "h-different"
+	if got := h.cacheFingerprint(testLogger(), "PRESSING-B", env); got != "PRESSING-B" {
+		t.Fatalf("low-confidence match: fingerprint = %q, want PRESSING-B", got)
+	}
+}
+
 func TestRestoreTitlesSkippedWhenAlreadyPresent(t *testing.T) {
 	// Envelope already has titles (normal scan path) -- should not be overwritten.
 	env := ripspec.Envelope{
@@ -365,8 +403,8 @@ func TestCheckStagingSpaceSkippedOnUnknownSize(t *testing.T) {
 	h := &Handler{cfg: &config.Config{}, titleOverride: -1}
 	h.cfg.Paths.StagingDir = t.TempDir()
 
-	// One title without a scan size estimate disables the check entirely,
-	// even when another title alone would exceed free space.
+	// One title without a scan size estimate drops the estimate, even when
+	// another title alone would exceed free space; only the floor applies.
 	targets := []ripspec.Title{
 		{ID: 0, SizeBytes: 1 << 62},
 		{ID: 1, SizeBytes: 0},
@@ -385,3 +423,112 @@ func TestCheckStagingSpaceSkippedOnStatfsFailure(t *testing.T) {
 		t.Fatalf("expected skip on statfs failure, got: %v", err)
 	}
 }
+
+func TestCheckStagingSpaceEnforcesFloor(t *testing.T) {
+	orig := fileutil.FreeSpace
+	t.Cleanup(func() { fileutil.FreeSpace = orig })
+	fileutil.FreeSpace = func(string) (int64, error) { return 8 << 30, nil }
+
+	h := &Handler{cfg: &config.Config{}, titleOverride: -1}
+	h.cfg.Paths.StagingDir = t.TempDir()
+	h.cfg.FreeSpace.StagingGiB = 10
+
+	// The floor holds even when no title size is known.
+	targets := []ripspec.Title{{ID: 0, SizeBytes: 0}}
+	err := h.checkStagingSpace(testLogger(), targets)
+	if !errors.Is(err, fileutil.ErrInsufficientSpace) {
+		t.Fatalf("err = %v, want ErrInsufficientSpace", err)
+	}
+
+	h.cfg.FreeSpace.StagingGiB = 5
+	if err := h.checkStagingSpace(testLogger(), targets); err != nil {
+		t.Fatalf("expected rip above the floor to pass, got: %v", err)
+	}
+	targets[0].SizeBytes = 4 << 30 // 4.4 GiB with margin leaves less than 5
+	if err := h.checkStagingSpace(testLogger(), targets); !errors.Is(err, fileutil.ErrInsufficientSpace) {
+		t.Fatalf("err = %v, want ErrInsufficientSpace once the estimate eats into the floor", err)
+	}
+}
+
+func TestScaledRipTimeoutGrowsWithDiscSize(t *testing.T) {
+	base := 4 * time.Hour
+	small := scaledRipTimeout(base, 8_000_000_000)  // DVD-sized title
+	large := scaledRipTimeout(base, 45_000_000_000) // BD-50 feature
+	unknown := scaledRipTimeout(base, 0)
+
+	if small != base || unknown != base {
+		t.Fatalf("small/unknown timeout = %v/%v, want base %v", small, unknown, base)
+	}
+	if large <= small {
+		t.Fatalf("large disc timeout %v not longer than small disc timeout %v", large, small)
+	}
+	if want := 7*time.Hour + 12*time.Minute; large != want {
+		t.Fatalf("45 GB title timeout = %v, want %v", large, want)
+	}
+}
+
+func TestEjectAfterRip(t *testing.T) {
+	ripErr := errors.New("makemkv exited 1")
+	for _, tc := range []struct {
+		mode   string
+		ripErr error
+		want   bool
+	}{
+		{config.EjectAfterRipKeep, nil, false},
+		{config.EjectAfterRipKeep, ripErr, false},
+		{config.EjectAfterRipAlways, nil, true},
+		{config.EjectAfterRipAlways, ripErr, true},
+		{config.EjectAfterRipOnSuccess, nil, true},
+		{config.EjectAfterRipOnSuccess, ripErr, false},
+	} {
+		var ejected []string
+		orig := ejectDisc
+		ejectDisc = func(_ context.Context, device string) error {
+			ejected = append(ejected, device)
+			return nil
+		}
+		h := &Handler{cfg: &config.Config{}}
+		h.cfg.MakeMKV.OpticalDrive = "/dev/sr1"
+		h.cfg.MakeMKV.EjectAfterRip = tc.mode
+
+		h.ejectAfterRip(context.Background(), testLogger(), tc.ripErr)
+		ejectDisc = orig
+
+		if got := len(ejected) == 1; got != tc.want || len(ejected) > 1 {
+			t.Errorf("mode=%s ripErr=%v: ejected %v, want eject=%v", tc.mode, tc.ripErr, ejected, tc.want)
+		}
+		if tc.want && len(ejected) == 1 && ejected[0] != "/dev/sr1" {
+			t.Errorf("mode=%s: ejected %q, want /dev/sr1", tc.mode, ejected[0])
+		}
+	}
+}
+
+func TestEjectAfterRipSkipsCancelledRipAndNonDeviceDrive(t *testing.T) {
+	calls := 0
+	orig := ejectDisc
+	ejectDisc = func(context.Context, string) error {
+		calls++
+		return errors.New("drive busy")
+	}
+	t.Cleanup(func() { ejectDisc = orig })
+
+	h := &Handler{cfg: &config.Config{}}
+	h.cfg.MakeMKV.EjectAfterRip = config.EjectAfterRipAlways
+
+	h.cfg.MakeMKV.OpticalDrive = "disc:0"
+	h.ejectAfterRip(context.Background(), testLogger(), nil)
+
+	h.cfg.MakeMKV.OpticalDrive = "/dev/sr0"
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	h.ejectAfterRip(ctx, testLogger(), context.Canceled)
+	if calls != 0 {
+		t.Fatalf("eject attempted %d times, want none", calls)
+	}
+
+	// A failed eject is logged, not surfaced.
+	h.ejectAfterRip(context.Background(), testLogger(), nil)
+	if calls != 1 {
+		t.Fatalf("eject attempted %d times, want 1", calls)
+	}
+}
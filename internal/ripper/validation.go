@@ -3,47 +3,127 @@ package ripper
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"strings"
 
+	"github.com/five82/spindle/internal/logs"
 	"github.com/five82/spindle/internal/media/ffprobe"
+	"github.com/five82/spindle/internal/ripspec"
+	"github.com/five82/spindle/internal/stage"
 )
 
 const minRipFileSizeBytes = 10 * 1024 * 1024 // 10 MB
 
+// ripRuntimeTolerance is the fraction of the scanned title duration a rip
+// may fall short by before it is treated as truncated. Container and scan
+// durations normally agree to within a few seconds.
+const ripRuntimeTolerance = 0.05
+
+var inspectRip = ffprobe.InspectWithOptions
+
 // validateRippedArtifact checks that a ripped file is a valid video, returning
-// an error describing the validation failure otherwise.
-func (h *Handler) validateRippedArtifact(ctx context.Context, path string) error {
+// its probe result or an error describing the validation failure. expectedSeconds
+// is the scanned title duration; a rip meaningfully shorter than it was cut off
+// (bad sector, early eject) and fails. Zero skips the runtime check.
+func (h *Handler) validateRippedArtifact(ctx context.Context, path string, expectedSeconds int) (*ffprobe.Result, error) {
 	clean := strings.TrimSpace(path)
 	if clean == "" {
-		return fmt.Errorf("rip validation: empty path")
+		return nil, fmt.Errorf("rip validation: empty path")
 	}
 
 	info, err := os.Stat(clean)
 	if err != nil {
-		return fmt.Errorf("rip validation: stat %s: %w", clean, err)
+		return nil, fmt.Errorf("rip validation: stat %s: %w", clean, err)
 	}
 	if info.IsDir() {
-		return fmt.Errorf("rip validation: %s is a directory, not a file", clean)
+		return nil, fmt.Errorf("rip validation: %s is a directory, not a file", clean)
 	}
 	if info.Size() < minRipFileSizeBytes {
-		return fmt.Errorf("rip validation: %s is %d bytes (minimum %d)", clean, info.Size(), minRipFileSizeBytes)
+		return nil, fmt.Errorf("rip validation: %s is %d bytes (minimum %d)", clean, info.Size(), minRipFileSizeBytes)
 	}
 
-	probe, err := ffprobe.Inspect(ctx, "ffprobe", clean)
+	probe, err := inspectRip(ctx, "ffprobe", clean, ffprobe.Options{Timeout: h.cfg.Workflow.FFprobeTimeoutDuration()})
 	if err != nil {
-		return fmt.Errorf("rip validation: ffprobe %s: %w", clean, err)
+		return nil, fmt.Errorf("rip validation: ffprobe %s: %w", clean, err)
 	}
 	if probe.VideoStreamCount() == 0 {
-		return fmt.Errorf("rip validation: %s has no video streams", clean)
+		return nil, fmt.Errorf("rip validation: %s has no video streams", clean)
 	}
 	if probe.AudioStreamCount() == 0 {
-		return fmt.Errorf("rip validation: %s has no audio streams", clean)
+		return nil, fmt.Errorf("rip validation: %s has no audio streams", clean)
 	}
 	if probe.DurationSeconds() <= 0 {
-		return fmt.Errorf("rip validation: %s has invalid duration", clean)
+		return nil, fmt.Errorf("rip validation: %s has invalid duration", clean)
+	}
+	if err := checkRipRuntime(probe.DurationSeconds(), expectedSeconds); err != nil {
+		return nil, fmt.Errorf("rip validation: %s %w", clean, err)
+	}
+
+	return probe, nil
+}
+
+// recordScanType sets the Interlaced flag on the ripped asset at index i from
+// its probe and logs the decision. Reel has no deinterlace or inverse
+// telecine filter, so an interlaced source cannot be corrected in the encode;
+// the caller routes it to review instead. It reports whether the asset is
+// interlaced.
+func recordScanType(logger *slog.Logger, env *ripspec.Envelope, i int, probe *ffprobe.Result) bool {
+	asset := &env.Assets.Ripped[i]
+	asset.Interlaced = probe.Interlaced()
+	fieldOrder := probe.VideoFieldOrder()
+	if !asset.Interlaced {
+		logger.Debug("progressive or unflagged source",
+			"decision_type", logs.DecisionInterlaceDetection,
+			"decision_result", "progressive",
+			"decision_reason", fmt.Sprintf("field_order=%q", fieldOrder),
+			"episode_key", asset.EpisodeKey,
+		)
+		return false
+	}
+	logger.Info("interlaced source detected",
+		"decision_type", logs.DecisionInterlaceDetection,
+		"decision_result", "flagged_for_review",
+		"decision_reason", fmt.Sprintf("field_order=%s (interlaced or telecined); the encoder cannot deinterlace, so the encode may show combing", fieldOrder),
+		"episode_key", asset.EpisodeKey,
+		"path", asset.Path,
+	)
+	return true
+}
+
+// flagInterlacedForReview routes an item with interlaced ripped sources to
+// review, marking each affected episode. The rip itself is kept.
+func flagInterlacedForReview(sess *stage.Session, keys []string) {
+	if len(keys) == 0 {
+		return
 	}
+	for _, key := range keys {
+		sess.AddEpisodeReviewReason(key, "Interlaced source")
+	}
+	sess.AddReviewReason(fmt.Sprintf("%d interlaced source(s); encode may show combing", len(keys)))
+}
 
-	return nil
+// checkRipRuntime fails when actualSeconds falls short of the scanned title
+// duration by more than ripRuntimeTolerance.
+func checkRipRuntime(actualSeconds float64, expectedSeconds int) error {
+	if expectedSeconds <= 0 {
+		return nil
+	}
+	expected := float64(expectedSeconds)
+	if actualSeconds >= expected*(1-ripRuntimeTolerance) {
+		return nil
+	}
+	return fmt.Errorf("is truncated: runtime %.0fs, scanned title %ds (%.0f%% short)",
+		actualSeconds, expectedSeconds, (expected-actualSeconds)/expected*100)
 }
 
+// titleDuration returns the scanned duration of titleID in seconds, or 0
+// when the title is unknown.
+func titleDuration(env *ripspec.Envelope, titleID int) int {
+	for _, t := range env.Titles {
+		if t.ID == titleID {
+			return t.Duration
+		}
+	}
+	return 0
+}
@@ -2,28 +2,38 @@ package ripper
 
 import (
 	"context"
+	"io"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/five82/spindle/internal/config"
+	"github.com/five82/spindle/internal/media/ffprobe"
+	"github.com/five82/spindle/internal/queue"
+	"github.com/five82/spindle/internal/ripspec"
+	"github.com/five82/spindle/internal/stage"
 )
 
 func TestValidateRippedArtifact_EmptyPath(t *testing.T) {
 	h := &Handler{}
-	if err := h.validateRippedArtifact(context.Background(), ""); err == nil {
+	if _, err := h.validateRippedArtifact(context.Background(), "", 0); err == nil {
 		t.Fatal("expected error for empty path")
 	}
 }
 
 func TestValidateRippedArtifact_NonExistent(t *testing.T) {
 	h := &Handler{}
-	if err := h.validateRippedArtifact(context.Background(), "/nonexistent/file.mkv"); err == nil {
+	if _, err := h.validateRippedArtifact(context.Background(), "/nonexistent/file.mkv", 0); err == nil {
 		t.Fatal("expected error for non-existent file")
 	}
 }
 
 func TestValidateRippedArtifact_Directory(t *testing.T) {
 	h := &Handler{}
-	if err := h.validateRippedArtifact(context.Background(), t.TempDir()); err == nil {
+	if _, err := h.validateRippedArtifact(context.Background(), t.TempDir(), 0); err == nil {
 		t.Fatal("expected error for directory")
 	}
 }
@@ -34,7 +44,99 @@ func TestValidateRippedArtifact_TooSmall(t *testing.T) {
 	if err := os.WriteFile(f, []byte("too small"), 0o644); err != nil {
 		t.Fatal(err)
 	}
-	if err := h.validateRippedArtifact(context.Background(), f); err == nil {
+	if _, err := h.validateRippedArtifact(context.Background(), f, 0); err == nil {
 		t.Fatal("expected error for file under 10 MB")
 	}
 }
+
+func TestValidateRippedArtifact_Runtime(t *testing.T) {
+	orig := inspectRip
+	t.Cleanup(func() { inspectRip = orig })
+	var gotTimeout time.Duration
+	inspectRip = func(_ context.Context, _, _ string, o ffprobe.Options) (*ffprobe.Result, error) {
+		gotTimeout = o.Timeout
+		return &ffprobe.Result{
+			Streams: []ffprobe.Stream{{CodecType: "video"}, {CodecType: "audio"}},
+			Format:  ffprobe.Format{Duration: "3000.0"},
+		}, nil
+	}
+	f := filepath.Join(t.TempDir(), "title_t00.mkv")
+	if err := os.WriteFile(f, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(f, minRipFileSizeBytes); err != nil {
+		t.Fatal(err)
+	}
+
+	h := &Handler{cfg: &config.Config{}}
+	h.cfg.Workflow.FFprobeTimeout = 120
+	_, err := h.validateRippedArtifact(context.Background(), f, 6000)
+	if err == nil || !strings.Contains(err.Error(), "truncated") {
+		t.Fatalf("half-length rip: err = %v, want truncation error", err)
+	}
+	if gotTimeout != 2*time.Minute {
+		t.Fatalf("ffprobe timeout = %s, want the configured 2m0s", gotTimeout)
+	}
+	if _, err := h.validateRippedArtifact(context.Background(), f, 3060); err != nil {
+		t.Fatalf("rip within tolerance: %v", err)
+	}
+	if _, err := h.validateRippedArtifact(context.Background(), f, 0); err != nil {
+		t.Fatalf("unknown title duration: %v", err)
+	}
+}
+
+func TestRecordScanTypeFlagsInterlacedSource(t *testing.T) {
+	env := &ripspec.Envelope{}
+	env.Assets.Ripped = []ripspec.Asset{
+		{EpisodeKey: "s01e01", Path: "/rip/a.mkv", Interlaced: true},
+		{EpisodeKey: "s01e02", Path: "/rip/b.mkv"},
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	probe := func(fieldOrder string) *ffprobe.Result {
+		return &ffprobe.Result{Streams: []ffprobe.Stream{{CodecType: "video", FieldOrder: fieldOrder}}}
+	}
+
+	if recordScanType(logger, env, 0, probe("progressive")) || env.Assets.Ripped[0].Interlaced {
+		t.Fatal("progressive source flagged as interlaced")
+	}
+	if !recordScanType(logger, env, 1, probe("tt")) || !env.Assets.Ripped[1].Interlaced {
+		t.Fatalf("interlaced source not flagged: %+v", env.Assets.Ripped[1])
+	}
+}
+
+func TestFlagInterlacedForReview(t *testing.T) {
+	env := &ripspec.Envelope{Episodes: []ripspec.Episode{{Key: "s01e01"}, {Key: "s01e02"}}}
+	sess := &stage.Session{Item: &queue.Item{}, Env: env}
+
+	flagInterlacedForReview(sess, nil)
+	if sess.Item.NeedsReview != 0 {
+		t.Fatal("item flagged for review without interlaced sources")
+	}
+	flagInterlacedForReview(sess, []string{"s01e02"})
+	if sess.Item.NeedsReview == 0 || !strings.Contains(sess.Item.ReviewReason, "1 interlaced source") {
+		t.Fatalf("item not routed to review: needs_review=%d reason=%q", sess.Item.NeedsReview, sess.Item.ReviewReason)
+	}
+	if env.Episodes[0].NeedsReview || !env.Episodes[1].NeedsReview || env.Episodes[1].ReviewReason != "Interlaced source" {
+		t.Fatalf("episode review flags = %+v", env.Episodes)
+	}
+}
+
+func TestCheckRipRuntime(t *testing.T) {
+	tests := []struct {
+		actual   float64
+		expected int
+		wantErr  bool
+	}{
+		{actual: 2580, expected: 2640, wantErr: false}, // 2.3% short
+		{actual: 2640, expected: 2640, wantErr: false},
+		{actual: 2700, expected: 2640, wantErr: false}, // longer is fine
+		{actual: 2400, expected: 2640, wantErr: true},  // 9% short
+		{actual: 100, expected: 0, wantErr: false},
+	}
+	for _, tt := range tests {
+		err := checkRipRuntime(tt.actual, tt.expected)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("checkRipRuntime(%v, %d) err = %v, wantErr %v", tt.actual, tt.expected, err, tt.wantErr)
+		}
+	}
+}
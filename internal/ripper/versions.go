@@ -0,0 +1,130 @@
+package ripper
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/five82/spindle/internal/config"
+	"github.com/five82/spindle/internal/ripspec"
+)
+
+// minVersionRuntimeRatio is how long a title must run, relative to the
+// longest feature, to count as another cut of the film rather than a bonus
+// feature or documentary.
+const minVersionRuntimeRatio = 0.7
+
+// detectMovieVersions clusters the disc's feature-length titles by runtime
+// and returns one title per cluster, longest first. Titles within
+// maxLanguageVariantRuntimeDiff of their neighbour are the same cut (language
+// variants, duplicate playlists); a wider gap starts another version. The
+// primary pick stands in for its own cluster; the other clusters are reduced
+// with the same funnel. It returns nil when the disc holds a single cut.
+func detectMovieVersions(titles []ripspec.Title, primary ripspec.Title) []ripspec.Title {
+	valid, _ := partitionValidTitles(titles)
+	longest := 0
+	for _, t := range valid {
+		longest = max(longest, t.Duration)
+	}
+	floor := max(minPrimaryRuntimeSeconds, int(float64(longest)*minVersionRuntimeRatio))
+	features := make([]ripspec.Title, 0, len(valid))
+	for _, t := range valid {
+		if t.Duration >= floor {
+			features = append(features, t)
+		}
+	}
+	sort.Slice(features, func(i, j int) bool {
+		if features[i].Duration == features[j].Duration {
+			return features[i].ID < features[j].ID
+		}
+		return features[i].Duration > features[j].Duration
+	})
+
+	var clusters [][]ripspec.Title
+	for _, t := range features {
+		if n := len(clusters); n > 0 {
+			last := clusters[n-1]
+			if last[len(last)-1].Duration-t.Duration <= maxLanguageVariantRuntimeDiff {
+				clusters[n-1] = append(last, t)
+				continue
+			}
+		}
+		clusters = append(clusters, []ripspec.Title{t})
+	}
+	if len(clusters) < 2 {
+		return nil
+	}
+
+	versions := make([]ripspec.Title, 0, len(clusters))
+	for _, cluster := range clusters {
+		pick, _, _ := choosePrimaryTitleTraced(cluster)
+		for _, t := range cluster {
+			if t.ID == primary.ID {
+				pick = primary
+				break
+			}
+		}
+		versions = append(versions, pick)
+	}
+	return versions
+}
+
+// movieVersionNames labels versions, longest first: the longest cut is
+// "Extended", the shortest "Theatrical", and any in between "Alternate",
+// numbered when there are several.
+func movieVersionNames(count int) []string {
+	names := make([]string, count)
+	for i := range names {
+		switch {
+		case i == 0:
+			names[i] = "Extended"
+		case i == count-1:
+			names[i] = "Theatrical"
+		case count == 3:
+			names[i] = "Alternate"
+		default:
+			names[i] = fmt.Sprintf("Alternate %d", i)
+		}
+	}
+	return names
+}
+
+// applyMovieVersionPolicy chooses which of versions (longest first, as
+// returned by detectMovieVersions) to rip under policy, records the choice
+// in env, and returns the titles to rip. The first kept cut is ripped under
+// the "main" key; keep_all rips the others under "edition_<name>" keys.
+func applyMovieVersionPolicy(env *ripspec.Envelope, versions []ripspec.Title, policy string) []ripspec.Title {
+	if policy == "" {
+		policy = config.MovieVersionsLongest
+	}
+	var keep []int
+	switch policy {
+	case config.MovieVersionsTheatrical:
+		keep = []int{len(versions) - 1}
+	case config.MovieVersionsKeepAll:
+		for i := range versions {
+			keep = append(keep, i)
+		}
+	default:
+		keep = []int{0}
+	}
+
+	names := movieVersionNames(len(versions))
+	choice := &ripspec.MovieVersionChoice{Policy: policy, Versions: make([]ripspec.Edition, len(versions))}
+	for i, t := range versions {
+		choice.Versions[i] = ripspec.Edition{TitleID: t.ID, Name: names[i], Duration: t.Duration}
+	}
+	env.Editions = nil
+	targets := make([]ripspec.Title, 0, len(keep))
+	for n, i := range keep {
+		key := ripspec.MainEditionKey
+		if n > 0 {
+			key = "edition_" + strings.ReplaceAll(strings.ToLower(names[i]), " ", "_")
+		}
+		choice.Versions[i].Key = key
+		env.Editions = append(env.Editions, choice.Versions[i])
+		targets = append(targets, versions[i])
+	}
+	env.Attributes.MovieVersions = choice
+	return targets
+}
@@ -0,0 +1,97 @@
+package ripper
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/five82/spindle/internal/config"
+	"github.com/five82/spindle/internal/ripspec"
+)
+
+// twoCutDisc holds a theatrical cut, an extended cut with a language-variant
+// duplicate, and a bonus documentary too short to be another cut.
+var twoCutDisc = []ripspec.Title{
+	{ID: 0, Duration: 6783, Chapters: 17, Playlist: "00800.mpls", SegmentCount: 17},
+	{ID: 1, Duration: 8100, Chapters: 20, Playlist: "00801.mpls", SegmentCount: 20},
+	{ID: 2, Duration: 8110, Chapters: 20, Playlist: "00802.mpls", SegmentCount: 20},
+	{ID: 3, Duration: 2700, Chapters: 5, Playlist: "00900.mpls", SegmentCount: 5},
+}
+
+func TestDetectMovieVersionsSeparatesCutsByRuntime(t *testing.T) {
+	primary, ok := ChoosePrimaryTitle(twoCutDisc)
+	if !ok {
+		t.Fatal("ChoosePrimaryTitle returned false")
+	}
+	versions := detectMovieVersions(twoCutDisc, primary)
+	var ids []int
+	for _, v := range versions {
+		ids = append(ids, v.ID)
+	}
+	if want := []int{primary.ID, 0}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("versions = %v, want %v (extended primary, then theatrical)", ids, want)
+	}
+
+	// Language variants a few seconds apart are one cut.
+	variants := []ripspec.Title{
+		{ID: 0, Duration: 7200, Playlist: "00800.mpls"},
+		{ID: 1, Duration: 7205, Playlist: "00801.mpls"},
+		{ID: 2, Duration: 1800, Playlist: "00900.mpls"},
+	}
+	if got := detectMovieVersions(variants, variants[0]); got != nil {
+		t.Fatalf("language variants = %+v, want a single cut", got)
+	}
+}
+
+func TestApplyMovieVersionPolicy(t *testing.T) {
+	versions := []ripspec.Title{{ID: 1, Duration: 8100}, {ID: 0, Duration: 6783}}
+	tests := []struct {
+		policy  string
+		wantIDs []int
+		keys    []string
+	}{
+		{policy: config.MovieVersionsLongest, wantIDs: []int{1}, keys: []string{"main"}},
+		{policy: config.MovieVersionsTheatrical, wantIDs: []int{0}, keys: []string{"main"}},
+		{policy: config.MovieVersionsKeepAll, wantIDs: []int{1, 0}, keys: []string{"main", "edition_theatrical"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			env := &ripspec.Envelope{Metadata: ripspec.Metadata{MediaType: "movie"}}
+			targets := applyMovieVersionPolicy(env, versions, tt.policy)
+			var ids []int
+			for _, target := range targets {
+				ids = append(ids, target.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Fatalf("targets = %v, want %v", ids, tt.wantIDs)
+			}
+			if got := env.AssetKeys(); !reflect.DeepEqual(got, tt.keys) {
+				t.Fatalf("AssetKeys() = %v, want %v", got, tt.keys)
+			}
+			choice := env.Attributes.MovieVersions
+			if choice == nil || choice.Policy != tt.policy || len(choice.Versions) != 2 {
+				t.Fatalf("recorded choice = %+v, want both versions under %s", choice, tt.policy)
+			}
+			if choice.Versions[0].Name != "Extended" || choice.Versions[1].Name != "Theatrical" {
+				t.Fatalf("version names = %q/%q, want Extended/Theatrical", choice.Versions[0].Name, choice.Versions[1].Name)
+			}
+		})
+	}
+}
+
+func TestSelectRipTargetsAppliesMovieVersionPolicy(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.MakeMKV.MovieVersions = config.MovieVersionsTheatrical
+	h := &Handler{cfg: cfg, titleOverride: -1}
+	env := &ripspec.Envelope{Metadata: ripspec.Metadata{MediaType: "movie"}, Titles: twoCutDisc}
+
+	targets, err := h.selectRipTargets(testLogger(), env)
+	if err != nil {
+		t.Fatalf("selectRipTargets: %v", err)
+	}
+	if len(targets) != 1 || targets[0].ID != 0 {
+		t.Fatalf("targets = %+v, want the theatrical title 0", targets)
+	}
+	if len(env.Editions) != 1 || env.Editions[0].Key != "main" || env.Editions[0].TitleID != 0 {
+		t.Fatalf("editions = %+v, want theatrical under main", env.Editions)
+	}
+}
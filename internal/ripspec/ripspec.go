@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+
+	"github.com/five82/spindle/internal/mediameta"
 )
 
 // CurrentVersion is the envelope schema version. Parse rejects any version
@@ -19,8 +21,44 @@ type Envelope struct {
 	Metadata    Metadata           `json:"metadata"`
 	Titles      []Title            `json:"titles"`
 	Episodes    []Episode          `json:"episodes"`
+	Editions    []Edition          `json:"editions,omitempty"`
 	Assets      Assets             `json:"assets"`
 	Attributes  EnvelopeAttributes `json:"attributes"`
+	Options     ItemOptions        `json:"options,omitzero"`
+}
+
+// ItemOptions are per-item processing choices made by the operator. They
+// live in the envelope so retries and reruns of the item honor them.
+type ItemOptions struct {
+	SkipSubtitles  bool `json:"skip_subtitles,omitempty"`
+	SkipCommentary bool `json:"skip_commentary,omitempty"`
+	// EncodeCRF encodes the item at this fixed CRF instead of Reel's
+	// target-quality search; lower is higher quality. Zero keeps the default.
+	EncodeCRF float64 `json:"encode_crf,omitempty"`
+	// PinnedTMDBID makes identification use this TMDB title directly instead
+	// of searching, so a corrected match survives re-identification.
+	// PinnedMediaType ("movie" or "tv") names the TMDB namespace of the ID.
+	PinnedTMDBID    int    `json:"pinned_tmdb_id,omitempty"`
+	PinnedMediaType string `json:"pinned_media_type,omitempty"`
+}
+
+// Validate checks option values: EncodeCRF must be zero or a Reel CRF,
+// 1-70 in 0.25 steps, and a TMDB pin needs a positive ID and a movie or tv
+// media type.
+func (o ItemOptions) Validate() error {
+	if o.EncodeCRF != 0 && (o.EncodeCRF < 1 || o.EncodeCRF > 70 || o.EncodeCRF*4 != float64(int(o.EncodeCRF*4))) {
+		return fmt.Errorf("encode CRF must be between 1 and 70 in 0.25 steps (got %g)", o.EncodeCRF)
+	}
+	if o.PinnedTMDBID < 0 {
+		return fmt.Errorf("pinned TMDB ID must be positive (got %d)", o.PinnedTMDBID)
+	}
+	if o.PinnedTMDBID > 0 && o.PinnedMediaType != "movie" && o.PinnedMediaType != "tv" {
+		return fmt.Errorf("pinned TMDB media type must be movie or tv (got %q)", o.PinnedMediaType)
+	}
+	if o.PinnedTMDBID == 0 && o.PinnedMediaType != "" {
+		return fmt.Errorf("pinned TMDB media type %q given without an ID", o.PinnedMediaType)
+	}
+	return nil
 }
 
 // Metadata holds content identification fields sourced from TMDB and disc info.
@@ -32,6 +70,7 @@ type Metadata struct {
 	ShowTitle    string  `json:"show_title,omitempty"`
 	SeriesTitle  string  `json:"series_title,omitempty"`
 	Year         string  `json:"year,omitempty"`
+	Genre        string  `json:"genre,omitempty"`
 	ReleaseDate  string  `json:"release_date,omitempty"`
 	FirstAirDate string  `json:"first_air_date,omitempty"`
 	IMDBID       string  `json:"imdb_id,omitempty"`
@@ -44,6 +83,40 @@ type Metadata struct {
 	Cached       bool    `json:"cached,omitempty"`
 	Filename     string  `json:"filename,omitempty"`
 	DiscSource   string  `json:"disc_source,omitempty"`
+	PosterPath   string  `json:"poster_path,omitempty"`
+	BackdropPath string  `json:"backdrop_path,omitempty"`
+}
+
+// QueueTitle renders the queue display title the way identification does:
+// "Title (Year)" for movies and "Show Season XX (Year)" for TV.
+func (m Metadata) QueueTitle() string {
+	title := m.Title
+	if m.MediaType == "tv" && m.SeasonNumber > 0 {
+		title = fmt.Sprintf("%s Season %02d", title, m.SeasonNumber)
+	}
+	if m.Year != "" {
+		return fmt.Sprintf("%s (%s)", title, m.Year)
+	}
+	return title
+}
+
+// ItemMetadataJSON returns the metadata_json a queue item stores for m: the
+// mediameta projection used for display and library paths.
+func (m Metadata) ItemMetadataJSON() (string, error) {
+	data, err := json.Marshal(mediameta.Metadata{
+		ID:           m.ID,
+		Title:        m.Title,
+		MediaType:    m.MediaType,
+		ShowTitle:    m.ShowTitle,
+		Year:         m.Year,
+		Genre:        m.Genre,
+		SeasonNumber: m.SeasonNumber,
+		Movie:        m.Movie,
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal metadata: %w", err)
+	}
+	return string(data), nil
 }
 
 // Title represents a MakeMKV title on the disc.
@@ -81,6 +154,20 @@ type Episode struct {
 	ReviewReason    string  `json:"review_reason,omitempty"`
 }
 
+// MainEditionKey is the asset key of a movie's primary cut; a movie with a
+// single cut carries only this key.
+const MainEditionKey = "main"
+
+// Edition is one full-length cut of a movie found on the disc, such as the
+// theatrical or extended version. Key is the asset key the cut is ripped
+// under; it is empty for a detected cut the configured policy left behind.
+type Edition struct {
+	Key      string `json:"key,omitempty"`
+	TitleID  int    `json:"title_id"`
+	Name     string `json:"name"`
+	Duration int    `json:"duration"`
+}
+
 // Asset represents a single file artifact at a pipeline stage.
 type Asset struct {
 	EpisodeKey     string `json:"episode_key"`
@@ -88,7 +175,13 @@ type Asset struct {
 	Path           string `json:"path"`
 	Status         string `json:"status"`
 	SubtitlesMuxed bool   `json:"subtitles_muxed,omitempty"`
-	ErrorMsg       string `json:"error_msg,omitempty"`
+	// Interlaced marks a ripped source stored as interlaced fields (or
+	// telecined), which needs deinterlacing the encoder does not apply.
+	Interlaced bool   `json:"interlaced,omitempty"`
+	ErrorMsg   string `json:"error_msg,omitempty"`
+	// NamedChapters marks an encoded asset that carries the source's named
+	// chapters; otherwise its chapters are numbered.
+	NamedChapters bool `json:"named_chapters,omitempty"`
 }
 
 // Asset status constants.
@@ -141,14 +234,25 @@ type ExcludedTrackRef struct {
 	Similarity float64 `json:"similarity,omitempty"`
 }
 
+// AudioDescriptionTrackRef identifies an audio-description track retained
+// as a labeled secondary track. GapRatio and Overlap are the speech-activity
+// measurements that identified it.
+type AudioDescriptionTrackRef struct {
+	Index    int     `json:"index"`
+	GapRatio float64 `json:"gap_ratio"`
+	Overlap  float64 `json:"overlap"`
+	Reason   string  `json:"reason"`
+}
+
 // EpisodeAudioAnalysis holds commentary detection results for one episode,
 // measured on the RIPPED source (track order and count are preserved by
 // encoding, so the indices remain valid on the encoded file until the apply
 // stage's refinement strips tracks).
 type EpisodeAudioAnalysis struct {
-	EpisodeKey       string               `json:"episode_key"`
-	CommentaryTracks []CommentaryTrackRef `json:"commentary_tracks,omitempty"`
-	ExcludedTracks   []ExcludedTrackRef   `json:"excluded_tracks,omitempty"`
+	EpisodeKey             string                     `json:"episode_key"`
+	CommentaryTracks       []CommentaryTrackRef       `json:"commentary_tracks,omitempty"`
+	ExcludedTracks         []ExcludedTrackRef         `json:"excluded_tracks,omitempty"`
+	AudioDescriptionTracks []AudioDescriptionTrackRef `json:"audio_description_tracks,omitempty"`
 }
 
 // AudioAnalysisData holds the results of audio track analysis. The
@@ -156,11 +260,44 @@ type EpisodeAudioAnalysis struct {
 // episodes (single entry for movies) and back the API/audit displays;
 // PerEpisode carries the per-key detail the apply stage uses.
 type AudioAnalysisData struct {
-	PrimaryTrack       AudioTrackRef          `json:"primary_track"`
-	PrimaryDescription string                 `json:"primary_description,omitempty"`
-	CommentaryTracks   []CommentaryTrackRef   `json:"commentary_tracks,omitempty"`
-	ExcludedTracks     []ExcludedTrackRef     `json:"excluded_tracks,omitempty"`
-	PerEpisode         []EpisodeAudioAnalysis `json:"per_episode,omitempty"`
+	PrimaryTrack           AudioTrackRef              `json:"primary_track"`
+	PrimaryDescription     string                     `json:"primary_description,omitempty"`
+	CommentaryTracks       []CommentaryTrackRef       `json:"commentary_tracks,omitempty"`
+	ExcludedTracks         []ExcludedTrackRef         `json:"excluded_tracks,omitempty"`
+	AudioDescriptionTracks []AudioDescriptionTrackRef `json:"audio_description_tracks,omitempty"`
+	PerEpisode             []EpisodeAudioAnalysis     `json:"per_episode,omitempty"`
+	Loudness               []LoudnessRecord           `json:"loudness,omitempty"`
+	CommentaryFormat       []CommentaryFormatRecord   `json:"commentary_format,omitempty"`
+}
+
+// CommentaryFormatRecord is one retained commentary track the apply stage
+// re-encoded to the configured commentary codec and channel layout.
+// TrackIndex is the audio-relative index after refinement.
+type CommentaryFormatRecord struct {
+	EpisodeKey     string `json:"episode_key"`
+	TrackIndex     int    `json:"track_index"`
+	SourceCodec    string `json:"source_codec"`
+	SourceChannels int    `json:"source_channels"`
+	Codec          string `json:"codec"`
+	Channels       int    `json:"channels"`
+	Bitrate        int64  `json:"bitrate"`
+}
+
+// LoudnessRecord is one loudnorm pass the apply stage made on an encoded
+// audio track: the first-pass measurement and the target it was normalized
+// to. TrackIndex is the audio-relative index after refinement.
+type LoudnessRecord struct {
+	EpisodeKey     string  `json:"episode_key"`
+	TrackIndex     int     `json:"track_index"`
+	Role           string  `json:"role"` // "primary" or "commentary"
+	MeasuredI      float64 `json:"measured_i"`
+	MeasuredTP     float64 `json:"measured_tp"`
+	MeasuredLRA    float64 `json:"measured_lra"`
+	MeasuredThresh float64 `json:"measured_thresh"`
+	Offset         float64 `json:"offset"`
+	TargetI        float64 `json:"target_i"`
+	TargetTP       float64 `json:"target_tp"`
+	TargetLRA      float64 `json:"target_lra"`
 }
 
 // EpisodeAnalysis returns the per-episode analysis entry for key, or nil.
@@ -184,6 +321,7 @@ type SubtitleGenRecord struct {
 	SubtitlePath      string   `json:"subtitle_path"`
 	Segments          int      `json:"segments"`
 	DurationSec       float64  `json:"duration_sec,omitempty"`
+	CoverageRatio     float64  `json:"coverage_ratio,omitempty"`
 	Language          string   `json:"language"`
 	ValidationResult  string   `json:"validation_result,omitempty"`
 	QCObservations    []string `json:"qc_observations,omitempty"`
@@ -192,6 +330,13 @@ type SubtitleGenRecord struct {
 	AuditResult       string   `json:"audit_result,omitempty"`
 	AuditEditsApplied int      `json:"audit_edits_applied,omitempty"`
 	AuditEditsDropped int      `json:"audit_edits_dropped,omitempty"`
+	// HearingImpaired marks subtitles with sound descriptions (SDH), which
+	// are delivered as a .hi sidecar and flagged when muxed.
+	HearingImpaired bool `json:"hearing_impaired,omitempty"`
+	// OpenSubtitlesFileID and OpenSubtitlesRank identify the downloaded
+	// candidate when Source is opensubtitles; rank 1 is the best match.
+	OpenSubtitlesFileID int `json:"opensubtitles_file_id,omitempty"`
+	OpenSubtitlesRank   int `json:"opensubtitles_rank,omitempty"`
 }
 
 // ContentIDSummary captures envelope-level provenance for the episode
@@ -216,6 +361,96 @@ type EnvelopeAttributes struct {
 	AudioAnalysis             *AudioAnalysisData  `json:"audio_analysis,omitempty"`
 	SubtitleGenerationResults []SubtitleGenRecord `json:"subtitle_generation_results,omitempty"`
 	ContentID                 *ContentIDSummary   `json:"content_id,omitempty"`
+	MatchCandidates           []MatchCandidate    `json:"match_candidates,omitempty"`
+	StageSkips                []StageSkip         `json:"stage_skips,omitempty"`
+	// ReplaceExisting lets the organizer overwrite library files the item
+	// would otherwise duplicate; set when a reviewer approves with replace.
+	ReplaceExisting bool `json:"replace_existing,omitempty"`
+	// MovieVersions records the cuts found on a multi-version movie disc
+	// and the policy that chose which to rip.
+	MovieVersions *MovieVersionChoice `json:"movie_versions,omitempty"`
+	// MatchLanguage is the TMDB language whose search produced the match,
+	// or "original" when it matched on an original-language title.
+	MatchLanguage string `json:"match_language,omitempty"`
+}
+
+// MovieVersionChoice records how a disc holding several full-length cuts
+// of a movie was ripped. Versions lists every cut detected, longest first;
+// the kept ones carry their asset key and also appear in Envelope.Editions.
+type MovieVersionChoice struct {
+	Policy   string    `json:"policy"`
+	Versions []Edition `json:"versions"`
+}
+
+// Stage skip categories.
+const (
+	SkipContentType       = "content_type"       // the stage does not apply to this media type
+	SkipDisabled          = "disabled"           // turned off in config or by an item option
+	SkipMissingDependency = "missing_dependency" // a required tool or client is unavailable
+)
+
+// StageSkip records why a stage, or one step of it, did not run, so the
+// pipeline's choices are visible without reading the logs.
+type StageSkip struct {
+	Stage    string `json:"stage"`
+	Step     string `json:"step,omitempty"` // empty when the whole stage was skipped
+	Category string `json:"category"`
+	Reason   string `json:"reason"`
+}
+
+// MatchCandidate is one TMDB search result identification considered. The
+// list is kept ranked best first, so a reviewer can pick the right title when
+// the best match was wrong or missing.
+type MatchCandidate struct {
+	ID           int     `json:"id"`
+	Score        float64 `json:"score"`
+	ExactMatch   bool    `json:"exact_match,omitempty"`
+	Title        string  `json:"title"`
+	MediaType    string  `json:"media_type,omitempty"`
+	Year         string  `json:"year,omitempty"`
+	ReleaseDate  string  `json:"release_date,omitempty"`
+	FirstAirDate string  `json:"first_air_date,omitempty"`
+	Overview     string  `json:"overview,omitempty"`
+	VoteAverage  float64 `json:"vote_average,omitempty"`
+	VoteCount    int     `json:"vote_count,omitempty"`
+	PosterPath   string  `json:"poster_path,omitempty"`
+	BackdropPath string  `json:"backdrop_path,omitempty"`
+	Genre        string  `json:"genre,omitempty"`
+}
+
+// Metadata returns the TMDB-sourced metadata for c, the fields
+// identification records for a matched title.
+func (c MatchCandidate) Metadata() Metadata {
+	meta := Metadata{
+		ID:           c.ID,
+		Title:        c.Title,
+		Overview:     c.Overview,
+		MediaType:    c.MediaType,
+		Year:         c.Year,
+		Genre:        c.Genre,
+		ReleaseDate:  c.ReleaseDate,
+		FirstAirDate: c.FirstAirDate,
+		VoteAverage:  c.VoteAverage,
+		VoteCount:    c.VoteCount,
+		Movie:        c.MediaType == "movie",
+		PosterPath:   c.PosterPath,
+		BackdropPath: c.BackdropPath,
+	}
+	if c.MediaType == "tv" {
+		meta.ShowTitle = c.Title
+	}
+	return meta
+}
+
+// Rematched returns c's metadata in place of m's TMDB-sourced fields,
+// keeping the disc-derived ones (language, season, disc number, source).
+func (m Metadata) Rematched(c MatchCandidate) Metadata {
+	out := c.Metadata()
+	out.Language = m.Language
+	out.SeasonNumber = m.SeasonNumber
+	out.DiscNumber = m.DiscNumber
+	out.DiscSource = m.DiscSource
+	return out
 }
 
 // ---------------------------------------------------------------------------
@@ -252,11 +487,31 @@ func (e *Envelope) Encode() (string, error) {
 	return string(data), nil
 }
 
+// RecordSkip stores skip, replacing an earlier record for the same stage and
+// step so a retried stage does not accumulate duplicates.
+func (e *Envelope) RecordSkip(skip StageSkip) {
+	for i, existing := range e.Attributes.StageSkips {
+		if existing.Stage == skip.Stage && existing.Step == skip.Step {
+			e.Attributes.StageSkips[i] = skip
+			return
+		}
+	}
+	e.Attributes.StageSkips = append(e.Attributes.StageSkips, skip)
+}
+
 // AssetKeys returns the episode keys for pipeline stages. Movies return
-// ["main"]; TV returns each episode's non-empty key.
+// ["main"], or each kept edition's key for a multi-version rip; TV returns
+// each episode's non-empty key.
 func (e *Envelope) AssetKeys() []string {
 	if e.Metadata.MediaType == "movie" {
-		return []string{"main"}
+		if len(e.Editions) == 0 {
+			return []string{MainEditionKey}
+		}
+		keys := make([]string, 0, len(e.Editions))
+		for _, ed := range e.Editions {
+			keys = append(keys, ed.Key)
+		}
+		return keys
 	}
 	keys := make([]string, 0, len(e.Episodes))
 	for _, ep := range e.Episodes {
@@ -279,10 +534,23 @@ func (e *Envelope) EpisodeByKey(key string) *Episode {
 	return nil
 }
 
-// ExpectedCount returns 1 for movies, len(Episodes) for TV content.
+// EditionByKey returns a pointer to the movie edition with the given asset
+// key (case-insensitive). Returns nil if not found.
+func (e *Envelope) EditionByKey(key string) *Edition {
+	lower := strings.ToLower(key)
+	for i := range e.Editions {
+		if strings.ToLower(e.Editions[i].Key) == lower {
+			return &e.Editions[i]
+		}
+	}
+	return nil
+}
+
+// ExpectedCount returns the number of kept editions (at least 1) for movies,
+// len(Episodes) for TV content.
 func (e *Envelope) ExpectedCount() int {
 	if e.Metadata.MediaType == "movie" {
-		return 1
+		return max(1, len(e.Editions))
 	}
 	return len(e.Episodes)
 }
@@ -455,6 +723,16 @@ func PlaceholderKey(season, discIndex int) string {
 	return fmt.Sprintf("s%02d_%03d", season, discIndex)
 }
 
+// SpecialsSeason is the season number TMDB and Plex use for specials and
+// bonus episodes. Placeholder episodes always carry the disc's own season,
+// so season 0 with a resolved episode number marks a special.
+const SpecialsSeason = 0
+
+// IsSpecial reports whether the entry resolved to a special (s00eNN).
+func (e Episode) IsSpecial() bool {
+	return e.Season == SpecialsSeason && e.Episode > 0
+}
+
 // EpisodeLast returns the last resolved episode number represented by the entry.
 // For single-episode entries this is Episode. Unresolved entries return 0.
 func (e Episode) EpisodeLast() int {
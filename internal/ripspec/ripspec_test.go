@@ -369,3 +369,57 @@ func TestCountUnresolvedEpisodes(t *testing.T) {
 		t.Errorf("CountUnresolvedEpisodes = %d, want 2", got)
 	}
 }
+
+func TestItemOptionsValidateEncodeCRF(t *testing.T) {
+	for crf, valid := range map[float64]bool{0: true, 1: true, 22.5: true, 70: true, 0.5: false, 71: false, 22.1: false, -1: false} {
+		err := ItemOptions{EncodeCRF: crf}.Validate()
+		if (err == nil) != valid {
+			t.Errorf("crf %g: err = %v, want valid=%t", crf, err, valid)
+		}
+	}
+}
+
+func TestItemOptionsValidateTMDBPin(t *testing.T) {
+	for _, tc := range []struct {
+		opts  ItemOptions
+		valid bool
+	}{
+		{ItemOptions{}, true},
+		{ItemOptions{PinnedTMDBID: 949, PinnedMediaType: "movie"}, true},
+		{ItemOptions{PinnedTMDBID: 1396, PinnedMediaType: "tv"}, true},
+		{ItemOptions{PinnedTMDBID: 949}, false},
+		{ItemOptions{PinnedTMDBID: 949, PinnedMediaType: "person"}, false},
+		{ItemOptions{PinnedTMDBID: -1, PinnedMediaType: "movie"}, false},
+		{ItemOptions{PinnedMediaType: "movie"}, false},
+	} {
+		if err := tc.opts.Validate(); (err == nil) != tc.valid {
+			t.Errorf("%+v: err = %v, want valid=%t", tc.opts, err, tc.valid)
+		}
+	}
+}
+
+func TestRecordSkipReplacesSameStageAndStep(t *testing.T) {
+	var env Envelope
+	env.RecordSkip(StageSkip{Stage: "subtitling", Category: SkipDisabled, Reason: "subtitles.enabled = false"})
+	env.RecordSkip(StageSkip{Stage: "analysis", Step: "commentary_detection", Category: SkipMissingDependency, Reason: "LLM client not configured"})
+	env.RecordSkip(StageSkip{Stage: "subtitling", Category: SkipDisabled, Reason: "item option skip_subtitles set"})
+
+	skips := env.Attributes.StageSkips
+	if len(skips) != 2 {
+		t.Fatalf("stage skips = %+v, want one per stage and step", skips)
+	}
+	if skips[0].Reason != "item option skip_subtitles set" {
+		t.Fatalf("retried skip not replaced: %+v", skips[0])
+	}
+}
+
+func TestRematchedKeepsDiscFields(t *testing.T) {
+	old := Metadata{ID: 1, Title: "Wrong", MediaType: "movie", Movie: true, Language: "de",
+		SeasonNumber: 2, DiscNumber: 3, DiscSource: "bluray", Genre: "Drama"}
+	got := old.Rematched(MatchCandidate{ID: 1396, Title: "Breaking Bad", MediaType: "tv", Year: "2008", Genre: "Crime", Score: 1.7})
+	want := Metadata{ID: 1396, Title: "Breaking Bad", ShowTitle: "Breaking Bad", MediaType: "tv", Year: "2008", Genre: "Crime",
+		Language: "de", SeasonNumber: 2, DiscNumber: 3, DiscSource: "bluray"}
+	if got != want {
+		t.Fatalf("Rematched = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,77 @@
+package srtsync
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/five82/spindle/internal/srtutil"
+)
+
+// referenceFrameRates are the release rates a downloaded subtitle is
+// commonly timed against: NTSC film, true film, PAL, and NTSC video.
+var referenceFrameRates = []float64{24000.0 / 1001, 24, 25, 30000.0 / 1001}
+
+const (
+	// sameRateTolerance treats rates within 0.1% as identical.
+	sameRateTolerance = 0.001
+	// rateMatchMargin is how many more cues a rescaled reference must align
+	// than the unscaled one before a rate conversion is trusted.
+	rateMatchMargin = 1.5
+)
+
+// FrameRateConversion describes the rate conversion applied to a reference
+// subtitle. Factor multiplies reference timestamps: a subtitle timed for
+// SourceFPS played against a TargetFPS rip stretches by SourceFPS/TargetFPS.
+type FrameRateConversion struct {
+	SourceFPS float64
+	TargetFPS float64
+	Factor    float64
+	Applied   bool
+	Reason    string
+}
+
+// DetectFrameRateConversion finds the release rate the reference was timed
+// for by rescaling it from each common rate to videoFPS and keeping the rate
+// whose timings align with the most transcript cues. A rate mismatch shows
+// up as progressive drift, so no single offset aligns the unscaled
+// reference while the correctly rescaled one lines up throughout.
+func DetectFrameRateConversion(reference, transcript []srtutil.Cue, videoFPS float64) FrameRateConversion {
+	result := FrameRateConversion{SourceFPS: videoFPS, TargetFPS: videoFPS, Factor: 1}
+	if videoFPS <= 0 {
+		result.Reason = "video frame rate unknown"
+		return result
+	}
+	baseline := EstimateSyncOffset(reference, transcript).Matched
+	bestMatched := 0
+	for _, rate := range referenceFrameRates {
+		factor := rate / videoFPS
+		if math.Abs(factor-1) < sameRateTolerance {
+			continue
+		}
+		matched := EstimateSyncOffset(RescaleCues(reference, factor), transcript).Matched
+		if matched > bestMatched {
+			bestMatched = matched
+			result.SourceFPS, result.Factor = rate, factor
+		}
+	}
+	if bestMatched < minSyncMatches || float64(bestMatched) < rateMatchMargin*float64(baseline) {
+		result.SourceFPS, result.Factor = videoFPS, 1
+		result.Reason = fmt.Sprintf("reference matches video rate (%d cues aligned unscaled)", baseline)
+		return result
+	}
+	result.Applied = true
+	result.Reason = fmt.Sprintf("%d cues aligned rescaled vs %d unscaled", bestMatched, baseline)
+	return result
+}
+
+// RescaleCues returns a copy of cues with every timestamp multiplied by
+// factor.
+func RescaleCues(cues []srtutil.Cue, factor float64) []srtutil.Cue {
+	scaled := make([]srtutil.Cue, len(cues))
+	for i, cue := range cues {
+		cue.Start *= factor
+		cue.End *= factor
+		scaled[i] = cue
+	}
+	return scaled
+}
@@ -0,0 +1,51 @@
+package srtsync
+
+import (
+	"math"
+	"testing"
+
+	"github.com/five82/spindle/internal/srtutil"
+)
+
+const ntscFilm = 24000.0 / 1001
+
+func TestRescaleCuesPALToNTSCFilm(t *testing.T) {
+	cues := []srtutil.Cue{{Index: 1, Start: 1000, End: 1002, Text: "line"}}
+	got := RescaleCues(cues, 25/ntscFilm)
+	if math.Abs(got[0].Start-1042.708) > 0.001 || math.Abs(got[0].End-1044.793) > 0.001 {
+		t.Fatalf("rescaled cue = %.3f-%.3f, want 1042.708-1044.793", got[0].Start, got[0].End)
+	}
+	if cues[0].Start != 1000 {
+		t.Fatal("RescaleCues must not modify its input")
+	}
+}
+
+func TestDetectFrameRateConversionPALReference(t *testing.T) {
+	transcript := syncTestTranscript(400)
+	// A 25 fps release runs 4% fast, so its subtitle timings are compressed
+	// relative to a 23.976 fps rip.
+	reference := RescaleCues(transcript, ntscFilm/25)
+
+	got := DetectFrameRateConversion(reference, transcript, ntscFilm)
+	if !got.Applied {
+		t.Fatalf("conversion not applied: %+v", got)
+	}
+	if got.SourceFPS != 25 || got.TargetFPS != ntscFilm {
+		t.Fatalf("rates = %.3f -> %.3f, want 25 -> 23.976", got.SourceFPS, got.TargetFPS)
+	}
+	corrected := RescaleCues(reference, got.Factor)
+	for i, cue := range corrected {
+		if math.Abs(cue.Start-transcript[i].Start) > 0.01 {
+			t.Fatalf("cue %d start = %.3f, want %.3f", i+1, cue.Start, transcript[i].Start)
+		}
+	}
+}
+
+func TestDetectFrameRateConversionMatchingRate(t *testing.T) {
+	transcript := syncTestTranscript(200)
+	reference := ShiftCues(transcript, -3)
+	got := DetectFrameRateConversion(reference, transcript, ntscFilm)
+	if got.Applied || got.Factor != 1 {
+		t.Fatalf("same-rate reference = %+v, want no conversion", got)
+	}
+}
@@ -0,0 +1,199 @@
+// Package srtsync aligns a reference subtitle to the WhisperX transcript of
+// a rip: it detects a frame-rate mismatch, rescales the reference, and then
+// estimates and applies a constant offset. It is shared by the stages that
+// compare or publish downloaded subtitles.
+package srtsync
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/five82/spindle/internal/media/ffprobe"
+	"github.com/five82/spindle/internal/srtutil"
+)
+
+var inspectVideo = ffprobe.Inspect
+
+const (
+	// maxSyncOffsetSec bounds the offsets considered when aligning a
+	// reference subtitle. Edition and framerate mismatches that produce a
+	// constant offset stay well inside two minutes.
+	maxSyncOffsetSec = 120.0
+	// syncBinSec is the histogram resolution of the coarse offset search.
+	syncBinSec = 0.25
+	// syncToleranceSec is how close a shifted reference cue start must land
+	// to a transcript cue start to count as a match.
+	syncToleranceSec = 0.5
+	// minSyncMatches and minSyncMatchFraction gate how much of the reference
+	// must agree on one offset before it is trusted.
+	minSyncMatches       = 10
+	minSyncMatchFraction = 0.3
+	// minSyncShiftSec is the smallest offset worth applying; anything below
+	// it is within normal subtitle timing slop.
+	minSyncShiftSec = 0.2
+)
+
+// SyncOffset describes the global offset estimated between a reference
+// subtitle and the WhisperX transcript of the ripped file. Offset is added
+// to reference timestamps to align them with the rip.
+type SyncOffset struct {
+	Offset  float64
+	Matched int
+	Total   int
+	Applied bool
+	Reason  string
+}
+
+// EstimateSyncOffset correlates reference cue start times against
+// transcript cue start times and returns the constant offset most cues
+// agree on. Each (reference, transcript) start pair within
+// maxSyncOffsetSec votes for its delta; the densest delta bin wins and is
+// refined to the median delta of the cues it matches. Applied is false when
+// too few cues agree or the offset is below minSyncShiftSec.
+func EstimateSyncOffset(reference, transcript []srtutil.Cue) SyncOffset {
+	result := SyncOffset{Total: len(reference)}
+	if len(transcript) == 0 {
+		result.Reason = "no transcript cues"
+		return result
+	}
+	if len(reference) < minSyncMatches {
+		result.Reason = "too few reference cues"
+		return result
+	}
+
+	starts := make([]float64, len(transcript))
+	for i, cue := range transcript {
+		starts[i] = cue.Start
+	}
+	sort.Float64s(starts)
+
+	bins := int(math.Round(2*maxSyncOffsetSec/syncBinSec)) + 1
+	votes := make([]int, bins)
+	for _, cue := range reference {
+		lo := sort.SearchFloat64s(starts, cue.Start-maxSyncOffsetSec)
+		for j := lo; j < len(starts) && starts[j] <= cue.Start+maxSyncOffsetSec; j++ {
+			votes[int(math.Round((starts[j]-cue.Start+maxSyncOffsetSec)/syncBinSec))]++
+		}
+	}
+	// Smooth over neighbouring bins so a true offset that straddles a bin
+	// edge is not outvoted by a sharper coincidental peak.
+	bestBin, bestVotes := 0, -1
+	for i := range votes {
+		sum := votes[i]
+		if i > 0 {
+			sum += votes[i-1]
+		}
+		if i+1 < len(votes) {
+			sum += votes[i+1]
+		}
+		if sum > bestVotes {
+			bestBin, bestVotes = i, sum
+		}
+	}
+	coarse := float64(bestBin)*syncBinSec - maxSyncOffsetSec
+
+	var deltas []float64
+	for _, cue := range reference {
+		if delta, ok := nearestDelta(starts, cue.Start+coarse); ok {
+			deltas = append(deltas, coarse+delta)
+		}
+	}
+	result.Matched = len(deltas)
+	if len(deltas) < minSyncMatches || float64(len(deltas)) < minSyncMatchFraction*float64(len(reference)) {
+		result.Reason = fmt.Sprintf("no consistent offset (%d/%d cues matched)", len(deltas), len(reference))
+		return result
+	}
+	sort.Float64s(deltas)
+	result.Offset = deltas[len(deltas)/2]
+	if len(deltas)%2 == 0 {
+		result.Offset = (deltas[len(deltas)/2-1] + deltas[len(deltas)/2]) / 2
+	}
+	if math.Abs(result.Offset) < minSyncShiftSec {
+		result.Reason = "already in sync"
+		return result
+	}
+	result.Applied = true
+	result.Reason = fmt.Sprintf("%d/%d cues agree", len(deltas), len(reference))
+	return result
+}
+
+// nearestDelta returns the signed distance from at to the closest sorted
+// start within syncToleranceSec.
+func nearestDelta(starts []float64, at float64) (float64, bool) {
+	i := sort.SearchFloat64s(starts, at)
+	best, ok := 0.0, false
+	for _, j := range []int{i - 1, i} {
+		if j < 0 || j >= len(starts) {
+			continue
+		}
+		delta := starts[j] - at
+		if math.Abs(delta) <= syncToleranceSec && (!ok || math.Abs(delta) < math.Abs(best)) {
+			best, ok = delta, true
+		}
+	}
+	return best, ok
+}
+
+// ShiftCues returns a copy of cues moved by offset seconds. Cues pushed
+// entirely before zero are dropped and a straddling cue starts at zero.
+// Indexes are renumbered from 1.
+func ShiftCues(cues []srtutil.Cue, offset float64) []srtutil.Cue {
+	shifted := make([]srtutil.Cue, 0, len(cues))
+	for _, cue := range cues {
+		cue.Start += offset
+		cue.End += offset
+		if cue.End <= 0 {
+			continue
+		}
+		cue.Start = max(cue.Start, 0)
+		cue.Index = len(shifted) + 1
+		shifted = append(shifted, cue)
+	}
+	return shifted
+}
+
+// ReferenceSync records the corrections applied to a reference subtitle:
+// a frame-rate rescale first, then a constant offset.
+type ReferenceSync struct {
+	Rate   FrameRateConversion
+	Offset SyncOffset
+}
+
+// SyncReferenceCues aligns reference cues to the WhisperX transcript at
+// transcriptPath. When videoPath is set its frame rate is probed so a
+// reference timed for another release rate is rescaled before the offset
+// search. The reference is returned unchanged, with the reason in the
+// result, when the transcript is unavailable or no reliable correction is
+// found.
+func SyncReferenceCues(ctx context.Context, reference []srtutil.Cue, transcriptPath, videoPath string) ([]srtutil.Cue, ReferenceSync) {
+	sync := ReferenceSync{
+		Rate:   FrameRateConversion{Factor: 1, Reason: "video frame rate unknown"},
+		Offset: SyncOffset{Total: len(reference)},
+	}
+	if transcriptPath == "" {
+		sync.Offset.Reason = "whisperx transcript unavailable"
+		return reference, sync
+	}
+	transcript, err := srtutil.ParseFile(transcriptPath)
+	if err != nil {
+		sync.Offset.Reason = "whisperx transcript unavailable"
+		return reference, sync
+	}
+
+	if videoPath != "" {
+		if probe, err := inspectVideo(ctx, "", videoPath); err == nil {
+			sync.Rate = DetectFrameRateConversion(reference, transcript, probe.VideoFrameRate())
+		}
+	}
+	if sync.Rate.Applied {
+		reference = RescaleCues(reference, sync.Rate.Factor)
+	}
+
+	sync.Offset = EstimateSyncOffset(reference, transcript)
+	if sync.Offset.Applied {
+		reference = ShiftCues(reference, sync.Offset.Offset)
+	}
+	return reference, sync
+}
@@ -0,0 +1,115 @@
+package srtsync
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/five82/spindle/internal/srtutil"
+)
+
+// syncTestTranscript builds irregularly spaced cues so only the true
+// alignment lines up many start times at once.
+func syncTestTranscript(n int) []srtutil.Cue {
+	rng := rand.New(rand.NewPCG(1, 2))
+	cues := make([]srtutil.Cue, 0, n)
+	at := 30.0
+	for i := range n {
+		at += 1.5 + rng.Float64()*4.5
+		cues = append(cues, srtutil.Cue{Index: i + 1, Start: at, End: at + 1.2, Text: "line"})
+	}
+	return cues
+}
+
+func TestEstimateSyncOffsetDetectsKnownOffset(t *testing.T) {
+	transcript := syncTestTranscript(200)
+	const trueOffset = 4.3
+
+	// The reference runs early by trueOffset, jitters a little, and skips
+	// every fifth line the transcript has.
+	var reference []srtutil.Cue
+	for i, cue := range transcript {
+		if i%5 == 0 {
+			continue
+		}
+		jitter := float64(i%3-1) * 0.08
+		reference = append(reference, srtutil.Cue{
+			Index: len(reference) + 1,
+			Start: cue.Start - trueOffset + jitter,
+			End:   cue.End - trueOffset + jitter,
+			Text:  cue.Text,
+		})
+	}
+
+	got := EstimateSyncOffset(reference, transcript)
+	if !got.Applied {
+		t.Fatalf("offset not applied: %+v", got)
+	}
+	if math.Abs(got.Offset-trueOffset) > 0.1 {
+		t.Fatalf("offset = %.3f, want %.1f +/- 0.1", got.Offset, trueOffset)
+	}
+
+	shifted := ShiftCues(reference, got.Offset)
+	if len(shifted) != len(reference) {
+		t.Fatalf("shifted cues = %d, want %d", len(shifted), len(reference))
+	}
+	for i, cue := range shifted {
+		want := transcript[i+1+i/4].Start
+		if math.Abs(cue.Start-want) > 0.2 {
+			t.Fatalf("cue %d start = %.3f, want %.3f +/- 0.2", i+1, cue.Start, want)
+		}
+	}
+}
+
+func TestEstimateSyncOffsetLeavesAlignedReference(t *testing.T) {
+	transcript := syncTestTranscript(50)
+	got := EstimateSyncOffset(transcript, transcript)
+	if got.Applied || got.Reason != "already in sync" {
+		t.Fatalf("aligned reference = %+v, want unapplied already in sync", got)
+	}
+}
+
+func TestSyncReferenceCuesSkipsWithoutTranscript(t *testing.T) {
+	reference := syncTestTranscript(20)
+	got, sync := SyncReferenceCues(context.Background(), reference, filepath.Join(t.TempDir(), "missing.srt"), "")
+	if offset := sync.Offset; offset.Applied || offset.Reason != "whisperx transcript unavailable" {
+		t.Fatalf("offset = %+v, want skip note", sync.Offset)
+	}
+	if len(got) != len(reference) || got[0].Start != reference[0].Start {
+		t.Fatal("reference should be returned unchanged")
+	}
+
+	path := filepath.Join(t.TempDir(), "audio.srt")
+	shiftedTranscript := ShiftCues(reference, 12)
+	if err := os.WriteFile(path, []byte(srtutil.Format(shiftedTranscript)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, sync = SyncReferenceCues(context.Background(), reference, path, "")
+	if offset := sync.Offset; !offset.Applied || math.Abs(offset.Offset-12) > 0.01 {
+		t.Fatalf("offset = %+v, want 12s applied", sync.Offset)
+	}
+	if math.Abs(got[0].Start-shiftedTranscript[0].Start) > 0.01 {
+		t.Fatalf("first cue start = %.3f, want %.3f", got[0].Start, shiftedTranscript[0].Start)
+	}
+}
+
+func TestShiftCuesDropsCuesBeforeZero(t *testing.T) {
+	cues := []srtutil.Cue{
+		{Index: 1, Start: 1, End: 2, Text: "gone"},
+		{Index: 2, Start: 2.5, End: 4, Text: "clamped"},
+		{Index: 3, Start: 5, End: 6, Text: "kept"},
+	}
+	got := ShiftCues(cues, -3)
+	if len(got) != 2 {
+		t.Fatalf("cues = %d, want 2", len(got))
+	}
+	if got[0].Start != 0 || got[0].Index != 1 || got[0].Text != "clamped" {
+		t.Fatalf("first cue = %+v", got[0])
+	}
+	if got[1].Start != 2 || got[1].Index != 2 {
+		t.Fatalf("second cue = %+v", got[1])
+	}
+}
@@ -1,6 +1,9 @@
 package stage
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 // ErrDegraded indicates a stage completed with degraded behavior. Workflow can
 // treat this as a successful stage while logging the degradation.
@@ -17,3 +20,28 @@ func (e *ErrDegraded) Error() string {
 }
 
 func (e *ErrDegraded) Unwrap() error { return e.Cause }
+
+// ErrFatal marks a stage failure that running the stage again cannot fix,
+// such as a TV disc with no TMDB match. Workflow fails the item without
+// spending the stage's retry budget; every other failure is retryable.
+type ErrFatal struct {
+	Cause error
+}
+
+func (e *ErrFatal) Error() string { return e.Cause.Error() }
+
+func (e *ErrFatal) Unwrap() error { return e.Cause }
+
+// Fatal wraps err as an ErrFatal. A nil err stays nil.
+func Fatal(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ErrFatal{Cause: err}
+}
+
+// IsFatal reports whether err is classified as fatal.
+func IsFatal(err error) bool {
+	var fatal *ErrFatal
+	return errors.As(err, &fatal)
+}
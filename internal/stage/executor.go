@@ -22,6 +22,10 @@ type WorkflowOptions struct {
 	// Task is the scheduler task this execution runs; the session reports
 	// progress against its row. Nil (OneShot) means in-memory progress only.
 	Task *queue.Task
+	// Retry, when set, reports whether a failure should be retried instead
+	// of failing the item. A retried failure only clears in_progress and
+	// sets ExecuteResult.Retrying; the caller re-queues the task.
+	Retry func(error) bool
 }
 
 // ExecuteResult describes the queue-visible outcome of a stage invocation.
@@ -31,6 +35,7 @@ type ExecuteResult struct {
 	DegradedMsg string
 	Canceled    bool
 	Failed      bool
+	Retrying    bool
 	UserStopped bool
 }
 
@@ -111,6 +116,14 @@ func ExecuteWorkflowStage(ctx context.Context, item *queue.Item, opts WorkflowOp
 				}
 				return res, fmt.Errorf("stage %s: %w", stageName, err)
 			}
+			if opts.Retry != nil && opts.Retry(err) {
+				res.Failed = false
+				res.Retrying = true
+				if updateErr := opts.Store.ClearInProgress(item); updateErr != nil {
+					return res, &PersistenceError{Op: "clear in_progress before retry", Err: updateErr}
+				}
+				return res, err
+			}
 			if updateErr := opts.Store.FailStage(item, stageName, err.Error()); updateErr != nil {
 				return res, &PersistenceError{Op: "persist stage failure", Err: updateErr}
 			}
@@ -55,6 +55,29 @@ func TestExecuteWorkflowStageMarksFailure(t *testing.T) {
 	}
 }
 
+func TestExecuteWorkflowStageRetryLeavesItemAtStage(t *testing.T) {
+	store := openExecutorTestStore(t)
+	item, _ := store.NewDisc("A", "fp1")
+	if err := store.StartStage(item); err != nil {
+		t.Fatalf("StartStage: %v", err)
+	}
+	stageErr := errors.New("boom")
+
+	res, err := ExecuteWorkflowStage(context.Background(), item, WorkflowOptions{
+		Store:   store,
+		Handler: executorStubHandler{run: func(context.Context, *Session) error { return stageErr }},
+		Stage:   queue.StageIdentification,
+		Retry:   func(err error) bool { return !IsFatal(err) },
+	})
+	if !errors.Is(err, stageErr) || !res.Retrying || res.Failed {
+		t.Fatalf("result err=%v retrying=%v failed=%v, want stage error and retrying", err, res.Retrying, res.Failed)
+	}
+	got, _ := store.GetByID(item.ID)
+	if got.Stage != queue.StageIdentification || got.InProgress != 0 || got.ErrorMessage != "" {
+		t.Fatalf("retry state = stage:%q in_progress:%d err:%q", got.Stage, got.InProgress, got.ErrorMessage)
+	}
+}
+
 func TestExecuteWorkflowStageTreatsDegradedAsSuccess(t *testing.T) {
 	store := openExecutorTestStore(t)
 	item, err := store.NewDisc("A", "fp1")
@@ -2,9 +2,12 @@ package stage
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/five82/spindle/internal/queue"
 	"github.com/five82/spindle/internal/ripspec"
@@ -25,6 +28,15 @@ type Session struct {
 	// never contend. A detached task (ID 0) keeps progress in memory only
 	// (OneShot CLI execution, where no scheduler task exists).
 	Task *queue.Task
+
+	// progressMu is shared by a session and its forks so concurrent
+	// workers serialize writes to the shared Task; nil when never forked.
+	progressMu *sync.Mutex
+	forked     bool
+	// lastProgress, shared by a session and its forks, holds the Unix
+	// nanoseconds of the last progress update that changed the task's
+	// percent, message, or byte count; zero until the first such change.
+	lastProgress *atomic.Int64
 }
 
 // NewSession creates a stage session and parses the item's RipSpec envelope.
@@ -53,9 +65,35 @@ func NewSession(ctx context.Context, store *queue.Store, item *queue.Item, task
 		Env:    &env,
 		Logger: slog.Default(),
 		Task:   task,
+
+		lastProgress: &atomic.Int64{},
 	}, nil
 }
 
+// Fork returns a session for one of several concurrent workers of the same
+// stage. The fork has its own copy of the envelope, so merge operations on
+// different forks never share memory; the item and task are shared, with
+// progress writes serialized and never moving the percentage backwards.
+// Forks must persist envelope changes only through merge operations, and
+// the parent should RefreshEnvelope once its workers finish.
+func (s *Session) Fork() (*Session, error) {
+	data, err := json.Marshal(s.Env)
+	if err != nil {
+		return nil, fmt.Errorf("fork session: %w", err)
+	}
+	var env ripspec.Envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("fork session: %w", err)
+	}
+	if s.progressMu == nil {
+		s.progressMu = &sync.Mutex{}
+	}
+	fork := *s
+	fork.Env = &env
+	fork.forked = true
+	return &fork, nil
+}
+
 // SetEnvelope replaces the session's RipSpec envelope.
 func (s *Session) SetEnvelope(env *ripspec.Envelope) {
 	if env == nil {
@@ -145,6 +183,24 @@ func (s *Session) MergeSave(mutate func(*ripspec.Envelope) error) error {
 	return nil
 }
 
+// RecordSkip persists why stage work did not run through a merge save, so it
+// is safe while other stages of the item run. The record is informational:
+// a failed save is logged and never fails the stage.
+func (s *Session) RecordSkip(skip ripspec.StageSkip) {
+	err := s.MergeSave(func(env *ripspec.Envelope) error {
+		env.RecordSkip(skip)
+		return nil
+	})
+	if err != nil && s.Logger != nil {
+		s.Logger.Warn("failed to record stage skip",
+			"event_type", "stage_skip_persist_error",
+			"error_hint", err.Error(),
+			"impact", "skip reason missing from the rip spec",
+			"skip_stage", skip.Stage,
+		)
+	}
+}
+
 // RefreshEnvelope reloads the item's envelope from the store under the
 // per-item lock, adopting fresh state as the session's view. ONLY safe for
 // handlers whose every envelope write goes through merge operations: any
@@ -232,6 +288,7 @@ type progressUpdate struct {
 	activeEpisode *string
 	bytesCopied   *int64
 	totalBytes    *int64
+	eta           *time.Duration
 	encodingJSON  *string
 }
 
@@ -248,6 +305,12 @@ func WithProgressBytes(copied, total int64) ProgressOption {
 	}
 }
 
+// WithProgressETA sets the estimated time remaining during a progress
+// update; 0 means unknown.
+func WithProgressETA(eta time.Duration) ProgressOption {
+	return func(u *progressUpdate) { u.eta = &eta }
+}
+
 // WithEncodingDetails sets the encoded telemetry JSON during a progress update.
 func WithEncodingDetails(json string) ProgressOption {
 	return func(u *progressUpdate) { u.encodingJSON = &json }
@@ -264,6 +327,17 @@ func (s *Session) Progress(percent float64, message string, opts ...ProgressOpti
 	for _, opt := range opts {
 		opt(&update)
 	}
+	if s.progressMu != nil {
+		s.progressMu.Lock()
+		defer s.progressMu.Unlock()
+	}
+	if s.forked {
+		percent = max(percent, s.Task.ProgressPercent)
+	}
+	if s.lastProgress != nil && (percent != s.Task.ProgressPercent || message != s.Task.ProgressMessage ||
+		(update.bytesCopied != nil && *update.bytesCopied != s.Task.ProgressBytesCopied)) {
+		s.lastProgress.Store(time.Now().UnixNano())
+	}
 
 	s.Task.ProgressPercent = percent
 	s.Task.ProgressMessage = message
@@ -276,6 +350,9 @@ func (s *Session) Progress(percent float64, message string, opts ...ProgressOpti
 	if update.totalBytes != nil {
 		s.Task.ProgressTotalBytes = *update.totalBytes
 	}
+	if update.eta != nil {
+		s.Task.ProgressETASeconds = update.eta.Seconds()
+	}
 	if update.encodingJSON != nil {
 		s.Item.EncodingDetailsJSON = *update.encodingJSON
 		if err := s.Store.UpdateEncodingDetails(s.Item); err != nil {
@@ -288,6 +365,20 @@ func (s *Session) Progress(percent float64, message string, opts ...ProgressOpti
 	return s.Store.UpdateTaskProgress(s.Task)
 }
 
+// LastProgress returns when a progress update last changed the task's
+// percent, message, or byte count, or the zero time before the first change.
+// Updates that repeat the current values do not count, so a stage that only
+// re-reports frozen progress looks stalled.
+func (s *Session) LastProgress() time.Time {
+	if s.lastProgress == nil {
+		return time.Time{}
+	}
+	if ns := s.lastProgress.Load(); ns != 0 {
+		return time.Unix(0, ns)
+	}
+	return time.Time{}
+}
+
 // SetActiveEpisode persists a change to the task's active asset key without
 // changing the current percent or message.
 func (s *Session) SetActiveEpisode(key string) error {
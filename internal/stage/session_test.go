@@ -4,7 +4,9 @@ import (
 	"context"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/five82/spindle/internal/queue"
 	"github.com/five82/spindle/internal/ripspec"
@@ -127,6 +129,83 @@ func TestSessionProgressDetachedTaskStaysInMemory(t *testing.T) {
 	}
 }
 
+func TestSessionLastProgressIgnoresRepeatedUpdates(t *testing.T) {
+	_, _, s := newTestSession(t)
+	if !s.LastProgress().IsZero() {
+		t.Fatal("LastProgress set before any progress")
+	}
+	if err := s.Progress(10, "Ripping"); err != nil {
+		t.Fatalf("Progress: %v", err)
+	}
+	first := s.LastProgress()
+	if first.IsZero() {
+		t.Fatal("LastProgress not set by a progress change")
+	}
+
+	fork, err := s.Fork()
+	if err != nil {
+		t.Fatalf("Fork: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+	if err := fork.Progress(10, "Ripping"); err != nil {
+		t.Fatalf("Progress: %v", err)
+	}
+	if got := s.LastProgress(); !got.Equal(first) {
+		t.Fatalf("repeated progress moved LastProgress from %v to %v", first, got)
+	}
+	if err := fork.Progress(10, "Ripping", WithProgressBytes(1024, 4096)); err != nil {
+		t.Fatalf("Progress: %v", err)
+	}
+	if got := s.LastProgress(); !got.After(first) {
+		t.Fatalf("byte progress on a fork did not advance the parent's LastProgress")
+	}
+}
+
+func TestSessionForkIsolatesEnvelope(t *testing.T) {
+	_, _, s := newTestSession(t)
+	s.Env.Version = ripspec.CurrentVersion
+	s.Env.Assets.AddAsset(ripspec.AssetKindRipped, ripspec.Asset{EpisodeKey: "s01e01", Path: "/rips/1.mkv"})
+
+	var wg sync.WaitGroup
+	for _, key := range []string{"s01e01", "s01e02", "s01e03"} {
+		fork, err := s.Fork()
+		if err != nil {
+			t.Fatalf("Fork: %v", err)
+		}
+		wg.Go(func() {
+			fork.Env.Metadata.Title = key // local to this fork
+			if err := fork.SaveAssetSuccess(ripspec.AssetKindSubtitled, ripspec.Asset{EpisodeKey: key}); err != nil {
+				t.Errorf("SaveAssetSuccess(%s): %v", key, err)
+			}
+			_ = fork.Progress(10, "working "+key)
+		})
+	}
+	wg.Wait()
+
+	if s.Env.Metadata.Title != "" || len(s.Env.Assets.Subtitled) != 0 {
+		t.Fatalf("fork writes leaked into the parent envelope: %+v", s.Env)
+	}
+	if err := s.RefreshEnvelope(); err != nil {
+		t.Fatalf("RefreshEnvelope: %v", err)
+	}
+	if got := len(s.Env.Assets.Subtitled); got != 3 {
+		t.Fatalf("merged subtitled assets = %d, want 3", got)
+	}
+	fork, err := s.Fork()
+	if err != nil {
+		t.Fatalf("Fork: %v", err)
+	}
+	if err := fork.Progress(80, "ahead"); err != nil {
+		t.Fatalf("Progress: %v", err)
+	}
+	if err := fork.Progress(20, "behind"); err != nil {
+		t.Fatalf("Progress: %v", err)
+	}
+	if s.Task.ProgressPercent != 80 || s.Task.ProgressMessage != "behind" {
+		t.Fatalf("shared task = %v%% %q, want forks never to move progress backwards", s.Task.ProgressPercent, s.Task.ProgressMessage)
+	}
+}
+
 func TestSessionSavePersistsRipSpec(t *testing.T) {
 	store, item, s := newTestSession(t)
 	s.SetEnvelope(&ripspec.Envelope{Version: ripspec.CurrentVersion, Fingerprint: "abc"})
@@ -0,0 +1,46 @@
+package stage
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/five82/spindle/internal/fileutil"
+)
+
+// EnsureFreeSpace is the guard stages run before a large write of about
+// need bytes into dir. It returns a *fileutil.SpaceError, halting the stage,
+// when the write would leave dir's filesystem below floor. A volume that
+// cannot be measured never blocks a write that might succeed.
+func EnsureFreeSpace(logger *slog.Logger, label, dir string, need, floor int64) error {
+	free, err := fileutil.CheckFreeSpace(label, dir, need, floor)
+	var spaceErr *fileutil.SpaceError
+	switch {
+	case errors.As(err, &spaceErr):
+		logger.Warn("write blocked by free space floor",
+			"event_type", "low_disk_space",
+			"error_hint", "free up space on "+dir+" or lower its floor in [free_space]",
+			"impact", "stage halted before writing",
+			"label", label,
+			"free_bytes", spaceErr.Free,
+			"need_bytes", need,
+			"floor_bytes", floor,
+		)
+		return err
+	case err != nil:
+		logger.Debug("free space check skipped",
+			"event_type", "free_space_check",
+			"label", label,
+			"reason", "statfs failed",
+			"error", err.Error(),
+		)
+		return nil
+	}
+	logger.Debug("free space check passed",
+		"event_type", "free_space_check",
+		"label", label,
+		"free_bytes", free,
+		"need_bytes", need,
+		"floor_bytes", floor,
+	)
+	return nil
+}
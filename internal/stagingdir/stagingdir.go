@@ -7,18 +7,25 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/five82/spindle/internal/fileutil"
 	"github.com/five82/spindle/internal/logs"
 )
 
+// OrganizedMarker names the file written into an item's staging directory
+// once the organizer has confirmed its final placement. Only marked
+// directories are removed by PruneOrganized and PruneOrganizedForSpace.
+const OrganizedMarker = ".organized"
+
 // DirInfo describes a staging directory.
 type DirInfo struct {
-	Name      string
-	Path      string
-	ModTime   time.Time
-	SizeBytes int64
+	Name      string    `json:"name"`
+	Path      string    `json:"path"`
+	ModTime   time.Time `json:"mod_time"`
+	SizeBytes int64     `json:"size_bytes"`
 }
 
 // CleanStaleResult reports what was cleaned.
@@ -115,6 +122,100 @@ func CleanStale(ctx context.Context, stagingDir string, maxAge time.Duration, ac
 	return result
 }
 
+// MarkOrganized records that the item staged in root has been organized.
+func MarkOrganized(root string) error {
+	return os.WriteFile(filepath.Join(root, OrganizedMarker), nil, 0o644)
+}
+
+// organizedDirs lists the marked directories in stagingDir, oldest first by
+// the time they were marked.
+func organizedDirs(stagingDir string) ([]DirInfo, error) {
+	entries, err := os.ReadDir(stagingDir)
+	if err != nil {
+		return nil, fmt.Errorf("read staging dir: %w", err)
+	}
+	var dirs []DirInfo
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		dirPath := filepath.Join(stagingDir, e.Name())
+		info, err := os.Stat(filepath.Join(dirPath, OrganizedMarker))
+		if err != nil {
+			continue
+		}
+		dirs = append(dirs, DirInfo{Name: e.Name(), Path: dirPath, ModTime: info.ModTime()})
+	}
+	slices.SortFunc(dirs, func(a, b DirInfo) int {
+		if c := a.ModTime.Compare(b.ModTime); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return dirs, nil
+}
+
+// PruneOrganized removes organized directories beyond the keep most recently
+// organized ones. Unmarked directories belong to items still in flight and
+// are never touched.
+func PruneOrganized(stagingDir string, keep int, logger *slog.Logger) CleanStaleResult {
+	var result CleanStaleResult
+	dirs, err := organizedDirs(stagingDir)
+	if err != nil {
+		result.Errors = append(result.Errors, err)
+		return result
+	}
+	if len(dirs) <= keep {
+		return result
+	}
+	for _, d := range dirs[:len(dirs)-keep] {
+		removeOrganized(d, "keep_recent", logger, &result)
+	}
+	return result
+}
+
+// freeSpace reports the bytes available on dir's filesystem. It is a
+// variable so tests can simulate low space.
+var freeSpace = fileutil.FreeSpace
+
+// PruneOrganizedForSpace removes organized directories, oldest first, until
+// stagingDir's filesystem has at least minFree bytes available or no
+// organized directories remain.
+func PruneOrganizedForSpace(stagingDir string, minFree int64, logger *slog.Logger) CleanStaleResult {
+	var result CleanStaleResult
+	dirs, err := organizedDirs(stagingDir)
+	if err != nil {
+		result.Errors = append(result.Errors, err)
+		return result
+	}
+	for _, d := range dirs {
+		free, err := freeSpace(stagingDir)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("statfs %s: %w", stagingDir, err))
+			return result
+		}
+		if free >= minFree {
+			break
+		}
+		removeOrganized(d, "low_space", logger, &result)
+	}
+	return result
+}
+
+func removeOrganized(d DirInfo, reason string, logger *slog.Logger, result *CleanStaleResult) {
+	logger.Info("removing organized staging directory",
+		"dir", d.Name,
+		"decision_type", logs.DecisionStagingCleanup,
+		"decision_result", "removed",
+		"decision_reason", reason,
+	)
+	if err := os.RemoveAll(d.Path); err != nil {
+		result.Errors = append(result.Errors, fmt.Errorf("remove %s: %w", d.Name, err))
+		return
+	}
+	result.Removed++
+}
+
 // isProtected reports whether a directory name should be skipped during cleanup.
 func isProtected(name string, activeFingerprints map[string]struct{}) bool {
 	if strings.HasPrefix(name, "queue-") {
@@ -166,3 +166,83 @@ func TestCleanStalePreservesQueueDirs(t *testing.T) {
 		t.Fatal("queue directory was removed")
 	}
 }
+
+// makeOrganized creates a staging directory marked organized at markedAt.
+func makeOrganized(t *testing.T, stagingDir, name string, markedAt time.Time) string {
+	t.Helper()
+	dir := filepath.Join(stagingDir, name)
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := MarkOrganized(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(filepath.Join(dir, OrganizedMarker), markedAt, markedAt); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestPruneOrganizedKeepsMostRecent(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	oldest := makeOrganized(t, dir, "AAA", now.Add(-3*time.Hour))
+	middle := makeOrganized(t, dir, "BBB", now.Add(-2*time.Hour))
+	newest := makeOrganized(t, dir, "CCC", now.Add(-1*time.Hour))
+	inFlight := filepath.Join(dir, "DDD")
+	if err := os.Mkdir(inFlight, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	result := PruneOrganized(dir, 2, testLogger())
+	if len(result.Errors) != 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	if result.Removed != 1 {
+		t.Fatalf("Removed = %d, want 1", result.Removed)
+	}
+	if _, err := os.Stat(oldest); !os.IsNotExist(err) {
+		t.Error("oldest organized directory should have been removed")
+	}
+	for _, kept := range []string{middle, newest, inFlight} {
+		if _, err := os.Stat(kept); err != nil {
+			t.Errorf("%s should be kept: %v", filepath.Base(kept), err)
+		}
+	}
+
+	if result := PruneOrganized(dir, 2, testLogger()); result.Removed != 0 {
+		t.Errorf("second prune Removed = %d, want 0", result.Removed)
+	}
+}
+
+func TestPruneOrganizedForSpaceRemovesOldestUntilFree(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	oldest := makeOrganized(t, dir, "AAA", now.Add(-3*time.Hour))
+	middle := makeOrganized(t, dir, "BBB", now.Add(-2*time.Hour))
+	newest := makeOrganized(t, dir, "CCC", now.Add(-1*time.Hour))
+
+	// Each removed directory frees 10 bytes; 20 are needed.
+	orig := freeSpace
+	t.Cleanup(func() { freeSpace = orig })
+	freeSpace = func(string) (int64, error) {
+		var free int64
+		for _, d := range []string{oldest, middle, newest} {
+			if _, err := os.Stat(d); os.IsNotExist(err) {
+				free += 10
+			}
+		}
+		return free, nil
+	}
+
+	result := PruneOrganizedForSpace(dir, 20, testLogger())
+	if len(result.Errors) != 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	if result.Removed != 2 {
+		t.Fatalf("Removed = %d, want 2", result.Removed)
+	}
+	if _, err := os.Stat(newest); err != nil {
+		t.Errorf("newest organized directory should be kept: %v", err)
+	}
+}
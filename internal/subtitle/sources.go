@@ -0,0 +1,408 @@
+package subtitle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/five82/spindle/internal/config"
+	"github.com/five82/spindle/internal/language"
+	"github.com/five82/spindle/internal/logs"
+	"github.com/five82/spindle/internal/media/ffprobe"
+	"github.com/five82/spindle/internal/opensubtitles"
+	"github.com/five82/spindle/internal/ripspec"
+	"github.com/five82/spindle/internal/srtsync"
+	"github.com/five82/spindle/internal/srtutil"
+	"github.com/five82/spindle/internal/stage"
+	"github.com/five82/spindle/internal/transcription"
+)
+
+// subtitleSourceFunc produces a display SRT for one job from a single
+// source. An error means the source has nothing usable for the job.
+type subtitleSourceFunc func(ctx context.Context, sess *stage.Session, job stage.AssetJob) (*GenerateDisplaySubtitleResult, error)
+
+// subtitleAttempt is one source's display SRT with its validation.
+type subtitleAttempt struct {
+	result     *GenerateDisplaySubtitleResult
+	record     ripspec.SubtitleGenRecord
+	validation validationResult
+}
+
+// textSubtitleCodecs are the embedded subtitle codecs ffmpeg can convert to
+// SRT. Bitmap formats (PGS, VobSub) would need OCR and are not candidates.
+var textSubtitleCodecs = map[string]bool{
+	"subrip":   true,
+	"srt":      true,
+	"ass":      true,
+	"ssa":      true,
+	"mov_text": true,
+	"webvtt":   true,
+	"text":     true,
+}
+
+var extractSubtitleStream = func(ctx context.Context, videoPath string, streamIndex int, destPath string) error {
+	cmd := exec.CommandContext(ctx, "ffmpeg", "-v", "error", "-y",
+		"-i", videoPath,
+		"-map", "0:"+strconv.Itoa(streamIndex),
+		"-c:s", "srt",
+		destPath,
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("ffmpeg extract subtitle: %w: %s", err, output)
+	}
+	return nil
+}
+
+// generateFromSources tries each source in subtitles.source_priority order
+// and returns the first attempt that validates without severe issues. When
+// no source is acceptable, the last validated attempt is returned so its
+// severe issues are recorded; when none validated, the last error.
+func (h *Handler) generateFromSources(ctx context.Context, sess *stage.Session, job stage.AssetJob) (*subtitleAttempt, error) {
+	logger := sess.Logger
+	priority := h.cfg.Subtitles.SourcePriority
+	if len(priority) == 0 {
+		priority = []string{config.SubtitleSourceWhisperX}
+	}
+
+	var rejected *subtitleAttempt
+	var lastErr error
+	for _, source := range priority {
+		generate, ok := h.sources[source]
+		if !ok {
+			continue
+		}
+		attempt, err := h.trySubtitleSource(ctx, sess, job, source, generate)
+		if err != nil {
+			lastErr = fmt.Errorf("%s: %w", source, err)
+			logger.Info("subtitle source unavailable",
+				"decision_type", logs.DecisionSubtitleSource,
+				"decision_result", "failed",
+				"decision_reason", err.Error(),
+				"episode_key", job.Key,
+				"source", source,
+			)
+			continue
+		}
+		if len(attempt.record.SevereIssues) > 0 {
+			rejected = attempt
+			logger.Info("subtitle source rejected",
+				"decision_type", logs.DecisionSubtitleSource,
+				"decision_result", "rejected",
+				"decision_reason", fmt.Sprintf("severe validation issues: %v", attempt.record.SevereIssues),
+				"episode_key", job.Key,
+				"source", source,
+			)
+			continue
+		}
+		logger.Info("subtitle source selected",
+			"decision_type", logs.DecisionSubtitleSource,
+			"decision_result", source,
+			"decision_reason", fmt.Sprintf("first acceptable source in priority %v", priority),
+			"episode_key", job.Key,
+		)
+		return attempt, nil
+	}
+	if rejected != nil {
+		return rejected, nil
+	}
+	if lastErr == nil {
+		lastErr = errors.New("no subtitle source configured")
+	}
+	return nil, lastErr
+}
+
+func (h *Handler) trySubtitleSource(ctx context.Context, sess *stage.Session, job stage.AssetJob, source string, generate subtitleSourceFunc) (*subtitleAttempt, error) {
+	result, err := generate(ctx, sess, job)
+	if err != nil {
+		return nil, err
+	}
+	record, validation, err := h.createDisplaySubtitleRecord(sess, job, source, result)
+	if err != nil {
+		return nil, err
+	}
+	return &subtitleAttempt{result: result, record: record, validation: validation}, nil
+}
+
+// embeddedSubtitle extracts the rip's English text subtitle stream as the
+// display SRT. Forced-only tracks are skipped since they cover just the
+// foreign-language dialogue.
+func (h *Handler) embeddedSubtitle(ctx context.Context, sess *stage.Session, job stage.AssetJob) (*GenerateDisplaySubtitleResult, error) {
+	probe, err := inspectSubtitleMedia(ctx, "", job.Input.Path)
+	if err != nil {
+		return nil, fmt.Errorf("probe subtitle streams: %w", err)
+	}
+	stream, ok := selectEmbeddedSubtitleStream(probe.Streams, "en", h.cfg.Subtitles.HearingImpaired)
+	if !ok {
+		return nil, errors.New("no English text subtitle stream in rip")
+	}
+	subtitleDir, err := h.subtitleDir(sess.Item)
+	if err != nil {
+		return nil, err
+	}
+	displayPath := displaySubtitlePath(filepath.Join(subtitleDir, job.Key+".mkv"), "en")
+	if err := extractSubtitleStream(ctx, job.Input.Path, stream.Index, displayPath); err != nil {
+		return nil, err
+	}
+	result, err := importedSubtitleResult(ctx, sess, job.Input.Path, displayPath)
+	if err != nil {
+		return nil, err
+	}
+	result.HearingImpaired = stream.Disposition["hearing_impaired"] == 1
+	return result, nil
+}
+
+// selectEmbeddedSubtitleStream returns the first full (non-forced) text
+// subtitle stream in lang, taking a stream that matches the hearing-impaired
+// preference over an earlier one that does not. Untagged streams count as a
+// language match.
+func selectEmbeddedSubtitleStream(streams []ffprobe.Stream, lang, hiPreference string) (ffprobe.Stream, bool) {
+	var fallback *ffprobe.Stream
+	for _, s := range streams {
+		if s.CodecType != "subtitle" || !textSubtitleCodecs[s.CodecName] || s.Disposition["forced"] == 1 {
+			continue
+		}
+		if tag := language.ExtractFromTags(s.Tags); tag != "" && language.ToISO2(tag) != lang {
+			continue
+		}
+		if hearingImpairedRank(s.Disposition["hearing_impaired"] == 1, hiPreference) == 0 {
+			return s, true
+		}
+		if fallback == nil {
+			fallback = &s
+		}
+	}
+	if fallback != nil {
+		return *fallback, true
+	}
+	return ffprobe.Stream{}, false
+}
+
+// hearingImpairedRank orders a subtitle by the subtitles.hearing_impaired
+// preference: 0 when it matches (or the preference is either), 1 otherwise.
+func hearingImpairedRank(hearingImpaired bool, preference string) int {
+	switch preference {
+	case config.HearingImpairedPrefer:
+		if !hearingImpaired {
+			return 1
+		}
+	case config.HearingImpairedEither:
+	default: // avoid
+		if hearingImpaired {
+			return 1
+		}
+	}
+	return 0
+}
+
+// openSubtitlesSubtitle downloads the best OpenSubtitles match for the job,
+// aligns it to the rip (see alignOpenSubtitlesCues), and writes the display
+// SRT.
+func (h *Handler) openSubtitlesSubtitle(ctx context.Context, sess *stage.Session, job stage.AssetJob) (*GenerateDisplaySubtitleResult, error) {
+	if h.osClient == nil {
+		if h.cfg.Offline {
+			return nil, errors.New("offline mode: OpenSubtitles client disabled")
+		}
+		return nil, errors.New("opensubtitles client not configured")
+	}
+	meta := sess.Env.Metadata
+	if meta.ID == 0 {
+		return nil, errors.New("no TMDB id to search by")
+	}
+	var season, episode int
+	if meta.MediaType != "movie" {
+		ep := sess.Env.EpisodeByKey(job.Key)
+		if ep == nil || ep.Episode <= 0 {
+			return nil, errors.New("episode not identified")
+		}
+		season, episode = ep.Season, ep.Episode
+	}
+	languages := h.cfg.Subtitles.OpenSubtitlesLanguages
+	if len(languages) == 0 {
+		languages = []string{"en"}
+	}
+	results, err := h.osClient.Search(ctx, meta.ID, season, episode, languages)
+	if err != nil {
+		return nil, err
+	}
+	ranked := rankOpenSubtitlesCandidates(results, h.cfg.Subtitles.HearingImpaired)
+	if len(ranked) == 0 {
+		return nil, errors.New("no OpenSubtitles match")
+	}
+
+	subtitleDir, err := h.subtitleDir(sess.Item)
+	if err != nil {
+		return nil, err
+	}
+	downloadPath := filepath.Join(subtitleDir, job.Key+".opensubtitles.srt")
+	pick, err := downloadRankedOpenSubtitles(ctx, sess.Logger, job.Key, ranked, func(ctx context.Context, fileID int) error {
+		return h.osClient.DownloadToFile(ctx, fileID, downloadPath)
+	})
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(downloadPath)
+	if err != nil {
+		return nil, fmt.Errorf("read opensubtitles download: %w", err)
+	}
+	cues := srtutil.Parse(opensubtitles.CleanSRT(string(data)))
+
+	cues = h.alignOpenSubtitlesCues(ctx, sess, job.Key, cues, job.Input.Path)
+
+	displayPath := displaySubtitlePath(filepath.Join(subtitleDir, job.Key+".mkv"), "en")
+	if err := os.WriteFile(displayPath, []byte(srtutil.Format(cues)), 0o644); err != nil {
+		return nil, fmt.Errorf("write display subtitle: %w", err)
+	}
+	result, err := importedSubtitleResult(ctx, sess, job.Input.Path, displayPath)
+	if err != nil {
+		return nil, err
+	}
+	result.openSubtitles = pick
+	result.HearingImpaired = ranked[pick.Rank-1].Attributes.HearingImpaired
+	return result, nil
+}
+
+// alignOpenSubtitlesCues aligns downloaded OpenSubtitles cues to the
+// WhisperX transcript artifact for key when subtitles.sync_reference_offset
+// is set. Without the setting or the transcript the cues are returned as
+// downloaded.
+func (h *Handler) alignOpenSubtitlesCues(ctx context.Context, sess *stage.Session, key string, cues []srtutil.Cue, videoPath string) []srtutil.Cue {
+	reason := "sync_reference_offset disabled"
+	if h.cfg.Subtitles.SyncReferenceOffset {
+		transcript := transcriptArtifact(sess, key)
+		if transcript != nil {
+			aligned, sync := srtsync.SyncReferenceCues(ctx, cues, transcript.SRTPath, videoPath)
+			sess.Logger.Info("opensubtitles subtitle aligned",
+				"decision_type", logs.DecisionReferenceSync,
+				"decision_result", fmt.Sprintf("rate_applied=%t offset_applied=%t", sync.Rate.Applied, sync.Offset.Applied),
+				"decision_reason", sync.Offset.Reason,
+				"episode_key", key,
+				"rate_factor", sync.Rate.Factor,
+				"offset_seconds", sync.Offset.Offset,
+			)
+			return aligned
+		}
+		reason = "whisperx transcript unavailable"
+	}
+	sess.Logger.Info("opensubtitles subtitle not aligned",
+		"decision_type", logs.DecisionReferenceSync,
+		"decision_result", "skipped",
+		"decision_reason", reason,
+		"episode_key", key,
+	)
+	return cues
+}
+
+// Ranking knobs for OpenSubtitles candidates. A rating backed by fewer than
+// minOpenSubtitlesVotes votes is treated as unrated, and downloads stop
+// after maxOpenSubtitlesDownloads failures since each attempt spends quota.
+const (
+	minOpenSubtitlesVotes     = 3
+	maxOpenSubtitlesDownloads = 3
+)
+
+// openSubtitlesPick identifies the downloaded OpenSubtitles candidate.
+type openSubtitlesPick struct {
+	FileID int
+	Rank   int // 1-based position in the ranking
+}
+
+// rankOpenSubtitlesCandidates orders the full-dialogue results best first:
+// the hearing-impaired preference, then rating, then download count.
+// Foreign-parts-only and fileless results are dropped.
+func rankOpenSubtitlesCandidates(results []opensubtitles.SubtitleResult, hiPreference string) []opensubtitles.SubtitleResult {
+	var candidates []opensubtitles.SubtitleResult
+	for _, r := range results {
+		if r.Attributes.ForeignPartsOnly || len(r.Attributes.Files) == 0 {
+			continue
+		}
+		candidates = append(candidates, r)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i].Attributes, candidates[j].Attributes
+		if ha, hb := hearingImpairedRank(a.HearingImpaired, hiPreference), hearingImpairedRank(b.HearingImpaired, hiPreference); ha != hb {
+			return ha < hb
+		}
+		if ra, rb := openSubtitlesRating(a), openSubtitlesRating(b); ra != rb {
+			return ra > rb
+		}
+		return a.DownloadCount > b.DownloadCount
+	})
+	return candidates
+}
+
+func openSubtitlesRating(a opensubtitles.SubtitleAttributes) float64 {
+	if a.Votes < minOpenSubtitlesVotes {
+		return 0
+	}
+	return a.Ratings
+}
+
+// downloadRankedOpenSubtitles downloads the best candidate, falling back
+// down the ranking when a download fails.
+func downloadRankedOpenSubtitles(ctx context.Context, logger *slog.Logger, key string, ranked []opensubtitles.SubtitleResult, download func(context.Context, int) error) (openSubtitlesPick, error) {
+	attempts := min(len(ranked), maxOpenSubtitlesDownloads)
+	var lastErr error
+	for i, candidate := range ranked[:attempts] {
+		attrs := candidate.Attributes
+		fileID := attrs.Files[0].FileID
+		if err := download(ctx, fileID); err != nil {
+			if ctx.Err() != nil {
+				return openSubtitlesPick{}, ctx.Err()
+			}
+			lastErr = err
+			impact := "trying the next ranked candidate"
+			if i == attempts-1 {
+				impact = "opensubtitles source unavailable for this episode"
+			}
+			logger.Warn("opensubtitles download failed",
+				"event_type", "opensubtitles_download_failed",
+				"error_hint", err.Error(),
+				"impact", impact,
+				"episode_key", key,
+				"file_id", fileID,
+				"rank", i+1,
+			)
+			continue
+		}
+		logger.Info("opensubtitles candidate selected",
+			"decision_type", logs.DecisionSubtitleRank,
+			"decision_result", fmt.Sprintf("file_id=%d", fileID),
+			"decision_reason", fmt.Sprintf("rank %d of %d: rating=%.1f votes=%d downloads=%d hearing_impaired=%t",
+				i+1, len(ranked), attrs.Ratings, attrs.Votes, attrs.DownloadCount, attrs.HearingImpaired),
+			"episode_key", key,
+			"release", attrs.Release,
+		)
+		return openSubtitlesPick{FileID: fileID, Rank: i + 1}, nil
+	}
+	return openSubtitlesPick{}, fmt.Errorf("download opensubtitles: %d candidate(s) failed: %w", attempts, lastErr)
+}
+
+// importedSubtitleResult describes a display SRT that came from outside
+// WhisperX: no formatting pass or audit runs over it.
+func importedSubtitleResult(ctx context.Context, sess *stage.Session, videoPath, displayPath string) (*GenerateDisplaySubtitleResult, error) {
+	cues, err := srtutil.ParseFile(displayPath)
+	if err != nil {
+		return nil, fmt.Errorf("read display subtitle: %w", err)
+	}
+	if len(cues) == 0 {
+		return nil, errors.New("subtitle has no cues")
+	}
+	videoSeconds, durationSource := resolveSubtitleVideoDuration(ctx, sess.Logger, videoPath, cues[len(cues)-1].End)
+	return &GenerateDisplaySubtitleResult{
+		SelectedAudio: transcription.SelectedAudio{Language: "en"},
+		Formatting: FormatResult{
+			DisplayPath:      displayPath,
+			OriginalSegments: len(cues),
+			FilteredSegments: len(cues),
+		},
+		VideoSeconds:   videoSeconds,
+		DurationSource: durationSource,
+		Audit:          AuditStats{Result: "skipped", FailureReason: "not a WhisperX transcript"},
+	}, nil
+}
@@ -0,0 +1,301 @@
+package subtitle
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/five82/spindle/internal/config"
+	"github.com/five82/spindle/internal/media/ffprobe"
+	"github.com/five82/spindle/internal/opensubtitles"
+	"github.com/five82/spindle/internal/ripspec"
+	"github.com/five82/spindle/internal/srtutil"
+	"github.com/five82/spindle/internal/stage"
+)
+
+const cleanTestSRT = "1\n00:00:01,000 --> 00:00:03,000\nHello there.\n\n2\n00:00:04,000 --> 00:00:06,000\nGeneral Kenobi.\n"
+
+const overlappingTestSRT = "1\n00:00:01,000 --> 00:00:05,000\nHello there.\n\n2\n00:00:04,000 --> 00:00:06,000\nGeneral Kenobi.\n"
+
+// fakeSources returns source funcs that write content to a per-source SRT,
+// recording call order in calls. A nil content entry makes that source fail.
+func fakeSources(t *testing.T, calls *[]string, content map[string]*string) map[string]subtitleSourceFunc {
+	t.Helper()
+	dir := t.TempDir()
+	sources := make(map[string]subtitleSourceFunc)
+	for _, name := range []string{config.SubtitleSourceEmbedded, config.SubtitleSourceOpenSubtitles, config.SubtitleSourceWhisperX} {
+		sources[name] = func(context.Context, *stage.Session, stage.AssetJob) (*GenerateDisplaySubtitleResult, error) {
+			*calls = append(*calls, name)
+			srt := content[name]
+			if srt == nil {
+				return nil, errors.New("unavailable")
+			}
+			path := filepath.Join(dir, name+".srt")
+			if err := os.WriteFile(path, []byte(*srt), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			return &GenerateDisplaySubtitleResult{Formatting: FormatResult{DisplayPath: path}, VideoSeconds: 10}, nil
+		}
+	}
+	return sources
+}
+
+func TestGenerateFromSourcesHonorsPriority(t *testing.T) {
+	clean, overlapping := cleanTestSRT, overlappingTestSRT
+	sess := &stage.Session{Logger: slog.New(slog.NewTextHandler(io.Discard, nil)), Env: &ripspec.Envelope{}}
+	job := stage.AssetJob{Key: "main"}
+
+	tests := []struct {
+		name      string
+		priority  []string
+		content   map[string]*string
+		want      string
+		wantCalls []string
+		wantErr   bool
+	}{
+		{
+			name:      "default whisperx",
+			priority:  nil,
+			content:   map[string]*string{config.SubtitleSourceWhisperX: &clean, config.SubtitleSourceEmbedded: &clean},
+			want:      config.SubtitleSourceWhisperX,
+			wantCalls: []string{config.SubtitleSourceWhisperX},
+		},
+		{
+			name:      "opensubtitles preferred",
+			priority:  []string{config.SubtitleSourceOpenSubtitles, config.SubtitleSourceWhisperX},
+			content:   map[string]*string{config.SubtitleSourceOpenSubtitles: &clean, config.SubtitleSourceWhisperX: &clean},
+			want:      config.SubtitleSourceOpenSubtitles,
+			wantCalls: []string{config.SubtitleSourceOpenSubtitles},
+		},
+		{
+			name:      "whisperx reordered first",
+			priority:  []string{config.SubtitleSourceWhisperX, config.SubtitleSourceOpenSubtitles},
+			content:   map[string]*string{config.SubtitleSourceOpenSubtitles: &clean, config.SubtitleSourceWhisperX: &clean},
+			want:      config.SubtitleSourceWhisperX,
+			wantCalls: []string{config.SubtitleSourceWhisperX},
+		},
+		{
+			name:      "unavailable and severe sources fall through",
+			priority:  []string{config.SubtitleSourceEmbedded, config.SubtitleSourceOpenSubtitles, config.SubtitleSourceWhisperX},
+			content:   map[string]*string{config.SubtitleSourceOpenSubtitles: &overlapping, config.SubtitleSourceWhisperX: &clean},
+			want:      config.SubtitleSourceWhisperX,
+			wantCalls: []string{config.SubtitleSourceEmbedded, config.SubtitleSourceOpenSubtitles, config.SubtitleSourceWhisperX},
+		},
+		{
+			name:      "only severe attempt is returned for recording",
+			priority:  []string{config.SubtitleSourceOpenSubtitles, config.SubtitleSourceEmbedded},
+			content:   map[string]*string{config.SubtitleSourceOpenSubtitles: &overlapping},
+			want:      config.SubtitleSourceOpenSubtitles,
+			wantCalls: []string{config.SubtitleSourceOpenSubtitles, config.SubtitleSourceEmbedded},
+		},
+		{
+			name:      "all unavailable",
+			priority:  []string{config.SubtitleSourceEmbedded},
+			content:   map[string]*string{},
+			wantCalls: []string{config.SubtitleSourceEmbedded},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			h := &Handler{
+				cfg:     &config.Config{Subtitles: config.SubtitlesConfig{SourcePriority: tt.priority}},
+				sources: fakeSources(t, &calls, tt.content),
+			}
+			attempt, err := h.generateFromSources(context.Background(), sess, job)
+			if !slices.Equal(calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", calls, tt.wantCalls)
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", attempt.record)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("generateFromSources: %v", err)
+			}
+			if attempt.record.Source != tt.want {
+				t.Errorf("source = %q, want %q", attempt.record.Source, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectEmbeddedSubtitleStream(t *testing.T) {
+	streams := []ffprobe.Stream{
+		{Index: 0, CodecType: "video"},
+		{Index: 2, CodecType: "subtitle", CodecName: "hdmv_pgs_subtitle", Tags: map[string]string{"language": "eng"}},
+		{Index: 3, CodecType: "subtitle", CodecName: "subrip", Tags: map[string]string{"language": "eng"}, Disposition: map[string]int{"forced": 1}},
+		{Index: 4, CodecType: "subtitle", CodecName: "subrip", Tags: map[string]string{"language": "fre"}},
+		{Index: 5, CodecType: "subtitle", CodecName: "subrip", Tags: map[string]string{"language": "eng"}},
+	}
+	got, ok := selectEmbeddedSubtitleStream(streams, "en", config.HearingImpairedAvoid)
+	if !ok || got.Index != 5 {
+		t.Fatalf("selected = %+v ok=%v, want stream 5", got, ok)
+	}
+	if _, ok := selectEmbeddedSubtitleStream(streams[:3], "en", config.HearingImpairedAvoid); ok {
+		t.Fatal("bitmap and forced streams must not be selected")
+	}
+
+	sdh := ffprobe.Stream{Index: 6, CodecType: "subtitle", CodecName: "subrip", Tags: map[string]string{"language": "eng"}, Disposition: map[string]int{"hearing_impaired": 1}}
+	withSDH := append(slices.Clone(streams), sdh)
+	if got, _ := selectEmbeddedSubtitleStream(withSDH, "en", config.HearingImpairedPrefer); got.Index != 6 {
+		t.Fatalf("prefer selected stream %d, want SDH stream 6", got.Index)
+	}
+	if got, _ := selectEmbeddedSubtitleStream(withSDH, "en", config.HearingImpairedAvoid); got.Index != 5 {
+		t.Fatalf("avoid selected stream %d, want 5", got.Index)
+	}
+	if got, _ := selectEmbeddedSubtitleStream([]ffprobe.Stream{sdh}, "en", config.HearingImpairedAvoid); got.Index != 6 {
+		t.Fatalf("avoid must still fall back to the only SDH stream, got %d", got.Index)
+	}
+}
+
+func osResult(id, downloads int, ratings float64, votes int, hi, foreign bool) opensubtitles.SubtitleResult {
+	return opensubtitles.SubtitleResult{Attributes: opensubtitles.SubtitleAttributes{
+		DownloadCount:    downloads,
+		Ratings:          ratings,
+		Votes:            votes,
+		HearingImpaired:  hi,
+		ForeignPartsOnly: foreign,
+		Files:            []opensubtitles.SubtitleFile{{FileID: id}},
+	}}
+}
+
+func TestRankOpenSubtitlesCandidates(t *testing.T) {
+	results := []opensubtitles.SubtitleResult{
+		osResult(1, 9000, 9, 50, false, true), // foreign parts only: dropped
+		osResult(2, 5000, 0, 0, true, false),
+		osResult(3, 100, 0, 0, false, false),
+		osResult(4, 800, 0, 0, false, false),
+		osResult(5, 50, 8, 12, false, false),
+		osResult(6, 20, 10, 1, false, false),                                 // too few votes to count
+		{Attributes: opensubtitles.SubtitleAttributes{DownloadCount: 99999}}, // no files: dropped
+	}
+	ids := func(ranked []opensubtitles.SubtitleResult) []int {
+		var out []int
+		for _, r := range ranked {
+			out = append(out, r.Attributes.Files[0].FileID)
+		}
+		return out
+	}
+
+	tests := []struct {
+		preference string
+		want       []int
+	}{
+		{config.HearingImpairedAvoid, []int{5, 4, 3, 6, 2}}, // non-HI, rated, then most downloaded
+		{config.HearingImpairedPrefer, []int{2, 5, 4, 3, 6}},
+		{config.HearingImpairedEither, []int{5, 2, 4, 3, 6}},
+	}
+	for _, tt := range tests {
+		if got := ids(rankOpenSubtitlesCandidates(results, tt.preference)); !slices.Equal(got, tt.want) {
+			t.Errorf("hearing_impaired=%s ranking = %v, want %v", tt.preference, got, tt.want)
+		}
+	}
+	if got := rankOpenSubtitlesCandidates(results[:1], config.HearingImpairedAvoid); len(got) != 0 {
+		t.Errorf("foreign-only results ranked: %v", ids(got))
+	}
+}
+
+func TestDownloadRankedOpenSubtitlesFallsBack(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ranked := []opensubtitles.SubtitleResult{
+		osResult(10, 0, 0, 0, false, false),
+		osResult(11, 0, 0, 0, false, false),
+		osResult(12, 0, 0, 0, false, false),
+		osResult(13, 0, 0, 0, false, false),
+	}
+
+	var tried []int
+	failing := map[int]bool{10: true, 11: true}
+	download := func(_ context.Context, fileID int) error {
+		tried = append(tried, fileID)
+		if failing[fileID] {
+			return errors.New("download quota exceeded")
+		}
+		return nil
+	}
+	pick, err := downloadRankedOpenSubtitles(context.Background(), logger, "s01e01", ranked, download)
+	if err != nil {
+		t.Fatalf("downloadRankedOpenSubtitles: %v", err)
+	}
+	if pick != (openSubtitlesPick{FileID: 12, Rank: 3}) || !slices.Equal(tried, []int{10, 11, 12}) {
+		t.Fatalf("pick = %+v after trying %v, want file 12 at rank 3", pick, tried)
+	}
+
+	tried = nil
+	failing[12] = true
+	if _, err := downloadRankedOpenSubtitles(context.Background(), logger, "s01e01", ranked, download); err == nil {
+		t.Fatal("expected an error when every attempted download fails")
+	}
+	if len(tried) != maxOpenSubtitlesDownloads {
+		t.Fatalf("tried %v, want the attempt cap of %d", tried, maxOpenSubtitlesDownloads)
+	}
+}
+
+func TestRunBypassesItemWithSkipSubtitlesOption(t *testing.T) {
+	clean := cleanTestSRT
+	var calls []string
+	env := &ripspec.Envelope{
+		Metadata: ripspec.Metadata{MediaType: "movie"},
+		Options:  ripspec.ItemOptions{SkipSubtitles: true},
+	}
+	env.Assets.AddAsset(ripspec.AssetKindEncoded, ripspec.Asset{EpisodeKey: "main", Path: "/encoded/main.mkv", Status: ripspec.AssetStatusCompleted})
+	h := &Handler{
+		cfg:     &config.Config{Subtitles: config.SubtitlesConfig{Enabled: true}},
+		sources: fakeSources(t, &calls, map[string]*string{config.SubtitleSourceWhisperX: &clean}),
+	}
+	sess := &stage.Session{Logger: slog.New(slog.NewTextHandler(io.Discard, nil)), Env: env}
+
+	if err := h.Run(context.Background(), sess); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(calls) != 0 {
+		t.Fatalf("subtitle sources called %v, want none for a skip_subtitles item", calls)
+	}
+	if len(env.Attributes.SubtitleGenerationResults) != 0 || len(env.Assets.Subtitled) != 0 {
+		t.Fatal("skipped item must not record subtitle results")
+	}
+}
+
+func TestAlignOpenSubtitlesCuesHonorsSyncReferenceOffset(t *testing.T) {
+	// Irregularly spaced transcript cues; the download runs 4s early.
+	var transcript, download []srtutil.Cue
+	at := 30.0
+	for i := range 60 {
+		at += 1.5 + float64(i%7)*0.6
+		transcript = append(transcript, srtutil.Cue{Index: i + 1, Start: at, End: at + 1.2, Text: "line"})
+		download = append(download, srtutil.Cue{Index: i + 1, Start: at - 4, End: at - 2.8, Text: "line"})
+	}
+	dir := t.TempDir()
+	srtPath := filepath.Join(dir, "audio.srt")
+	if err := os.WriteFile(srtPath, []byte(srtutil.Format(transcript)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "audio.json"), []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	env := &ripspec.Envelope{}
+	env.Assets.AddAsset(ripspec.AssetKindTranscript, ripspec.Asset{EpisodeKey: "main", Path: srtPath, Status: ripspec.AssetStatusCompleted})
+	sess := &stage.Session{Logger: slog.New(slog.NewTextHandler(io.Discard, nil)), Env: env}
+
+	for _, sync := range []bool{false, true} {
+		h := &Handler{cfg: &config.Config{Subtitles: config.SubtitlesConfig{SyncReferenceOffset: sync}}}
+		got := h.alignOpenSubtitlesCues(context.Background(), sess, "main", slices.Clone(download), "")
+		want := download[0].Start
+		if sync {
+			want = transcript[0].Start
+		}
+		if len(got) != len(download) || math.Abs(got[0].Start-want) > 0.2 {
+			t.Errorf("sync_reference_offset=%t: first cue start = %.2f, want %.2f", sync, got[0].Start, want)
+		}
+	}
+}
@@ -10,12 +10,15 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/five82/spindle/internal/config"
 	"github.com/five82/spindle/internal/llm"
 	"github.com/five82/spindle/internal/logs"
 	"github.com/five82/spindle/internal/media/ffprobe"
+	"github.com/five82/spindle/internal/opensubtitles"
+	"github.com/five82/spindle/internal/queue"
 	"github.com/five82/spindle/internal/ripspec"
 	"github.com/five82/spindle/internal/srtutil"
 	"github.com/five82/spindle/internal/stage"
@@ -29,15 +32,26 @@ type Handler struct {
 	cfg         *config.Config
 	transcriber *transcription.Service
 	llm         *llm.Client
+	osClient    *opensubtitles.Client
+	// sources maps subtitles.source_priority names to their generators.
+	sources map[string]subtitleSourceFunc
 }
 
-// New creates a subtitle handler.
-func New(cfg *config.Config, transcriber *transcription.Service, llmClient *llm.Client) *Handler {
-	return &Handler{
+// New creates a subtitle handler. osClient may be nil, in which case the
+// opensubtitles source always falls through to the next configured source.
+func New(cfg *config.Config, transcriber *transcription.Service, llmClient *llm.Client, osClient *opensubtitles.Client) *Handler {
+	h := &Handler{
 		cfg:         cfg,
 		transcriber: transcriber,
 		llm:         llmClient,
+		osClient:    osClient,
 	}
+	h.sources = map[string]subtitleSourceFunc{
+		config.SubtitleSourceEmbedded:      h.embeddedSubtitle,
+		config.SubtitleSourceOpenSubtitles: h.openSubtitlesSubtitle,
+		config.SubtitleSourceWhisperX:      h.generateDisplaySubtitle,
+	}
+	return h
 }
 
 // DisplaySubtitleError reports which display-subtitle generation step failed.
@@ -93,6 +107,11 @@ type GenerateDisplaySubtitleResult struct {
 	VideoSeconds   float64
 	DurationSource string
 	Audit          AuditStats
+	// HearingImpaired is set when the source flags the subtitle as SDH.
+	// WhisperX transcripts are dialogue only and never are.
+	HearingImpaired bool
+
+	openSubtitles openSubtitlesPick // set for OpenSubtitles downloads
 }
 
 // GenerateDisplaySubtitle selects primary audio, creates canonical WhisperX
@@ -192,7 +211,23 @@ func (h *Handler) Run(ctx context.Context, sess *stage.Session) error {
 			"decision_type", logs.DecisionSubtitleSkip,
 			"decision_result", "skipped",
 			"decision_reason", "subtitles.enabled = false",
+			"skip_category", ripspec.SkipDisabled,
+		)
+		sess.RecordSkip(ripspec.StageSkip{
+			Stage: string(queue.StageSubtitling), Category: ripspec.SkipDisabled, Reason: "subtitles.enabled = false",
+		})
+		return nil
+	}
+	if sess.Env.Options.SkipSubtitles {
+		logger.Info("subtitles skipped for item",
+			"decision_type", logs.DecisionSubtitleSkip,
+			"decision_result", "skipped",
+			"decision_reason", "item option skip_subtitles set",
+			"skip_category", ripspec.SkipDisabled,
 		)
+		sess.RecordSkip(ripspec.StageSkip{
+			Stage: string(queue.StageSubtitling), Category: ripspec.SkipDisabled, Reason: "item option skip_subtitles set",
+		})
 		return nil
 	}
 
@@ -255,6 +290,9 @@ func (h *Handler) logSkippedSubtitleJobs(logger *slog.Logger, skippedCompleted [
 }
 
 func (h *Handler) processSubtitleJobs(ctx context.Context, sess *stage.Session, jobs []stage.AssetJob) (subtitleRunSummary, error) {
+	if workers := h.cfg.Subtitles.EpisodeWorkers; workers > 1 && len(jobs) > 1 {
+		return h.processSubtitleJobsConcurrently(ctx, sess, jobs, workers)
+	}
 	var summary subtitleRunSummary
 	for _, job := range jobs {
 		if ctx.Err() != nil {
@@ -275,24 +313,92 @@ func (h *Handler) processSubtitleJobs(ctx context.Context, sess *stage.Session,
 	return summary, nil
 }
 
+// processSubtitleJobsConcurrently runs up to workers jobs at once, each on
+// its own forked session so envelope merges never share memory. WhisperX
+// runs stay bounded by the transcription service's own worker slots. A
+// persistence error cancels the remaining jobs, as it stops the sequential
+// loop.
+func (h *Handler) processSubtitleJobsConcurrently(ctx context.Context, sess *stage.Session, jobs []stage.AssetJob, workers int) (subtitleRunSummary, error) {
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	sess.Logger.Info("subtitle jobs running concurrently",
+		"decision_type", logs.DecisionSubtitleConcurrency,
+		"decision_result", min(workers, len(jobs)),
+		"decision_reason", fmt.Sprintf("subtitles.episode_workers=%d, %d jobs", workers, len(jobs)),
+	)
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		summary  subtitleRunSummary
+		firstErr error
+	)
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+	}
+	slots := make(chan struct{}, workers)
+dispatch:
+	for _, job := range jobs {
+		select {
+		case slots <- struct{}{}:
+		case <-runCtx.Done():
+			break dispatch
+		}
+		fork, err := sess.Fork()
+		if err != nil {
+			<-slots
+			fail(err)
+			break
+		}
+		wg.Go(func() {
+			defer func() { <-slots }()
+			succeeded, err := h.processSubtitleJob(runCtx, fork, job)
+			if err != nil {
+				fail(err)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			summary.attempted++
+			if succeeded {
+				summary.succeeded++
+			} else {
+				summary.failed++
+			}
+		})
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return summary, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return summary, err
+	}
+	// Adopt the workers' merged records; every subtitle write is a merge.
+	return summary, sess.RefreshEnvelope()
+}
+
 func (h *Handler) processSubtitleJob(ctx context.Context, sess *stage.Session, job stage.AssetJob) (bool, error) {
 	logger := sess.Logger
 	key := job.Key
 
 	h.startSubtitleJob(sess, job)
 
-	result, err := h.generateDisplaySubtitle(ctx, sess, job)
+	attempt, err := h.generateFromSources(ctx, sess, job)
 	if err != nil {
 		h.recordSubtitleFailure(logger, sess, key, err.Error())
 		return false, nil
 	}
-	h.applySubtitleAuditReviewIssue(logger, sess, key, result.Audit)
+	h.applySubtitleAuditReviewIssue(logger, sess, key, attempt.result.Audit)
+	h.applySubtitleReviewIssues(logger, sess, key, attempt.validation)
 
-	record, err := h.createDisplaySubtitleRecord(sess, job, result)
-	if err != nil {
-		h.recordSubtitleFailure(logger, sess, key, err.Error())
-		return false, nil
-	}
+	record := attempt.record
 	if len(record.SevereIssues) > 0 {
 		severeReason := strings.Join(record.SevereIssues, ", ")
 		if mergeErr := sess.MergeSave(func(env *ripspec.Envelope) error {
@@ -321,6 +427,7 @@ func (h *Handler) processSubtitleJob(ctx context.Context, sess *stage.Session, j
 	logger.Info("subtitle generated",
 		"event_type", "subtitle_generated",
 		"episode_key", key,
+		"source", record.Source,
 		"subtitle_path", record.SubtitlePath,
 		"segments", record.Segments,
 	)
@@ -348,17 +455,12 @@ func (h *Handler) generateDisplaySubtitle(ctx context.Context, sess *stage.Sessi
 	key := job.Key
 	workDir := filepath.Join(os.TempDir(), fmt.Sprintf("spindle-subtitle-%s-%s", item.DiscFingerprint, key))
 
-	// Generated SRTs land in staging; the apply stage places them next to
-	// the encoded output and muxes them after the encoding branch joins.
-	stagingRoot, err := item.StagingRoot(h.cfg.Paths.StagingDir)
+	subtitleDir, err := h.subtitleDir(item)
 	if err != nil {
 		return nil, err
 	}
-	subtitleDir := filepath.Join(stagingRoot, "subtitles")
-	if err := os.MkdirAll(subtitleDir, 0o755); err != nil {
-		return nil, fmt.Errorf("create subtitles dir: %w", err)
-	}
 
+	message := job.PhaseMessage("Generating subtitles (" + key + ")")
 	return GenerateDisplaySubtitle(ctx, GenerateDisplaySubtitleRequest{
 		VideoPath:       asset.Path,
 		DisplayBasePath: filepath.Join(subtitleDir, key+".mkv"),
@@ -373,7 +475,8 @@ func (h *Handler) generateDisplaySubtitle(ctx context.Context, sess *stage.Sessi
 		MediaContext:    auditMediaContext(sess.Env.Metadata, key),
 		Logger:          sess.Logger,
 		Progress: func(phase transcription.Phase, elapsed time.Duration) {
-			message := sess.Task.ProgressMessage
+			// Track this job's own message: with concurrent episodes the
+			// task's current message may belong to another job.
 			switch phase {
 			case transcription.PhaseExtract:
 				if elapsed == 0 {
@@ -407,6 +510,21 @@ func (h *Handler) generateDisplaySubtitle(ctx context.Context, sess *stage.Sessi
 	})
 }
 
+// subtitleDir returns the item's staging subtitle directory, creating it.
+// Display SRTs land in staging; the apply stage places them next to the
+// encoded output and muxes them after the encoding branch joins.
+func (h *Handler) subtitleDir(item *queue.Item) (string, error) {
+	stagingRoot, err := item.StagingRoot(h.cfg.Paths.StagingDir)
+	if err != nil {
+		return "", err
+	}
+	subtitleDir := filepath.Join(stagingRoot, "subtitles")
+	if err := os.MkdirAll(subtitleDir, 0o755); err != nil {
+		return "", fmt.Errorf("create subtitles dir: %w", err)
+	}
+	return subtitleDir, nil
+}
+
 // transcriptArtifact returns the episode's shared WhisperX transcript
 // artifact (recorded by episode identification, or commentary analysis for
 // movies) when both its SRT and JSON still exist, so subtitle generation can
@@ -447,32 +565,38 @@ func transcriptArtifact(sess *stage.Session, key string) *transcription.Transcri
 	}
 }
 
-func (h *Handler) createDisplaySubtitleRecord(sess *stage.Session, job stage.AssetJob, result *GenerateDisplaySubtitleResult) (ripspec.SubtitleGenRecord, error) {
+// createDisplaySubtitleRecord validates a source's display SRT and builds
+// its generation record. Review issues are returned in the validation rather
+// than persisted, since only the winning source's issues apply.
+func (h *Handler) createDisplaySubtitleRecord(sess *stage.Session, job stage.AssetJob, source string, result *GenerateDisplaySubtitleResult) (ripspec.SubtitleGenRecord, validationResult, error) {
 	logger := sess.Logger
 	key := job.Key
 	formatting := result.Formatting
 
-	h.logSubtitleFormatting(logger, key, formatting)
+	if source == config.SubtitleSourceWhisperX {
+		h.logSubtitleFormatting(logger, key, formatting)
+	}
 
 	formattedCues, readErr := srtutil.ParseFile(formatting.DisplayPath)
 	if readErr != nil {
-		return ripspec.SubtitleGenRecord{}, fmt.Errorf("read formatted subtitle: %w", readErr)
+		return ripspec.SubtitleGenRecord{}, validationResult{}, fmt.Errorf("read formatted subtitle: %w", readErr)
 	}
 	if len(formattedCues) == 0 {
-		return ripspec.SubtitleGenRecord{}, fmt.Errorf("formatted subtitle produced zero cues")
+		return ripspec.SubtitleGenRecord{}, validationResult{}, fmt.Errorf("formatted subtitle produced zero cues")
 	}
 
 	validation := validateCuesDetailed(formattedCues, result.VideoSeconds)
+	validation.applyCoverageCheck(h.cfg.Subtitles.MinCoverage)
 	h.logSubtitleValidation(logger, key, validation, formatting)
-	h.applySubtitleReviewIssues(logger, sess, key, validation)
 
 	record := ripspec.SubtitleGenRecord{
 		EpisodeKey:        key,
-		Source:            "whisperx",
+		Source:            source,
 		SubtitlePath:      formatting.DisplayPath,
 		Segments:          len(formattedCues),
 		DurationSec:       result.VideoSeconds,
 		Language:          result.SelectedAudio.Language,
+		CoverageRatio:     validation.Stats.Coverage,
 		ValidationResult:  subtitleValidationResult(validation),
 		QCObservations:    validation.Issues,
 		ReviewIssues:      validation.ReviewIssues,
@@ -480,9 +604,13 @@ func (h *Handler) createDisplaySubtitleRecord(sess *stage.Session, job stage.Ass
 		AuditResult:       result.Audit.Result,
 		AuditEditsApplied: result.Audit.Applied,
 		AuditEditsDropped: result.Audit.Dropped,
+		HearingImpaired:   result.HearingImpaired,
+
+		OpenSubtitlesFileID: result.openSubtitles.FileID,
+		OpenSubtitlesRank:   result.openSubtitles.Rank,
 	}
 
-	return record, nil
+	return record, validation, nil
 }
 
 func (h *Handler) logSubtitleFormatting(logger *slog.Logger, key string, formatting FormatResult) {
@@ -517,6 +645,7 @@ func (h *Handler) logSubtitleValidation(logger *slog.Logger, key string, validat
 		"overlong_line_cues", stats.OverlongLineCues,
 		"unbalanced_line_break_cues", stats.UnbalancedLineBreakCues,
 		"too_many_line_cues", stats.TooManyLineCues,
+		"coverage_ratio", stats.Coverage,
 		"split_cues", formatting.SplitCues,
 		"merged_cues", formatting.MergedCues,
 		"wrapped_cues", formatting.WrappedCues,
@@ -7,11 +7,17 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/five82/spindle/internal/config"
 	"github.com/five82/spindle/internal/media/ffprobe"
+	"github.com/five82/spindle/internal/queue"
 	"github.com/five82/spindle/internal/ripspec"
+	"github.com/five82/spindle/internal/stage"
 	"github.com/five82/spindle/internal/transcription"
 )
 
@@ -303,3 +309,171 @@ func TestResolveSubtitleVideoDuration(t *testing.T) {
 		t.Fatalf("fallback = %v, %q; want 90, transcript_fallback", got, source)
 	}
 }
+
+func TestRunRecordsSkipReason(t *testing.T) {
+	tests := []struct {
+		name          string
+		enabled       bool
+		skipSubtitles bool
+		reason        string
+	}{
+		{"disabled in config", false, false, "subtitles.enabled = false"},
+		{"item option", true, true, "item option skip_subtitles set"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := queue.Open(filepath.Join(t.TempDir(), "queue.db"))
+			if err != nil {
+				t.Fatalf("open queue: %v", err)
+			}
+			defer func() { _ = store.Close() }()
+			item, err := store.NewDisc("Test", "fp1")
+			if err != nil {
+				t.Fatalf("new disc: %v", err)
+			}
+			env := ripspec.Envelope{Version: ripspec.CurrentVersion, Metadata: ripspec.Metadata{MediaType: "movie"}}
+			env.Options.SkipSubtitles = tt.skipSubtitles
+			if item.RipSpecData, err = env.Encode(); err != nil {
+				t.Fatal(err)
+			}
+			if err := store.UpdateWorkState(item); err != nil {
+				t.Fatalf("update work state: %v", err)
+			}
+
+			cfg := &config.Config{}
+			cfg.Subtitles.Enabled = tt.enabled
+			sess, err := stage.NewSession(context.Background(), store, item, nil)
+			if err != nil {
+				t.Fatalf("NewSession: %v", err)
+			}
+			if err := New(cfg, nil, nil, nil).Run(context.Background(), sess); err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+
+			stored, err := store.GetByID(item.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			persisted, err := ripspec.Parse(stored.RipSpecData)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := ripspec.StageSkip{Stage: "subtitling", Category: ripspec.SkipDisabled, Reason: tt.reason}
+			if skips := persisted.Attributes.StageSkips; len(skips) != 1 || skips[0] != want {
+				t.Fatalf("stage skips = %+v, want [%+v]", skips, want)
+			}
+		})
+	}
+}
+
+// runEpisodes runs the subtitle stage over a four-episode TV item with
+// episodeWorkers and returns the persisted envelope. s01e03 has no usable
+// source so the failure path runs alongside the successes.
+func runEpisodes(t *testing.T, episodeWorkers int) *ripspec.Envelope {
+	t.Helper()
+	store, err := queue.Open(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("open queue: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	item, err := store.NewDisc("Show", "fp-show")
+	if err != nil {
+		t.Fatalf("new disc: %v", err)
+	}
+	env := ripspec.Envelope{Version: ripspec.CurrentVersion, Metadata: ripspec.Metadata{MediaType: "tv"}}
+	keys := []string{"s01e01", "s01e02", "s01e03", "s01e04"}
+	for i, key := range keys {
+		env.Episodes = append(env.Episodes, ripspec.Episode{Key: key, Season: 1, Episode: i + 1})
+		env.Assets.AddAsset(ripspec.AssetKindRipped, ripspec.Asset{
+			EpisodeKey: key, Path: "/rips/" + key + ".mkv", Status: ripspec.AssetStatusCompleted,
+		})
+	}
+	if item.RipSpecData, err = env.Encode(); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.UpdateWorkState(item); err != nil {
+		t.Fatalf("update work state: %v", err)
+	}
+
+	dir := t.TempDir()
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	h := &Handler{
+		cfg: &config.Config{Subtitles: config.SubtitlesConfig{Enabled: true, EpisodeWorkers: episodeWorkers}},
+		sources: map[string]subtitleSourceFunc{
+			config.SubtitleSourceWhisperX: func(_ context.Context, _ *stage.Session, job stage.AssetJob) (*GenerateDisplaySubtitleResult, error) {
+				mu.Lock()
+				inFlight++
+				peak = max(peak, inFlight)
+				mu.Unlock()
+				time.Sleep(20 * time.Millisecond)
+				mu.Lock()
+				inFlight--
+				mu.Unlock()
+				if job.Key == "s01e03" {
+					return nil, fmt.Errorf("no audio")
+				}
+				path := filepath.Join(dir, job.Key+".srt")
+				srt := cleanTestSRT + "\n3\n00:00:07,000 --> 00:00:09,000\nEpisode " + job.Key + ".\n"
+				if err := os.WriteFile(path, []byte(srt), 0o644); err != nil {
+					return nil, err
+				}
+				return &GenerateDisplaySubtitleResult{Formatting: FormatResult{DisplayPath: path}, VideoSeconds: 10}, nil
+			},
+		},
+	}
+	sess, err := stage.NewSession(context.Background(), store, item, nil)
+	if err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	if err := h.Run(context.Background(), sess); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if want := min(max(episodeWorkers, 1), len(keys)); peak != want {
+		t.Errorf("peak concurrent jobs = %d, want %d", peak, want)
+	}
+
+	stored, err := store.GetByID(item.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	persisted, err := ripspec.Parse(stored.RipSpecData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sess.Env.Attributes.SubtitleGenerationResults) != len(persisted.Attributes.SubtitleGenerationResults) {
+		t.Errorf("session envelope not refreshed after the run")
+	}
+	return &persisted
+}
+
+// TestConcurrentEpisodesMatchSequential checks that running episodes in
+// parallel persists the same records as running them one at a time. Run
+// with -race to cover the forked sessions and shared handler state.
+func TestConcurrentEpisodesMatchSequential(t *testing.T) {
+	summarize := func(env *ripspec.Envelope) string {
+		var lines []string
+		for _, rec := range env.Attributes.SubtitleGenerationResults {
+			lines = append(lines, fmt.Sprintf("gen %s %s %s segments=%d severe=%v",
+				rec.EpisodeKey, rec.Source, filepath.Base(rec.SubtitlePath), rec.Segments, rec.SevereIssues))
+		}
+		for _, asset := range env.Assets.Subtitled {
+			lines = append(lines, fmt.Sprintf("asset %s %s %s", asset.EpisodeKey, asset.Status, asset.ErrorMsg))
+		}
+		for _, ep := range env.Episodes {
+			lines = append(lines, fmt.Sprintf("episode %s review=%q", ep.Key, ep.ReviewReason))
+		}
+		slices.Sort(lines)
+		return strings.Join(lines, "\n")
+	}
+
+	sequential := summarize(runEpisodes(t, 1))
+	parallel := summarize(runEpisodes(t, 3))
+	if parallel != sequential {
+		t.Fatalf("parallel output differs from sequential:\n--- sequential\n%s\n--- parallel\n%s", sequential, parallel)
+	}
+	if !strings.Contains(sequential, "gen s01e04 whisperx s01e04.srt segments=3") ||
+		!strings.Contains(sequential, "asset s01e03 failed") {
+		t.Fatalf("unexpected sequential output:\n%s", sequential)
+	}
+}
@@ -52,6 +52,9 @@ type subtitleQCStats struct {
 	OverlongLineCues        int
 	UnbalancedLineBreakCues int
 	TooManyLineCues         int
+	// Coverage is the fraction of the video runtime spanned up to the last
+	// cue's end; 0 when the runtime is unknown.
+	Coverage float64
 }
 
 func validateCues(cues []srtutil.Cue, videoSeconds float64) []string {
@@ -64,6 +67,7 @@ func validateCuesDetailed(cues []srtutil.Cue, videoSeconds float64) validationRe
 	}
 
 	stats := calculateSubtitleQCStats(cues)
+	stats.Coverage = subtitleCoverage(cues, videoSeconds)
 	seen := make(map[string]bool)
 	severeSeen := make(map[string]bool)
 	var issues []string
@@ -132,6 +136,28 @@ func validateCuesDetailed(cues []srtutil.Cue, videoSeconds float64) validationRe
 	return validationResult{Issues: issues, ReviewIssues: subtitleReviewIssues(issues, severe, stats), SevereIssues: severe, Stats: stats}
 }
 
+// subtitleCoverage returns how much of the runtime the cues span, capped at 1.
+func subtitleCoverage(cues []srtutil.Cue, videoSeconds float64) float64 {
+	if len(cues) == 0 || videoSeconds <= 0 {
+		return 0
+	}
+	return min(cues[len(cues)-1].End/videoSeconds, 1)
+}
+
+// applyCoverageCheck flags a subtitle ending before minCoverage of the
+// runtime as severe short_coverage, so the next source is tried and the
+// episode routes to review when none covers it. Truncated downloads are the
+// usual cause; the slack below 1 absorbs dialogue-free end credits. A zero
+// minCoverage or unknown runtime skips the check.
+func (v *validationResult) applyCoverageCheck(minCoverage float64) {
+	if minCoverage <= 0 || v.Stats.Coverage <= 0 || v.Stats.Coverage >= minCoverage {
+		return
+	}
+	v.Issues = append(v.Issues, "short_coverage")
+	v.ReviewIssues = append(v.ReviewIssues, "short_coverage")
+	v.SevereIssues = append(v.SevereIssues, "short_coverage")
+}
+
 func subtitleReviewIssues(issues, severe []string, stats subtitleQCStats) []string {
 	if len(issues) == 0 {
 		return nil
@@ -151,7 +177,7 @@ func subtitleReviewIssues(issues, severe []string, stats subtitleQCStats) []stri
 
 func subtitleIssueRequiresReview(issue string, _ subtitleQCStats) bool {
 	switch issue {
-	case "duration_mismatch", "sparse_subtitles", "late_first_cue":
+	case "duration_mismatch", "sparse_subtitles", "late_first_cue", "short_coverage":
 		return true
 	case "high_reading_speed", "short_cue_duration", "long_cue_duration", "too_many_lines", "line_too_long", "unbalanced_line_breaks":
 		return false
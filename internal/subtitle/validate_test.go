@@ -2,12 +2,19 @@ package subtitle
 
 import (
 	"fmt"
+	"io"
+	"log/slog"
+	"math"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
+	"github.com/five82/spindle/internal/config"
+	"github.com/five82/spindle/internal/ripspec"
 	"github.com/five82/spindle/internal/srtutil"
+	"github.com/five82/spindle/internal/stage"
 )
 
 func TestValidateSRTContent_Valid(t *testing.T) {
@@ -438,3 +445,46 @@ func containsIssue(issues []string, target string) bool {
 	}
 	return false
 }
+
+func TestCoverageCheck(t *testing.T) {
+	// Cues every 10s, the last ending at 95s of a 100s runtime.
+	full := manyCues(10)
+	tests := []struct {
+		name       string
+		cues       []srtutil.Cue
+		wantSevere bool
+	}{
+		{"full coverage", full, false},
+		{"truncated", full[:5], true},
+	}
+	h := &Handler{cfg: &config.Config{Subtitles: config.SubtitlesConfig{MinCoverage: 0.8}}}
+	sess := &stage.Session{Logger: slog.New(slog.NewTextHandler(io.Discard, nil)), Env: &ripspec.Envelope{}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeSRTFile(t, tt.cues)
+			result := &GenerateDisplaySubtitleResult{Formatting: FormatResult{DisplayPath: path}, VideoSeconds: 100}
+			record, _, err := h.createDisplaySubtitleRecord(sess, stage.AssetJob{Key: "main"}, config.SubtitleSourceOpenSubtitles, result)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantRatio := tt.cues[len(tt.cues)-1].End / 100
+			if math.Abs(record.CoverageRatio-wantRatio) > 1e-9 {
+				t.Errorf("coverage ratio = %v, want %v", record.CoverageRatio, wantRatio)
+			}
+			if got := slices.Contains(record.SevereIssues, "short_coverage"); got != tt.wantSevere {
+				t.Errorf("short_coverage severe = %v, want %v (issues %v)", got, tt.wantSevere, record.SevereIssues)
+			}
+		})
+	}
+
+	h.cfg.Subtitles.MinCoverage = 0
+	path := writeSRTFile(t, full[:5])
+	record, _, err := h.createDisplaySubtitleRecord(sess, stage.AssetJob{Key: "main"}, config.SubtitleSourceOpenSubtitles,
+		&GenerateDisplaySubtitleResult{Formatting: FormatResult{DisplayPath: path}, VideoSeconds: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slices.Contains(record.SevereIssues, "short_coverage") {
+		t.Fatal("min_coverage = 0 must disable the check")
+	}
+}
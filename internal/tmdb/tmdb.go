@@ -3,17 +3,23 @@ package tmdb
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
+	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unicode"
 
+	"github.com/five82/spindle/internal/jsoncache"
 	"github.com/five82/spindle/internal/logs"
+	"github.com/five82/spindle/internal/ripspec"
 )
 
 // Client communicates with the TMDB API.
@@ -21,8 +27,17 @@ type Client struct {
 	apiKey   string
 	baseURL  string
 	language string
+	region   string // ISO 3166-1 code; empty sends none
 	client   *http.Client
 	logger   *slog.Logger
+	cache    *jsoncache.Store[json.RawMessage] // optional response cache keyed on request path and query; nil queries TMDB every time
+
+	// mu guards the request pacing shared by every goroutine using the
+	// client, so concurrent searches stay under TMDB's rate limit and a 429
+	// pauses them all.
+	mu          sync.Mutex
+	nextSlot    time.Time // earliest start for the next request
+	pausedUntil time.Time // end of the latest 429 pause
 }
 
 // New creates a TMDB client.
@@ -56,6 +71,29 @@ type SearchResult struct {
 	VoteCount     int     `json:"vote_count"`
 	OriginalTitle string  `json:"original_title"`
 	OriginalName  string  `json:"original_name"`
+	PosterPath    string  `json:"poster_path"`   // relative; see ImageConfig
+	BackdropPath  string  `json:"backdrop_path"` // relative; see ImageConfig
+	GenreIDs      []int   `json:"genre_ids"`     // search results
+	Genres        []Genre `json:"genres"`        // details lookups
+}
+
+// Genre is a TMDB genre as listed by a details lookup.
+type Genre struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// WithOriginalTitle returns r titled by its original-language name, so it
+// can be matched against a disc labelled in that language. Results without
+// an original name are returned unchanged.
+func (r SearchResult) WithOriginalTitle() SearchResult {
+	if r.OriginalTitle != "" {
+		r.Title = r.OriginalTitle
+	}
+	if r.OriginalName != "" {
+		r.Name = r.OriginalName
+	}
+	return r
 }
 
 // DisplayTitle returns the best title for display.
@@ -78,14 +116,101 @@ func (r SearchResult) Year() string {
 	return ""
 }
 
+// movieGenres maps TMDB's fixed movie genre IDs to their English names.
+var movieGenres = map[int]string{
+	12: "Adventure", 14: "Fantasy", 16: "Animation", 18: "Drama", 27: "Horror",
+	28: "Action", 35: "Comedy", 36: "History", 37: "Western", 53: "Thriller",
+	80: "Crime", 99: "Documentary", 878: "Science Fiction", 9648: "Mystery",
+	10402: "Music", 10749: "Romance", 10751: "Family", 10752: "War",
+	10770: "TV Movie",
+}
+
+// Genre returns the English name of the result's first known movie genre,
+// or "" when TMDB lists none. Search results list genre IDs; details lookups
+// list genres, whose names are used only for IDs outside movieGenres.
+func (r SearchResult) Genre() string {
+	for _, id := range r.GenreIDs {
+		if name, ok := movieGenres[id]; ok {
+			return name
+		}
+	}
+	for _, g := range r.Genres {
+		if name, ok := movieGenres[g.ID]; ok {
+			return name
+		}
+	}
+	for _, g := range r.Genres {
+		if g.Name != "" {
+			return g.Name
+		}
+	}
+	return ""
+}
+
+// Candidate returns r as a match candidate of mediaType, without a score.
+func (r SearchResult) Candidate(mediaType string) ripspec.MatchCandidate {
+	return ripspec.MatchCandidate{
+		ID:           r.ID,
+		Title:        r.DisplayTitle(),
+		MediaType:    mediaType,
+		Year:         r.Year(),
+		ReleaseDate:  r.ReleaseDate,
+		FirstAirDate: r.FirstAirDate,
+		Overview:     r.Overview,
+		VoteAverage:  r.VoteAverage,
+		VoteCount:    r.VoteCount,
+		PosterPath:   r.PosterPath,
+		BackdropPath: r.BackdropPath,
+		Genre:        r.Genre(),
+	}
+}
+
+// TVSeries contains TV series details, including the season list.
+type TVSeries struct {
+	ID               int             `json:"id"`
+	Name             string          `json:"name"`
+	OriginalName     string          `json:"original_name"`
+	Overview         string          `json:"overview"`
+	FirstAirDate     string          `json:"first_air_date"`
+	LastAirDate      string          `json:"last_air_date"`
+	NumberOfSeasons  int             `json:"number_of_seasons"`
+	NumberOfEpisodes int             `json:"number_of_episodes"`
+	EpisodeRunTime   []int           `json:"episode_run_time"`
+	Seasons          []SeasonSummary `json:"seasons"`
+}
+
+// RegularSeasons returns the numbered seasons, leaving out specials
+// (season 0).
+func (s *TVSeries) RegularSeasons() []SeasonSummary {
+	var regular []SeasonSummary
+	for _, season := range s.Seasons {
+		if season.SeasonNumber > 0 {
+			regular = append(regular, season)
+		}
+	}
+	return regular
+}
+
+// SeasonSummary is one entry of a series' season list.
+type SeasonSummary struct {
+	SeasonNumber int    `json:"season_number"`
+	Name         string `json:"name"`
+	EpisodeCount int    `json:"episode_count"`
+	AirDate      string `json:"air_date"`
+}
+
 // Season contains TV season information.
 type Season struct {
 	SeasonNumber int       `json:"season_number"`
+	Name         string    `json:"name"`
+	AirDate      string    `json:"air_date"`
 	Episodes     []Episode `json:"episodes"`
 }
 
 // Episode contains TV episode information.
 type Episode struct {
+	ID            int     `json:"id"`
+	SeasonNumber  int     `json:"season_number"`
 	EpisodeNumber int     `json:"episode_number"`
 	Name          string  `json:"name"`
 	Overview      string  `json:"overview"`
@@ -106,31 +231,139 @@ const maxRequestAttempts = 3
 
 var retryBaseDelay = time.Second // var so tests can shorten it
 
-// get builds a URL, sets the Authorization Bearer header, makes the GET request,
-// reads the body, and unmarshals into result, retrying transient failures.
+// minRequestInterval spaces request starts across all callers of a client,
+// keeping bursts of concurrent searches under TMDB's ~50 requests/second
+// limit. maxRateLimitPause caps a server-requested Retry-After.
+var (
+	minRequestInterval = 25 * time.Millisecond
+	maxRateLimitPause  = 30 * time.Second
+)
+
+// SetHTTPClient replaces the HTTP client used for TMDB requests.
+func (c *Client) SetHTTPClient(hc *http.Client) {
+	c.client = hc
+}
+
+// SetRegion sets the ISO 3166-1 region (for example "DE") sent with every
+// lookup, so release dates and certifications follow the user's locale.
+func (c *Client) SetRegion(region string) {
+	c.region = region
+}
+
+// fallbackLanguage fills fields TMDB has no translation for in the
+// configured language.
+const fallbackLanguage = "en-US"
+
+// needsFallback reports whether the client asks TMDB for a language other
+// than English, so untranslated fields may come back empty.
+func (c *Client) needsFallback() bool {
+	return !strings.HasPrefix(strings.ToLower(c.language), "en")
+}
+
+// SetCache attaches a response cache consulted before every lookup.
+func (c *Client) SetCache(cache *jsoncache.Store[json.RawMessage]) {
+	c.cache = cache
+}
+
+type bypassCacheKey struct{}
+
+// WithoutCache returns a context whose lookups skip cached responses. Fresh
+// responses are still written back, so a bypassed run also refreshes the
+// cache.
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassCacheKey{}, true)
+}
+
+type languageKey struct{}
+
+// WithLanguage returns a context whose lookups ask TMDB for language instead
+// of the client's configured one, e.g. to retry a search in a fallback
+// language.
+func WithLanguage(ctx context.Context, language string) context.Context {
+	return context.WithValue(ctx, languageKey{}, language)
+}
+
+// get fetches path and unmarshals the response into result, answering from
+// the response cache when one is attached and holds the same request.
 func (c *Client) get(ctx context.Context, path string, params url.Values, result any) error {
 	if params == nil {
 		params = url.Values{}
 	}
-	params.Set("language", c.language)
+	if params.Get("language") == "" {
+		language, _ := ctx.Value(languageKey{}).(string)
+		if language == "" {
+			language = c.language
+		}
+		params.Set("language", language)
+	}
+	if c.region != "" {
+		params.Set("region", c.region)
+	}
+	key := path + "?" + params.Encode()
+
+	if c.cache != nil && ctx.Value(bypassCacheKey{}) == nil {
+		body, ok, expired := c.cache.Get(key)
+		switch {
+		case ok && json.Unmarshal(body, result) == nil:
+			c.logger.Info("TMDB cache hit",
+				"decision_type", logs.DecisionTMDBCache,
+				"decision_result", "hit",
+				"decision_reason", "response cached within ttl",
+				"request", key,
+			)
+			return nil
+		case expired:
+			c.logger.Info("TMDB cache entry expired",
+				"decision_type", logs.DecisionTMDBCache,
+				"decision_result", "expired",
+				"decision_reason", fmt.Sprintf("older than ttl %s", c.cache.TTL()),
+				"request", key,
+			)
+		}
+	}
+
+	body, err := c.fetch(ctx, key)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("tmdb: decoding response: %w", err)
+	}
+	if c.cache != nil {
+		if err := c.cache.Put(key, body, nil); err != nil {
+			c.logger.Warn("TMDB response cache write failed",
+				"event_type", "tmdb_cache_write_failed",
+				"error_hint", err.Error(),
+				"impact", "the next identical lookup queries TMDB again",
+			)
+		}
+	}
+	return nil
+}
 
-	reqURL := c.baseURL + path + "?" + params.Encode()
+// fetch makes the GET request for pathAndQuery with the Authorization
+// Bearer header and returns the body, retrying transient failures.
+func (c *Client) fetch(ctx context.Context, pathAndQuery string) ([]byte, error) {
+	reqURL := c.baseURL + pathAndQuery
 
 	var lastErr error
 	for attempt := 1; attempt <= maxRequestAttempts; attempt++ {
 		if attempt > 1 {
 			select {
 			case <-ctx.Done():
-				return ctx.Err()
+				return nil, ctx.Err()
 			case <-time.After(retryBaseDelay << (attempt - 2)):
 			}
 		}
-		retryable, err := c.doGet(ctx, reqURL, result)
+		if err := c.throttle(ctx); err != nil {
+			return nil, err
+		}
+		body, retryable, err := c.doGet(ctx, reqURL)
 		if err == nil {
-			return nil
+			return body, nil
 		}
 		if !retryable || ctx.Err() != nil {
-			return err
+			return nil, err
 		}
 		lastErr = err
 		if attempt < maxRequestAttempts {
@@ -143,39 +376,99 @@ func (c *Client) get(ctx context.Context, path string, params url.Values, result
 			)
 		}
 	}
-	return fmt.Errorf("tmdb: %d attempts failed: %w", maxRequestAttempts, lastErr)
+	return nil, fmt.Errorf("tmdb: %d attempts failed: %w", maxRequestAttempts, lastErr)
 }
 
 // doGet performs one GET round trip. retryable reports whether the failure is
 // transient: network/read errors, HTTP 429, or 5xx.
-func (c *Client) doGet(ctx context.Context, reqURL string, result any) (retryable bool, err error) {
+func (c *Client) doGet(ctx context.Context, reqURL string) (body []byte, retryable bool, err error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
-		return false, fmt.Errorf("tmdb: creating request: %w", err)
+		return nil, false, fmt.Errorf("tmdb: creating request: %w", err)
 	}
 	req.Header.Set("Authorization", "Bearer "+c.apiKey)
 	req.Header.Set("Accept", "application/json")
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return true, fmt.Errorf("tmdb: request failed: %w", err)
+		return nil, true, fmt.Errorf("tmdb: request failed: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	body, err := io.ReadAll(resp.Body)
+	body, err = io.ReadAll(resp.Body)
 	if err != nil {
-		return true, fmt.Errorf("tmdb: reading response: %w", err)
+		return nil, true, fmt.Errorf("tmdb: reading response: %w", err)
 	}
 
+	if resp.StatusCode == http.StatusTooManyRequests {
+		c.pause(retryAfter(resp.Header.Get("Retry-After")))
+	}
 	if resp.StatusCode != http.StatusOK {
 		transient := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
-		return transient, fmt.Errorf("tmdb: unexpected status %d: %s", resp.StatusCode, string(body))
+		return nil, transient, fmt.Errorf("tmdb: unexpected status %d: %s", resp.StatusCode, string(body))
 	}
+	return body, false, nil
+}
 
-	if err := json.Unmarshal(body, result); err != nil {
-		return false, fmt.Errorf("tmdb: decoding response: %w", err)
+// throttle waits until this caller may start a request: minRequestInterval
+// after the previous start, and not before a shared 429 pause ends. The slot
+// is re-checked after every wait so a pause raised meanwhile still applies.
+func (c *Client) throttle(ctx context.Context) error {
+	for {
+		c.mu.Lock()
+		now := time.Now()
+		start := c.nextSlot
+		if c.pausedUntil.After(start) {
+			start = c.pausedUntil
+		}
+		if !start.After(now) {
+			c.nextSlot = now.Add(minRequestInterval)
+			c.mu.Unlock()
+			return nil
+		}
+		c.mu.Unlock()
+
+		timer := time.NewTimer(start.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// pause holds back every caller's next request for d after a 429.
+func (c *Client) pause(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if until := time.Now().Add(d); until.After(c.pausedUntil) {
+		c.pausedUntil = until
+	}
+}
+
+// retryAfter parses a Retry-After seconds value, falling back to the retry
+// base delay when it is absent or malformed.
+func retryAfter(header string) time.Duration {
+	secs, err := strconv.Atoi(strings.TrimSpace(header))
+	if err != nil || secs <= 0 {
+		return retryBaseDelay
 	}
-	return false, nil
+	return min(time.Duration(secs)*time.Second, maxRateLimitPause)
+}
+
+// CheckHealth verifies connectivity and the API key by hitting the
+// /configuration endpoint.
+func (c *Client) CheckHealth(ctx context.Context) error {
+	if c == nil {
+		return fmt.Errorf("tmdb: client not configured")
+	}
+	// Always a live request: a cached answer would prove nothing.
+	params := url.Values{"language": {c.language}}
+	if _, err := c.fetch(ctx, "/configuration?"+params.Encode()); err != nil {
+		return fmt.Errorf("tmdb health: %w", err)
+	}
+	return nil
 }
 
 // SearchTV searches for TV shows by name with an optional year filter.
@@ -208,6 +501,103 @@ func (c *Client) SearchMulti(ctx context.Context, query string) ([]SearchResult,
 	return resp.Results, nil
 }
 
+// SearchQuery is one search for SearchAll. MediaType "tv" runs a TV search
+// filtered by Year; anything else runs a multi search.
+type SearchQuery struct {
+	Query     string
+	Year      string
+	MediaType string
+}
+
+// SearchOutcome is the result of one SearchQuery.
+type SearchOutcome struct {
+	Results []SearchResult
+	Err     error
+}
+
+// SearchAll runs queries with at most limit in flight and returns outcomes
+// in query order, so callers select deterministically however the requests
+// complete. A failed query does not affect the others. All requests still
+// share the client's rate limit.
+func (c *Client) SearchAll(ctx context.Context, queries []SearchQuery, limit int) []SearchOutcome {
+	outcomes := make([]SearchOutcome, len(queries))
+	sem := make(chan struct{}, max(limit, 1))
+	var wg sync.WaitGroup
+	for i, q := range queries {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if q.MediaType == "tv" {
+				outcomes[i].Results, outcomes[i].Err = c.SearchTV(ctx, q.Query, q.Year)
+			} else {
+				outcomes[i].Results, outcomes[i].Err = c.SearchMulti(ctx, q.Query)
+			}
+		})
+	}
+	wg.Wait()
+	return outcomes
+}
+
+// GetTV retrieves TV series details, including its season list.
+func (c *Client) GetTV(ctx context.Context, tvID int) (*TVSeries, error) {
+	var s TVSeries
+	path := fmt.Sprintf("/tv/%d", tvID)
+	if err := c.get(ctx, path, nil, &s); err != nil {
+		return nil, err
+	}
+	if c.needsFallback() && (s.Name == "" || s.Overview == "") {
+		var en TVSeries
+		if c.getFallback(ctx, path, &en) {
+			fillEmpty(&s.Name, en.Name)
+			fillEmpty(&s.Overview, en.Overview)
+		}
+	}
+	return &s, nil
+}
+
+// GetDetails retrieves a movie or TV series by TMDB ID as a SearchResult,
+// so a known ID can stand in for a search match. mediaType is "movie" or
+// "tv"; an unknown ID returns the API's not-found error.
+func (c *Client) GetDetails(ctx context.Context, mediaType string, id int) (*SearchResult, error) {
+	if mediaType != "movie" && mediaType != "tv" {
+		return nil, fmt.Errorf("tmdb: unsupported media type %q", mediaType)
+	}
+	var r SearchResult
+	if err := c.get(ctx, fmt.Sprintf("/%s/%d", mediaType, id), nil, &r); err != nil {
+		return nil, err
+	}
+	if r.ID != id {
+		return nil, fmt.Errorf("tmdb: %s %d not found", mediaType, id)
+	}
+	r.MediaType = mediaType
+	c.FillUntranslated(ctx, &r)
+	return &r, nil
+}
+
+// FillUntranslated fills r's title and overview from TMDB's English data
+// when the configured language has no translation for them. It is a no-op
+// for English clients, complete results, and a nil client; a failed
+// fallback lookup leaves r as it was.
+func (c *Client) FillUntranslated(ctx context.Context, r *SearchResult) {
+	if c == nil || !c.needsFallback() || (r.DisplayTitle() != "" && r.Overview != "") {
+		return
+	}
+	mediaType := r.MediaType
+	if mediaType != "tv" {
+		mediaType = "movie"
+	}
+	var en SearchResult
+	if !c.getFallback(ctx, fmt.Sprintf("/%s/%d", mediaType, r.ID), &en) {
+		return
+	}
+	if mediaType == "tv" {
+		fillEmpty(&r.Name, en.Name)
+	} else {
+		fillEmpty(&r.Title, en.Title)
+	}
+	fillEmpty(&r.Overview, en.Overview)
+}
+
 // GetSeason retrieves TV season information including episodes.
 func (c *Client) GetSeason(ctx context.Context, tvID, season int) (*Season, error) {
 	var s Season
@@ -215,16 +605,144 @@ func (c *Client) GetSeason(ctx context.Context, tvID, season int) (*Season, erro
 	if err := c.get(ctx, path, nil, &s); err != nil {
 		return nil, err
 	}
+	if c.needsFallback() && slices.ContainsFunc(s.Episodes, func(e Episode) bool { return e.Name == "" || e.Overview == "" }) {
+		var en Season
+		if c.getFallback(ctx, path, &en) {
+			english := make(map[int]Episode, len(en.Episodes))
+			for _, e := range en.Episodes {
+				english[e.EpisodeNumber] = e
+			}
+			for i := range s.Episodes {
+				e := &s.Episodes[i]
+				fillEmpty(&e.Name, english[e.EpisodeNumber].Name)
+				fillEmpty(&e.Overview, english[e.EpisodeNumber].Overview)
+			}
+		}
+	}
 	return &s, nil
 }
 
+// getFallback fetches path in fallbackLanguage, reporting success. Failures
+// are logged and otherwise ignored: the localized response is still usable.
+func (c *Client) getFallback(ctx context.Context, path string, result any) bool {
+	if err := c.get(ctx, path, url.Values{"language": {fallbackLanguage}}, result); err != nil {
+		c.logger.Warn("TMDB English fallback lookup failed",
+			"event_type", "tmdb_language_fallback_failed",
+			"error_hint", err.Error(),
+			"impact", "untranslated fields stay empty",
+			"path", path,
+		)
+		return false
+	}
+	return true
+}
+
+func fillEmpty(dst *string, fallback string) {
+	if *dst == "" {
+		*dst = fallback
+	}
+}
+
+// ImageConfig is the image section of TMDB's /configuration response.
+// Image paths in API responses are relative and only become URLs when
+// joined with the base URL and one of the advertised sizes.
+type ImageConfig struct {
+	SecureBaseURL string   `json:"secure_base_url"`
+	PosterSizes   []string `json:"poster_sizes"`
+	BackdropSizes []string `json:"backdrop_sizes"`
+}
+
+// GetImageConfig retrieves the image base URL and available sizes.
+func (c *Client) GetImageConfig(ctx context.Context) (*ImageConfig, error) {
+	var resp struct {
+		Images ImageConfig `json:"images"`
+	}
+	if err := c.get(ctx, "/configuration", nil, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Images.SecureBaseURL == "" {
+		return nil, fmt.Errorf("tmdb: configuration has no image base URL")
+	}
+	return &resp.Images, nil
+}
+
+// PosterURL returns the full URL of a poster path at size, or "" when the
+// path is empty.
+func (ic *ImageConfig) PosterURL(path, size string) string {
+	return ic.imageURL(path, size, ic.PosterSizes)
+}
+
+// BackdropURL returns the full URL of a backdrop path at size, or "" when
+// the path is empty.
+func (ic *ImageConfig) BackdropURL(path, size string) string {
+	return ic.imageURL(path, size, ic.BackdropSizes)
+}
+
+// imageURL joins path to the base URL at size. A size TMDB does not offer
+// for this image type falls back to "original", which every type supports.
+func (ic *ImageConfig) imageURL(path, size string, sizes []string) string {
+	if path == "" {
+		return ""
+	}
+	if !slices.Contains(sizes, size) {
+		size = "original"
+	}
+	return strings.TrimSuffix(ic.SecureBaseURL, "/") + "/" + size + "/" + strings.TrimPrefix(path, "/")
+}
+
+// DownloadImage fetches imageURL and writes it to destPath. The file is
+// written through a temporary sibling so a failed download never leaves a
+// truncated image behind.
+func (c *Client) DownloadImage(ctx context.Context, imageURL, destPath string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return fmt.Errorf("tmdb: creating image request: %w", err)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("tmdb: image request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("tmdb: image request returned status %d", resp.StatusCode)
+	}
+
+	tmp := destPath + ".part"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("tmdb: create image file: %w", err)
+	}
+	_, copyErr := io.Copy(f, resp.Body)
+	closeErr := f.Close()
+	if err := errors.Join(copyErr, closeErr); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("tmdb: write image: %w", err)
+	}
+	if err := os.Rename(tmp, destPath); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("tmdb: write image: %w", err)
+	}
+	return nil
+}
+
+// DefaultMatchThreshold is the score a non-exact match needs, before the
+// vote-count term, for SelectBestResult to accept it.
+const DefaultMatchThreshold = 1.3
+
+// DefaultYearTolerance is how many years an exact title's release year may
+// differ from the searched year when no same-year title qualifies; regional
+// disc releases are often dated a year off TMDB.
+const DefaultYearTolerance = 1
+
 // Scoring and acceptance constants for TMDB search result ranking.
 const (
 	voteAverageDivisor          = 10.0
 	voteCountDivisor            = 1000.0
 	exactMatchMinVoteAverage    = 2.0
 	nonExactMatchMinVoteAverage = 3.0
-	nonExactMatchBaseThreshold  = 1.3
+	// nearYearPenalty is taken off a near-year match's score for each year
+	// its release year differs from the searched year.
+	nearYearPenalty = 0.25
 )
 
 // scoreResult computes the raw score for a single result against the query.
@@ -327,6 +845,78 @@ func releaseYear(r *SearchResult) int {
 	return y
 }
 
+// exactTitleMatch reports whether r's normalized title equals queryNorm and,
+// when year > 0, whether its release year matches too. An exact title from
+// the wrong year is not an exact match.
+func exactTitleMatch(queryNorm string, r *SearchResult, year int) (exact, yearMatch bool) {
+	exact = normalizeForComparison(r.DisplayTitle()) == queryNorm
+	yearMatch = true
+	if exact && year > 0 {
+		yearMatch = releaseYear(r) == year
+		exact = yearMatch
+	}
+	return exact, yearMatch
+}
+
+// nearYearScore scores an exact title released within tolerance years of
+// year. ok is false for any other result. The score drops by
+// nearYearPenalty per year of difference, so an exact-year match always
+// outscores the same title one year off.
+func nearYearScore(query, queryNorm string, r *SearchResult, year, tolerance int) (score float64, ok bool) {
+	if year <= 0 || tolerance <= 0 || normalizeForComparison(r.DisplayTitle()) != queryNorm {
+		return 0, false
+	}
+	ry := releaseYear(r)
+	if ry == 0 {
+		return 0, false
+	}
+	diff := ry - year
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff > tolerance {
+		return 0, false
+	}
+	return scoreResult(query, r) - float64(diff)*nearYearPenalty, true
+}
+
+// RankedResult is a search result with the score SelectBestResult gave it.
+type RankedResult struct {
+	SearchResult
+	Score      float64
+	ExactMatch bool
+}
+
+// RankResults scores results the way SelectBestResult does and orders them
+// best first: exact (year-aware) title matches ahead of the rest, then by
+// score. Unlike SelectBestResult it applies no acceptance thresholds, so it
+// ranks the alternatives a reviewer may pick from.
+func RankResults(results []SearchResult, query string, year int) []RankedResult {
+	queryNorm := normalizeForComparison(query)
+	ranked := make([]RankedResult, 0, len(results))
+	for i := range results {
+		r := &results[i]
+		exact, _ := exactTitleMatch(queryNorm, r, year)
+		ranked = append(ranked, RankedResult{SearchResult: *r, Score: scoreResult(query, r), ExactMatch: exact})
+	}
+	slices.SortStableFunc(ranked, func(a, b RankedResult) int {
+		if a.ExactMatch != b.ExactMatch {
+			if a.ExactMatch {
+				return -1
+			}
+			return 1
+		}
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		}
+		return 0
+	})
+	return ranked
+}
+
 // SelectBestResult scores each TMDB result and returns the best match, or nil
 // if no result meets acceptance thresholds.
 //
@@ -334,15 +924,17 @@ func releaseYear(r *SearchResult) int {
 //
 // Acceptance paths:
 //   - Exact match (normalized title equals query): voteAverage >= 2.0 AND voteCount >= minVoteCountExact.
-//   - Non-exact: voteAverage >= 3.0 AND score >= 1.3 + voteCount/1000.
+//   - Non-exact: voteAverage >= 3.0 AND score >= matchThreshold + voteCount/1000.
 //
 // Year-aware matching: when year > 0, an exact title match also requires the
 // result's release year to equal the provided year. This disambiguates
 // same-title films from different years.
 //
 // Preference: an exact match meeting its thresholds is preferred over a
-// higher-scoring non-exact result.
-func SelectBestResult(results []SearchResult, query string, year, minVoteCountExact int, logger *slog.Logger) *SearchResult {
+// higher-scoring non-exact result. When no same-year title qualifies, an
+// exact title released within yearTolerance years of year is preferred
+// next, at a penalized score; yearTolerance 0 disables this.
+func SelectBestResult(results []SearchResult, query string, year, yearTolerance, minVoteCountExact int, matchThreshold float64, logger *slog.Logger) *SearchResult {
 	if len(results) == 0 {
 		return nil
 	}
@@ -354,18 +946,13 @@ func SelectBestResult(results []SearchResult, query string, year, minVoteCountEx
 	var bestIsExact bool
 	var bestExact *SearchResult
 	var bestExactScore float64
+	var bestNear *SearchResult
+	var bestNearScore float64
 
 	for i := range results {
 		r := &results[i]
 		score := scoreResult(query, r)
-		titleNorm := normalizeForComparison(r.DisplayTitle())
-
-		exactMatch := titleNorm == queryNorm
-		yearMatch := true
-		if exactMatch && year > 0 {
-			yearMatch = releaseYear(r) == year
-			exactMatch = yearMatch
-		}
+		exactMatch, yearMatch := exactTitleMatch(queryNorm, r, year)
 
 		logger.Debug("TMDB candidate scored",
 			"decision_type", logs.DecisionTMDBSearch,
@@ -381,6 +968,12 @@ func SelectBestResult(results []SearchResult, query string, year, minVoteCountEx
 			bestExact = r
 			bestExactScore = score
 		}
+		if !yearMatch {
+			if near, ok := nearYearScore(query, queryNorm, r, year, yearTolerance); ok && (bestNear == nil || near > bestNearScore) {
+				bestNear = r
+				bestNearScore = near
+			}
+		}
 		if score > bestScore {
 			best = r
 			bestScore = score
@@ -406,6 +999,16 @@ func SelectBestResult(results []SearchResult, query string, year, minVoteCountEx
 		selected = bestExact
 		selectedScore = bestExactScore
 		selectedExact = true
+	} else if bestNear != nil && bestNear.VoteAverage >= exactMatchMinVoteAverage &&
+		bestNear.VoteCount >= minVoteCountExact {
+		logger.Info("TMDB near-year match preferred",
+			"decision_type", logs.DecisionTMDBMatchPreference,
+			"decision_result", "near_year_preferred",
+			"decision_reason", fmt.Sprintf("title=%q search_year=%d release_year=%s score=%.3f", bestNear.DisplayTitle(), year, bestNear.Year(), bestNearScore),
+		)
+		selected = bestNear
+		selectedScore = bestNearScore
+		selectedExact = true
 	}
 
 	// Apply acceptance thresholds.
@@ -427,20 +1030,27 @@ func SelectBestResult(results []SearchResult, query string, year, minVoteCountEx
 			)
 			return nil
 		}
-		if selectedScore < nonExactMatchBaseThreshold+float64(selected.VoteCount)/voteCountDivisor {
+		threshold := matchThreshold + float64(selected.VoteCount)/voteCountDivisor
+		if selectedScore < threshold {
 			logger.Info("TMDB match rejected",
 				"decision_type", logs.DecisionTMDBMatch,
 				"decision_result", "rejected",
-				"decision_reason", fmt.Sprintf("non-exact match score below threshold: title=%q score=%.3f threshold=%.3f", selected.DisplayTitle(), selectedScore, nonExactMatchBaseThreshold+float64(selected.VoteCount)/voteCountDivisor),
+				"decision_reason", fmt.Sprintf("non-exact match score below threshold: title=%q score=%.3f threshold=%.3f", selected.DisplayTitle(), selectedScore, threshold),
 			)
 			return nil
 		}
+		logger.Info("TMDB match selected",
+			"decision_type", logs.DecisionTMDBMatch,
+			"decision_result", "accepted",
+			"decision_reason", fmt.Sprintf("title=%q score=%.3f threshold=%.3f exact=false vote_avg=%.1f vote_count=%d", selected.DisplayTitle(), selectedScore, threshold, selected.VoteAverage, selected.VoteCount),
+		)
+		return selected
 	}
 
 	logger.Info("TMDB match selected",
 		"decision_type", logs.DecisionTMDBMatch,
 		"decision_result", "accepted",
-		"decision_reason", fmt.Sprintf("title=%q score=%.3f exact=%v vote_avg=%.1f vote_count=%d", selected.DisplayTitle(), selectedScore, selectedExact, selected.VoteAverage, selected.VoteCount),
+		"decision_reason", fmt.Sprintf("title=%q score=%.3f exact=true vote_avg=%.1f vote_count=%d", selected.DisplayTitle(), selectedScore, selected.VoteAverage, selected.VoteCount),
 	)
 	return selected
 }
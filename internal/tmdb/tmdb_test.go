@@ -7,8 +7,17 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/five82/spindle/internal/jsoncache"
 )
 
 func TestDisplayTitle(t *testing.T) {
@@ -97,7 +106,7 @@ func TestSelectBestResult_ExactMatchAccepted(t *testing.T) {
 		{ID: 1, Title: "Other Movie", VoteAverage: 7.0, VoteCount: 100},
 		{ID: 2, Title: "Inception", ReleaseDate: "2010-07-16", VoteAverage: 8.4, VoteCount: 5000},
 	}
-	best := SelectBestResult(results, "Inception", 0, 5, slog.Default())
+	best := SelectBestResult(results, "Inception", 0, DefaultYearTolerance, 5, DefaultMatchThreshold, slog.Default())
 	if best == nil {
 		t.Fatal("expected a result, got nil")
 	}
@@ -110,7 +119,7 @@ func TestSelectBestResult_ExactMatchRejectedLowVotes(t *testing.T) {
 	results := []SearchResult{
 		{ID: 1, Title: "Munich", ReleaseDate: "1972-01-01", VoteAverage: 5.0, VoteCount: 0},
 	}
-	best := SelectBestResult(results, "Munich", 0, 5, slog.Default())
+	best := SelectBestResult(results, "Munich", 0, DefaultYearTolerance, 5, DefaultMatchThreshold, slog.Default())
 	if best != nil {
 		t.Errorf("expected nil (below vote threshold), got ID %d", best.ID)
 	}
@@ -123,7 +132,7 @@ func TestSelectBestResult_NonExactAccepted(t *testing.T) {
 	results := []SearchResult{
 		{ID: 1, Title: "Inception: The Beginning", VoteAverage: 8.4, VoteCount: 5000},
 	}
-	best := SelectBestResult(results, "Inception", 0, 5, slog.Default())
+	best := SelectBestResult(results, "Inception", 0, DefaultYearTolerance, 5, DefaultMatchThreshold, slog.Default())
 	if best == nil {
 		t.Fatal("expected a result, got nil")
 	}
@@ -136,7 +145,7 @@ func TestSelectBestResult_NonExactRejectedLowAverage(t *testing.T) {
 	results := []SearchResult{
 		{ID: 1, Title: "Inception: The Beginning", VoteAverage: 2.0, VoteCount: 5000},
 	}
-	best := SelectBestResult(results, "Inception", 0, 5, slog.Default())
+	best := SelectBestResult(results, "Inception", 0, DefaultYearTolerance, 5, DefaultMatchThreshold, slog.Default())
 	if best != nil {
 		t.Errorf("expected nil (vote_average below 3.0), got ID %d", best.ID)
 	}
@@ -149,7 +158,7 @@ func TestSelectBestResult_ExactPreferredOverHigherScore(t *testing.T) {
 		// Exact match with lower score.
 		{ID: 2, Title: "Munich", ReleaseDate: "2005-12-23", VoteAverage: 7.0, VoteCount: 3000},
 	}
-	best := SelectBestResult(results, "Munich", 0, 5, slog.Default())
+	best := SelectBestResult(results, "Munich", 0, DefaultYearTolerance, 5, DefaultMatchThreshold, slog.Default())
 	if best == nil {
 		t.Fatal("expected a result, got nil")
 	}
@@ -163,7 +172,7 @@ func TestSelectBestResult_YearDisambiguation(t *testing.T) {
 		{ID: 1, Title: "Dune", ReleaseDate: "1984-12-14", VoteAverage: 6.0, VoteCount: 500},
 		{ID: 2, Title: "Dune", ReleaseDate: "2021-10-22", VoteAverage: 7.8, VoteCount: 8000},
 	}
-	best := SelectBestResult(results, "Dune", 2021, 5, slog.Default())
+	best := SelectBestResult(results, "Dune", 2021, DefaultYearTolerance, 5, DefaultMatchThreshold, slog.Default())
 	if best == nil {
 		t.Fatal("expected a result, got nil")
 	}
@@ -172,8 +181,62 @@ func TestSelectBestResult_YearDisambiguation(t *testing.T) {
 	}
 }
 
+func TestSelectBestResult_MatchThreshold(t *testing.T) {
+	// Non-exact title scoring 1 + 6.0/10 + 100/1000 = 1.7; the vote-count
+	// term is added to the threshold too, so the cutoff is threshold + 0.1.
+	results := []SearchResult{{ID: 1, Title: "Inception: The Cobol Job", VoteAverage: 6.0, VoteCount: 100}}
+	for threshold, wantMatch := range map[float64]bool{1.55: true, 1.59: true, 1.61: false, 1.8: false} {
+		best := SelectBestResult(results, "Inception", 0, DefaultYearTolerance, 5, threshold, slog.Default())
+		if (best != nil) != wantMatch {
+			t.Errorf("threshold %g: got %+v, want match %v", threshold, best, wantMatch)
+		}
+	}
+}
+
+func TestSelectBestResultNearYear(t *testing.T) {
+	// A regional release printed 2005 on the disc; TMDB dates it 2006.
+	results := []SearchResult{
+		{ID: 1, Title: "Tsotsi", ReleaseDate: "2006-02-24", VoteAverage: 2.8, VoteCount: 300},
+		{ID: 2, Title: "Tsotsi Returns", ReleaseDate: "2005-01-01", VoteAverage: 2.5, VoteCount: 20},
+	}
+	if best := SelectBestResult(results, "Tsotsi", 2005, DefaultYearTolerance, 5, DefaultMatchThreshold, slog.Default()); best == nil || best.ID != 1 {
+		t.Fatalf("one year off: got %+v, want ID 1", best)
+	}
+	if best := SelectBestResult(results, "Tsotsi", 2003, DefaultYearTolerance, 5, DefaultMatchThreshold, slog.Default()); best != nil {
+		t.Errorf("three years off: expected nil, got ID %d", best.ID)
+	}
+	if best := SelectBestResult(results, "Tsotsi", 2005, 0, 5, DefaultMatchThreshold, slog.Default()); best != nil {
+		t.Errorf("tolerance 0: expected nil, got ID %d", best.ID)
+	}
+}
+
+func TestNearYearScoreBelowExactYear(t *testing.T) {
+	exactYear := SearchResult{Title: "Heat", ReleaseDate: "1995-12-15", VoteAverage: 7.9, VoteCount: 7000}
+	offByOne := exactYear
+	offByOne.ReleaseDate = "1996-02-01"
+	norm := normalizeForComparison("Heat")
+
+	exactScore, ok := nearYearScore("Heat", norm, &exactYear, 1995, DefaultYearTolerance)
+	if !ok {
+		t.Fatal("exact year: not accepted")
+	}
+	nearScore, ok := nearYearScore("Heat", norm, &offByOne, 1995, DefaultYearTolerance)
+	if !ok {
+		t.Fatal("one year off: not accepted")
+	}
+	if nearScore >= exactScore {
+		t.Errorf("one-year-off score %.3f should be below exact-year score %.3f", nearScore, exactScore)
+	}
+
+	results := []SearchResult{offByOne, exactYear}
+	results[0].ID, results[1].ID = 1, 2
+	if best := SelectBestResult(results, "Heat", 1995, DefaultYearTolerance, 5, DefaultMatchThreshold, slog.Default()); best == nil || best.ID != 2 {
+		t.Errorf("expected exact-year result ID 2 preferred, got %+v", best)
+	}
+}
+
 func TestSelectBestResult_NoResults(t *testing.T) {
-	best := SelectBestResult(nil, "Inception", 0, 5, slog.Default())
+	best := SelectBestResult(nil, "Inception", 0, DefaultYearTolerance, 5, DefaultMatchThreshold, slog.Default())
 	if best != nil {
 		t.Errorf("expected nil, got %+v", best)
 	}
@@ -224,7 +287,7 @@ func TestSelectBestResult_AliasMatchAccepted(t *testing.T) {
 		{ID: 1, Name: "Star Trek: The Next Generation", FirstAirDate: "1987-09-28", VoteAverage: 8.4, VoteCount: 1775, MediaType: "tv"},
 		{ID: 2, Name: "Star Trek", FirstAirDate: "1966-09-08", VoteAverage: 7.8, VoteCount: 900, MediaType: "tv"},
 	}
-	best := SelectBestResult(results, "Star Trek TNG", 0, 5, slog.Default())
+	best := SelectBestResult(results, "Star Trek TNG", 0, DefaultYearTolerance, 5, DefaultMatchThreshold, slog.Default())
 	if best == nil {
 		t.Fatal("expected a result, got nil")
 	}
@@ -269,6 +332,88 @@ func TestGetSeason_HTTPTest(t *testing.T) {
 	}
 }
 
+func TestLocalizedLookupsSendLanguageAndRegion(t *testing.T) {
+	var queries []url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/search/multi":
+			_, _ = w.Write([]byte(`{"results":[{"id":680,"title":"Pulp Fiction","overview":"Die Geschichte zweier Gangster.","media_type":"movie"}]}`))
+		case "/movie/680":
+			_, _ = w.Write([]byte(`{"id":680,"title":"Pulp Fiction","overview":"Die Geschichte zweier Gangster."}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	client := New("test-key", srv.URL, "de-DE", nil)
+	client.SetRegion("DE")
+	results, err := client.SearchMulti(context.Background(), "Pulp Fiction")
+	if err != nil {
+		t.Fatalf("SearchMulti: %v", err)
+	}
+	if len(results) != 1 || results[0].Overview != "Die Geschichte zweier Gangster." {
+		t.Fatalf("results = %+v, want the localized overview", results)
+	}
+	details, err := client.GetDetails(context.Background(), "movie", 680)
+	if err != nil {
+		t.Fatalf("GetDetails: %v", err)
+	}
+	if details.Overview != "Die Geschichte zweier Gangster." {
+		t.Fatalf("overview = %q, want the localized overview", details.Overview)
+	}
+	if len(queries) != 2 {
+		t.Fatalf("made %d requests, want 2 with no English fallback for complete data", len(queries))
+	}
+	for _, q := range queries {
+		if q.Get("language") != "de-DE" || q.Get("region") != "DE" {
+			t.Errorf("query = %v, want language=de-DE and region=DE", q)
+		}
+	}
+}
+
+func TestUntranslatedFieldsFallBackToEnglish(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		english := r.URL.Query().Get("language") == "en-US"
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/tv/1396" && english:
+			_, _ = w.Write([]byte(`{"id":1396,"name":"Breaking Bad","overview":"A chemistry teacher turns to crime."}`))
+		case r.URL.Path == "/tv/1396":
+			_, _ = w.Write([]byte(`{"id":1396,"name":"Breaking Bad","overview":""}`))
+		case r.URL.Path == "/tv/1396/season/1" && english:
+			_, _ = w.Write([]byte(`{"season_number":1,"episodes":[{"episode_number":1,"name":"Pilot","overview":"Walt is diagnosed."},{"episode_number":2,"name":"Cat's in the Bag...","overview":"Walt and Jesse clean up."}]}`))
+		case r.URL.Path == "/tv/1396/season/1":
+			_, _ = w.Write([]byte(`{"season_number":1,"episodes":[{"episode_number":1,"name":"Der Einstieg","overview":"Walt erhält eine Diagnose."},{"episode_number":2,"name":"","overview":""}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	client := New("test-key", srv.URL, "de-DE", nil)
+	details, err := client.GetDetails(context.Background(), "tv", 1396)
+	if err != nil {
+		t.Fatalf("GetDetails: %v", err)
+	}
+	if details.Overview != "A chemistry teacher turns to crime." {
+		t.Errorf("overview = %q, want the English fallback", details.Overview)
+	}
+
+	season, err := client.GetSeason(context.Background(), 1396, 1)
+	if err != nil {
+		t.Fatalf("GetSeason: %v", err)
+	}
+	if ep := season.Episodes[0]; ep.Name != "Der Einstieg" || ep.Overview != "Walt erhält eine Diagnose." {
+		t.Errorf("episode 1 = %q / %q, want the translation kept", ep.Name, ep.Overview)
+	}
+	if ep := season.Episodes[1]; ep.Name != "Cat's in the Bag..." || ep.Overview != "Walt and Jesse clean up." {
+		t.Errorf("episode 2 = %q / %q, want the English fallback", ep.Name, ep.Overview)
+	}
+}
+
 func TestAuthHeader(t *testing.T) {
 	var gotAuth string
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -393,3 +538,278 @@ func TestGetStopsRetryingOnContextCancel(t *testing.T) {
 		t.Errorf("server calls = %d, want 1 (canceled during backoff)", calls)
 	}
 }
+
+func TestSearchAllPreservesQueryOrder(t *testing.T) {
+	withFastRetry(t)
+	var inFlight, peak atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		query := r.URL.Query().Get("query")
+		if query == "broken" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// Earlier queries answer slower so completion order is reversed.
+		delay, _ := strconv.Atoi(strings.TrimPrefix(query, "q"))
+		time.Sleep(time.Duration(5-delay) * 20 * time.Millisecond)
+		_ = json.NewEncoder(w).Encode(searchResponse{Results: []SearchResult{{ID: delay, Name: query + r.URL.Path}}})
+	}))
+	defer srv.Close()
+
+	client := New("key", srv.URL, "", nil)
+	queries := []SearchQuery{
+		{Query: "q1", MediaType: "tv"},
+		{Query: "q2"},
+		{Query: "broken"},
+		{Query: "q3", MediaType: "tv"},
+		{Query: "q4"},
+	}
+	outcomes := client.SearchAll(context.Background(), queries, 3)
+	if len(outcomes) != len(queries) {
+		t.Fatalf("outcomes = %d, want %d", len(outcomes), len(queries))
+	}
+	want := []string{"q1/search/tv", "q2/search/multi", "", "q3/search/tv", "q4/search/multi"}
+	for i, o := range outcomes {
+		if want[i] == "" {
+			if o.Err == nil {
+				t.Errorf("outcome %d: expected error", i)
+			}
+			continue
+		}
+		if o.Err != nil || len(o.Results) != 1 || o.Results[0].Name != want[i] {
+			t.Errorf("outcome %d = %+v, want %q", i, o, want[i])
+		}
+	}
+	if p := peak.Load(); p > 3 {
+		t.Errorf("peak concurrency = %d, want <= 3", p)
+	}
+}
+
+func TestRateLimitPausesAllCallers(t *testing.T) {
+	withFastRetry(t)
+	prev := retryBaseDelay
+	retryBaseDelay = 100 * time.Millisecond
+	t.Cleanup(func() { retryBaseDelay = prev })
+
+	var mu sync.Mutex
+	var limitedAt time.Time
+	var afterLimit []time.Duration
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if limitedAt.IsZero() {
+			limitedAt = time.Now()
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		afterLimit = append(afterLimit, time.Since(limitedAt))
+		_, _ = w.Write([]byte(`{"results":[]}`))
+	}))
+	defer srv.Close()
+
+	client := New("key", srv.URL, "", nil)
+	queries := []SearchQuery{{Query: "a"}, {Query: "b"}, {Query: "c"}}
+	for i, o := range client.SearchAll(context.Background(), queries, len(queries)) {
+		if o.Err != nil {
+			t.Fatalf("query %d: %v", i, o.Err)
+		}
+	}
+	if len(afterLimit) != len(queries) {
+		t.Fatalf("requests after 429 = %d, want %d", len(afterLimit), len(queries))
+	}
+	for i, d := range afterLimit {
+		if d < 90*time.Millisecond {
+			t.Errorf("request %d started %s after the 429, want the shared pause honored", i, d)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	withFastRetry(t)
+	if got := retryAfter("2"); got != 2*time.Second {
+		t.Errorf("retryAfter(2) = %s, want 2s", got)
+	}
+	if got := retryAfter("3600"); got != maxRateLimitPause {
+		t.Errorf("retryAfter(3600) = %s, want cap %s", got, maxRateLimitPause)
+	}
+	if got := retryAfter(""); got != retryBaseDelay {
+		t.Errorf("retryAfter(\"\") = %s, want retry base delay", got)
+	}
+}
+
+func TestCachedSearchSkipsHTTP(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"results":[{"id":27205,"title":"Inception"}]}`))
+	}))
+	defer srv.Close()
+
+	cache, err := jsoncache.Open[json.RawMessage](filepath.Join(t.TempDir(), "tmdb_cache.json"), time.Hour)
+	if err != nil {
+		t.Fatalf("open cache: %v", err)
+	}
+	client := New("key", srv.URL, "", nil)
+	client.SetCache(cache)
+	ctx := context.Background()
+
+	for i := range 2 {
+		results, err := client.SearchMulti(ctx, "inception")
+		if err != nil {
+			t.Fatalf("SearchMulti #%d: %v", i+1, err)
+		}
+		if len(results) != 1 || results[0].ID != 27205 {
+			t.Fatalf("SearchMulti #%d results = %+v", i+1, results)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("server calls = %d, want 1 (second search served from cache)", got)
+	}
+
+	// A different query is a different key.
+	if _, err := client.SearchMulti(ctx, "interstellar"); err != nil {
+		t.Fatal(err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("server calls = %d, want 2 after a new query", got)
+	}
+
+	// Bypassing goes to TMDB even for a cached query.
+	if _, err := client.SearchMulti(WithoutCache(ctx), "inception"); err != nil {
+		t.Fatal(err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Fatalf("server calls = %d, want 3 after bypass", got)
+	}
+
+	// Health checks are never answered from the cache.
+	for range 2 {
+		if err := client.CheckHealth(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := calls.Load(); got != 5 {
+		t.Fatalf("server calls = %d, want 5 after two health checks", got)
+	}
+}
+
+// recordedTVServer serves trimmed TMDB TV responses from testdata, keyed by
+// request path, and records the query of every request it answers.
+func recordedTVServer(t *testing.T, queries *[]url.Values) *httptest.Server {
+	t.Helper()
+	fixtures := map[string]string{
+		"/search/tv":        "search_tv_breaking_bad.json",
+		"/tv/1396":          "tv_1396.json",
+		"/tv/1396/season/1": "tv_1396_season_1.json",
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, ok := fixtures[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		*queries = append(*queries, r.URL.Query())
+		data, err := os.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			t.Errorf("read fixture: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRecordedTVResponses(t *testing.T) {
+	var queries []url.Values
+	srv := recordedTVServer(t, &queries)
+	client := New("key", srv.URL, "en-US", nil)
+	client.SetHTTPClient(srv.Client())
+	ctx := context.Background()
+
+	results, err := client.SearchTV(ctx, "Breaking Bad", "2008")
+	if err != nil {
+		t.Fatalf("SearchTV: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != 1396 || results[0].MediaType != "tv" || results[0].Year() != "2008" {
+		t.Fatalf("SearchTV results = %+v", results)
+	}
+	if got := queries[0].Get("first_air_date_year"); got != "2008" {
+		t.Errorf("first_air_date_year = %q, want 2008", got)
+	}
+
+	series, err := client.GetTV(ctx, 1396)
+	if err != nil {
+		t.Fatalf("GetTV: %v", err)
+	}
+	if series.Name != "Breaking Bad" || series.NumberOfSeasons != 5 || len(series.Seasons) != 6 {
+		t.Fatalf("GetTV = %+v", series)
+	}
+	regular := series.RegularSeasons()
+	if len(regular) != 5 || regular[0].SeasonNumber != 1 || regular[0].EpisodeCount != 7 {
+		t.Fatalf("RegularSeasons = %+v, want seasons 1-5 without specials", regular)
+	}
+
+	season, err := client.GetSeason(ctx, 1396, 1)
+	if err != nil {
+		t.Fatalf("GetSeason: %v", err)
+	}
+	if season.Name != "Season 1" || len(season.Episodes) != 3 {
+		t.Fatalf("GetSeason = %+v", season)
+	}
+	ep := season.Episodes[1]
+	if ep.ID != 62086 || ep.SeasonNumber != 1 || ep.EpisodeNumber != 2 || ep.Runtime != 49 {
+		t.Fatalf("episode 2 = %+v", ep)
+	}
+}
+
+func TestImageConfigResolvesURLs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/configuration" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"images":{"secure_base_url":"https://image.tmdb.org/t/p/","poster_sizes":["w342","w780","original"],"backdrop_sizes":["w300","w1280","original"]}}`))
+	}))
+	defer srv.Close()
+
+	client := New("key", srv.URL, "en-US", nil)
+	images, err := client.GetImageConfig(context.Background())
+	if err != nil {
+		t.Fatalf("GetImageConfig: %v", err)
+	}
+	if got, want := images.PosterURL("/ggFHVNu6YYI5L9pCfOacjizRGt.jpg", "w780"), "https://image.tmdb.org/t/p/w780/ggFHVNu6YYI5L9pCfOacjizRGt.jpg"; got != want {
+		t.Errorf("PosterURL = %q, want %q", got, want)
+	}
+	// w780 is a poster size only; backdrops fall back to original.
+	if got, want := images.BackdropURL("/tsRy63Mu5cu8etL1X7ZLyf7UP1M.jpg", "w780"), "https://image.tmdb.org/t/p/original/tsRy63Mu5cu8etL1X7ZLyf7UP1M.jpg"; got != want {
+		t.Errorf("BackdropURL = %q, want %q", got, want)
+	}
+	if got := images.PosterURL("", "w780"); got != "" {
+		t.Errorf("PosterURL of missing image = %q, want empty", got)
+	}
+}
+
+func TestSearchResultGenre(t *testing.T) {
+	if got := (SearchResult{GenreIDs: []int{99999, 878, 28}}).Genre(); got != "Science Fiction" {
+		t.Errorf("Genre() = %q, want first known genre", got)
+	}
+	details := SearchResult{Genres: []Genre{{ID: 99999, Name: "Anime"}, {ID: 18, Name: "Drama"}}}
+	if got := details.Genre(); got != "Drama" {
+		t.Errorf("Genre() = %q, want the first known genre of a details lookup", got)
+	}
+	if got := (SearchResult{Genres: []Genre{{ID: 99999, Name: "Anime"}}}).Genre(); got != "Anime" {
+		t.Errorf("Genre() = %q, want the listed name of an unknown genre", got)
+	}
+	if got := (SearchResult{}).Genre(); got != "" {
+		t.Errorf("Genre() = %q, want empty without genres", got)
+	}
+}
@@ -0,0 +1,71 @@
+package transcription
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/five82/spindle/internal/srtutil"
+)
+
+// whisperXSegment is the subset of a WhisperX JSON segment needed for text
+// extraction. Word scores are WhisperX alignment confidences in [0, 1].
+type whisperXSegment struct {
+	Text  string `json:"text"`
+	Words []struct {
+		Score *float64 `json:"score"`
+	} `json:"words"`
+}
+
+// TranscriptText returns the flattened plain text of a canonical transcript.
+// With minConfidence <= 0 it is the SRT cue text, unfiltered. Otherwise the
+// WhisperX JSON next to the SRT is read and segments whose mean word score
+// falls below minConfidence are dropped; such segments are mostly
+// hallucinations on music or noise and skew similarity scoring. Segments
+// without any scored words are kept, since there is no evidence against them.
+func TranscriptText(srtPath, jsonPath string, minConfidence float64) (string, error) {
+	if minConfidence <= 0 {
+		cues, err := srtutil.ParseFile(srtPath)
+		if err != nil {
+			return "", err
+		}
+		return srtutil.PlainText(cues), nil
+	}
+	data, err := os.ReadFile(jsonPath)
+	if err != nil {
+		return "", fmt.Errorf("read whisperx json: %w", err)
+	}
+	var payload struct {
+		Segments []whisperXSegment `json:"segments"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return "", fmt.Errorf("parse whisperx json: %w", err)
+	}
+	kept := make([]srtutil.Cue, 0, len(payload.Segments))
+	for _, seg := range payload.Segments {
+		if score, ok := seg.meanScore(); ok && score < minConfidence {
+			continue
+		}
+		kept = append(kept, srtutil.Cue{Text: strings.TrimSpace(seg.Text)})
+	}
+	return srtutil.PlainText(kept), nil
+}
+
+// meanScore averages the scored words of the segment; ok is false when no
+// word carries a score (WhisperX omits scores for unalignable tokens).
+func (s whisperXSegment) meanScore() (float64, bool) {
+	var sum float64
+	var n int
+	for _, w := range s.Words {
+		if w.Score == nil {
+			continue
+		}
+		sum += *w.Score
+		n++
+	}
+	if n == 0 {
+		return 0, false
+	}
+	return sum / float64(n), true
+}
@@ -9,6 +9,9 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -20,9 +23,22 @@ import (
 type Service struct {
 	model       string
 	cudaEnabled bool
+	computeType string
+	deviceIndex int
 	vadMethod   string
 	hfToken     string
+	workers     int
 	logger      *slog.Logger
+
+	// warmed records a completed WhisperX run, after which the uvx
+	// environment and model weights are cached and concurrent runs only
+	// read them.
+	warmed atomic.Bool
+	// slots caps WhisperX processes across every caller at workers, so
+	// concurrent episodes never load more model copies than the GPU was
+	// sized for; cold admits one run at a time until warmed.
+	slots chan struct{}
+	cold  sync.Mutex
 }
 
 // Params holds the fields New needs from config.SubtitlesConfig's WhisperX-
@@ -30,8 +46,17 @@ type Service struct {
 type Params struct {
 	Model       string
 	CUDAEnabled bool
+	// ComputeType overrides the CTranslate2 compute type. Empty selects
+	// float16 on CUDA and int8 on CPU.
+	ComputeType string
+	// DeviceIndex selects the GPU when CUDA is enabled.
+	DeviceIndex int
 	VADMethod   string
 	HFToken     string
+	// Workers is the number of WhisperX processes a batch may run at once.
+	// Each loads its own model copy, so it is bounded by GPU memory.
+	// Values below 1 mean 1.
+	Workers int
 }
 
 // New creates a transcription service.
@@ -48,9 +73,13 @@ func New(p Params, logger *slog.Logger) *Service {
 	return &Service{
 		model:       model,
 		cudaEnabled: p.CUDAEnabled,
+		computeType: p.ComputeType,
+		deviceIndex: p.DeviceIndex,
 		vadMethod:   vadMethod,
 		hfToken:     p.HFToken,
+		workers:     max(p.Workers, 1),
 		logger:      logger,
+		slots:       make(chan struct{}, max(p.Workers, 1)),
 	}
 }
 
@@ -140,6 +169,9 @@ func (s *Service) Transcribe(ctx context.Context, req TranscribeRequest, progres
 //
 // If progress is non-nil, it is called at the start and end of each phase
 // (once per phase for the whole batch).
+//
+// With more than one worker configured the requests are split into
+// contiguous shards that run as concurrent batches; see transcribeSharded.
 func (s *Service) TranscribeBatch(ctx context.Context, reqs []TranscribeRequest, progress ...ProgressFunc) ([]*TranscribeResult, error) {
 	if len(reqs) == 0 {
 		return nil, fmt.Errorf("transcribe batch: no requests")
@@ -162,7 +194,95 @@ func (s *Service) TranscribeBatch(ctx context.Context, reqs []TranscribeRequest,
 			return nil, fmt.Errorf("transcribe batch: mixed models %q and %q", model, m)
 		}
 	}
+	if s.workers > 1 && len(reqs) > 1 {
+		return s.transcribeSharded(ctx, reqs, model, onProgress)
+	}
+	return s.transcribeBatch(ctx, reqs, model, onProgress)
+}
+
+// transcribeSharded splits reqs into at most s.workers contiguous shards and
+// runs each as its own batch, returning results in request order. Shards
+// write only to their requests' output directories. Until one WhisperX run
+// has completed, the first shard runs alone so a cold uvx environment or
+// model download is populated once rather than by racing processes. The
+// first shard error cancels the rest. progress, if set, sees a single
+// transcribe phase spanning every shard.
+func (s *Service) transcribeSharded(ctx context.Context, reqs []TranscribeRequest, model string, onProgress ProgressFunc) ([]*TranscribeResult, error) {
+	shards := shardRequests(len(reqs), s.workers)
+	results := make([]*TranscribeResult, len(reqs))
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+	)
+	run := func(shard [2]int) {
+		shardResults, err := s.transcribeBatch(ctx, reqs[shard[0]:shard[1]], model, nil)
+		if err != nil {
+			mu.Lock()
+			if firstErr == nil {
+				firstErr = err
+				cancel()
+			}
+			mu.Unlock()
+			return
+		}
+		copy(results[shard[0]:shard[1]], shardResults)
+	}
 
+	if onProgress != nil {
+		onProgress(PhaseTranscribe, 0)
+	}
+	start := time.Now()
+	s.logger.Info("running sharded WhisperX transcription",
+		transcriptionLogFields(reqs[0],
+			"event_type", "transcription_sharded",
+			"batch_files", len(reqs),
+			"shards", len(shards),
+			"cold_start", !s.warmed.Load(),
+		)...,
+	)
+	if !s.warmed.Load() {
+		run(shards[0])
+		if firstErr != nil {
+			return nil, firstErr
+		}
+		shards = shards[1:]
+	}
+	var wg sync.WaitGroup
+	for _, shard := range shards {
+		wg.Go(func() { run(shard) })
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if onProgress != nil {
+		onProgress(PhaseTranscribe, time.Since(start))
+	}
+	return results, nil
+}
+
+// shardRequests splits n requests into at most workers contiguous
+// [start, end) ranges of near-equal size.
+func shardRequests(n, workers int) [][2]int {
+	workers = min(max(workers, 1), n)
+	shards := make([][2]int, 0, workers)
+	start := 0
+	for i := range workers {
+		size := n / workers
+		if i < n%workers {
+			size++
+		}
+		shards = append(shards, [2]int{start, start + size})
+		start += size
+	}
+	return shards
+}
+
+// transcribeBatch runs one WhisperX invocation over reqs; see TranscribeBatch.
+func (s *Service) transcribeBatch(ctx context.Context, reqs []TranscribeRequest, model string, onProgress ProgressFunc) ([]*TranscribeResult, error) {
 	// Extract audio for every request via FFmpeg.
 	if onProgress != nil {
 		onProgress(PhaseExtract, 0)
@@ -209,22 +329,22 @@ func (s *Service) TranscribeBatch(ctx context.Context, reqs []TranscribeRequest,
 	if onProgress != nil {
 		onProgress(PhaseTranscribe, 0)
 	}
-	invocation := s.buildWhisperXInvocation(wavPaths, reqs, model)
-	s.logger.Info("running WhisperX transcription",
-		transcriptionLogFields(reqs[0],
-			"event_type", "transcription_whisperx",
-			"decision_type", "transcription_profile",
-			"decision_result", invocation.TranscriptionProfileName,
-			"decision_reason", fmt.Sprintf("vad_method=%s device=%s compute_type=%s condition_on_previous_text=%t batch_size=%d chunk_size=%d", s.vadMethod, invocation.Device, invocation.ComputeType, invocation.ConditionOnPreviousText, whisperXBatchSize, whisperXVADChunkSize),
-			"model", model,
-			"batch_files", len(reqs),
-		)...,
-	)
 	transcribeStart := time.Now()
-	whisperCmd := exec.CommandContext(ctx, whisperXCommand, invocation.Args...)
-	whisperCmd.Env = invocation.Env
-	ConfigureGroupKill(whisperCmd)
-	if output, err := whisperCmd.CombinedOutput(); err != nil {
+	invocation := s.buildWhisperXInvocation(wavPaths, reqs, model)
+	output, err := s.runWhisperX(ctx, invocation, reqs, model)
+	if err != nil && invocation.Device == "cuda" && ctx.Err() == nil && isCUDAError(output) {
+		s.logger.Warn("WhisperX CUDA failure; retrying on CPU",
+			transcriptionLogFields(reqs[0],
+				"event_type", "transcription_cpu_fallback",
+				"error_hint", cudaErrorHint(output),
+				"impact", "transcription continues on CPU and runs slower",
+				"batch_files", len(reqs),
+			)...,
+		)
+		invocation = s.whisperXInvocation(wavPaths, reqs, model, false)
+		output, err = s.runWhisperX(ctx, invocation, reqs, model)
+	}
+	if err != nil {
 		return nil, fmt.Errorf("whisperx transcription: %w: %s", err, output)
 	}
 	transcribeTime := time.Since(transcribeStart)
@@ -273,6 +393,94 @@ func (s *Service) TranscribeBatch(ctx context.Context, reqs []TranscribeRequest,
 	return results, nil
 }
 
+// runWhisperX runs one wrapper invocation and returns its combined output.
+func (s *Service) runWhisperX(ctx context.Context, invocation whisperXInvocation, reqs []TranscribeRequest, model string) ([]byte, error) {
+	release, err := s.acquireSlot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	s.logger.Info("running WhisperX transcription",
+		transcriptionLogFields(reqs[0],
+			"event_type", "transcription_whisperx",
+			"decision_type", "transcription_profile",
+			"decision_result", invocation.TranscriptionProfileName,
+			"decision_reason", fmt.Sprintf("vad_method=%s device=%s compute_type=%s condition_on_previous_text=%t batch_size=%d chunk_size=%d", s.vadMethod, invocation.Device, invocation.ComputeType, invocation.ConditionOnPreviousText, whisperXBatchSize, whisperXVADChunkSize),
+			"model", model,
+			"batch_files", len(reqs),
+		)...,
+	)
+	whisperCmd := exec.CommandContext(ctx, whisperXCommand, invocation.Args...)
+	whisperCmd.Env = invocation.Env
+	ConfigureGroupKill(whisperCmd)
+	output, err := runWhisperXCommand(whisperCmd)
+	if err == nil {
+		s.warmed.Store(true)
+	}
+	return output, err
+}
+
+// runWhisperXCommand runs the wrapper process. It is a variable so tests can
+// observe how many runs overlap without launching WhisperX.
+var runWhisperXCommand = func(cmd *exec.Cmd) ([]byte, error) {
+	return cmd.CombinedOutput()
+}
+
+// acquireSlot blocks until a WhisperX slot is free, or ctx is done. Until a
+// run has completed, the holder also takes the cold lock so a cold uvx
+// environment or model download is populated once rather than by racing
+// processes.
+func (s *Service) acquireSlot(ctx context.Context) (release func(), err error) {
+	if s.slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case s.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if s.warmed.Load() {
+		return func() { <-s.slots }, nil
+	}
+	s.cold.Lock()
+	return func() {
+		s.cold.Unlock()
+		<-s.slots
+	}, nil
+}
+
+// cudaErrorMarkers are output fragments from torch, CTranslate2, and the CUDA
+// runtime that identify a GPU-side failure: memory exhaustion on a contended
+// card or a driver fault. A run that fails without one of these failed for a
+// reason a CPU retry would not fix.
+var cudaErrorMarkers = []string{
+	"CUDA out of memory",
+	"OutOfMemoryError",
+	"CUDA failed with error",
+	"CUDA error",
+	"CUDA driver",
+	"CUBLAS_STATUS_",
+	"CUDNN_STATUS_",
+	"no CUDA-capable device",
+}
+
+func isCUDAError(output []byte) bool {
+	return cudaErrorHint(output) != ""
+}
+
+// cudaErrorHint returns the first output line carrying a CUDA error marker,
+// or "" when there is none.
+func cudaErrorHint(output []byte) string {
+	for line := range strings.Lines(string(output)) {
+		for _, marker := range cudaErrorMarkers {
+			if strings.Contains(line, marker) {
+				return strings.TrimSpace(line)
+			}
+		}
+	}
+	return ""
+}
+
 func transcriptionLogFields(req TranscribeRequest, fields ...any) []any {
 	out := make([]any, 0, len(fields)+8)
 	if req.ItemID != 0 {
@@ -289,12 +497,22 @@ func transcriptionLogFields(req TranscribeRequest, fields ...any) []any {
 }
 
 func (s *Service) buildWhisperXInvocation(wavPaths []string, reqs []TranscribeRequest, model string) whisperXInvocation {
+	return s.whisperXInvocation(wavPaths, reqs, model, s.cudaEnabled)
+}
+
+// whisperXInvocation builds the wrapper command line for the GPU or, with
+// cuda false, the CPU. A configured compute type the CPU cannot run (float16
+// and int8_float16) is replaced by the CPU default.
+func (s *Service) whisperXInvocation(wavPaths []string, reqs []TranscribeRequest, model string, cuda bool) whisperXInvocation {
 	device := "cpu"
 	computeType := "int8"
-	if s.cudaEnabled {
+	if cuda {
 		device = "cuda"
 		computeType = "float16"
 	}
+	if s.computeType != "" && (cuda || s.computeType == "int8" || s.computeType == "float32") {
+		computeType = s.computeType
+	}
 	args := []string{
 		"--from", whisperXPackage,
 		"python", "-c", whisperXWrapperScript,
@@ -311,6 +529,7 @@ func (s *Service) buildWhisperXInvocation(wavPaths []string, reqs []TranscribeRe
 		"--model", model,
 		"--vad-method", s.vadMethod,
 		"--device", device,
+		"--device-index", strconv.Itoa(s.deviceIndex),
 		"--compute-type", computeType,
 		"--batch-size", strconv.Itoa(whisperXBatchSize),
 		"--chunk-size", strconv.Itoa(whisperXVADChunkSize),
@@ -350,10 +569,13 @@ func analyzeSRT(path string) (segments int, duration float64, err error) {
 // Config returns the service's WhisperX configuration for display purposes.
 func (s *Service) Config() (model, device, vadMethod string) {
 	model = s.model
-	if s.cudaEnabled {
-		device = "cuda"
-	} else {
+	switch {
+	case !s.cudaEnabled:
 		device = "cpu"
+	case s.deviceIndex > 0:
+		device = fmt.Sprintf("cuda:%d", s.deviceIndex)
+	default:
+		device = "cuda"
 	}
 	vadMethod = s.vadMethod
 	return
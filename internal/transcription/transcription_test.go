@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/five82/spindle/internal/media/ffprobe"
 )
@@ -171,6 +174,35 @@ func TestBuildWhisperXInvocation(t *testing.T) {
 	}
 }
 
+func TestBuildWhisperXInvocationComputeOptions(t *testing.T) {
+	for _, tc := range []struct {
+		params      Params
+		wantCompute string
+		wantArgs    []string
+	}{
+		{Params{}, "int8", []string{"--device cpu", "--device-index 0", "--compute-type int8"}},
+		{Params{CUDAEnabled: true}, "float16", []string{"--device cuda", "--device-index 0", "--compute-type float16"}},
+		{Params{CUDAEnabled: true, ComputeType: "int8_float16", DeviceIndex: 1}, "int8_float16", []string{"--device cuda", "--device-index 1", "--compute-type int8_float16"}},
+		{Params{ComputeType: "float32"}, "float32", []string{"--device cpu", "--compute-type float32"}},
+	} {
+		svc := New(tc.params, nil)
+		invocation := svc.buildWhisperXInvocation(
+			[]string{"/tmp/audio.wav"},
+			[]TranscribeRequest{{OutputDir: "/tmp/out", Language: "en"}},
+			"large-v3",
+		)
+		if invocation.ComputeType != tc.wantCompute {
+			t.Errorf("%+v: compute type = %q, want %q", tc.params, invocation.ComputeType, tc.wantCompute)
+		}
+		joined := strings.Join(invocation.Args, " ")
+		for _, want := range tc.wantArgs {
+			if !strings.Contains(joined, want) {
+				t.Errorf("%+v: invocation args missing %q: %s", tc.params, want, joined)
+			}
+		}
+	}
+}
+
 func TestBuildWhisperXInvocationBatch(t *testing.T) {
 	svc := New(Params{Model: "large-v3", VADMethod: "silero"}, nil)
 	invocation := svc.buildWhisperXInvocation(
@@ -213,3 +245,274 @@ func TestTranscribeBatchRejectsEmpty(t *testing.T) {
 		t.Fatal("expected error for empty batch")
 	}
 }
+
+// installFakeTools puts fake ffmpeg and uvx executables first on PATH. ffmpeg
+// writes its input path into the WAV; uvx turns each --audio/--output-dir
+// pair into an SRT carrying that text and logs one line per invocation.
+func installFakeTools(t *testing.T) (invocationLog string) {
+	t.Helper()
+	bin := t.TempDir()
+	invocationLog = filepath.Join(bin, "invocations.log")
+	ffmpeg := `#!/bin/sh
+in=""
+while [ $# -gt 1 ]; do
+  [ "$1" = "-i" ] && in="$2"
+  shift
+done
+printf '%s' "$in" > "$1"
+`
+	uvx := `#!/bin/sh
+echo run >> "` + invocationLog + `"
+while [ $# -gt 0 ]; do
+  if [ "$1" = "--audio" ]; then audio="$2"; fi
+  if [ "$1" = "--output-dir" ]; then
+    printf '1\n00:00:01,000 --> 00:00:02,000\n%s\n' "$(cat "$audio")" > "$2/audio.srt"
+    echo '{"segments":[]}' > "$2/audio.json"
+  fi
+  shift
+done
+`
+	for name, script := range map[string]string{"ffmpeg": ffmpeg, "uvx": uvx} {
+		if err := os.WriteFile(filepath.Join(bin, name), []byte(script), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return invocationLog
+}
+
+func TestTranscribeBatchShardedMatchesSequential(t *testing.T) {
+	invocationLog := installFakeTools(t)
+	reqsFor := func(root string) []TranscribeRequest {
+		reqs := make([]TranscribeRequest, 5)
+		for i := range reqs {
+			reqs[i] = TranscribeRequest{
+				InputPath:  fmt.Sprintf("/rip/title%d.mkv", i),
+				Language:   "en",
+				OutputDir:  filepath.Join(root, fmt.Sprintf("s01_%03d", i+1)),
+				EpisodeKey: fmt.Sprintf("s01_%03d", i+1),
+			}
+		}
+		return reqs
+	}
+	transcripts := func(results []*TranscribeResult) []string {
+		out := make([]string, len(results))
+		for i, r := range results {
+			data, err := os.ReadFile(r.SRTPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out[i] = string(data)
+		}
+		return out
+	}
+
+	sequential, err := New(Params{}, nil).TranscribeBatch(context.Background(), reqsFor(t.TempDir()))
+	if err != nil {
+		t.Fatalf("sequential batch: %v", err)
+	}
+	if err := os.Remove(invocationLog); err != nil {
+		t.Fatal(err)
+	}
+
+	parallel, err := New(Params{Workers: 3}, nil).TranscribeBatch(context.Background(), reqsFor(t.TempDir()))
+	if err != nil {
+		t.Fatalf("sharded batch: %v", err)
+	}
+	seqText, parText := transcripts(sequential), transcripts(parallel)
+	for i := range seqText {
+		if !strings.Contains(seqText[i], fmt.Sprintf("/rip/title%d.mkv", i)) {
+			t.Fatalf("sequential result %d = %q, want title%d", i, seqText[i], i)
+		}
+		if parText[i] != seqText[i] {
+			t.Fatalf("sharded result %d = %q, want %q", i, parText[i], seqText[i])
+		}
+	}
+	runs, err := os.ReadFile(invocationLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(string(runs), "run"); got != 3 {
+		t.Fatalf("WhisperX invocations = %d, want 3 (one per shard)", got)
+	}
+}
+
+func TestConcurrentTranscribeSharesWorkerSlots(t *testing.T) {
+	installFakeTools(t)
+	orig := runWhisperXCommand
+	t.Cleanup(func() { runWhisperXCommand = orig })
+
+	svc := New(Params{Workers: 2}, nil)
+	var mu sync.Mutex
+	active, peak, coldOverlap := 0, 0, false
+	runWhisperXCommand = func(cmd *exec.Cmd) ([]byte, error) {
+		mu.Lock()
+		active++
+		peak = max(peak, active)
+		if !svc.warmed.Load() && active > 1 {
+			coldOverlap = true
+		}
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		out, err := cmd.CombinedOutput()
+		mu.Lock()
+		active--
+		mu.Unlock()
+		return out, err
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 6)
+	for i := range errs {
+		wg.Go(func() {
+			_, errs[i] = svc.Transcribe(context.Background(), TranscribeRequest{
+				InputPath: fmt.Sprintf("/rip/title%d.mkv", i),
+				Language:  "en",
+				OutputDir: t.TempDir(),
+			})
+		})
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("Transcribe %d: %v", i, err)
+		}
+	}
+	if peak > 2 {
+		t.Fatalf("%d WhisperX runs overlapped, want at most whisperx_workers=2", peak)
+	}
+	if coldOverlap {
+		t.Fatal("a second run started before the first cold run completed")
+	}
+}
+
+// failCUDARuns wraps the fake uvx so any run on --device cuda exits with
+// message instead of transcribing.
+func failCUDARuns(t *testing.T, invocationLog, message string) {
+	t.Helper()
+	bin := filepath.Dir(invocationLog)
+	if err := os.Rename(filepath.Join(bin, "uvx"), filepath.Join(bin, "uvx-real")); err != nil {
+		t.Fatal(err)
+	}
+	wrapper := `#!/bin/sh
+case " $* " in
+*" --device cuda "*)
+  echo cuda >> "` + invocationLog + `"
+  echo '` + message + `' >&2
+  exit 1
+  ;;
+esac
+exec "` + filepath.Join(bin, "uvx-real") + `" "$@"
+`
+	if err := os.WriteFile(filepath.Join(bin, "uvx"), []byte(wrapper), 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTranscribeFallsBackToCPUOnCUDAOOM(t *testing.T) {
+	invocationLog := installFakeTools(t)
+	failCUDARuns(t, invocationLog, "torch.OutOfMemoryError: CUDA out of memory. Tried to allocate 20.00 MiB")
+
+	svc := New(Params{CUDAEnabled: true, ComputeType: "int8_float16"}, nil)
+	result, err := svc.Transcribe(context.Background(), TranscribeRequest{
+		InputPath: "/rip/title0.mkv",
+		Language:  "en",
+		OutputDir: t.TempDir(),
+	})
+	if err != nil {
+		t.Fatalf("Transcribe: %v", err)
+	}
+	data, err := os.ReadFile(result.SRTPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "/rip/title0.mkv") {
+		t.Fatalf("transcript = %q, want CPU run output", data)
+	}
+	runs, err := os.ReadFile(invocationLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Fields(string(runs)); fmt.Sprint(got) != "[cuda run]" {
+		t.Fatalf("invocations = %v, want one CUDA attempt then one CPU run", got)
+	}
+}
+
+func TestTranscribeDoesNotFallBackOnOtherFailures(t *testing.T) {
+	invocationLog := installFakeTools(t)
+	failCUDARuns(t, invocationLog, "ValueError: unsupported language xx")
+
+	svc := New(Params{CUDAEnabled: true}, nil)
+	_, err := svc.Transcribe(context.Background(), TranscribeRequest{
+		InputPath: "/rip/title0.mkv",
+		Language:  "xx",
+		OutputDir: t.TempDir(),
+	})
+	if err == nil || !strings.Contains(err.Error(), "unsupported language") {
+		t.Fatalf("expected the GPU failure to surface, got %v", err)
+	}
+	runs, err := os.ReadFile(invocationLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(runs), "run") {
+		t.Fatalf("non-CUDA failure retried on CPU: %q", runs)
+	}
+}
+
+func TestBuildWhisperXInvocationCPUFallbackComputeType(t *testing.T) {
+	for computeType, want := range map[string]string{"": "int8", "float16": "int8", "int8_float16": "int8", "float32": "float32"} {
+		svc := New(Params{CUDAEnabled: true, ComputeType: computeType}, nil)
+		invocation := svc.whisperXInvocation([]string{"/tmp/audio.wav"}, []TranscribeRequest{{OutputDir: "/tmp/out", Language: "en"}}, "large-v3", false)
+		if invocation.Device != "cpu" || invocation.ComputeType != want {
+			t.Errorf("compute type %q: CPU fallback = %s/%s, want cpu/%s", computeType, invocation.Device, invocation.ComputeType, want)
+		}
+	}
+}
+
+func TestShardRequests(t *testing.T) {
+	got := shardRequests(5, 3)
+	want := [][2]int{{0, 2}, {2, 4}, {4, 5}}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("shardRequests(5, 3) = %v, want %v", got, want)
+	}
+	if got := shardRequests(2, 8); len(got) != 2 {
+		t.Fatalf("shardRequests(2, 8) = %v, want 2 shards", got)
+	}
+}
+
+func TestTranscriptTextConfidenceFilter(t *testing.T) {
+	dir := t.TempDir()
+	srtPath := filepath.Join(dir, "audio.srt")
+	jsonPath := filepath.Join(dir, "audio.json")
+	if err := os.WriteFile(srtPath, []byte(sampleSRT), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	payload := `{"segments":[
+		{"text":" Hello, world.","words":[{"word":"Hello,","score":0.9},{"word":"world.","score":0.8}]},
+		{"text":" Thank you.","words":[{"word":"Thank","score":0.1},{"word":"you.","score":0.2}]},
+		{"text":" 1984.","words":[{"word":"1984."}]}
+	]}`
+	if err := os.WriteFile(jsonPath, []byte(payload), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := TranscriptText(srtPath, jsonPath, 0.5)
+	if err != nil {
+		t.Fatalf("TranscriptText: %v", err)
+	}
+	if strings.Contains(got, "Thank you") {
+		t.Errorf("low-confidence segment kept: %q", got)
+	}
+	if !strings.Contains(got, "Hello, world.") || !strings.Contains(got, "1984.") {
+		t.Errorf("expected confident and unscored segments, got %q", got)
+	}
+
+	got, err = TranscriptText(srtPath, jsonPath, 0)
+	if err != nil {
+		t.Fatalf("TranscriptText unfiltered: %v", err)
+	}
+	if !strings.Contains(got, "This is a test.") {
+		t.Errorf("threshold 0 should return SRT text, got %q", got)
+	}
+}
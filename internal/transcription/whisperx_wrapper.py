@@ -80,6 +80,7 @@ def _load_model(whisperx, args, *, language, task):
     }
     kwargs = {
         "compute_type": args.compute_type,
+        "device_index": args.device_index,
         "language": language,
         "asr_options": asr_options,
         "vad_method": args.vad_method,
@@ -97,6 +98,12 @@ def _load_model(whisperx, args, *, language, task):
         return whisperx.load_model(args.model, args.device, **kwargs)
 
 
+def _torch_device(args):
+    if args.device == "cuda":
+        return f"cuda:{args.device_index}"
+    return args.device
+
+
 def _align_cached(whisperx, args, align_cache, audio, raw_segments, detected_language):
     if not raw_segments:
         return raw_segments
@@ -104,7 +111,7 @@ def _align_cached(whisperx, args, align_cache, audio, raw_segments, detected_lan
         if detected_language not in align_cache:
             align_cache[detected_language] = whisperx.load_align_model(
                 language_code=detected_language,
-                device=args.device,
+                device=_torch_device(args),
             )
         align_model, align_metadata = align_cache[detected_language]
         aligned_result = whisperx.align(
@@ -112,7 +119,7 @@ def _align_cached(whisperx, args, align_cache, audio, raw_segments, detected_lan
             align_model,
             align_metadata,
             audio,
-            args.device,
+            _torch_device(args),
             return_char_alignments=False,
         )
         if isinstance(aligned_result, dict) and aligned_result.get("segments"):
@@ -150,6 +157,7 @@ def main() -> None:
     parser.add_argument("--model", required=True)
     parser.add_argument("--vad-method", required=True)
     parser.add_argument("--device", required=True)
+    parser.add_argument("--device-index", type=int, default=0)
     parser.add_argument("--compute-type", required=True)
     parser.add_argument("--batch-size", type=int, default=16)
     parser.add_argument("--chunk-size", type=int, default=30)
@@ -175,6 +183,7 @@ def main() -> None:
         "name": args.transcription_profile_name,
         "vad_method": args.vad_method,
         "device": args.device,
+        "device_index": args.device_index,
         "compute_type": args.compute_type,
         "condition_on_previous_text": args.condition_on_previous_text,
         "batch_size": args.batch_size,
@@ -0,0 +1,74 @@
+package watchfolder
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"github.com/five82/spindle/internal/logs"
+	"github.com/five82/spindle/internal/mkvimport"
+	"github.com/five82/spindle/internal/queue"
+	"github.com/five82/spindle/internal/tmdb"
+)
+
+var scanFile = mkvimport.ScanFile
+
+// Importer returns an IngestFunc that enqueues each file as a single-file
+// imported rip, matched against TMDB by its file name. A file whose content
+// is already queued, under any name, is skipped.
+func Importer(store *queue.Store, client *tmdb.Client, logger *slog.Logger) IngestFunc {
+	logger = logs.Default(logger)
+	return func(ctx context.Context, path string) error {
+		return importFile(ctx, store, client, path, logger)
+	}
+}
+
+func importFile(ctx context.Context, store *queue.Store, client *tmdb.Client, path string, logger *slog.Logger) error {
+	file, err := scanFile(ctx, path)
+	if err != nil {
+		return err
+	}
+	title, year := mkvimport.InferTitle(strings.TrimSuffix(path, filepath.Ext(path)))
+	meta, err := mkvimport.Resolve(ctx, client, mkvimport.Hints{Title: title, Year: year}, logger)
+	if err != nil {
+		return err
+	}
+	env, err := mkvimport.NewEnvelope([]mkvimport.File{file}, meta)
+	if err != nil {
+		return err
+	}
+	existing, err := store.FindByFingerprint(env.Fingerprint)
+	if err != nil {
+		return fmt.Errorf("check duplicate fingerprint: %w", err)
+	}
+	if existing != nil {
+		logger.Info("watch folder file already queued",
+			"decision_type", logs.DecisionWatchFolder,
+			"decision_result", "skipped",
+			"decision_reason", fmt.Sprintf("identical content already queued as item %d", existing.ID),
+			"path", path,
+			"item_id", existing.ID,
+		)
+		return nil
+	}
+
+	ripSpecData, metaJSON, err := mkvimport.Encode(&env)
+	if err != nil {
+		return err
+	}
+	item, err := store.NewImportedRip(env.Metadata.QueueTitle(), env.Fingerprint, mkvimport.EntryStage(&env), ripSpecData, metaJSON)
+	if err != nil {
+		return err
+	}
+	logger.Info("watch folder file queued",
+		"decision_type", logs.DecisionWatchFolder,
+		"decision_result", "queued",
+		"decision_reason", "file stopped changing for the settle period",
+		"path", path,
+		"item_id", item.ID,
+		"tmdb_id", env.Metadata.ID,
+	)
+	return nil
+}
@@ -0,0 +1,72 @@
+package watchfolder
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/five82/spindle/internal/mkvimport"
+	"github.com/five82/spindle/internal/queue"
+	"github.com/five82/spindle/internal/tmdb"
+)
+
+func TestReingestingSameFileDoesNotQueueTwice(t *testing.T) {
+	orig := scanFile
+	scanFile = func(_ context.Context, path string) (mkvimport.File, error) {
+		info, err := os.Stat(path)
+		if err != nil {
+			return mkvimport.File{}, err
+		}
+		name := filepath.Base(path)
+		return mkvimport.File{Path: path, Name: strings.TrimSuffix(name, filepath.Ext(name)), DurationSeconds: 6000, SizeBytes: info.Size()}, nil
+	}
+	t.Cleanup(func() { scanFile = orig })
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":949,"title":"Heat","release_date":"1995-12-15","media_type":"movie","vote_count":9000,"vote_average":7.9,"popularity":50}]}`))
+	}))
+	t.Cleanup(srv.Close)
+	client := tmdb.New("key", srv.URL, "en-US", nil)
+
+	store, err := queue.Open(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	ingest := Importer(store, client, nil)
+	dir := t.TempDir()
+	first := filepath.Join(dir, "Heat (1995).mkv")
+	writeFile(t, first, "feature", os.O_TRUNC)
+	if err := ingest(context.Background(), first); err != nil {
+		t.Fatalf("first ingest: %v", err)
+	}
+
+	// The same file dropped again under another name and folder is a no-op.
+	other := filepath.Join(t.TempDir(), "heat.1995.mkv")
+	writeFile(t, other, "feature", os.O_TRUNC)
+	if err := ingest(context.Background(), other); err != nil {
+		t.Fatalf("second ingest: %v", err)
+	}
+	items, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("queue has %d items after re-ingest, want 1", len(items))
+	}
+
+	// Different content is a new item.
+	changed := filepath.Join(t.TempDir(), "Heat (1995).mkv")
+	writeFile(t, changed, "feature v2", os.O_TRUNC)
+	if err := ingest(context.Background(), changed); err != nil {
+		t.Fatalf("third ingest: %v", err)
+	}
+	if items, _ = store.List(); len(items) != 2 {
+		t.Fatalf("queue has %d items, want a new item for different content", len(items))
+	}
+}
@@ -0,0 +1,223 @@
+// Package watchfolder ingests MKV files dropped into a watched directory.
+// An fsnotify watcher notices new files, waits until each has stopped
+// changing for the settle period, moves it into the imported/ subfolder,
+// and hands it to an ingest function that enqueues it as an imported rip.
+// Files the ingest function rejects are moved to failed/ instead.
+package watchfolder
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/five82/spindle/internal/logs"
+	"github.com/five82/spindle/internal/mkvimport"
+)
+
+// IngestFunc enqueues one fully written file.
+type IngestFunc func(ctx context.Context, path string) error
+
+// Subfolders of the watched directory that processed files are moved to,
+// so they are not picked up again after a restart or a queue clear.
+const (
+	importedDir = "imported"
+	failedDir   = "failed"
+)
+
+// Watcher watches one directory for new MKV files.
+type Watcher struct {
+	dir    string
+	settle time.Duration
+	ingest IngestFunc
+	logger *slog.Logger
+	now    func() time.Time
+
+	// pending holds files still being written, keyed by path; ignored holds
+	// unsupported files still in the directory so repeated events are not
+	// logged again.
+	pending map[string]*pendingFile
+	ignored map[string]bool
+}
+
+// pendingFile is the last observed state of a file that is not yet stable.
+type pendingFile struct {
+	size    int64
+	modTime time.Time
+	since   time.Time // when size or modTime last changed
+}
+
+// New creates a watcher for dir. A file is ingested once its size and
+// modification time have not changed for settle.
+func New(dir string, settle time.Duration, ingest IngestFunc, logger *slog.Logger) *Watcher {
+	return &Watcher{
+		dir:     dir,
+		settle:  settle,
+		ingest:  ingest,
+		logger:  logs.Default(logger),
+		now:     time.Now,
+		pending: make(map[string]*pendingFile),
+		ignored: make(map[string]bool),
+	}
+}
+
+// Run watches the directory until ctx is done. Files already present when
+// it starts are picked up too, so drops made while the daemon was down are
+// not missed; processed files have been moved into subfolders, which are
+// not watched.
+func (w *Watcher) Run(ctx context.Context) error {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("create watcher: %w", err)
+	}
+	defer func() { _ = fsw.Close() }()
+	if err := fsw.Add(w.dir); err != nil {
+		return fmt.Errorf("watch %s: %w", w.dir, err)
+	}
+
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", w.dir, err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			w.observe(filepath.Join(w.dir, entry.Name()))
+		}
+	}
+	w.logger.Info("watch folder started", "event_type", "watch_folder_start", "dir", w.dir, "settle", w.settle)
+
+	ticker := time.NewTicker(max(w.settle/4, 250*time.Millisecond))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-fsw.Events:
+			if !ok {
+				return nil
+			}
+			if event.Has(fsnotify.Create) || event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+				delete(w.ignored, event.Name)
+			}
+			if event.Has(fsnotify.Create) || event.Has(fsnotify.Write) {
+				w.observe(event.Name)
+			}
+		case err, ok := <-fsw.Errors:
+			if !ok {
+				return nil
+			}
+			w.logger.Warn("watch folder error",
+				"event_type", "watch_folder_error",
+				"error_hint", err.Error(),
+				"impact", "new files may be picked up late",
+			)
+		case <-ticker.C:
+			w.poll(ctx)
+		}
+	}
+}
+
+// observe starts or refreshes tracking of path. Directories, hidden files,
+// and non-MKV files are rejected once.
+func (w *Watcher) observe(path string) {
+	if w.ignored[path] {
+		return
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return
+	}
+	name := filepath.Base(path)
+	if strings.HasPrefix(name, ".") || !mkvimport.IsMKV(name) {
+		w.ignored[path] = true
+		w.logger.Info("watch folder file ignored",
+			"decision_type", logs.DecisionWatchFolder,
+			"decision_result", "ignored",
+			"decision_reason", "not an .mkv file",
+			"path", path,
+		)
+		return
+	}
+	w.track(path, info)
+}
+
+// track records the current state of path, restarting its settle period
+// when the file changed since it was last seen.
+func (w *Watcher) track(path string, info os.FileInfo) {
+	p, ok := w.pending[path]
+	if !ok {
+		w.pending[path] = &pendingFile{size: info.Size(), modTime: info.ModTime(), since: w.now()}
+		return
+	}
+	if p.size != info.Size() || !p.modTime.Equal(info.ModTime()) {
+		p.size, p.modTime, p.since = info.Size(), info.ModTime(), w.now()
+	}
+}
+
+// poll ingests every pending file that has been stable for the settle
+// period, moving it into imported/ first so the queued item refers to its
+// final location. Files that disappeared are dropped; a file whose ingest
+// fails is moved to failed/, and moving it back retries it.
+func (w *Watcher) poll(ctx context.Context) {
+	for path, p := range w.pending {
+		info, err := os.Stat(path)
+		if err != nil {
+			delete(w.pending, path)
+			continue
+		}
+		w.track(path, info)
+		if w.now().Sub(p.since) < w.settle {
+			continue
+		}
+		delete(w.pending, path)
+		imported, err := moveInto(filepath.Join(w.dir, importedDir), path)
+		if err != nil {
+			w.logger.Warn("watch folder file not moved",
+				"event_type", "watch_folder_move_failed",
+				"error_hint", err.Error(),
+				"impact", "file not queued; re-save or re-copy it to retry",
+				"path", path,
+			)
+			continue
+		}
+		if err := w.ingest(ctx, imported); err != nil {
+			failed, moveErr := moveInto(filepath.Join(w.dir, failedDir), imported)
+			if moveErr != nil {
+				failed = imported
+			}
+			w.logger.Warn("watch folder ingest failed",
+				"event_type", "watch_folder_ingest_failed",
+				"error_hint", err.Error(),
+				"impact", "file not queued; move it back into the watch folder to retry",
+				"path", failed,
+			)
+		}
+	}
+}
+
+// moveInto moves path into dir, creating dir when needed, and returns the
+// new path. A name already taken in dir gets a numeric suffix, so an earlier
+// file that a queued item still refers to is never replaced.
+func moveInto(dir, path string) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	name := filepath.Base(path)
+	ext := filepath.Ext(name)
+	target := filepath.Join(dir, name)
+	for n := 2; ; n++ {
+		if _, err := os.Lstat(target); os.IsNotExist(err) {
+			break
+		}
+		target = filepath.Join(dir, fmt.Sprintf("%s (%d)%s", strings.TrimSuffix(name, ext), n, ext))
+	}
+	if err := os.Rename(path, target); err != nil {
+		return "", err
+	}
+	return target, nil
+}
@@ -0,0 +1,135 @@
+package watchfolder
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestWatcher(t *testing.T) (*Watcher, *fakeClock, *[]string) {
+	t.Helper()
+	var ingested []string
+	w := New(t.TempDir(), 30*time.Second, func(_ context.Context, path string) error {
+		ingested = append(ingested, path)
+		return nil
+	}, nil)
+	clock := &fakeClock{t: time.Unix(1_700_000_000, 0)}
+	w.now = clock.now
+	return w, clock, &ingested
+}
+
+func writeFile(t *testing.T, path string, data string, flag int) {
+	t.Helper()
+	f, err := os.OpenFile(path, flag|os.O_WRONLY|os.O_CREATE, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStabilizedFileIsIngested(t *testing.T) {
+	w, clock, ingested := newTestWatcher(t)
+	path := filepath.Join(w.dir, "Heat (1995).mkv")
+	writeFile(t, path, "data", os.O_TRUNC)
+	w.observe(path)
+
+	clock.advance(10 * time.Second)
+	w.poll(context.Background())
+	if len(*ingested) != 0 {
+		t.Fatalf("ingested %v before settle period elapsed", *ingested)
+	}
+
+	clock.advance(25 * time.Second)
+	w.poll(context.Background())
+	imported := filepath.Join(w.dir, importedDir, "Heat (1995).mkv")
+	if len(*ingested) != 1 || (*ingested)[0] != imported {
+		t.Fatalf("ingested = %v, want [%s]", *ingested, imported)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("ingested file left in the watch folder: %v", err)
+	}
+
+	// The same name dropped again does not replace the imported file.
+	writeFile(t, path, "again", os.O_TRUNC)
+	w.observe(path)
+	clock.advance(time.Minute)
+	w.poll(context.Background())
+	second := filepath.Join(w.dir, importedDir, "Heat (1995) (2).mkv")
+	if len(*ingested) != 2 || (*ingested)[1] != second {
+		t.Fatalf("ingested = %v, want second drop at %s", *ingested, second)
+	}
+	if data, _ := os.ReadFile(imported); string(data) != "data" {
+		t.Fatalf("first import overwritten: %q", data)
+	}
+}
+
+func TestFailedIngestMovesFileAside(t *testing.T) {
+	w := New(t.TempDir(), 30*time.Second, func(context.Context, string) error {
+		return errors.New("no TMDB match")
+	}, nil)
+	clock := &fakeClock{t: time.Unix(1_700_000_000, 0)}
+	w.now = clock.now
+	path := filepath.Join(w.dir, "Unknown Film.mkv")
+	writeFile(t, path, "data", os.O_TRUNC)
+	w.observe(path)
+
+	clock.advance(time.Minute)
+	w.poll(context.Background())
+	if _, err := os.Stat(filepath.Join(w.dir, failedDir, "Unknown Film.mkv")); err != nil {
+		t.Fatalf("failed file not moved to %s/: %v", failedDir, err)
+	}
+	if len(w.pending) != 0 {
+		t.Fatalf("pending = %v, want nothing tracked", w.pending)
+	}
+}
+
+func TestStillWritingFileIsNotIngested(t *testing.T) {
+	w, clock, ingested := newTestWatcher(t)
+	path := filepath.Join(w.dir, "Heat (1995).mkv")
+	writeFile(t, path, "part1", os.O_TRUNC)
+	w.observe(path)
+
+	// The copy keeps growing; each poll sees a new size and restarts the
+	// settle period.
+	for range 5 {
+		clock.advance(20 * time.Second)
+		writeFile(t, path, "more", os.O_APPEND)
+		w.poll(context.Background())
+	}
+	if len(*ingested) != 0 {
+		t.Fatalf("ingested %v while file was still being written", *ingested)
+	}
+
+	clock.advance(31 * time.Second)
+	w.poll(context.Background())
+	if len(*ingested) != 1 {
+		t.Fatalf("ingested = %v, want file queued once writes stopped", *ingested)
+	}
+}
+
+func TestUnsupportedFilesAreIgnored(t *testing.T) {
+	w, clock, ingested := newTestWatcher(t)
+	for _, name := range []string{"notes.txt", ".Heat (1995).mkv.part"} {
+		path := filepath.Join(w.dir, name)
+		writeFile(t, path, "data", os.O_TRUNC)
+		w.observe(path)
+	}
+	clock.advance(time.Minute)
+	w.poll(context.Background())
+	if len(*ingested) != 0 || len(w.pending) != 0 {
+		t.Fatalf("ingested = %v, pending = %v; want nothing tracked", *ingested, w.pending)
+	}
+}
@@ -0,0 +1,210 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/five82/spindle/internal/logs"
+	"github.com/five82/spindle/internal/notify"
+	"github.com/five82/spindle/internal/queue"
+)
+
+// Dependency is an external service some stages cannot run without. The
+// scheduler polls Check and, after repeated failures, stops dispatching the
+// Stages lanes until a check passes again, so queued items wait instead of
+// failing one by one against a dead service.
+type Dependency struct {
+	Name   string
+	Stages []queue.Stage
+	Check  func(context.Context) error
+}
+
+// dependencyState tracks the health of one Dependency.
+type dependencyState struct {
+	failures int
+	paused   bool
+	lastErr  error
+}
+
+// dependencyMonitor holds auto-pause configuration and state.
+type dependencyMonitor struct {
+	mu       sync.Mutex
+	deps     []Dependency
+	failures int
+	interval time.Duration
+	states   map[string]*dependencyState
+}
+
+// ConfigureDependencies registers the external services whose health gates
+// stage lanes. A dependency's lanes pause after failures consecutive failed
+// checks, made every interval, and resume on the first passing check.
+// failures <= 0 disables auto-pause.
+func (m *Manager) ConfigureDependencies(deps []Dependency, failures int, interval time.Duration) {
+	m.depMon.mu.Lock()
+	defer m.depMon.mu.Unlock()
+	m.depMon.deps = deps
+	m.depMon.failures = failures
+	m.depMon.interval = interval
+	m.depMon.states = make(map[string]*dependencyState, len(deps))
+	for _, d := range deps {
+		m.depMon.states[d.Name] = &dependencyState{}
+	}
+}
+
+// dependencyChecksEnabled reports whether monitorDependencies has work.
+func (m *Manager) dependencyChecksEnabled() bool {
+	m.depMon.mu.Lock()
+	defer m.depMon.mu.Unlock()
+	return len(m.depMon.deps) > 0 && m.depMon.failures > 0 && m.depMon.interval > 0
+}
+
+// monitorDependencies checks every dependency each interval until ctx ends.
+func (m *Manager) monitorDependencies(ctx context.Context) {
+	ticker := time.NewTicker(m.depMon.interval)
+	defer ticker.Stop()
+	for {
+		m.checkDependencies(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkDependencies runs one round of checks, each bounded by the check
+// interval so a hung service cannot stall the monitor.
+func (m *Manager) checkDependencies(ctx context.Context) {
+	results := make(map[string]error, len(m.depMon.deps))
+	for _, d := range m.depMon.deps {
+		checkCtx, cancel := context.WithTimeout(ctx, m.depMon.interval)
+		results[d.Name] = d.Check(checkCtx)
+		cancel()
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	m.recordDependencyChecks(ctx, results)
+}
+
+// recordDependencyChecks applies one round of check results, pausing lanes
+// of dependencies that reached the failure limit and resuming lanes of
+// dependencies that recovered. When every dependency is down at once the
+// pause is reported as one global outage (likely the host's network)
+// rather than as separate per-service failures.
+func (m *Manager) recordDependencyChecks(ctx context.Context, results map[string]error) {
+	mon := &m.depMon
+	mon.mu.Lock()
+	var paused, resumed []Dependency
+	down := 0
+	for _, d := range mon.deps {
+		st := mon.states[d.Name]
+		err := results[d.Name]
+		if err == nil {
+			if st.paused {
+				resumed = append(resumed, d)
+			}
+			*st = dependencyState{}
+			continue
+		}
+		st.failures++
+		st.lastErr = err
+		if st.failures >= mon.failures {
+			down++
+			if !st.paused {
+				st.paused = true
+				paused = append(paused, d)
+			}
+		}
+	}
+	global := len(mon.deps) > 1 && down == len(mon.deps)
+	mon.mu.Unlock()
+
+	logger := m.pipeline.logger
+	if len(paused) > 0 && global {
+		var names []string
+		var stages []queue.Stage
+		for _, d := range mon.deps {
+			names = append(names, d.Name)
+			stages = append(stages, d.Stages...)
+		}
+		m.notePaused(ctx, "global", names, stages, fmt.Sprintf("all %d dependencies failed %d consecutive checks", len(names), mon.failures), nil)
+	} else {
+		for _, d := range paused {
+			mon.mu.Lock()
+			lastErr := mon.states[d.Name].lastErr
+			mon.mu.Unlock()
+			m.notePaused(ctx, "dependency", []string{d.Name}, d.Stages, fmt.Sprintf("%d consecutive failed checks", mon.failures), lastErr)
+		}
+	}
+
+	for _, d := range resumed {
+		logger.Info("resuming stage lanes after dependency recovered",
+			"decision_type", logs.DecisionLanePause,
+			"decision_result", "resumed",
+			"decision_reason", "dependency health check passed",
+			"dependency", d.Name,
+			"stages", stageList(d.Stages),
+		)
+		_ = notify.SendLogged(ctx, m.notifier, logger, notify.EventLaneResumed, "Spindle resumed",
+			fmt.Sprintf("%s is reachable again; resumed %s.", d.Name, stageList(d.Stages)),
+			"dependency", d.Name,
+		)
+	}
+	if len(resumed) > 0 {
+		m.signalWake()
+	}
+}
+
+// notePaused logs and notifies one lane pause. scope is "dependency" for a
+// single failing service or "global" when every dependency is down.
+func (m *Manager) notePaused(ctx context.Context, scope string, names []string, stages []queue.Stage, reason string, err error) {
+	logger := m.pipeline.logger
+	attrs := []any{
+		"decision_type", logs.DecisionLanePause,
+		"decision_result", "paused",
+		"decision_reason", reason,
+		"pause_scope", scope,
+		"dependencies", strings.Join(names, ","),
+		"stages", stageList(stages),
+	}
+	if err != nil {
+		attrs = append(attrs, "error", err)
+	}
+	logger.Warn("pausing stage lanes for unavailable dependency", attrs...)
+
+	msg := fmt.Sprintf("%s unreachable; paused %s until it recovers.", strings.Join(names, ", "), stageList(stages))
+	if scope == "global" {
+		msg = fmt.Sprintf("All external services (%s) unreachable, likely a network outage; paused %s until they recover.",
+			strings.Join(names, ", "), stageList(stages))
+	}
+	_ = notify.SendLogged(ctx, m.notifier, logger, notify.EventLanePaused, "Spindle paused", msg,
+		"pause_scope", scope,
+	)
+}
+
+// stagePaused reports whether a paused dependency gates stage.
+func (m *Manager) stagePaused(stage queue.Stage) bool {
+	m.depMon.mu.Lock()
+	defer m.depMon.mu.Unlock()
+	for _, d := range m.depMon.deps {
+		if m.depMon.states[d.Name].paused && slices.Contains(d.Stages, stage) {
+			return true
+		}
+	}
+	return false
+}
+
+func stageList(stages []queue.Stage) string {
+	names := make([]string, 0, len(stages))
+	for _, s := range stages {
+		if !slices.Contains(names, string(s)) {
+			names = append(names, string(s))
+		}
+	}
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,188 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/five82/spindle/internal/notify"
+	"github.com/five82/spindle/internal/queue"
+)
+
+// recordedNotifications captures ntfy titles and bodies from a test server.
+type recordedNotifications struct {
+	mu     sync.Mutex
+	titles []string
+	bodies []string
+}
+
+func (n *recordedNotifications) server(t *testing.T) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		n.mu.Lock()
+		n.titles = append(n.titles, r.Header.Get("Title"))
+		n.bodies = append(n.bodies, string(body))
+		n.mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func (n *recordedNotifications) snapshot() ([]string, []string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]string(nil), n.titles...), append([]string(nil), n.bodies...)
+}
+
+func TestRepeatedDependencyFailuresPauseLaneAndRecoveryResumes(t *testing.T) {
+	var sent recordedNotifications
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	manager := New(nil, notify.New(sent.server(t).URL, 5, logger), nil, logger)
+	manager.ConfigureDependencies([]Dependency{
+		{Name: "tmdb", Stages: []queue.Stage{queue.StageIdentification}},
+	}, 3, time.Minute)
+
+	ctx := context.Background()
+	down := map[string]error{"tmdb": errors.New("tmdb: status 503")}
+	for range 2 {
+		manager.recordDependencyChecks(ctx, down)
+	}
+	if manager.stagePaused(queue.StageIdentification) {
+		t.Fatal("lane paused before reaching the failure limit")
+	}
+
+	manager.recordDependencyChecks(ctx, down)
+	manager.recordDependencyChecks(ctx, down)
+	if !manager.stagePaused(queue.StageIdentification) {
+		t.Fatal("lane not paused after repeated failures")
+	}
+	if manager.stagePaused(queue.StageRipping) {
+		t.Fatal("lane without the dependency was paused")
+	}
+	if titles, _ := sent.snapshot(); len(titles) != 1 || titles[0] != "Spindle paused" {
+		t.Fatalf("notifications after pause = %v, want one pause", titles)
+	}
+
+	manager.recordDependencyChecks(ctx, map[string]error{"tmdb": nil})
+	if manager.stagePaused(queue.StageIdentification) {
+		t.Fatal("lane still paused after recovery")
+	}
+	if titles, _ := sent.snapshot(); len(titles) != 2 || titles[1] != "Spindle resumed" {
+		t.Fatalf("notifications after recovery = %v, want pause then resume", titles)
+	}
+
+	// Recovery resets the failure count.
+	manager.recordDependencyChecks(ctx, down)
+	if manager.stagePaused(queue.StageIdentification) {
+		t.Fatal("single failure after recovery paused the lane")
+	}
+}
+
+func TestDependencyPauseDistinguishesGlobalOutage(t *testing.T) {
+	var sent recordedNotifications
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	manager := New(nil, notify.New(sent.server(t).URL, 5, logger), nil, logger)
+	manager.ConfigureDependencies([]Dependency{
+		{Name: "tmdb", Stages: []queue.Stage{queue.StageIdentification}},
+		{Name: "jellyfin", Stages: []queue.Stage{queue.StageOrganizing}},
+	}, 1, time.Minute)
+
+	ctx := context.Background()
+	manager.recordDependencyChecks(ctx, map[string]error{"tmdb": errors.New("down"), "jellyfin": nil})
+	if !manager.stagePaused(queue.StageIdentification) || manager.stagePaused(queue.StageOrganizing) {
+		t.Fatal("per-dependency failure should pause only that dependency's lane")
+	}
+	_, bodies := sent.snapshot()
+	if len(bodies) != 1 || !strings.HasPrefix(bodies[0], "tmdb unreachable") {
+		t.Fatalf("per-dependency notification = %q", bodies)
+	}
+
+	manager.recordDependencyChecks(ctx, map[string]error{"tmdb": errors.New("down"), "jellyfin": errors.New("down")})
+	if !manager.stagePaused(queue.StageOrganizing) {
+		t.Fatal("global outage should pause every dependency's lane")
+	}
+	_, bodies = sent.snapshot()
+	if len(bodies) != 2 || !strings.Contains(bodies[1], "All external services (tmdb, jellyfin) unreachable") {
+		t.Fatalf("global outage notification = %q", bodies)
+	}
+}
+
+func TestSchedulerHoldsPausedLaneUntilDependencyRecovers(t *testing.T) {
+	store, err := queue.Open(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("open queue: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	item, _ := store.NewDisc("A", "fp1")
+
+	var healthy atomic.Bool
+	var checks atomic.Int32
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	manager := New(store, nil, nil, logger)
+	manager.ConfigureStages([]PipelineStage{
+		{Stage: queue.StageIdentification, Handler: stubHandler{}},
+		{Stage: queue.StageOrganizing, Handler: stubHandler{}},
+	})
+	manager.ConfigureDependencies([]Dependency{{
+		Name:   "tmdb",
+		Stages: []queue.Stage{queue.StageIdentification},
+		Check: func(context.Context) error {
+			checks.Add(1)
+			if healthy.Load() {
+				return nil
+			}
+			return errors.New("down")
+		},
+	}}, 1, 20*time.Millisecond)
+	// Pause before the scheduler starts so the first pass already skips.
+	manager.checkDependencies(context.Background())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		manager.Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	deadline := time.Now().Add(testWait)
+	for checks.Load() < 5 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	tasks, err := store.TasksForItem(item.ID)
+	if err != nil {
+		t.Fatalf("tasks: %v", err)
+	}
+	for _, task := range tasks {
+		if task.State != queue.TaskPending || task.Attempts != 0 {
+			t.Fatalf("task %s ran while its lane was paused: state %q, attempts %d", task.Type, task.State, task.Attempts)
+		}
+	}
+
+	healthy.Store(true)
+	for time.Now().Before(deadline) {
+		got, err := store.GetByID(item.ID)
+		if err != nil {
+			t.Fatalf("get item: %v", err)
+		}
+		if got.Stage == queue.StageCompleted {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("item did not complete after the dependency recovered")
+}
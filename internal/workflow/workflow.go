@@ -30,10 +30,121 @@ type PipelineStage struct {
 	// at dispatch time, such as skipping the GPU claim for movies during
 	// episode identification. Returned resources must appear in Claims.
 	ClaimsFunc func(*queue.Item) map[string]int
+	// OrderFunc, when set, orders this stage's ready tasks by ascending
+	// OrderFunc(item) instead of item creation order, such as running the
+	// cheapest encode first. Ties keep creation order.
+	OrderFunc func(*queue.Item) float64
 	// DependsOn names the stages whose tasks must complete before this
 	// stage's task is ready. Empty means: depend on the previously
 	// registered stage (linear default); the first stage is a root.
 	DependsOn []queue.Stage
+	// Timeout, when positive, bounds one run of this stage. A handler still
+	// running at the deadline has its context cancelled and the item fails
+	// with ErrStageTimeout, so a hung external tool cannot hold a lane.
+	Timeout time.Duration
+	// StallTimeout, when positive, is how long the stage's progress may stay
+	// frozen once it has started reporting. A handler whose progress stops
+	// changing for that long has its context cancelled and fails with
+	// ErrStageStalled, which Retries can retry. This catches a hung tool
+	// that Timeout would only catch hours later.
+	StallTimeout time.Duration
+	// Retries is how many times a failed run is retried before the item
+	// fails. When a positive budget runs out, the item is also flagged for
+	// review with every attempt's error. 0 fails on the first error, and
+	// stage.ErrFatal failures never retry.
+	Retries int
+}
+
+// retryBackoff is how long a task re-queued by its retry budget waits
+// before it is dispatched again.
+var retryBackoff = 30 * time.Second
+
+// ErrStageTimeout marks a stage failure caused by PipelineStage.Timeout.
+// The persisted item error starts with its text so timeouts are
+// recognizable in the queue.
+var ErrStageTimeout = errors.New("stage timeout")
+
+// timeoutHandler runs a stage handler under its PipelineStage.Timeout.
+type timeoutHandler struct {
+	inner   stage.Handler
+	stage   queue.Stage
+	timeout time.Duration
+}
+
+func (h timeoutHandler) Run(ctx context.Context, sess *stage.Session) error {
+	stageCtx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+	err := h.inner.Run(stageCtx, sess)
+	// Only the stage's own deadline is a timeout; a daemon shutdown or user
+	// stop cancels the parent and keeps its cancellation semantics.
+	if err != nil && ctx.Err() == nil && errors.Is(stageCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %s exceeded %s: %v", ErrStageTimeout, h.stage, h.timeout, err)
+	}
+	return err
+}
+
+// ErrStageStalled marks a stage failure caused by PipelineStage.StallTimeout.
+var ErrStageStalled = errors.New("stage stalled")
+
+// stallHandler runs a stage handler under a progress watchdog. The watchdog
+// arms on the session's first progress change: setup before a tool starts
+// reporting (a disc scan, a model load) is legitimately quiet and is left to
+// the stage timeout, while a tool that reported progress and then went
+// silent has hung.
+type stallHandler struct {
+	inner stage.Handler
+	stage queue.Stage
+	after time.Duration
+}
+
+func (h stallHandler) Run(ctx context.Context, sess *stage.Session) error {
+	stageCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Go(func() { h.watch(stageCtx, sess, cancel, done) })
+	err := h.inner.Run(stageCtx, sess)
+	close(done)
+	wg.Wait()
+
+	// A daemon shutdown or user stop cancels the parent and keeps its
+	// cancellation semantics.
+	if err != nil && ctx.Err() == nil && errors.Is(context.Cause(stageCtx), ErrStageStalled) {
+		return fmt.Errorf("%w: %s made no progress for %s: %v", ErrStageStalled, h.stage, h.after, err)
+	}
+	return err
+}
+
+// watch cancels the stage with ErrStageStalled once its progress has been
+// frozen for h.after. It checks four times per interval.
+func (h stallHandler) watch(ctx context.Context, sess *stage.Session, cancel context.CancelCauseFunc, done <-chan struct{}) {
+	ticker := time.NewTicker(h.after / 4)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		last := sess.LastProgress()
+		if last.IsZero() {
+			continue
+		}
+		if frozen := time.Since(last); frozen >= h.after {
+			sess.Logger.Warn("stage progress stalled",
+				"event_type", "stage_stalled",
+				"error_hint", "the external tool stopped reporting progress; check it is not hung",
+				"impact", "stage cancelled and failed",
+				"stage", h.stage,
+				"stalled_for", logs.FormatDuration(frozen),
+			)
+			cancel(ErrStageStalled)
+			return
+		}
+	}
 }
 
 // pipelineState holds runtime state for the pipeline.
@@ -65,6 +176,10 @@ type Manager struct {
 	// running tracks each active worker by item and task. Parallel branches
 	// may coexist, but a canceled worker from deleted task rows must drain
 	// before retry dispatches replacements that could touch the same files.
+	// Liveness is this in-process map, not a heartbeat timestamp: a running
+	// task is never reclaimed for taking too long, however slow a rip or
+	// probe is. Running rows are only reset by startup recovery, after the
+	// process that owned them is gone (the daemon lock guarantees one owner).
 	runningMu sync.Mutex
 	running   map[int64]map[int64]context.CancelFunc
 
@@ -73,6 +188,16 @@ type Manager struct {
 	// with the wait duration on grant, not on every scheduler pass.
 	blockedMu sync.Mutex
 	blocked   map[int64]time.Time
+
+	// retryAt holds the earliest dispatch time of each task re-queued by
+	// its stage's retry budget, so a failing stage backs off instead of
+	// re-running on the next scheduler pass.
+	retryMu sync.Mutex
+	retryAt map[int64]time.Time
+
+	// depMon pauses stage lanes while an external service they need is
+	// down; see ConfigureDependencies.
+	depMon dependencyMonitor
 }
 
 // New creates a workflow manager. statusTracker may be nil.
@@ -85,6 +210,7 @@ func New(store *queue.Store, notifier *notify.Notifier, statusTracker *httpapi.S
 		wake:                make(chan struct{}, 1),
 		running:             make(map[int64]map[int64]context.CancelFunc),
 		blocked:             make(map[int64]time.Time),
+		retryAt:             make(map[int64]time.Time),
 		pipeline: &pipelineState{
 			logger: logs.Default(logger),
 		},
@@ -138,9 +264,9 @@ func (m *Manager) ConfigureStages(stages []PipelineStage) {
 		p.specs[i] = spec
 	}
 
-	// Every resource is intentionally exclusive: there is one optical drive,
-	// and concurrent GPU/encode processes have exceeded available VRAM.
-	// Raise a capacity only after measuring real-disc peak memory use.
+	// Every resource starts exclusive: there is one optical drive, and
+	// concurrent GPU/encode processes have exceeded available VRAM.
+	// SetCapacity raises one only after measuring real-disc peak memory use.
 	m.budgetCap = make(map[string]int)
 	m.budgetUsed = make(map[string]int)
 	m.budgetHolders = make(map[string][]httpapi.ResourceHolder)
@@ -151,6 +277,17 @@ func (m *Manager) ConfigureStages(stages []PipelineStage) {
 	}
 }
 
+// SetCapacity sets how many claims on resource may be held at once. It
+// overrides the exclusive default ConfigureStages registers, so call it
+// after ConfigureStages; capacities below 1 mean 1.
+func (m *Manager) SetCapacity(resource string, capacity int) {
+	m.budgetMu.Lock()
+	defer m.budgetMu.Unlock()
+	if _, ok := m.budgetCap[resource]; ok {
+		m.budgetCap[resource] = max(capacity, 1)
+	}
+}
+
 // reserve attempts to claim the stage's resources for a task. It is
 // all-or-nothing; holders are recorded for the status API.
 func (m *Manager) reserve(claims map[string]int, holder httpapi.ResourceHolder) bool {
@@ -335,7 +472,18 @@ func (m *Manager) cancelStoppedWorkers() {
 
 // Run executes the scheduler loop until ctx is cancelled.
 func (m *Manager) Run(ctx context.Context) {
+	if !m.dependencyChecksEnabled() {
+		m.runScheduler(ctx)
+		return
+	}
+	// The scheduler can stop on its own after a persistence failure, so
+	// the monitor gets a context that ends with it.
+	ctx, cancel := context.WithCancel(ctx)
+	var monitor sync.WaitGroup
+	monitor.Go(func() { m.monitorDependencies(ctx) })
 	m.runScheduler(ctx)
+	cancel()
+	monitor.Wait()
 }
 
 // runScheduler dispatches every ready task whose resource claims fit the
@@ -427,6 +575,16 @@ func (m *Manager) dispatch(ctx context.Context, workers *sync.WaitGroup) {
 		}
 	}
 	m.blockedMu.Unlock()
+	now := time.Now()
+	m.retryMu.Lock()
+	for id := range m.retryAt {
+		if _, ok := readyIDs[id]; !ok {
+			delete(m.retryAt, id)
+		}
+	}
+	m.retryMu.Unlock()
+
+	m.orderReady(ready, byID)
 
 	for _, task := range ready {
 		if ctx.Err() != nil {
@@ -455,6 +613,14 @@ func (m *Manager) dispatch(ctx context.Context, workers *sync.WaitGroup) {
 			continue
 		}
 		ps := p.stages[idx]
+		// A paused lane leaves its tasks pending; recordDependencyChecks
+		// logs the pause once and wakes the scheduler on resume.
+		if m.stagePaused(ps.Stage) {
+			continue
+		}
+		if m.retryPending(task.ID, now) {
+			continue
+		}
 
 		claims := ps.Claims
 		if ps.ClaimsFunc != nil {
@@ -520,6 +686,39 @@ func (m *Manager) dispatch(ctx context.Context, workers *sync.WaitGroup) {
 	}
 }
 
+// orderReady reorders, in place, the ready tasks of each stage that has an
+// OrderFunc. A stage's tasks keep the slots they occupy in ready, so ordering
+// one lane never changes its position relative to other stages.
+func (m *Manager) orderReady(ready []*queue.Task, byID map[int64]*queue.Item) {
+	p := m.pipeline
+	for _, ps := range p.stages {
+		if ps.OrderFunc == nil {
+			continue
+		}
+		var slots []int
+		var lane []*queue.Task
+		for i, task := range ready {
+			if task.Type == ps.Stage {
+				slots = append(slots, i)
+				lane = append(lane, task)
+			}
+		}
+		if len(lane) < 2 {
+			continue
+		}
+		key := func(task *queue.Task) float64 {
+			if item, ok := byID[task.ItemID]; ok {
+				return ps.OrderFunc(item)
+			}
+			return 0
+		}
+		sort.SliceStable(lane, func(i, j int) bool { return key(lane[i]) < key(lane[j]) })
+		for i, slot := range slots {
+			ready[slot] = lane[i]
+		}
+	}
+}
+
 // noteTaskBlocked records and logs the first scheduler pass on which a ready
 // task could not reserve its resource claims. Subsequent passes stay silent
 // until the claim is granted.
@@ -582,6 +781,12 @@ func (m *Manager) runTask(ctx context.Context, task *queue.Task, item *queue.Ite
 		if refreshed, err := m.store.GetByID(item.ID); err == nil && refreshed != nil {
 			errMsg = refreshed.ErrorMessage
 		}
+	case outcomeRetry:
+		state = queue.TaskPending
+		errMsg = task.ErrorMsg
+		m.retryMu.Lock()
+		m.retryAt[task.ID] = time.Now().Add(retryBackoff)
+		m.retryMu.Unlock()
 	default:
 		state = queue.TaskPending
 	}
@@ -597,6 +802,7 @@ type itemOutcome int
 const (
 	outcomeDone itemOutcome = iota
 	outcomeFailed
+	outcomeRetry
 	outcomeCanceled
 	outcomeStopped
 	outcomePersistence
@@ -619,12 +825,24 @@ func (m *Manager) processItem(ctx context.Context, task *queue.Task, item *queue
 	)
 	m.maybeStartQueueCycle(ctx, itemLogger)
 
+	handler := ps.Handler
+	if ps.Timeout > 0 {
+		handler = timeoutHandler{inner: ps.Handler, stage: ps.Stage, timeout: ps.Timeout}
+	}
+	if ps.StallTimeout > 0 {
+		handler = stallHandler{inner: handler, stage: ps.Stage, after: ps.StallTimeout}
+	}
+	var retry func(error) bool
+	if ps.Retries > 0 {
+		retry = func(err error) bool { return !stage.IsFatal(err) && task.Attempts <= ps.Retries }
+	}
 	res, err := stage.ExecuteWorkflowStage(ctx, item, stage.WorkflowOptions{
 		Store:   m.store,
-		Handler: ps.Handler,
+		Handler: handler,
 		Logger:  p.logger,
 		Stage:   ps.Stage,
 		Task:    task,
+		Retry:   retry,
 	})
 	if res.Canceled {
 		if err != nil && !errors.Is(err, context.Canceled) {
@@ -662,6 +880,21 @@ func (m *Manager) processItem(ctx context.Context, task *queue.Task, item *queue
 			m.reportPersistenceFailure(itemLogger, persistenceErr.Err, eventType, hint, item.ID)
 			return outcomePersistence
 		}
+		task.ErrorMsg = appendAttemptError(task.ErrorMsg, task.Attempts, err)
+		if res.Retrying {
+			itemLogger.Warn("stage failed; retrying",
+				"event_type", "stage_retry",
+				"error_hint", fmt.Sprintf("attempt %d of %d failed", task.Attempts, ps.Retries+1),
+				"impact", fmt.Sprintf("item stays at %s and retries after %s", ps.Stage, retryBackoff),
+				"error", err,
+				"stage", ps.Stage,
+				"stage_duration", logs.FormatDuration(res.Duration),
+			)
+			return outcomeRetry
+		}
+		if ps.Retries > 0 && !stage.IsFatal(err) {
+			m.flagRetriesExhausted(item, task, ps, itemLogger)
+		}
 		m.recordStageFailure(ctx, item, err, ps, res.Duration)
 		return outcomeFailed
 	}
@@ -691,6 +924,46 @@ func (m *Manager) processItem(ctx context.Context, task *queue.Task, item *queue
 	return outcomeDone
 }
 
+// flagRetriesExhausted flags an item whose stage used up its retry budget
+// for review, carrying every attempt's error so the reviewer sees whether
+// the failures were the same or varied.
+func (m *Manager) flagRetriesExhausted(item *queue.Item, task *queue.Task, ps PipelineStage, logger *slog.Logger) {
+	reason := fmt.Sprintf("%s failed after %d attempts: %s", queue.HumanStage(ps.Stage), task.Attempts, task.ErrorMsg)
+	if err := m.store.FlagReview(item, reason); err != nil {
+		logger.Error("flag exhausted retries for review failed",
+			"event_type", "review_persist_failed",
+			"error_hint", "item failed but was not flagged for review",
+			"error", err,
+			"stage", ps.Stage,
+		)
+		return
+	}
+	logger.Info("stage retry budget exhausted",
+		"decision_type", logs.DecisionStageExecution,
+		"decision_result", "needs_review",
+		"decision_reason", fmt.Sprintf("%d of %d attempts failed", task.Attempts, ps.Retries+1),
+		"stage", ps.Stage,
+	)
+}
+
+// appendAttemptError adds one attempt's error to a task's error history.
+func appendAttemptError(history string, attempt int, err error) string {
+	entry := fmt.Sprintf("attempt %d: %v", attempt, err)
+	if history == "" {
+		return entry
+	}
+	return history + "; " + entry
+}
+
+// retryPending reports whether a task re-queued by its retry budget is
+// still backing off.
+func (m *Manager) retryPending(taskID int64, now time.Time) bool {
+	m.retryMu.Lock()
+	defer m.retryMu.Unlock()
+	at, ok := m.retryAt[taskID]
+	return ok && now.Before(at)
+}
+
 // finalizeItem derives and persists the item's display stage once no
 // workers remain: the earliest not-done task (in registration order) is the
 // item's stage, or completed when every task is done. With DAG templates a
@@ -789,13 +1062,24 @@ func (m *Manager) recordStageFailure(ctx context.Context, item *queue.Item, err
 	p := m.pipeline
 	itemLogger := p.logger.With("item_id", item.ID)
 
-	itemLogger.Error("stage failed",
-		"event_type", "stage_failure",
-		"error_hint", ps.Stage,
-		"error", err,
-		"stage", ps.Stage,
-		"stage_duration", logs.FormatDuration(duration),
-	)
+	if errors.Is(err, ErrStageTimeout) {
+		itemLogger.Error("stage timed out",
+			"event_type", "stage_timeout",
+			"error_hint", fmt.Sprintf("%s exceeded its %s timeout", ps.Stage, ps.Timeout),
+			"error", err,
+			"stage", ps.Stage,
+			"stage_duration", logs.FormatDuration(duration),
+			"timeout", ps.Timeout.String(),
+		)
+	} else {
+		itemLogger.Error("stage failed",
+			"event_type", "stage_failure",
+			"error_hint", ps.Stage,
+			"error", err,
+			"stage", ps.Stage,
+			"stage_duration", logs.FormatDuration(duration),
+		)
+	}
 
 	if m.statusTracker != nil {
 		m.statusTracker.RecordFailure(err.Error())
@@ -803,7 +1087,9 @@ func (m *Manager) recordStageFailure(ctx context.Context, item *queue.Item, err
 
 	title := fmt.Sprintf("Failed: %s during %s", item.DisplayTitle(), queue.HumanStage(ps.Stage))
 	msg := fmt.Sprintf("Processing stopped.\nStage: %s\nReason: %s\nItem ID: %d", queue.HumanStage(ps.Stage), err.Error(), item.ID)
-	_ = notify.SendLogged(ctx, m.notifier, itemLogger, notify.EventError, title, msg,
+	_ = notify.SendItemLogged(ctx, m.notifier, itemLogger, notify.EventError,
+		notify.Fields{Item: item.ID, Stage: string(ps.Stage), Error: err.Error()},
+		title, msg,
 		"stage", ps.Stage,
 	)
 
@@ -3,12 +3,16 @@ package workflow
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -447,6 +451,79 @@ func TestSchedulerBudgetSerializesSameClaim(t *testing.T) {
 	t.Fatal("both items did not run")
 }
 
+func TestSetCapacityAdmitsConcurrentClaimsUpToCapacity(t *testing.T) {
+	store, err := queue.Open(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("open queue: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	for _, fp := range []string{"fp1", "fp2", "fp3"} {
+		_, _ = store.NewDisc(fp, fp)
+	}
+
+	var mu sync.Mutex
+	running, maxRunning, total := 0, 0, 0
+	handler := stubHandler{run: func(context.Context, *stage.Session) error {
+		mu.Lock()
+		running++
+		maxRunning = max(maxRunning, running)
+		mu.Unlock()
+		// Hold the GPU until a second holder arrives (or the lone third
+		// item gives up waiting), so the overlap is observed, not raced.
+		for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+			mu.Lock()
+			paired := running >= 2
+			mu.Unlock()
+			if paired {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		mu.Lock()
+		running--
+		total++
+		mu.Unlock()
+		return nil
+	}}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	manager := New(store, nil, nil, logger)
+	manager.ConfigureStages([]PipelineStage{
+		{Stage: queue.StageIdentification, Handler: handler, Claims: map[string]int{"gpu": 1}},
+	})
+	manager.SetCapacity("gpu", 2)
+	if got := manager.SchedulerSnapshot()["gpu"].Capacity; got != 2 {
+		t.Fatalf("gpu capacity = %d, want 2", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		manager.Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	deadline := time.Now().Add(testWait)
+	for time.Now().Before(deadline) {
+		mu.Lock()
+		finished, peak := total, maxRunning
+		mu.Unlock()
+		if finished == 3 {
+			if peak != 2 {
+				t.Fatalf("max concurrent gpu stages = %d, want the capacity of 2", peak)
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("all three items did not run")
+}
+
 func TestSchedulerFailureMarksTaskFailedAndStopsItem(t *testing.T) {
 	store, err := queue.Open(filepath.Join(t.TempDir(), "queue.db"))
 	if err != nil {
@@ -507,6 +584,262 @@ func TestSchedulerFailureMarksTaskFailedAndStopsItem(t *testing.T) {
 
 var errTestBoom = errors.New("boom")
 
+// runRetryScenario runs the scheduler with no retry backoff until the item
+// fails, returning the failed item and its tasks.
+func runRetryScenario(t *testing.T, stages []PipelineStage) (*queue.Item, []*queue.Task) {
+	t.Helper()
+	saved := retryBackoff
+	retryBackoff = 0
+	t.Cleanup(func() { retryBackoff = saved })
+
+	store, err := queue.Open(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("open queue: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	item, _ := store.NewDisc("A", "fp1")
+
+	manager := New(store, nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	manager.ConfigureStages(stages)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		manager.Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	deadline := time.Now().Add(testWait)
+	for time.Now().Before(deadline) {
+		got, err := store.GetByID(item.ID)
+		if err != nil {
+			t.Fatalf("get item: %v", err)
+		}
+		tasks, err := store.TasksForItem(item.ID)
+		if err != nil {
+			t.Fatalf("tasks: %v", err)
+		}
+		if got.Stage == queue.StageFailed && slices.ContainsFunc(tasks, func(task *queue.Task) bool { return task.State == queue.TaskFailed }) {
+			return got, tasks
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("item did not fail")
+	return nil, nil
+}
+
+func TestStageRetryBudgetZeroFailsWhileBudgetedStageRetries(t *testing.T) {
+	var identifyRuns, ripRuns atomic.Int32
+	_, tasks := runRetryScenario(t, []PipelineStage{
+		{Stage: queue.StageIdentification, Retries: 2, Handler: stubHandler{run: func(context.Context, *stage.Session) error {
+			if identifyRuns.Add(1) < 3 {
+				return errTestBoom
+			}
+			return nil
+		}}},
+		{Stage: queue.StageRipping, Handler: stubHandler{run: func(context.Context, *stage.Session) error {
+			ripRuns.Add(1)
+			return errTestBoom
+		}}},
+	})
+
+	if tasks[0].State != queue.TaskDone || tasks[0].Attempts != 3 {
+		t.Errorf("identification task = %s after %d attempts, want done after 3", tasks[0].State, tasks[0].Attempts)
+	}
+	if tasks[1].State != queue.TaskFailed || tasks[1].Attempts != 1 || ripRuns.Load() != 1 {
+		t.Errorf("ripping task = %s after %d attempts (%d runs), want failed after 1", tasks[1].State, tasks[1].Attempts, ripRuns.Load())
+	}
+}
+
+func TestStageRetryBudgetExhaustedRoutesToReview(t *testing.T) {
+	var runs atomic.Int32
+	item, tasks := runRetryScenario(t, []PipelineStage{
+		{Stage: queue.StageIdentification, Retries: 1, Handler: stubHandler{run: func(context.Context, *stage.Session) error {
+			return fmt.Errorf("boom %d", runs.Add(1))
+		}}},
+		{Stage: queue.StageRipping, Handler: stubHandler{}},
+	})
+
+	if tasks[0].Attempts != 2 {
+		t.Errorf("attempts = %d, want 2", tasks[0].Attempts)
+	}
+	if item.NeedsReview != 1 || !strings.Contains(item.ReviewReason, "attempt 1: boom 1; attempt 2: boom 2") {
+		t.Errorf("review = %d %q, want flagged with both attempts' errors", item.NeedsReview, item.ReviewReason)
+	}
+}
+
+func TestStageRetryBudgetSkipsFatalErrors(t *testing.T) {
+	item, tasks := runRetryScenario(t, []PipelineStage{
+		{Stage: queue.StageIdentification, Retries: 3, Handler: stubHandler{run: func(context.Context, *stage.Session) error {
+			return stage.Fatal(errTestBoom)
+		}}},
+	})
+
+	if tasks[0].Attempts != 1 {
+		t.Errorf("attempts = %d, want 1 for a fatal error", tasks[0].Attempts)
+	}
+	if item.NeedsReview != 0 {
+		t.Errorf("fatal failure flagged for review: %q", item.ReviewReason)
+	}
+}
+
+func TestSchedulerCancelsStageAtTimeout(t *testing.T) {
+	store, err := queue.Open(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("open queue: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	item, _ := store.NewDisc("A", "fp1")
+
+	handlerCanceled := make(chan struct{})
+	hung := stubHandler{run: func(ctx context.Context, _ *stage.Session) error {
+		<-ctx.Done()
+		close(handlerCanceled)
+		return errors.New("makemkvcon: signal: killed")
+	}}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	manager := New(store, nil, nil, logger)
+	manager.ConfigureStages([]PipelineStage{
+		{Stage: queue.StageIdentification, Handler: hung, Claims: map[string]int{"drive": 1}, Timeout: 50 * time.Millisecond},
+		{Stage: queue.StageRipping, Handler: stubHandler{}, Claims: map[string]int{"drive": 1}},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		manager.Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	select {
+	case <-handlerCanceled:
+	case <-time.After(testWait):
+		t.Fatal("hung stage was not cancelled at its timeout")
+	}
+
+	deadline := time.Now().Add(testWait)
+	for time.Now().Before(deadline) {
+		got, err := store.GetByID(item.ID)
+		if err != nil {
+			t.Fatalf("get item: %v", err)
+		}
+		tasks, err := store.TasksForItem(item.ID)
+		if err != nil {
+			t.Fatalf("tasks: %v", err)
+		}
+		if got.Stage == queue.StageFailed && len(tasks) == 2 && tasks[0].State == queue.TaskFailed {
+			if got.FailedAtStage != queue.StageIdentification {
+				t.Fatalf("failed_at_stage = %q, want identification", got.FailedAtStage)
+			}
+			if !strings.HasPrefix(got.ErrorMessage, "stage timeout: identification exceeded 50ms") {
+				t.Fatalf("error message = %q, want stage timeout prefix", got.ErrorMessage)
+			}
+			if got.UserStopped() {
+				t.Fatal("timed-out item must stay retryable, not user-stopped")
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("timed-out item did not settle into failed state")
+}
+
+func newStallSession(t *testing.T) *stage.Session {
+	t.Helper()
+	store, err := queue.Open(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("open queue: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	item, _ := store.NewDisc("A", "fp1")
+	sess, err := stage.NewSession(context.Background(), store, item, nil)
+	if err != nil {
+		t.Fatalf("new session: %v", err)
+	}
+	sess.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	return sess
+}
+
+func TestStallWatchdogCancelsFrozenProgress(t *testing.T) {
+	sess := newStallSession(t)
+	// The handler keeps re-reporting the same progress, like a heartbeat
+	// that outlives a hung tool.
+	frozen := stubHandler{run: func(ctx context.Context, sess *stage.Session) error {
+		for {
+			_ = sess.Progress(42, "Encoding")
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("drapto: %w", ctx.Err())
+			case <-time.After(5 * time.Millisecond):
+			}
+		}
+	}}
+
+	h := stallHandler{inner: frozen, stage: queue.StageEncoding, after: 50 * time.Millisecond}
+	err := h.Run(context.Background(), sess)
+	if !errors.Is(err, ErrStageStalled) {
+		t.Fatalf("err = %v, want ErrStageStalled", err)
+	}
+	if !strings.HasPrefix(err.Error(), "stage stalled: encoding made no progress for 50ms") {
+		t.Fatalf("err = %q, want stall prefix", err)
+	}
+}
+
+func TestStallWatchdogAllowsQuietSetupAndSteadyProgress(t *testing.T) {
+	sess := newStallSession(t)
+	const after = 50 * time.Millisecond
+	slow := stubHandler{run: func(ctx context.Context, sess *stage.Session) error {
+		// Setup with no progress at all does not arm the watchdog.
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(3 * after):
+		}
+		for pct := range 20 {
+			if err := sess.Progress(float64(pct*5), "Ripping title 1"); err != nil {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(after / 5):
+			}
+		}
+		return nil
+	}}
+
+	h := stallHandler{inner: slow, stage: queue.StageRipping, after: after}
+	if err := h.Run(context.Background(), sess); err != nil {
+		t.Fatalf("slow but live stage failed: %v", err)
+	}
+}
+
+func TestStallWatchdogKeepsParentCancellation(t *testing.T) {
+	sess := newStallSession(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := stubHandler{run: func(ctx context.Context, sess *stage.Session) error {
+		_ = sess.Progress(10, "Ripping")
+		cancel()
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+
+	h := stallHandler{inner: stopped, stage: queue.StageRipping, after: time.Hour}
+	if err := h.Run(ctx, sess); !errors.Is(err, context.Canceled) || errors.Is(err, ErrStageStalled) {
+		t.Fatalf("err = %v, want plain cancellation", err)
+	}
+}
+
 func TestSchedulerCancelsWorkerOnUserStop(t *testing.T) {
 	store, err := queue.Open(filepath.Join(t.TempDir(), "queue.db"))
 	if err != nil {
@@ -846,3 +1179,146 @@ func TestClaimsFuncRoutesItemsToPerItemSlots(t *testing.T) {
 	}
 	t.Fatal("items did not complete")
 }
+
+func TestOrderFuncRunsCheapestEncodeFirst(t *testing.T) {
+	store, err := queue.Open(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("open queue: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	// A is older but costlier; shortest-first must encode B before A.
+	itemA, _ := store.NewDisc("A-long", "fpA")
+	itemB, _ := store.NewDisc("B-short", "fpB")
+	for item, cost := range map[*queue.Item]float64{itemA: 9000, itemB: 1200} {
+		item.EstimatedEncodeCost = cost
+		if err := store.UpdateWorkState(item); err != nil {
+			t.Fatalf("update work state: %v", err)
+		}
+		if err := store.MoveToStage(item, queue.StageEncoding); err != nil {
+			t.Fatalf("move to encoding: %v", err)
+		}
+	}
+
+	var mu sync.Mutex
+	var order []int64
+	handler := stubHandler{run: func(_ context.Context, sess *stage.Session) error {
+		mu.Lock()
+		order = append(order, sess.Item.ID)
+		mu.Unlock()
+		return nil
+	}}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	manager := New(store, nil, nil, logger)
+	manager.ConfigureStages([]PipelineStage{
+		{
+			Stage:     queue.StageEncoding,
+			Handler:   handler,
+			Claims:    map[string]int{"encode": 1},
+			OrderFunc: func(item *queue.Item) float64 { return item.EstimatedEncodeCost },
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		manager.Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	deadline := time.Now().Add(testWait)
+	for time.Now().Before(deadline) {
+		mu.Lock()
+		n := len(order)
+		mu.Unlock()
+		if n == 2 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(order) != 2 {
+		t.Fatalf("encodes run = %d, want 2", len(order))
+	}
+	if order[0] != itemB.ID || order[1] != itemA.ID {
+		t.Fatalf("encode order = %v, want [%d %d]", order, itemB.ID, itemA.ID)
+	}
+}
+
+func TestSchedulerNeverReclaimsLongRunningTask(t *testing.T) {
+	store, err := queue.Open(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("open queue: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	item, _ := store.NewDisc("A", "fp1")
+
+	var (
+		mu   sync.Mutex
+		runs int
+	)
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	handler := stubHandler{run: func(ctx context.Context, _ *stage.Session) error {
+		mu.Lock()
+		runs++
+		mu.Unlock()
+		started <- struct{}{}
+		select {
+		case <-release:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	manager := New(store, nil, nil, logger)
+	manager.ConfigureStages([]PipelineStage{
+		{Stage: queue.StageIdentification, Handler: handler, Claims: map[string]int{"drive": 1}},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		manager.Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(testWait):
+		t.Fatal("handler never started")
+	}
+
+	// Many scheduler passes go by while the worker is busy. None of them may
+	// treat the long-running task as abandoned and dispatch it again.
+	for range 50 {
+		manager.signalWake()
+		time.Sleep(5 * time.Millisecond)
+	}
+	tasks, err := store.TasksForItem(item.ID)
+	if err != nil {
+		t.Fatalf("tasks: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].State != queue.TaskRunning {
+		t.Fatalf("tasks = %+v, want one running task", tasks)
+	}
+	mu.Lock()
+	if runs != 1 {
+		t.Fatalf("handler ran %d times while busy, want 1", runs)
+	}
+	mu.Unlock()
+	close(release)
+}